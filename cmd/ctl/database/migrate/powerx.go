@@ -63,15 +63,25 @@ func (m *PowerMigrator) AutoMigrate() {
 		&customerdomain.Customer{}, &membership.Membership{},
 	)
 	_ = m.db.AutoMigrate(&wechat.WechatOACustomer{}, &wechat.WechatMPCustomer{}, &wechat.WeWorkExternalContact{})
+	_ = m.db.AutoMigrate(&wechat.WechatOAAutoReplyRule{}, &wechat.WechatOAMenu{}, &wechat.WechatOAMenuVersion{})
+	_ = m.db.AutoMigrate(&wechat.WechatOAQrcode{}, &wechat.WechatOAQrcodeEvent{})
+	_ = m.db.AutoMigrate(&wechat.WechatNotificationTemplate{}, &wechat.WechatMPSubscription{}, &wechat.WechatNotificationLog{})
 	_ = m.db.AutoMigrate(
 		&product.PivotProductToProductCategory{},
 	)
 	// product
 	_ = m.db.AutoMigrate(&product.Product{}, &product.ProductCategory{})
-	_ = m.db.AutoMigrate(&product.ProductSpecific{}, &product.SpecificOption{}, &product.ProductStatistics{})
+	_ = m.db.AutoMigrate(&product.ProductSpecific{}, &product.SpecificOption{}, &product.ProductStatistics{}, &product.ProductStatisticsSnapshot{})
 	_ = m.db.AutoMigrate(&product.SKU{}, &product.PivotSkuToSpecificOption{})
 	_ = m.db.AutoMigrate(&product.PriceBook{}, &product.PriceBookEntry{}, &product.PriceConfig{})
+	_ = m.db.AutoMigrate(&product.ProductSearchDocument{})
+	_ = m.db.AutoMigrate(&product.ProductRevision{}, &product.ProductApprovalRecord{})
+	_ = m.db.AutoMigrate(&product.ProductImportJob{})
+	_ = m.db.AutoMigrate(&product.ProductBundleItem{})
+	_ = m.db.AutoMigrate(&product.ProductReview{}, &product.ProductReviewHelpful{})
 	_ = m.db.AutoMigrate(&market.Store{}, &product.Artisan{}, &product.PivotStoreToArtisan{})
+	_ = m.db.AutoMigrate(&product.StoreProduct{}, &product.StoreSKU{})
+	_ = m.db.AutoMigrate(&product.ArtisanSchedule{}, &product.ArtisanLeave{}, &product.Booking{})
 
 	// market
 	_ = m.db.AutoMigrate(&market.Media{})
@@ -84,10 +94,15 @@ func (m *PowerMigrator) AutoMigrate() {
 	_ = m.db.AutoMigrate(&trade.ShippingAddress{}, &trade.DeliveryAddress{}, &trade.BillingAddress{})
 	_ = m.db.AutoMigrate(&trade.Warehouse{}, &trade.Inventory{}, &trade.Logistics{})
 	_ = m.db.AutoMigrate(&trade.Cart{}, &trade.CartItem{}, &trade.Order{}, &trade.OrderItem{})
+	_ = m.db.AutoMigrate(&trade.OrderItemComponent{})
 	_ = m.db.AutoMigrate(&trade.OrderStatusTransition{}, &trade.PivotOrderToInventoryLog{})
 	_ = m.db.AutoMigrate(&trade.Payment{}, &trade.PaymentItem{})
+	_ = m.db.AutoMigrate(&trade.PaymentNotification{})
+	_ = m.db.AutoMigrate(&trade.PaymentReconciliation{}, &trade.PaymentReconciliationItem{})
+	_ = m.db.AutoMigrate(&trade.Invoice{})
+	_ = m.db.AutoMigrate(&trade.Subscription{})
 	_ = m.db.AutoMigrate(&trade.RefundOrder{}, &trade.RefundOrderItem{})
-	_ = m.db.AutoMigrate(&trade.TokenBalance{}, &trade.TokenExchangeRatio{}, &trade.TokenExchangeRecord{})
+	_ = m.db.AutoMigrate(&trade.TokenBalance{}, &trade.TokenExchangeRatio{}, &trade.TokenExchangeRecord{}, &trade.TokenLedger{})
 
 	// custom
 	migrate.AutoMigrateCustom(m.db)
@@ -96,6 +111,9 @@ func (m *PowerMigrator) AutoMigrate() {
 	_ = m.db.AutoMigrate(&organization.WeWorkEmployee{}, &organization.WeWorkDepartment{})
 	// wechat customer
 	_ = m.db.AutoMigrate(&customer.WeWorkExternalContacts{}, &customer.WeWorkExternalContactFollow{})
+	_ = m.db.AutoMigrate(&customer.WeWorkCustomerWelcomeRule{}, &customer.WeWorkCustomerWelcomeLog{})
+	_ = m.db.AutoMigrate(&customer.WeWorkCustomerTransfer{})
+	_ = m.db.AutoMigrate(&customer.WeWorkCustomerGroup{}, &customer.WeWorkCustomerGroupMember{}, &customer.WeWorkCustomerGroupMemberLog{}, &customer.WeWorkCustomerGroupTag{}, &customer.WeWorkCustomerGroupStatistic{})
 	// wechat resource
 	_ = m.db.AutoMigrate(&resource.WeWorkResource{})
 	// wechat app
@@ -104,4 +122,6 @@ func (m *PowerMigrator) AutoMigrate() {
 	_ = m.db.AutoMigrate(&tag.WeWorkTag{}, &tag.WeWorkTagGroup{})
 	// qrcode
 	_ = m.db.AutoMigrate(&scene.SceneQrcode{})
+	_ = m.db.AutoMigrate(&scene.SceneQrcodeScanLog{}, &scene.SceneQrcodeDailyStatistics{}, &scene.SceneQrcodeCandidate{})
+	_ = m.db.AutoMigrate(&organization.DTalkDepartment{}, &organization.DTalkEmployee{})
 }
@@ -43,6 +43,13 @@ func defaultPaymentTypeDataDictionary() *model.DataDictionaryType {
 				Value: trade.PaymentTypeCreditCard,
 				Sort:  0,
 			},
+			&model.DataDictionaryItem{
+				Key:   trade.PaymentTypeToken,
+				Type:  trade.TypePaymentType,
+				Name:  "代币",
+				Value: trade.PaymentTypeToken,
+				Sort:  0,
+			},
 		},
 		Type:        trade.TypePaymentType,
 		Name:        "支付单类型",
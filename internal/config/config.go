@@ -34,9 +34,28 @@ type WeWork struct {
 	Debug     bool
 }
 
+type DingTalk struct {
+	AppKey      string
+	AppSecret   string
+	AgentId     int64
+	RobotSecret string `json:",optional"` // 机器人加签密钥
+	BaseUrl     string `json:",default=https://oapi.dingtalk.com"`
+	HttpDebug   bool   `json:",optional"`
+}
+
+const (
+	SCRMProviderWeWork   = "wework"
+	SCRMProviderDingTalk = "dingtalk"
+)
+
+type SCRM struct {
+	Provider string `json:",default=wework,options=wework|dingtalk"`
+}
+
 type WechatOA struct {
 	AppId  string
 	Secret string
+	Token  string `json:",optional"`
 	AESKey string
 	OAuth  struct {
 		Callback string
@@ -57,10 +76,35 @@ type WechatPay struct {
 	SerialNo         string
 	WechatPaySerial  string
 	NotifyUrl        string
+	PlatformCertPath string `json:",optional"` // 微信支付平台证书/公钥, 配置后校验回调签名
 	HttpDebug        bool
 	Debug            bool
 }
 
+type Alipay struct {
+	AppId           string
+	PrivateKey      string // 应用私钥
+	AlipayPublicKey string // 支付宝公钥
+	NotifyUrl       string
+	ReturnUrl       string `json:",optional"`
+	GatewayUrl      string `json:",default=https://openapi.alipay.com/gateway.do"`
+	HttpDebug       bool   `json:",optional"`
+}
+
+// 线下转账收款信息, 由管理员确认到账
+type OfflinePay struct {
+	BankName      string `json:",optional"`
+	AccountName   string `json:",optional"`
+	AccountNumber string `json:",optional"`
+	Remark        string `json:",optional"`
+}
+
+type Payment struct {
+	Alipay  Alipay     `json:",optional"`
+	Offline OfflinePay `json:",optional"`
+	Fake    bool       `json:",optional"` // 自动化测试使用内存支付渠道, 不访问真实渠道
+}
+
 type WechatMP struct {
 	AppId  string
 	Secret string
@@ -115,6 +159,9 @@ type Config struct {
 	WechatOA      WechatOA
 	WechatMP      WechatMP
 	WechatPay     WechatPay
+	Payment       Payment `json:",optional"`
 	WeWork        WeWork
+	DingTalk      DingTalk `json:",optional"`
+	SCRM          SCRM     `json:",optional"`
 	MediaResource MediaResource
 }
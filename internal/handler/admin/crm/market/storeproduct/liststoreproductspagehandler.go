@@ -0,0 +1,28 @@
+package storeproduct
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/crm/market/storeproduct"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func ListStoreProductsPageHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ListStoreProductsPageRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := storeproduct.NewListStoreProductsPageLogic(r.Context(), svcCtx)
+		resp, err := l.ListStoreProductsPage(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
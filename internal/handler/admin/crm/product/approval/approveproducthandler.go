@@ -0,0 +1,28 @@
+package approval
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/crm/product/approval"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func ApproveProductHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ApproveProductRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := approval.NewApproveProductLogic(r.Context(), svcCtx)
+		resp, err := l.ApproveProduct(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package approval
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/crm/product/approval"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func GetProductPendingRevisionHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.GetProductPendingRevisionRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := approval.NewGetProductPendingRevisionLogic(r.Context(), svcCtx)
+		resp, err := l.GetProductPendingRevision(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package booking
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/crm/product/booking"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func CreateArtisanLeaveHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.CreateArtisanLeaveRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := booking.NewCreateArtisanLeaveLogic(r.Context(), svcCtx)
+		resp, err := l.CreateArtisanLeave(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package booking
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/crm/product/booking"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func DeleteArtisanLeaveHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.DeleteArtisanLeaveRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := booking.NewDeleteArtisanLeaveLogic(r.Context(), svcCtx)
+		resp, err := l.DeleteArtisanLeave(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
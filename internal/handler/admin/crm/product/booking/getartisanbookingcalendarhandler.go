@@ -0,0 +1,28 @@
+package booking
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/crm/product/booking"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func GetArtisanBookingCalendarHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.GetArtisanBookingCalendarRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := booking.NewGetArtisanBookingCalendarLogic(r.Context(), svcCtx)
+		resp, err := l.GetArtisanBookingCalendar(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
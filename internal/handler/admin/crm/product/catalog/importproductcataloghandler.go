@@ -0,0 +1,21 @@
+package catalog
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/crm/product/catalog"
+	"PowerX/internal/svc"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func ImportProductCatalogHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := catalog.NewImportProductCatalogLogic(r.Context(), svcCtx)
+		resp, err := l.ImportProductCatalog(r)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package productbundle
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/crm/product/productbundle"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func ConfigProductBundleHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ConfigProductBundleRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := productbundle.NewConfigProductBundleLogic(r.Context(), svcCtx)
+		resp, err := l.ConfigProductBundle(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package productreview
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/crm/product/productreview"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func ListProductReviewsPageHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ListProductReviewsPageRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := productreview.NewListProductReviewsPageLogic(r.Context(), svcCtx)
+		resp, err := l.ListProductReviewsPage(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package productspecific
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/crm/product/productspecific"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func PreviewConfigProductSpecificHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ConfigProductSpecificRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := productspecific.NewPreviewConfigProductSpecificLogic(r.Context(), svcCtx)
+		resp, err := l.PreviewConfigProductSpecific(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package productstatistics
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/crm/product/productstatistics"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func ListProductStatisticsSnapshotsHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ListProductStatisticsSnapshotsRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := productstatistics.NewListProductStatisticsSnapshotsLogic(r.Context(), svcCtx)
+		resp, err := l.ListProductStatisticsSnapshots(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
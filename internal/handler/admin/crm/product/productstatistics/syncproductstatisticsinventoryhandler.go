@@ -0,0 +1,28 @@
+package productstatistics
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/crm/product/productstatistics"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func SyncProductStatisticsInventoryHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.SyncProductStatisticsInventoryRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := productstatistics.NewSyncProductStatisticsInventoryLogic(r.Context(), svcCtx)
+		resp, err := l.SyncProductStatisticsInventory(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
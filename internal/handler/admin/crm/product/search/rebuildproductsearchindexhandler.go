@@ -0,0 +1,28 @@
+package search
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/crm/product/search"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func RebuildProductSearchIndexHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.RebuildProductSearchIndexRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := search.NewRebuildProductSearchIndexLogic(r.Context(), svcCtx)
+		resp, err := l.RebuildProductSearchIndex(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package payment
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/crm/trade/payment"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func ListPaymentNotificationsPageHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ListPaymentNotificationsPageRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := payment.NewListPaymentNotificationsPageLogic(r.Context(), svcCtx)
+		resp, err := l.ListPaymentNotificationsPage(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package reconciliation
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/crm/trade/reconciliation"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func CreatePaymentReconciliationHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.CreatePaymentReconciliationRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := reconciliation.NewCreatePaymentReconciliationLogic(r.Context(), svcCtx)
+		resp, err := l.CreatePaymentReconciliation(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
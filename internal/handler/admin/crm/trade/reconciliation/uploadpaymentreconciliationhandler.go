@@ -0,0 +1,21 @@
+package reconciliation
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/crm/trade/reconciliation"
+	"PowerX/internal/svc"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func UploadPaymentReconciliationHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := reconciliation.NewUploadPaymentReconciliationLogic(r.Context(), svcCtx)
+		resp, err := l.UploadPaymentReconciliation(r)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package storeorder
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/crm/trade/storeorder"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func DeliverStoreOrderHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.DeliverStoreOrderRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := storeorder.NewDeliverStoreOrderLogic(r.Context(), svcCtx)
+		resp, err := l.DeliverStoreOrder(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package subscription
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/crm/trade/subscription"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func CancelSubscriptionHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.CancelSubscriptionRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := subscription.NewCancelSubscriptionLogic(r.Context(), svcCtx)
+		resp, err := l.CancelSubscription(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
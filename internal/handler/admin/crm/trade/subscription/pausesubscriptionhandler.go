@@ -0,0 +1,28 @@
+package subscription
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/crm/trade/subscription"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func PauseSubscriptionHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.PauseSubscriptionRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := subscription.NewPauseSubscriptionLogic(r.Context(), svcCtx)
+		resp, err := l.PauseSubscription(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
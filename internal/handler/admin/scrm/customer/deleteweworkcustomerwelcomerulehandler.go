@@ -0,0 +1,28 @@
+package customer
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/scrm/customer"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func DeleteWeWorkCustomerWelcomeRuleHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ActionWeWorkCustomerWelcomeRuleRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := customer.NewDeleteWeWorkCustomerWelcomeRuleLogic(r.Context(), svcCtx)
+		resp, err := l.DeleteWeWorkCustomerWelcomeRule(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package customer
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/scrm/customer"
+	"PowerX/internal/svc"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func SyncWeWorkCustomerGroupHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := customer.NewSyncWeWorkCustomerGroupLogic(r.Context(), svcCtx)
+		resp, err := l.SyncWeWorkCustomerGroup()
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package qrcode
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/scrm/qrcode"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func AnalyticsWeWorkQrcodeHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.SceneQrcodeAnalyticsRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := qrcode.NewAnalyticsWeWorkQrcodeLogic(r.Context(), svcCtx)
+		resp, err := l.AnalyticsWeWorkQrcode(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package qrcode
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/scrm/qrcode"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func ListWeWorkQrcodeCandidateHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.SceneQrcodeCandidateRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := qrcode.NewListWeWorkQrcodeCandidateLogic(r.Context(), svcCtx)
+		resp, err := l.ListWeWorkQrcodeCandidate(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
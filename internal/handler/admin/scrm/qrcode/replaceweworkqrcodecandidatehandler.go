@@ -0,0 +1,28 @@
+package qrcode
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/scrm/qrcode"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func ReplaceWeWorkQrcodeCandidateHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ReplaceSceneQrcodeCandidateRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := qrcode.NewReplaceWeWorkQrcodeCandidateLogic(r.Context(), svcCtx)
+		resp, err := l.ReplaceWeWorkQrcodeCandidate(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package notification
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/wechat/notification"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func ListNotificationTemplatesPageHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ListNotificationTemplatesPageRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := notification.NewListNotificationTemplatesPageLogic(r.Context(), svcCtx)
+		resp, err := l.ListNotificationTemplatesPage(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
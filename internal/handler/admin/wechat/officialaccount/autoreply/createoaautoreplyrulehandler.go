@@ -0,0 +1,28 @@
+package autoreply
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/wechat/officialaccount/autoreply"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func CreateOAAutoReplyRuleHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.CreateOAAutoReplyRuleRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := autoreply.NewCreateOAAutoReplyRuleLogic(r.Context(), svcCtx)
+		resp, err := l.CreateOAAutoReplyRule(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
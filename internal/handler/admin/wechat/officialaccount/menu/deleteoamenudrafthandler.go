@@ -0,0 +1,28 @@
+package menu
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/admin/wechat/officialaccount/menu"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func DeleteOAMenuDraftHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.DeleteOAMenuDraftRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := menu.NewDeleteOAMenuDraftLogic(r.Context(), svcCtx)
+		resp, err := l.DeleteOAMenuDraft(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package productreview
+
+import (
+	"net/http"
+
+	"PowerX/internal/logic/mp/crm/product/productreview"
+	"PowerX/internal/svc"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func UploadProductReviewImageHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := productreview.NewUploadProductReviewImageLogic(r.Context(), svcCtx)
+		resp, err := l.UploadProductReviewImage(r)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -13,19 +13,30 @@ import (
 	admincrmmarketmedia "PowerX/internal/handler/admin/crm/market/media"
 	admincrmmarketmgm "PowerX/internal/handler/admin/crm/market/mgm"
 	admincrmmarketstore "PowerX/internal/handler/admin/crm/market/store"
+	admincrmmarketstoreproduct "PowerX/internal/handler/admin/crm/market/storeproduct"
 	admincrmproduct "PowerX/internal/handler/admin/crm/product"
+	admincrmproductapproval "PowerX/internal/handler/admin/crm/product/approval"
 	admincrmproductartisan "PowerX/internal/handler/admin/crm/product/artisan"
+	admincrmproductbooking "PowerX/internal/handler/admin/crm/product/booking"
+	admincrmproductcatalog "PowerX/internal/handler/admin/crm/product/catalog"
 	admincrmproductcategory "PowerX/internal/handler/admin/crm/product/category"
 	admincrmproductpricebook "PowerX/internal/handler/admin/crm/product/pricebook"
 	admincrmproductpricebookentry "PowerX/internal/handler/admin/crm/product/pricebookentry"
+	admincrmproductproductbundle "PowerX/internal/handler/admin/crm/product/productbundle"
+	admincrmproductproductreview "PowerX/internal/handler/admin/crm/product/productreview"
 	admincrmproductproductspecific "PowerX/internal/handler/admin/crm/product/productspecific"
 	admincrmproductproductstatistics "PowerX/internal/handler/admin/crm/product/productstatistics"
+	admincrmproductsearch "PowerX/internal/handler/admin/crm/product/search"
 	admincrmproductsku "PowerX/internal/handler/admin/crm/product/sku"
 	admincrmtradeaddressbilling "PowerX/internal/handler/admin/crm/trade/address/billing"
 	admincrmtradeaddressdelivery "PowerX/internal/handler/admin/crm/trade/address/delivery"
 	admincrmtradeaddressshipping "PowerX/internal/handler/admin/crm/trade/address/shipping"
+	admincrmtradeinvoice "PowerX/internal/handler/admin/crm/trade/invoice"
 	admincrmtradeorder "PowerX/internal/handler/admin/crm/trade/order"
 	admincrmtradepayment "PowerX/internal/handler/admin/crm/trade/payment"
+	admincrmtradereconciliation "PowerX/internal/handler/admin/crm/trade/reconciliation"
+	admincrmtradestoreorder "PowerX/internal/handler/admin/crm/trade/storeorder"
+	admincrmtradesubscription "PowerX/internal/handler/admin/crm/trade/subscription"
 	admincrmtradetoken "PowerX/internal/handler/admin/crm/trade/token"
 	admincrmtradewarehouse "PowerX/internal/handler/admin/crm/trade/warehouse"
 	admindepartment "PowerX/internal/handler/admin/department"
@@ -45,21 +56,30 @@ import (
 	adminscrmtag "PowerX/internal/handler/admin/scrm/tag"
 	admintag "PowerX/internal/handler/admin/tag"
 	adminuserinfo "PowerX/internal/handler/admin/userinfo"
+	adminwechatnotification "PowerX/internal/handler/admin/wechat/notification"
+	adminwechatofficialaccountautoreply "PowerX/internal/handler/admin/wechat/officialaccount/autoreply"
 	adminwechatofficialaccountmedia "PowerX/internal/handler/admin/wechat/officialaccount/media"
 	adminwechatofficialaccountmenu "PowerX/internal/handler/admin/wechat/officialaccount/menu"
+	adminwechatofficialaccountqrcode "PowerX/internal/handler/admin/wechat/officialaccount/qrcode"
 	mpcrmcustomerauth "PowerX/internal/handler/mp/crm/customer/auth"
 	mpcrmmarketmedia "PowerX/internal/handler/mp/crm/market/media"
 	mpcrmmarketstore "PowerX/internal/handler/mp/crm/market/store"
 	mpcrmproduct "PowerX/internal/handler/mp/crm/product"
 	mpcrmproductartisan "PowerX/internal/handler/mp/crm/product/artisan"
+	mpcrmproductbooking "PowerX/internal/handler/mp/crm/product/booking"
+	mpcrmproductproductreview "PowerX/internal/handler/mp/crm/product/productreview"
 	mpcrmproductproductstatistics "PowerX/internal/handler/mp/crm/product/productstatistics"
+	mpcrmproductsearch "PowerX/internal/handler/mp/crm/product/search"
 	mpcrmtradeaddressbilling "PowerX/internal/handler/mp/crm/trade/address/billing"
 	mpcrmtradeaddressdelivery "PowerX/internal/handler/mp/crm/trade/address/delivery"
 	mpcrmtradeaddressshipping "PowerX/internal/handler/mp/crm/trade/address/shipping"
 	mpcrmtradecart "PowerX/internal/handler/mp/crm/trade/cart"
+	mpcrmtradeinvoice "PowerX/internal/handler/mp/crm/trade/invoice"
 	mpcrmtradeorder "PowerX/internal/handler/mp/crm/trade/order"
 	mpcrmtradepayment "PowerX/internal/handler/mp/crm/trade/payment"
+	mpcrmtradesubscription "PowerX/internal/handler/mp/crm/trade/subscription"
 	mpdictionary "PowerX/internal/handler/mp/dictionary"
+	mpwechatnotification "PowerX/internal/handler/mp/wechat/notification"
 	plugin "PowerX/internal/handler/plugin"
 	systemhealth "PowerX/internal/handler/system/health"
 	webcustomerauth "PowerX/internal/handler/web/customer/auth"
@@ -664,6 +684,35 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 		rest.WithPrefix("/api/v1/admin/market"),
 	)
 
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/stores/:id/products/page-list",
+					Handler: admincrmmarketstoreproduct.ListStoreProductsPageHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/stores/:id/products",
+					Handler: admincrmmarketstoreproduct.AssignStoreProductsHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodDelete,
+					Path:    "/stores/:id/products/:productId",
+					Handler: admincrmmarketstoreproduct.RemoveStoreProductHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/stores/:id/skus",
+					Handler: admincrmmarketstoreproduct.UpsertStoreSKUsHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/admin/market"),
+	)
+
 	server.AddRoutes(
 		rest.WithMiddlewares(
 			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
@@ -907,6 +956,11 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 					Path:    "/product-specifics/config",
 					Handler: admincrmproductproductspecific.ConfigProductSpecificHandler(serverCtx),
 				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/product-specifics/config/preview",
+					Handler: admincrmproductproductspecific.PreviewConfigProductSpecificHandler(serverCtx),
+				},
 				{
 					Method:  http.MethodPut,
 					Path:    "/product-specifics/:id",
@@ -927,6 +981,25 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 		rest.WithPrefix("/api/v1/admin/product"),
 	)
 
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/products/:id/bundle-items",
+					Handler: admincrmproductproductbundle.GetProductBundleHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/products/:id/bundle-items",
+					Handler: admincrmproductproductbundle.ConfigProductBundleHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/admin/product"),
+	)
+
 	server.AddRoutes(
 		rest.WithMiddlewares(
 			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
@@ -1044,6 +1117,181 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 		rest.WithPrefix("/api/v1/admin/product"),
 	)
 
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
+			[]rest.Route{
+				{
+					Method:  http.MethodPost,
+					Path:    "/product-search/rebuild",
+					Handler: admincrmproductsearch.RebuildProductSearchIndexHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/admin/product"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
+			[]rest.Route{
+				{
+					Method:  http.MethodPost,
+					Path:    "/products/:id/approval/submit",
+					Handler: admincrmproductapproval.SubmitProductApprovalHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/products/:id/approval/approve",
+					Handler: admincrmproductapproval.ApproveProductHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/products/:id/approval/reject",
+					Handler: admincrmproductapproval.RejectProductHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/products/:id/approval/revision",
+					Handler: admincrmproductapproval.GetProductPendingRevisionHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/products/:id/approval/records",
+					Handler: admincrmproductapproval.ListProductApprovalRecordsHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/admin/product"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
+			[]rest.Route{
+				{
+					Method:  http.MethodPost,
+					Path:    "/products/catalog/import",
+					Handler: admincrmproductcatalog.ImportProductCatalogHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/products/catalog/import-jobs/:id",
+					Handler: admincrmproductcatalog.GetProductImportJobHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/products/catalog/export",
+					Handler: admincrmproductcatalog.ExportProductCatalogHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/products/catalog/template",
+					Handler: admincrmproductcatalog.DownloadProductCatalogTemplateHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/admin/product"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/product-reviews/page-list",
+					Handler: admincrmproductproductreview.ListProductReviewsPageHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/product-reviews/:id",
+					Handler: admincrmproductproductreview.GetProductReviewHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/product-reviews/:id/approve",
+					Handler: admincrmproductproductreview.ApproveProductReviewHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/product-reviews/:id/hide",
+					Handler: admincrmproductproductreview.HideProductReviewHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/product-reviews/:id/reply",
+					Handler: admincrmproductproductreview.ReplyProductReviewHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/admin/product"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/stores/:id/artisans/:artisanId/schedules",
+					Handler: admincrmproductbooking.ListArtisanSchedulesHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/stores/:id/artisans/:artisanId/schedules",
+					Handler: admincrmproductbooking.ReplaceArtisanSchedulesHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/artisans/:id/leaves",
+					Handler: admincrmproductbooking.ListArtisanLeavesHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/artisans/:id/leaves",
+					Handler: admincrmproductbooking.CreateArtisanLeaveHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodDelete,
+					Path:    "/artisan-leaves/:id",
+					Handler: admincrmproductbooking.DeleteArtisanLeaveHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/stores/:id/calendar",
+					Handler: admincrmproductbooking.GetStoreBookingCalendarHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/artisans/:id/calendar",
+					Handler: admincrmproductbooking.GetArtisanBookingCalendarHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/bookings/page-list",
+					Handler: admincrmproductbooking.ListBookingsPageHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/bookings/:id/cancel",
+					Handler: admincrmproductbooking.CancelBookingHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/bookings/:id/reschedule",
+					Handler: admincrmproductbooking.RescheduleBookingHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/bookings/:id/complete",
+					Handler: admincrmproductbooking.CompleteBookingHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/admin/product"),
+	)
+
 	server.AddRoutes(
 		rest.WithMiddlewares(
 			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
@@ -1239,6 +1487,103 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 		rest.WithPrefix("/api/v1/admin/trade"),
 	)
 
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/payment-reconciliations/page-list",
+					Handler: admincrmtradereconciliation.ListPaymentReconciliationsPageHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/payment-reconciliations/:id",
+					Handler: admincrmtradereconciliation.GetPaymentReconciliationHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/payment-reconciliations",
+					Handler: admincrmtradereconciliation.CreatePaymentReconciliationHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/payment-reconciliations/upload",
+					Handler: admincrmtradereconciliation.UploadPaymentReconciliationHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/admin/trade"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/invoices/page-list",
+					Handler: admincrmtradeinvoice.ListInvoicesPageHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/invoices/export",
+					Handler: admincrmtradeinvoice.ExportInvoicesHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/invoices/:id",
+					Handler: admincrmtradeinvoice.GetInvoiceHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/invoices/:id/issue",
+					Handler: admincrmtradeinvoice.IssueInvoiceHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/invoices/:id/reject",
+					Handler: admincrmtradeinvoice.RejectInvoiceHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/admin/trade"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/subscriptions/page-list",
+					Handler: admincrmtradesubscription.ListSubscriptionsPageHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/subscriptions/:id",
+					Handler: admincrmtradesubscription.GetSubscriptionHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/subscriptions/:id/pause",
+					Handler: admincrmtradesubscription.PauseSubscriptionHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/subscriptions/:id/resume",
+					Handler: admincrmtradesubscription.ResumeSubscriptionHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/subscriptions/:id/cancel",
+					Handler: admincrmtradesubscription.CancelSubscriptionHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/admin/trade"),
+	)
+
 	server.AddRoutes(
 		rest.WithMiddlewares(
 			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
@@ -1311,6 +1656,35 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 		rest.WithPrefix("/api/v1/admin/scrm/organization/wechat"),
 	)
 
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/employee/handover/:userId",
+					Handler: adminscrmorganization.ListWeWorkEmployeeHandoverHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/employee/handover/:userId",
+					Handler: adminscrmorganization.TransferWeWorkEmployeeHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/employee/transfer/page",
+					Handler: adminscrmorganization.ListWeWorkCustomerTransferPageHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/employee/transfer/sync",
+					Handler: adminscrmorganization.SyncWeWorkCustomerTransferHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/admin/scrm/organization/wechat"),
+	)
+
 	server.AddRoutes(
 		rest.WithMiddlewares(
 			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
@@ -1401,10 +1775,89 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 					Path:    "/group/message/template",
 					Handler: adminscrmcustomer.SendWeWorkCustomerGroupMessageHandler(serverCtx),
 				},
-			}...,
-		),
-		rest.WithPrefix("/api/v1/admin/scrm/customer/wechat"),
-	)
+				{
+					Method:  http.MethodGet,
+					Path:    "/group/sync",
+					Handler: adminscrmcustomer.SyncWeWorkCustomerGroupHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/group/page",
+					Handler: adminscrmcustomer.ListWeWorkCustomerGroupPageHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/group/member/:chatId",
+					Handler: adminscrmcustomer.ListWeWorkCustomerGroupMemberHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/group/member/log/page",
+					Handler: adminscrmcustomer.ListWeWorkCustomerGroupMemberLogPageHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/group/tag/:chatId",
+					Handler: adminscrmcustomer.ActionWeWorkCustomerGroupTagHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/group/statistic/sync",
+					Handler: adminscrmcustomer.SyncWeWorkCustomerGroupStatisticHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/group/statistic",
+					Handler: adminscrmcustomer.ListWeWorkCustomerGroupStatisticHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/admin/scrm/customer/wechat"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
+			[]rest.Route{
+				{
+					Method:  http.MethodPost,
+					Path:    "/welcome/rule/page",
+					Handler: adminscrmcustomer.ListWeWorkCustomerWelcomeRulePageHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/welcome/rule",
+					Handler: adminscrmcustomer.CreateWeWorkCustomerWelcomeRuleHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPatch,
+					Path:    "/welcome/rule/:id",
+					Handler: adminscrmcustomer.UpdateWeWorkCustomerWelcomeRuleHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPatch,
+					Path:    "/welcome/rule/enable/:id",
+					Handler: adminscrmcustomer.EnableWeWorkCustomerWelcomeRuleHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPatch,
+					Path:    "/welcome/rule/disable/:id",
+					Handler: adminscrmcustomer.DisableWeWorkCustomerWelcomeRuleHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodDelete,
+					Path:    "/welcome/rule/:id",
+					Handler: adminscrmcustomer.DeleteWeWorkCustomerWelcomeRuleHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/welcome/log/page",
+					Handler: adminscrmcustomer.ListWeWorkCustomerWelcomeLogPageHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/admin/scrm/customer/wechat"),
+	)
 
 	server.AddRoutes(
 		rest.WithMiddlewares(
@@ -1415,6 +1868,11 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 					Path:    "/message/articles",
 					Handler: adminscrmbot.BotWeWorkArticlesHandler(serverCtx),
 				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/message/notice",
+					Handler: adminscrmbot.BotWorkNoticeHandler(serverCtx),
+				},
 			}...,
 		),
 		rest.WithPrefix("/api/v1/admin/scrm/bot/wechat"),
@@ -1478,6 +1936,21 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 					Path:    "/qrcode/:qid",
 					Handler: adminscrmqrcode.UpdateActiveQrcodeLinkHandler(serverCtx),
 				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/group/analytics/:qid",
+					Handler: adminscrmqrcode.AnalyticsWeWorkQrcodeHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/group/candidate/:qid",
+					Handler: adminscrmqrcode.ListWeWorkQrcodeCandidateHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/group/candidate/:qid",
+					Handler: adminscrmqrcode.ReplaceWeWorkQrcodeCandidateHandler(serverCtx),
+				},
 			}...,
 		),
 		rest.WithPrefix("/api/v1/admin/scrm/qrcode/wechat"),
@@ -1566,6 +2039,41 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 					Path:    "/menus",
 					Handler: adminwechatofficialaccountmenu.DeleteMenuHandler(serverCtx),
 				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/menus/drafts",
+					Handler: adminwechatofficialaccountmenu.ListOAMenuDraftsHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/menus/drafts/:id",
+					Handler: adminwechatofficialaccountmenu.GetOAMenuDraftHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/menus/drafts/:id",
+					Handler: adminwechatofficialaccountmenu.UpdateOAMenuDraftHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/menus/drafts/:id/publish",
+					Handler: adminwechatofficialaccountmenu.PublishOAMenuDraftHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/menus/drafts/:id/rollback",
+					Handler: adminwechatofficialaccountmenu.RollbackOAMenuDraftHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/menus/drafts/:id/diff",
+					Handler: adminwechatofficialaccountmenu.DiffOAMenuDraftHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodDelete,
+					Path:    "/menus/drafts/:id",
+					Handler: adminwechatofficialaccountmenu.DeleteOAMenuDraftHandler(serverCtx),
+				},
 			}...,
 		),
 		rest.WithPrefix("/api/v1/admin/wechat/official-account"),
@@ -1615,6 +2123,103 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 		rest.WithPrefix("/api/v1/admin/wechat/official-account"),
 	)
 
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/auto-replies/page-list",
+					Handler: adminwechatofficialaccountautoreply.ListOAAutoReplyRulesPageHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/auto-replies",
+					Handler: adminwechatofficialaccountautoreply.CreateOAAutoReplyRuleHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/auto-replies/:id",
+					Handler: adminwechatofficialaccountautoreply.UpdateOAAutoReplyRuleHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodDelete,
+					Path:    "/auto-replies/:id",
+					Handler: adminwechatofficialaccountautoreply.DeleteOAAutoReplyRuleHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/admin/wechat/official-account"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/qrcodes/page-list",
+					Handler: adminwechatofficialaccountqrcode.ListOAQrcodesPageHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/qrcodes",
+					Handler: adminwechatofficialaccountqrcode.CreateOAQrcodeHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodDelete,
+					Path:    "/qrcodes/:id",
+					Handler: adminwechatofficialaccountqrcode.DeleteOAQrcodeHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/qrcodes/:id/statistics",
+					Handler: adminwechatofficialaccountqrcode.GetOAQrcodeStatisticsHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/admin/wechat/official-account"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/notification-templates/page-list",
+					Handler: adminwechatnotification.ListNotificationTemplatesPageHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/notification-templates",
+					Handler: adminwechatnotification.CreateNotificationTemplateHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/notification-templates/:id",
+					Handler: adminwechatnotification.UpdateNotificationTemplateHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodDelete,
+					Path:    "/notification-templates/:id",
+					Handler: adminwechatnotification.DeleteNotificationTemplateHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/notification-logs/page-list",
+					Handler: adminwechatnotification.ListNotificationLogsPageHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/notification-logs/:id/resend",
+					Handler: adminwechatnotification.ResendNotificationLogHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/admin/wechat"),
+	)
+
 	server.AddRoutes(
 		[]rest.Route{
 			{
@@ -1764,6 +2369,21 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 					Path:    "/product-statistics/:id",
 					Handler: admincrmproductproductstatistics.PatchProductStatisticsHandler(serverCtx),
 				},
+				{
+					Method:  http.MethodDelete,
+					Path:    "/product-statistics/:id",
+					Handler: admincrmproductproductstatistics.DeleteProductStatisticsHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/product-statistics/:id/snapshots",
+					Handler: admincrmproductproductstatistics.ListProductStatisticsSnapshotsHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/product-statistics/:id/sync-inventory",
+					Handler: admincrmproductproductstatistics.SyncProductStatisticsInventoryHandler(serverCtx),
+				},
 			}...,
 		),
 		rest.WithPrefix("/api/v1/admin/product"),
@@ -1785,6 +2405,97 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 		rest.WithPrefix("/api/v1/mp/product"),
 	)
 
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodGet,
+				Path:    "/product-search",
+				Handler: mpcrmproductsearch.SearchProductsHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/product-search/suggestions",
+				Handler: mpcrmproductsearch.SuggestProductsHandler(serverCtx),
+			},
+		},
+		rest.WithPrefix("/api/v1/mp/product"),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodGet,
+				Path:    "/products/:id/reviews",
+				Handler: mpcrmproductproductreview.ListProductReviewsPageHandler(serverCtx),
+			},
+		},
+		rest.WithPrefix("/api/v1/mp/product"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.MPCustomerJWTAuth, serverCtx.MPCustomerGet},
+			[]rest.Route{
+				{
+					Method:  http.MethodPost,
+					Path:    "/product-reviews",
+					Handler: mpcrmproductproductreview.CreateProductReviewHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/product-reviews/images",
+					Handler: mpcrmproductproductreview.UploadProductReviewImageHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/product-reviews/:id/helpful",
+					Handler: mpcrmproductproductreview.MarkProductReviewHelpfulHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/mp/product"),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodGet,
+				Path:    "/stores/:id/artisans/:artisanId/slots",
+				Handler: mpcrmproductbooking.ListBookingSlotsHandler(serverCtx),
+			},
+		},
+		rest.WithPrefix("/api/v1/mp/product"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.MPCustomerJWTAuth, serverCtx.MPCustomerGet},
+			[]rest.Route{
+				{
+					Method:  http.MethodPost,
+					Path:    "/bookings",
+					Handler: mpcrmproductbooking.CreateBookingHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/bookings/page-list",
+					Handler: mpcrmproductbooking.ListBookingsPageHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/bookings/:id/reschedule",
+					Handler: mpcrmproductbooking.RescheduleBookingHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/bookings/:id/cancel",
+					Handler: mpcrmproductbooking.CancelBookingHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/mp/product"),
+	)
+
 	server.AddRoutes(
 		rest.WithMiddlewares(
 			[]rest.Middleware{serverCtx.MPCustomerJWTAuth, serverCtx.MPCustomerGet},
@@ -1907,6 +2618,35 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 		rest.WithPrefix("/api/v1/admin/trade"),
 	)
 
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/store-orders/page-list",
+					Handler: admincrmtradestoreorder.ListStoreOrdersPageHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/store-orders/:id",
+					Handler: admincrmtradestoreorder.GetStoreOrderHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/store-orders/:id/ship",
+					Handler: admincrmtradestoreorder.ShipStoreOrderHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/store-orders/:id/deliver",
+					Handler: admincrmtradestoreorder.DeliverStoreOrderHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/admin/trade"),
+	)
+
 	server.AddRoutes(
 		rest.WithMiddlewares(
 			[]rest.Middleware{serverCtx.EmployeeJWTAuth},
@@ -1941,6 +2681,36 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 					Path:    "/payments/:id",
 					Handler: admincrmtradepayment.DeletePaymentHandler(serverCtx),
 				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/payments/:id/confirm",
+					Handler: admincrmtradepayment.ConfirmOfflinePaymentHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/payments/:id/refund",
+					Handler: admincrmtradepayment.RefundPaymentHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/payments/:id/close",
+					Handler: admincrmtradepayment.ClosePaymentHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/payment-notifications/page-list",
+					Handler: admincrmtradepayment.ListPaymentNotificationsPageHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/payment-notifications/:id",
+					Handler: admincrmtradepayment.GetPaymentNotificationHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/payment-notifications/:id/replay",
+					Handler: admincrmtradepayment.ReplayPaymentNotificationHandler(serverCtx),
+				},
 			}...,
 		),
 		rest.WithPrefix("/api/v1/admin/trade"),
@@ -2092,6 +2862,88 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 		rest.WithPrefix("/api/v1/mp/trade"),
 	)
 
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.MPCustomerJWTAuth, serverCtx.MPCustomerGet},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/invoices/page-list",
+					Handler: mpcrmtradeinvoice.ListInvoicesPageHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/invoices/:id",
+					Handler: mpcrmtradeinvoice.GetInvoiceHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/invoices",
+					Handler: mpcrmtradeinvoice.RequestInvoiceHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/invoices/:id/cancel",
+					Handler: mpcrmtradeinvoice.CancelInvoiceHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/mp/trade"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.MPCustomerJWTAuth, serverCtx.MPCustomerGet},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/subscriptions/page-list",
+					Handler: mpcrmtradesubscription.ListSubscriptionsPageHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/subscriptions/:id",
+					Handler: mpcrmtradesubscription.GetSubscriptionHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/subscriptions/:id/pause",
+					Handler: mpcrmtradesubscription.PauseSubscriptionHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/subscriptions/:id/resume",
+					Handler: mpcrmtradesubscription.ResumeSubscriptionHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPut,
+					Path:    "/subscriptions/:id/cancel",
+					Handler: mpcrmtradesubscription.CancelSubscriptionHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/mp/trade"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.MPCustomerJWTAuth, serverCtx.MPCustomerGet},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/subscribe-templates",
+					Handler: mpwechatnotification.ListSubscribeTemplatesHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/subscriptions",
+					Handler: mpwechatnotification.RecordSubscriptionsHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/mp/wechat"),
+	)
+
 	server.AddRoutes(
 		rest.WithMiddlewares(
 			[]rest.Middleware{serverCtx.WebCustomerJWTAuth},
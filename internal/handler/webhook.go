@@ -1,6 +1,7 @@
 package handler
 
 import (
+    "PowerX/internal/handler/webhook/officialaccount"
     "PowerX/internal/handler/webhook/payment"
     "PowerX/internal/handler/webhook/wework"
     "PowerX/internal/svc"
@@ -45,5 +46,38 @@ func RegisterWebhookHandlers(server *rest.Server, serverCtx *svc.ServiceContext)
         rest.WithPrefix("/webhook/wx"),
     )
 
+    server.AddRoutes(
+        rest.WithMiddlewares(
+            []rest.Middleware{},
+            []rest.Route{
+                {
+                    Method:  http.MethodPost,
+                    Path:    "/pay/",
+                    Handler: payment.PostAlipayMessageHandler(serverCtx),
+                },
+            }...,
+        ),
+        rest.WithPrefix("/webhook/alipay"),
+    )
+
+    server.AddRoutes(
+        rest.WithMiddlewares(
+            []rest.Middleware{},
+            []rest.Route{
+                {
+                    Method:  http.MethodGet,
+                    Path:    "/message",
+                    Handler: officialaccount.GetMessageHandler(serverCtx),
+                },
+                {
+                    Method:  http.MethodPost,
+                    Path:    "/message",
+                    Handler: officialaccount.PostMessageHandler(serverCtx),
+                },
+            }...,
+        ),
+        rest.WithPrefix("/api/webhook/wechat/official-account"),
+    )
+
     // custom
 }
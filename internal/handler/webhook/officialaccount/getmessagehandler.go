@@ -0,0 +1,16 @@
+package officialaccount
+
+import (
+	oaLogic "PowerX/internal/logic/wx/officialaccount"
+	"net/http"
+
+	"PowerX/internal/svc"
+)
+
+func GetMessageHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := oaLogic.NewWebhookGetMessageLogic(r.Context(), svcCtx)
+		l.WebhookGetMessage(w, r)
+
+	}
+}
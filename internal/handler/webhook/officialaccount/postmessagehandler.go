@@ -0,0 +1,16 @@
+package officialaccount
+
+import (
+	oaLogic "PowerX/internal/logic/wx/officialaccount"
+	"net/http"
+
+	"PowerX/internal/svc"
+)
+
+func PostMessageHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := oaLogic.NewWebhookPostMessageLogic(r.Context(), svcCtx)
+		l.WebhookPostMessage(w, r)
+
+	}
+}
@@ -0,0 +1,16 @@
+package payment
+
+import (
+	paymentLogic "PowerX/internal/logic/alipay/payment"
+	"net/http"
+
+	"PowerX/internal/svc"
+)
+
+func PostAlipayMessageHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := paymentLogic.NewAlipayPostPaymentLogic(r.Context(), svcCtx)
+		l.WebhookAlipayPostPayment(w, r)
+
+	}
+}
@@ -2,6 +2,8 @@ package customer
 
 import (
 	customerdomain2 "PowerX/internal/model/crm/customerdomain"
+	"PowerX/internal/model/powermodel"
+	"PowerX/internal/types/errorx"
 	"PowerX/internal/uc/powerx/crm/customerdomain"
 	"context"
 
@@ -26,13 +28,17 @@ func NewListCustomersPageLogic(ctx context.Context, svcCtx *svc.ServiceContext)
 }
 
 func (l *ListCustomersPageLogic) ListCustomersPage(req *types.ListCustomersPageRequest) (resp *types.ListCustomersPageReply, err error) {
+	querySpec, err := powermodel.NewQuerySpec(customerdomain2.CustomerQueryFields, req.OrderBy, req.Filters)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
 
 	page, err := l.svcCtx.PowerX.Customer.FindManyCustomers(l.ctx, &customerdomain.FindManyCustomersOption{
 		LikeName:   req.LikeName,
 		LikeMobile: req.LikeMobile,
 		Statuses:   req.Statuses,
 		Sources:    req.Sources,
-		OrderBy:    req.OrderBy,
+		QuerySpec:  querySpec,
 		PageEmbedOption: types.PageEmbedOption{
 			PageIndex: req.PageIndex,
 			PageSize:  req.PageSize,
@@ -4,6 +4,8 @@ import (
 	"PowerX/internal/logic/admin/mediaresource"
 	product2 "PowerX/internal/model/crm/market"
 	"PowerX/internal/model/media"
+	"PowerX/internal/model/powermodel"
+	"PowerX/internal/types/errorx"
 	"PowerX/internal/uc/powerx/crm/market"
 	"context"
 
@@ -28,9 +30,14 @@ func NewListStoresPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Li
 }
 
 func (l *ListStoresLogic) ListStoresPage(req *types.ListStoresPageRequest) (resp *types.ListStoresPageReply, err error) {
+	querySpec, err := powermodel.NewQuerySpec(product2.StoreQueryFields, req.OrderBy, req.Filters)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+
 	stores, err := l.svcCtx.PowerX.Store.FindManyStores(l.ctx, &market.FindManyStoresOption{
-		LikeName: req.LikeName,
-		OrderBy:  req.OrderBy,
+		LikeName:  req.LikeName,
+		QuerySpec: querySpec,
 		PageEmbedOption: types.PageEmbedOption{
 			PageIndex: req.PageIndex,
 			PageSize:  req.PageSize,
@@ -0,0 +1,44 @@
+package storeproduct
+
+import (
+	"PowerX/internal/types/errorx"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type AssignStoreProductsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewAssignStoreProductsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *AssignStoreProductsLogic {
+	return &AssignStoreProductsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *AssignStoreProductsLogic) AssignStoreProducts(req *types.AssignStoreProductsRequest) (resp *types.AssignStoreProductsReply, err error) {
+	if len(req.ProductIds) == 0 {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "请选择上架的产品")
+	}
+	if _, err = l.svcCtx.PowerX.Store.GetStore(l.ctx, req.StoreId); err != nil {
+		return nil, errorx.WithCause(errorx.ErrNotFoundObject, "未找到门店")
+	}
+
+	err = l.svcCtx.PowerX.StoreProduct.AssignStoreProducts(l.ctx, req.StoreId, req.ProductIds, req.IsAvailable)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.AssignStoreProductsReply{
+		StoreId:    req.StoreId,
+		ProductIds: req.ProductIds,
+	}, nil
+}
@@ -0,0 +1,96 @@
+package storeproduct
+
+import (
+	"PowerX/internal/logic/admin/mediaresource"
+	"PowerX/internal/model/crm/product"
+	productUC "PowerX/internal/uc/powerx/crm/product"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListStoreProductsPageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListStoreProductsPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListStoreProductsPageLogic {
+	return &ListStoreProductsPageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListStoreProductsPageLogic) ListStoreProductsPage(req *types.ListStoreProductsPageRequest) (resp *types.ListStoreProductsPageReply, err error) {
+	page := l.svcCtx.PowerX.StoreProduct.FindManyStoreProducts(l.ctx, &productUC.FindManyStoreProductsOption{
+		StoreId:    req.StoreId,
+		ProductIds: req.ProductIds,
+		PageEmbedOption: types.PageEmbedOption{
+			PageIndex: req.PageIndex,
+			PageSize:  req.PageSize,
+		},
+	})
+
+	productIds := []int64{}
+	for _, storeProduct := range page.List {
+		productIds = append(productIds, storeProduct.ProductId)
+	}
+	mapStoreSKUs := map[int64]*product.StoreSKU{}
+	if len(productIds) > 0 {
+		mapStoreSKUs = l.svcCtx.PowerX.StoreProduct.FindStoreSKUs(l.ctx, req.StoreId, productIds)
+	}
+
+	list := []*types.StoreProduct{}
+	for _, storeProduct := range page.List {
+		list = append(list, TransformStoreProductToReply(storeProduct, mapStoreSKUs))
+	}
+	return &types.ListStoreProductsPageReply{
+		List:      list,
+		PageIndex: page.PageIndex,
+		PageSize:  page.PageSize,
+		Total:     page.Total,
+	}, nil
+}
+
+func TransformStoreProductToReply(storeProduct *product.StoreProduct, mapStoreSKUs map[int64]*product.StoreSKU) *types.StoreProduct {
+	reply := &types.StoreProduct{
+		Id:          storeProduct.Id,
+		StoreId:     storeProduct.StoreId,
+		ProductId:   storeProduct.ProductId,
+		IsAvailable: storeProduct.IsAvailable,
+		SKUs:        []*types.StoreSKU{},
+		CreatedAt:   storeProduct.CreatedAt.String(),
+	}
+	if storeProduct.Product == nil {
+		return reply
+	}
+	reply.ProductName = storeProduct.Product.Name
+	reply.SPU = storeProduct.Product.SPU
+	if len(storeProduct.Product.PivotCoverImages) > 0 {
+		reply.CoverImage = mediaresource.TransformMediaResourceToReply(storeProduct.Product.PivotCoverImages[0].MediaResource)
+	}
+	for _, sku := range storeProduct.Product.SKUs {
+		reply.SKUs = append(reply.SKUs, TransformStoreSKUToReply(sku, mapStoreSKUs[sku.Id]))
+	}
+	return reply
+}
+
+// TransformStoreSKUToReply 门店没有配置覆盖的SKU按可售返回, 门店库存为空
+func TransformStoreSKUToReply(sku *product.SKU, storeSKU *product.StoreSKU) *types.StoreSKU {
+	reply := &types.StoreSKU{
+		SkuId:        sku.Id,
+		SkuNo:        sku.SkuNo,
+		IsAvailable:  true,
+		SkuInventory: sku.Inventory,
+	}
+	if storeSKU != nil {
+		reply.IsAvailable = storeSKU.IsAvailable
+		reply.Inventory = storeSKU.Inventory
+	}
+	return reply
+}
@@ -0,0 +1,33 @@
+package storeproduct
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RemoveStoreProductLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRemoveStoreProductLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RemoveStoreProductLogic {
+	return &RemoveStoreProductLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RemoveStoreProductLogic) RemoveStoreProduct(req *types.RemoveStoreProductRequest) (resp *types.RemoveStoreProductReply, err error) {
+	l.svcCtx.PowerX.StoreProduct.RemoveStoreProducts(l.ctx, req.StoreId, []int64{req.ProductId})
+
+	return &types.RemoveStoreProductReply{
+		StoreId:   req.StoreId,
+		ProductId: req.ProductId,
+	}, nil
+}
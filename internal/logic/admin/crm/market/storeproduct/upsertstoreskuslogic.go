@@ -0,0 +1,50 @@
+package storeproduct
+
+import (
+	"PowerX/internal/model/crm/product"
+	"PowerX/internal/types/errorx"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UpsertStoreSKUsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUpsertStoreSKUsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpsertStoreSKUsLogic {
+	return &UpsertStoreSKUsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UpsertStoreSKUsLogic) UpsertStoreSKUs(req *types.UpsertStoreSKUsRequest) (resp *types.UpsertStoreSKUsReply, err error) {
+	if len(req.SKUs) == 0 {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "请选择配置的SKU")
+	}
+
+	storeSKUs := []*product.StoreSKU{}
+	for _, sku := range req.SKUs {
+		storeSKUs = append(storeSKUs, &product.StoreSKU{
+			SkuId:       sku.SkuId,
+			IsAvailable: sku.IsAvailable,
+			Inventory:   sku.Inventory,
+		})
+	}
+	err = l.svcCtx.PowerX.StoreProduct.UpsertStoreSKUs(l.ctx, req.StoreId, storeSKUs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.UpsertStoreSKUsReply{
+		StoreId: req.StoreId,
+		SKUs:    req.SKUs,
+	}, nil
+}
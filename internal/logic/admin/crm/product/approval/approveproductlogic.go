@@ -0,0 +1,74 @@
+package approval
+
+import (
+	"PowerX/internal/logic/admin/crm/product"
+	"PowerX/internal/model"
+	product2 "PowerX/internal/model/crm/product"
+	"context"
+	"encoding/json"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ApproveProductLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewApproveProductLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ApproveProductLogic {
+	return &ApproveProductLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ApproveProductLogic) ApproveProduct(req *types.ApproveProductRequest) (resp *types.ApproveProductReply, err error) {
+	cred, err := l.svcCtx.PowerX.AdminAuthorization.AuthMetadataFromContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 已上架产品审核的是待审核修订, 通过后将修订内容更新到线上产品
+	var mdlProduct *product2.Product
+	approvalStatus := l.svcCtx.PowerX.ProductApproval.GetApprovalStatusId(l.ctx, model.ApprovalStatusSuccess)
+	revision, err := l.svcCtx.PowerX.ProductApproval.ApprovePendingRevision(l.ctx, req.ProductId, cred.UID, req.Comment,
+		func(revision *product2.ProductRevision) error {
+			putRequest := &types.PutProductRequest{}
+			if err := json.Unmarshal(revision.Payload, putRequest); err != nil {
+				return err
+			}
+			putRequest.ProductId = revision.ProductId
+
+			updated, err := product.UpdateProductFromRequest(l.ctx, l.svcCtx, putRequest, approvalStatus)
+			mdlProduct = updated
+			return err
+		})
+	if err != nil {
+		return nil, err
+	}
+	if revision != nil {
+		l.svcCtx.PowerX.ProductSearch.IndexProduct(l.ctx, mdlProduct.Id)
+
+		return &types.ApproveProductReply{
+			ProductId:      mdlProduct.Id,
+			ApprovalStatus: mdlProduct.ApprovalStatus,
+			RevisionId:     revision.Id,
+		}, nil
+	}
+
+	mdlProduct, err = l.svcCtx.PowerX.ProductApproval.ApproveProduct(l.ctx, req.ProductId, cred.UID, req.Comment)
+	if err != nil {
+		return nil, err
+	}
+	l.svcCtx.PowerX.ProductSearch.IndexProduct(l.ctx, mdlProduct.Id)
+
+	return &types.ApproveProductReply{
+		ProductId:      mdlProduct.Id,
+		ApprovalStatus: mdlProduct.ApprovalStatus,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package approval
+
+import (
+	"PowerX/internal/logic/admin/crm/product"
+	product2 "PowerX/internal/model/crm/product"
+	"context"
+	"encoding/json"
+	"time"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetProductPendingRevisionLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetProductPendingRevisionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetProductPendingRevisionLogic {
+	return &GetProductPendingRevisionLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetProductPendingRevisionLogic) GetProductPendingRevision(req *types.GetProductPendingRevisionRequest) (resp *types.GetProductPendingRevisionReply, err error) {
+	revision := l.svcCtx.PowerX.ProductApproval.GetPendingRevision(l.ctx, req.ProductId)
+	if revision == nil {
+		return &types.GetProductPendingRevisionReply{}, nil
+	}
+
+	return &types.GetProductPendingRevisionReply{
+		ProductRevision: TransformProductRevisionToReply(revision),
+	}, nil
+}
+
+func TransformProductRevisionToReply(revision *product2.ProductRevision) *types.ProductRevision {
+	reply := &types.ProductRevision{
+		Id:          revision.Id,
+		ProductId:   revision.ProductId,
+		Status:      revision.Status,
+		SubmittedBy: revision.SubmittedBy,
+		ReviewedBy:  revision.ReviewedBy,
+		Comment:     revision.Comment,
+		CreatedAt:   revision.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   revision.UpdatedAt.Format(time.RFC3339),
+	}
+	if revision.ReviewedAt != nil {
+		reply.ReviewedAt = revision.ReviewedAt.Format(time.RFC3339)
+	}
+
+	// 修订内容为修改产品时的请求, 转换为产品返回
+	putRequest := &types.PutProductRequest{}
+	if err := json.Unmarshal(revision.Payload, putRequest); err == nil {
+		mdlProduct := product.TransformRequestToProduct(&(putRequest.Product))
+		mdlProduct.Id = revision.ProductId
+		reply.Product = product.TransformProductToReply(mdlProduct)
+		reply.Product.CategoryIds = putRequest.CategoryIds
+		reply.Product.SalesChannelsItemIds = putRequest.SalesChannelsItemIds
+		reply.Product.PromoteChannelsItemIds = putRequest.PromoteChannelsItemIds
+		reply.Product.CoverImageIds = putRequest.CoverImageIds
+		reply.Product.DetailImageIds = putRequest.DetailImageIds
+	}
+
+	return reply
+}
@@ -0,0 +1,52 @@
+package approval
+
+import (
+	"PowerX/internal/model/crm/product"
+	"context"
+	"time"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListProductApprovalRecordsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListProductApprovalRecordsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListProductApprovalRecordsLogic {
+	return &ListProductApprovalRecordsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListProductApprovalRecordsLogic) ListProductApprovalRecords(req *types.ListProductApprovalRecordsRequest) (resp *types.ListProductApprovalRecordsReply, err error) {
+	records := l.svcCtx.PowerX.ProductApproval.FindApprovalRecords(l.ctx, req.ProductId)
+
+	list := []*types.ProductApprovalRecord{}
+	for _, record := range records {
+		list = append(list, TransformProductApprovalRecordToReply(record))
+	}
+
+	return &types.ListProductApprovalRecordsReply{
+		List: list,
+	}, nil
+}
+
+func TransformProductApprovalRecordToReply(record *product.ProductApprovalRecord) *types.ProductApprovalRecord {
+	return &types.ProductApprovalRecord{
+		Id:             record.Id,
+		ProductId:      record.ProductId,
+		RevisionId:     record.RevisionId,
+		Action:         record.Action,
+		ApprovalStatus: record.ApprovalStatus,
+		OperatorId:     record.OperatorId,
+		Comment:        record.Comment,
+		CreatedAt:      record.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,41 @@
+package approval
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RejectProductLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRejectProductLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RejectProductLogic {
+	return &RejectProductLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RejectProductLogic) RejectProduct(req *types.RejectProductRequest) (resp *types.RejectProductReply, err error) {
+	cred, err := l.svcCtx.PowerX.AdminAuthorization.AuthMetadataFromContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = l.svcCtx.PowerX.ProductApproval.RejectProduct(l.ctx, req.ProductId, cred.UID, req.Comment)
+	if err != nil {
+		return nil, err
+	}
+	l.svcCtx.PowerX.ProductSearch.IndexProduct(l.ctx, req.ProductId)
+
+	return &types.RejectProductReply{
+		ProductId: req.ProductId,
+	}, nil
+}
@@ -0,0 +1,41 @@
+package approval
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SubmitProductApprovalLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSubmitProductApprovalLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SubmitProductApprovalLogic {
+	return &SubmitProductApprovalLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *SubmitProductApprovalLogic) SubmitProductApproval(req *types.SubmitProductApprovalRequest) (resp *types.SubmitProductApprovalReply, err error) {
+	cred, err := l.svcCtx.PowerX.AdminAuthorization.AuthMetadataFromContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mdlProduct, err := l.svcCtx.PowerX.ProductApproval.SubmitProduct(l.ctx, req.ProductId, cred.UID, req.Comment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.SubmitProductApprovalReply{
+		ProductId:      mdlProduct.Id,
+		ApprovalStatus: mdlProduct.ApprovalStatus,
+	}, nil
+}
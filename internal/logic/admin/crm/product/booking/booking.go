@@ -0,0 +1,130 @@
+package booking
+
+import (
+	"PowerX/internal/model/crm/product"
+	"PowerX/internal/types"
+	"PowerX/internal/types/errorx"
+	"PowerX/pkg/datetime/carbonx"
+	"github.com/golang-module/carbon/v2"
+	"time"
+)
+
+// 日历最多查询的天数
+const maxCalendarDays = 31
+
+// ParseBookingTime 解析预约及请假的时间
+func ParseBookingTime(value string) (time.Time, error) {
+	t := carbon.Parse(value)
+	if value == "" || t.Error != nil || t.IsInvalid() {
+		return time.Time{}, errorx.WithCause(errorx.ErrBadRequest, "时间格式错误")
+	}
+	return t.ToStdTime(), nil
+}
+
+// parseCalendarRange 日历的日期范围, 结束日期当天包含在内
+func parseCalendarRange(startDate string, endDate string) (time.Time, time.Time, error) {
+	start := carbon.ParseByFormat(startDate, carbonx.DateFormat)
+	end := carbon.ParseByFormat(endDate, carbonx.DateFormat)
+	if start.Error != nil || end.Error != nil || start.IsInvalid() || end.IsInvalid() {
+		return time.Time{}, time.Time{}, errorx.WithCause(errorx.ErrBadRequest, "日期格式错误")
+	}
+	startAt := start.StartOfDay().ToStdTime()
+	endAt := end.AddDay().StartOfDay().ToStdTime()
+	if !startAt.Before(endAt) {
+		return time.Time{}, time.Time{}, errorx.WithCause(errorx.ErrBadRequest, "结束日期不能早于开始日期")
+	}
+	if endAt.Sub(startAt) > maxCalendarDays*24*time.Hour {
+		return time.Time{}, time.Time{}, errorx.WithCause(errorx.ErrBadRequest, "日历最多查询31天")
+	}
+	return startAt, endAt, nil
+}
+
+func TransformArtisanScheduleToReply(schedule *product.ArtisanSchedule) *types.ArtisanSchedule {
+	return &types.ArtisanSchedule{
+		Id:          schedule.Id,
+		StoreId:     schedule.StoreId,
+		ArtisanId:   schedule.ArtisanId,
+		Weekday:     schedule.Weekday,
+		StartTime:   schedule.StartTime,
+		EndTime:     schedule.EndTime,
+		SlotMinutes: schedule.SlotMinutes,
+	}
+}
+
+func TransformArtisanSchedulesToReply(schedules []*product.ArtisanSchedule) []*types.ArtisanSchedule {
+	list := []*types.ArtisanSchedule{}
+	for _, schedule := range schedules {
+		list = append(list, TransformArtisanScheduleToReply(schedule))
+	}
+	return list
+}
+
+func TransformArtisanLeaveToReply(leave *product.ArtisanLeave) *types.ArtisanLeave {
+	return &types.ArtisanLeave{
+		Id:        leave.Id,
+		ArtisanId: leave.ArtisanId,
+		StartAt:   leave.StartAt.Format(time.RFC3339),
+		EndAt:     leave.EndAt.Format(time.RFC3339),
+		Reason:    leave.Reason,
+		CreatedAt: leave.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func TransformArtisanLeavesToReply(leaves []*product.ArtisanLeave) []*types.ArtisanLeave {
+	list := []*types.ArtisanLeave{}
+	for _, leave := range leaves {
+		list = append(list, TransformArtisanLeaveToReply(leave))
+	}
+	return list
+}
+
+func TransformBookingToReply(booking *product.Booking) *types.Booking {
+	reply := &types.Booking{
+		Id:              booking.Id,
+		StoreId:         booking.StoreId,
+		ArtisanId:       booking.ArtisanId,
+		CustomerId:      booking.CustomerId,
+		OrderId:         booking.OrderId,
+		OrderItemId:     booking.OrderItemId,
+		ProductId:       booking.ProductId,
+		StartAt:         booking.StartAt.Format(time.RFC3339),
+		EndAt:           booking.EndAt.Format(time.RFC3339),
+		Status:          booking.Status,
+		Comment:         booking.Comment,
+		RescheduleCount: booking.RescheduleCount,
+		CancelReason:    booking.CancelReason,
+		CreatedAt:       booking.CreatedAt.Format(time.RFC3339),
+	}
+	if booking.Artisan != nil {
+		reply.ArtisanName = booking.Artisan.Name
+	}
+	if booking.Product != nil {
+		reply.ProductName = booking.Product.Name
+	}
+	if booking.CancelledAt != nil {
+		reply.CancelledAt = booking.CancelledAt.Format(time.RFC3339)
+	}
+	if booking.CompletedAt != nil {
+		reply.CompletedAt = booking.CompletedAt.Format(time.RFC3339)
+	}
+	return reply
+}
+
+func TransformBookingsToReply(bookings []*product.Booking) []*types.Booking {
+	list := []*types.Booking{}
+	for _, booking := range bookings {
+		list = append(list, TransformBookingToReply(booking))
+	}
+	return list
+}
+
+func TransformTimeSlotsToReply(slots []*product.TimeSlot) []*types.BookingTimeSlot {
+	list := []*types.BookingTimeSlot{}
+	for _, slot := range slots {
+		list = append(list, &types.BookingTimeSlot{
+			StartAt: slot.StartAt.Format(time.RFC3339),
+			EndAt:   slot.EndAt.Format(time.RFC3339),
+		})
+	}
+	return list
+}
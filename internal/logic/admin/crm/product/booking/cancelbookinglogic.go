@@ -0,0 +1,40 @@
+package booking
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CancelBookingLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCancelBookingLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CancelBookingLogic {
+	return &CancelBookingLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CancelBookingLogic) CancelBooking(req *types.CancelBookingRequest) (resp *types.CancelBookingReply, err error) {
+	booking, err := l.svcCtx.PowerX.Booking.GetBooking(l.ctx, req.BookingId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = l.svcCtx.PowerX.Booking.CancelBooking(l.ctx, booking, req.Reason, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.CancelBookingReply{
+		Booking: TransformBookingToReply(booking),
+	}, nil
+}
@@ -0,0 +1,40 @@
+package booking
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CompleteBookingLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCompleteBookingLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CompleteBookingLogic {
+	return &CompleteBookingLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CompleteBookingLogic) CompleteBooking(req *types.CompleteBookingRequest) (resp *types.CompleteBookingReply, err error) {
+	booking, err := l.svcCtx.PowerX.Booking.GetBooking(l.ctx, req.BookingId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = l.svcCtx.PowerX.Booking.CompleteBooking(l.ctx, booking)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.CompleteBookingReply{
+		Booking: TransformBookingToReply(booking),
+	}, nil
+}
@@ -0,0 +1,50 @@
+package booking
+
+import (
+	"PowerX/internal/model/crm/product"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreateArtisanLeaveLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateArtisanLeaveLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateArtisanLeaveLogic {
+	return &CreateArtisanLeaveLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CreateArtisanLeaveLogic) CreateArtisanLeave(req *types.CreateArtisanLeaveRequest) (resp *types.CreateArtisanLeaveReply, err error) {
+	startAt, err := ParseBookingTime(req.StartAt)
+	if err != nil {
+		return nil, err
+	}
+	endAt, err := ParseBookingTime(req.EndAt)
+	if err != nil {
+		return nil, err
+	}
+
+	leave := &product.ArtisanLeave{
+		ArtisanId: req.ArtisanId,
+		StartAt:   startAt,
+		EndAt:     endAt,
+		Reason:    req.Reason,
+	}
+	if err = l.svcCtx.PowerX.Booking.CreateArtisanLeave(l.ctx, leave); err != nil {
+		return nil, err
+	}
+
+	return &types.CreateArtisanLeaveReply{
+		ArtisanLeaveId: leave.Id,
+	}, nil
+}
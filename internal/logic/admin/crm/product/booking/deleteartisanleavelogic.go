@@ -0,0 +1,35 @@
+package booking
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteArtisanLeaveLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeleteArtisanLeaveLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteArtisanLeaveLogic {
+	return &DeleteArtisanLeaveLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeleteArtisanLeaveLogic) DeleteArtisanLeave(req *types.DeleteArtisanLeaveRequest) (resp *types.DeleteArtisanLeaveReply, err error) {
+	err = l.svcCtx.PowerX.Booking.DeleteArtisanLeave(l.ctx, req.ArtisanLeaveId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.DeleteArtisanLeaveReply{
+		ArtisanLeaveId: req.ArtisanLeaveId,
+	}, nil
+}
@@ -0,0 +1,47 @@
+package booking
+
+import (
+	"PowerX/internal/model/crm/product"
+	productUC "PowerX/internal/uc/powerx/crm/product"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetArtisanBookingCalendarLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetArtisanBookingCalendarLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetArtisanBookingCalendarLogic {
+	return &GetArtisanBookingCalendarLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetArtisanBookingCalendarLogic) GetArtisanBookingCalendar(req *types.GetArtisanBookingCalendarRequest) (resp *types.GetArtisanBookingCalendarReply, err error) {
+	startAt, endAt, err := parseCalendarRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	bookings := l.svcCtx.PowerX.Booking.FindAllBookings(l.ctx, &productUC.FindManyBookingsOption{
+		ArtisanId: req.ArtisanId,
+		Statuses:  []string{product.BookingStatusBooked, product.BookingStatusCompleted},
+		StartAt:   startAt,
+		EndAt:     endAt,
+	})
+	leaves := l.svcCtx.PowerX.Booking.FindArtisanLeaves(l.ctx, req.ArtisanId, startAt, endAt)
+
+	return &types.GetArtisanBookingCalendarReply{
+		ArtisanId: req.ArtisanId,
+		Bookings:  TransformBookingsToReply(bookings),
+		Leaves:    TransformArtisanLeavesToReply(leaves),
+	}, nil
+}
@@ -0,0 +1,48 @@
+package booking
+
+import (
+	"PowerX/internal/model/crm/product"
+	productUC "PowerX/internal/uc/powerx/crm/product"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetStoreBookingCalendarLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetStoreBookingCalendarLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetStoreBookingCalendarLogic {
+	return &GetStoreBookingCalendarLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetStoreBookingCalendarLogic) GetStoreBookingCalendar(req *types.GetStoreBookingCalendarRequest) (resp *types.GetStoreBookingCalendarReply, err error) {
+	startAt, endAt, err := parseCalendarRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := l.svcCtx.PowerX.Booking.FindArtisanSchedules(l.ctx, req.StoreId, req.ArtisanId)
+	bookings := l.svcCtx.PowerX.Booking.FindAllBookings(l.ctx, &productUC.FindManyBookingsOption{
+		StoreId:   req.StoreId,
+		ArtisanId: req.ArtisanId,
+		Statuses:  []string{product.BookingStatusBooked, product.BookingStatusCompleted},
+		StartAt:   startAt,
+		EndAt:     endAt,
+	})
+
+	return &types.GetStoreBookingCalendarReply{
+		StoreId:   req.StoreId,
+		Schedules: TransformArtisanSchedulesToReply(schedules),
+		Bookings:  TransformBookingsToReply(bookings),
+	}, nil
+}
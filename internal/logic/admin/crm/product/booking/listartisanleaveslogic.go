@@ -0,0 +1,45 @@
+package booking
+
+import (
+	"context"
+	"time"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListArtisanLeavesLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListArtisanLeavesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListArtisanLeavesLogic {
+	return &ListArtisanLeavesLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListArtisanLeavesLogic) ListArtisanLeaves(req *types.ListArtisanLeavesRequest) (resp *types.ListArtisanLeavesReply, err error) {
+	var startAt, endAt time.Time
+	if req.StartAt != "" {
+		if startAt, err = ParseBookingTime(req.StartAt); err != nil {
+			return nil, err
+		}
+	}
+	if req.EndAt != "" {
+		if endAt, err = ParseBookingTime(req.EndAt); err != nil {
+			return nil, err
+		}
+	}
+
+	leaves := l.svcCtx.PowerX.Booking.FindArtisanLeaves(l.ctx, req.ArtisanId, startAt, endAt)
+
+	return &types.ListArtisanLeavesReply{
+		List: TransformArtisanLeavesToReply(leaves),
+	}, nil
+}
@@ -0,0 +1,32 @@
+package booking
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListArtisanSchedulesLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListArtisanSchedulesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListArtisanSchedulesLogic {
+	return &ListArtisanSchedulesLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListArtisanSchedulesLogic) ListArtisanSchedules(req *types.ListArtisanSchedulesRequest) (resp *types.ListArtisanSchedulesReply, err error) {
+	schedules := l.svcCtx.PowerX.Booking.FindArtisanSchedules(l.ctx, req.StoreId, req.ArtisanId)
+
+	return &types.ListArtisanSchedulesReply{
+		List: TransformArtisanSchedulesToReply(schedules),
+	}, nil
+}
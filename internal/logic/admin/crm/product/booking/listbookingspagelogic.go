@@ -0,0 +1,58 @@
+package booking
+
+import (
+	productUC "PowerX/internal/uc/powerx/crm/product"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListBookingsPageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListBookingsPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListBookingsPageLogic {
+	return &ListBookingsPageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListBookingsPageLogic) ListBookingsPage(req *types.ListBookingsPageRequest) (resp *types.ListBookingsPageReply, err error) {
+	opt := &productUC.FindManyBookingsOption{
+		StoreId:    req.StoreId,
+		ArtisanId:  req.ArtisanId,
+		CustomerId: req.CustomerId,
+		OrderId:    req.OrderId,
+		Statuses:   req.Statuses,
+		PageEmbedOption: types.PageEmbedOption{
+			PageIndex: req.PageIndex,
+			PageSize:  req.PageSize,
+		},
+	}
+	if req.StartAt != "" {
+		if opt.StartAt, err = ParseBookingTime(req.StartAt); err != nil {
+			return nil, err
+		}
+	}
+	if req.EndAt != "" {
+		if opt.EndAt, err = ParseBookingTime(req.EndAt); err != nil {
+			return nil, err
+		}
+	}
+
+	page := l.svcCtx.PowerX.Booking.FindManyBookings(l.ctx, opt)
+
+	return &types.ListBookingsPageReply{
+		List:      TransformBookingsToReply(page.List),
+		PageIndex: page.PageIndex,
+		PageSize:  page.PageSize,
+		Total:     page.Total,
+	}, nil
+}
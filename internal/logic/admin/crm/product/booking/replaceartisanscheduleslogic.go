@@ -0,0 +1,46 @@
+package booking
+
+import (
+	"PowerX/internal/model/crm/product"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ReplaceArtisanSchedulesLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewReplaceArtisanSchedulesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ReplaceArtisanSchedulesLogic {
+	return &ReplaceArtisanSchedulesLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ReplaceArtisanSchedulesLogic) ReplaceArtisanSchedules(req *types.ReplaceArtisanSchedulesRequest) (resp *types.ReplaceArtisanSchedulesReply, err error) {
+	schedules := []*product.ArtisanSchedule{}
+	for _, schedule := range req.Schedules {
+		schedules = append(schedules, &product.ArtisanSchedule{
+			Weekday:     schedule.Weekday,
+			StartTime:   schedule.StartTime,
+			EndTime:     schedule.EndTime,
+			SlotMinutes: schedule.SlotMinutes,
+		})
+	}
+
+	err = l.svcCtx.PowerX.Booking.ReplaceArtisanSchedules(l.ctx, req.StoreId, req.ArtisanId, schedules)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ReplaceArtisanSchedulesReply{
+		List: TransformArtisanSchedulesToReply(schedules),
+	}, nil
+}
@@ -0,0 +1,44 @@
+package booking
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RescheduleBookingLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRescheduleBookingLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RescheduleBookingLogic {
+	return &RescheduleBookingLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RescheduleBookingLogic) RescheduleBooking(req *types.RescheduleBookingRequest) (resp *types.RescheduleBookingReply, err error) {
+	startAt, err := ParseBookingTime(req.StartAt)
+	if err != nil {
+		return nil, err
+	}
+	booking, err := l.svcCtx.PowerX.Booking.GetBooking(l.ctx, req.BookingId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = l.svcCtx.PowerX.Booking.RescheduleBooking(l.ctx, booking, startAt, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.RescheduleBookingReply{
+		Booking: TransformBookingToReply(booking),
+	}, nil
+}
@@ -0,0 +1,44 @@
+package catalog
+
+import (
+	model "PowerX/internal/model/crm/product"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	productUC "PowerX/internal/uc/powerx/crm/product"
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DownloadProductCatalogTemplateLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDownloadProductCatalogTemplateLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DownloadProductCatalogTemplateLogic {
+	return &DownloadProductCatalogTemplateLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DownloadProductCatalogTemplateLogic) DownloadProductCatalogTemplate(req *types.DownloadProductCatalogTemplateRequest) (resp *types.DownloadProductCatalogTemplateReply, err error) {
+	format := req.Format
+	if format == "" {
+		format = model.ProductImportFormatCSV
+	}
+
+	content, contentType, err := productUC.WriteCatalogFile(format, productUC.GetCatalogTemplateRows())
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.DownloadProductCatalogTemplateReply{
+		Content:  content,
+		FileName: "product-catalog-template." + format,
+		FileSize: len(content),
+		FileType: contentType,
+	}, nil
+}
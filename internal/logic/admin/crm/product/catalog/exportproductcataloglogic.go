@@ -0,0 +1,75 @@
+package catalog
+
+import (
+	model "PowerX/internal/model/crm/product"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"PowerX/internal/types/errorx"
+	productUC "PowerX/internal/uc/powerx/crm/product"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const exportBatchSize = 100
+
+type ExportProductCatalogLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewExportProductCatalogLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ExportProductCatalogLogic {
+	return &ExportProductCatalogLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// ExportProductCatalog 导出的文件与导入模板格式一致, 修改后可直接重新导入
+func (l *ExportProductCatalogLogic) ExportProductCatalog(req *types.ExportProductCatalogRequest) (resp *types.ExportProductCatalogReply, err error) {
+	format := req.Format
+	if format == "" {
+		format = model.ProductImportFormatCSV
+	}
+
+	products := []*model.Product{}
+	opt := &productUC.FindManyProductsOption{
+		LikeName:   req.LikeName,
+		CategoryId: req.CategoryId,
+		PageEmbedOption: types.PageEmbedOption{
+			PageIndex: 1,
+			PageSize:  exportBatchSize,
+		},
+	}
+	for {
+		page, err := l.svcCtx.PowerX.Product.FindManyProducts(l.ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, page.List...)
+		if len(page.List) < exportBatchSize || int64(len(products)) >= page.Total {
+			break
+		}
+		if len(products) >= productUC.CatalogMaxRows {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("导出的产品不能超过%d个, 请增加筛选条件", productUC.CatalogMaxRows))
+		}
+		opt.PageIndex++
+	}
+
+	rows := l.svcCtx.PowerX.ProductCatalog.BuildCatalogRows(l.ctx, products)
+	content, contentType, err := productUC.WriteCatalogFile(format, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ExportProductCatalogReply{
+		Content:  content,
+		FileName: fmt.Sprintf("products-%s.%s", time.Now().Format("20060102150405"), format),
+		FileSize: len(content),
+		FileType: contentType,
+	}, nil
+}
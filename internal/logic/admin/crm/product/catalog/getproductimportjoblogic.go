@@ -0,0 +1,35 @@
+package catalog
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetProductImportJobLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetProductImportJobLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetProductImportJobLogic {
+	return &GetProductImportJobLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetProductImportJobLogic) GetProductImportJob(req *types.GetProductImportJobRequest) (resp *types.GetProductImportJobReply, err error) {
+	job, err := l.svcCtx.PowerX.ProductCatalog.GetImportJob(l.ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetProductImportJobReply{
+		ProductImportJob: TransformProductImportJobToReply(job),
+	}, nil
+}
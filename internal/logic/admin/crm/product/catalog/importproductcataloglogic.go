@@ -0,0 +1,353 @@
+package catalog
+
+import (
+	"PowerX/internal/logic/admin/crm/product"
+	model "PowerX/internal/model/crm/product"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"PowerX/internal/types/errorx"
+	productUC "PowerX/internal/uc/powerx/crm/product"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 每处理多少个产品更新一次任务进度
+const importProgressBatchSize = 20
+
+type ImportProductCatalogLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewImportProductCatalogLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ImportProductCatalogLogic {
+	return &ImportProductCatalogLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// ImportProductCatalog 保存上传的文件并创建导入任务, 任务在后台处理, 通过任务Id查询进度和结果
+func (l *ImportProductCatalogLogic) ImportProductCatalog(r *http.Request) (resp *types.ImportProductCatalogReply, err error) {
+	cred, err := l.svcCtx.PowerX.AdminAuthorization.AuthMetadataFromContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 获取上传文件
+	err = r.ParseMultipartForm(productUC.CatalogMaxFileSize)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+	file, header, err := r.FormFile("resource")
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+	defer file.Close()
+
+	if header.Size > productUC.CatalogMaxFileSize {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("文件不能超过%dMB", productUC.CatalogMaxFileSize>>20))
+	}
+	format := productUC.GetCatalogFileFormat(header.Filename)
+	if format == "" {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "仅支持csv或xlsx文件")
+	}
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+	dryRun, _ := strconv.ParseBool(r.FormValue("dryRun"))
+
+	job := &model.ProductImportJob{
+		FileName:   header.Filename,
+		Format:     format,
+		Content:    content,
+		DryRun:     dryRun,
+		OperatorId: cred.UID,
+	}
+	l.svcCtx.PowerX.ProductCatalog.CreateImportJob(l.ctx, job)
+
+	go RunProductImportJob(context.Background(), l.svcCtx, job.Id)
+
+	return &types.ImportProductCatalogReply{
+		ProductImportJob: TransformProductImportJobToReply(job),
+	}, nil
+}
+
+// RunProductImportJob
+//
+//	@Description: 处理导入任务, 先整体校验, 有错误的产品整体跳过, 其余产品逐个保存; 试运行只校验并统计新建和更新的产品数
+//	@param ctx
+//	@param svcCtx
+//	@param jobId
+func RunProductImportJob(ctx context.Context, svcCtx *svc.ServiceContext, jobId int64) {
+	ucCatalog := svcCtx.PowerX.ProductCatalog
+
+	var job *model.ProductImportJob
+	defer func() {
+		if r := recover(); r != nil {
+			logx.WithContext(ctx).Errorf("产品导入任务%d处理失败:%v", jobId, r)
+			if job != nil {
+				failProductImportJob(ctx, svcCtx, job, "导入任务处理失败, 请稍后重试")
+			}
+		}
+	}()
+
+	job = ucCatalog.StartImportJob(ctx, jobId)
+	if job == nil {
+		return
+	}
+
+	rows, err := productUC.ReadCatalogFile(job.Format, job.Content)
+	if err != nil {
+		ucCatalog.FinishImportJob(ctx, job, nil, "文件读取失败: "+err.Error())
+		return
+	}
+	if len(rows)-1 > productUC.CatalogMaxRows {
+		ucCatalog.FinishImportJob(ctx, job, nil, fmt.Sprintf("数据行数不能超过%d行", productUC.CatalogMaxRows))
+		return
+	}
+
+	result := productUC.ParseCatalogRows(rows)
+	ucCatalog.ValidateCatalogProducts(ctx, result)
+	products := result.ValidProducts()
+
+	job.TotalRows = result.TotalRows
+	job.FailedRows = result.FailedRows()
+	job.ProcessedRows = job.FailedRows
+
+	if job.DryRun {
+		for _, p := range products {
+			if p.Existing == nil {
+				job.CreatedProducts++
+			} else {
+				job.UpdatedProducts++
+			}
+		}
+		job.ProcessedRows = job.TotalRows
+		ucCatalog.FinishImportJob(ctx, job, result.RowErrors, "")
+		return
+	}
+
+	ucCatalog.UpdateImportJobProgress(ctx, job)
+	for i, p := range products {
+		created, err := importCatalogProduct(ctx, svcCtx, job.OperatorId, p)
+		if err != nil {
+			result.AddProductError(p, "", err.Error())
+		} else if created {
+			job.CreatedProducts++
+		} else {
+			job.UpdatedProducts++
+		}
+		job.ProcessedRows += len(p.Rows)
+		job.FailedRows = result.FailedRows()
+
+		if (i+1)%importProgressBatchSize == 0 {
+			ucCatalog.UpdateImportJobProgress(ctx, job)
+		}
+	}
+
+	ucCatalog.FinishImportJob(ctx, job, result.RowErrors, "")
+}
+
+func failProductImportJob(ctx context.Context, svcCtx *svc.ServiceContext, job *model.ProductImportJob, message string) {
+	defer func() {
+		if r := recover(); r != nil {
+			logx.WithContext(ctx).Errorf("产品导入任务%d状态更新失败:%v", job.Id, r)
+		}
+	}()
+	svcCtx.PowerX.ProductCatalog.FinishImportJob(ctx, job, nil, message)
+}
+
+// importCatalogProduct 保存单个产品, 已上架的产品按修订提交审核, SKU和价格直接生效
+func importCatalogProduct(ctx context.Context, svcCtx *svc.ServiceContext, operatorId int64, p *productUC.CatalogProduct) (created bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logx.WithContext(ctx).Errorf("导入产品%s失败:%v", p.SPU, r)
+			err = fmt.Errorf("产品保存失败")
+		}
+	}()
+
+	putRequest := &types.PutProductRequest{
+		Product: types.Product{
+			ProductAttribute: &types.ProductAttribute{},
+		},
+	}
+	isApproved := false
+	if p.Existing != nil {
+		mdlProduct, err := svcCtx.PowerX.Product.GetProduct(ctx, p.Existing.Id)
+		if err != nil {
+			return false, err
+		}
+		isApproved = svcCtx.PowerX.ProductApproval.IsProductApproved(ctx, mdlProduct)
+
+		// 已有待审核修订时在修订的基础上修改, 避免覆盖未审核的内容
+		var revision *model.ProductRevision
+		if isApproved {
+			revision = svcCtx.PowerX.ProductApproval.GetPendingRevision(ctx, mdlProduct.Id)
+		}
+		if revision != nil {
+			if err = json.Unmarshal(revision.Payload, putRequest); err != nil {
+				return false, err
+			}
+		} else {
+			putRequest.Product = *TransformProductToPutRequest(mdlProduct)
+		}
+		putRequest.ProductId = mdlProduct.Id
+	}
+
+	applyCatalogProduct(ctx, svcCtx, &putRequest.Product, p)
+
+	productId := putRequest.ProductId
+	if isApproved {
+		if err = product.CheckProductBillingInterval(ctx, svcCtx, product.TransformRequestToProduct(&putRequest.Product)); err != nil {
+			return false, err
+		}
+		payload, err := json.Marshal(putRequest)
+		if err != nil {
+			return false, err
+		}
+		svcCtx.PowerX.ProductApproval.ReviseProduct(ctx, productId, payload, operatorId)
+	} else {
+		approvalStatus := 0
+		if p.Existing != nil {
+			approvalStatus = p.Existing.ApprovalStatus
+		}
+		mdlProduct, err := product.UpdateProductFromRequest(ctx, svcCtx, putRequest, approvalStatus)
+		if err != nil {
+			return false, err
+		}
+		productId = mdlProduct.Id
+	}
+
+	if err = svcCtx.PowerX.ProductCatalog.SaveCatalogSKUs(ctx, productId, p); err != nil {
+		return false, err
+	}
+	svcCtx.PowerX.ProductSearch.IndexProduct(ctx, productId)
+
+	return p.Existing == nil, nil
+}
+
+// applyCatalogProduct 将导入的值写入产品请求, 文件中未填写的字段保持不变
+func applyCatalogProduct(ctx context.Context, svcCtx *svc.ServiceContext, req *types.Product, p *productUC.CatalogProduct) {
+	ucCatalog := svcCtx.PowerX.ProductCatalog
+
+	req.SPU = p.SPU
+	req.Name = p.Name
+	if p.TypeId > 0 {
+		req.Type = p.TypeId
+	}
+	if p.PlanId > 0 {
+		req.Plan = p.PlanId
+	}
+	if p.Description != nil {
+		req.Description = *p.Description
+	}
+	if p.IsActivated != nil {
+		req.IsActivated = *p.IsActivated
+	}
+	if p.CanSellOnline != nil {
+		req.CanSellOnline = *p.CanSellOnline
+	}
+	if p.SaleStartDate != nil {
+		req.SaleStartDate = productUC.FormatCatalogTime(*p.SaleStartDate)
+	}
+	if p.SaleEndDate != nil {
+		req.SaleEndDate = productUC.FormatCatalogTime(*p.SaleEndDate)
+	}
+	if p.Sort != nil {
+		req.Sort = *p.Sort
+	}
+
+	if len(p.CategoryPaths) > 0 {
+		req.CategoryIds = []int64{}
+		for _, names := range p.CategoryPaths {
+			category := ucCatalog.FindOrCreateCategoryByPath(ctx, names)
+			req.CategoryIds = append(req.CategoryIds, category.Id)
+		}
+	}
+
+	if len(p.CoverImageUrls) > 0 {
+		req.CoverImageIds, req.CoverImageIdSortIndexs = getImageIdsByUrls(ctx, svcCtx, p.CoverImageUrls)
+	}
+	if len(p.DetailImageUrls) > 0 {
+		req.DetailImageIds, req.DetailImageIdSortIndexs = getImageIdsByUrls(ctx, svcCtx, p.DetailImageUrls)
+	}
+}
+
+// getImageIdsByUrls 图片按文件中的顺序排列
+func getImageIdsByUrls(ctx context.Context, svcCtx *svc.ServiceContext, urls []string) ([]int64, []*types.SortIdItem) {
+	ids := []int64{}
+	sortIndexes := []*types.SortIdItem{}
+	for i, resource := range svcCtx.PowerX.ProductCatalog.FindOrCreateMediaResourcesByUrls(ctx, urls) {
+		ids = append(ids, resource.Id)
+		sortIndexes = append(sortIndexes, &types.SortIdItem{Id: resource.Id, SortIndex: i})
+	}
+	return ids, sortIndexes
+}
+
+// TransformProductToPutRequest 将产品转换为修改请求, 用于在现有产品的基础上修改部分字段
+func TransformProductToPutRequest(mdlProduct *model.Product) *types.Product {
+	req := product.TransformProductToReply(mdlProduct)
+	req.SaleStartDate = productUC.FormatCatalogTime(mdlProduct.SaleStartDate)
+	req.SaleEndDate = productUC.FormatCatalogTime(mdlProduct.SaleEndDate)
+	req.ProductAttribute = &types.ProductAttribute{
+		Inventory:  mdlProduct.Inventory,
+		SoldAmount: mdlProduct.SoldAmount,
+		Weight:     mdlProduct.Weight,
+		Volume:     mdlProduct.Volume,
+		Encode:     mdlProduct.Encode,
+		BarCode:    mdlProduct.BarCode,
+		Extra:      string(mdlProduct.Extra),
+	}
+
+	// 以下为展示用的字段, 修改请求中不需要
+	req.ProductCategories = nil
+	req.CoverImages = nil
+	req.DetailImages = nil
+	req.ActivePriceEntry = nil
+	req.PriceBookEntries = nil
+	req.SKUs = nil
+	req.ProductSpecifics = nil
+
+	return req
+}
+
+func TransformProductImportJobToReply(job *model.ProductImportJob) *types.ProductImportJob {
+	rowErrors := []*types.ProductImportRowError{}
+	if len(job.RowErrors) > 0 {
+		_ = json.Unmarshal(job.RowErrors, &rowErrors)
+	}
+
+	reply := &types.ProductImportJob{
+		Id:              job.Id,
+		FileName:        job.FileName,
+		Format:          job.Format,
+		DryRun:          job.DryRun,
+		Status:          job.Status,
+		TotalRows:       job.TotalRows,
+		ProcessedRows:   job.ProcessedRows,
+		FailedRows:      job.FailedRows,
+		CreatedProducts: job.CreatedProducts,
+		UpdatedProducts: job.UpdatedProducts,
+		RowErrors:       rowErrors,
+		Message:         job.Message,
+		OperatorId:      job.OperatorId,
+		CreatedAt:       job.CreatedAt.Format(time.RFC3339),
+	}
+	if job.StartedAt != nil {
+		reply.StartedAt = job.StartedAt.Format(time.RFC3339)
+	}
+	if job.FinishedAt != nil {
+		reply.FinishedAt = job.FinishedAt.Format(time.RFC3339)
+	}
+	return reply
+}
@@ -3,6 +3,8 @@ package category
 import (
 	"PowerX/internal/logic/admin/mediaresource"
 	"PowerX/internal/model/crm/product"
+	"PowerX/internal/model/powermodel"
+	"PowerX/internal/types/errorx"
 	product2 "PowerX/internal/uc/powerx/crm/product"
 	"context"
 
@@ -27,9 +29,14 @@ func NewListProductCategoryTreeLogic(ctx context.Context, svcCtx *svc.ServiceCon
 }
 
 func (l *ListProductCategoryTreeLogic) ListProductCategoryTree(req *types.ListProductCategoryTreeRequest) (resp *types.ListProductCategoryTreeReply, err error) {
+	querySpec, err := powermodel.NewQuerySpec(product.ProductCategoryQueryFields, req.OrderBy, req.Filters)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+
 	option := product2.FindProductCategoryOption{
-		Names:   req.Names,
-		OrderBy: req.OrderBy,
+		Names:     req.Names,
+		QuerySpec: querySpec,
 	}
 
 	// 获取模型类型的列表
@@ -3,14 +3,17 @@ package product
 import (
 	"PowerX/internal/logic/admin/crm/product/category"
 	"PowerX/internal/logic/admin/crm/product/pricebookentry"
+	"PowerX/internal/logic/admin/crm/product/productbundle"
 	"PowerX/internal/logic/admin/mediaresource"
 	"PowerX/internal/model"
 	"PowerX/internal/model/crm/product"
 	"PowerX/internal/model/media"
 	"PowerX/internal/svc"
 	"PowerX/internal/types"
+	"PowerX/internal/types/errorx"
 	"PowerX/internal/uc/powerx"
 	product2 "PowerX/internal/uc/powerx/crm/product"
+	tradeUC "PowerX/internal/uc/powerx/crm/trade"
 	"context"
 	"encoding/json"
 	"github.com/golang-module/carbon/v2"
@@ -36,6 +39,11 @@ func NewCreateProductLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Cre
 func (l *CreateProductLogic) CreateProduct(req *types.CreateProductRequest) (resp *types.CreateProductReply, err error) {
 
 	mdlProduct := TransformRequestToProduct(&(req.Product))
+	// 新建的产品为草稿, 需提交审核通过后才能上架
+	mdlProduct.ApprovalStatus = 0
+	if err = CheckProductBillingInterval(l.ctx, l.svcCtx, mdlProduct); err != nil {
+		return nil, err
+	}
 
 	if len(req.SalesChannelsItemIds) > 0 {
 		salesChannelsItems, err := l.svcCtx.PowerX.DataDictionary.FindAllDictionaryItems(l.ctx, &powerx.FindManyDataDictItemOption{
@@ -91,32 +99,54 @@ func (l *CreateProductLogic) CreateProduct(req *types.CreateProductRequest) (res
 	}
 
 	err = l.svcCtx.PowerX.Product.CreateProduct(l.ctx, mdlProduct)
+	if err == nil {
+		l.svcCtx.PowerX.ProductSearch.IndexProduct(l.ctx, mdlProduct.Id)
+	}
 
 	return &types.CreateProductReply{
 		mdlProduct.Id,
 	}, err
 }
 
+// CheckProductBillingInterval 周期性产品必须设置计费周期, 用于生成订阅
+func CheckProductBillingInterval(ctx context.Context, svcCtx *svc.ServiceContext, mdlProduct *product.Product) error {
+	if mdlProduct.Plan == 0 {
+		return nil
+	}
+	plan, err := svcCtx.PowerX.DataDictionary.GetDataDictionaryItemById(ctx, mdlProduct.Plan)
+	if err != nil || plan.Key != product.ProductPlanPeriod {
+		return nil
+	}
+	if mdlProduct.BillingCycles < 0 {
+		return errorx.WithCause(errorx.ErrBadRequest, "订阅总期数不能小于0")
+	}
+	return tradeUC.ValidateBillingInterval(mdlProduct.BillingIntervalUnit, mdlProduct.BillingIntervalCount)
+}
+
 func TransformRequestToProduct(productRequest *types.Product) (mdlProduct *product.Product) {
 
 	saleStartDate := carbon.Parse(productRequest.SaleStartDate)
 	saleEndDate := carbon.Parse(productRequest.SaleEndDate)
 	mdlProduct = &product.Product{
-		Name:                productRequest.Name,
-		SPU:                 productRequest.SPU,
-		Type:                productRequest.Type,
-		Plan:                productRequest.Plan,
-		AccountingCategory:  productRequest.AccountingCategory,
-		CanSellOnline:       productRequest.CanSellOnline,
-		CanUseForDeduct:     productRequest.CanUseForDeduct,
-		ApprovalStatus:      productRequest.ApprovalStatus,
-		IsActivated:         productRequest.IsActivated,
-		Description:         productRequest.Description,
-		AllowedSellQuantity: productRequest.AllowedSellQuantity,
-		ValidityPeriodDays:  productRequest.ValidityPeriodDays,
-		SaleStartDate:       saleStartDate.ToStdTime(),
-		SaleEndDate:         saleEndDate.ToStdTime(),
-		Sort:                productRequest.Sort,
+		Name:                 productRequest.Name,
+		SPU:                  productRequest.SPU,
+		Type:                 productRequest.Type,
+		Plan:                 productRequest.Plan,
+		AccountingCategory:   productRequest.AccountingCategory,
+		CanSellOnline:        productRequest.CanSellOnline,
+		CanUseForDeduct:      productRequest.CanUseForDeduct,
+		ApprovalStatus:       productRequest.ApprovalStatus,
+		IsActivated:          productRequest.IsActivated,
+		Description:          productRequest.Description,
+		AllowedSellQuantity:  productRequest.AllowedSellQuantity,
+		ValidityPeriodDays:   productRequest.ValidityPeriodDays,
+		BillingIntervalUnit:  productRequest.BillingIntervalUnit,
+		BillingIntervalCount: productRequest.BillingIntervalCount,
+		BillingCycles:        productRequest.BillingCycles,
+		IsBundle:             productRequest.IsBundle,
+		SaleStartDate:        saleStartDate.ToStdTime(),
+		SaleEndDate:          saleEndDate.ToStdTime(),
+		Sort:                 productRequest.Sort,
 		ProductAttribute: product.ProductAttribute{
 			Inventory: productRequest.Inventory,
 			Weight:    productRequest.Weight,
@@ -137,22 +167,26 @@ func TransformProductToReply(mdlProduct *product.Product) (productReply *types.P
 	arrayDetailImageIds, arrayDetailImageIdSortIndexs := media.GetImageIds(mdlProduct.PivotDetailImages)
 
 	return &types.Product{
-		Id:                  mdlProduct.Id,
-		Name:                mdlProduct.Name,
-		SPU:                 mdlProduct.SPU,
-		Type:                mdlProduct.Type,
-		Plan:                mdlProduct.Plan,
-		AccountingCategory:  mdlProduct.AccountingCategory,
-		CanSellOnline:       mdlProduct.CanSellOnline,
-		CanUseForDeduct:     mdlProduct.CanUseForDeduct,
-		ApprovalStatus:      mdlProduct.ApprovalStatus,
-		IsActivated:         mdlProduct.IsActivated,
-		Description:         mdlProduct.Description,
-		AllowedSellQuantity: mdlProduct.AllowedSellQuantity,
-		ValidityPeriodDays:  mdlProduct.ValidityPeriodDays,
-		SaleStartDate:       mdlProduct.SaleStartDate.String(),
-		SaleEndDate:         mdlProduct.SaleEndDate.String(),
-		Sort:                mdlProduct.Sort,
+		Id:                   mdlProduct.Id,
+		Name:                 mdlProduct.Name,
+		SPU:                  mdlProduct.SPU,
+		Type:                 mdlProduct.Type,
+		Plan:                 mdlProduct.Plan,
+		AccountingCategory:   mdlProduct.AccountingCategory,
+		CanSellOnline:        mdlProduct.CanSellOnline,
+		CanUseForDeduct:      mdlProduct.CanUseForDeduct,
+		ApprovalStatus:       mdlProduct.ApprovalStatus,
+		IsActivated:          mdlProduct.IsActivated,
+		Description:          mdlProduct.Description,
+		AllowedSellQuantity:  mdlProduct.AllowedSellQuantity,
+		ValidityPeriodDays:   mdlProduct.ValidityPeriodDays,
+		BillingIntervalUnit:  mdlProduct.BillingIntervalUnit,
+		BillingIntervalCount: mdlProduct.BillingIntervalCount,
+		BillingCycles:        mdlProduct.BillingCycles,
+		IsBundle:             mdlProduct.IsBundle,
+		SaleStartDate:        mdlProduct.SaleStartDate.String(),
+		SaleEndDate:          mdlProduct.SaleEndDate.String(),
+		Sort:                 mdlProduct.Sort,
 		//PivotSalesChannels:   TransformDDsToReply(mdlProduct.PivotSalesChannels),
 		//PivotPromoteChannels: TransformDDsToReply(mdlProduct.PivotPromoteChannels),
 		ProductCategories:       category.TransformProductCategoriesToReply(mdlProduct.ProductCategories),
@@ -163,6 +197,8 @@ func TransformProductToReply(mdlProduct *product.Product) (productReply *types.P
 		ActivePriceEntry:        pricebookentry.TransformPriceEntriesToActivePriceEntryReply(mdlProduct.PriceBookEntries),
 		PriceBookEntries:        pricebookentry.TransformPriceBookEntriesToPriceBookEntriesReply(mdlProduct.PriceBookEntries),
 		SKUs:                    TransformSkusToReply(mdlProduct.SKUs),
+		BundleItems:             productbundle.TransformProductBundleItemsToReply(mdlProduct.BundleItems),
+		BundleAvailableQuantity: product.BundleAvailableQuantity(mdlProduct.BundleItems),
 		CoverImageIds:           arrayCoverImageIds,
 		DetailImageIds:          arrayDetailImageIds,
 		CoverImageIdSortIndexs:  arrayCoverImageIdSortIndexs,
@@ -210,6 +246,7 @@ func TransformSkuToReply(sku *product.SKU) (skuReply *types.SKU) {
 		ListPrice:  listPrice,
 		IsActive:   isActive,
 		OptionsIds: optionsIds,
+		IsRetired:  sku.IsRetired,
 	}
 }
 
@@ -29,6 +29,7 @@ func (l *DeleteProductLogic) DeleteProduct(req *types.DeleteProductRequest) (res
 	if err != nil {
 		return nil, err
 	}
+	l.svcCtx.PowerX.ProductSearch.IndexProduct(l.ctx, req.ProductId)
 
 	return &types.DeleteProductReply{
 		ProductId: req.ProductId,
@@ -29,6 +29,7 @@ func (l *DisableProductLogic) DisableProduct(req *types.DisableProductRequest) (
 	}
 	//fmt.Dump(p)
 	l.svcCtx.PowerX.Product.PatchProduct(l.ctx, req.ProductId, p)
+	l.svcCtx.PowerX.ProductSearch.IndexProduct(l.ctx, req.ProductId)
 
 	return &types.DisableProductReply{
 		ProductId: req.ProductId,
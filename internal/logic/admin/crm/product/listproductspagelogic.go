@@ -2,8 +2,10 @@ package product
 
 import (
 	"PowerX/internal/model/crm/product"
+	"PowerX/internal/model/powermodel"
 	"PowerX/internal/svc"
 	"PowerX/internal/types"
+	"PowerX/internal/types/errorx"
 	productUC "PowerX/internal/uc/powerx/crm/product"
 	"PowerX/pkg/datetime/carbonx"
 	"context"
@@ -27,6 +29,10 @@ func NewListProductsPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *
 }
 
 func (l *ListProductsLogic) ListProductsPage(req *types.ListProductsPageRequest) (resp *types.ListProductsPageReply, err error) {
+	querySpec, err := powermodel.NewQuerySpec(product.ProductQueryFields, req.OrderBy, req.Filters)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
 
 	startAt := carbon.ParseByFormat(req.SalesStartAt, carbonx.DateFormat)
 	endAt := carbon.ParseByFormat(req.SalesEndAt, carbonx.DateFormat)
@@ -46,6 +52,8 @@ func (l *ListProductsLogic) ListProductsPage(req *types.ListProductsPageRequest)
 		NotInTypes:  []int{notInTypeId},
 		Types:       req.ProductTypeIds,
 		CategoryIds: req.ProductCategoryIds,
+		StoreId:     req.StoreId,
+		QuerySpec:   querySpec,
 		PageEmbedOption: types.PageEmbedOption{
 			PageIndex: req.PageIndex,
 			PageSize:  req.PageSize,
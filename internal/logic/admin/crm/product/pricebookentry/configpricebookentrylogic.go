@@ -2,6 +2,7 @@ package pricebookentry
 
 import (
 	"PowerX/internal/model/crm/product"
+	"PowerX/internal/types/errorx"
 	"context"
 	"github.com/kr/pretty"
 	"github.com/pkg/errors"
@@ -39,6 +40,13 @@ func (l *ConfigPriceBookEntryLogic) ConfigPriceBookEntry(req *types.ConfigPriceB
 		return nil, err
 	}
 
+	// 按组件合计定价的组合商品跟随组件价格更新
+	productIds := []int64{}
+	for _, entry := range entries {
+		productIds = append(productIds, entry.ProductId)
+	}
+	l.svcCtx.PowerX.ProductBundle.RefreshBundlesByProducts(l.ctx, productIds)
+
 	return &types.ConfigPriceBookEntryReply{
 		PriceBookEntries: TransformPriceBookEntriesToPriceBookEntriesReply(entries),
 	}, err
@@ -57,6 +65,9 @@ func TransformRequestToPriceBook(entryRequest *types.PriceBookEntry) *product.Pr
 		UnitPrice:   entryRequest.UnitPrice,
 		ListPrice:   entryRequest.ListPrice,
 		IsActive:    entryRequest.IsActive,
+
+		BundlePricing:  entryRequest.BundlePricing,
+		BundleDiscount: entryRequest.BundleDiscount,
 	}
 	entry.UniqueID = entry.GetComposedUniqueID()
 
@@ -70,6 +81,9 @@ func flattenPriceBookEntries(req *types.ConfigPriceBookEntryRequest) (entries []
 		if entry.PriceBookId <= 0 || entry.ProductId <= 0 {
 			return nil, errors.New(pretty.Sprintf("price book entry index: %d is not valid", i))
 		}
+		if !product.IsValidBundlePricing(entry.BundlePricing) || entry.BundleDiscount < 0 {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "组合商品定价方式不正确")
+		}
 		entries = append(entries, TransformRequestToPriceBook(&entry))
 		if len(entry.SKUEntries) > 0 {
 			for j, skuEntry := range entry.SKUEntries {
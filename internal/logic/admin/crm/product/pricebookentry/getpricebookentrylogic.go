@@ -47,17 +47,19 @@ func TransformPriceBookEntryToPriceBookEntryReply(entry *product.PriceBookEntry)
 	//fmt.Dump(entry)
 	discount := CalDiscount(entry.UnitPrice, entry.ListPrice)
 	return &types.PriceBookEntry{
-		PriceBookId:   entry.PriceBookId,
-		ProductId:     entry.ProductId,
-		SkuId:         entry.SkuId,
-		UnitPrice:     entry.UnitPrice,
-		ListPrice:     entry.ListPrice,
-		PriceBookName: priceBookName,
-		ProductName:   productName,
-		SPU:           spu,
-		Discount:      discount,
-		IsActive:      entry.IsActive,
-		PriceConfigs:  TransformPriceConfigToPriceConfigReply(entry.PriceConfigs),
+		PriceBookId:    entry.PriceBookId,
+		ProductId:      entry.ProductId,
+		SkuId:          entry.SkuId,
+		UnitPrice:      entry.UnitPrice,
+		ListPrice:      entry.ListPrice,
+		BundlePricing:  entry.BundlePricing,
+		BundleDiscount: entry.BundleDiscount,
+		PriceBookName:  priceBookName,
+		ProductName:    productName,
+		SPU:            spu,
+		Discount:       discount,
+		IsActive:       entry.IsActive,
+		PriceConfigs:   TransformPriceConfigToPriceConfigReply(entry.PriceConfigs),
 	}
 
 }
@@ -3,6 +3,7 @@ package pricebookentry
 import (
 	"PowerX/internal/model/crm/product"
 	"PowerX/internal/model/powermodel"
+	"PowerX/internal/types/errorx"
 	"context"
 
 	"PowerX/internal/svc"
@@ -36,6 +37,12 @@ func (l *UpdatePriceBookEntryLogic) UpdatePriceBookEntry(req *types.UpdatePriceB
 		UnitPrice:   req.UnitPrice,
 		ListPrice:   req.ListPrice,
 		IsActive:    req.IsActive,
+
+		BundlePricing:  req.BundlePricing,
+		BundleDiscount: req.BundleDiscount,
+	}
+	if !product.IsValidBundlePricing(priceBook.BundlePricing) || priceBook.BundleDiscount < 0 {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "组合商品定价方式不正确")
 	}
 	priceBook.UniqueID = priceBook.GetComposedUniqueID()
 
@@ -43,6 +50,7 @@ func (l *UpdatePriceBookEntryLogic) UpdatePriceBookEntry(req *types.UpdatePriceB
 	if err != nil {
 		return nil, err
 	}
+	l.svcCtx.PowerX.ProductBundle.RefreshBundlesByProducts(l.ctx, []int64{priceBook.ProductId})
 
 	return &types.UpdatePriceBookEntryReply{
 		Id: req.Id,
@@ -0,0 +1,50 @@
+package productbundle
+
+import (
+	"context"
+
+	"PowerX/internal/model/crm/product"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ConfigProductBundleLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewConfigProductBundleLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ConfigProductBundleLogic {
+	return &ConfigProductBundleLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ConfigProductBundleLogic) ConfigProductBundle(req *types.ConfigProductBundleRequest) (resp *types.ConfigProductBundleReply, err error) {
+	items := []*product.ProductBundleItem{}
+	for _, itemRequest := range req.BundleItems {
+		if itemRequest == nil {
+			continue
+		}
+		items = append(items, &product.ProductBundleItem{
+			SkuId:    itemRequest.SkuId,
+			Quantity: itemRequest.Quantity,
+			Sort:     itemRequest.Sort,
+		})
+	}
+
+	items, err = l.svcCtx.PowerX.ProductBundle.ConfigBundleItems(l.ctx, req.ProductId, items)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ConfigProductBundleReply{
+		ProductId:         req.ProductId,
+		BundleItems:       TransformProductBundleItemsToReply(items),
+		AvailableQuantity: product.BundleAvailableQuantity(items),
+	}, nil
+}
@@ -0,0 +1,65 @@
+package productbundle
+
+import (
+	"context"
+
+	"PowerX/internal/model/crm/product"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetProductBundleLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetProductBundleLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetProductBundleLogic {
+	return &GetProductBundleLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetProductBundleLogic) GetProductBundle(req *types.GetProductBundleRequest) (resp *types.GetProductBundleReply, err error) {
+	items := l.svcCtx.PowerX.ProductBundle.GetBundleItems(l.ctx, req.ProductId)
+
+	return &types.GetProductBundleReply{
+		ProductId:         req.ProductId,
+		BundleItems:       TransformProductBundleItemsToReply(items),
+		AvailableQuantity: product.BundleAvailableQuantity(items),
+	}, nil
+}
+
+func TransformProductBundleItemsToReply(items []*product.ProductBundleItem) []*types.ProductBundleItem {
+	itemsReply := []*types.ProductBundleItem{}
+	for _, item := range items {
+		itemsReply = append(itemsReply, TransformProductBundleItemToReply(item))
+	}
+	return itemsReply
+}
+
+func TransformProductBundleItemToReply(item *product.ProductBundleItem) *types.ProductBundleItem {
+	if item == nil {
+		return nil
+	}
+
+	itemReply := &types.ProductBundleItem{
+		Id:        item.Id,
+		ProductId: item.ProductId,
+		SkuId:     item.SkuId,
+		Quantity:  item.Quantity,
+		Sort:      item.Sort,
+	}
+	if item.Product != nil {
+		itemReply.ProductName = item.Product.Name
+	}
+	if item.SKU != nil {
+		itemReply.SkuNo = item.SKU.SkuNo
+		itemReply.Inventory = item.SKU.Inventory
+	}
+	return itemReply
+}
@@ -0,0 +1,41 @@
+package productreview
+
+import (
+	"PowerX/internal/model/crm/product"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ApproveProductReviewLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewApproveProductReviewLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ApproveProductReviewLogic {
+	return &ApproveProductReviewLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ApproveProductReviewLogic) ApproveProductReview(req *types.ApproveProductReviewRequest) (resp *types.ApproveProductReviewReply, err error) {
+	cred, err := l.svcCtx.PowerX.AdminAuthorization.AuthMetadataFromContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := l.svcCtx.PowerX.ProductReview.ModerateProductReview(l.ctx, req.ProductReviewId, product.ProductReviewStatusApproved, "", cred.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ApproveProductReviewReply{
+		ProductReview: TransformProductReviewToReply(review),
+	}, nil
+}
@@ -0,0 +1,82 @@
+package productreview
+
+import (
+	"PowerX/internal/logic/admin/mediaresource"
+	"PowerX/internal/model/crm/product"
+	"context"
+	"time"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetProductReviewLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetProductReviewLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetProductReviewLogic {
+	return &GetProductReviewLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetProductReviewLogic) GetProductReview(req *types.GetProductReviewRequest) (resp *types.GetProductReviewReply, err error) {
+	review, err := l.svcCtx.PowerX.ProductReview.GetProductReview(l.ctx, req.ProductReviewId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetProductReviewReply{
+		ProductReview: TransformProductReviewToReply(review),
+	}, nil
+}
+
+func TransformProductReviewToReply(review *product.ProductReview) *types.ProductReview {
+	if review == nil {
+		return nil
+	}
+
+	images := []*types.MediaResource{}
+	for _, pivot := range review.PivotImages {
+		image := mediaresource.TransformMediaResourceToReply(pivot.MediaResource)
+		if image == nil {
+			continue
+		}
+		image.SortIndex = pivot.Sort
+		images = append(images, image)
+	}
+
+	reply := &types.ProductReview{
+		Id:           review.Id,
+		ProductId:    review.ProductId,
+		SkuId:        review.SkuId,
+		OrderId:      review.OrderId,
+		OrderItemId:  review.OrderItemId,
+		CustomerId:   review.CustomerId,
+		CustomerName: review.CustomerName,
+		IsAnonymous:  review.IsAnonymous,
+		SkuNo:        review.SkuNo,
+		Rating:       review.Rating,
+		Content:      review.Content,
+		Images:       images,
+		Status:       review.Status,
+		HelpfulCount: review.HelpfulCount,
+		HiddenReason: review.HiddenReason,
+		ModeratedBy:  review.ModeratedBy,
+		Reply:        review.Reply,
+		CreatedAt:    review.CreatedAt.Format(time.RFC3339),
+	}
+	if review.ModeratedAt != nil {
+		reply.ModeratedAt = review.ModeratedAt.Format(time.RFC3339)
+	}
+	if review.RepliedAt != nil {
+		reply.RepliedAt = review.RepliedAt.Format(time.RFC3339)
+	}
+	return reply
+}
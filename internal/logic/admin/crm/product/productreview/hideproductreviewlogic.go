@@ -0,0 +1,41 @@
+package productreview
+
+import (
+	"PowerX/internal/model/crm/product"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type HideProductReviewLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewHideProductReviewLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HideProductReviewLogic {
+	return &HideProductReviewLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *HideProductReviewLogic) HideProductReview(req *types.HideProductReviewRequest) (resp *types.HideProductReviewReply, err error) {
+	cred, err := l.svcCtx.PowerX.AdminAuthorization.AuthMetadataFromContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := l.svcCtx.PowerX.ProductReview.ModerateProductReview(l.ctx, req.ProductReviewId, product.ProductReviewStatusHidden, req.HiddenReason, cred.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.HideProductReviewReply{
+		ProductReview: TransformProductReviewToReply(review),
+	}, nil
+}
@@ -0,0 +1,50 @@
+package productreview
+
+import (
+	productUC "PowerX/internal/uc/powerx/crm/product"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListProductReviewsPageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListProductReviewsPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListProductReviewsPageLogic {
+	return &ListProductReviewsPageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListProductReviewsPageLogic) ListProductReviewsPage(req *types.ListProductReviewsPageRequest) (resp *types.ListProductReviewsPageReply, err error) {
+	page := l.svcCtx.PowerX.ProductReview.FindManyProductReviews(l.ctx, &productUC.FindManyProductReviewsOption{
+		ProductId:  req.ProductId,
+		CustomerId: req.CustomerId,
+		Statuses:   req.Statuses,
+		Rating:     req.Rating,
+		SortBy:     req.SortBy,
+		PageEmbedOption: types.PageEmbedOption{
+			PageIndex: req.PageIndex,
+			PageSize:  req.PageSize,
+		},
+	})
+
+	list := []*types.ProductReview{}
+	for _, review := range page.List {
+		list = append(list, TransformProductReviewToReply(review))
+	}
+	return &types.ListProductReviewsPageReply{
+		List:      list,
+		PageIndex: page.PageIndex,
+		PageSize:  page.PageSize,
+		Total:     page.Total,
+	}, nil
+}
@@ -0,0 +1,40 @@
+package productreview
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ReplyProductReviewLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewReplyProductReviewLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ReplyProductReviewLogic {
+	return &ReplyProductReviewLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ReplyProductReviewLogic) ReplyProductReview(req *types.ReplyProductReviewRequest) (resp *types.ReplyProductReviewReply, err error) {
+	cred, err := l.svcCtx.PowerX.AdminAuthorization.AuthMetadataFromContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := l.svcCtx.PowerX.ProductReview.ReplyProductReview(l.ctx, req.ProductReviewId, req.Reply, cred.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ReplyProductReviewReply{
+		ProductReview: TransformProductReviewToReply(review),
+	}, nil
+}
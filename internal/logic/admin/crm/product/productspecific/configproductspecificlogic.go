@@ -3,6 +3,7 @@ package productspecific
 import (
 	product2 "PowerX/internal/model/crm/product"
 	"PowerX/internal/types/errorx"
+	productUC "PowerX/internal/uc/powerx/crm/product"
 	"context"
 
 	"PowerX/internal/svc"
@@ -27,30 +28,81 @@ func NewConfigProductSpecificLogic(ctx context.Context, svcCtx *svc.ServiceConte
 
 func (l *ConfigProductSpecificLogic) ConfigProductSpecific(req *types.ConfigProductSpecificRequest) (resp *types.ConfigProductSpecificReply, err error) {
 
-	specifics := TransformRequestToProductSpecifics(req.ProductSpecifics)
-	options := GetOptionsFromProductSpecificsRequest(specifics)
-	//fmt.Dump(specifics)
-	err = l.svcCtx.PowerX.ProductSpecific.ConfigProductSpecific(l.ctx, specifics, options)
-	//specifics, err = l.svcCtx.PowerX.ProductSpecific.UpsertProductSpecifics(l.ctx, specifics)
+	productId, specifics, err := GetProductSpecificsFromConfigRequest(req)
 	if err != nil {
-		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+		return nil, err
 	}
 
-	// ReFact product skus
-	product, err := l.svcCtx.PowerX.Product.GetProduct(l.ctx, specifics[0].ProductId)
+	diff, err := l.svcCtx.PowerX.Product.ApplySKUMatrix(l.ctx, productId, specifics)
 	if err != nil {
-		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
-	}
-	err = l.svcCtx.PowerX.Product.ReFactSKUs(l.ctx, product)
-	if err != nil {
-		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+		return nil, err
 	}
 
 	return &types.ConfigProductSpecificReply{
-		Result: true,
+		Result:    true,
+		SKUMatrix: TransformSKUMatrixDiffToReply(diff),
 	}, nil
 }
 
+// GetProductSpecificsFromConfigRequest 请求中的规格都属于同一个产品, 未传产品Id时取第一个规格的产品Id
+func GetProductSpecificsFromConfigRequest(req *types.ConfigProductSpecificRequest) (int64, []*product2.ProductSpecific, error) {
+	productId := req.ProductId
+	if productId <= 0 && len(req.ProductSpecifics) > 0 {
+		productId = req.ProductSpecifics[0].ProductId
+	}
+	if productId <= 0 {
+		return 0, nil, errorx.WithCause(errorx.ErrBadRequest, "产品Id不能为空")
+	}
+
+	for i := range req.ProductSpecifics {
+		if req.ProductSpecifics[i].ProductId > 0 && req.ProductSpecifics[i].ProductId != productId {
+			return 0, nil, errorx.WithCause(errorx.ErrBadRequest, "规格不属于同一个产品")
+		}
+		req.ProductSpecifics[i].ProductId = productId
+	}
+
+	specifics := []*product2.ProductSpecific{}
+	for _, specificRequest := range req.ProductSpecifics {
+		specific := TransformRequestToProductSpecific(specificRequest)
+		if specific == nil {
+			return 0, nil, errorx.WithCause(errorx.ErrBadRequest, "规格名称不能为空")
+		}
+		specifics = append(specifics, specific)
+	}
+	return productId, specifics, nil
+}
+
+func TransformSKUMatrixDiffToReply(diff *productUC.SKUMatrixDiff) *types.SKUMatrixDiff {
+	if diff == nil {
+		return nil
+	}
+	return &types.SKUMatrixDiff{
+		Added:         TransformSKUMatrixCombinationsToReply(diff.Added),
+		Unchanged:     TransformSKUMatrixCombinationsToReply(diff.Unchanged),
+		Updated:       TransformSKUMatrixCombinationsToReply(diff.Updated),
+		Removed:       TransformSKUMatrixCombinationsToReply(diff.Removed),
+		BlockedSkuNos: diff.BlockedSkuNos(),
+	}
+}
+
+func TransformSKUMatrixCombinationsToReply(combinations []*productUC.SKUMatrixCombination) []*types.SKUMatrixItem {
+	items := []*types.SKUMatrixItem{}
+	for _, combination := range combinations {
+		item := &types.SKUMatrixItem{
+			OptionIds:      combination.OptionIds,
+			OptionNames:    combination.OptionNames,
+			OpenOrderCount: combination.OpenOrderCount,
+		}
+		if combination.SKU != nil {
+			item.SkuId = combination.SKU.Id
+			item.SkuNo = combination.SKU.SkuNo
+			item.Inventory = combination.SKU.Inventory
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
 func TransformRequestToProductSpecifics(specificsRequest []types.ProductSpecific) []*product2.ProductSpecific {
 
 	specifics := []*product2.ProductSpecific{}
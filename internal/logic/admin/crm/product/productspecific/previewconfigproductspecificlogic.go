@@ -0,0 +1,40 @@
+package productspecific
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type PreviewConfigProductSpecificLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewPreviewConfigProductSpecificLogic(ctx context.Context, svcCtx *svc.ServiceContext) *PreviewConfigProductSpecificLogic {
+	return &PreviewConfigProductSpecificLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *PreviewConfigProductSpecificLogic) PreviewConfigProductSpecific(req *types.ConfigProductSpecificRequest) (resp *types.PreviewConfigProductSpecificReply, err error) {
+	productId, specifics, err := GetProductSpecificsFromConfigRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := l.svcCtx.PowerX.Product.PreviewSKUMatrix(l.ctx, productId, specifics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.PreviewConfigProductSpecificReply{
+		SKUMatrixDiff: TransformSKUMatrixDiffToReply(diff),
+	}, nil
+}
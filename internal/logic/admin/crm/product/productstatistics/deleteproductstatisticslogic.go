@@ -24,7 +24,12 @@ func NewDeleteProductStatisticsLogic(ctx context.Context, svcCtx *svc.ServiceCon
 }
 
 func (l *DeleteProductStatisticsLogic) DeleteProductStatistics(req *types.DeleteProductStatisticsRequest) (resp *types.DeleteProductStatisticsReply, err error) {
-	// todo: add your logic here and delete this line
+	err = l.svcCtx.PowerX.ProductStatistics.DeleteProductStatistics(l.ctx, req.ProductStatisticsId)
+	if err != nil {
+		return nil, err
+	}
 
-	return
+	return &types.DeleteProductStatisticsReply{
+		ProductStatisticsId: req.ProductStatisticsId,
+	}, nil
 }
@@ -46,5 +46,7 @@ func TransformProductStatisticsToReply(specific *product2.ProductStatistics) (sp
 		BaseSoldAmount:        specific.BaseSoldAmount,
 		BaseInventoryQuantity: specific.BaseInventoryQuantity,
 		BaseViewCount:         specific.BaseViewCount,
+		ReviewCount:           specific.ReviewCount,
+		AverageRating:         specific.AverageRating,
 	}
 }
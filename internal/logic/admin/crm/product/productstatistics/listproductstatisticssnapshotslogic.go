@@ -0,0 +1,65 @@
+package productstatistics
+
+import (
+	"PowerX/pkg/datetime/carbonx"
+	"context"
+	"github.com/golang-module/carbon/v2"
+	"time"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListProductStatisticsSnapshotsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListProductStatisticsSnapshotsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListProductStatisticsSnapshotsLogic {
+	return &ListProductStatisticsSnapshotsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListProductStatisticsSnapshotsLogic) ListProductStatisticsSnapshots(req *types.ListProductStatisticsSnapshotsRequest) (resp *types.ListProductStatisticsSnapshotsReply, err error) {
+	statistics, err := l.svcCtx.PowerX.ProductStatistics.GetProductStatisticsByProductId(l.ctx, req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+
+	var startDate, endDate time.Time
+	if req.StartDate != "" {
+		startDate = carbon.ParseByFormat(req.StartDate, carbonx.DateFormat).ToStdTime()
+	}
+	if req.EndDate != "" {
+		endDate = carbon.ParseByFormat(req.EndDate, carbonx.DateFormat).ToStdTime()
+	}
+	snapshots := l.svcCtx.PowerX.ProductStatistics.FindProductStatisticsSnapshots(l.ctx, req.ProductId, startDate, endDate)
+
+	list := []*types.ProductStatisticsSnapshot{}
+	for _, snapshot := range snapshots {
+		list = append(list, &types.ProductStatisticsSnapshot{
+			SnapshotDate:      snapshot.SnapshotDate.Format(carbonx.GoDateFormat),
+			SoldAmount:        snapshot.SoldAmount,
+			InventoryQuantity: snapshot.InventoryQuantity,
+			ViewCount:         snapshot.ViewCount,
+			ReviewCount:       snapshot.ReviewCount,
+			AverageRating:     snapshot.AverageRating,
+			DailySoldAmount:   snapshot.DailySoldAmount,
+			DailyViewCount:    snapshot.DailyViewCount,
+		})
+	}
+
+	return &types.ListProductStatisticsSnapshotsReply{
+		ProductId:             req.ProductId,
+		BaseSoldAmount:        statistics.BaseSoldAmount,
+		BaseInventoryQuantity: statistics.BaseInventoryQuantity,
+		BaseViewCount:         statistics.BaseViewCount,
+		List:                  list,
+	}, nil
+}
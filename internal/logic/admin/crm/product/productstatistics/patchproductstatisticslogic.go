@@ -24,7 +24,15 @@ func NewPatchProductStatisticsLogic(ctx context.Context, svcCtx *svc.ServiceCont
 }
 
 func (l *PatchProductStatisticsLogic) PatchProductStatistics(req *types.PatchProductStatisticsRequest) (resp *types.PatchProductStatisticsReply, err error) {
-	// todo: add your logic here and delete this line
+	// 只更新请求中非零的展示偏移量
+	l.svcCtx.PowerX.ProductStatistics.PatchProductStatistics(l.ctx, req.ProductStatisticsId, TransformRequestToProductStaticstics(&req.ProductStatistics))
 
-	return
+	statistics, err := l.svcCtx.PowerX.ProductStatistics.GetProductStatistics(l.ctx, req.ProductStatisticsId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.PatchProductStatisticsReply{
+		ProductStatistics: TransformProductStatisticsToReply(statistics),
+	}, nil
 }
@@ -24,7 +24,12 @@ func NewPutProductStatisticsLogic(ctx context.Context, svcCtx *svc.ServiceContex
 }
 
 func (l *PutProductStatisticsLogic) PutProductStatistics(req *types.PutProductStatisticsRequest) (resp *types.PutProductStatisticsReply, err error) {
-	// todo: add your logic here and delete this line
+	statistics, err := l.svcCtx.PowerX.ProductStatistics.UpdateProductStatisticsBase(l.ctx, req.ProductStatisticsId, TransformRequestToProductStaticstics(&req.ProductStatistics))
+	if err != nil {
+		return nil, err
+	}
 
-	return
+	return &types.PutProductStatisticsReply{
+		ProductStatistics: TransformProductStatisticsToReply(statistics),
+	}, nil
 }
@@ -0,0 +1,37 @@
+package productstatistics
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SyncProductStatisticsInventoryLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSyncProductStatisticsInventoryLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SyncProductStatisticsInventoryLogic {
+	return &SyncProductStatisticsInventoryLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *SyncProductStatisticsInventoryLogic) SyncProductStatisticsInventory(req *types.SyncProductStatisticsInventoryRequest) (resp *types.SyncProductStatisticsInventoryReply, err error) {
+	l.svcCtx.PowerX.ProductStatistics.SyncProductInventories(l.ctx, []int64{req.ProductId})
+
+	statistics, err := l.svcCtx.PowerX.ProductStatistics.GetProductStatisticsByProductId(l.ctx, req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.SyncProductStatisticsInventoryReply{
+		ProductStatistics: TransformProductStatisticsToReply(statistics),
+	}, nil
+}
@@ -2,12 +2,14 @@ package product
 
 import (
 	"PowerX/internal/model"
+	"PowerX/internal/model/crm/product"
 	"PowerX/internal/model/media"
 	"PowerX/internal/svc"
 	"PowerX/internal/types"
 	"PowerX/internal/uc/powerx"
 	product2 "PowerX/internal/uc/powerx/crm/product"
 	"context"
+	"encoding/json"
 
 	"github.com/zeromicro/go-zero/core/logx"
 )
@@ -28,12 +30,57 @@ func NewPutProductLogic(ctx context.Context, svcCtx *svc.ServiceContext) *PutPro
 
 func (l *PutProductLogic) PutProduct(req *types.PutProductRequest) (resp *types.PutProductReply, err error) {
 
-	mdlProduct := TransformRequestToProduct(&(req.Product))
+	currentProduct, err := l.svcCtx.PowerX.Product.GetProduct(l.ctx, req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 已上架的产品不直接修改, 保存为待审核修订, 审核通过后再更新线上产品
+	if l.svcCtx.PowerX.ProductApproval.IsProductApproved(l.ctx, currentProduct) {
+		if err = CheckProductBillingInterval(l.ctx, l.svcCtx, TransformRequestToProduct(&(req.Product))); err != nil {
+			return nil, err
+		}
+		cred, err := l.svcCtx.PowerX.AdminAuthorization.AuthMetadataFromContext(l.ctx)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := json.Marshal(req)
+		if err != nil {
+			return nil, err
+		}
+		revision := l.svcCtx.PowerX.ProductApproval.ReviseProduct(l.ctx, req.ProductId, payload, cred.UID)
+
+		return &types.PutProductReply{
+			Product:           TransformProductToReply(currentProduct),
+			PendingRevisionId: revision.Id,
+		}, nil
+	}
+
+	mdlProduct, err := UpdateProductFromRequest(l.ctx, l.svcCtx, req, currentProduct.ApprovalStatus)
+	if err != nil {
+		return nil, err
+	}
+	l.svcCtx.PowerX.ProductSearch.IndexProduct(l.ctx, mdlProduct.Id)
+
+	return &types.PutProductReply{
+		Product: TransformProductToReply(mdlProduct),
+	}, nil
+
+}
+
+// UpdateProductFromRequest 按请求内容更新产品及其关联对象, 审核状态由调用方决定, 不使用请求中的值
+func UpdateProductFromRequest(ctx context.Context, svcCtx *svc.ServiceContext, req *types.PutProductRequest, approvalStatus int) (mdlProduct *product.Product, err error) {
+
+	mdlProduct = TransformRequestToProduct(&(req.Product))
 	mdlProduct.Id = req.ProductId
+	mdlProduct.ApprovalStatus = approvalStatus
+	if err = CheckProductBillingInterval(ctx, svcCtx, mdlProduct); err != nil {
+		return nil, err
+	}
 
 	// 处理销售渠道
 	if len(req.SalesChannelsItemIds) > 0 {
-		salesChannelsItems, err := l.svcCtx.PowerX.DataDictionary.FindAllDictionaryItems(l.ctx, &powerx.FindManyDataDictItemOption{
+		salesChannelsItems, err := svcCtx.PowerX.DataDictionary.FindAllDictionaryItems(ctx, &powerx.FindManyDataDictItemOption{
 			Ids: req.SalesChannelsItemIds,
 		})
 		if err != nil {
@@ -45,7 +92,7 @@ func (l *PutProductLogic) PutProduct(req *types.PutProductRequest) (resp *types.
 
 	// 处理推广渠道
 	if len(req.PromoteChannelsItemIds) > 0 {
-		promoteChannelsItems, err := l.svcCtx.PowerX.DataDictionary.FindAllDictionaryItems(l.ctx, &powerx.FindManyDataDictItemOption{
+		promoteChannelsItems, err := svcCtx.PowerX.DataDictionary.FindAllDictionaryItems(ctx, &powerx.FindManyDataDictItemOption{
 			Ids: req.PromoteChannelsItemIds,
 		})
 		if err != nil {
@@ -56,14 +103,14 @@ func (l *PutProductLogic) PutProduct(req *types.PutProductRequest) (resp *types.
 
 	// 处理产品品类
 	if len(req.CategoryIds) > 0 {
-		productCategories := l.svcCtx.PowerX.ProductCategory.FindAllProductCategories(l.ctx, &product2.FindProductCategoryOption{
+		productCategories := svcCtx.PowerX.ProductCategory.FindAllProductCategories(ctx, &product2.FindProductCategoryOption{
 			Ids: req.CategoryIds,
 		})
 		mdlProduct.ProductCategories = productCategories
 	}
 
 	if len(req.CoverImageIds) > 0 {
-		mediaResources, err := l.svcCtx.PowerX.MediaResource.FindAllMediaResources(l.ctx, &powerx.FindManyMediaResourcesOption{
+		mediaResources, err := svcCtx.PowerX.MediaResource.FindAllMediaResources(ctx, &powerx.FindManyMediaResourcesOption{
 			Ids: req.CoverImageIds,
 		})
 		if err != nil {
@@ -77,7 +124,7 @@ func (l *PutProductLogic) PutProduct(req *types.PutProductRequest) (resp *types.
 
 	if len(req.DetailImageIds) > 0 {
 		// 查询相关的MediaResource
-		mediaResources, err := l.svcCtx.PowerX.MediaResource.FindAllMediaResources(l.ctx, &powerx.FindManyMediaResourcesOption{
+		mediaResources, err := svcCtx.PowerX.MediaResource.FindAllMediaResources(ctx, &powerx.FindManyMediaResourcesOption{
 			Ids: req.DetailImageIds,
 		})
 		if err != nil {
@@ -93,13 +140,5 @@ func (l *PutProductLogic) PutProduct(req *types.PutProductRequest) (resp *types.
 	}
 
 	// 更新产品对象
-	mdlProduct, err = l.svcCtx.PowerX.Product.UpsertProduct(l.ctx, mdlProduct)
-	if err != nil {
-		return nil, err
-	}
-
-	return &types.PutProductReply{
-		Product: TransformProductToReply(mdlProduct),
-	}, nil
-
+	return svcCtx.PowerX.Product.UpsertProduct(ctx, mdlProduct)
 }
@@ -0,0 +1,32 @@
+package search
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RebuildProductSearchIndexLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRebuildProductSearchIndexLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RebuildProductSearchIndexLogic {
+	return &RebuildProductSearchIndexLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RebuildProductSearchIndexLogic) RebuildProductSearchIndex(req *types.RebuildProductSearchIndexRequest) (resp *types.RebuildProductSearchIndexReply, err error) {
+	count := l.svcCtx.PowerX.ProductSearch.RebuildIndex(l.ctx)
+
+	return &types.RebuildProductSearchIndexReply{
+		Count: count,
+	}, nil
+}
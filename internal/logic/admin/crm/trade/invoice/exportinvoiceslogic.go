@@ -0,0 +1,174 @@
+package invoice
+
+import (
+	"PowerX/internal/logic/admin/crm/trade/order"
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+	"PowerX/internal/types/errorx"
+	tradeUC "PowerX/internal/uc/powerx/crm/trade"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"github.com/golang-module/carbon/v2"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var invoiceExportHeaders = []string{
+	"申请单号",
+	"订单号",
+	"发票种类",
+	"冲销蓝字发票号码",
+	"抬头类型",
+	"发票抬头",
+	"纳税人识别号",
+	"接收邮箱",
+	"开票金额",
+	"已红冲金额",
+	"状态",
+	"发票代码",
+	"发票号码",
+	"开票时间",
+	"发票PDF",
+	"驳回原因",
+	"备注",
+	"申请时间",
+}
+
+var invoiceKindNames = map[string]string{
+	trade.InvoiceKindBlue: "蓝字",
+	trade.InvoiceKindRed:  "红字",
+}
+
+var invoiceTitleTypeNames = map[string]string{
+	trade.InvoiceTitleTypePersonal: "个人",
+	trade.InvoiceTitleTypeCompany:  "单位",
+}
+
+var invoiceStatusNames = map[string]string{
+	trade.InvoiceStatusPending:   "待开票",
+	trade.InvoiceStatusIssued:    "已开票",
+	trade.InvoiceStatusRejected:  "已驳回",
+	trade.InvoiceStatusCancelled: "已撤销",
+	trade.InvoiceStatusReversed:  "已红冲",
+}
+
+type ExportInvoicesLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewExportInvoicesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ExportInvoicesLogic {
+	return &ExportInvoicesLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// ExportInvoices 按申请时间导出开票申请, 供财务在开票系统中批量开具
+func (l *ExportInvoicesLogic) ExportInvoices(ctx context.Context, req *types.ExportInvoicesRequest) (resp *types.ExportInvoicesReply, err error) {
+
+	startAt := carbon.Parse(req.StartAt).ToStdTime()
+	endAt := carbon.Parse(req.EndAt).ToStdTime()
+	if err = order.CheckTimeRangeIsValid(startAt, endAt); err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+
+	invoices, err := l.svcCtx.PowerX.Invoice.FindAllInvoices(l.ctx, &tradeUC.FindManyInvoicesOption{
+		Kind:     req.Kind,
+		Statuses: req.Statuses,
+		StartAt:  startAt,
+		EndAt:    endAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 红字发票需要对应的蓝字发票号码
+	blueNumbers := map[int64]string{}
+	for _, invoice := range invoices {
+		if invoice.Kind == trade.InvoiceKindBlue {
+			blueNumbers[invoice.Id] = invoice.InvoiceNumber
+		}
+	}
+	for _, invoice := range invoices {
+		if invoice.Kind != trade.InvoiceKindRed {
+			continue
+		}
+		if _, ok := blueNumbers[invoice.BlueInvoiceId]; !ok {
+			blue, err := l.svcCtx.PowerX.Invoice.GetInvoice(l.ctx, invoice.BlueInvoiceId)
+			if err == nil {
+				blueNumbers[blue.Id] = blue.InvoiceNumber
+			}
+		}
+	}
+
+	buffer := &bytes.Buffer{}
+	// 写入BOM, Excel打开时不乱码
+	buffer.WriteString("\xEF\xBB\xBF")
+	writer := csv.NewWriter(buffer)
+	if err = writer.Write(invoiceExportHeaders); err != nil {
+		return nil, err
+	}
+	for _, invoice := range invoices {
+		if err = writer.Write(transformRowByInvoice(invoice, blueNumbers[invoice.BlueInvoiceId])); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		return nil, err
+	}
+
+	content := buffer.Bytes()
+	return &types.ExportInvoicesReply{
+		Content:  content,
+		FileName: "invoices_" + startAt.Format("20060102") + "_to_" + endAt.Format("20060102") + ".csv",
+		FileSize: len(content),
+		FileType: "text/csv",
+	}, nil
+}
+
+func transformRowByInvoice(invoice *trade.Invoice, blueInvoiceNumber string) []string {
+	orderNumber := ""
+	if invoice.Order != nil {
+		orderNumber = invoice.Order.OrderNumber
+	}
+	issuedAt := ""
+	if invoice.IssuedAt != nil {
+		issuedAt = invoice.IssuedAt.Format(time.DateTime)
+	}
+	pdfUrl := ""
+	if invoice.PdfResource != nil {
+		pdfUrl = invoice.PdfResource.Url
+	}
+	if invoice.Kind != trade.InvoiceKindRed {
+		blueInvoiceNumber = ""
+	}
+
+	return []string{
+		invoice.RequestNumber,
+		orderNumber,
+		invoiceKindNames[invoice.Kind],
+		blueInvoiceNumber,
+		invoiceTitleTypeNames[invoice.TitleType],
+		invoice.Title,
+		invoice.TaxIdNumber,
+		invoice.Email,
+		fmt.Sprintf("%.2f", invoice.Amount),
+		fmt.Sprintf("%.2f", invoice.ReversedAmount),
+		invoiceStatusNames[invoice.Status],
+		invoice.InvoiceCode,
+		invoice.InvoiceNumber,
+		issuedAt,
+		pdfUrl,
+		invoice.RejectReason,
+		invoice.Remark,
+		invoice.CreatedAt.Format(time.DateTime),
+	}
+}
@@ -0,0 +1,69 @@
+package invoice
+
+import (
+	"PowerX/internal/logic/admin/mediaresource"
+	"PowerX/internal/model/crm/trade"
+	"context"
+	"time"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetInvoiceLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetInvoiceLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetInvoiceLogic {
+	return &GetInvoiceLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetInvoiceLogic) GetInvoice(req *types.GetInvoiceRequest) (resp *types.GetInvoiceReply, err error) {
+	invoice, err := l.svcCtx.PowerX.Invoice.GetInvoice(l.ctx, req.InvoiceId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetInvoiceReply{
+		Invoice: TransformInvoiceToReply(invoice),
+	}, nil
+}
+
+func TransformInvoiceToReply(invoice *trade.Invoice) *types.Invoice {
+	reply := &types.Invoice{
+		Id:             invoice.Id,
+		OrderId:        invoice.OrderId,
+		CustomerId:     invoice.CustomerId,
+		RequestNumber:  invoice.RequestNumber,
+		Kind:           invoice.Kind,
+		BlueInvoiceId:  invoice.BlueInvoiceId,
+		TitleType:      invoice.TitleType,
+		Title:          invoice.Title,
+		TaxIdNumber:    invoice.TaxIdNumber,
+		Email:          invoice.Email,
+		Amount:         invoice.Amount,
+		ReversedAmount: invoice.ReversedAmount,
+		Status:         invoice.Status,
+		InvoiceCode:    invoice.InvoiceCode,
+		InvoiceNumber:  invoice.InvoiceNumber,
+		PdfResource:    mediaresource.TransformMediaResourceToReply(invoice.PdfResource),
+		RejectReason:   invoice.RejectReason,
+		Remark:         invoice.Remark,
+		CreatedAt:      invoice.CreatedAt.Format(time.RFC3339),
+	}
+	if invoice.Order != nil {
+		reply.OrderNumber = invoice.Order.OrderNumber
+	}
+	if invoice.IssuedAt != nil {
+		reply.IssuedAt = invoice.IssuedAt.Format(time.RFC3339)
+	}
+	return reply
+}
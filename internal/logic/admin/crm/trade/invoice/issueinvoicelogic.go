@@ -0,0 +1,58 @@
+package invoice
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/types/errorx"
+	"context"
+	"github.com/golang-module/carbon/v2"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type IssueInvoiceLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewIssueInvoiceLogic(ctx context.Context, svcCtx *svc.ServiceContext) *IssueInvoiceLogic {
+	return &IssueInvoiceLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *IssueInvoiceLogic) IssueInvoice(req *types.IssueInvoiceRequest) (resp *types.IssueInvoiceReply, err error) {
+	cred, err := l.svcCtx.PowerX.AdminAuthorization.AuthMetadataFromContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	issued := &trade.Invoice{
+		InvoiceCode:   req.InvoiceCode,
+		InvoiceNumber: req.InvoiceNumber,
+		PdfResourceId: req.PdfResourceId,
+		Remark:        req.Remark,
+	}
+	if req.IssuedAt != "" {
+		issuedAt := carbon.Parse(req.IssuedAt)
+		if issuedAt.Error != nil {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "开票时间格式不正确")
+		}
+		stdIssuedAt := issuedAt.ToStdTime()
+		issued.IssuedAt = &stdIssuedAt
+	}
+
+	invoice, err := l.svcCtx.PowerX.Invoice.IssueInvoice(l.ctx, req.InvoiceId, issued, cred.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.IssueInvoiceReply{
+		Invoice: TransformInvoiceToReply(invoice),
+	}, nil
+}
@@ -0,0 +1,61 @@
+package invoice
+
+import (
+	tradeUC "PowerX/internal/uc/powerx/crm/trade"
+	"context"
+	"github.com/golang-module/carbon/v2"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListInvoicesPageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListInvoicesPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListInvoicesPageLogic {
+	return &ListInvoicesPageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListInvoicesPageLogic) ListInvoicesPage(req *types.ListInvoicesPageRequest) (resp *types.ListInvoicesPageReply, err error) {
+	opt := &tradeUC.FindManyInvoicesOption{
+		OrderId:       req.OrderId,
+		Kind:          req.Kind,
+		Statuses:      req.Statuses,
+		RequestNumber: req.RequestNumber,
+		PageEmbedOption: types.PageEmbedOption{
+			PageIndex: req.PageIndex,
+			PageSize:  req.PageSize,
+		},
+	}
+	if req.StartAt != "" {
+		opt.StartAt = carbon.Parse(req.StartAt).ToStdTime()
+	}
+	if req.EndAt != "" {
+		opt.EndAt = carbon.Parse(req.EndAt).ToStdTime()
+	}
+
+	page, err := l.svcCtx.PowerX.Invoice.FindManyInvoices(l.ctx, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*types.Invoice{}
+	for _, invoice := range page.List {
+		list = append(list, TransformInvoiceToReply(invoice))
+	}
+	return &types.ListInvoicesPageReply{
+		List:      list,
+		PageIndex: page.PageIndex,
+		PageSize:  page.PageSize,
+		Total:     page.Total,
+	}, nil
+}
@@ -0,0 +1,45 @@
+package invoice
+
+import (
+	"PowerX/internal/types/errorx"
+	"context"
+	"strings"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RejectInvoiceLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRejectInvoiceLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RejectInvoiceLogic {
+	return &RejectInvoiceLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RejectInvoiceLogic) RejectInvoice(req *types.RejectInvoiceRequest) (resp *types.RejectInvoiceReply, err error) {
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "请填写驳回原因")
+	}
+	cred, err := l.svcCtx.PowerX.AdminAuthorization.AuthMetadataFromContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice, err := l.svcCtx.PowerX.Invoice.RejectInvoice(l.ctx, req.InvoiceId, cred.UID, strings.TrimSpace(req.Reason))
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.RejectInvoiceReply{
+		Invoice: TransformInvoiceToReply(invoice),
+	}, nil
+}
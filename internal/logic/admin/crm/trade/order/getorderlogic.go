@@ -42,20 +42,24 @@ func (l *GetOrderLogic) GetOrder(req *types.GetOrderRequest) (resp *types.GetOrd
 func TransformOrderToReply(mdlOrder *trade.Order) (orderReply *types.Order) {
 
 	return &types.Order{
-		Id:          mdlOrder.Id,
-		CustomerId:  mdlOrder.CustomerId,
-		PaymentType: mdlOrder.PaymentType,
-		Type:        mdlOrder.Type,
-		Status:      mdlOrder.Status,
-		OrderNumber: mdlOrder.OrderNumber,
-		Discount:    mdlOrder.Discount,
-		ListPrice:   mdlOrder.ListPrice,
-		UnitPrice:   mdlOrder.UnitPrice,
-		Comment:     mdlOrder.Comment,
-		OrderItems:  TransformOrderItemsToOrderItemsReply(mdlOrder.Items),
-		Payments:    payment.TransformPaymentsToReply(mdlOrder.Payments),
-		Logistics:   TransformLogisticsToReply(mdlOrder.Logistics),
-		CreatedAt:   mdlOrder.CreatedAt.String(),
+		Id:                mdlOrder.Id,
+		CustomerId:        mdlOrder.CustomerId,
+		StoreId:           mdlOrder.StoreId,
+		SubscriptionId:    mdlOrder.SubscriptionId,
+		PaymentType:       mdlOrder.PaymentType,
+		Type:              mdlOrder.Type,
+		Status:            mdlOrder.Status,
+		OrderNumber:       mdlOrder.OrderNumber,
+		Discount:          mdlOrder.Discount,
+		ListPrice:         mdlOrder.ListPrice,
+		UnitPrice:         mdlOrder.UnitPrice,
+		PaidAmount:        mdlOrder.PaidAmount,
+		OutstandingAmount: mdlOrder.GetOutstandingAmount(),
+		Comment:           mdlOrder.Comment,
+		OrderItems:        TransformOrderItemsToOrderItemsReply(mdlOrder.Items),
+		Payments:          payment.TransformPaymentsToReply(mdlOrder.Payments),
+		Logistics:         TransformLogisticsToReply(mdlOrder.Logistics),
+		CreatedAt:         mdlOrder.CreatedAt.String(),
 	}
 
 }
@@ -83,9 +87,27 @@ func TransformOrderItemToReply(orderItem *trade.OrderItem) (orderItemReply *type
 		ListPrice:   orderItem.ListPrice,
 		Quantity:    orderItem.Quantity,
 		CoverImage:  mediaresource.TransformMediaResourceToReply(orderItem.CoverImage),
+		Components:  TransformOrderItemComponentsToReply(orderItem.Components),
 	}
 }
 
+func TransformOrderItemComponentsToReply(components []*trade.OrderItemComponent) (componentsReply []*types.OrderItemComponent) {
+	componentsReply = []*types.OrderItemComponent{}
+	for _, component := range components {
+		componentsReply = append(componentsReply, &types.OrderItemComponent{
+			Id:              component.Id,
+			OrderItemId:     component.OrderItemId,
+			ProductId:       component.ProductId,
+			SkuId:           component.SkuId,
+			ProductName:     component.ProductName,
+			SkuNo:           component.SkuNo,
+			Quantity:        component.Quantity,
+			AllocatedAmount: component.AllocatedAmount,
+		})
+	}
+	return componentsReply
+}
+
 func TransformLogisticsToReply(logistics *trade.Logistics) *types.Logistics {
 	if logistics == nil {
 		return nil
@@ -2,6 +2,7 @@ package order
 
 import (
 	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/model/wechat"
 	"PowerX/internal/types/errorx"
 	trade2 "PowerX/internal/uc/powerx/crm/trade"
 	"context"
@@ -158,6 +159,7 @@ func (l *ImportOrdersLogic) processOrders() {
 					l.OrdersFailed = append(l.OrdersFailed, order)
 				} else {
 					l.OrdersSucceeded = append(l.OrdersSucceeded, order)
+					l.svcCtx.PowerX.WechatNotification.NotifyOrderEvent(wechat.NotificationEventOrderShipped, order.Id, nil)
 				}
 			}
 		}(i)
@@ -2,6 +2,8 @@ package order
 
 import (
 	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/model/powermodel"
+	"PowerX/internal/types/errorx"
 	tradeUC "PowerX/internal/uc/powerx/crm/trade"
 	"PowerX/pkg/datetime/carbonx"
 	"context"
@@ -28,6 +30,11 @@ func NewListOrdersPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Li
 }
 
 func (l *ListOrdersPageLogic) ListOrdersPage(req *types.ListOrdersPageRequest) (resp *types.ListOrdersPageReply, err error) {
+	querySpec, err := powermodel.NewQuerySpec(trade.OrderQueryFields, req.OrderBy, req.Filters)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+
 	startAt := carbon.ParseByFormat(req.StartAt, carbonx.DateFormat)
 	endAt := carbon.ParseByFormat(req.EndAt, carbonx.DateFormat)
 	if !startAt.IsZero() && endAt.IsZero() {
@@ -38,11 +45,13 @@ func (l *ListOrdersPageLogic) ListOrdersPage(req *types.ListOrdersPageRequest) (
 	//fmt.Dump(startAt.String(), endAt.String())
 
 	page, err := l.svcCtx.PowerX.Order.FindManyOrders(l.ctx, &tradeUC.FindManyOrdersOption{
-		StartAt:  startAt.ToStdTime(),
-		EndAt:    endAt.ToStdTime(),
-		LikeName: req.Name,
-		Status:   req.StatusIds,
-		Type:     req.TypeIds,
+		StartAt:   startAt.ToStdTime(),
+		EndAt:     endAt.ToStdTime(),
+		LikeName:  req.Name,
+		Status:    req.StatusIds,
+		Type:      req.TypeIds,
+		StoreIds:  req.StoreIds,
+		QuerySpec: querySpec,
 		PageEmbedOption: types.PageEmbedOption{
 			PageIndex: req.PageIndex,
 			PageSize:  req.PageSize,
@@ -0,0 +1,44 @@
+package payment
+
+import (
+	"PowerX/internal/types/errorx"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ClosePaymentLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewClosePaymentLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ClosePaymentLogic {
+	return &ClosePaymentLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ClosePaymentLogic) ClosePayment(req *types.ClosePaymentRequest) (resp *types.ClosePaymentReply, err error) {
+	payment, err := l.svcCtx.PowerX.Payment.GetPayment(l.ctx, req.PaymentId)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err = l.svcCtx.PowerX.Payment.ClosePayment(l.ctx, payment)
+	if err != nil {
+		if _, ok := err.(*errorx.Error); ok {
+			return nil, err
+		}
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "关闭支付单失败:"+err.Error())
+	}
+
+	return &types.ClosePaymentReply{
+		Payment: TransformPaymentToReply(payment),
+	}, nil
+}
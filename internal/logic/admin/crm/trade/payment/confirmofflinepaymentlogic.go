@@ -0,0 +1,51 @@
+package payment
+
+import (
+	"PowerX/internal/model/wechat"
+	"PowerX/internal/types/errorx"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ConfirmOfflinePaymentLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewConfirmOfflinePaymentLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ConfirmOfflinePaymentLogic {
+	return &ConfirmOfflinePaymentLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ConfirmOfflinePaymentLogic) ConfirmOfflinePayment(req *types.ConfirmOfflinePaymentRequest) (resp *types.ConfirmOfflinePaymentReply, err error) {
+	if req.ReferenceNumber == "" {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "请填写银行流水号")
+	}
+
+	payment, err := l.svcCtx.PowerX.Payment.ConfirmOfflinePayment(l.ctx, req.PaymentId, req.ReferenceNumber, req.Remark)
+	if err != nil {
+		return nil, err
+	}
+
+	// order如果状态修改出错，可以在另外的机制处理，不能干预payment的记录状态
+	if payment.Order != nil {
+		settled, err := l.svcCtx.PowerX.Payment.SettleOrderAfterPaid(l.ctx, payment.Order)
+		if err != nil {
+			l.Logger.Errorf("线下转账确认-记录订单状态跳变:%s,错误信息：%s", payment.PaymentNumber, err.Error())
+		} else if settled {
+			l.svcCtx.PowerX.WechatNotification.NotifyOrderEvent(wechat.NotificationEventOrderPaid, payment.OrderId, nil)
+		}
+	}
+
+	return &types.ConfirmOfflinePaymentReply{
+		Payment: TransformPaymentToReply(payment),
+	}, nil
+}
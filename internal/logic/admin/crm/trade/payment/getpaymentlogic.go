@@ -46,6 +46,7 @@ func TransformPaymentToReply(mdlPayment *trade.Payment) (paymentReply *types.Pay
 		PaymentType:     int(mdlPayment.PaymentType),
 		Status:          int(mdlPayment.Status),
 		PaidAmount:      mdlPayment.PaidAmount,
+		RefundedAmount:  mdlPayment.RefundedAmount,
 		PaymentNumber:   mdlPayment.PaymentNumber,
 		ReferenceNumber: mdlPayment.ReferenceNumber,
 
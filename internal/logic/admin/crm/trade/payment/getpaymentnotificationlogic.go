@@ -0,0 +1,61 @@
+package payment
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"context"
+	"time"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetPaymentNotificationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetPaymentNotificationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetPaymentNotificationLogic {
+	return &GetPaymentNotificationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetPaymentNotificationLogic) GetPaymentNotification(req *types.GetPaymentNotificationRequest) (resp *types.GetPaymentNotificationReply, err error) {
+	notification, err := l.svcCtx.PowerX.Payment.GetPaymentNotification(l.ctx, req.NotificationId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetPaymentNotificationReply{
+		PaymentNotification: TransformPaymentNotificationToReply(notification),
+	}, nil
+}
+
+func TransformPaymentNotificationToReply(notification *trade.PaymentNotification) *types.PaymentNotification {
+	reply := &types.PaymentNotification{
+		Id:            notification.Id,
+		PaymentType:   notification.PaymentType,
+		NotifyId:      notification.NotifyId,
+		Event:         notification.Event,
+		TransactionId: notification.TransactionId,
+		PaymentNumber: notification.PaymentNumber,
+		Amount:        notification.Amount,
+		Currency:      notification.Currency,
+		RawHeaders:    string(notification.RawHeaders),
+		RawBody:       notification.RawBody,
+		Payload:       string(notification.Payload),
+		Status:        notification.Status,
+		Error:         notification.Error,
+		Attempts:      notification.Attempts,
+		CreatedAt:     notification.CreatedAt.Format(time.RFC3339),
+	}
+	if notification.ProcessedAt != nil {
+		reply.ProcessedAt = notification.ProcessedAt.Format(time.RFC3339)
+	}
+	return reply
+}
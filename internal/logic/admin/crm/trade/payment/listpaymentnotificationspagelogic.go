@@ -0,0 +1,54 @@
+package payment
+
+import (
+	tradeUC "PowerX/internal/uc/powerx/crm/trade"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListPaymentNotificationsPageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListPaymentNotificationsPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListPaymentNotificationsPageLogic {
+	return &ListPaymentNotificationsPageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListPaymentNotificationsPageLogic) ListPaymentNotificationsPage(req *types.ListPaymentNotificationsPageRequest) (resp *types.ListPaymentNotificationsPageReply, err error) {
+	page, err := l.svcCtx.PowerX.Payment.FindManyPaymentNotifications(l.ctx, &tradeUC.FindManyPaymentNotificationsOption{
+		PaymentType:   req.PaymentType,
+		PaymentNumber: req.PaymentNumber,
+		Status:        req.Status,
+		PageEmbedOption: types.PageEmbedOption{
+			PageIndex: req.PageIndex,
+			PageSize:  req.PageSize,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 列表不返回原始报文
+	list := []*types.PaymentNotification{}
+	for _, notification := range page.List {
+		reply := TransformPaymentNotificationToReply(notification)
+		reply.RawHeaders, reply.RawBody, reply.Payload = "", "", ""
+		list = append(list, reply)
+	}
+	return &types.ListPaymentNotificationsPageReply{
+		List:      list,
+		PageIndex: page.PageIndex,
+		PageSize:  page.PageSize,
+		Total:     page.Total,
+	}, nil
+}
@@ -2,6 +2,8 @@ package payment
 
 import (
 	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/model/powermodel"
+	"PowerX/internal/types/errorx"
 	tradeUC "PowerX/internal/uc/powerx/crm/trade"
 	"context"
 
@@ -26,7 +28,13 @@ func NewListPaymentsPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *
 }
 
 func (l *ListPaymentsPageLogic) ListPaymentsPage(req *types.ListPaymentsPageRequest) (resp *types.ListPaymentsPageReply, err error) {
+	querySpec, err := powermodel.NewQuerySpec(trade.PaymentQueryFields, req.OrderBy, req.Filters)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+
 	page, err := l.svcCtx.PowerX.Payment.FindManyPayments(l.ctx, &tradeUC.FindManyPaymentsOption{
+		QuerySpec: querySpec,
 		PageEmbedOption: types.PageEmbedOption{
 			PageIndex: req.PageIndex,
 			PageSize:  req.PageSize,
@@ -0,0 +1,84 @@
+package payment
+
+import (
+	payment2 "PowerX/internal/logic/mp/crm/trade/payment"
+	"PowerX/internal/types/errorx"
+	tradeUC "PowerX/internal/uc/powerx/crm/trade"
+	"PowerX/internal/uc/powerx/crm/trade/provider"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RefundPaymentLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRefundPaymentLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RefundPaymentLogic {
+	return &RefundPaymentLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RefundPaymentLogic) RefundPayment(req *types.RefundPaymentRequest) (resp *types.RefundPaymentReply, err error) {
+	payment, err := l.svcCtx.PowerX.Payment.GetPayment(l.ctx, req.PaymentId)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := l.svcCtx.PowerX.Payment.RefundPayment(l.ctx, payment, req.Amount, req.Reason)
+	if err != nil {
+		if _, ok := err.(*errorx.Error); ok {
+			return nil, err
+		}
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "退款失败:"+err.Error())
+	}
+
+	// 渠道同步返回退款成功时直接处理, 否则等待渠道的退款通知
+	if result.State == provider.RefundStateSuccess {
+		err = payment2.NewHandlePaymentNotificationLogic(l.ctx, l.svcCtx).HandlePaymentNotification(&provider.Notification{
+			Event:  provider.NotificationEventRefunded,
+			Refund: result,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 组合商品的退款按组件明细的剩余金额拆分, 退款完成时已记录分摊, 处理中的退款返回预计的分摊
+	allocations := []*tradeUC.ComponentRefundAllocation{}
+	if result.State == provider.RefundStateSuccess {
+		allocations = l.svcCtx.PowerX.Order.FindRefundAllocations(l.ctx, result.RefundNumber)
+	} else if result.State != provider.RefundStateFailed {
+		allocations = l.svcCtx.PowerX.Order.AllocateRefundToComponents(l.ctx, payment.OrderId, result.Amount)
+	}
+
+	return &types.RefundPaymentReply{
+		RefundNumber:      result.RefundNumber,
+		ReferenceNumber:   result.ReferenceNumber,
+		State:             string(result.State),
+		Amount:            result.Amount,
+		BundleAllocations: TransformComponentRefundAllocationsToReply(allocations),
+	}, nil
+}
+
+func TransformComponentRefundAllocationsToReply(allocations []*tradeUC.ComponentRefundAllocation) []*types.RefundBundleAllocation {
+	allocationsReply := []*types.RefundBundleAllocation{}
+	for _, allocation := range allocations {
+		allocationsReply = append(allocationsReply, &types.RefundBundleAllocation{
+			OrderItemId: allocation.Component.OrderItemId,
+			ComponentId: allocation.Component.Id,
+			SkuNo:       allocation.Component.SkuNo,
+			Quantity:    allocation.Component.Quantity,
+			Amount:      allocation.Amount,
+		})
+	}
+	return allocationsReply
+}
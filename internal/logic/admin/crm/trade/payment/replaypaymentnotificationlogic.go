@@ -0,0 +1,36 @@
+package payment
+
+import (
+	payment2 "PowerX/internal/logic/mp/crm/trade/payment"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ReplayPaymentNotificationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewReplayPaymentNotificationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ReplayPaymentNotificationLogic {
+	return &ReplayPaymentNotificationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ReplayPaymentNotificationLogic) ReplayPaymentNotification(req *types.ReplayPaymentNotificationRequest) (resp *types.ReplayPaymentNotificationReply, err error) {
+	notification, err := payment2.NewHandlePaymentNotificationLogic(l.ctx, l.svcCtx).Replay(req.NotificationId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ReplayPaymentNotificationReply{
+		PaymentNotification: TransformPaymentNotificationToReply(notification),
+	}, nil
+}
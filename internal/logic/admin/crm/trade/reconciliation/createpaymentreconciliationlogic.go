@@ -0,0 +1,36 @@
+package reconciliation
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreatePaymentReconciliationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreatePaymentReconciliationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreatePaymentReconciliationLogic {
+	return &CreatePaymentReconciliationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CreatePaymentReconciliationLogic) CreatePaymentReconciliation(req *types.CreatePaymentReconciliationRequest) (resp *types.CreatePaymentReconciliationReply, err error) {
+	reconciliation, err := l.svcCtx.PowerX.PaymentReconciliation.ReconcileWechatBill(l.ctx, req.BillDate)
+	if reconciliation == nil {
+		return nil, err
+	}
+
+	// 下载账单失败时同样返回失败的对账记录
+	return &types.CreatePaymentReconciliationReply{
+		PaymentReconciliation: TransformPaymentReconciliationToReply(reconciliation),
+	}, nil
+}
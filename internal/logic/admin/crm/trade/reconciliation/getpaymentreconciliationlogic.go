@@ -0,0 +1,75 @@
+package reconciliation
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"context"
+	"time"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetPaymentReconciliationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetPaymentReconciliationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetPaymentReconciliationLogic {
+	return &GetPaymentReconciliationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetPaymentReconciliationLogic) GetPaymentReconciliation(req *types.GetPaymentReconciliationRequest) (resp *types.GetPaymentReconciliationReply, err error) {
+	reconciliation, err := l.svcCtx.PowerX.PaymentReconciliation.GetPaymentReconciliation(l.ctx, req.ReconciliationId, req.Results)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetPaymentReconciliationReply{
+		PaymentReconciliation: TransformPaymentReconciliationToReply(reconciliation),
+	}, nil
+}
+
+func TransformPaymentReconciliationToReply(reconciliation *trade.PaymentReconciliation) *types.PaymentReconciliation {
+	reply := &types.PaymentReconciliation{
+		Id:             reconciliation.Id,
+		PaymentType:    reconciliation.PaymentType,
+		BillDate:       reconciliation.BillDate,
+		Source:         reconciliation.Source,
+		Status:         reconciliation.Status,
+		BillCount:      reconciliation.BillCount,
+		BillAmount:     reconciliation.BillAmount,
+		PaymentCount:   reconciliation.PaymentCount,
+		PaymentAmount:  reconciliation.PaymentAmount,
+		MatchedCount:   reconciliation.MatchedCount,
+		ExceptionCount: reconciliation.ExceptionCount,
+		HealedCount:    reconciliation.HealedCount,
+		ErrorMessage:   reconciliation.ErrorMessage,
+		CreatedAt:      reconciliation.CreatedAt.Format(time.RFC3339),
+	}
+	if reconciliation.FinishedAt != nil {
+		reply.FinishedAt = reconciliation.FinishedAt.Format(time.RFC3339)
+	}
+	for _, item := range reconciliation.Items {
+		reply.Items = append(reply.Items, &types.PaymentReconciliationItem{
+			Id:              item.Id,
+			PaymentId:       item.PaymentId,
+			PaymentNumber:   item.PaymentNumber,
+			ReferenceNumber: item.ReferenceNumber,
+			BillTradeState:  item.BillTradeState,
+			BillAmount:      item.BillAmount,
+			RefundAmount:    item.RefundAmount,
+			PaymentAmount:   item.PaymentAmount,
+			PaymentStatus:   item.PaymentStatus,
+			Result:          item.Result,
+			Remark:          item.Remark,
+		})
+	}
+	return reply
+}
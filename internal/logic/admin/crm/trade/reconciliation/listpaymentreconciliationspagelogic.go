@@ -0,0 +1,51 @@
+package reconciliation
+
+import (
+	tradeUC "PowerX/internal/uc/powerx/crm/trade"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListPaymentReconciliationsPageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListPaymentReconciliationsPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListPaymentReconciliationsPageLogic {
+	return &ListPaymentReconciliationsPageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListPaymentReconciliationsPageLogic) ListPaymentReconciliationsPage(req *types.ListPaymentReconciliationsPageRequest) (resp *types.ListPaymentReconciliationsPageReply, err error) {
+	page, err := l.svcCtx.PowerX.PaymentReconciliation.FindManyPaymentReconciliations(l.ctx, &tradeUC.FindManyPaymentReconciliationsOption{
+		PaymentType: req.PaymentType,
+		BillDate:    req.BillDate,
+		Status:      req.Status,
+		PageEmbedOption: types.PageEmbedOption{
+			PageIndex: req.PageIndex,
+			PageSize:  req.PageSize,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*types.PaymentReconciliation{}
+	for _, reconciliation := range page.List {
+		list = append(list, TransformPaymentReconciliationToReply(reconciliation))
+	}
+	return &types.ListPaymentReconciliationsPageReply{
+		List:      list,
+		PageIndex: page.PageIndex,
+		PageSize:  page.PageSize,
+		Total:     page.Total,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package reconciliation
+
+import (
+	"PowerX/internal/types/errorx"
+	"context"
+	"io"
+	"net/http"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 微信账单单日文件较大, 放宽至20M
+const MaxBillFileSize = 20 << 20
+
+type UploadPaymentReconciliationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUploadPaymentReconciliationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UploadPaymentReconciliationLogic {
+	return &UploadPaymentReconciliationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UploadPaymentReconciliationLogic) UploadPaymentReconciliation(r *http.Request) (resp *types.UploadPaymentReconciliationReply, err error) {
+	// 获取上传文件
+	err = r.ParseMultipartForm(MaxBillFileSize)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+
+	file, _, err := r.FormFile("resource")
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+
+	reconciliation, err := l.svcCtx.PowerX.PaymentReconciliation.ReconcileUploadedWechatBill(l.ctx, r.FormValue("billDate"), content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.UploadPaymentReconciliationReply{
+		PaymentReconciliation: TransformPaymentReconciliationToReply(reconciliation),
+	}, nil
+}
@@ -0,0 +1,41 @@
+package storeorder
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeliverStoreOrderLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeliverStoreOrderLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeliverStoreOrderLogic {
+	return &DeliverStoreOrderLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeliverStoreOrderLogic) DeliverStoreOrder(req *types.DeliverStoreOrderRequest) (resp *types.DeliverStoreOrderReply, err error) {
+	mdlOrder, err := getManagedStoreOrder(l.ctx, l.svcCtx, req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	mdlOrder, err = l.svcCtx.PowerX.Order.DeliverOrder(l.ctx, mdlOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.DeliverStoreOrderReply{
+		OrderId: mdlOrder.Id,
+		Status:  mdlOrder.Status,
+	}, nil
+}
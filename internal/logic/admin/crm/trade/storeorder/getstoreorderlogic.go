@@ -0,0 +1,36 @@
+package storeorder
+
+import (
+	"PowerX/internal/logic/admin/crm/trade/order"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetStoreOrderLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetStoreOrderLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetStoreOrderLogic {
+	return &GetStoreOrderLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetStoreOrderLogic) GetStoreOrder(req *types.GetStoreOrderRequest) (resp *types.GetOrderReply, err error) {
+	mdlOrder, err := getManagedStoreOrder(l.ctx, l.svcCtx, req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetOrderReply{
+		Order: order.TransformOrderToReply(mdlOrder),
+	}, nil
+}
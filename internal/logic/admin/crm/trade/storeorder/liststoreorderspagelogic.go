@@ -0,0 +1,66 @@
+package storeorder
+
+import (
+	"PowerX/internal/logic/admin/crm/trade/order"
+	"PowerX/internal/types/errorx"
+	tradeUC "PowerX/internal/uc/powerx/crm/trade"
+	"PowerX/pkg/slicex"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListStoreOrdersPageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListStoreOrdersPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListStoreOrdersPageLogic {
+	return &ListStoreOrdersPageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListStoreOrdersPageLogic) ListStoreOrdersPage(req *types.ListStoreOrdersPageRequest) (resp *types.ListOrdersPageReply, err error) {
+	storeIds, err := getManagedStoreIds(l.ctx, l.svcCtx)
+	if err != nil {
+		return nil, err
+	}
+	if req.StoreId > 0 {
+		if !slicex.Contains(storeIds, req.StoreId) {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "无权查看该门店的订单")
+		}
+		storeIds = []int64{req.StoreId}
+	}
+	if len(storeIds) == 0 {
+		return &types.ListOrdersPageReply{
+			List: []*types.Order{},
+		}, nil
+	}
+
+	page, err := l.svcCtx.PowerX.Order.FindManyOrders(l.ctx, &tradeUC.FindManyOrdersOption{
+		StoreIds: storeIds,
+		Status:   req.StatusIds,
+		LikeName: req.Name,
+		PageEmbedOption: types.PageEmbedOption{
+			PageIndex: req.PageIndex,
+			PageSize:  req.PageSize,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ListOrdersPageReply{
+		List:      order.TransformOrdersToReply(page.List),
+		PageIndex: page.PageIndex,
+		PageSize:  page.PageSize,
+		Total:     page.Total,
+	}, nil
+}
@@ -0,0 +1,41 @@
+package storeorder
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ShipStoreOrderLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewShipStoreOrderLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ShipStoreOrderLogic {
+	return &ShipStoreOrderLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ShipStoreOrderLogic) ShipStoreOrder(req *types.ShipStoreOrderRequest) (resp *types.ShipStoreOrderReply, err error) {
+	mdlOrder, err := getManagedStoreOrder(l.ctx, l.svcCtx, req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	mdlOrder, err = l.svcCtx.PowerX.Order.ShipOrder(l.ctx, mdlOrder, req.Carrier, req.TrackingCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ShipStoreOrderReply{
+		OrderId: mdlOrder.Id,
+		Status:  mdlOrder.Status,
+	}, nil
+}
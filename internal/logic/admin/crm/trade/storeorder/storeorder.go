@@ -0,0 +1,34 @@
+package storeorder
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/svc"
+	"PowerX/internal/types/errorx"
+	"PowerX/pkg/slicex"
+	"context"
+)
+
+// getManagedStoreIds 当前员工作为店长管理的门店
+func getManagedStoreIds(ctx context.Context, svcCtx *svc.ServiceContext) ([]int64, error) {
+	cred, err := svcCtx.PowerX.AdminAuthorization.AuthMetadataFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return svcCtx.PowerX.Store.GetManagedStoreIds(ctx, cred.UID), nil
+}
+
+// getManagedStoreOrder 只能操作自己管理门店的订单
+func getManagedStoreOrder(ctx context.Context, svcCtx *svc.ServiceContext, orderId int64) (*trade.Order, error) {
+	storeIds, err := getManagedStoreIds(ctx, svcCtx)
+	if err != nil {
+		return nil, err
+	}
+	mdlOrder, err := svcCtx.PowerX.Order.GetOrder(ctx, orderId)
+	if err != nil {
+		return nil, errorx.ErrNotFoundObject
+	}
+	if mdlOrder.StoreId == 0 || !slicex.Contains(storeIds, mdlOrder.StoreId) {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "无权操作该门店的订单")
+	}
+	return mdlOrder, nil
+}
@@ -0,0 +1,47 @@
+package subscription
+
+import (
+	"context"
+	"strings"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CancelSubscriptionLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCancelSubscriptionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CancelSubscriptionLogic {
+	return &CancelSubscriptionLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CancelSubscriptionLogic) CancelSubscription(req *types.CancelSubscriptionRequest) (resp *types.CancelSubscriptionReply, err error) {
+	subscription, err := l.svcCtx.PowerX.Subscription.GetSubscription(l.ctx, req.SubscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		reason = "管理员取消"
+	}
+	if err = l.svcCtx.PowerX.Subscription.CancelSubscription(l.ctx, subscription, req.Immediately, reason); err != nil {
+		return nil, err
+	}
+
+	subscription, err = l.svcCtx.PowerX.Subscription.GetSubscription(l.ctx, req.SubscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	return &types.CancelSubscriptionReply{
+		Subscription: TransformSubscriptionToReply(subscription),
+	}, nil
+}
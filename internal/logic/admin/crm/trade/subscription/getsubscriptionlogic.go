@@ -0,0 +1,79 @@
+package subscription
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"context"
+	"time"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetSubscriptionLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetSubscriptionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetSubscriptionLogic {
+	return &GetSubscriptionLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetSubscriptionLogic) GetSubscription(req *types.GetSubscriptionRequest) (resp *types.GetSubscriptionReply, err error) {
+	subscription, err := l.svcCtx.PowerX.Subscription.GetSubscription(l.ctx, req.SubscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetSubscriptionReply{
+		Subscription: TransformSubscriptionToReply(subscription),
+	}, nil
+}
+
+func formatSubscriptionTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.DateTime)
+}
+
+func TransformSubscriptionToReply(subscription *trade.Subscription) *types.Subscription {
+	originOrderNumber := ""
+	if subscription.OriginOrder != nil {
+		originOrderNumber = subscription.OriginOrder.OrderNumber
+	}
+
+	return &types.Subscription{
+		Id:                 subscription.Id,
+		SubscriptionNumber: subscription.SubscriptionNumber,
+		CustomerId:         subscription.CustomerId,
+		OriginOrderId:      subscription.OriginOrderId,
+		OriginOrderNumber:  originOrderNumber,
+		PendingOrderId:     subscription.PendingOrderId,
+		ProductName:        subscription.ProductName,
+		Amount:             subscription.Amount,
+		IntervalUnit:       subscription.IntervalUnit,
+		IntervalCount:      subscription.IntervalCount,
+		TotalCycles:        subscription.TotalCycles,
+		PaidCycles:         subscription.PaidCycles,
+		Status:             subscription.Status,
+		CurrentPeriodStart: subscription.CurrentPeriodStart.Format(time.DateTime),
+		CurrentPeriodEnd:   subscription.CurrentPeriodEnd.Format(time.DateTime),
+		NextChargeAt:       formatSubscriptionTime(subscription.NextChargeAt),
+		CancelAtPeriodEnd:  subscription.CancelAtPeriodEnd,
+		DunningAttempts:    subscription.DunningAttempts,
+		NextRetryAt:        formatSubscriptionTime(subscription.NextRetryAt),
+		LastFailReason:     subscription.LastFailReason,
+		PausedAt:           formatSubscriptionTime(subscription.PausedAt),
+		CancelledAt:        formatSubscriptionTime(subscription.CancelledAt),
+		EndedAt:            formatSubscriptionTime(subscription.EndedAt),
+		CancelReason:       subscription.CancelReason,
+		CreatedAt:          subscription.CreatedAt.Format(time.DateTime),
+	}
+}
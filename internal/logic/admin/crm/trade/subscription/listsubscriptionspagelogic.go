@@ -0,0 +1,51 @@
+package subscription
+
+import (
+	tradeUC "PowerX/internal/uc/powerx/crm/trade"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListSubscriptionsPageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListSubscriptionsPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListSubscriptionsPageLogic {
+	return &ListSubscriptionsPageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListSubscriptionsPageLogic) ListSubscriptionsPage(req *types.ListSubscriptionsPageRequest) (resp *types.ListSubscriptionsPageReply, err error) {
+	page, err := l.svcCtx.PowerX.Subscription.FindManySubscriptions(l.ctx, &tradeUC.FindManySubscriptionsOption{
+		CustomerId:         req.CustomerId,
+		Statuses:           req.Statuses,
+		SubscriptionNumber: req.SubscriptionNumber,
+		PageEmbedOption: types.PageEmbedOption{
+			PageIndex: req.PageIndex,
+			PageSize:  req.PageSize,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*types.Subscription{}
+	for _, subscription := range page.List {
+		list = append(list, TransformSubscriptionToReply(subscription))
+	}
+	return &types.ListSubscriptionsPageReply{
+		List:      list,
+		PageIndex: page.PageIndex,
+		PageSize:  page.PageSize,
+		Total:     page.Total,
+	}, nil
+}
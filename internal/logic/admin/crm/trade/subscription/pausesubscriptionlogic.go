@@ -0,0 +1,42 @@
+package subscription
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type PauseSubscriptionLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewPauseSubscriptionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *PauseSubscriptionLogic {
+	return &PauseSubscriptionLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *PauseSubscriptionLogic) PauseSubscription(req *types.PauseSubscriptionRequest) (resp *types.PauseSubscriptionReply, err error) {
+	subscription, err := l.svcCtx.PowerX.Subscription.GetSubscription(l.ctx, req.SubscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	if err = l.svcCtx.PowerX.Subscription.PauseSubscription(l.ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	subscription, err = l.svcCtx.PowerX.Subscription.GetSubscription(l.ctx, req.SubscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	return &types.PauseSubscriptionReply{
+		Subscription: TransformSubscriptionToReply(subscription),
+	}, nil
+}
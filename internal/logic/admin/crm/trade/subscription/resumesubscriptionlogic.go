@@ -0,0 +1,42 @@
+package subscription
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ResumeSubscriptionLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewResumeSubscriptionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ResumeSubscriptionLogic {
+	return &ResumeSubscriptionLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ResumeSubscriptionLogic) ResumeSubscription(req *types.ResumeSubscriptionRequest) (resp *types.ResumeSubscriptionReply, err error) {
+	subscription, err := l.svcCtx.PowerX.Subscription.GetSubscription(l.ctx, req.SubscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	if err = l.svcCtx.PowerX.Subscription.ResumeSubscription(l.ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	subscription, err = l.svcCtx.PowerX.Subscription.GetSubscription(l.ctx, req.SubscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ResumeSubscriptionReply{
+		Subscription: TransformSubscriptionToReply(subscription),
+	}, nil
+}
@@ -3,8 +3,10 @@ package category
 import (
 	"PowerX/internal/logic/admin/mediaresource"
 	infoorganizatoin "PowerX/internal/model/infoorganization"
+	"PowerX/internal/model/powermodel"
 	"PowerX/internal/svc"
 	"PowerX/internal/types"
+	"PowerX/internal/types/errorx"
 	"PowerX/internal/uc/powerx/crm/infoorganization"
 	"context"
 	"github.com/zeromicro/go-zero/core/logx"
@@ -25,9 +27,14 @@ func NewListCategoryTreeLogic(ctx context.Context, svcCtx *svc.ServiceContext) *
 }
 
 func (l *ListCategoryTreeLogic) ListCategoryTree(req *types.ListCategoryTreeRequest) (resp *types.ListCategoryTreeReply, err error) {
+	querySpec, err := powermodel.NewQuerySpec(infoorganizatoin.CategoryQueryFields, req.OrderBy, req.Filters)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+
 	option := infoorganization.FindCategoryOption{
-		Names:   req.Names,
-		OrderBy: req.OrderBy,
+		Names:     req.Names,
+		QuerySpec: querySpec,
 	}
 
 	// 获取模型类型的列表
@@ -1,8 +1,8 @@
 package bot
 
 import (
+	"PowerX/internal/uc/powerx/scrm/provider"
 	"context"
-	"github.com/ArtisanCloud/PowerWeChat/v3/src/work/groupRobot/request"
 
 	"PowerX/internal/svc"
 	"PowerX/internal/types"
@@ -33,11 +33,10 @@ func NewBotWeWorkArticlesLogic(ctx context.Context, svcCtx *svc.ServiceContext)
 //	@return err
 func (bot *BotWeWorkArticlesLogic) BotWeWorkArticles(req *types.GroupRobotMsgNewsArticlesRequest) (resp *types.GroupRobotMsgNewsArticlesReply, err error) {
 
-	articles := []*request.GroupRobotMsgNewsArticles{
-		{Title: req.Title, Description: req.Description, Url: req.Url, PicUrl: req.PicUrl},
-	}
-	replay, err := bot.svcCtx.PowerX.SCRM.Wechat.PushWeWorkBotArticlesRequest(req.Key, articles)
-	resp.Messaage = replay.Message
+	article := &provider.Article{Title: req.Title, Description: req.Description, Url: req.Url, PicUrl: req.PicUrl}
+	replay, err := bot.svcCtx.PowerX.SCRM.Provider.SendRobotArticle(bot.ctx, req.Key, article)
 
-	return resp, err
+	return &types.GroupRobotMsgNewsArticlesReply{
+		Messaage: replay,
+	}, err
 }
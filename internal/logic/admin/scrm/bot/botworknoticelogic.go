@@ -0,0 +1,53 @@
+package bot
+
+import (
+	"PowerX/internal/types/errorx"
+	"PowerX/internal/uc/powerx/scrm/provider"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type BotWorkNoticeLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewBotWorkNoticeLogic(ctx context.Context, svcCtx *svc.ServiceContext) *BotWorkNoticeLogic {
+	return &BotWorkNoticeLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// BotWorkNotice
+//
+//	@Description: 工作通知, 按配置的服务商发送
+//	@receiver notice
+//	@param req
+//	@return resp
+//	@return err
+func (notice *BotWorkNoticeLogic) BotWorkNotice(req *types.WorkNoticeRequest) (resp *types.WorkNoticeReply, err error) {
+	if len(req.UserIds) == 0 {
+		return nil, errorx.ErrBadRequest
+	}
+	err = notice.svcCtx.PowerX.SCRM.Provider.SendWorkNotice(notice.ctx, &provider.Notice{
+		UserIds: req.UserIds,
+		Title:   req.Title,
+		Content: req.Content,
+		Url:     req.Url,
+	})
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+
+	return &types.WorkNoticeReply{
+		Provider: notice.svcCtx.PowerX.SCRM.Provider.Name(),
+		Status:   `success`,
+	}, nil
+}
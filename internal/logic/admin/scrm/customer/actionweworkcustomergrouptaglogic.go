@@ -0,0 +1,46 @@
+package customer
+
+import (
+	"PowerX/internal/types/errorx"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ActionWeWorkCustomerGroupTagLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewActionWeWorkCustomerGroupTagLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ActionWeWorkCustomerGroupTagLogic {
+	return &ActionWeWorkCustomerGroupTagLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+//
+// ActionWeWorkCustomerGroupTag
+//  @Description: 设置客户群标签
+//  @receiver l
+//  @param req
+//  @return resp
+//  @return err
+//
+func (l *ActionWeWorkCustomerGroupTagLogic) ActionWeWorkCustomerGroupTag(req *types.ActionWeWorkCustomerGroupTagRequest) (resp *types.ActionWeWorkCustomerGroupReply, err error) {
+	if req.ChatId == `` {
+		return nil, errorx.ErrBadRequest
+	}
+	if err = l.svcCtx.PowerX.SCRM.Wechat.ActionWeWorkCustomerGroupTag(req.ChatId, req.Tags); err != nil {
+		return nil, errorx.WithCause(errorx.ErrUpdateObject, err.Error())
+	}
+
+	return &types.ActionWeWorkCustomerGroupReply{
+		Status: `success`,
+	}, nil
+}
@@ -0,0 +1,60 @@
+package customer
+
+import (
+	"PowerX/internal/types/errorx"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreateWeWorkCustomerWelcomeRuleLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateWeWorkCustomerWelcomeRuleLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateWeWorkCustomerWelcomeRuleLogic {
+	return &CreateWeWorkCustomerWelcomeRuleLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+//
+// CreateWeWorkCustomerWelcomeRule
+//  @Description: 创建欢迎语规则
+//  @receiver l
+//  @param req
+//  @return resp
+//  @return err
+//
+func (l *CreateWeWorkCustomerWelcomeRuleLogic) CreateWeWorkCustomerWelcomeRule(req *types.WeWorkCustomerWelcomeRuleRequest) (resp *types.ActionWeWorkCustomerWelcomeRuleReply, err error) {
+	if err = l.OPT(req); err != nil {
+		return nil, err
+	}
+
+	err = l.svcCtx.PowerX.SCRM.Wechat.CreateWeWorkCustomerWelcomeRule(req)
+	if err != nil {
+		return nil, errorx.ErrCreateObject
+	}
+
+	return &types.ActionWeWorkCustomerWelcomeRuleReply{
+		Status: `success`,
+	}, err
+}
+
+//
+// OPT
+//  @Description: 校验
+//  @receiver l
+//  @param opt
+//  @return err
+//
+func (l *CreateWeWorkCustomerWelcomeRuleLogic) OPT(opt *types.WeWorkCustomerWelcomeRuleRequest) (err error) {
+
+	return checkWelcomeRuleRequest(opt)
+}
@@ -0,0 +1,49 @@
+package customer
+
+import (
+	"PowerX/internal/model/scrm/customer"
+	"PowerX/internal/types/errorx"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteWeWorkCustomerWelcomeRuleLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeleteWeWorkCustomerWelcomeRuleLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteWeWorkCustomerWelcomeRuleLogic {
+	return &DeleteWeWorkCustomerWelcomeRuleLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+//
+// DeleteWeWorkCustomerWelcomeRule
+//  @Description: 删除欢迎语规则
+//  @receiver l
+//  @param req
+//  @return resp
+//  @return err
+//
+func (l *DeleteWeWorkCustomerWelcomeRuleLogic) DeleteWeWorkCustomerWelcomeRule(req *types.ActionWeWorkCustomerWelcomeRuleRequest) (resp *types.ActionWeWorkCustomerWelcomeRuleReply, err error) {
+	if req.Id == 0 {
+		return nil, errorx.ErrBadRequest
+	}
+
+	err = l.svcCtx.PowerX.SCRM.Wechat.ActionWeWorkCustomerWelcomeRule(req.Id, customer.WelcomeRuleStateDelete)
+	if err != nil {
+		return nil, errorx.ErrUpdateObject
+	}
+
+	return &types.ActionWeWorkCustomerWelcomeRuleReply{
+		Status: `success`,
+	}, err
+}
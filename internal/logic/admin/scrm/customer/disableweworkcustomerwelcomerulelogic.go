@@ -0,0 +1,49 @@
+package customer
+
+import (
+	"PowerX/internal/model/scrm/customer"
+	"PowerX/internal/types/errorx"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DisableWeWorkCustomerWelcomeRuleLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDisableWeWorkCustomerWelcomeRuleLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DisableWeWorkCustomerWelcomeRuleLogic {
+	return &DisableWeWorkCustomerWelcomeRuleLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+//
+// DisableWeWorkCustomerWelcomeRule
+//  @Description: 禁用欢迎语规则
+//  @receiver l
+//  @param req
+//  @return resp
+//  @return err
+//
+func (l *DisableWeWorkCustomerWelcomeRuleLogic) DisableWeWorkCustomerWelcomeRule(req *types.ActionWeWorkCustomerWelcomeRuleRequest) (resp *types.ActionWeWorkCustomerWelcomeRuleReply, err error) {
+	if req.Id == 0 {
+		return nil, errorx.ErrBadRequest
+	}
+
+	err = l.svcCtx.PowerX.SCRM.Wechat.ActionWeWorkCustomerWelcomeRule(req.Id, customer.WelcomeRuleStateDisable)
+	if err != nil {
+		return nil, errorx.ErrUpdateObject
+	}
+
+	return &types.ActionWeWorkCustomerWelcomeRuleReply{
+		Status: `success`,
+	}, err
+}
@@ -0,0 +1,49 @@
+package customer
+
+import (
+	"PowerX/internal/model/scrm/customer"
+	"PowerX/internal/types/errorx"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type EnableWeWorkCustomerWelcomeRuleLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewEnableWeWorkCustomerWelcomeRuleLogic(ctx context.Context, svcCtx *svc.ServiceContext) *EnableWeWorkCustomerWelcomeRuleLogic {
+	return &EnableWeWorkCustomerWelcomeRuleLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+//
+// EnableWeWorkCustomerWelcomeRule
+//  @Description: 启用欢迎语规则
+//  @receiver l
+//  @param req
+//  @return resp
+//  @return err
+//
+func (l *EnableWeWorkCustomerWelcomeRuleLogic) EnableWeWorkCustomerWelcomeRule(req *types.ActionWeWorkCustomerWelcomeRuleRequest) (resp *types.ActionWeWorkCustomerWelcomeRuleReply, err error) {
+	if req.Id == 0 {
+		return nil, errorx.ErrBadRequest
+	}
+
+	err = l.svcCtx.PowerX.SCRM.Wechat.ActionWeWorkCustomerWelcomeRule(req.Id, customer.WelcomeRuleStateEnable)
+	if err != nil {
+		return nil, errorx.ErrUpdateObject
+	}
+
+	return &types.ActionWeWorkCustomerWelcomeRuleReply{
+		Status: `success`,
+	}, err
+}
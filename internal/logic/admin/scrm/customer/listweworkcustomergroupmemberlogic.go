@@ -0,0 +1,54 @@
+package customer
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListWeWorkCustomerGroupMemberLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListWeWorkCustomerGroupMemberLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListWeWorkCustomerGroupMemberLogic {
+	return &ListWeWorkCustomerGroupMemberLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+//
+// ListWeWorkCustomerGroupMember
+//  @Description: 客户群成员
+//  @receiver l
+//  @param req
+//  @return resp
+//  @return err
+//
+func (l *ListWeWorkCustomerGroupMemberLogic) ListWeWorkCustomerGroupMember(req *types.WeWorkCustomerGroupMemberRequest) (resp *types.WeWorkCustomerGroupMemberReply, err error) {
+	members := l.svcCtx.PowerX.SCRM.Wechat.FindWeWorkCustomerGroupMembers(req.ChatId, req.Status)
+
+	resp = &types.WeWorkCustomerGroupMemberReply{List: make([]*types.WeWorkCustomerGroupMember, 0, len(members))}
+	for _, member := range members {
+		resp.List = append(resp.List, &types.WeWorkCustomerGroupMember{
+			UserId:        member.UserId,
+			Type:          member.Type,
+			JoinTime:      member.JoinTime,
+			JoinScene:     member.JoinScene,
+			State:         member.State,
+			Invitor:       member.Invitor,
+			GroupNickname: member.GroupNickname,
+			Name:          member.Name,
+			Status:        member.Status,
+			QuitTime:      member.QuitTime,
+		})
+	}
+
+	return resp, nil
+}
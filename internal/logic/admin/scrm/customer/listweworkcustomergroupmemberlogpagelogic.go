@@ -0,0 +1,65 @@
+package customer
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListWeWorkCustomerGroupMemberLogPageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListWeWorkCustomerGroupMemberLogPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListWeWorkCustomerGroupMemberLogPageLogic {
+	return &ListWeWorkCustomerGroupMemberLogPageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+//
+// ListWeWorkCustomerGroupMemberLogPage
+//  @Description: 客户群成员变动
+//  @receiver l
+//  @param req
+//  @return resp
+//  @return err
+//
+func (l *ListWeWorkCustomerGroupMemberLogPageLogic) ListWeWorkCustomerGroupMemberLogPage(req *types.ListWeWorkCustomerGroupMemberLogRequest) (resp *types.ListWeWorkCustomerGroupMemberLogReply, err error) {
+	reply, err := l.svcCtx.PowerX.SCRM.Wechat.FindWeWorkCustomerGroupMemberLogPage(&types.PageOption[types.ListWeWorkCustomerGroupMemberLogRequest]{
+		Option:    *req,
+		PageIndex: req.PageIndex,
+		PageSize:  req.PageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &types.ListWeWorkCustomerGroupMemberLogReply{
+		List:      make([]*types.WeWorkCustomerGroupMemberLog, 0, len(reply.List)),
+		PageIndex: reply.PageIndex,
+		PageSize:  reply.PageSize,
+		Total:     reply.Total,
+	}
+	for _, log := range reply.List {
+		resp.List = append(resp.List, &types.WeWorkCustomerGroupMemberLog{
+			Id:         log.Id,
+			ChatId:     log.ChatId,
+			UserId:     log.UserId,
+			Type:       log.Type,
+			Action:     log.Action,
+			JoinScene:  log.JoinScene,
+			QuitScene:  log.QuitScene,
+			State:      log.State,
+			ActionTime: log.ActionTime,
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,91 @@
+package customer
+
+import (
+	"PowerX/internal/model/scrm/customer"
+	"strings"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListWeWorkCustomerGroupPageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListWeWorkCustomerGroupPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListWeWorkCustomerGroupPageLogic {
+	return &ListWeWorkCustomerGroupPageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+//
+// ListWeWorkCustomerGroupPage
+//  @Description: 客户群列表
+//  @receiver l
+//  @param req
+//  @return resp
+//  @return err
+//
+func (l *ListWeWorkCustomerGroupPageLogic) ListWeWorkCustomerGroupPage(req *types.ListWeWorkCustomerGroupPageRequest) (resp *types.ListWeWorkCustomerGroupPageReply, err error) {
+	reply, err := l.svcCtx.PowerX.SCRM.Wechat.FindWeWorkCustomerGroupPage(&types.PageOption[types.ListWeWorkCustomerGroupPageRequest]{
+		Option:    *req,
+		PageIndex: req.PageIndex,
+		PageSize:  req.PageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ListWeWorkCustomerGroupPageReply{
+		List:      l.DTO(reply.List),
+		PageIndex: reply.PageIndex,
+		PageSize:  reply.PageSize,
+		Total:     reply.Total,
+	}, err
+}
+
+//
+// DTO
+//  @Description:
+//  @receiver l
+//  @param groups
+//  @return reply
+//
+func (l *ListWeWorkCustomerGroupPageLogic) DTO(groups []*customer.WeWorkCustomerGroup) (reply []*types.WeWorkCustomerGroup) {
+
+	var chatIds []string
+	for _, group := range groups {
+		chatIds = append(chatIds, group.ChatId)
+	}
+	tags := map[string][]string{}
+	if len(chatIds) > 0 {
+		tags = l.svcCtx.PowerX.SCRM.Wechat.FindWeWorkCustomerGroupTags(chatIds)
+	}
+
+	for _, group := range groups {
+		admins := []string{}
+		if group.AdminList != `` {
+			admins = strings.Split(group.AdminList, `,`)
+		}
+		reply = append(reply, &types.WeWorkCustomerGroup{
+			ChatId:      group.ChatId,
+			Name:        group.Name,
+			Owner:       group.Owner,
+			CreateTime:  group.CreateTime,
+			Notice:      group.Notice,
+			AdminList:   admins,
+			MemberCount: group.MemberCount,
+			Status:      group.Status,
+			Tags:        tags[group.ChatId],
+		})
+	}
+	return reply
+
+}
@@ -0,0 +1,53 @@
+package customer
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListWeWorkCustomerGroupStatisticLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListWeWorkCustomerGroupStatisticLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListWeWorkCustomerGroupStatisticLogic {
+	return &ListWeWorkCustomerGroupStatisticLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+//
+// ListWeWorkCustomerGroupStatistic
+//  @Description: 群聊数据统计
+//  @receiver l
+//  @param req
+//  @return resp
+//  @return err
+//
+func (l *ListWeWorkCustomerGroupStatisticLogic) ListWeWorkCustomerGroupStatistic(req *types.ListWeWorkCustomerGroupStatisticRequest) (resp *types.ListWeWorkCustomerGroupStatisticReply, err error) {
+	statistics := l.svcCtx.PowerX.SCRM.Wechat.FindWeWorkCustomerGroupStatistic(req.Owners, req.StartDate, req.EndDate)
+
+	resp = &types.ListWeWorkCustomerGroupStatisticReply{List: make([]*types.WeWorkCustomerGroupStatistic, 0, len(statistics))}
+	for _, val := range statistics {
+		resp.List = append(resp.List, &types.WeWorkCustomerGroupStatistic{
+			Owner:        val.Owner,
+			Date:         val.Date,
+			NewChatCnt:   val.NewChatCnt,
+			ChatTotal:    val.ChatTotal,
+			ChatHasMsg:   val.ChatHasMsg,
+			NewMemberCnt: val.NewMemberCnt,
+			MemberTotal:  val.MemberTotal,
+			MemberHasMsg: val.MemberHasMsg,
+			MsgTotal:     val.MsgTotal,
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,79 @@
+package customer
+
+import (
+	"PowerX/internal/model/scrm/customer"
+	"time"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListWeWorkCustomerWelcomeLogPageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListWeWorkCustomerWelcomeLogPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListWeWorkCustomerWelcomeLogPageLogic {
+	return &ListWeWorkCustomerWelcomeLogPageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+//
+// ListWeWorkCustomerWelcomeLogPage
+//  @Description: 欢迎语执行记录
+//  @receiver l
+//  @param req
+//  @return resp
+//  @return err
+//
+func (l *ListWeWorkCustomerWelcomeLogPageLogic) ListWeWorkCustomerWelcomeLogPage(req *types.ListWeWorkCustomerWelcomeLogRequest) (resp *types.ListWeWorkCustomerWelcomeLogReply, err error) {
+	reply, err := l.svcCtx.PowerX.SCRM.Wechat.FindWeWorkCustomerWelcomeLogPage(&types.PageOption[types.ListWeWorkCustomerWelcomeLogRequest]{
+		Option:    *req,
+		PageIndex: req.PageIndex,
+		PageSize:  req.PageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ListWeWorkCustomerWelcomeLogReply{
+		List:      l.DTO(reply.List),
+		PageIndex: reply.PageIndex,
+		PageSize:  reply.PageSize,
+		Total:     reply.Total,
+	}, err
+}
+
+//
+// DTO
+//  @Description:
+//  @receiver l
+//  @param logs
+//  @return reply
+//
+func (l *ListWeWorkCustomerWelcomeLogPageLogic) DTO(logs []*customer.WeWorkCustomerWelcomeLog) (reply []*types.WeWorkCustomerWelcomeLog) {
+
+	for _, log := range logs {
+		reply = append(reply, &types.WeWorkCustomerWelcomeLog{
+			Id:             log.Id,
+			RuleId:         log.RuleId,
+			ExternalUserId: log.ExternalUserId,
+			UserId:         log.UserId,
+			State:          log.State,
+			WelcomeStatus:  log.WelcomeStatus,
+			TagStatus:      log.TagStatus,
+			GroupStatus:    log.GroupStatus,
+			Error:          log.Error,
+			CreatedAt:      log.CreatedAt.Format(time.DateTime),
+		})
+	}
+	return reply
+
+}
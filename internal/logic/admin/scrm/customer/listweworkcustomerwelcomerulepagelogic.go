@@ -0,0 +1,124 @@
+package customer
+
+import (
+	"PowerX/internal/model/scrm/customer"
+	"strings"
+	"time"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListWeWorkCustomerWelcomeRulePageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListWeWorkCustomerWelcomeRulePageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListWeWorkCustomerWelcomeRulePageLogic {
+	return &ListWeWorkCustomerWelcomeRulePageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+//
+// ListWeWorkCustomerWelcomeRulePage
+//  @Description: 欢迎语规则列表
+//  @receiver l
+//  @param req
+//  @return resp
+//  @return err
+//
+func (l *ListWeWorkCustomerWelcomeRulePageLogic) ListWeWorkCustomerWelcomeRulePage(req *types.ListWeWorkCustomerWelcomeRuleRequest) (resp *types.ListWeWorkCustomerWelcomeRuleReply, err error) {
+	reply, err := l.svcCtx.PowerX.SCRM.Wechat.FindWeWorkCustomerWelcomeRulePage(l.OPT(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ListWeWorkCustomerWelcomeRuleReply{
+		List:      l.DTO(reply.List),
+		PageIndex: reply.PageIndex,
+		PageSize:  reply.PageSize,
+		Total:     reply.Total,
+	}, err
+}
+
+//
+// OPT
+//  @Description:
+//  @receiver l
+//  @param opt
+//  @return *types.PageOption[types.ListWeWorkCustomerWelcomeRuleRequest]
+//
+func (l *ListWeWorkCustomerWelcomeRulePageLogic) OPT(opt *types.ListWeWorkCustomerWelcomeRuleRequest) *types.PageOption[types.ListWeWorkCustomerWelcomeRuleRequest] {
+
+	return &types.PageOption[types.ListWeWorkCustomerWelcomeRuleRequest]{
+		Option:    *opt,
+		PageIndex: opt.PageIndex,
+		PageSize:  opt.PageSize,
+	}
+
+}
+
+//
+// DTO
+//  @Description:
+//  @receiver l
+//  @param rules
+//  @return reply
+//
+func (l *ListWeWorkCustomerWelcomeRulePageLogic) DTO(rules []*customer.WeWorkCustomerWelcomeRule) (reply []*types.WeWorkCustomerWelcomeRule) {
+
+	for _, rule := range rules {
+		reply = append(reply, TransferWelcomeRuleToReply(rule))
+	}
+	return reply
+
+}
+
+//
+// TransferWelcomeRuleToReply
+//  @Description:
+//  @param rule
+//  @return *types.WeWorkCustomerWelcomeRule
+//
+func TransferWelcomeRuleToReply(rule *customer.WeWorkCustomerWelcomeRule) *types.WeWorkCustomerWelcomeRule {
+
+	tagIds := []string{}
+	if rule.TagIds != `` {
+		tagIds = strings.Split(rule.TagIds, `,`)
+	}
+
+	return &types.WeWorkCustomerWelcomeRule{
+		Id:             rule.Id,
+		Name:           rule.Name,
+		Qid:            rule.Qid,
+		State:          rule.State,
+		UserId:         rule.UserId,
+		Priority:       rule.Priority,
+		Text:           rule.Text,
+		AttachmentType: rule.AttachmentType,
+		ResourceId:     rule.ResourceId,
+		Link: types.WeWorkCustomerWelcomeLink{
+			Title:  rule.LinkTitle,
+			PicUrl: rule.LinkPicUrl,
+			Desc:   rule.LinkDesc,
+			Url:    rule.LinkUrl,
+		},
+		MiniProgram: types.WeWorkCustomerWelcomeMiniProgram{
+			AppId:      rule.MiniAppId,
+			Title:      rule.MiniTitle,
+			Page:       rule.MiniPage,
+			PicMediaId: rule.MiniPicMediaId,
+		},
+		TagIds:    tagIds,
+		GroupQid:  rule.GroupQid,
+		Status:    rule.Status,
+		CreatedAt: rule.CreatedAt.Format(time.DateTime),
+	}
+}
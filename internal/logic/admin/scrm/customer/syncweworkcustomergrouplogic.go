@@ -0,0 +1,44 @@
+package customer
+
+import (
+	"PowerX/internal/types/errorx"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SyncWeWorkCustomerGroupLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSyncWeWorkCustomerGroupLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SyncWeWorkCustomerGroupLogic {
+	return &SyncWeWorkCustomerGroupLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+//
+// SyncWeWorkCustomerGroup
+//  @Description: 同步客户群及成员
+//  @receiver l
+//  @return resp
+//  @return err
+//
+func (l *SyncWeWorkCustomerGroupLogic) SyncWeWorkCustomerGroup() (resp *types.SyncWeWorkCustomerGroupReply, err error) {
+	count, err := l.svcCtx.PowerX.SCRM.Wechat.SyncWeWorkCustomerGroup()
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+
+	return &types.SyncWeWorkCustomerGroupReply{
+		Status: `success`,
+		Count:  count,
+	}, nil
+}
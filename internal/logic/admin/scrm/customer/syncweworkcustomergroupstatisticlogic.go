@@ -0,0 +1,53 @@
+package customer
+
+import (
+	"PowerX/internal/types/errorx"
+	"time"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SyncWeWorkCustomerGroupStatisticLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSyncWeWorkCustomerGroupStatisticLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SyncWeWorkCustomerGroupStatisticLogic {
+	return &SyncWeWorkCustomerGroupStatisticLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+//
+// SyncWeWorkCustomerGroupStatistic
+//  @Description: 同步群聊数据统计
+//  @receiver l
+//  @param req
+//  @return resp
+//  @return err
+//
+func (l *SyncWeWorkCustomerGroupStatisticLogic) SyncWeWorkCustomerGroupStatistic(req *types.SyncWeWorkCustomerGroupStatisticRequest) (resp *types.SyncWeWorkCustomerGroupReply, err error) {
+	day := time.Now().AddDate(0, 0, -1)
+	if req.Date != `` {
+		if day, err = time.ParseInLocation(`2006-01-02`, req.Date, time.Local); err != nil {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, `date格式错误`)
+		}
+	}
+
+	count, err := l.svcCtx.PowerX.SCRM.Wechat.SyncWeWorkCustomerGroupStatistic(day)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+
+	return &types.SyncWeWorkCustomerGroupReply{
+		Status: `success`,
+		Count:  count,
+	}, nil
+}
@@ -0,0 +1,60 @@
+package customer
+
+import (
+	"PowerX/internal/types/errorx"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UpdateWeWorkCustomerWelcomeRuleLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUpdateWeWorkCustomerWelcomeRuleLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateWeWorkCustomerWelcomeRuleLogic {
+	return &UpdateWeWorkCustomerWelcomeRuleLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+//
+// UpdateWeWorkCustomerWelcomeRule
+//  @Description: 更新欢迎语规则
+//  @receiver l
+//  @param req
+//  @return resp
+//  @return err
+//
+func (l *UpdateWeWorkCustomerWelcomeRuleLogic) UpdateWeWorkCustomerWelcomeRule(req *types.WeWorkCustomerWelcomeRuleRequest) (resp *types.ActionWeWorkCustomerWelcomeRuleReply, err error) {
+	if err = l.OPT(req); err != nil {
+		return nil, err
+	}
+
+	err = l.svcCtx.PowerX.SCRM.Wechat.UpdateWeWorkCustomerWelcomeRule(req)
+	if err != nil {
+		return nil, errorx.ErrUpdateObject
+	}
+
+	return &types.ActionWeWorkCustomerWelcomeRuleReply{
+		Status: `success`,
+	}, err
+}
+
+//
+// OPT
+//  @Description: 校验
+//  @receiver l
+//  @param opt
+//  @return err
+//
+func (l *UpdateWeWorkCustomerWelcomeRuleLogic) OPT(opt *types.WeWorkCustomerWelcomeRuleRequest) (err error) {
+
+	return checkWelcomeRuleRequest(opt)
+}
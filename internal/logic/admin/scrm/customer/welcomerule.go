@@ -0,0 +1,39 @@
+package customer
+
+import (
+	"PowerX/internal/model/scrm/customer"
+	"PowerX/internal/types"
+	"PowerX/internal/types/errorx"
+)
+
+//
+// checkWelcomeRuleRequest
+//  @Description: 欢迎语规则校验
+//  @param opt
+//  @return error
+//
+func checkWelcomeRuleRequest(opt *types.WeWorkCustomerWelcomeRuleRequest) error {
+
+	if opt.Name == `` {
+		return errorx.WithCause(errorx.ErrBadRequest, `规则名称不能为空`)
+	}
+	if opt.Text == `` && opt.AttachmentType == customer.WelcomeAttachmentTypeNone && opt.GroupQid == `` && len(opt.TagIds) == 0 {
+		return errorx.WithCause(errorx.ErrBadRequest, `欢迎语、附件、标签、入群至少配置一项`)
+	}
+	switch opt.AttachmentType {
+	case customer.WelcomeAttachmentTypeImage:
+		if opt.ResourceId == 0 {
+			return errorx.WithCause(errorx.ErrBadRequest, `请选择图片`)
+		}
+	case customer.WelcomeAttachmentTypeMiniProgram:
+		if opt.MiniProgram.AppId == `` || opt.MiniProgram.Page == `` || opt.MiniProgram.PicMediaId == `` {
+			return errorx.WithCause(errorx.ErrBadRequest, `小程序信息不完整`)
+		}
+	case customer.WelcomeAttachmentTypeLink:
+		if opt.Link.Title == `` || opt.Link.Url == `` {
+			return errorx.WithCause(errorx.ErrBadRequest, `链接信息不完整`)
+		}
+	}
+
+	return nil
+}
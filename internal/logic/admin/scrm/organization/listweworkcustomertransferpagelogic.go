@@ -0,0 +1,80 @@
+package organization
+
+import (
+	"PowerX/internal/model/scrm/customer"
+	"time"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListWeWorkCustomerTransferPageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListWeWorkCustomerTransferPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListWeWorkCustomerTransferPageLogic {
+	return &ListWeWorkCustomerTransferPageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+//
+// ListWeWorkCustomerTransferPage
+//  @Description: 继承记录
+//  @receiver l
+//  @param req
+//  @return resp
+//  @return err
+//
+func (l *ListWeWorkCustomerTransferPageLogic) ListWeWorkCustomerTransferPage(req *types.ListWeWorkCustomerTransferRequest) (resp *types.ListWeWorkCustomerTransferReply, err error) {
+	reply, err := l.svcCtx.PowerX.SCRM.Wechat.FindWeWorkCustomerTransferPage(&types.PageOption[types.ListWeWorkCustomerTransferRequest]{
+		Option:    *req,
+		PageIndex: req.PageIndex,
+		PageSize:  req.PageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ListWeWorkCustomerTransferReply{
+		List:      l.DTO(reply.List),
+		PageIndex: reply.PageIndex,
+		PageSize:  reply.PageSize,
+		Total:     reply.Total,
+	}, err
+}
+
+//
+// DTO
+//  @Description:
+//  @receiver l
+//  @param transfers
+//  @return reply
+//
+func (l *ListWeWorkCustomerTransferPageLogic) DTO(transfers []*customer.WeWorkCustomerTransfer) (reply []*types.WeWorkCustomerTransfer) {
+
+	for _, val := range transfers {
+		reply = append(reply, &types.WeWorkCustomerTransfer{
+			Id:             val.Id,
+			Type:           val.Type,
+			Mode:           val.Mode,
+			HandoverUserId: val.HandoverUserId,
+			TakeoverUserId: val.TakeoverUserId,
+			ExternalUserId: val.ExternalUserId,
+			ChatId:         val.ChatId,
+			Status:         val.Status,
+			TakeoverTime:   val.TakeoverTime,
+			Error:          val.Error,
+			CreatedAt:      val.CreatedAt.Format(time.DateTime),
+		})
+	}
+	return reply
+
+}
@@ -0,0 +1,62 @@
+package organization
+
+import (
+	"PowerX/internal/types/errorx"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListWeWorkEmployeeHandoverLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListWeWorkEmployeeHandoverLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListWeWorkEmployeeHandoverLogic {
+	return &ListWeWorkEmployeeHandoverLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+//
+// ListWeWorkEmployeeHandover
+//  @Description: 待交接的客户与客户群
+//  @receiver l
+//  @param req
+//  @return resp
+//  @return err
+//
+func (l *ListWeWorkEmployeeHandoverLogic) ListWeWorkEmployeeHandover(req *types.WeWorkEmployeeHandoverRequest) (resp *types.WeWorkEmployeeHandoverReply, err error) {
+	customers, chats, err := l.svcCtx.PowerX.SCRM.Wechat.FindWeWorkEmployeeHandover(req.UserId)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+
+	resp = &types.WeWorkEmployeeHandoverReply{
+		UserId:     req.UserId,
+		Customers:  make([]*types.WeWorkEmployeeHandoverCustomer, 0, len(customers)),
+		GroupChats: make([]*types.WeWorkEmployeeHandoverGroupChat, 0, len(chats)),
+	}
+	for _, follow := range customers {
+		resp.Customers = append(resp.Customers, &types.WeWorkEmployeeHandoverCustomer{
+			ExternalUserId: follow.ExternalUserId,
+			Remark:         follow.Remark,
+			CreateTime:     follow.Createtime,
+		})
+	}
+	for _, chat := range chats {
+		resp.GroupChats = append(resp.GroupChats, &types.WeWorkEmployeeHandoverGroupChat{
+			ChatId:      chat.ChatID,
+			Name:        chat.Name,
+			MemberCount: len(chat.MemberList),
+		})
+	}
+
+	return resp, nil
+}
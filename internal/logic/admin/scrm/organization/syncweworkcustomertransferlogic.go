@@ -0,0 +1,44 @@
+package organization
+
+import (
+	"PowerX/internal/types/errorx"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SyncWeWorkCustomerTransferLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSyncWeWorkCustomerTransferLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SyncWeWorkCustomerTransferLogic {
+	return &SyncWeWorkCustomerTransferLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+//
+// SyncWeWorkCustomerTransfer
+//  @Description: 同步客户接替状态
+//  @receiver l
+//  @return resp
+//  @return err
+//
+func (l *SyncWeWorkCustomerTransferLogic) SyncWeWorkCustomerTransfer() (resp *types.SyncWeWorkCustomerTransferReply, err error) {
+	updated, err := l.svcCtx.PowerX.SCRM.Wechat.SyncWeWorkCustomerTransferResult()
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+
+	return &types.SyncWeWorkCustomerTransferReply{
+		Status:  `success`,
+		Updated: updated,
+	}, nil
+}
@@ -31,7 +31,7 @@ func NewSyncWeWorkEmployeeLogic(ctx context.Context, svcCtx *svc.ServiceContext)
 //	@return err
 func (sync *SyncWeWorkEmployeeLogic) SyncWeWorkEmployee() (resp *types.SyncWeWorkOrganizationReply, err error) {
 
-	err = sync.svcCtx.PowerX.SCRM.Wechat.PullSyncDepartmentsAndEmployeesRequest(sync.ctx)
+	err = sync.svcCtx.PowerX.SCRM.Provider.SyncOrganization(sync.ctx)
 
 	return &types.SyncWeWorkOrganizationReply{
 		Status: `success`,
@@ -0,0 +1,79 @@
+package organization
+
+import (
+	"PowerX/internal/types/errorx"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type TransferWeWorkEmployeeLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewTransferWeWorkEmployeeLogic(ctx context.Context, svcCtx *svc.ServiceContext) *TransferWeWorkEmployeeLogic {
+	return &TransferWeWorkEmployeeLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+//
+// TransferWeWorkEmployee
+//  @Description: 在职/离职继承
+//  @receiver l
+//  @param req
+//  @return resp
+//  @return err
+//
+func (l *TransferWeWorkEmployeeLogic) TransferWeWorkEmployee(req *types.TransferWeWorkEmployeeRequest) (resp *types.TransferWeWorkEmployeeReply, err error) {
+	if err = l.check(req); err != nil {
+		return nil, err
+	}
+
+	resp, err = l.svcCtx.PowerX.SCRM.Wechat.TransferWeWorkEmployee(req)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrUpdateObject, err.Error())
+	}
+
+	return resp, nil
+}
+
+//
+// check
+//  @Description:
+//  @receiver l
+//  @param req
+//  @return error
+//
+func (l *TransferWeWorkEmployeeLogic) check(req *types.TransferWeWorkEmployeeRequest) error {
+
+	if req.Strategy == `balance` {
+		if len(req.TakeoverUserIds) == 0 {
+			return errorx.WithCause(errorx.ErrBadRequest, `请选择接替员工`)
+		}
+		for _, userId := range req.TakeoverUserIds {
+			if userId == req.UserId {
+				return errorx.WithCause(errorx.ErrBadRequest, `接替员工不能是原跟进员工`)
+			}
+		}
+		return nil
+	}
+
+	if len(req.Customers) == 0 && len(req.GroupChats) == 0 {
+		return errorx.WithCause(errorx.ErrBadRequest, `请选择需要分配的客户或客户群`)
+	}
+	for _, item := range append(append([]*types.TransferWeWorkEmployeeItem{}, req.Customers...), req.GroupChats...) {
+		if item.TakeoverUserId == `` || item.TakeoverUserId == req.UserId {
+			return errorx.WithCause(errorx.ErrBadRequest, `接替员工错误`)
+		}
+	}
+	return nil
+
+}
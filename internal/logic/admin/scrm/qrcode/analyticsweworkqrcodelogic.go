@@ -0,0 +1,67 @@
+package qrcode
+
+import (
+	"PowerX/internal/types/errorx"
+	"time"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type AnalyticsWeWorkQrcodeLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewAnalyticsWeWorkQrcodeLogic(ctx context.Context, svcCtx *svc.ServiceContext) *AnalyticsWeWorkQrcodeLogic {
+	return &AnalyticsWeWorkQrcodeLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *AnalyticsWeWorkQrcodeLogic) AnalyticsWeWorkQrcode(req *types.SceneQrcodeAnalyticsRequest) (resp *types.SceneQrcodeAnalyticsReply, err error) {
+	end := time.Now()
+	if req.EndDate != `` {
+		if end, err = time.ParseInLocation(`2006-01-02`, req.EndDate, time.Local); err != nil {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, `endDate格式错误`)
+		}
+	}
+	start := end.AddDate(0, 0, -6)
+	if req.StartDate != `` {
+		if start, err = time.ParseInLocation(`2006-01-02`, req.StartDate, time.Local); err != nil {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, `startDate格式错误`)
+		}
+	}
+	if start.After(end) || end.Sub(start) > 366*24*time.Hour {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, `日期区间错误(最长一年)`)
+	}
+
+	series := l.svcCtx.PowerX.Scene.Scene.FindSceneQrcodeAnalytics(req.Qid, start, end)
+
+	resp = &types.SceneQrcodeAnalyticsReply{
+		Qid:    req.Qid,
+		Series: make([]*types.SceneQrcodeDailyAnalytics, 0, len(series)),
+	}
+	for _, item := range series {
+		resp.TotalScanCount += item.ScanCount
+		resp.TotalUniqueVisitorCount += item.UniqueVisitorCount
+		resp.TotalAddedCount += item.AddedCount
+		resp.Series = append(resp.Series, &types.SceneQrcodeDailyAnalytics{
+			Date:               item.Date,
+			ScanCount:          item.ScanCount,
+			UniqueVisitorCount: item.UniqueVisitorCount,
+			AddedCount:         item.AddedCount,
+		})
+	}
+	if resp.TotalUniqueVisitorCount > 0 {
+		resp.ConversionRate = float64(resp.TotalAddedCount) / float64(resp.TotalUniqueVisitorCount)
+	}
+
+	return resp, nil
+}
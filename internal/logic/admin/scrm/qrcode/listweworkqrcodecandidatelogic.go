@@ -0,0 +1,42 @@
+package qrcode
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListWeWorkQrcodeCandidateLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListWeWorkQrcodeCandidateLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListWeWorkQrcodeCandidateLogic {
+	return &ListWeWorkQrcodeCandidateLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListWeWorkQrcodeCandidateLogic) ListWeWorkQrcodeCandidate(req *types.SceneQrcodeCandidateRequest) (resp *types.SceneQrcodeCandidateReply, err error) {
+	candidates := l.svcCtx.PowerX.Scene.Scene.FindSceneQrcodeCandidates(req.Qid)
+
+	resp = &types.SceneQrcodeCandidateReply{List: make([]*types.SceneQrcodeCandidate, 0, len(candidates))}
+	for _, candidate := range candidates {
+		resp.List = append(resp.List, &types.SceneQrcodeCandidate{
+			Id:        candidate.Id,
+			Link:      candidate.Link,
+			ChatId:    candidate.ChatId,
+			MemberCap: candidate.MemberCap,
+			ScanCount: candidate.ScanCount,
+			State:     candidate.State,
+		})
+	}
+
+	return resp, nil
+}
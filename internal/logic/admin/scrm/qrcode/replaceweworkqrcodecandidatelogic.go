@@ -0,0 +1,47 @@
+package qrcode
+
+import (
+	"PowerX/internal/model/scene"
+	"PowerX/internal/types/errorx"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ReplaceWeWorkQrcodeCandidateLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewReplaceWeWorkQrcodeCandidateLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ReplaceWeWorkQrcodeCandidateLogic {
+	return &ReplaceWeWorkQrcodeCandidateLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ReplaceWeWorkQrcodeCandidateLogic) ReplaceWeWorkQrcodeCandidate(req *types.ReplaceSceneQrcodeCandidateRequest) (resp *types.ActionWeWorkGroupQrcodeActiveReply, err error) {
+	candidates := make([]*scene.SceneQrcodeCandidate, 0, len(req.List))
+	for _, val := range req.List {
+		if val.Link == `` {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, `群二维码不能为空`)
+		}
+		candidates = append(candidates, &scene.SceneQrcodeCandidate{
+			Link:      val.Link,
+			ChatId:    val.ChatId,
+			MemberCap: val.MemberCap,
+		})
+	}
+	if err = l.svcCtx.PowerX.Scene.Scene.ReplaceSceneQrcodeCandidates(req.Qid, candidates); err != nil {
+		return nil, errorx.WithCause(errorx.ErrUpdateObject, err.Error())
+	}
+
+	return &types.ActionWeWorkGroupQrcodeActiveReply{
+		Status: `success`,
+	}, nil
+}
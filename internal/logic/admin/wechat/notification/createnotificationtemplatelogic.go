@@ -0,0 +1,52 @@
+package notification
+
+import (
+	"PowerX/internal/model/wechat"
+	"context"
+	"encoding/json"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreateNotificationTemplateLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateNotificationTemplateLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateNotificationTemplateLogic {
+	return &CreateNotificationTemplateLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CreateNotificationTemplateLogic) CreateNotificationTemplate(req *types.CreateNotificationTemplateRequest) (resp *types.CreateNotificationTemplateReply, err error) {
+	template := TransformRequestToNotificationTemplate(&req.NotificationTemplate)
+	if err = l.svcCtx.PowerX.WechatNotification.CreateNotificationTemplate(l.ctx, template); err != nil {
+		return nil, err
+	}
+
+	return &types.CreateNotificationTemplateReply{
+		Id: template.Id,
+	}, nil
+}
+
+func TransformRequestToNotificationTemplate(templateRequest *types.NotificationTemplate) *wechat.WechatNotificationTemplate {
+	mappings, _ := json.Marshal(templateRequest.FieldMappings)
+	return &wechat.WechatNotificationTemplate{
+		Name:                templateRequest.Name,
+		Event:               templateRequest.Event,
+		Channel:             templateRequest.Channel,
+		TemplateId:          templateRequest.TemplateId,
+		Page:                templateRequest.Page,
+		MiniProgramAppId:    templateRequest.MiniProgramAppId,
+		MiniProgramPagePath: templateRequest.MiniProgramPagePath,
+		FieldMappings:       mappings,
+		Status:              templateRequest.Status,
+	}
+}
@@ -0,0 +1,35 @@
+package notification
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteNotificationTemplateLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeleteNotificationTemplateLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteNotificationTemplateLogic {
+	return &DeleteNotificationTemplateLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeleteNotificationTemplateLogic) DeleteNotificationTemplate(req *types.DeleteNotificationTemplateRequest) (resp *types.DeleteNotificationTemplateReply, err error) {
+	err = l.svcCtx.PowerX.WechatNotification.DeleteNotificationTemplate(l.ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.DeleteNotificationTemplateReply{
+		Id: req.Id,
+	}, nil
+}
@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"PowerX/internal/model/wechat"
+	wechatUC "PowerX/internal/uc/powerx/wechat"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListNotificationLogsPageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListNotificationLogsPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListNotificationLogsPageLogic {
+	return &ListNotificationLogsPageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListNotificationLogsPageLogic) ListNotificationLogsPage(req *types.ListNotificationLogsPageRequest) (resp *types.ListNotificationLogsPageReply, err error) {
+	page := l.svcCtx.PowerX.WechatNotification.FindManyNotificationLogs(l.ctx, &wechatUC.FindManyNotificationLogsOption{
+		Event:      req.Event,
+		Channel:    req.Channel,
+		Statuses:   req.Statuses,
+		CustomerId: req.CustomerId,
+		OrderId:    req.OrderId,
+		PageEmbedOption: types.PageEmbedOption{
+			PageIndex: req.PageIndex,
+			PageSize:  req.PageSize,
+		},
+	})
+
+	list := []*types.NotificationLog{}
+	for _, log := range page.List {
+		list = append(list, TransformNotificationLogToReply(log))
+	}
+	return &types.ListNotificationLogsPageReply{
+		List:      list,
+		PageIndex: page.PageIndex,
+		PageSize:  page.PageSize,
+		Total:     page.Total,
+	}, nil
+}
+
+func TransformNotificationLogToReply(log *wechat.WechatNotificationLog) *types.NotificationLog {
+	nextRetryAt, sentAt := "", ""
+	if log.NextRetryAt != nil {
+		nextRetryAt = log.NextRetryAt.String()
+	}
+	if log.SentAt != nil {
+		sentAt = log.SentAt.String()
+	}
+	return &types.NotificationLog{
+		Id:                     log.Id,
+		NotificationTemplateId: log.NotificationTemplateId,
+		Event:                  log.Event,
+		Channel:                log.Channel,
+		TemplateId:             log.TemplateId,
+		CustomerId:             log.CustomerId,
+		OrderId:                log.OrderId,
+		OpenId:                 log.OpenId,
+		Page:                   log.Page,
+		Data:                   string(log.Data),
+		Status:                 log.Status,
+		Attempts:               log.Attempts,
+		LastError:              log.LastError,
+		MsgId:                  log.MsgId,
+		NextRetryAt:            nextRetryAt,
+		SentAt:                 sentAt,
+		CreatedAt:              log.CreatedAt.String(),
+	}
+}
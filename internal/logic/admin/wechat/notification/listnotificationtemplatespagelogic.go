@@ -0,0 +1,69 @@
+package notification
+
+import (
+	"PowerX/internal/model/wechat"
+	wechatUC "PowerX/internal/uc/powerx/wechat"
+	"context"
+	"encoding/json"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListNotificationTemplatesPageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListNotificationTemplatesPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListNotificationTemplatesPageLogic {
+	return &ListNotificationTemplatesPageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListNotificationTemplatesPageLogic) ListNotificationTemplatesPage(req *types.ListNotificationTemplatesPageRequest) (resp *types.ListNotificationTemplatesPageReply, err error) {
+	page := l.svcCtx.PowerX.WechatNotification.FindManyNotificationTemplates(l.ctx, &wechatUC.FindManyNotificationTemplatesOption{
+		Events:   req.Events,
+		Channel:  req.Channel,
+		Status:   req.Status,
+		LikeName: req.LikeName,
+		PageEmbedOption: types.PageEmbedOption{
+			PageIndex: req.PageIndex,
+			PageSize:  req.PageSize,
+		},
+	})
+
+	list := []*types.NotificationTemplate{}
+	for _, template := range page.List {
+		list = append(list, TransformNotificationTemplateToReply(template))
+	}
+	return &types.ListNotificationTemplatesPageReply{
+		List:      list,
+		PageIndex: page.PageIndex,
+		PageSize:  page.PageSize,
+		Total:     page.Total,
+	}, nil
+}
+
+func TransformNotificationTemplateToReply(template *wechat.WechatNotificationTemplate) *types.NotificationTemplate {
+	mappings := map[string]string{}
+	_ = json.Unmarshal(template.FieldMappings, &mappings)
+	return &types.NotificationTemplate{
+		Id:                  template.Id,
+		Name:                template.Name,
+		Event:               template.Event,
+		Channel:             template.Channel,
+		TemplateId:          template.TemplateId,
+		Page:                template.Page,
+		MiniProgramAppId:    template.MiniProgramAppId,
+		MiniProgramPagePath: template.MiniProgramPagePath,
+		FieldMappings:       mappings,
+		Status:              template.Status,
+		CreatedAt:           template.CreatedAt.String(),
+	}
+}
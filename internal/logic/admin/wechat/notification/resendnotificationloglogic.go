@@ -0,0 +1,35 @@
+package notification
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ResendNotificationLogLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewResendNotificationLogLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ResendNotificationLogLogic {
+	return &ResendNotificationLogLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ResendNotificationLogLogic) ResendNotificationLog(req *types.ResendNotificationLogRequest) (resp *types.ResendNotificationLogReply, err error) {
+	err = l.svcCtx.PowerX.WechatNotification.ResendNotificationLog(l.ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ResendNotificationLogReply{
+		Id: req.Id,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package notification
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UpdateNotificationTemplateLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUpdateNotificationTemplateLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateNotificationTemplateLogic {
+	return &UpdateNotificationTemplateLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UpdateNotificationTemplateLogic) UpdateNotificationTemplate(req *types.UpdateNotificationTemplateRequest) (resp *types.UpdateNotificationTemplateReply, err error) {
+	template := TransformRequestToNotificationTemplate(&req.NotificationTemplate)
+	err = l.svcCtx.PowerX.WechatNotification.UpdateNotificationTemplate(l.ctx, req.TemplateId, template)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.UpdateNotificationTemplateReply{
+		Id: req.TemplateId,
+	}, nil
+}
@@ -0,0 +1,92 @@
+package autoreply
+
+import (
+	"PowerX/internal/model/wechat"
+	"PowerX/internal/types/errorx"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreateOAAutoReplyRuleLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateOAAutoReplyRuleLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateOAAutoReplyRuleLogic {
+	return &CreateOAAutoReplyRuleLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CreateOAAutoReplyRuleLogic) CreateOAAutoReplyRule(req *types.CreateOAAutoReplyRuleRequest) (resp *types.CreateOAAutoReplyRuleReply, err error) {
+	if err = CheckOAAutoReplyRule(&req.OAAutoReplyRule); err != nil {
+		return nil, err
+	}
+	rule := TransformRequestToOAAutoReplyRule(&req.OAAutoReplyRule)
+
+	l.svcCtx.PowerX.WechatOA.CreateOAAutoReplyRule(l.ctx, rule)
+
+	return &types.CreateOAAutoReplyRuleReply{
+		Id: rule.Id,
+	}, nil
+}
+
+// CheckOAAutoReplyRule 校验匹配条件与回复内容是否完整
+func CheckOAAutoReplyRule(rule *types.OAAutoReplyRule) error {
+	switch rule.MatchType {
+	case wechat.OAAutoReplyMatchTypeExact, wechat.OAAutoReplyMatchTypeFuzzy, wechat.OAAutoReplyMatchTypeClick:
+		if rule.Keyword == "" {
+			return errorx.WithCause(errorx.ErrBadRequest, "关键词/菜单Key不能为空")
+		}
+	}
+
+	switch rule.ReplyType {
+	case wechat.OAAutoReplyTypeText:
+		if rule.Content == "" {
+			return errorx.WithCause(errorx.ErrBadRequest, "文本内容不能为空")
+		}
+	case wechat.OAAutoReplyTypeImage:
+		if rule.MediaId == "" {
+			return errorx.WithCause(errorx.ErrBadRequest, "图片MediaId不能为空")
+		}
+	case wechat.OAAutoReplyTypeNews:
+		if rule.Title == "" || rule.Url == "" {
+			return errorx.WithCause(errorx.ErrBadRequest, "图文标题与链接不能为空")
+		}
+	case wechat.OAAutoReplyTypeMiniProgram:
+		if rule.AppId == "" || rule.PagePath == "" || rule.MediaId == "" {
+			return errorx.WithCause(errorx.ErrBadRequest, "小程序AppId、页面与封面MediaId不能为空")
+		}
+	}
+	return nil
+}
+
+func TransformRequestToOAAutoReplyRule(ruleRequest *types.OAAutoReplyRule) *wechat.WechatOAAutoReplyRule {
+	status := ruleRequest.Status
+	if status == 0 {
+		status = wechat.OAAutoReplyStatusEnable
+	}
+	return &wechat.WechatOAAutoReplyRule{
+		Name:        ruleRequest.Name,
+		MatchType:   ruleRequest.MatchType,
+		Keyword:     ruleRequest.Keyword,
+		ReplyType:   ruleRequest.ReplyType,
+		Content:     ruleRequest.Content,
+		MediaId:     ruleRequest.MediaId,
+		Title:       ruleRequest.Title,
+		Description: ruleRequest.Description,
+		Url:         ruleRequest.Url,
+		PicUrl:      ruleRequest.PicUrl,
+		AppId:       ruleRequest.AppId,
+		PagePath:    ruleRequest.PagePath,
+		Priority:    ruleRequest.Priority,
+		Status:      status,
+	}
+}
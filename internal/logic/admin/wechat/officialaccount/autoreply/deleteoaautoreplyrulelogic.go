@@ -0,0 +1,35 @@
+package autoreply
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteOAAutoReplyRuleLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeleteOAAutoReplyRuleLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteOAAutoReplyRuleLogic {
+	return &DeleteOAAutoReplyRuleLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeleteOAAutoReplyRuleLogic) DeleteOAAutoReplyRule(req *types.DeleteOAAutoReplyRuleRequest) (resp *types.DeleteOAAutoReplyRuleReply, err error) {
+	err = l.svcCtx.PowerX.WechatOA.DeleteOAAutoReplyRule(l.ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.DeleteOAAutoReplyRuleReply{
+		Id: req.Id,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package autoreply
+
+import (
+	"PowerX/internal/model/wechat"
+	wechatUC "PowerX/internal/uc/powerx/wechat"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListOAAutoReplyRulesPageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListOAAutoReplyRulesPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListOAAutoReplyRulesPageLogic {
+	return &ListOAAutoReplyRulesPageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListOAAutoReplyRulesPageLogic) ListOAAutoReplyRulesPage(req *types.ListOAAutoReplyRulesPageRequest) (resp *types.ListOAAutoReplyRulesPageReply, err error) {
+	page := l.svcCtx.PowerX.WechatOA.FindManyOAAutoReplyRules(l.ctx, &wechatUC.FindManyOAAutoReplyRulesOption{
+		MatchTypes: req.MatchTypes,
+		Status:     req.Status,
+		LikeName:   req.LikeName,
+		PageEmbedOption: types.PageEmbedOption{
+			PageIndex: req.PageIndex,
+			PageSize:  req.PageSize,
+		},
+	})
+
+	list := []*types.OAAutoReplyRule{}
+	for _, rule := range page.List {
+		list = append(list, TransformOAAutoReplyRuleToReply(rule))
+	}
+	return &types.ListOAAutoReplyRulesPageReply{
+		List:      list,
+		PageIndex: page.PageIndex,
+		PageSize:  page.PageSize,
+		Total:     page.Total,
+	}, nil
+}
+
+func TransformOAAutoReplyRuleToReply(rule *wechat.WechatOAAutoReplyRule) *types.OAAutoReplyRule {
+	return &types.OAAutoReplyRule{
+		Id:          rule.Id,
+		Name:        rule.Name,
+		MatchType:   rule.MatchType,
+		Keyword:     rule.Keyword,
+		ReplyType:   rule.ReplyType,
+		Content:     rule.Content,
+		MediaId:     rule.MediaId,
+		Title:       rule.Title,
+		Description: rule.Description,
+		Url:         rule.Url,
+		PicUrl:      rule.PicUrl,
+		AppId:       rule.AppId,
+		PagePath:    rule.PagePath,
+		Priority:    rule.Priority,
+		Status:      rule.Status,
+		CreatedAt:   rule.CreatedAt.String(),
+	}
+}
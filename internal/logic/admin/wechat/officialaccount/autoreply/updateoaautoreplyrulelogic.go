@@ -0,0 +1,40 @@
+package autoreply
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UpdateOAAutoReplyRuleLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUpdateOAAutoReplyRuleLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateOAAutoReplyRuleLogic {
+	return &UpdateOAAutoReplyRuleLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UpdateOAAutoReplyRuleLogic) UpdateOAAutoReplyRule(req *types.UpdateOAAutoReplyRuleRequest) (resp *types.UpdateOAAutoReplyRuleReply, err error) {
+	if err = CheckOAAutoReplyRule(&req.OAAutoReplyRule); err != nil {
+		return nil, err
+	}
+	rule := TransformRequestToOAAutoReplyRule(&req.OAAutoReplyRule)
+
+	err = l.svcCtx.PowerX.WechatOA.UpdateOAAutoReplyRule(l.ctx, req.RuleId, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.UpdateOAAutoReplyRuleReply{
+		Id: req.RuleId,
+	}, nil
+}
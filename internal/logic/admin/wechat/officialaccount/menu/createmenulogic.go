@@ -1,6 +1,7 @@
 package menu
 
 import (
+	"PowerX/internal/model/wechat"
 	"context"
 
 	"PowerX/internal/svc"
@@ -24,7 +25,40 @@ func NewCreateMenuLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Create
 }
 
 func (l *CreateMenuLogic) CreateMenu(req *types.CreateMenuRequest) (resp *types.CreateMenuReply, err error) {
-	// todo: add your logic here and delete this line
+	menu := TransformRequestToOAMenuModel(&req.OAMenu)
+	menu, err = l.svcCtx.PowerX.WechatOA.SaveOAMenuDraft(l.ctx, menu, TransformRequestToWechatOAMenu(&req.OAMenu), req.Remark)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.CreateMenuReply{
+		Success: true,
+		Data: &types.UpdateOAMenuDraftReply{
+			Id:           menu.Id,
+			DraftVersion: menu.DraftVersion,
+		},
+	}, nil
+}
 
-	return
+// TransformRequestToOAMenuModel 带匹配规则的视为个性化菜单
+func TransformRequestToOAMenuModel(req *types.OAMenu) *wechat.WechatOAMenu {
+	menu := &wechat.WechatOAMenu{
+		Name:     req.Name,
+		MenuType: wechat.OAMenuTypeDefault,
+	}
+	if req.MatchRule != nil {
+		menu.OAMenuMatchRule = wechat.OAMenuMatchRule{
+			TagId:              req.MatchRule.TagId,
+			Sex:                req.MatchRule.Sex,
+			ClientPlatformType: req.MatchRule.ClientPlatformType,
+			Country:            req.MatchRule.Country,
+			Province:           req.MatchRule.Province,
+			City:               req.MatchRule.City,
+			Language:           req.MatchRule.Language,
+		}
+		if !menu.OAMenuMatchRule.IsEmpty() {
+			menu.MenuType = wechat.OAMenuTypeConditional
+		}
+	}
+	return menu
 }
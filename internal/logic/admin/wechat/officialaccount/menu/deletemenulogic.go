@@ -24,7 +24,12 @@ func NewDeleteMenuLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Delete
 }
 
 func (l *DeleteMenuLogic) DeleteMenu() (resp *types.DeleteMenuReply, err error) {
-	// todo: add your logic here and delete this line
+	err = l.svcCtx.PowerX.WechatOA.DeleteOAPublishedMenus(l.ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	return
+	return &types.DeleteMenuReply{
+		Success: true,
+	}, nil
 }
@@ -0,0 +1,35 @@
+package menu
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteOAMenuDraftLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeleteOAMenuDraftLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteOAMenuDraftLogic {
+	return &DeleteOAMenuDraftLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeleteOAMenuDraftLogic) DeleteOAMenuDraft(req *types.DeleteOAMenuDraftRequest) (resp *types.DeleteOAMenuDraftReply, err error) {
+	err = l.svcCtx.PowerX.WechatOA.DeleteOAMenu(l.ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.DeleteOAMenuDraftReply{
+		Id: req.Id,
+	}, nil
+}
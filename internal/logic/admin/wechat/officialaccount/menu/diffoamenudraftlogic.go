@@ -0,0 +1,45 @@
+package menu
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DiffOAMenuDraftLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDiffOAMenuDraftLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DiffOAMenuDraftLogic {
+	return &DiffOAMenuDraftLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DiffOAMenuDraftLogic) DiffOAMenuDraft(req *types.DiffOAMenuDraftRequest) (resp *types.DiffOAMenuDraftReply, err error) {
+	diffs, err := l.svcCtx.PowerX.WechatOA.DiffOAMenu(l.ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*types.OAMenuDiff{}
+	for _, diff := range diffs {
+		list = append(list, &types.OAMenuDiff{
+			Path:   diff.Path,
+			Action: diff.Action,
+			Remote: diff.Remote,
+			Local:  diff.Local,
+		})
+	}
+	return &types.DiffOAMenuDraftReply{
+		Same: len(list) == 0,
+		List: list,
+	}, nil
+}
@@ -0,0 +1,103 @@
+package menu
+
+import (
+	"PowerX/internal/model/wechat"
+	wechatUC "PowerX/internal/uc/powerx/wechat"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/officialAccount/menu/request"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetOAMenuDraftLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetOAMenuDraftLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetOAMenuDraftLogic {
+	return &GetOAMenuDraftLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetOAMenuDraftLogic) GetOAMenuDraft(req *types.GetOAMenuDraftRequest) (resp *types.GetOAMenuDraftReply, err error) {
+	menu, err := l.svcCtx.PowerX.WechatOA.GetOAMenu(l.ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []*types.OAMenuVersion{}
+	for _, version := range menu.Versions {
+		versions = append(versions, &types.OAMenuVersion{
+			Version:   version.Version,
+			Remark:    version.Remark,
+			OAButton:  TransformWechatOAMenuToReply(wechatUC.DecodeOAMenuButtons(version)),
+			CreatedAt: version.CreatedAt.String(),
+		})
+	}
+	return &types.GetOAMenuDraftReply{
+		OAMenuDraft: TransformOAMenuToReply(menu),
+		Versions:    versions,
+	}, nil
+}
+
+func TransformOAMenuToReply(menu *wechat.WechatOAMenu) *types.OAMenuDraft {
+	publishedAt := ""
+	if menu.PublishedAt != nil {
+		publishedAt = menu.PublishedAt.String()
+	}
+	return &types.OAMenuDraft{
+		Id:       menu.Id,
+		Name:     menu.Name,
+		MenuType: menu.MenuType,
+		MatchRule: &types.MatchRule{
+			TagId:              menu.TagId,
+			Sex:                menu.Sex,
+			Country:            menu.Country,
+			Province:           menu.Province,
+			City:               menu.City,
+			Language:           menu.Language,
+			ClientPlatformType: menu.ClientPlatformType,
+		},
+		DraftVersion:     menu.DraftVersion,
+		PublishedVersion: menu.PublishedVersion,
+		WechatMenuId:     menu.WechatMenuId,
+		PublishedAt:      publishedAt,
+		CreatedAt:        menu.CreatedAt.String(),
+	}
+}
+
+func TransformWechatOAMenuToReply(buttons []*request.Button) []*types.OAButton {
+	oaButtons := []*types.OAButton{}
+	for _, button := range buttons {
+		oaSubButtons := []*types.OASubButton{}
+		for _, sub := range button.SubButtons {
+			oaSubButtons = append(oaSubButtons, &types.OASubButton{
+				Name:     sub.Name,
+				Type:     sub.Type,
+				Key:      sub.Key,
+				Url:      sub.URL,
+				AppID:    sub.AppID,
+				PagePath: sub.PagePath,
+				MediaId:  sub.MediaId,
+			})
+		}
+		oaButtons = append(oaButtons, &types.OAButton{
+			Name:        button.Name,
+			Type:        button.Type,
+			Key:         button.Key,
+			Url:         button.URL,
+			AppID:       button.AppID,
+			PagePath:    button.PagePath,
+			MediaId:     button.MediaId,
+			OASubButton: oaSubButtons,
+		})
+	}
+	return oaButtons
+}
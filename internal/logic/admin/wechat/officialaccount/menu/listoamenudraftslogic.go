@@ -0,0 +1,36 @@
+package menu
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListOAMenuDraftsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListOAMenuDraftsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListOAMenuDraftsLogic {
+	return &ListOAMenuDraftsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListOAMenuDraftsLogic) ListOAMenuDrafts() (resp *types.ListOAMenuDraftsReply, err error) {
+	menus := l.svcCtx.PowerX.WechatOA.FindAllOAMenus(l.ctx)
+
+	list := []*types.OAMenuDraft{}
+	for _, menu := range menus {
+		list = append(list, TransformOAMenuToReply(menu))
+	}
+	return &types.ListOAMenuDraftsReply{
+		List: list,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package menu
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type PublishOAMenuDraftLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewPublishOAMenuDraftLogic(ctx context.Context, svcCtx *svc.ServiceContext) *PublishOAMenuDraftLogic {
+	return &PublishOAMenuDraftLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *PublishOAMenuDraftLogic) PublishOAMenuDraft(req *types.PublishOAMenuDraftRequest) (resp *types.PublishOAMenuDraftReply, err error) {
+	menu, err := l.svcCtx.PowerX.WechatOA.PublishOAMenu(l.ctx, req.Id, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.PublishOAMenuDraftReply{
+		Id:               menu.Id,
+		PublishedVersion: menu.PublishedVersion,
+	}, nil
+}
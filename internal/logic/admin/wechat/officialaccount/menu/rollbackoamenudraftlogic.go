@@ -0,0 +1,37 @@
+package menu
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RollbackOAMenuDraftLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRollbackOAMenuDraftLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RollbackOAMenuDraftLogic {
+	return &RollbackOAMenuDraftLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RollbackOAMenuDraftLogic) RollbackOAMenuDraft(req *types.RollbackOAMenuDraftRequest) (resp *types.RollbackOAMenuDraftReply, err error) {
+	menu, err := l.svcCtx.PowerX.WechatOA.RollbackOAMenu(l.ctx, req.Id, req.Version, req.Publish)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.RollbackOAMenuDraftReply{
+		Id:               menu.Id,
+		DraftVersion:     menu.DraftVersion,
+		PublishedVersion: menu.PublishedVersion,
+	}, nil
+}
@@ -1,6 +1,8 @@
 package menu
 
 import (
+	model "PowerX/internal/model/wechat"
+	"PowerX/internal/uc/powerx/wechat"
 	"context"
 	"github.com/ArtisanCloud/PowerWeChat/v3/src/officialAccount/menu/request"
 
@@ -26,17 +28,23 @@ func NewSyncMenusLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SyncMen
 
 func (l *SyncMenusLogic) SyncMenus(req *types.SyncMenusRequest) (resp *types.SyncMenusReply, err error) {
 
-	//resDelete, err := l.svcCtx.PowerX.WechatOA.App.Menu.Delete(l.ctx)
-	//if err != nil {
-	//	return nil, err
-	//}
-	//if resDelete.ErrCode != 0 {
-	//	return nil, errorx.WithCause(errorx.ErrDeleteObject, resDelete.ErrMsg)
-	//}
-
+	// 直接同步视为保存默认菜单草稿并立即发布, 保证本地有版本记录
 	buttons := TransformRequestToWechatOAMenu(&req.OAMenu)
-	//fmt.Dump(buttons)
-	_, err = l.svcCtx.PowerX.WechatOA.App.Menu.Create(l.ctx, buttons)
+	if err = wechat.ValidateOAMenuButtons(buttons); err != nil {
+		return nil, err
+	}
+
+	menu := &model.WechatOAMenu{Name: req.Name, MenuType: model.OAMenuTypeDefault}
+	for _, mdlMenu := range l.svcCtx.PowerX.WechatOA.FindAllOAMenus(l.ctx) {
+		if mdlMenu.MenuType == model.OAMenuTypeDefault {
+			menu = mdlMenu
+		}
+	}
+	menu, err = l.svcCtx.PowerX.WechatOA.SaveOAMenuDraft(l.ctx, menu, buttons, req.Remark)
+	if err != nil {
+		return nil, err
+	}
+	_, err = l.svcCtx.PowerX.WechatOA.PublishOAMenu(l.ctx, menu.Id, menu.DraftVersion)
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +70,7 @@ func TransformRequestToWechatOAButton(oaButton *types.OAButton) *request.Button
 		Type: oaButton.Type,
 		Name: oaButton.Name,
 		Key:  oaButton.Key,
-		//MediaId:    oaButton.MediaId,
+		MediaId:    oaButton.MediaId,
 		URL:        oaButton.Url,
 		AppID:      oaButton.AppID,
 		PagePath:   oaButton.PagePath,
@@ -87,6 +95,7 @@ func TransformRequestToWechatOASubButton(oaSuhButton *types.OASubButton) request
 		AppID:    oaSuhButton.AppID,
 		PagePath: oaSuhButton.PagePath,
 		Key:      oaSuhButton.Key,
+		MediaId:  oaSuhButton.MediaId,
 	}
 
 }
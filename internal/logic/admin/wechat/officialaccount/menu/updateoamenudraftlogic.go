@@ -0,0 +1,38 @@
+package menu
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UpdateOAMenuDraftLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUpdateOAMenuDraftLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateOAMenuDraftLogic {
+	return &UpdateOAMenuDraftLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UpdateOAMenuDraftLogic) UpdateOAMenuDraft(req *types.UpdateOAMenuDraftRequest) (resp *types.UpdateOAMenuDraftReply, err error) {
+	menu := TransformRequestToOAMenuModel(&req.OAMenu)
+	menu.Id = req.MenuId
+	menu, err = l.svcCtx.PowerX.WechatOA.SaveOAMenuDraft(l.ctx, menu, TransformRequestToWechatOAMenu(&req.OAMenu), req.Remark)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.UpdateOAMenuDraftReply{
+		Id:           menu.Id,
+		DraftVersion: menu.DraftVersion,
+	}, nil
+}
@@ -0,0 +1,51 @@
+package qrcode
+
+import (
+	"PowerX/internal/model/wechat"
+	"PowerX/internal/types/errorx"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreateOAQrcodeLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateOAQrcodeLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateOAQrcodeLogic {
+	return &CreateOAQrcodeLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CreateOAQrcodeLogic) CreateOAQrcode(req *types.CreateOAQrcodeRequest) (resp *types.CreateOAQrcodeReply, err error) {
+	if req.Name == "" {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "二维码名称不能为空")
+	}
+	if len(req.SceneStr) > 64 {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "场景值最长64个字符")
+	}
+
+	qrcode, err := l.svcCtx.PowerX.WechatOA.CreateOAQrcode(l.ctx, &wechat.WechatOAQrcode{
+		Name:          req.Name,
+		Channel:       req.Channel,
+		Campaign:      req.Campaign,
+		QrType:        req.QrType,
+		SceneStr:      req.SceneStr,
+		ExpireSeconds: req.ExpireSeconds,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.CreateOAQrcodeReply{
+		OAQrcode: TransformOAQrcodeToReply(l.svcCtx, qrcode),
+	}, nil
+}
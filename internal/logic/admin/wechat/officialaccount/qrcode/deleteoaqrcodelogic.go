@@ -0,0 +1,35 @@
+package qrcode
+
+import (
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteOAQrcodeLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeleteOAQrcodeLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteOAQrcodeLogic {
+	return &DeleteOAQrcodeLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeleteOAQrcodeLogic) DeleteOAQrcode(req *types.DeleteOAQrcodeRequest) (resp *types.DeleteOAQrcodeReply, err error) {
+	err = l.svcCtx.PowerX.WechatOA.DeleteOAQrcode(l.ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.DeleteOAQrcodeReply{
+		Id: req.Id,
+	}, nil
+}
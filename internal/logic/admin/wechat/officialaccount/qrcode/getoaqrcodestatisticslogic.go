@@ -0,0 +1,72 @@
+package qrcode
+
+import (
+	"PowerX/internal/types/errorx"
+	"context"
+	"time"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetOAQrcodeStatisticsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetOAQrcodeStatisticsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetOAQrcodeStatisticsLogic {
+	return &GetOAQrcodeStatisticsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetOAQrcodeStatisticsLogic) GetOAQrcodeStatistics(req *types.GetOAQrcodeStatisticsRequest) (resp *types.GetOAQrcodeStatisticsReply, err error) {
+	end := time.Now()
+	start := end.AddDate(0, 0, -6)
+	if req.StartDate != "" {
+		if start, err = time.ParseInLocation(time.DateOnly, req.StartDate, time.Local); err != nil {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "开始日期格式错误")
+		}
+	}
+	if req.EndDate != "" {
+		if end, err = time.ParseInLocation(time.DateOnly, req.EndDate, time.Local); err != nil {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "结束日期格式错误")
+		}
+	}
+
+	statistics, err := l.svcCtx.PowerX.WechatOA.FindOAQrcodeStatistics(l.ctx, req.Id, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	daily := []*types.OAQrcodeDailyStatistics{}
+	for _, day := range statistics.Daily {
+		daily = append(daily, &types.OAQrcodeDailyStatistics{
+			Date:         day.Date,
+			Scans:        day.Scans,
+			Subscribes:   day.Subscribes,
+			NewFans:      day.NewFans,
+			Unsubscribes: day.Unsubscribe,
+			Retained:     day.Retained,
+		})
+	}
+	rate := 0.0
+	if statistics.Subscribes > 0 {
+		rate = float64(statistics.Retained) / float64(statistics.Subscribes)
+	}
+
+	return &types.GetOAQrcodeStatisticsReply{
+		Scans:         statistics.Scans,
+		Subscribes:    statistics.Subscribes,
+		NewFans:       statistics.NewFans,
+		Unsubscribes:  statistics.Unsubscribe,
+		Retained:      statistics.Retained,
+		RetentionRate: rate,
+		Daily:         daily,
+	}, nil
+}
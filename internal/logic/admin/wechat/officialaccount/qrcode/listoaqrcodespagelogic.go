@@ -0,0 +1,72 @@
+package qrcode
+
+import (
+	"PowerX/internal/model/wechat"
+	wechatUC "PowerX/internal/uc/powerx/wechat"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListOAQrcodesPageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListOAQrcodesPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListOAQrcodesPageLogic {
+	return &ListOAQrcodesPageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListOAQrcodesPageLogic) ListOAQrcodesPage(req *types.ListOAQrcodesPageRequest) (resp *types.ListOAQrcodesPageReply, err error) {
+	page := l.svcCtx.PowerX.WechatOA.FindManyOAQrcodes(l.ctx, &wechatUC.FindManyOAQrcodesOption{
+		Channel:  req.Channel,
+		Campaign: req.Campaign,
+		QrType:   req.QrType,
+		LikeName: req.LikeName,
+		PageEmbedOption: types.PageEmbedOption{
+			PageIndex: req.PageIndex,
+			PageSize:  req.PageSize,
+		},
+	})
+
+	list := []*types.OAQrcode{}
+	for _, qrcode := range page.List {
+		list = append(list, TransformOAQrcodeToReply(l.svcCtx, qrcode))
+	}
+	return &types.ListOAQrcodesPageReply{
+		List:      list,
+		PageIndex: page.PageIndex,
+		PageSize:  page.PageSize,
+		Total:     page.Total,
+	}, nil
+}
+
+func TransformOAQrcodeToReply(svcCtx *svc.ServiceContext, qrcode *wechat.WechatOAQrcode) *types.OAQrcode {
+	expiredAt := ""
+	if qrcode.ExpiredAt != nil {
+		expiredAt = qrcode.ExpiredAt.String()
+	}
+	return &types.OAQrcode{
+		Id:            qrcode.Id,
+		Name:          qrcode.Name,
+		Channel:       qrcode.Channel,
+		Campaign:      qrcode.Campaign,
+		QrType:        qrcode.QrType,
+		SceneStr:      qrcode.SceneStr,
+		ExpireSeconds: qrcode.ExpireSeconds,
+		Ticket:        qrcode.Ticket,
+		Url:           qrcode.Url,
+		ImageUrl:      svcCtx.PowerX.WechatOA.OAQrcodeImageUrl(qrcode),
+		ExpiredAt:     expiredAt,
+		Expired:       qrcode.IsExpired(),
+		CreatedAt:     qrcode.CreatedAt.String(),
+	}
+}
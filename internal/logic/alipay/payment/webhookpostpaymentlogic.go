@@ -0,0 +1,32 @@
+package payment
+
+import (
+	"PowerX/internal/logic/mp/crm/trade/payment"
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/svc"
+	"context"
+	"github.com/zeromicro/go-zero/core/logx"
+	"net/http"
+)
+
+type AlipayPostPaymentLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewAlipayPostPaymentLogic(ctx context.Context, svcCtx *svc.ServiceContext) *AlipayPostPaymentLogic {
+	return &AlipayPostPaymentLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *AlipayPostPaymentLogic) WebhookAlipayPostPayment(w http.ResponseWriter, r *http.Request) {
+
+	// 支付宝异步通知验签后统一处理, 应答 success/fail
+	payment.NewHandlePaymentNotificationLogic(l.ctx, l.svcCtx).HandleWebhook(w, r, trade.PaymentTypeAlipay)
+
+	return
+}
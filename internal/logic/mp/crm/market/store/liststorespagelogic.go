@@ -2,6 +2,9 @@ package store
 
 import (
 	product2 "PowerX/internal/logic/admin/crm/market/store"
+	market2 "PowerX/internal/model/crm/market"
+	"PowerX/internal/model/powermodel"
+	"PowerX/internal/types/errorx"
 	"PowerX/internal/uc/powerx/crm/market"
 	"context"
 
@@ -26,9 +29,14 @@ func NewListStoresPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Li
 }
 
 func (l *ListStoresPageLogic) ListStoresPage(req *types.ListStoresPageRequest) (resp *types.ListStoresPageReply, err error) {
+	querySpec, err := powermodel.NewQuerySpec(market2.StoreQueryFields, req.OrderBy, req.Filters)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+
 	stores, err := l.svcCtx.PowerX.Store.FindManyStores(l.ctx, &market.FindManyStoresOption{
-		LikeName: req.LikeName,
-		OrderBy:  req.OrderBy,
+		LikeName:  req.LikeName,
+		QuerySpec: querySpec,
 		PageEmbedOption: types.PageEmbedOption{
 			PageIndex: req.PageIndex,
 			PageSize:  req.PageSize,
@@ -2,6 +2,9 @@ package artisan
 
 import (
 	"PowerX/internal/logic/admin/crm/product/artisan"
+	"PowerX/internal/model/crm/product"
+	"PowerX/internal/model/powermodel"
+	"PowerX/internal/types/errorx"
 	product3 "PowerX/internal/uc/powerx/crm/product"
 	"context"
 
@@ -26,10 +29,15 @@ func NewListArtisansPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *
 }
 
 func (l *ListArtisansPageLogic) ListArtisansPage(req *types.ListArtisansPageRequest) (resp *types.ListArtisansPageReply, err error) {
+	querySpec, err := powermodel.NewQuerySpec(product.ArtisanQueryFields, req.OrderBy, req.Filters)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+
 	artisans, err := l.svcCtx.PowerX.Artisan.FindManyArtisans(l.ctx, &product3.FindManyArtisanOption{
-		LikeName: req.LikeName,
-		OrderBy:  req.OrderBy,
-		StoreIds: req.StoreIds,
+		LikeName:  req.LikeName,
+		QuerySpec: querySpec,
+		StoreIds:  req.StoreIds,
 		PageEmbedOption: types.PageEmbedOption{
 			PageIndex: req.PageIndex,
 			PageSize:  req.PageSize,
@@ -0,0 +1,50 @@
+package booking
+
+import (
+	adminBooking "PowerX/internal/logic/admin/crm/product/booking"
+	customerdomain2 "PowerX/internal/model/crm/customerdomain"
+	"PowerX/internal/types/errorx"
+	"PowerX/internal/uc/powerx/crm/customerdomain"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CancelBookingLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCancelBookingLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CancelBookingLogic {
+	return &CancelBookingLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CancelBookingLogic) CancelBooking(req *types.CancelBookingRequest) (resp *types.CancelBookingReply, err error) {
+	vAuthCustomer := l.ctx.Value(customerdomain.AuthCustomerKey)
+	authCustomer := vAuthCustomer.(*customerdomain2.Customer)
+
+	booking, err := l.svcCtx.PowerX.Booking.GetBooking(l.ctx, req.BookingId)
+	if err != nil {
+		return nil, err
+	}
+	if booking.CustomerId != authCustomer.Id {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "只能操作自己的预约")
+	}
+
+	err = l.svcCtx.PowerX.Booking.CancelBooking(l.ctx, booking, req.Reason, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.CancelBookingReply{
+		Booking: adminBooking.TransformBookingToReply(booking),
+	}, nil
+}
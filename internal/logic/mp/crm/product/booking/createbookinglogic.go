@@ -0,0 +1,53 @@
+package booking
+
+import (
+	adminBooking "PowerX/internal/logic/admin/crm/product/booking"
+	customerdomain2 "PowerX/internal/model/crm/customerdomain"
+	"PowerX/internal/model/crm/product"
+	"PowerX/internal/uc/powerx/crm/customerdomain"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreateBookingLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateBookingLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateBookingLogic {
+	return &CreateBookingLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CreateBookingLogic) CreateBooking(req *types.CreateBookingRequest) (resp *types.CreateBookingReply, err error) {
+	vAuthCustomer := l.ctx.Value(customerdomain.AuthCustomerKey)
+	authCustomer := vAuthCustomer.(*customerdomain2.Customer)
+
+	startAt, err := adminBooking.ParseBookingTime(req.StartAt)
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := l.svcCtx.PowerX.Booking.CreateBooking(l.ctx, authCustomer.Id, &product.Booking{
+		StoreId:     req.StoreId,
+		ArtisanId:   req.ArtisanId,
+		OrderItemId: req.OrderItemId,
+		StartAt:     startAt,
+		Comment:     req.Comment,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.CreateBookingReply{
+		Booking: adminBooking.TransformBookingToReply(booking),
+	}, nil
+}
@@ -0,0 +1,44 @@
+package booking
+
+import (
+	adminBooking "PowerX/internal/logic/admin/crm/product/booking"
+	"PowerX/internal/types/errorx"
+	"PowerX/pkg/datetime/carbonx"
+	"context"
+	"github.com/golang-module/carbon/v2"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListBookingSlotsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListBookingSlotsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListBookingSlotsLogic {
+	return &ListBookingSlotsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListBookingSlotsLogic) ListBookingSlots(req *types.ListBookingSlotsRequest) (resp *types.ListBookingSlotsReply, err error) {
+	date := carbon.ParseByFormat(req.Date, carbonx.DateFormat)
+	if date.Error != nil || date.IsInvalid() {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "日期格式错误")
+	}
+
+	slots, err := l.svcCtx.PowerX.Booking.GetAvailableSlots(l.ctx, req.StoreId, req.ArtisanId, date.ToStdTime())
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ListBookingSlotsReply{
+		List: adminBooking.TransformTimeSlotsToReply(slots),
+	}, nil
+}
@@ -0,0 +1,49 @@
+package booking
+
+import (
+	adminBooking "PowerX/internal/logic/admin/crm/product/booking"
+	customerdomain2 "PowerX/internal/model/crm/customerdomain"
+	"PowerX/internal/uc/powerx/crm/customerdomain"
+	productUC "PowerX/internal/uc/powerx/crm/product"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListBookingsPageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListBookingsPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListBookingsPageLogic {
+	return &ListBookingsPageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListBookingsPageLogic) ListBookingsPage(req *types.ListMPBookingsPageRequest) (resp *types.ListBookingsPageReply, err error) {
+	vAuthCustomer := l.ctx.Value(customerdomain.AuthCustomerKey)
+	authCustomer := vAuthCustomer.(*customerdomain2.Customer)
+
+	page := l.svcCtx.PowerX.Booking.FindManyBookings(l.ctx, &productUC.FindManyBookingsOption{
+		CustomerId: authCustomer.Id,
+		Statuses:   req.Statuses,
+		PageEmbedOption: types.PageEmbedOption{
+			PageIndex: req.PageIndex,
+			PageSize:  req.PageSize,
+		},
+	})
+
+	return &types.ListBookingsPageReply{
+		List:      adminBooking.TransformBookingsToReply(page.List),
+		PageIndex: page.PageIndex,
+		PageSize:  page.PageSize,
+		Total:     page.Total,
+	}, nil
+}
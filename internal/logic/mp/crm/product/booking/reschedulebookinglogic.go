@@ -0,0 +1,54 @@
+package booking
+
+import (
+	adminBooking "PowerX/internal/logic/admin/crm/product/booking"
+	customerdomain2 "PowerX/internal/model/crm/customerdomain"
+	"PowerX/internal/types/errorx"
+	"PowerX/internal/uc/powerx/crm/customerdomain"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RescheduleBookingLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRescheduleBookingLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RescheduleBookingLogic {
+	return &RescheduleBookingLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RescheduleBookingLogic) RescheduleBooking(req *types.RescheduleBookingRequest) (resp *types.RescheduleBookingReply, err error) {
+	vAuthCustomer := l.ctx.Value(customerdomain.AuthCustomerKey)
+	authCustomer := vAuthCustomer.(*customerdomain2.Customer)
+
+	startAt, err := adminBooking.ParseBookingTime(req.StartAt)
+	if err != nil {
+		return nil, err
+	}
+	booking, err := l.svcCtx.PowerX.Booking.GetBooking(l.ctx, req.BookingId)
+	if err != nil {
+		return nil, err
+	}
+	if booking.CustomerId != authCustomer.Id {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "只能操作自己的预约")
+	}
+
+	err = l.svcCtx.PowerX.Booking.RescheduleBooking(l.ctx, booking, startAt, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.RescheduleBookingReply{
+		Booking: adminBooking.TransformBookingToReply(booking),
+	}, nil
+}
@@ -2,6 +2,7 @@ package product
 
 import (
 	"PowerX/internal/logic/admin/crm/product/pricebookentry"
+	"PowerX/internal/logic/admin/crm/product/productbundle"
 	"PowerX/internal/logic/mp/mediaresource"
 	"PowerX/internal/model"
 	"PowerX/internal/model/crm/product"
@@ -11,6 +12,7 @@ import (
 	"PowerX/internal/types/errorx"
 	"context"
 	"github.com/zeromicro/go-zero/core/logx"
+	"time"
 )
 
 type GetProductLogic struct {
@@ -33,6 +35,20 @@ func (l *GetProductLogic) GetProduct(req *types.GetProductRequest) (resp *types.
 	if err != nil {
 		return nil, errorx.ErrNotFoundObject
 	}
+	// 未审核通过、未激活或不在售卖时间内的产品不对客户展示
+	if !mdlProduct.IsActivated || !mdlProduct.IsInSalePeriod(time.Now()) ||
+		!l.svcCtx.PowerX.ProductApproval.IsProductApproved(l.ctx, mdlProduct) {
+		return nil, errorx.ErrNotFoundObject
+	}
+	// 门店上下文中只展示已上架到门店且门店可售的产品
+	if req.StoreId > 0 {
+		if !l.svcCtx.PowerX.StoreProduct.IsProductAvailableInStore(l.ctx, req.StoreId, mdlProduct.Id) {
+			return nil, errorx.ErrNotFoundObject
+		}
+		l.svcCtx.PowerX.StoreProduct.ApplyStoreContext(l.ctx, req.StoreId, []*product.Product{mdlProduct})
+	}
+
+	l.svcCtx.PowerX.ProductStatistics.RecordProductView(l.ctx, mdlProduct.Id)
 
 	return &types.GetProductReply{
 		Product: TransformProductToReplyForMP(mdlProduct),
@@ -88,6 +104,10 @@ func TransformProductToReplyForMP(mdlProduct *product.Product) (productReply *ty
 		ProductSpecifics:       TransformSpecificsToReplyForMP(mdlProduct.ProductSpecifics),
 		ActivePriceEntry:       TransformPriceEntryToReplyForMP(mdlProduct.PriceBookEntries),
 		SKUs:                   TransformSkusToReplyForMP(mdlProduct.SKUs),
+		IsBundle:               mdlProduct.IsBundle,
+		// 组合商品的可售数量由组件库存决定
+		BundleItems:             productbundle.TransformProductBundleItemsToReply(mdlProduct.BundleItems),
+		BundleAvailableQuantity: product.BundleAvailableQuantity(mdlProduct.BundleItems),
 		ProductAttribute: &types.ProductAttribute{
 			Inventory:  mdlProduct.Inventory,
 			SoldAmount: mdlProduct.SoldAmount,
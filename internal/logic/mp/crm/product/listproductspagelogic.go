@@ -2,6 +2,8 @@ package product
 
 import (
 	product2 "PowerX/internal/model/crm/product"
+	"PowerX/internal/model/powermodel"
+	"PowerX/internal/types/errorx"
 	productUC "PowerX/internal/uc/powerx/crm/product"
 	"context"
 
@@ -26,6 +28,11 @@ func NewListProductsPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *
 }
 
 func (l *ListProductsPageLogic) ListProductsPage(req *types.ListProductsPageRequest) (resp *types.ListProductsPageReply, err error) {
+	querySpec, err := powermodel.NewQuerySpec(product2.ProductQueryFields, req.OrderBy, req.Filters)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+
 	if req.ProductCategoryId <= 0 {
 		return &types.ListProductsPageReply{
 			List:      nil,
@@ -38,7 +45,10 @@ func (l *ListProductsPageLogic) ListProductsPage(req *types.ListProductsPageRequ
 	page, err := l.svcCtx.PowerX.Product.FindManyProducts(l.ctx, &productUC.FindManyProductsOption{
 		CategoryId:    req.ProductCategoryId,
 		NeedActivated: true,
+		OnlyOnSale:    true,
+		StoreId:       req.StoreId,
 		//OrderBy:       "sort desc",
+		QuerySpec: querySpec,
 		PageEmbedOption: types.PageEmbedOption{
 			PageIndex: req.PageIndex,
 			PageSize:  req.PageSize,
@@ -48,6 +58,10 @@ func (l *ListProductsPageLogic) ListProductsPage(req *types.ListProductsPageRequ
 		return nil, err
 	}
 
+	if req.StoreId > 0 {
+		l.svcCtx.PowerX.StoreProduct.ApplyStoreContext(l.ctx, req.StoreId, page.List)
+	}
+
 	// list
 	list := TransformProductsToReplyForMP(page.List)
 	return &types.ListProductsPageReply{
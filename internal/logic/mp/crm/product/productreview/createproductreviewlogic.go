@@ -0,0 +1,46 @@
+package productreview
+
+import (
+	customerdomain2 "PowerX/internal/model/crm/customerdomain"
+	"PowerX/internal/model/crm/product"
+	"PowerX/internal/uc/powerx/crm/customerdomain"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreateProductReviewLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateProductReviewLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateProductReviewLogic {
+	return &CreateProductReviewLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CreateProductReviewLogic) CreateProductReview(req *types.CreateProductReviewRequest) (resp *types.CreateProductReviewReply, err error) {
+	vAuthCustomer := l.ctx.Value(customerdomain.AuthCustomerKey)
+	authCustomer := vAuthCustomer.(*customerdomain2.Customer)
+
+	review, err := l.svcCtx.PowerX.ProductReview.CreateProductReview(l.ctx, authCustomer, &product.ProductReview{
+		OrderItemId: req.OrderItemId,
+		Rating:      req.Rating,
+		Content:     req.Content,
+		IsAnonymous: req.IsAnonymous,
+	}, req.ImageIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.CreateProductReviewReply{
+		ProductReview: TransformProductReviewToMPReply(review),
+	}, nil
+}
@@ -0,0 +1,70 @@
+package productreview
+
+import (
+	"PowerX/internal/logic/admin/crm/product/productreview"
+	"PowerX/internal/model/crm/product"
+	productUC "PowerX/internal/uc/powerx/crm/product"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListProductReviewsPageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListProductReviewsPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListProductReviewsPageLogic {
+	return &ListProductReviewsPageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListProductReviewsPageLogic) ListProductReviewsPage(req *types.ListMPProductReviewsPageRequest) (resp *types.ListProductReviewsPageReply, err error) {
+	page := l.svcCtx.PowerX.ProductReview.FindManyProductReviews(l.ctx, &productUC.FindManyProductReviewsOption{
+		ProductId:  req.ProductId,
+		Statuses:   []string{product.ProductReviewStatusApproved},
+		Rating:     req.Rating,
+		WithImages: req.WithImages,
+		SortBy:     req.SortBy,
+		PageEmbedOption: types.PageEmbedOption{
+			PageIndex: req.PageIndex,
+			PageSize:  req.PageSize,
+		},
+	})
+
+	list := []*types.ProductReview{}
+	for _, review := range page.List {
+		list = append(list, TransformProductReviewToMPReply(review))
+	}
+	return &types.ListProductReviewsPageReply{
+		List:      list,
+		PageIndex: page.PageIndex,
+		PageSize:  page.PageSize,
+		Total:     page.Total,
+	}, nil
+}
+
+// TransformProductReviewToMPReply 小程序展示的评价不返回审核信息, 匿名评价隐藏客户
+func TransformProductReviewToMPReply(review *product.ProductReview) *types.ProductReview {
+	reply := productreview.TransformProductReviewToReply(review)
+	if reply == nil {
+		return nil
+	}
+	reply.CustomerName = review.DisplayCustomerName()
+	if review.IsAnonymous {
+		reply.CustomerId = 0
+	}
+	reply.OrderId = 0
+	reply.OrderItemId = 0
+	reply.HiddenReason = ""
+	reply.ModeratedBy = 0
+	reply.ModeratedAt = ""
+	return reply
+}
@@ -0,0 +1,40 @@
+package productreview
+
+import (
+	customerdomain2 "PowerX/internal/model/crm/customerdomain"
+	"PowerX/internal/uc/powerx/crm/customerdomain"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type MarkProductReviewHelpfulLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewMarkProductReviewHelpfulLogic(ctx context.Context, svcCtx *svc.ServiceContext) *MarkProductReviewHelpfulLogic {
+	return &MarkProductReviewHelpfulLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *MarkProductReviewHelpfulLogic) MarkProductReviewHelpful(req *types.MarkProductReviewHelpfulRequest) (resp *types.MarkProductReviewHelpfulReply, err error) {
+	vAuthCustomer := l.ctx.Value(customerdomain.AuthCustomerKey)
+	authCustomer := vAuthCustomer.(*customerdomain2.Customer)
+
+	review, err := l.svcCtx.PowerX.ProductReview.MarkProductReviewHelpful(l.ctx, req.ProductReviewId, authCustomer.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MarkProductReviewHelpfulReply{
+		ProductReview: TransformProductReviewToMPReply(review),
+	}, nil
+}
@@ -0,0 +1,60 @@
+package productreview
+
+import (
+	"PowerX/internal/logic/admin/mediaresource"
+	customerdomain2 "PowerX/internal/model/crm/customerdomain"
+	"PowerX/internal/types/errorx"
+	"PowerX/internal/uc/powerx"
+	"PowerX/internal/uc/powerx/crm/customerdomain"
+	"context"
+	"net/http"
+	"strings"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UploadProductReviewImageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUploadProductReviewImageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UploadProductReviewImageLogic {
+	return &UploadProductReviewImageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UploadProductReviewImageLogic) UploadProductReviewImage(r *http.Request) (resp *types.UploadProductReviewImageReply, err error) {
+	vAuthCustomer := l.ctx.Value(customerdomain.AuthCustomerKey)
+	authCustomer := vAuthCustomer.(*customerdomain2.Customer)
+
+	err = r.ParseMultipartForm(mediaresource.MaxFileSize)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+
+	file, handler, err := r.FormFile("resource")
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+	defer file.Close()
+
+	if !strings.HasPrefix(handler.Header.Get("Content-Type"), "image/") {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "评价图片只支持图片格式")
+	}
+
+	resource, err := l.svcCtx.PowerX.MediaResource.MakeCustomerMediaResource(l.ctx, authCustomer.Id, powerx.BucketMediaResourceReview, handler)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+
+	return &types.UploadProductReviewImageReply{
+		MediaResource: mediaresource.TransformMediaResourceToReply(resource),
+	}, nil
+}
@@ -45,8 +45,10 @@ func TransformProductStatisticsToReplyForMP(specific *product2.ProductStatistics
 	return &types.ProductStatistics{
 		Id:                specific.Id,
 		ProductId:         specific.ProductId,
-		SoldAmount:        specific.BaseSoldAmount + specific.SoldAmount,
-		InventoryQuantity: specific.BaseInventoryQuantity + specific.InventoryQuantity,
-		ViewCount:         specific.BaseViewCount + specific.ViewCount,
+		SoldAmount:        specific.DisplaySoldAmount(),
+		InventoryQuantity: specific.DisplayInventoryQuantity(),
+		ViewCount:         specific.DisplayViewCount(),
+		ReviewCount:       specific.ReviewCount,
+		AverageRating:     specific.AverageRating,
 	}
 }
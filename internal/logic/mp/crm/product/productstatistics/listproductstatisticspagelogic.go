@@ -1,6 +1,7 @@
 package productstatistics
 
 import (
+	"PowerX/internal/uc/powerx/crm/product"
 	"context"
 
 	"PowerX/internal/svc"
@@ -24,7 +25,22 @@ func NewListProductStatisticsPageLogic(ctx context.Context, svcCtx *svc.ServiceC
 }
 
 func (l *ListProductStatisticsPageLogic) ListProductStatisticsPage(req *types.ListProductStatisticsPageRequest) (resp *types.ListProductStatisticsPageReply, err error) {
-	// todo: add your logic here and delete this line
+	page := l.svcCtx.PowerX.ProductStatistics.FindManyProductStatistics(l.ctx, &product.FindProductStatisticsOption{
+		ProductId: req.ProductId,
+		PageEmbedOption: types.PageEmbedOption{
+			PageIndex: req.PageIndex,
+			PageSize:  req.PageSize,
+		},
+	})
 
-	return
+	list := []*types.ProductStatistics{}
+	for _, statistics := range page.List {
+		list = append(list, TransformProductStatisticsToReplyForMP(statistics))
+	}
+	return &types.ListProductStatisticsPageReply{
+		List:      list,
+		PageIndex: page.PageIndex,
+		PageSize:  page.PageSize,
+		Total:     page.Total,
+	}, nil
 }
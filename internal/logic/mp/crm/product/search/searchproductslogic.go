@@ -0,0 +1,95 @@
+package search
+
+import (
+	"PowerX/internal/logic/mp/crm/product"
+	product2 "PowerX/internal/model/crm/product"
+	productUC "PowerX/internal/uc/powerx/crm/product"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SearchProductsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSearchProductsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SearchProductsLogic {
+	return &SearchProductsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *SearchProductsLogic) SearchProducts(req *types.SearchProductsRequest) (resp *types.SearchProductsReply, err error) {
+	result, err := l.svcCtx.PowerX.ProductSearch.SearchProducts(l.ctx, &productUC.SearchProductsOption{
+		Keyword:         req.Keyword,
+		CategoryIds:     req.CategoryIds,
+		SpecificOptions: req.SpecificOptions,
+		SalesChannelId:  req.SalesChannelId,
+		MinPrice:        req.MinPrice,
+		MaxPrice:        req.MaxPrice,
+		OnlyOnSale:      true,
+		SortBy:          req.SortBy,
+		PageEmbedOption: types.PageEmbedOption{
+			PageIndex: req.PageIndex,
+			PageSize:  req.PageSize,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 按检索结果的排序返回产品
+	products := []*product2.Product{}
+	if len(result.ProductIds) > 0 {
+		page, err := l.svcCtx.PowerX.Product.FindManyProducts(l.ctx, &productUC.FindManyProductsOption{
+			Ids: result.ProductIds,
+			PageEmbedOption: types.PageEmbedOption{
+				PageIndex: 1,
+				PageSize:  len(result.ProductIds),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		mapProducts := map[int64]*product2.Product{}
+		for _, p := range page.List {
+			mapProducts[p.Id] = p
+		}
+		for _, id := range result.ProductIds {
+			if p, ok := mapProducts[id]; ok {
+				products = append(products, p)
+			}
+		}
+	}
+
+	return &types.SearchProductsReply{
+		List:      product.TransformProductsToReplyForMP(products),
+		PageIndex: result.PageIndex,
+		PageSize:  result.PageSize,
+		Total:     result.Total,
+		Facets: types.ProductSearchFacets{
+			Categories:      TransformProductSearchFacetsToReply(result.Facets.Categories),
+			PriceBands:      TransformProductSearchFacetsToReply(result.Facets.PriceBands),
+			SpecificOptions: TransformProductSearchFacetsToReply(result.Facets.SpecificOptions),
+		},
+	}, nil
+}
+
+func TransformProductSearchFacetsToReply(facets []*productUC.ProductSearchFacet) []types.ProductSearchFacet {
+	list := []types.ProductSearchFacet{}
+	for _, facet := range facets {
+		list = append(list, types.ProductSearchFacet{
+			Key:   facet.Key,
+			Name:  facet.Name,
+			Count: facet.Count,
+		})
+	}
+	return list
+}
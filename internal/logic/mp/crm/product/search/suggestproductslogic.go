@@ -0,0 +1,45 @@
+package search
+
+import (
+	productUC "PowerX/internal/uc/powerx/crm/product"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SuggestProductsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSuggestProductsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SuggestProductsLogic {
+	return &SuggestProductsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *SuggestProductsLogic) SuggestProducts(req *types.SuggestProductsRequest) (resp *types.SuggestProductsReply, err error) {
+	suggestions := l.svcCtx.PowerX.ProductSearch.SuggestProducts(l.ctx, req.Keyword, req.Limit)
+
+	return &types.SuggestProductsReply{
+		List: TransformProductSearchSuggestionsToReply(suggestions),
+	}, nil
+}
+
+func TransformProductSearchSuggestionsToReply(suggestions []*productUC.ProductSearchSuggestion) []types.ProductSearchSuggestion {
+	list := []types.ProductSearchSuggestion{}
+	for _, suggestion := range suggestions {
+		list = append(list, types.ProductSearchSuggestion{
+			Type: suggestion.Type,
+			Id:   suggestion.Id,
+			Text: suggestion.Text,
+		})
+	}
+	return list
+}
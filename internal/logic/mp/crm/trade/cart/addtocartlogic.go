@@ -32,6 +32,24 @@ func (l *AddToCartLogic) AddToCart(req *types.AddToCartRequest) (resp *types.Add
 	vAuthCustomer := l.ctx.Value(customerdomain.AuthCustomerKey)
 	authCustomer := vAuthCustomer.(*customerdomain2.Customer)
 
+	productIds := []int64{req.ProductId}
+	if req.SkuId > 0 {
+		sku, err := l.svcCtx.PowerX.SKU.GetSKU(l.ctx, req.SkuId)
+		if err != nil {
+			return nil, err
+		}
+		if sku.IsRetired {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "商品规格已下架")
+		}
+		if sku.ProductId != req.ProductId {
+			productIds = append(productIds, sku.ProductId)
+		}
+	}
+	// 未审核通过、未激活或不在售卖时间内的产品不能加购
+	if err = l.svcCtx.PowerX.ProductApproval.CheckProductsOnSale(l.ctx, productIds); err != nil {
+		return nil, err
+	}
+
 	cartItem := TransformRequestToCartItemForMP(req, authCustomer)
 
 	cartItem, err = l.svcCtx.PowerX.Cart.AddItemToCart(l.ctx, cartItem)
@@ -0,0 +1,40 @@
+package invoice
+
+import (
+	customerdomain2 "PowerX/internal/model/crm/customerdomain"
+	"PowerX/internal/uc/powerx/crm/customerdomain"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CancelInvoiceLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCancelInvoiceLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CancelInvoiceLogic {
+	return &CancelInvoiceLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CancelInvoiceLogic) CancelInvoice(req *types.CancelInvoiceRequest) (resp *types.CancelInvoiceReply, err error) {
+	vAuthCustomer := l.ctx.Value(customerdomain.AuthCustomerKey)
+	authCustomer := vAuthCustomer.(*customerdomain2.Customer)
+
+	invoice, err := l.svcCtx.PowerX.Invoice.CancelInvoice(l.ctx, authCustomer.Id, req.InvoiceId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.CancelInvoiceReply{
+		Invoice: TransformInvoiceToReplyForMP(invoice),
+	}, nil
+}
@@ -0,0 +1,79 @@
+package invoice
+
+import (
+	"PowerX/internal/logic/mp/mediaresource"
+	customerdomain2 "PowerX/internal/model/crm/customerdomain"
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/types/errorx"
+	"PowerX/internal/uc/powerx/crm/customerdomain"
+	"context"
+	"time"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetInvoiceLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetInvoiceLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetInvoiceLogic {
+	return &GetInvoiceLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetInvoiceLogic) GetInvoice(req *types.GetInvoiceRequest) (resp *types.GetInvoiceReply, err error) {
+	vAuthCustomer := l.ctx.Value(customerdomain.AuthCustomerKey)
+	authCustomer := vAuthCustomer.(*customerdomain2.Customer)
+
+	invoice, err := l.svcCtx.PowerX.Invoice.GetInvoice(l.ctx, req.InvoiceId)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.CustomerId != authCustomer.Id {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "无权查看该发票")
+	}
+
+	return &types.GetInvoiceReply{
+		Invoice: TransformInvoiceToReplyForMP(invoice),
+	}, nil
+}
+
+// TransformInvoiceToReplyForMP 客户侧不返回审核员工等内部信息
+func TransformInvoiceToReplyForMP(invoice *trade.Invoice) *types.Invoice {
+	reply := &types.Invoice{
+		Id:             invoice.Id,
+		OrderId:        invoice.OrderId,
+		RequestNumber:  invoice.RequestNumber,
+		Kind:           invoice.Kind,
+		BlueInvoiceId:  invoice.BlueInvoiceId,
+		TitleType:      invoice.TitleType,
+		Title:          invoice.Title,
+		TaxIdNumber:    invoice.TaxIdNumber,
+		Email:          invoice.Email,
+		Amount:         invoice.Amount,
+		ReversedAmount: invoice.ReversedAmount,
+		Status:         invoice.Status,
+		InvoiceCode:    invoice.InvoiceCode,
+		InvoiceNumber:  invoice.InvoiceNumber,
+		RejectReason:   invoice.RejectReason,
+		CreatedAt:      invoice.CreatedAt.Format(time.RFC3339),
+	}
+	if invoice.Order != nil {
+		reply.OrderNumber = invoice.Order.OrderNumber
+	}
+	if invoice.IssuedAt != nil {
+		reply.IssuedAt = invoice.IssuedAt.Format(time.RFC3339)
+	}
+	if invoice.PdfResource != nil {
+		reply.PdfResource = mediaresource.TransformMediaResourceToReplyForMP(invoice.PdfResource)
+	}
+	return reply
+}
@@ -0,0 +1,57 @@
+package invoice
+
+import (
+	customerdomain2 "PowerX/internal/model/crm/customerdomain"
+	"PowerX/internal/uc/powerx/crm/customerdomain"
+	tradeUC "PowerX/internal/uc/powerx/crm/trade"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListInvoicesPageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListInvoicesPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListInvoicesPageLogic {
+	return &ListInvoicesPageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListInvoicesPageLogic) ListInvoicesPage(req *types.ListInvoicesPageRequest) (resp *types.ListInvoicesPageReply, err error) {
+	vAuthCustomer := l.ctx.Value(customerdomain.AuthCustomerKey)
+	authCustomer := vAuthCustomer.(*customerdomain2.Customer)
+
+	page, err := l.svcCtx.PowerX.Invoice.FindManyInvoices(l.ctx, &tradeUC.FindManyInvoicesOption{
+		CustomerId: authCustomer.Id,
+		OrderId:    req.OrderId,
+		Kind:       req.Kind,
+		Statuses:   req.Statuses,
+		PageEmbedOption: types.PageEmbedOption{
+			PageIndex: req.PageIndex,
+			PageSize:  req.PageSize,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*types.Invoice{}
+	for _, invoice := range page.List {
+		list = append(list, TransformInvoiceToReplyForMP(invoice))
+	}
+	return &types.ListInvoicesPageReply{
+		List:      list,
+		PageIndex: page.PageIndex,
+		PageSize:  page.PageSize,
+		Total:     page.Total,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package invoice
+
+import (
+	customerdomain2 "PowerX/internal/model/crm/customerdomain"
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/types/errorx"
+	"PowerX/internal/uc/powerx/crm/customerdomain"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RequestInvoiceLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRequestInvoiceLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RequestInvoiceLogic {
+	return &RequestInvoiceLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RequestInvoiceLogic) RequestInvoice(req *types.RequestInvoiceRequest) (resp *types.RequestInvoiceReply, err error) {
+	vAuthCustomer := l.ctx.Value(customerdomain.AuthCustomerKey)
+	authCustomer := vAuthCustomer.(*customerdomain2.Customer)
+
+	order, err := l.svcCtx.PowerX.Order.GetOrder(l.ctx, req.OrderId)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrNotFoundObject, "未找到该订单")
+	}
+	if order.CustomerId != authCustomer.Id {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "无权操作该订单")
+	}
+
+	invoice := &trade.Invoice{
+		TitleType:   req.TitleType,
+		Title:       req.Title,
+		TaxIdNumber: req.TaxIdNumber,
+		Email:       req.Email,
+		Remark:      req.Remark,
+	}
+	err = l.svcCtx.PowerX.Invoice.RequestInvoice(l.ctx, order, invoice)
+	if err != nil {
+		return nil, err
+	}
+	invoice.Order = order
+
+	return &types.RequestInvoiceReply{
+		Invoice: TransformInvoiceToReplyForMP(invoice),
+	}, nil
+}
@@ -40,10 +40,25 @@ func (l *CancelOrderLogic) CancelOrder(req *types.CancelOrderRequest) (resp *typ
 	if order.CustomerId != authCustomer.Id {
 		return nil, errorx.WithCause(errorx.ErrBadRequest, "无权取消该订单")
 	}
+	// 续费订单由订阅管理, 不续费需要取消订阅
+	if order.SubscriptionId > 0 &&
+		l.svcCtx.PowerX.Order.IsOrderStatusSameAs(l.ctx, order, trade.OrderStatusToBePaid) {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "订阅续费订单不能单独取消, 请取消订阅")
+	}
+	// 未付清的订单可能已有部分支付(代币, 定金), 取消时关闭未支付的支付单并退回已付部分
+	if l.svcCtx.PowerX.Order.IsOrderStatusSameAs(l.ctx, order, trade.OrderStatusToBePaid) {
+		l.svcCtx.PowerX.Payment.ReleaseOrderPayments(l.ctx, order)
+	}
+
 	orderStatusId := l.svcCtx.PowerX.Order.GetOrderStatusId(l.ctx, trade.OrderStatusCancelled)
 	order.Status = orderStatusId
 	l.svcCtx.PowerX.Order.PatchOrder(l.ctx, req.OrderId, order)
 
+	// 退回门店订单下单时扣减的库存
+	if err = l.svcCtx.PowerX.Order.ReleaseOrderInventories(l.ctx, order); err != nil {
+		l.Logger.Errorf("取消订单%s-退回库存失败:%s", order.OrderNumber, err.Error())
+	}
+
 	return &types.CancelOrderReply{
 		OrderId: order.Id,
 	}, nil
@@ -50,7 +50,7 @@ func (l *CreateOrderByCartItemsLogic) CreateOrderByCartItems(req *types.CreateOr
 	}
 
 	// 创建订单
-	order, cart, err := l.svcCtx.PowerX.Order.CreateOrderByCartItems(l.ctx, authCustomer, cartItems, shippingAddress, req.Comment)
+	order, cart, err := l.svcCtx.PowerX.Order.CreateOrderByCartItems(l.ctx, authCustomer, req.StoreId, cartItems, shippingAddress, req.Comment)
 	if err != nil {
 		return nil, errorx.WithCause(errorx.ErrCreateObject, err.Error())
 	}
@@ -32,28 +32,32 @@ func (l *CreateOrderByProductsLogic) CreateOrderByProducts(req *types.CreateOrde
 	vAuthCustomer := l.ctx.Value(customerdomain.AuthCustomerKey)
 	authCustomer := vAuthCustomer.(*customerdomain2.Customer)
 
-	if req.PriceBookId <= 0 {
-		standardBook, err := l.svcCtx.PowerX.PriceBook.GetStandardPriceBook(l.ctx)
-		if err != nil {
-			return nil, errorx.WithCause(errorx.ErrNotFoundObject, "未找到标准价格手册")
+	// 门店订单使用门店价格手册, 门店价格手册中缺失的商品使用标准价格手册
+	priceBook, err := l.svcCtx.PowerX.PriceBook.ResolveOrderPriceBook(l.ctx, req.StoreId, req.PriceBookId)
+	if err != nil {
+		return nil, err
+	}
+	req.PriceBookId = priceBook.Id
+	fallbackPriceBookId := int64(0)
+	if priceBook.StoreId > 0 {
+		if standardBook, err := l.svcCtx.PowerX.PriceBook.GetStandardPriceBook(l.ctx); err == nil {
+			fallbackPriceBookId = standardBook.Id
 		}
-		req.PriceBookId = standardBook.Id
 	}
 
 	entries := []*product2.PriceBookEntry{}
 	if len(req.SkuIds) > 0 {
-		page := l.svcCtx.PowerX.PriceBookEntry.FindManyPriceBookEntries(l.ctx, &product.FindPriceBookEntryOption{
+		entries = l.svcCtx.PowerX.PriceBookEntry.FindManyPriceBookEntriesWithFallback(l.ctx, &product.FindPriceBookEntryOption{
 			PriceBookId: req.PriceBookId,
 			SkuIds:      req.SkuIds,
-		})
-		entries = page.List
+		}, fallbackPriceBookId)
 
 	} else if len(req.ProductIds) > 0 {
 		//如果搜索ProductId，那么就要排除掉SKU的选项
-		page := l.svcCtx.PowerX.PriceBookEntry.FindManyPriceBookEntries(l.ctx, &product.FindPriceBookEntryOption{
-			ProductIds: req.ProductIds,
-		})
-		entries = page.List
+		entries = l.svcCtx.PowerX.PriceBookEntry.FindManyPriceBookEntriesWithFallback(l.ctx, &product.FindPriceBookEntryOption{
+			PriceBookId: req.PriceBookId,
+			ProductIds:  req.ProductIds,
+		}, fallbackPriceBookId)
 
 	} else {
 		return nil, errorx.WithCause(errorx.ErrNotFoundObject, "请求相应的商品信息有误")
@@ -75,7 +79,7 @@ func (l *CreateOrderByProductsLogic) CreateOrderByProducts(req *types.CreateOrde
 
 	// 创建订单
 	order, err := l.svcCtx.PowerX.Order.CreateOrderByPriceBookEntries(
-		l.ctx, authCustomer, entries,
+		l.ctx, authCustomer, req.StoreId, entries,
 		req.Quantities, shippingAddress, req.Comment,
 	)
 	if err != nil {
@@ -1,6 +1,7 @@
 package order
 
 import (
+	adminOrder "PowerX/internal/logic/admin/crm/trade/order"
 	"PowerX/internal/logic/admin/mediaresource"
 	"PowerX/internal/model/crm/trade"
 	"PowerX/internal/types/errorx"
@@ -48,18 +49,22 @@ func TransformOrderToReplyForMP(order *trade.Order) *types.Order {
 		discount = 0
 	}
 	return &types.Order{
-		Id:          order.Id,
-		CustomerId:  order.CustomerId,
-		PaymentType: order.PaymentType,
-		Type:        order.Type,
-		Status:      order.Status,
-		OrderNumber: order.OrderNumber,
-		Discount:    discount,
-		ListPrice:   order.ListPrice,
-		UnitPrice:   order.UnitPrice,
-		Comment:     order.Comment,
-		OrderItems:  TransformOrderItemsToReplyForMP(order.Items),
-		Payments:    TransformPaymentsToReplyForMP(order.Payments),
+		Id:                order.Id,
+		CustomerId:        order.CustomerId,
+		StoreId:           order.StoreId,
+		SubscriptionId:    order.SubscriptionId,
+		PaymentType:       order.PaymentType,
+		Type:              order.Type,
+		Status:            order.Status,
+		OrderNumber:       order.OrderNumber,
+		Discount:          discount,
+		ListPrice:         order.ListPrice,
+		UnitPrice:         order.UnitPrice,
+		PaidAmount:        order.PaidAmount,
+		OutstandingAmount: order.GetOutstandingAmount(),
+		Comment:           order.Comment,
+		OrderItems:        TransformOrderItemsToReplyForMP(order.Items),
+		Payments:          TransformPaymentsToReplyForMP(order.Payments),
 	}
 }
 
@@ -91,6 +96,7 @@ func TransformOrderItemToReplyForMP(orderItem *trade.OrderItem) (orderItemReply
 		CoverImage:       mediaresource.TransformMediaResourceToReply(orderItem.CoverImage),
 		ProductName:      orderItem.ProductName,
 		SkuNo:            orderItem.SkuNo,
+		Components:       adminOrder.TransformOrderItemComponentsToReply(orderItem.Components),
 	}
 }
 
@@ -3,6 +3,8 @@ package order
 import (
 	customerdomain2 "PowerX/internal/model/crm/customerdomain"
 	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/model/powermodel"
+	"PowerX/internal/types/errorx"
 	"PowerX/internal/uc/powerx/crm/customerdomain"
 	tradeUC "PowerX/internal/uc/powerx/crm/trade"
 	"context"
@@ -28,6 +30,11 @@ func NewListOrdersPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Li
 }
 
 func (l *ListOrdersPageLogic) ListOrdersPage(req *types.ListOrdersPageRequest) (resp *types.ListOrdersPageReply, err error) {
+	querySpec, err := powermodel.NewQuerySpec(trade.OrderQueryFields, req.OrderBy, req.Filters)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+
 	vAuthCustomer := l.ctx.Value(customerdomain.AuthCustomerKey)
 	authCustomer := vAuthCustomer.(*customerdomain2.Customer)
 
@@ -35,6 +42,7 @@ func (l *ListOrdersPageLogic) ListOrdersPage(req *types.ListOrdersPageRequest) (
 		CustomerId: authCustomer.Id,
 		Status:     req.StatusIds,
 		Type:       req.TypeIds,
+		QuerySpec:  querySpec,
 		PageEmbedOption: types.PageEmbedOption{
 			PageIndex: req.PageIndex,
 			PageSize:  req.PageSize,
@@ -3,6 +3,7 @@ package payment
 import (
 	customerdomain2 "PowerX/internal/model/crm/customerdomain"
 	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/model/wechat"
 	"PowerX/internal/types/errorx"
 	"PowerX/internal/uc/powerx/crm/customerdomain"
 	"context"
@@ -42,31 +43,35 @@ func (l *CreatePaymentFromOrderLogic) CreatePaymentFromOrder(req *types.CreatePa
 		return nil, errorx.WithCause(errorx.ErrBadRequest, "该订单不属于待支付状态")
 	}
 
-	// 定义支付单
-	var createdPayment *trade.Payment
-	// 支付平台的返回信息
-	var data interface{}
-	// 微信支付
-
-	ddPaymentType := l.svcCtx.PowerX.DataDictionary.GetCachedDDById(l.ctx, req.PaymentType)
+	// 按支付方式选择支付渠道, 创建一条支付单, 订单可分多笔支付(如代币+微信, 定金+尾款)
+	createdPayment, data, err := l.svcCtx.PowerX.Payment.CreatePaymentFromOrder(l.ctx,
+		authCustomer, order,
+		req.PaymentType, req.Amount, authCustomer.OpenIdInMiniProgram,
+	)
+	if err != nil {
+		if _, ok := err.(*errorx.Error); ok {
+			return nil, err
+		}
+		return nil, errorx.WithCause(errorx.ErrCreateObject, "创建支付单失败:"+err.Error())
+	}
 
-	switch ddPaymentType.Key {
-	case trade.PaymentTypeWeChat:
-		// 创建一条支付单
-		createdPayment, data, err = l.svcCtx.PowerX.Payment.CreatePaymentFromOrderByWechat(l.ctx,
-			authCustomer, order,
-			authCustomer.OpenIdInMiniProgram, req.PaymentType,
-		)
+	// 代币等同步到账的支付单, 直接汇总订单
+	paid := l.svcCtx.PowerX.Payment.IsPaymentStatusSameAs(l.ctx, createdPayment, trade.PaymentStatusPaid)
+	if paid {
+		settled, err := l.svcCtx.PowerX.Payment.SettleOrderAfterPaid(l.ctx, order)
 		if err != nil {
-			return nil, errorx.WithCause(errorx.ErrCreateObject, "创建微信小程序订单失败:"+err.Error())
+			l.Logger.Errorf("创建支付单-记录订单状态跳变:%s,错误信息：%s", createdPayment.PaymentNumber, err.Error())
+		} else if settled {
+			l.svcCtx.PowerX.WechatNotification.NotifyOrderEvent(wechat.NotificationEventOrderPaid, order.Id, nil)
 		}
-
-	default:
-		return nil, errorx.WithCause(errorx.ErrBadRequest, "支付类型不支持")
 	}
+	summary := l.svcCtx.PowerX.Payment.GetOrderPaymentSummary(l.ctx, order)
 
 	return &types.CreatePaymentFromOrderRequestReply{
-		PaymentId: createdPayment.Id,
-		Data:      data,
+		PaymentId:         createdPayment.Id,
+		Amount:            createdPayment.PaidAmount,
+		Paid:              paid,
+		OutstandingAmount: summary.OutstandingAmount,
+		Data:              data,
 	}, nil
 }
@@ -0,0 +1,298 @@
+package payment
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/model/wechat"
+	"PowerX/internal/svc"
+	"PowerX/internal/types/errorx"
+	"PowerX/internal/uc/powerx/crm/trade/provider"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+	"io"
+	"math"
+	"net/http"
+	"time"
+)
+
+// NotificationRejectedError 通知内容与支付单不符, 渠道重试也无法成功, 需人工核查
+type NotificationRejectedError struct {
+	Reason string
+}
+
+func (e *NotificationRejectedError) Error() string {
+	return e.Reason
+}
+
+func rejectNotification(format string, args ...interface{}) error {
+	return &NotificationRejectedError{Reason: fmt.Sprintf(format, args...)}
+}
+
+type HandlePaymentNotificationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewHandlePaymentNotificationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HandlePaymentNotificationLogic {
+	return &HandlePaymentNotificationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// HandleWebhook 保存原始通知, 由支付方式对应的渠道验签解析后统一处理支付单
+func (l *HandlePaymentNotificationLogic) HandleWebhook(w http.ResponseWriter, r *http.Request, paymentType string) {
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		l.Logger.Errorf("支付回调-读取报文失败:%s", err.Error())
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewBuffer(body))
+
+	headers, _ := json.Marshal(r.Header)
+	record := &trade.PaymentNotification{
+		PaymentType: paymentType,
+		RawHeaders:  headers,
+		RawBody:     string(body),
+	}
+	l.svcCtx.PowerX.Payment.CreatePaymentNotification(l.ctx, record)
+
+	payProvider, err := l.svcCtx.PowerX.Payment.GetProviderByName(paymentType)
+	if err != nil {
+		l.markInvalid(record, err)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// 不是渠道官方调用或验签失败时, 渠道实现已经应答失败, 这里只做记录
+	err = payProvider.HandleNotification(w, r, func(notification *provider.Notification) error {
+		return l.ProcessNotification(record, notification)
+	})
+	if err != nil {
+		l.Logger.Errorf("支付回调-解析通知失败:%d,错误信息：%s", record.Id, err.Error())
+		l.markInvalid(record, err)
+	}
+}
+
+// ProcessNotification
+//
+//	@Description: 处理已解析的通知并记录结果, 重复通知直接应答成功
+//	@receiver l
+//	@param record 原始通知记录
+//	@param notification
+//	@return error 返回error时由渠道重试
+func (l *HandlePaymentNotificationLogic) ProcessNotification(record *trade.PaymentNotification, notification *provider.Notification) error {
+
+	record.Attempts++
+	record.NotifyId = notification.NotifyId
+	record.Event = string(notification.Event)
+	if notification.Transaction != nil {
+		record.TransactionId = notification.Transaction.ReferenceNumber
+		record.PaymentNumber = notification.Transaction.PaymentNumber
+		record.Amount = notification.Transaction.Amount
+		record.Currency = notification.Transaction.Currency
+	}
+	if notification.Refund != nil {
+		record.TransactionId = notification.Refund.ReferenceNumber
+		record.PaymentNumber = notification.Refund.PaymentNumber
+		record.Amount = notification.Refund.Amount
+	}
+	record.Payload, _ = json.Marshal(notification)
+
+	if l.svcCtx.PowerX.Payment.IsPaymentNotificationProcessed(l.ctx, record) {
+		return l.finish(record, trade.PaymentNotificationStatusDuplicate, nil)
+	}
+
+	err := l.HandlePaymentNotification(notification)
+	var rejected *NotificationRejectedError
+	switch {
+	case err == nil:
+		return l.finish(record, trade.PaymentNotificationStatusProcessed, nil)
+	case errors.As(err, &rejected):
+		// 应答成功避免渠道无意义的重试, 由管理员核查后重放
+		l.Logger.Errorf("支付回调-通知校验不通过:%s,错误信息：%s", record.PaymentNumber, err.Error())
+		return l.finish(record, trade.PaymentNotificationStatusRejected, err)
+	default:
+		l.Logger.Errorf("支付回调-处理失败:%s,错误信息：%s", record.PaymentNumber, err.Error())
+		_ = l.finish(record, trade.PaymentNotificationStatusFailed, err)
+		return err
+	}
+}
+
+// Replay 管理员重放已保存的通知, 只重放已解析且未处理成功的通知
+func (l *HandlePaymentNotificationLogic) Replay(id int64) (*trade.PaymentNotification, error) {
+
+	record, err := l.svcCtx.PowerX.Payment.GetPaymentNotification(l.ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(record.Payload) == 0 {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "该通知未能解析, 无法重放")
+	}
+	if record.Status == trade.PaymentNotificationStatusProcessed || record.Status == trade.PaymentNotificationStatusDuplicate {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "该通知已处理")
+	}
+
+	notification := &provider.Notification{}
+	if err = json.Unmarshal(record.Payload, notification); err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "通知内容损坏:"+err.Error())
+	}
+
+	// 处理结果记录在通知中
+	_ = l.ProcessNotification(record, notification)
+
+	return record, nil
+}
+
+func (l *HandlePaymentNotificationLogic) finish(record *trade.PaymentNotification, status string, err error) error {
+	now := time.Now()
+	record.Status = status
+	record.Error = ""
+	if err != nil {
+		record.Error = err.Error()
+	}
+	record.ProcessedAt = &now
+	l.svcCtx.PowerX.Payment.SavePaymentNotification(l.ctx, record)
+	return nil
+}
+
+func (l *HandlePaymentNotificationLogic) markInvalid(record *trade.PaymentNotification, err error) {
+	record.Status = trade.PaymentNotificationStatusInvalid
+	record.Error = err.Error()
+	l.svcCtx.PowerX.Payment.SavePaymentNotification(l.ctx, record)
+}
+
+func (l *HandlePaymentNotificationLogic) HandlePaymentNotification(notification *provider.Notification) error {
+
+	switch notification.Event {
+	case provider.NotificationEventPaid:
+		return l.handlePaid(notification.Transaction)
+	case provider.NotificationEventRefunded:
+		return l.handleRefunded(notification.Refund)
+	case provider.NotificationEventClosed:
+		return l.handleClosed(notification.Transaction)
+	}
+
+	return nil
+}
+
+func (l *HandlePaymentNotificationLogic) handlePaid(transaction *provider.TransactionResult) error {
+
+	if transaction == nil || transaction.PaymentNumber == "" {
+		return rejectNotification("no content notify")
+	}
+
+	// 获取该支付单
+	payment, err := l.svcCtx.PowerX.Payment.GetPaymentByNumber(l.ctx, transaction.PaymentNumber)
+	if err != nil {
+		return rejectNotification("未找到支付单:%s", transaction.PaymentNumber)
+	}
+
+	// 校验通知金额与币种
+	if transaction.Currency != "" && transaction.Currency != trade.PaymentCurrencyCNY {
+		return rejectNotification("支付单%s币种不符:%s", payment.PaymentNumber, transaction.Currency)
+	}
+	if math.Round(transaction.Amount*100) != math.Round(payment.PaidAmount*100) {
+		return rejectNotification("支付单%s金额不符, 通知金额%.2f, 支付单金额%.2f", payment.PaymentNumber, transaction.Amount, payment.PaidAmount)
+	}
+
+	// 只从待支付状态变更, 已支付视为重复通知
+	if l.svcCtx.PowerX.Payment.IsPaymentStatusSameAs(l.ctx, payment, trade.PaymentStatusPaid) {
+		return nil
+	}
+	if !l.svcCtx.PowerX.Payment.IsPaymentStatusSameAs(l.ctx, payment, trade.PaymentStatusPending) {
+		return rejectNotification("支付单%s不属于待支付状态", payment.PaymentNumber)
+	}
+	if !l.svcCtx.PowerX.Payment.MarkPaymentPaidFromPending(l.ctx, payment, transaction.ReferenceNumber, transaction.PaidAt) {
+		return nil
+	}
+
+	// 订单可能由多笔支付单组合支付, 付清后才变更订单状态
+	// order如果状态修改出错，可以在另外的机制处理，不能干预payment的记录状态
+	if payment.Order != nil {
+		settled, err := l.svcCtx.PowerX.Payment.SettleOrderAfterPaid(l.ctx, payment.Order)
+		if err != nil {
+			l.Logger.Errorf("支付回调-记录订单状态跳变:%s,错误信息：%s", payment.PaymentNumber, err.Error())
+		} else if settled {
+			l.svcCtx.PowerX.WechatNotification.NotifyOrderEvent(wechat.NotificationEventOrderPaid, payment.OrderId, nil)
+		}
+	}
+
+	// 如果需要做其他的事件，可以通过消息队列方式，异步去处理订单所产生的业务变化
+	// 这里只做支付单的记录和状态变更
+	// ...
+
+	return nil
+}
+
+func (l *HandlePaymentNotificationLogic) handleRefunded(refund *provider.RefundResult) error {
+
+	if refund == nil || refund.PaymentNumber == "" {
+		return rejectNotification("no content notify")
+	}
+	if refund.State == provider.RefundStateProcessing {
+		return nil
+	}
+
+	// 累计支付单的退款金额, 重复通知不再累计, 累计达到支付金额时支付单变为已退款
+	payment, applied, err := l.svcCtx.PowerX.Payment.ApplyRefundResult(l.ctx, refund)
+	if err != nil {
+		return rejectNotification("支付单%s退款处理失败:%s", refund.PaymentNumber, err.Error())
+	}
+	if !applied {
+		return nil
+	}
+
+	// 每笔退款(包括部分退款)按本次退款金额处理发票, 已开具的发票生成红字发票, 待开具的申请同步调整
+	if payment.Order != nil {
+		l.svcCtx.PowerX.Invoice.HandleOrderRefunded(l.ctx, payment.Order, refund.Amount)
+	}
+
+	// 部分退款不改变支付单状态
+	if !l.svcCtx.PowerX.Payment.IsPaymentStatusSameAs(l.ctx, payment, trade.PaymentStatusRefunded) {
+		if payment.Order != nil {
+			l.svcCtx.PowerX.Payment.RefreshOrderPaidAmount(l.ctx, payment.Order)
+		}
+		return nil
+	}
+
+	// 组合支付中单笔退款只扣减订单已付金额, 全部退款后订单才变为已退款
+	if payment.Order != nil {
+		refunded, err := l.svcCtx.PowerX.Payment.SettleOrderAfterRefunded(l.ctx, payment.Order)
+		if err != nil {
+			l.Logger.Errorf("退款回调-记录订单状态跳变:%s,错误信息：%s", payment.PaymentNumber, err.Error())
+			return nil
+		}
+		if !refunded {
+			return nil
+		}
+	}
+	l.svcCtx.PowerX.WechatNotification.NotifyOrderEvent(wechat.NotificationEventOrderRefunded, payment.OrderId, nil)
+
+	return nil
+}
+
+// handleClosed 交易关闭只取消该笔支付单, 订单其余支付单及已付金额不受影响, 客户可重新发起支付
+func (l *HandlePaymentNotificationLogic) handleClosed(transaction *provider.TransactionResult) error {
+
+	if transaction == nil || transaction.PaymentNumber == "" {
+		return rejectNotification("no content notify")
+	}
+
+	payment, err := l.svcCtx.PowerX.Payment.GetPaymentByNumber(l.ctx, transaction.PaymentNumber)
+	if err != nil {
+		return rejectNotification("未找到支付单:%s", transaction.PaymentNumber)
+	}
+
+	l.svcCtx.PowerX.Payment.MarkPaymentCancelledFromPending(l.ctx, payment)
+
+	return nil
+}
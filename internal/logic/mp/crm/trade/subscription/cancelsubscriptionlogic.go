@@ -0,0 +1,54 @@
+package subscription
+
+import (
+	"PowerX/internal/logic/admin/crm/trade/subscription"
+	customerdomain2 "PowerX/internal/model/crm/customerdomain"
+	"PowerX/internal/uc/powerx/crm/customerdomain"
+	"context"
+	"strings"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CancelSubscriptionLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCancelSubscriptionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CancelSubscriptionLogic {
+	return &CancelSubscriptionLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CancelSubscriptionLogic) CancelSubscription(req *types.CancelSubscriptionRequest) (resp *types.CancelSubscriptionReply, err error) {
+	vAuthCustomer := l.ctx.Value(customerdomain.AuthCustomerKey)
+	authCustomer := vAuthCustomer.(*customerdomain2.Customer)
+
+	mdlSubscription, err := l.svcCtx.PowerX.Subscription.GetCustomerSubscription(l.ctx, authCustomer.Id, req.SubscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		reason = "客户取消"
+	}
+	// 客户取消时已付的当期继续有效
+	if err = l.svcCtx.PowerX.Subscription.CancelSubscription(l.ctx, mdlSubscription, false, reason); err != nil {
+		return nil, err
+	}
+
+	mdlSubscription, err = l.svcCtx.PowerX.Subscription.GetSubscription(l.ctx, req.SubscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	return &types.CancelSubscriptionReply{
+		Subscription: subscription.TransformSubscriptionToReply(mdlSubscription),
+	}, nil
+}
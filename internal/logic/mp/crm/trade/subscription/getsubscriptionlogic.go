@@ -0,0 +1,41 @@
+package subscription
+
+import (
+	"PowerX/internal/logic/admin/crm/trade/subscription"
+	customerdomain2 "PowerX/internal/model/crm/customerdomain"
+	"PowerX/internal/uc/powerx/crm/customerdomain"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetSubscriptionLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetSubscriptionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetSubscriptionLogic {
+	return &GetSubscriptionLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetSubscriptionLogic) GetSubscription(req *types.GetSubscriptionRequest) (resp *types.GetSubscriptionReply, err error) {
+	vAuthCustomer := l.ctx.Value(customerdomain.AuthCustomerKey)
+	authCustomer := vAuthCustomer.(*customerdomain2.Customer)
+
+	mdlSubscription, err := l.svcCtx.PowerX.Subscription.GetCustomerSubscription(l.ctx, authCustomer.Id, req.SubscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetSubscriptionReply{
+		Subscription: subscription.TransformSubscriptionToReply(mdlSubscription),
+	}, nil
+}
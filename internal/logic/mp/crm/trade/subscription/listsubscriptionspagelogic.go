@@ -0,0 +1,56 @@
+package subscription
+
+import (
+	"PowerX/internal/logic/admin/crm/trade/subscription"
+	customerdomain2 "PowerX/internal/model/crm/customerdomain"
+	"PowerX/internal/uc/powerx/crm/customerdomain"
+	tradeUC "PowerX/internal/uc/powerx/crm/trade"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListSubscriptionsPageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListSubscriptionsPageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListSubscriptionsPageLogic {
+	return &ListSubscriptionsPageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListSubscriptionsPageLogic) ListSubscriptionsPage(req *types.ListSubscriptionsPageRequest) (resp *types.ListSubscriptionsPageReply, err error) {
+	vAuthCustomer := l.ctx.Value(customerdomain.AuthCustomerKey)
+	authCustomer := vAuthCustomer.(*customerdomain2.Customer)
+
+	page, err := l.svcCtx.PowerX.Subscription.FindManySubscriptions(l.ctx, &tradeUC.FindManySubscriptionsOption{
+		CustomerId: authCustomer.Id,
+		Statuses:   req.Statuses,
+		PageEmbedOption: types.PageEmbedOption{
+			PageIndex: req.PageIndex,
+			PageSize:  req.PageSize,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*types.Subscription{}
+	for _, mdlSubscription := range page.List {
+		list = append(list, subscription.TransformSubscriptionToReply(mdlSubscription))
+	}
+	return &types.ListSubscriptionsPageReply{
+		List:      list,
+		PageIndex: page.PageIndex,
+		PageSize:  page.PageSize,
+		Total:     page.Total,
+	}, nil
+}
@@ -0,0 +1,48 @@
+package subscription
+
+import (
+	"PowerX/internal/logic/admin/crm/trade/subscription"
+	customerdomain2 "PowerX/internal/model/crm/customerdomain"
+	"PowerX/internal/uc/powerx/crm/customerdomain"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ResumeSubscriptionLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewResumeSubscriptionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ResumeSubscriptionLogic {
+	return &ResumeSubscriptionLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ResumeSubscriptionLogic) ResumeSubscription(req *types.ResumeSubscriptionRequest) (resp *types.ResumeSubscriptionReply, err error) {
+	vAuthCustomer := l.ctx.Value(customerdomain.AuthCustomerKey)
+	authCustomer := vAuthCustomer.(*customerdomain2.Customer)
+
+	mdlSubscription, err := l.svcCtx.PowerX.Subscription.GetCustomerSubscription(l.ctx, authCustomer.Id, req.SubscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	if err = l.svcCtx.PowerX.Subscription.ResumeSubscription(l.ctx, mdlSubscription); err != nil {
+		return nil, err
+	}
+
+	mdlSubscription, err = l.svcCtx.PowerX.Subscription.GetSubscription(l.ctx, req.SubscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ResumeSubscriptionReply{
+		Subscription: subscription.TransformSubscriptionToReply(mdlSubscription),
+	}, nil
+}
@@ -0,0 +1,42 @@
+package notification
+
+import (
+	"PowerX/internal/model/wechat"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListSubscribeTemplatesLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListSubscribeTemplatesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListSubscribeTemplatesLogic {
+	return &ListSubscribeTemplatesLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListSubscribeTemplatesLogic) ListSubscribeTemplates(req *types.ListSubscribeTemplatesRequest) (resp *types.ListSubscribeTemplatesReply, err error) {
+	templates := l.svcCtx.PowerX.WechatNotification.FindEnabledNotificationTemplates(l.ctx, wechat.NotificationChannelMPSubscribe, req.Events)
+
+	list := []*types.SubscribeTemplate{}
+	for _, template := range templates {
+		list = append(list, &types.SubscribeTemplate{
+			Event:      template.Event,
+			TemplateId: template.TemplateId,
+			Name:       template.Name,
+		})
+	}
+
+	return &types.ListSubscribeTemplatesReply{
+		List: list,
+	}, nil
+}
@@ -0,0 +1,48 @@
+package notification
+
+import (
+	customerdomain2 "PowerX/internal/model/crm/customerdomain"
+	"PowerX/internal/model/wechat"
+	"PowerX/internal/uc/powerx/crm/customerdomain"
+	"context"
+
+	"PowerX/internal/svc"
+	"PowerX/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RecordSubscriptionsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRecordSubscriptionsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RecordSubscriptionsLogic {
+	return &RecordSubscriptionsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RecordSubscriptionsLogic) RecordSubscriptions(req *types.RecordSubscriptionsRequest) (resp *types.RecordSubscriptionsReply, err error) {
+	vAuthCustomer := l.ctx.Value(customerdomain.AuthCustomerKey)
+	authCustomer := vAuthCustomer.(*customerdomain2.Customer)
+
+	err = l.svcCtx.PowerX.WechatNotification.RecordMPSubscriptions(l.ctx, authCustomer, req.Results)
+	if err != nil {
+		return nil, err
+	}
+
+	accepted := []string{}
+	for templateId, status := range req.Results {
+		if status == wechat.MPSubscriptionAccept {
+			accepted = append(accepted, templateId)
+		}
+	}
+
+	return &types.RecordSubscriptionsReply{
+		Accepted: accepted,
+	}, nil
+}
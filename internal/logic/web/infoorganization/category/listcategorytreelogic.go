@@ -2,6 +2,9 @@ package category
 
 import (
 	"PowerX/internal/logic/admin/infoorganization/category"
+	infoorganization2 "PowerX/internal/model/infoorganization"
+	"PowerX/internal/model/powermodel"
+	"PowerX/internal/types/errorx"
 	"PowerX/internal/uc/powerx/crm/infoorganization"
 	"context"
 
@@ -26,9 +29,14 @@ func NewListCategoryTreeLogic(ctx context.Context, svcCtx *svc.ServiceContext) *
 }
 
 func (l *ListCategoryTreeLogic) ListCategoryTree(req *types.ListCategoryTreeRequest) (resp *types.ListCategoryTreeReply, err error) {
+	querySpec, err := powermodel.NewQuerySpec(infoorganization2.CategoryQueryFields, req.OrderBy, req.Filters)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+
 	option := infoorganization.FindCategoryOption{
-		Names:   req.Names,
-		OrderBy: req.OrderBy,
+		Names:     req.Names,
+		QuerySpec: querySpec,
 	}
 
 	// 获取模型类型的列表
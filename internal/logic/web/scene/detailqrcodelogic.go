@@ -1,8 +1,13 @@
 package scene
 
 import (
+	"PowerX/internal/model/scene"
+	"PowerX/internal/types/errorx"
+	"PowerX/internal/uc/powerx/crm/customerdomain"
 	"context"
 	"fmt"
+	"github.com/golang-jwt/jwt/v4"
+	"strconv"
 	"strings"
 
 	"PowerX/internal/svc"
@@ -39,7 +44,26 @@ func (qrcode *DetailQrcodeLogic) DetailQrcode(opt *types.SceneRequest) (resp *ty
 	}
 
 	detail := qrcode.svcCtx.PowerX.Scene.Scene.FindOneSceneQrcodeDetail(opt.Qid)
-	go qrcode.svcCtx.PowerX.Scene.Scene.IncreaseSceneCpaNumber(opt.Qid)
+	if detail == nil || detail.Id == 0 {
+		return nil, errorx.ErrNotFoundObject
+	}
+	if detail.IsExpired() {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, `活码已过期`)
+	}
+
+	candidate, err := qrcode.svcCtx.PowerX.Scene.Scene.RecordSceneQrcodeScan(&scene.SceneQrcodeScanLog{
+		QId:        detail.QId,
+		Link:       detail.RealQrcodeLink,
+		Ip:         qrcode.clientIp(opt),
+		UserAgent:  opt.UserAgent,
+		Referrer:   opt.Referer,
+		CustomerId: qrcode.customerId(opt.Authorization),
+	})
+	if err != nil {
+		qrcode.Error(`scene.qrcode.scan.record.error`, err)
+	} else if candidate != nil {
+		go qrcode.rotate(detail, candidate)
+	}
 
 	return &types.SceneQrcodeActiveReply{
 		QId:                detail.QId,
@@ -54,6 +78,82 @@ func (qrcode *DetailQrcodeLogic) DetailQrcode(opt *types.SceneRequest) (resp *ty
 		ExpiryDate:         detail.ExpiryDate,
 		State:              detail.State,
 		ActiveQrcodeLink:   detail.ActiveQrcodeLink,
-		CPA:                detail.Cpa,
-	}, err
+		CPA:                detail.Cpa + 1,
+	}, nil
+}
+
+// rotate
+//
+//	@Description: 当前群码展示次数达到安全阈值或群人数达到上限时切换下一个
+//	@receiver qrcode
+//	@param detail
+//	@param candidate
+func (qrcode *DetailQrcodeLogic) rotate(detail *scene.SceneQrcode, candidate *scene.SceneQrcodeCandidate) {
+
+	defer func() {
+		if err := recover(); err != nil {
+			logx.Errorf(`scene.qrcode.rotate.error: %v`, err)
+		}
+	}()
+
+	full := detail.SafeThresholdValue > 0 && candidate.ScanCount >= detail.SafeThresholdValue
+	if !full && candidate.ChatId != `` && candidate.MemberCap > 0 {
+		count, err := qrcode.svcCtx.PowerX.SCRM.Wechat.PullWeWorkCustomerGroupMemberCount(candidate.ChatId)
+		if err != nil {
+			logx.Errorf(`scene.qrcode.rotate.member.count.error: %v`, err)
+			return
+		}
+		full = count >= candidate.MemberCap
+	}
+	if !full {
+		return
+	}
+	if _, err := qrcode.svcCtx.PowerX.Scene.Scene.SwitchSceneQrcodeCandidate(detail.QId); err != nil {
+		logx.Errorf(`scene.qrcode.rotate.switch.error: %v`, err)
+	}
+
+}
+
+// clientIp
+//
+//	@Description:
+//	@receiver qrcode
+//	@param opt
+//	@return string
+func (qrcode *DetailQrcodeLogic) clientIp(opt *types.SceneRequest) string {
+
+	if opt.ForwardedFor != `` {
+		return strings.TrimSpace(strings.Split(opt.ForwardedFor, `,`)[0])
+	}
+	return opt.RealIp
+
+}
+
+// customerId
+//
+//	@Description: 可选登录, token无效时按匿名访客处理
+//	@receiver qrcode
+//	@param authorization
+//	@return int64
+func (qrcode *DetailQrcodeLogic) customerId(authorization string) int64 {
+
+	splits := strings.Split(authorization, `Bearer`)
+	if len(splits) != 2 {
+		return 0
+	}
+	var claims types.TokenClaims
+	token, err := jwt.ParseWithClaims(strings.TrimSpace(splits[1]), &claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(qrcode.svcCtx.Config.JWT.WebJWTSecret), nil
+	})
+	if err != nil || !token.Valid {
+		return 0
+	}
+	payload, err := customerdomain.GetPayloadFromToken(token.Raw)
+	if err != nil {
+		return 0
+	}
+	sub, _ := payload[`sub`].(string)
+	customerId, _ := strconv.ParseInt(sub, 10, 64)
+	return customerId
+
 }
@@ -46,6 +46,60 @@ func (l *WebhookPostMessageLogic) WebhookPostMessage(w http.ResponseWriter, r *h
 			}
 			fmt.Dump(msg)
 
+		case models.CALLBACK_MSG_TYPE_EVENT:
+			if event.GetEvent() == models2.CALLBACK_EVENT_CHANGE_EXTERNAL_CONTACT &&
+				event.GetChangeType() == models2.CALLBACK_EVENT_CHANGE_TYPE_ADD_EXTERNAL_CONTACT {
+				msg := models2.EventExternalUserAdd{}
+				err := event.ReadMessage(&msg)
+				if err != nil {
+					println(err.Error())
+					return "error"
+				}
+				// 欢迎语/自动打标签，WelcomeCode 20秒内有效，异步执行避免阻塞回调
+				go func() {
+					defer func() {
+						if r := recover(); r != nil {
+							logx.Errorf(`scrm.wework.welcome.invoke.panic. %v`, r)
+						}
+					}()
+					err := l.svcCtx.PowerX.SCRM.Wechat.InvokeWeWorkCustomerWelcomeRule(&msg)
+					if err != nil {
+						logx.Errorf(`scrm.wework.welcome.invoke.error. %v`, err)
+					}
+				}()
+			}
+			if event.GetEvent() == models2.CALLBACK_EVENT_CHANGE_EXTERNAL_CONTACT &&
+				event.GetChangeType() == models2.CALLBACK_EVENT_CHANGE_TYPE_TRANSFER_FAIL {
+				msg := models2.EventExternalTransferFail{}
+				err := event.ReadMessage(&msg)
+				if err != nil {
+					println(err.Error())
+					return "error"
+				}
+				l.svcCtx.PowerX.SCRM.Wechat.HandleWeWorkCustomerTransferFail(&msg)
+			}
+			if event.GetEvent() == models2.CALLBACK_EVENT_CHANGE_EXTERNAL_CHAT {
+				msg := models2.EventExternalChatUpdate{}
+				err := event.ReadMessage(&msg)
+				if err != nil {
+					println(err.Error())
+					return "error"
+				}
+				// 客户群创建/变更/解散，需回查企业微信，异步执行
+				changeType := event.GetChangeType()
+				go func() {
+					defer func() {
+						if r := recover(); r != nil {
+							logx.Errorf(`scrm.wework.customer.group.event.panic. %v`, r)
+						}
+					}()
+					err := l.svcCtx.PowerX.SCRM.Wechat.HandleWeWorkCustomerGroupEvent(changeType, msg.ChatID, msg.QuitScene)
+					if err != nil {
+						logx.Errorf(`scrm.wework.customer.group.event.error. %v`, err)
+					}
+				}()
+			}
+
 		}
 
 		return kernel.SUCCESS_EMPTY_RESPONSE
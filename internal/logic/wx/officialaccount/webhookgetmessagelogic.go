@@ -0,0 +1,37 @@
+package officialaccount
+
+import (
+	"PowerX/internal/svc"
+	"PowerX/pkg/httpx"
+	"context"
+	"github.com/zeromicro/go-zero/core/logx"
+	"net/http"
+)
+
+type WebhookGetMessageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewWebhookGetMessageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *WebhookGetMessageLogic {
+	return &WebhookGetMessageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// WebhookGetMessage 公众号服务器配置校验, 验签通过后原样返回echostr
+func (l *WebhookGetMessageLogic) WebhookGetMessage(w http.ResponseWriter, r *http.Request) {
+
+	rs, err := l.svcCtx.PowerX.WechatOA.App.Server.VerifyURL(r)
+	if err != nil {
+		panic(err)
+	}
+
+	err = httpx.HttpResponseSend(rs, w)
+	if err != nil {
+		panic(err)
+	}
+}
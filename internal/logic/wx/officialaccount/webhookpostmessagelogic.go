@@ -0,0 +1,116 @@
+package officialaccount
+
+import (
+	"PowerX/internal/model/wechat"
+	"PowerX/internal/svc"
+	"PowerX/pkg/httpx"
+	"context"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/kernel"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/kernel/contract"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/kernel/models"
+	oaModels "github.com/ArtisanCloud/PowerWeChat/v3/src/officialAccount/server/handlers/models"
+	"github.com/zeromicro/go-zero/core/logx"
+	"net/http"
+)
+
+// EventScan 已关注用户扫描带参二维码
+type EventScan struct {
+	contract.EventInterface
+	models.CallbackMessageHeader
+	EventKey string `xml:"EventKey"`
+	Ticket   string `xml:"Ticket"`
+}
+
+type WebhookPostMessageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewWebhookPostMessageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *WebhookPostMessageLogic {
+	return &WebhookPostMessageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// WebhookPostMessage 公众号消息与事件推送, 安全模式下由SDK使用配置的AESKey解密并加密回复
+func (l *WebhookPostMessageLogic) WebhookPostMessage(w http.ResponseWriter, r *http.Request) {
+
+	rs, err := l.svcCtx.PowerX.WechatOA.App.Server.Notify(r, func(event contract.EventInterface) interface{} {
+
+		switch event.GetMsgType() {
+		case models.CALLBACK_MSG_TYPE_TEXT:
+			msg := oaModels.MessageText{}
+			if err := event.ReadMessage(&msg); err != nil {
+				l.Errorf("wechat oa read text message failed: %v", err)
+				return kernel.SUCCESS_EMPTY_RESPONSE
+			}
+			return l.autoReply(event.GetFromUserName(), wechat.OAAutoReplyMatchTypeExact, msg.Content)
+
+		case models.CALLBACK_MSG_TYPE_EVENT:
+			return l.handleEvent(event)
+		}
+
+		return kernel.SUCCESS_EMPTY_RESPONSE
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	err = httpx.HttpResponseSend(rs, w)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (l *WebhookPostMessageLogic) handleEvent(event contract.EventInterface) interface{} {
+	oa := l.svcCtx.PowerX.WechatOA
+	openId := event.GetFromUserName()
+
+	switch event.GetEvent() {
+	case oaModels.CALLBACK_EVENT_SUBSCRIBE:
+		msg := oaModels.EventSubscribe{}
+		if err := event.ReadMessage(&msg); err != nil {
+			l.Errorf("wechat oa read subscribe event failed: %v", err)
+		}
+		if _, err := oa.SubscribeOACustomer(l.ctx, openId, msg.EventKey); err != nil {
+			l.Errorf("wechat oa subscribe customer failed: %v", err)
+		}
+		return l.autoReply(openId, wechat.OAAutoReplyMatchTypeSubscribe, "")
+
+	case oaModels.CALLBACK_EVENT_UNSUBSCRIBE:
+		if _, err := oa.UnsubscribeOACustomer(l.ctx, openId); err != nil {
+			l.Errorf("wechat oa unsubscribe customer failed: %v", err)
+		}
+
+	case oaModels.CALLBACK_EVENT_SCAN:
+		msg := EventScan{}
+		if err := event.ReadMessage(&msg); err != nil {
+			l.Errorf("wechat oa read scan event failed: %v", err)
+			break
+		}
+		if _, err := oa.ScanOACustomer(l.ctx, openId, msg.EventKey); err != nil {
+			l.Errorf("wechat oa scan customer failed: %v", err)
+		}
+
+	case oaModels.CALLBACK_EVENT_CLICK:
+		msg := oaModels.EventClick{}
+		if err := event.ReadMessage(&msg); err != nil {
+			l.Errorf("wechat oa read click event failed: %v", err)
+			break
+		}
+		return l.autoReply(openId, wechat.OAAutoReplyMatchTypeClick, msg.EventKey)
+	}
+
+	return kernel.SUCCESS_EMPTY_RESPONSE
+}
+
+func (l *WebhookPostMessageLogic) autoReply(openId string, matchType int8, keyword string) interface{} {
+	rule, err := l.svcCtx.PowerX.WechatOA.MatchOAAutoReplyRule(l.ctx, matchType, keyword)
+	if err != nil {
+		return kernel.SUCCESS_EMPTY_RESPONSE
+	}
+	return l.svcCtx.PowerX.WechatOA.BuildOAAutoReply(l.ctx, openId, rule)
+}
@@ -2,6 +2,7 @@ package payment
 
 import (
 	"PowerX/internal/logic/mp/crm/trade/payment"
+	"PowerX/internal/model/crm/trade"
 	"PowerX/internal/svc"
 	"context"
 	"github.com/zeromicro/go-zero/core/logx"
@@ -24,16 +25,8 @@ func NewWXPostPaymentLogic(ctx context.Context, svcCtx *svc.ServiceContext) *WXP
 
 func (l *WXPostPaymentLogic) WebhookWXPostPayment(w http.ResponseWriter, r *http.Request) {
 
-	handleWXPaidLogic := payment.NewHandleWXPaidLogic(l.ctx, l.svcCtx)
-	res, err := l.svcCtx.PowerX.Payment.WXPayment.HandlePaidNotify(r, handleWXPaidLogic.HandleWXPaid(w, r))
-
-	// 这里可能是因为不是微信官方调用的，无法正常解析出transaction和message，所以直接抛错。
-	if err != nil {
-		panic(err)
-	}
-
-	// 这里根据之前返回的是true或者fail，框架这边自动会帮你回复微信
-	err = res.Write(w)
+	// 支付与退款结果通知都由微信支付渠道验签解密后统一处理
+	payment.NewHandlePaymentNotificationLogic(l.ctx, l.svcCtx).HandleWebhook(w, r, trade.PaymentTypeWeChat)
 
 	return
 }
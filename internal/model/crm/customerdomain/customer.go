@@ -34,3 +34,15 @@ const TypeCustomerType = "_customer_type"
 
 const CustomerPersonal = "_personal"
 const CustomerCompany = "_company"
+
+// 列表接口允许排序及筛选的字段
+var CustomerQueryFields = powermodel.CommonQueryFields.Merge(powermodel.QueryFields{
+	"name":        {Column: "name", Type: powermodel.QueryFieldTypeString, Sortable: true, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterLike}},
+	"mobile":      {Column: "mobile", Type: powermodel.QueryFieldTypeString, Sortable: true, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterLike}},
+	"email":       {Column: "email", Type: powermodel.QueryFieldTypeString, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterLike}},
+	"source":      {Column: "source", Type: powermodel.QueryFieldTypeInt, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+	"type":        {Column: "type", Type: powermodel.QueryFieldTypeInt, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+	"isActivated": {Column: "is_activated", Type: powermodel.QueryFieldTypeBool, Filters: []string{powermodel.QueryFilterEq}},
+	"inviterId":   {Column: "inviter_id", Type: powermodel.QueryFieldTypeInt, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+	"mgmId":       {Column: "mgm_id", Type: powermodel.QueryFieldTypeInt, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+}).WithTable("customers")
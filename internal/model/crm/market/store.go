@@ -31,6 +31,14 @@ type Store struct {
 const TableNameStore = "stores"
 const StoreUniqueId = powermodel.UniqueId
 
+// 列表接口允许排序及筛选的字段
+var StoreQueryFields = powermodel.CommonQueryFields.Merge(powermodel.QueryFields{
+	"name":            {Column: "name", Type: powermodel.QueryFieldTypeString, Sortable: true, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterLike}},
+	"storeEmployeeId": {Column: "store_employee_id", Type: powermodel.QueryFieldTypeInt, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+	"address":         {Column: "address", Type: powermodel.QueryFieldTypeString, Filters: []string{powermodel.QueryFilterLike}},
+	"contactNumber":   {Column: "contact_number", Type: powermodel.QueryFieldTypeString, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterLike}},
+}).WithTable(TableNameStore)
+
 func (mdl *Store) LoadArtisans(db *gorm.DB, conditions *map[string]interface{}, withClauseAssociations bool) error {
 
 	mdl.Artisans = []*product.Artisan{}
@@ -36,6 +36,15 @@ type Artisan struct {
 const TableNameArtisan = "artisans"
 const ArtisanUniqueId = powermodel.UniqueId
 
+// 列表接口允许排序及筛选的字段
+var ArtisanQueryFields = powermodel.CommonQueryFields.Merge(powermodel.QueryFields{
+	"name":        {Column: "name", Type: powermodel.QueryFieldTypeString, Sortable: true, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterLike}},
+	"level":       {Column: "level", Type: powermodel.QueryFieldTypeInt, Sortable: true, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+	"employeeId":  {Column: "employee_id", Type: powermodel.QueryFieldTypeInt, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+	"workNo":      {Column: "work_no", Type: powermodel.QueryFieldTypeString, Sortable: true, Filters: []string{powermodel.QueryFilterEq}},
+	"phoneNumber": {Column: "phone_number", Type: powermodel.QueryFieldTypeString, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterLike}},
+}).WithTable(TableNameArtisan)
+
 // artisan level dd type
 const ArtisanLevelType = "_artisan_level"
 
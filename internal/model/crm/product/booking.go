@@ -0,0 +1,193 @@
+package product
+
+import (
+	"PowerX/internal/model/powermodel"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ArtisanSchedule 元匠在门店的每周排班, 同一天可以有多个时段, 时段按SlotMinutes切分为可预约的时间段
+type ArtisanSchedule struct {
+	powermodel.PowerModel
+
+	StoreId     int64  `gorm:"comment:门店Id; index:idx_store_artisan;not null" json:"storeId"`
+	ArtisanId   int64  `gorm:"comment:元匠Id; index:idx_store_artisan;not null" json:"artisanId"`
+	Weekday     int    `gorm:"comment:星期几, 0为周日" json:"weekday"`
+	StartTime   string `gorm:"comment:开始时间 HH:MM" json:"startTime"`
+	EndTime     string `gorm:"comment:结束时间 HH:MM" json:"endTime"`
+	SlotMinutes int    `gorm:"comment:每个预约时间段的分钟数" json:"slotMinutes"`
+}
+
+// ArtisanLeave 元匠请假, 请假期间在所有门店都不能被预约
+type ArtisanLeave struct {
+	powermodel.PowerModel
+
+	ArtisanId int64     `gorm:"comment:元匠Id; index;not null" json:"artisanId"`
+	StartAt   time.Time `gorm:"comment:请假开始时间; index" json:"startAt"`
+	EndAt     time.Time `gorm:"comment:请假结束时间; index" json:"endAt"`
+	Reason    string    `gorm:"comment:请假原因" json:"reason"`
+}
+
+// Booking 客户使用已支付的服务订单预约元匠在门店的服务时间段
+type Booking struct {
+	powermodel.PowerModel
+
+	Artisan *Artisan `gorm:"foreignKey:ArtisanId;references:Id" json:"artisan"`
+	Product *Product `gorm:"foreignKey:ProductId;references:Id" json:"product"`
+
+	StoreId         int64      `gorm:"comment:门店Id; index;not null" json:"storeId"`
+	ArtisanId       int64      `gorm:"comment:元匠Id; index;not null" json:"artisanId"`
+	CustomerId      int64      `gorm:"comment:客户Id; index;not null" json:"customerId"`
+	OrderId         int64      `gorm:"comment:服务订单Id; index" json:"orderId"`
+	OrderItemId     int64      `gorm:"comment:服务订单项Id, 每个订单项可预约的次数为购买数量; index;not null" json:"orderItemId"`
+	ProductId       int64      `gorm:"comment:服务产品Id; index" json:"productId"`
+	StartAt         time.Time  `gorm:"comment:预约开始时间; index" json:"startAt"`
+	EndAt           time.Time  `gorm:"comment:预约结束时间; index" json:"endAt"`
+	Status          string     `gorm:"comment:预约状态; index" json:"status"`
+	Comment         string     `gorm:"comment:客户备注" json:"comment"`
+	RescheduleCount int        `gorm:"comment:客户改期次数" json:"rescheduleCount"`
+	CancelReason    string     `gorm:"comment:取消原因" json:"cancelReason"`
+	CancelledAt     *time.Time `gorm:"comment:取消时间" json:"cancelledAt"`
+	CompletedAt     *time.Time `gorm:"comment:服务完成时间" json:"completedAt"`
+}
+
+const TableNameArtisanSchedule = "artisan_schedules"
+const TableNameArtisanLeave = "artisan_leaves"
+const TableNameBooking = "bookings"
+
+const (
+	BookingStatusBooked    = "_booked"    // 已预约
+	BookingStatusCancelled = "_cancelled" // 已取消
+	BookingStatusCompleted = "_completed" // 已完成
+)
+
+const (
+	DefaultBookingSlotMinutes = 60
+	// 客户在预约开始前2小时内不能改期或取消
+	BookingChangeDeadline = 2 * time.Hour
+	// 每个预约客户最多改期2次
+	BookingMaxRescheduleCount = 2
+	// 客户最多提前30天预约
+	BookingMaxAdvanceDays = 30
+)
+
+// TimeSlot 可预约的时间段
+type TimeSlot struct {
+	StartAt time.Time
+	EndAt   time.Time
+}
+
+// ParseClock 解析HH:MM格式的时间, 返回当天的分钟数, 24:00表示当天结束
+func ParseClock(clock string) (int, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(clock, "%d:%d", &hour, &minute); err != nil {
+		return 0, fmt.Errorf("时间格式错误: %s", clock)
+	}
+	if hour < 0 || minute < 0 || minute > 59 || hour*60+minute > 24*60 {
+		return 0, fmt.Errorf("时间格式错误: %s", clock)
+	}
+	return hour*60 + minute, nil
+}
+
+// IsOverlapping 两个时间段是否重叠, 首尾相接不算重叠
+func IsOverlapping(startA time.Time, endA time.Time, startB time.Time, endB time.Time) bool {
+	return startA.Before(endB) && startB.Before(endA)
+}
+
+// CanCustomerChange 客户只能在预约开始前的截止时间之前改期或取消
+func (mdl *Booking) CanCustomerChange(now time.Time) bool {
+	return mdl.Status == BookingStatusBooked && mdl.StartAt.Sub(now) >= BookingChangeDeadline
+}
+
+// storeWorkMinutes 门店营业时间只取时分, 开始和结束都未设置时视为全天营业
+func storeWorkMinutes(startWork time.Time, endWork time.Time) (int, int) {
+	start := startWork.Hour()*60 + startWork.Minute()
+	end := endWork.Hour()*60 + endWork.Minute()
+	if start == 0 && end == 0 {
+		return 0, 24 * 60
+	}
+	return start, end
+}
+
+// ComputeBookingSlots
+//
+//	@Description: 按门店营业时间与元匠当天排班的交集切分时间段, 去掉已过去的、与请假或已预约重叠的时间段
+//	@param date 预约日期, 按其所在时区计算
+//	@param startWork 门店开始营业时间, 只取时分
+//	@param endWork 门店结束营业时间, 只取时分
+//	@param schedules 元匠在门店的排班
+//	@param leaves 元匠的请假
+//	@param bookings 元匠的预约, 只有已预约状态占用时间段
+//	@param now
+//	@return []*TimeSlot 按开始时间排序
+func ComputeBookingSlots(
+	date time.Time, startWork time.Time, endWork time.Time,
+	schedules []*ArtisanSchedule, leaves []*ArtisanLeave, bookings []*Booking,
+	now time.Time,
+) []*TimeSlot {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	storeStart, storeEnd := storeWorkMinutes(startWork, endWork)
+
+	slots := []*TimeSlot{}
+	seen := map[int64]bool{}
+	for _, schedule := range schedules {
+		if schedule.Weekday != int(day.Weekday()) {
+			continue
+		}
+		start, errStart := ParseClock(schedule.StartTime)
+		end, errEnd := ParseClock(schedule.EndTime)
+		if errStart != nil || errEnd != nil {
+			continue
+		}
+		if start < storeStart {
+			start = storeStart
+		}
+		if end > storeEnd {
+			end = storeEnd
+		}
+		slotMinutes := schedule.SlotMinutes
+		if slotMinutes <= 0 {
+			slotMinutes = DefaultBookingSlotMinutes
+		}
+
+		for minute := start; minute+slotMinutes <= end; minute += slotMinutes {
+			slot := &TimeSlot{
+				StartAt: day.Add(time.Duration(minute) * time.Minute),
+				EndAt:   day.Add(time.Duration(minute+slotMinutes) * time.Minute),
+			}
+			if slot.StartAt.Before(now) || seen[slot.StartAt.Unix()] || isSlotOccupied(slot, leaves, bookings) {
+				continue
+			}
+			seen[slot.StartAt.Unix()] = true
+			slots = append(slots, slot)
+		}
+	}
+
+	sort.Slice(slots, func(i, j int) bool { return slots[i].StartAt.Before(slots[j].StartAt) })
+	return slots
+}
+
+func isSlotOccupied(slot *TimeSlot, leaves []*ArtisanLeave, bookings []*Booking) bool {
+	for _, leave := range leaves {
+		if IsOverlapping(slot.StartAt, slot.EndAt, leave.StartAt, leave.EndAt) {
+			return true
+		}
+	}
+	for _, booking := range bookings {
+		if booking.Status == BookingStatusBooked && IsOverlapping(slot.StartAt, slot.EndAt, booking.StartAt, booking.EndAt) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindTimeSlot 查找开始时间相同的时间段
+func FindTimeSlot(slots []*TimeSlot, startAt time.Time) *TimeSlot {
+	for _, slot := range slots {
+		if slot.StartAt.Equal(startAt) {
+			return slot
+		}
+	}
+	return nil
+}
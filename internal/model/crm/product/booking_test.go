@@ -0,0 +1,82 @@
+package product
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseClock(t *testing.T) {
+	minutes, err := ParseClock("09:30")
+	assert.NoError(t, err)
+	assert.Equal(t, 570, minutes)
+
+	minutes, err = ParseClock("24:00")
+	assert.NoError(t, err)
+	assert.Equal(t, 1440, minutes)
+
+	for _, clock := range []string{"", "9", "25:00", "10:60", "24:30"} {
+		_, err = ParseClock(clock)
+		assert.Error(t, err, clock)
+	}
+}
+
+func TestComputeBookingSlots(t *testing.T) {
+	// 2026-10-19 是周一
+	date := time.Date(2026, 10, 19, 0, 0, 0, 0, time.Local)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 10, 19, hour, minute, 0, 0, time.Local)
+	}
+	startWork := time.Date(0, 1, 1, 10, 0, 0, 0, time.Local)
+	endWork := time.Date(0, 1, 1, 18, 0, 0, 0, time.Local)
+	schedules := []*ArtisanSchedule{
+		{Weekday: 1, StartTime: "09:00", EndTime: "12:00", SlotMinutes: 60},
+		{Weekday: 1, StartTime: "14:00", EndTime: "19:00", SlotMinutes: 90},
+		{Weekday: 2, StartTime: "09:00", EndTime: "18:00"},
+	}
+	now := at(8, 0)
+
+	starts := func(slots []*TimeSlot) []time.Time {
+		result := []time.Time{}
+		for _, slot := range slots {
+			result = append(result, slot.StartAt)
+		}
+		return result
+	}
+
+	// 排班与营业时间取交集: 10:00-12:00 每60分钟, 14:00-18:00 每90分钟
+	slots := ComputeBookingSlots(date, startWork, endWork, schedules, nil, nil, now)
+	assert.Equal(t, []time.Time{at(10, 0), at(11, 0), at(14, 0), at(15, 30)}, starts(slots))
+	assert.Equal(t, at(17, 0), slots[3].EndAt)
+
+	// 请假及已预约的时间段被占用, 已取消的预约不占用
+	leaves := []*ArtisanLeave{{StartAt: at(10, 30), EndAt: at(11, 0)}}
+	bookings := []*Booking{
+		{Status: BookingStatusBooked, StartAt: at(14, 0), EndAt: at(15, 30)},
+		{Status: BookingStatusCancelled, StartAt: at(15, 30), EndAt: at(17, 0)},
+	}
+	slots = ComputeBookingSlots(date, startWork, endWork, schedules, leaves, bookings, now)
+	assert.Equal(t, []time.Time{at(11, 0), at(15, 30)}, starts(slots))
+
+	// 已过去的时间段不能预约
+	slots = ComputeBookingSlots(date, startWork, endWork, schedules, nil, nil, at(11, 0))
+	assert.Equal(t, []time.Time{at(11, 0), at(14, 0), at(15, 30)}, starts(slots))
+
+	// 门店未设置营业时间时按排班
+	slots = ComputeBookingSlots(date, time.Time{}, time.Time{}, schedules[:1], nil, nil, now)
+	assert.Equal(t, []time.Time{at(9, 0), at(10, 0), at(11, 0)}, starts(slots))
+
+	// 当天没有排班
+	assert.Empty(t, ComputeBookingSlots(date.AddDate(0, 0, 2), startWork, endWork, schedules, nil, nil, now))
+}
+
+func TestBooking_CanCustomerChange(t *testing.T) {
+	startAt := time.Date(2026, 10, 19, 14, 0, 0, 0, time.Local)
+	booking := &Booking{Status: BookingStatusBooked, StartAt: startAt}
+	assert.True(t, booking.CanCustomerChange(startAt.Add(-3*time.Hour)))
+	assert.True(t, booking.CanCustomerChange(startAt.Add(-BookingChangeDeadline)))
+	assert.False(t, booking.CanCustomerChange(startAt.Add(-time.Hour)))
+
+	booking.Status = BookingStatusCancelled
+	assert.False(t, booking.CanCustomerChange(startAt.Add(-3*time.Hour)))
+}
@@ -25,6 +25,9 @@ type PriceBookEntry struct {
 	UnitPrice   float64           `gorm:"type:decimal(10,2); comment:单价" json:"unitPrice"`
 	ListPrice   float64           `gorm:"type:decimal(10,2); comment:零售价" json:"listPrice"`
 	IsActive    bool              `gorm:"comment:是否激活" json:"isActive"`
+	// 组合商品的定价方式, 为空时按固定价格
+	BundlePricing  string  `gorm:"comment:组合商品定价方式 fixed/sum_minus_discount" json:"bundlePricing"`
+	BundleDiscount float64 `gorm:"type:decimal(10,2); comment:组合商品按组件合计定价时的优惠金额" json:"bundleDiscount"`
 }
 
 const TableNamePriceBookEntry = "price_book_entries"
@@ -48,3 +51,21 @@ func (mdl *PriceBookEntry) LoadProduct(db *gorm.DB, conditions *map[string]inter
 
 	return product, err
 }
+
+// MergePriceBookEntries 按产品及SKU合并价格条目, preferred中的条目优先, 缺失的使用fallback中的条目
+func MergePriceBookEntries(preferred []*PriceBookEntry, fallback []*PriceBookEntry) []*PriceBookEntry {
+	type entryKey struct{ productId, skuId int64 }
+	keys := map[entryKey]bool{}
+	entries := []*PriceBookEntry{}
+	for _, entry := range preferred {
+		keys[entryKey{entry.ProductId, entry.SkuId}] = true
+		entries = append(entries, entry)
+	}
+	for _, entry := range fallback {
+		if keys[entryKey{entry.ProductId, entry.SkuId}] {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -0,0 +1,22 @@
+package product
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMergePriceBookEntries(t *testing.T) {
+	storeProduct := &PriceBookEntry{ProductId: 1, UnitPrice: 8}
+	storeSku := &PriceBookEntry{ProductId: 1, SkuId: 11, UnitPrice: 9}
+	standardProduct := &PriceBookEntry{ProductId: 1, UnitPrice: 10}
+	standardSku := &PriceBookEntry{ProductId: 1, SkuId: 11, UnitPrice: 12}
+	standardOtherSku := &PriceBookEntry{ProductId: 1, SkuId: 12, UnitPrice: 13}
+
+	merged := MergePriceBookEntries(
+		[]*PriceBookEntry{storeProduct, storeSku},
+		[]*PriceBookEntry{standardProduct, standardSku, standardOtherSku},
+	)
+	assert.Equal(t, []*PriceBookEntry{storeProduct, storeSku, standardOtherSku}, merged)
+
+	assert.Equal(t, []*PriceBookEntry{standardProduct}, MergePriceBookEntries(nil, []*PriceBookEntry{standardProduct}))
+}
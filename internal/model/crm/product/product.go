@@ -27,6 +27,7 @@ type Product struct {
 	PivotDetailImages      []*media.PivotMediaResourceToObject  `gorm:"polymorphic:Object;polymorphicValue:products" json:"pivotDetailImages"`
 	PriceBooks             []*PriceBook                         `gorm:"many2many:public.price_book_entries;foreignKey:Id;joinForeignKey:Id;References:Id;JoinReferences:PriceBookId" json:"priceBooks"`
 	PriceBookEntries       []*PriceBookEntry                    `gorm:"foreignKey:ProductId;references:Id" json:"priceBookEntries"`
+	BundleItems            []*ProductBundleItem                 `gorm:"foreignKey:BundleProductId;references:Id" json:"bundleItems"`
 	PivotSalesChannels     []*model.PivotDataDictionaryToObject `gorm:"polymorphic:Object;polymorphicValue:products" json:"pivotSalesChannels"`
 	PivotPromoteChannels   []*model.PivotDataDictionaryToObject `gorm:"polymorphic:Object;polymorphicValue:products" json:"pivotPromoteChannels"`
 	ProductCategoryIds     []int64                              `gorm:"-"`
@@ -51,12 +52,32 @@ type Product struct {
 	SaleStartDate       time.Time `gorm:"comment:售卖开始时间"`
 	SaleEndDate         time.Time `gorm:"comment:售卖结束时间"`
 	Sort                int       `gorm:"comment:排序，越大约靠前"`
+	// 周期性产品的计费周期, 如每1个月扣费一次, 共12期
+	BillingIntervalUnit  string `gorm:"comment:计费周期单位 day/week/month/year"`
+	BillingIntervalCount int    `gorm:"comment:每期包含的计费周期单位数"`
+	BillingCycles        int    `gorm:"comment:订阅总期数，0为不限期"`
+	// 组合商品由多个SKU组成, 库存按组件计算, 下单时拆分为组件明细
+	IsBundle bool `gorm:"comment:是否为组合商品"`
 	ProductAttribute
 }
 
 const TableNameProduct = "products"
 const ProductUniqueId = powermodel.UniqueId
 
+// 列表接口允许排序及筛选的字段
+var ProductQueryFields = powermodel.CommonQueryFields.Merge(powermodel.QueryFields{
+	"name":           {Column: "name", Type: powermodel.QueryFieldTypeString, Sortable: true, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterLike}},
+	"spu":            {Column: "spu", Type: powermodel.QueryFieldTypeString, Sortable: true, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterLike}},
+	"type":           {Column: "type", Type: powermodel.QueryFieldTypeInt, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+	"plan":           {Column: "plan", Type: powermodel.QueryFieldTypeInt, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+	"isActivated":    {Column: "is_activated", Type: powermodel.QueryFieldTypeBool, Filters: []string{powermodel.QueryFilterEq}},
+	"canSellOnline":  {Column: "can_sell_online", Type: powermodel.QueryFieldTypeBool, Filters: []string{powermodel.QueryFilterEq}},
+	"approvalStatus": {Column: "approval_status", Type: powermodel.QueryFieldTypeInt, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+	"sort":           {Column: "sort", Type: powermodel.QueryFieldTypeInt, Sortable: true},
+	"saleStartDate":  {Column: "sale_start_date", Type: powermodel.QueryFieldTypeTime, Sortable: true, Filters: []string{powermodel.QueryFilterRange}},
+	"saleEndDate":    {Column: "sale_end_date", Type: powermodel.QueryFieldTypeTime, Sortable: true, Filters: []string{powermodel.QueryFilterRange}},
+}).WithTable(TableNameProduct)
+
 // Data Dictionary
 const TypeProductType = "_product_type"
 const TypeProductPlan = "_product_plan"
@@ -68,6 +89,13 @@ const ProductTypeService = "_service"
 const ProductPlanOnce = "_once"
 const ProductPlanPeriod = "_period"
 
+const (
+	BillingIntervalDay   = "day"
+	BillingIntervalWeek  = "week"
+	BillingIntervalMonth = "month"
+	BillingIntervalYear  = "year"
+)
+
 func (mdl *Product) GetTableName(needFull bool) string {
 	tableName := TableNameProduct
 	if needFull {
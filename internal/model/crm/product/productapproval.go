@@ -0,0 +1,63 @@
+package product
+
+import (
+	"PowerX/internal/model/powermodel"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"time"
+)
+
+// 已上架产品的修改内容, 审核通过后才会更新到线上产品
+type ProductRevision struct {
+	powermodel.PowerModel
+
+	ProductId   int64          `gorm:"comment:产品Id;index" json:"productId"`
+	Status      string         `gorm:"comment:审核状态;index" json:"status"`
+	Payload     datatypes.JSON `gorm:"comment:修改后的产品内容" json:"payload"`
+	SubmittedBy int64          `gorm:"comment:提交员工Id" json:"submittedBy"`
+	ReviewedBy  int64          `gorm:"comment:审核员工Id" json:"reviewedBy"`
+	ReviewedAt  *time.Time     `gorm:"comment:审核时间" json:"reviewedAt"`
+	Comment     string         `gorm:"comment:审核意见" json:"comment"`
+}
+
+// 产品审核的操作记录, 每次提交及审核决定都会保留
+type ProductApprovalRecord struct {
+	powermodel.PowerModel
+
+	ProductId      int64  `gorm:"comment:产品Id;index" json:"productId"`
+	RevisionId     int64  `gorm:"comment:修订Id, 0为产品本身;index" json:"revisionId"`
+	Action         string `gorm:"comment:审核操作" json:"action"`
+	ApprovalStatus string `gorm:"comment:操作后的审核状态" json:"approvalStatus"`
+	OperatorId     int64  `gorm:"comment:操作员工Id" json:"operatorId"`
+	Comment        string `gorm:"comment:审核意见" json:"comment"`
+}
+
+const (
+	ProductApprovalActionSubmit  = "_submit"  // 提交审核
+	ProductApprovalActionRevise  = "_revise"  // 提交修订
+	ProductApprovalActionApprove = "_approve" // 审核通过
+	ProductApprovalActionReject  = "_reject"  // 审核驳回
+)
+
+// 售卖时间早于该时间视为未设置, 不限制售卖时间
+var ProductSaleDateUnsetBefore = time.Date(1971, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// IsInSalePeriod 判断产品在指定时间是否处于售卖时间内, 未设置的开始或结束时间不做限制
+func (mdl *Product) IsInSalePeriod(now time.Time) bool {
+	if mdl.SaleStartDate.After(ProductSaleDateUnsetBefore) && now.Before(mdl.SaleStartDate) {
+		return false
+	}
+	if mdl.SaleEndDate.After(ProductSaleDateUnsetBefore) && now.After(mdl.SaleEndDate) {
+		return false
+	}
+	return true
+}
+
+// WhereInSalePeriod 筛选在指定时间处于售卖时间内的记录, 与IsInSalePeriod的判断一致
+func WhereInSalePeriod(now time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.
+			Where("(sale_start_date < ? OR sale_start_date <= ?)", ProductSaleDateUnsetBefore, now).
+			Where("(sale_end_date < ? OR sale_end_date >= ?)", ProductSaleDateUnsetBefore, now)
+	}
+}
@@ -0,0 +1,32 @@
+package product
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestProduct_IsInSalePeriod(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.Local)
+	day := 24 * time.Hour
+
+	cases := []struct {
+		name  string
+		start time.Time
+		end   time.Time
+		want  bool
+	}{
+		{"未设置售卖时间", time.Time{}, time.Time{}, true},
+		{"在售卖时间内", now.Add(-day), now.Add(day), true},
+		{"未到开始时间", now.Add(day), now.Add(2 * day), false},
+		{"已过结束时间", now.Add(-2 * day), now.Add(-day), false},
+		{"只设置开始时间", now.Add(-day), time.Time{}, true},
+		{"只设置结束时间", time.Time{}, now.Add(-day), false},
+		// carbon解析空字符串得到的零值时间
+		{"零值时间", time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC), true},
+	}
+	for _, c := range cases {
+		product := &Product{SaleStartDate: c.start, SaleEndDate: c.end}
+		assert.Equal(t, c.want, product.IsInSalePeriod(now), c.name)
+	}
+}
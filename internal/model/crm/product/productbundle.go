@@ -0,0 +1,71 @@
+package product
+
+import (
+	"PowerX/internal/model/powermodel"
+	"math"
+)
+
+// ProductBundleItem 组合商品(礼盒、套餐)包含的SKU及数量, 组合商品本身没有SKU和库存
+type ProductBundleItem struct {
+	powermodel.PowerModel
+
+	Product *Product `gorm:"foreignKey:ProductId;references:Id" json:"product"`
+	SKU     *SKU     `gorm:"foreignKey:SkuId;references:Id" json:"sku"`
+
+	BundleProductId int64 `gorm:"comment:组合商品Id; index;not null" json:"bundleProductId"`
+	ProductId       int64 `gorm:"comment:组件产品Id; index;not null" json:"productId"`
+	SkuId           int64 `gorm:"comment:组件SKUId; index;not null" json:"skuId"`
+	Quantity        int   `gorm:"comment:每份组合商品包含的数量" json:"quantity"`
+	Sort            int   `gorm:"comment:排序" json:"sort"`
+}
+
+const TableNameProductBundleItem = "product_bundle_items"
+
+// 组合商品在价格手册中的定价方式
+const (
+	// 固定价格, 取价格条目的单价
+	BundlePricingFixed = "fixed"
+	// 各组件在同一价格手册中的单价合计减去优惠金额
+	BundlePricingSumMinusDiscount = "sum_minus_discount"
+)
+
+func IsValidBundlePricing(pricing string) bool {
+	return pricing == "" || pricing == BundlePricingFixed || pricing == BundlePricingSumMinusDiscount
+}
+
+// BundleAvailableQuantity 按组件库存计算组合商品可售份数, 需要预加载组件SKU
+func BundleAvailableQuantity(items []*ProductBundleItem) int {
+	if len(items) == 0 {
+		return 0
+	}
+	available := math.MaxInt
+	for _, item := range items {
+		if item.SKU == nil || item.SKU.IsRetired || item.Quantity <= 0 {
+			return 0
+		}
+		if quantity := item.SKU.Inventory / item.Quantity; quantity < available {
+			available = quantity
+		}
+	}
+	if available < 0 {
+		return 0
+	}
+	return available
+}
+
+// ComputeBundleUnitPrice
+//
+//	@Description: 组合商品的成交单价, 合计减优惠的定价方式下不低于0
+//	@param entry 组合商品的价格条目
+//	@param componentTotal 各组件单价乘以数量的合计
+//	@return float64
+func ComputeBundleUnitPrice(entry *PriceBookEntry, componentTotal float64) float64 {
+	if entry.BundlePricing != BundlePricingSumMinusDiscount {
+		return entry.UnitPrice
+	}
+	price := math.Round((componentTotal-entry.BundleDiscount)*100) / 100
+	if price < 0 {
+		return 0
+	}
+	return price
+}
@@ -0,0 +1,38 @@
+package product
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBundleAvailableQuantity(t *testing.T) {
+	items := []*ProductBundleItem{
+		{SKU: &SKU{Inventory: 10}, Quantity: 2},
+		{SKU: &SKU{Inventory: 7}, Quantity: 1},
+	}
+	assert.Equal(t, 5, BundleAvailableQuantity(items))
+
+	items[1].SKU.Inventory = 3
+	assert.Equal(t, 3, BundleAvailableQuantity(items))
+
+	// 任一组件停用或未加载时不可售
+	items[1].SKU.IsRetired = true
+	assert.Equal(t, 0, BundleAvailableQuantity(items))
+	assert.Equal(t, 0, BundleAvailableQuantity([]*ProductBundleItem{{Quantity: 1}}))
+	assert.Equal(t, 0, BundleAvailableQuantity(nil))
+}
+
+func TestComputeBundleUnitPrice(t *testing.T) {
+	fixed := &PriceBookEntry{UnitPrice: 199, BundlePricing: BundlePricingFixed}
+	assert.Equal(t, 199.0, ComputeBundleUnitPrice(fixed, 300))
+
+	// 未设置定价方式时按固定价格
+	assert.Equal(t, 99.0, ComputeBundleUnitPrice(&PriceBookEntry{UnitPrice: 99}, 300))
+
+	discount := &PriceBookEntry{UnitPrice: 199, BundlePricing: BundlePricingSumMinusDiscount, BundleDiscount: 20.5}
+	assert.Equal(t, 279.5, ComputeBundleUnitPrice(discount, 300))
+	assert.Equal(t, 0.0, ComputeBundleUnitPrice(discount, 10))
+
+	assert.True(t, IsValidBundlePricing(""))
+	assert.False(t, IsValidBundlePricing("percent"))
+}
@@ -26,6 +26,13 @@ type ProductCategory struct {
 
 const ProductCategoryUniqueId = powermodel.UniqueId
 
+// 列表接口允许排序及筛选的字段
+var ProductCategoryQueryFields = powermodel.CommonQueryFields.Merge(powermodel.QueryFields{
+	"name": {Column: "name", Type: powermodel.QueryFieldTypeString, Sortable: true, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterLike}},
+	"pId":  {Column: "p_id", Type: powermodel.QueryFieldTypeInt, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+	"sort": {Column: "sort", Type: powermodel.QueryFieldTypeInt, Sortable: true},
+}).WithTable("product_categories")
+
 func (mdl *ProductCategory) GetCategoryIds(categories []*ProductCategory) []int64 {
 	uniqueIds := make(map[int64]bool)
 	arrayIds := []int64{}
@@ -0,0 +1,47 @@
+package product
+
+import (
+	"PowerX/internal/model/powermodel"
+	"gorm.io/datatypes"
+	"time"
+)
+
+// 产品目录的批量导入任务, 文件上传后异步处理, 试运行只校验不写入
+type ProductImportJob struct {
+	powermodel.PowerModel
+
+	FileName        string         `gorm:"comment:文件名" json:"fileName"`
+	Format          string         `gorm:"comment:文件格式 csv/xlsx" json:"format"`
+	Content         []byte         `gorm:"type:bytea;comment:文件内容" json:"-"`
+	DryRun          bool           `gorm:"comment:是否试运行" json:"dryRun"`
+	Status          string         `gorm:"comment:任务状态;index" json:"status"`
+	TotalRows       int            `gorm:"comment:数据行数" json:"totalRows"`
+	ProcessedRows   int            `gorm:"comment:已处理行数" json:"processedRows"`
+	FailedRows      int            `gorm:"comment:失败行数" json:"failedRows"`
+	CreatedProducts int            `gorm:"comment:新建产品数" json:"createdProducts"`
+	UpdatedProducts int            `gorm:"comment:更新产品数" json:"updatedProducts"`
+	RowErrors       datatypes.JSON `gorm:"comment:行级错误" json:"rowErrors"`
+	Message         string         `gorm:"comment:任务失败原因" json:"message"`
+	OperatorId      int64          `gorm:"comment:操作员工Id;index" json:"operatorId"`
+	StartedAt       *time.Time     `gorm:"comment:开始处理时间" json:"startedAt"`
+	FinishedAt      *time.Time     `gorm:"comment:处理完成时间" json:"finishedAt"`
+}
+
+// 导入文件中的行级错误, 行号与文件中的行号一致, 表头为第1行
+type ProductImportRowError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column"`
+	Message string `json:"message"`
+}
+
+const (
+	ProductImportFormatCSV  = "csv"
+	ProductImportFormatXLSX = "xlsx"
+)
+
+const (
+	ProductImportStatusPending    = "_pending"    // 等待处理
+	ProductImportStatusProcessing = "_processing" // 处理中
+	ProductImportStatusCompleted  = "_completed"  // 已完成
+	ProductImportStatusFailed     = "_failed"     // 处理失败
+)
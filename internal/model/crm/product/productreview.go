@@ -0,0 +1,98 @@
+package product
+
+import (
+	"PowerX/internal/model/media"
+	"PowerX/internal/model/powermodel"
+	"math"
+	"time"
+)
+
+// ProductReview 客户对已完成订单项的评价, 审核通过后才在小程序展示并计入产品评分
+type ProductReview struct {
+	powermodel.PowerModel
+
+	PivotImages []*media.PivotMediaResourceToObject `gorm:"polymorphic:Object;polymorphicValue:product_reviews" json:"pivotImages"`
+
+	ProductId    int64      `gorm:"comment:产品Id; index;not null" json:"productId"`
+	SkuId        int64      `gorm:"comment:SKUId; index" json:"skuId"`
+	OrderId      int64      `gorm:"comment:订单Id; index" json:"orderId"`
+	OrderItemId  int64      `gorm:"comment:订单项Id, 每个订单项只能评价一次; uniqueIndex;not null" json:"orderItemId"`
+	CustomerId   int64      `gorm:"comment:客户Id; index;not null" json:"customerId"`
+	CustomerName string     `gorm:"comment:评价时的客户名称" json:"customerName"`
+	IsAnonymous  bool       `gorm:"comment:是否匿名评价" json:"isAnonymous"`
+	SkuNo        string     `gorm:"comment:购买的规格" json:"skuNo"`
+	Rating       int        `gorm:"comment:评分1-5; index" json:"rating"`
+	Content      string     `gorm:"comment:评价内容; type:text" json:"content"`
+	Status       string     `gorm:"comment:审核状态; index" json:"status"`
+	HelpfulCount int        `gorm:"comment:有用数; index" json:"helpfulCount"`
+	HiddenReason string     `gorm:"comment:隐藏原因" json:"hiddenReason"`
+	ModeratedBy  int64      `gorm:"comment:审核人Id" json:"moderatedBy"`
+	ModeratedAt  *time.Time `gorm:"comment:审核时间" json:"moderatedAt"`
+	Reply        string     `gorm:"comment:商家回复; type:text" json:"reply"`
+	RepliedBy    int64      `gorm:"comment:回复人Id" json:"repliedBy"`
+	RepliedAt    *time.Time `gorm:"comment:回复时间" json:"repliedAt"`
+}
+
+// ProductReviewHelpful 客户标记评价有用的记录, 每个客户对同一评价只计一次
+type ProductReviewHelpful struct {
+	powermodel.PowerModel
+
+	ReviewId   int64 `gorm:"comment:评价Id; uniqueIndex:idx_review_customer;not null" json:"reviewId"`
+	CustomerId int64 `gorm:"comment:客户Id; uniqueIndex:idx_review_customer;not null" json:"customerId"`
+}
+
+const TableNameProductReview = "product_reviews"
+
+const (
+	ProductReviewStatusPending  = "_pending"  // 待审核
+	ProductReviewStatusApproved = "_approved" // 已通过
+	ProductReviewStatusHidden   = "_hidden"   // 已隐藏
+)
+
+const (
+	ProductReviewSortNewest  = "newest"
+	ProductReviewSortHelpful = "helpful"
+)
+
+const (
+	ProductReviewMinRating     = 1
+	ProductReviewMaxRating     = 5
+	ProductReviewMaxImages     = 9
+	ProductReviewMaxContentLen = 1000
+)
+
+func (mdl *ProductReview) GetTableName(needFull bool) string {
+	tableName := TableNameProductReview
+	if needFull {
+		tableName = "public." + tableName
+	}
+	return tableName
+}
+
+func (mdl *ProductReview) GetForeignReferValue() int64 {
+	return mdl.Id
+}
+
+// DisplayCustomerName 匿名评价只展示客户名称的首尾字符
+func (mdl *ProductReview) DisplayCustomerName() string {
+	if !mdl.IsAnonymous {
+		return mdl.CustomerName
+	}
+	name := []rune(mdl.CustomerName)
+	switch len(name) {
+	case 0:
+		return "匿名用户"
+	case 1, 2:
+		return string(name[0]) + "*"
+	default:
+		return string(name[0]) + "***" + string(name[len(name)-1])
+	}
+}
+
+// AverageRating 平均评分四舍五入保留一位小数
+func AverageRating(ratingSum int64, reviewCount int64) float64 {
+	if reviewCount <= 0 {
+		return 0
+	}
+	return math.Round(float64(ratingSum)*10/float64(reviewCount)) / 10
+}
@@ -0,0 +1,31 @@
+package product
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAverageRating(t *testing.T) {
+	assert.Equal(t, 0.0, AverageRating(0, 0))
+	assert.Equal(t, 5.0, AverageRating(5, 1))
+	assert.Equal(t, 4.5, AverageRating(9, 2))
+	assert.Equal(t, 4.7, AverageRating(14, 3))
+	assert.Equal(t, 4.3, AverageRating(13, 3))
+}
+
+func TestProductReview_DisplayCustomerName(t *testing.T) {
+	cases := []struct {
+		name        string
+		isAnonymous bool
+		want        string
+	}{
+		{"张三丰", false, "张三丰"},
+		{"张三丰", true, "张***丰"},
+		{"张三", true, "张*"},
+		{"", true, "匿名用户"},
+	}
+	for _, c := range cases {
+		review := &ProductReview{CustomerName: c.name, IsAnonymous: c.isAnonymous}
+		assert.Equal(t, c.want, review.DisplayCustomerName(), c.name)
+	}
+}
@@ -0,0 +1,36 @@
+package product
+
+import (
+	"PowerX/internal/model/powermodel"
+	"gorm.io/datatypes"
+	"time"
+)
+
+// 产品的检索文档, 由索引器根据产品, 品类, 规格, 价格及销售渠道生成, 基于PostgreSQL全文检索
+type ProductSearchDocument struct {
+	powermodel.PowerModel
+
+	ProductId       int64          `gorm:"comment:产品Id;unique" json:"productId"`
+	Name            string         `gorm:"comment:产品名称" json:"name"`
+	SPU             string         `gorm:"comment:产品货号;index" json:"spu"`
+	CategoryIds     datatypes.JSON `gorm:"type:jsonb;comment:品类Id, 包含上级品类" json:"categoryIds"`
+	CategoryPath    string         `gorm:"type:text;comment:品类路径" json:"categoryPath"`
+	SpecificOptions datatypes.JSON `gorm:"type:jsonb;comment:规格项, 规格名:规格项" json:"specificOptions"`
+	SalesChannelIds datatypes.JSON `gorm:"type:jsonb;comment:销售渠道Id" json:"salesChannelIds"`
+	MinPrice        float64        `gorm:"type:decimal(10,2);comment:标准价格手册最低价;index" json:"minPrice"`
+	MaxPrice        float64        `gorm:"type:decimal(10,2);comment:标准价格手册最高价" json:"maxPrice"`
+	IsActivated     bool           `gorm:"comment:产品是否被激活" json:"isActivated"`
+	CanSellOnline   bool           `gorm:"comment:是否允许线上销售" json:"canSellOnline"`
+	IsApproved      bool           `gorm:"comment:产品是否审核通过" json:"isApproved"`
+	SaleStartDate   time.Time      `gorm:"comment:售卖开始时间" json:"saleStartDate"`
+	SaleEndDate     time.Time      `gorm:"comment:售卖结束时间" json:"saleEndDate"`
+	Sort            int            `gorm:"comment:产品排序" json:"sort"`
+	Tokens          string         `gorm:"type:text;comment:分词结果" json:"tokens"`
+	SearchVector    string         `gorm:"type:tsvector;index:idx_product_search_vector,type:gin;comment:全文检索向量" json:"-"`
+	IndexedAt       time.Time      `gorm:"comment:索引时间;index" json:"indexedAt"`
+}
+
+const ProductSearchDocumentUniqueId = "product_id"
+
+// 检索文档使用simple配置, 分词由索引器完成
+const ProductSearchConfig = "simple"
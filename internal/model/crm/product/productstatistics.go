@@ -1,7 +1,12 @@
 package product
 
-import "PowerX/internal/model/powermodel"
+import (
+	"PowerX/internal/model/powermodel"
+	"time"
+)
 
+// ProductStatistics 产品的实际统计, 销量由订单付清和退款维护, 浏览量由小程序详情访问汇总, 库存由SKU及仓库库存同步;
+// Base开头的字段是运营配置的展示偏移量, 小程序展示时与实际统计相加
 type ProductStatistics struct {
 	powermodel.PowerModel
 
@@ -12,6 +17,65 @@ type ProductStatistics struct {
 	BaseSoldAmount        int64 `gorm:"comment:销量" json:"baseSoldAmount"`
 	BaseInventoryQuantity int64 `gorm:"comment:库存;" json:"baseInventoryQuantity"`
 	BaseViewCount         int64 `gorm:"comment:浏览量;" json:"baseViewCount"`
+
+	// 评价统计只计入审核通过的评价
+	ReviewCount   int64   `gorm:"comment:评价数;" json:"reviewCount"`
+	RatingSum     int64   `gorm:"comment:评分合计;" json:"ratingSum"`
+	AverageRating float64 `gorm:"type:decimal(3,1); comment:平均评分;" json:"averageRating"`
+}
+
+// ProductStatisticsSnapshot 产品统计的每日快照, 记录当日结束时的实际统计及当日增量, 用于绘制产品表现趋势
+type ProductStatisticsSnapshot struct {
+	powermodel.PowerModel
+
+	ProductId         int64     `gorm:"comment:产品Id; uniqueIndex:idx_product_snapshot_date;not null" json:"productId"`
+	SnapshotDate      time.Time `gorm:"comment:快照日期; type:date; uniqueIndex:idx_product_snapshot_date;not null" json:"snapshotDate"`
+	SoldAmount        int64     `gorm:"comment:累计销量" json:"soldAmount"`
+	InventoryQuantity int64     `gorm:"comment:库存" json:"inventoryQuantity"`
+	ViewCount         int64     `gorm:"comment:累计浏览量" json:"viewCount"`
+	ReviewCount       int64     `gorm:"comment:评价数" json:"reviewCount"`
+	AverageRating     float64   `gorm:"type:decimal(3,1); comment:平均评分" json:"averageRating"`
+	DailySoldAmount   int64     `gorm:"comment:当日销量" json:"dailySoldAmount"`
+	DailyViewCount    int64     `gorm:"comment:当日浏览量" json:"dailyViewCount"`
 }
 
 const ProductStatisticsUniqueId = "product_id"
+
+// ProductStatisticsBaseColumns 运营可配置的展示偏移字段, 其余字段由系统计算
+var ProductStatisticsBaseColumns = []string{"base_sold_amount", "base_inventory_quantity", "base_view_count"}
+
+// ProductStatisticsReviewColumns 评价统计字段只由评价审核刷新
+var ProductStatisticsReviewColumns = []string{"review_count", "rating_sum", "average_rating"}
+
+// DisplaySoldAmount 展示销量为实际销量加偏移量
+func (mdl *ProductStatistics) DisplaySoldAmount() int64 {
+	return mdl.BaseSoldAmount + mdl.SoldAmount
+}
+
+func (mdl *ProductStatistics) DisplayInventoryQuantity() int64 {
+	return mdl.BaseInventoryQuantity + mdl.InventoryQuantity
+}
+
+func (mdl *ProductStatistics) DisplayViewCount() int64 {
+	return mdl.BaseViewCount + mdl.ViewCount
+}
+
+// MakeProductStatisticsSnapshot 按当前统计和前一日快照生成快照, 前一日没有快照时当日增量为累计值
+func MakeProductStatisticsSnapshot(statistics *ProductStatistics, previous *ProductStatisticsSnapshot, date time.Time) *ProductStatisticsSnapshot {
+	snapshot := &ProductStatisticsSnapshot{
+		ProductId:         statistics.ProductId,
+		SnapshotDate:      time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location()),
+		SoldAmount:        statistics.SoldAmount,
+		InventoryQuantity: statistics.InventoryQuantity,
+		ViewCount:         statistics.ViewCount,
+		ReviewCount:       statistics.ReviewCount,
+		AverageRating:     statistics.AverageRating,
+		DailySoldAmount:   statistics.SoldAmount,
+		DailyViewCount:    statistics.ViewCount,
+	}
+	if previous != nil {
+		snapshot.DailySoldAmount = statistics.SoldAmount - previous.SoldAmount
+		snapshot.DailyViewCount = statistics.ViewCount - previous.ViewCount
+	}
+	return snapshot
+}
@@ -0,0 +1,25 @@
+package product
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMakeProductStatisticsSnapshot(t *testing.T) {
+	date := time.Date(2023, 6, 1, 0, 5, 0, 0, time.Local)
+	statistics := &ProductStatistics{ProductId: 1, SoldAmount: 30, ViewCount: 500, InventoryQuantity: 20, BaseSoldAmount: 1000}
+
+	first := MakeProductStatisticsSnapshot(statistics, nil, date)
+	assert.Equal(t, time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local), first.SnapshotDate)
+	assert.Equal(t, int64(30), first.SoldAmount)
+	assert.Equal(t, int64(30), first.DailySoldAmount)
+	assert.Equal(t, int64(500), first.DailyViewCount)
+
+	// 退款使累计销量减少时当日增量为负数
+	previous := &ProductStatisticsSnapshot{SoldAmount: 32, ViewCount: 420}
+	next := MakeProductStatisticsSnapshot(statistics, previous, date)
+	assert.Equal(t, int64(-2), next.DailySoldAmount)
+	assert.Equal(t, int64(80), next.DailyViewCount)
+	assert.Equal(t, int64(1030), statistics.DisplaySoldAmount())
+}
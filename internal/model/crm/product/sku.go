@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/ArtisanCloud/PowerLibs/v3/object"
 	"gorm.io/datatypes"
+	"time"
 )
 
 // SKU 数据表结构
@@ -20,6 +21,9 @@ type SKU struct {
 	SkuNo     string            `gorm:"comment:SKU编号" json:"sku"`
 	Inventory int               `gorm:"comment:库存数量" json:"inventory"`
 	OptionIds datatypes.JSON    `gorm:"comment:规格Ids" json:"OptionIds"`
+	// 规格组合被移除后SKU只停用不删除, 保留价格、库存及订单和购物车的引用, 组合恢复时重新启用
+	IsRetired bool       `gorm:"comment:是否已停用;index" json:"isRetired"`
+	RetiredAt *time.Time `gorm:"comment:停用时间" json:"retiredAt"`
 }
 
 const TableNameSKU = "sku"
@@ -0,0 +1,47 @@
+package product
+
+import (
+	"PowerX/internal/model/powermodel"
+)
+
+// StoreProduct 上架到门店的产品, 小程序带门店上下文时只展示门店已上架且可售的产品
+type StoreProduct struct {
+	powermodel.PowerModel
+
+	Product *Product `gorm:"foreignKey:ProductId;references:Id" json:"product"`
+
+	StoreId     int64 `gorm:"comment:门店Id; uniqueIndex:idx_store_product;not null" json:"storeId"`
+	ProductId   int64 `gorm:"comment:产品Id; uniqueIndex:idx_store_product;index;not null" json:"productId"`
+	IsAvailable bool  `gorm:"comment:门店是否可售" json:"isAvailable"`
+}
+
+// StoreSKU 门店对SKU的可售及库存覆盖, 未配置时使用SKU本身的库存
+type StoreSKU struct {
+	powermodel.PowerModel
+
+	StoreId     int64 `gorm:"comment:门店Id; uniqueIndex:idx_store_sku;not null" json:"storeId"`
+	ProductId   int64 `gorm:"comment:产品Id; index;not null" json:"productId"`
+	SkuId       int64 `gorm:"comment:SKUId; uniqueIndex:idx_store_sku;not null" json:"skuId"`
+	IsAvailable bool  `gorm:"comment:门店是否可售" json:"isAvailable"`
+	Inventory   *int  `gorm:"comment:门店库存, 为空时使用SKU库存" json:"inventory"`
+}
+
+const TableNameStoreProduct = "store_products"
+const TableNameStoreSKU = "store_skus"
+
+// ResolveStoreSKUInventory 门店SKU的可售状态及可售库存, override为空时使用SKU本身的库存
+func ResolveStoreSKUInventory(sku *SKU, override *StoreSKU) (inventory int, isAvailable bool) {
+	if sku == nil || sku.IsRetired {
+		return 0, false
+	}
+	if override == nil {
+		return sku.Inventory, true
+	}
+	if !override.IsAvailable {
+		return 0, false
+	}
+	if override.Inventory != nil {
+		return *override.Inventory, true
+	}
+	return sku.Inventory, true
+}
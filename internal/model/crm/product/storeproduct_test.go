@@ -0,0 +1,31 @@
+package product
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestResolveStoreSKUInventory(t *testing.T) {
+	storeInventory := 3
+	zero := 0
+	cases := []struct {
+		name          string
+		sku           *SKU
+		override      *StoreSKU
+		wantInventory int
+		wantAvailable bool
+	}{
+		{"no sku", nil, nil, 0, false},
+		{"retired sku", &SKU{Inventory: 10, IsRetired: true}, nil, 0, false},
+		{"no override", &SKU{Inventory: 10}, nil, 10, true},
+		{"unavailable in store", &SKU{Inventory: 10}, &StoreSKU{IsAvailable: false, Inventory: &storeInventory}, 0, false},
+		{"store inventory", &SKU{Inventory: 10}, &StoreSKU{IsAvailable: true, Inventory: &storeInventory}, 3, true},
+		{"store sold out", &SKU{Inventory: 10}, &StoreSKU{IsAvailable: true, Inventory: &zero}, 0, true},
+		{"availability only", &SKU{Inventory: 10}, &StoreSKU{IsAvailable: true}, 10, true},
+	}
+	for _, c := range cases {
+		inventory, available := ResolveStoreSKUInventory(c.sku, c.override)
+		assert.Equal(t, c.wantInventory, inventory, c.name)
+		assert.Equal(t, c.wantAvailable, available, c.name)
+	}
+}
@@ -0,0 +1,61 @@
+package trade
+
+import (
+	"PowerX/internal/model/media"
+	"PowerX/internal/model/powermodel"
+	"github.com/ArtisanCloud/PowerLibs/v3/object"
+	"github.com/golang-module/carbon/v2"
+	"time"
+)
+
+// 订单的开票申请及开具记录, 蓝字发票由客户申请, 红字发票在订单退款时生成, 冲销对应的蓝字发票
+type Invoice struct {
+	*powermodel.PowerModel
+
+	Order       *Order               `gorm:"foreignKey:OrderId;references:Id" json:"order"`
+	PdfResource *media.MediaResource `gorm:"foreignKey:PdfResourceId;references:Id" json:"pdfResource"`
+
+	OrderId        int64      `gorm:"comment:订单Id;index" json:"orderId"`
+	CustomerId     int64      `gorm:"comment:客户Id;index" json:"customerId"`
+	RequestNumber  string     `gorm:"comment:开票申请单号;unique" json:"requestNumber"`
+	Kind           string     `gorm:"comment:发票种类 蓝字/红字;index" json:"kind"`
+	BlueInvoiceId  int64      `gorm:"comment:红字发票冲销的蓝字发票Id;index" json:"blueInvoiceId"`
+	TitleType      string     `gorm:"comment:抬头类型 个人/单位" json:"titleType"`
+	Title          string     `gorm:"comment:发票抬头" json:"title"`
+	TaxIdNumber    string     `gorm:"comment:纳税人识别号" json:"taxIdNumber"`
+	Email          string     `gorm:"comment:接收邮箱" json:"email"`
+	Amount         float64    `gorm:"type:decimal(10,2); comment:开票金额" json:"amount"`
+	Status         string     `gorm:"comment:开票状态;index" json:"status"`
+	InvoiceCode    string     `gorm:"comment:发票代码" json:"invoiceCode"`
+	InvoiceNumber  string     `gorm:"comment:发票号码;index" json:"invoiceNumber"`
+	IssuedAt       *time.Time `gorm:"comment:开票时间" json:"issuedAt"`
+	PdfResourceId  int64      `gorm:"comment:发票PDF媒体资源Id" json:"pdfResourceId"`
+	ReviewedBy     int64      `gorm:"comment:审核员工Id" json:"reviewedBy"`
+	RejectReason   string     `gorm:"comment:驳回原因" json:"rejectReason"`
+	ReversedAmount float64    `gorm:"type:decimal(10,2); comment:已红冲金额" json:"reversedAmount"`
+	Remark         string     `gorm:"comment:备注" json:"remark"`
+}
+
+const InvoiceUniqueId = powermodel.UniqueId
+
+const (
+	InvoiceKindBlue = "_blue" // 蓝字发票
+	InvoiceKindRed  = "_red"  // 红字发票
+)
+
+const (
+	InvoiceTitleTypePersonal = "_personal" // 个人
+	InvoiceTitleTypeCompany  = "_company"  // 单位
+)
+
+const (
+	InvoiceStatusPending   = "_pending"   // 待开票
+	InvoiceStatusIssued    = "_issued"    // 已开票
+	InvoiceStatusRejected  = "_rejected"  // 已驳回
+	InvoiceStatusCancelled = "_cancelled" // 已撤销
+	InvoiceStatusReversed  = "_reversed"  // 已全额红冲
+)
+
+func GenerateInvoiceRequestNumber() string {
+	return "IV" + carbon.Now().Format("YmdHis") + object.QuickRandom(6)
+}
@@ -7,6 +7,7 @@ import (
 	"PowerX/internal/model/powermodel"
 	"github.com/golang-module/carbon/v2"
 	"github.com/zeromicro/go-zero/core/stringx"
+	"math"
 	"time"
 )
 
@@ -26,23 +27,53 @@ type Order struct {
 	//ResellerId     int64   `gorm:"comment:reseller_uuid" json:"resellerId"`
 	CustomerId     int64     `gorm:"comment:客户Id; index" json:"customerId"`
 	CartId         int64     `gorm:"comment:购物车Id; index" json:"cartId"`
+	StoreId        int64     `gorm:"comment:下单门店Id, 门店店长可查看及履约; index" json:"storeId"`
+	SubscriptionId int64     `gorm:"comment:订阅Id, 订阅的首期及续费订单; index" json:"subscriptionId"`
 	PaymentType    int       `gorm:"comment:支付方式" json:"paymentType"`
 	Type           int       `gorm:"comment:订单类型" json:"type"`
 	Status         int       `gorm:"comment:订单状态" json:"status"`
 	OrderNumber    string    `gorm:"comment:订单号; index;unique" json:"orderNumber"`
 	UnitPrice      float64   `gorm:"type:decimal(10,2); comment:是实际交易价格" json:"unitPrice"`
+	PaidAmount     float64   `gorm:"type:decimal(10,2); comment:已支付金额, 由支付单汇总" json:"paidAmount"`
 	ListPrice      float64   `gorm:"type:decimal(10,2); comment:是订单价格" json:"listPrice"`
 	Discount       float64   `gorm:"type:decimal(4,2); comment:折扣" json:"discount"`
 	Comment        string    `gorm:"comment:备注" json:"comment"`
 	CompletedAt    time.Time `gorm:"comment:订单完成时间" json:"completedAt"`
 	CancelledAt    time.Time `gorm:"comment:订单取消时间" json:"cancelledAt"`
 	ShippingMethod string    `gorm:"comment:物流方式" json:"shippingMethod"`
+	// 订单取消或全额退款时退回下单扣减的门店库存及组件库存, 只退回一次
+	InventoryReleased bool `gorm:"comment:下单扣减的库存是否已退回" json:"inventoryReleased"`
+}
+
+// GetOutstandingAmount 订单未付金额, 组合支付时为订单金额减去已支付金额
+func (mdl *Order) GetOutstandingAmount() float64 {
+	outstanding := math.Round((mdl.UnitPrice-mdl.PaidAmount)*100) / 100
+	if outstanding < 0 {
+		return 0
+	}
+	return outstanding
 }
 
 const TypeOrderType = "_order_type"
 const TypeOrderStatus = "_order_status"
 
 const OrderUniqueId = powermodel.UniqueId
+
+// 列表接口允许排序及筛选的字段
+var OrderQueryFields = powermodel.CommonQueryFields.Merge(powermodel.QueryFields{
+	"orderNumber":    {Column: "order_number", Type: powermodel.QueryFieldTypeString, Sortable: true, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterLike}},
+	"customerId":     {Column: "customer_id", Type: powermodel.QueryFieldTypeInt, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+	"storeId":        {Column: "store_id", Type: powermodel.QueryFieldTypeInt, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+	"subscriptionId": {Column: "subscription_id", Type: powermodel.QueryFieldTypeInt, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+	"type":           {Column: "type", Type: powermodel.QueryFieldTypeInt, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+	"status":         {Column: "status", Type: powermodel.QueryFieldTypeInt, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+	"paymentType":    {Column: "payment_type", Type: powermodel.QueryFieldTypeInt, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+	"unitPrice":      {Column: "unit_price", Type: powermodel.QueryFieldTypeFloat, Sortable: true, Filters: []string{powermodel.QueryFilterRange}},
+	"listPrice":      {Column: "list_price", Type: powermodel.QueryFieldTypeFloat, Sortable: true, Filters: []string{powermodel.QueryFilterRange}},
+	"paidAmount":     {Column: "paid_amount", Type: powermodel.QueryFieldTypeFloat, Sortable: true, Filters: []string{powermodel.QueryFilterRange}},
+	"completedAt":    {Column: "completed_at", Type: powermodel.QueryFieldTypeTime, Sortable: true, Filters: []string{powermodel.QueryFilterRange}},
+}).WithTable("orders")
+
 const (
 	OrderStatusPending     = "_pending"       // 待处理
 	OrderStatusToBePaid    = "_to_be_paid"    // 待付款
@@ -82,6 +113,7 @@ type OrderItem struct {
 	Order            *Order                  `gorm:"foreignKey:OrderId;references:Id" json:"order"`
 	ProductBookEntry *product.PriceBookEntry `gorm:"foreignKey:PriceBookEntryId;references:Id" json:"priceBook"`
 	CoverImage       *media.MediaResource    `gorm:"foreignKey:CoverImageId;references:Id" json:"coverImage"`
+	Components       []*OrderItemComponent   `gorm:"foreignKey:OrderItemId;references:Id" json:"components"`
 	//Membership       *membership.Membership  `gorm:"foreignKey:OrderItemId;references:Id" json:"membership"`
 	//CouponItem  *CouponItem `gorm:"foreignKey:OrderItemId;references:Id" json:"CouponItem"`
 
@@ -100,6 +132,20 @@ type OrderItem struct {
 	Discount         float64 `gorm:"type:decimal(10,2); comment:折扣" json:"discount"`
 }
 
+// OrderItemComponent 组合商品订单项拆分出的组件明细, 用于发货、扣减库存和退款分摊
+type OrderItemComponent struct {
+	*powermodel.PowerModel
+
+	OrderId         int64   `gorm:"comment:订单Id; index" json:"orderId"`
+	OrderItemId     int64   `gorm:"comment:订单项Id; index" json:"orderItemId"`
+	ProductId       int64   `gorm:"comment:组件产品Id; index" json:"productId"`
+	SkuId           int64   `gorm:"comment:组件SKUId; index" json:"skuId"`
+	ProductName     string  `gorm:"comment:组件产品名称" json:"productName"`
+	SkuNo           string  `gorm:"comment:组件SKU编号" json:"skuNo"`
+	Quantity        int     `gorm:"comment:组件总数量, 每份数量乘以购买份数" json:"quantity"`
+	AllocatedAmount float64 `gorm:"type:decimal(10,2); comment:按组件标价分摊的成交金额" json:"allocatedAmount"`
+}
+
 type OrderStatusTransition struct {
 	*powermodel.PowerModel
 
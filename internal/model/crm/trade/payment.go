@@ -17,6 +17,7 @@ type Payment struct {
 	PaymentDate     time.Time `gorm:"comment:支付日期" json:"paymentDate"`
 	PaymentType     int       `gorm:"comment:支付方式" json:"paymentType"`
 	PaidAmount      float64   `gorm:"type:decimal(10,2); comment:实际支付金额" json:"paidAmount"`
+	RefundedAmount  float64   `gorm:"type:decimal(10,2); comment:累计已退款金额" json:"refundedAmount"`
 	PaymentNumber   string    `gorm:"comment:支付单单号" json:"paymentNumber"`
 	ReferenceNumber string    `gorm:"comment:参考单号" json:"referenceNumber"`
 	Remark          string    `gorm:"comment:备注" json:"remark"`
@@ -39,6 +40,7 @@ const (
 	PaymentTypeAlipay     = "_alipay"      // 支付宝
 	PaymentTypePayPal     = "_paypal"      // PayPal
 	PaymentTypeCreditCard = "_credit_card" // 信用卡
+	PaymentTypeToken      = "_token"       // 代币
 )
 
 type PaymentItem struct {
@@ -62,6 +64,17 @@ type PaymentItem struct {
 
 const PaymentUniqueId = powermodel.UniqueId
 
+// 列表接口允许排序及筛选的字段
+var PaymentQueryFields = powermodel.CommonQueryFields.Merge(powermodel.QueryFields{
+	"paymentNumber":   {Column: "payment_number", Type: powermodel.QueryFieldTypeString, Sortable: true, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterLike}},
+	"referenceNumber": {Column: "reference_number", Type: powermodel.QueryFieldTypeString, Filters: []string{powermodel.QueryFilterEq}},
+	"orderId":         {Column: "order_id", Type: powermodel.QueryFieldTypeInt, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+	"paymentType":     {Column: "payment_type", Type: powermodel.QueryFieldTypeInt, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+	"status":          {Column: "status", Type: powermodel.QueryFieldTypeInt, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+	"paidAmount":      {Column: "paid_amount", Type: powermodel.QueryFieldTypeFloat, Sortable: true, Filters: []string{powermodel.QueryFilterRange}},
+	"paymentDate":     {Column: "payment_date", Type: powermodel.QueryFieldTypeTime, Sortable: true, Filters: []string{powermodel.QueryFilterRange}},
+}).WithTable("payments")
+
 func GeneratePaymentNumber() string {
 	return "PO" + carbon.Now().Format("YmdHis") + object.QuickRandom(6)
 }
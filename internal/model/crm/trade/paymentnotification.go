@@ -0,0 +1,40 @@
+package trade
+
+import (
+	"PowerX/internal/model/powermodel"
+	"gorm.io/datatypes"
+	"time"
+)
+
+// 支付渠道的每一次异步通知都原样保存, 用于去重、审计与重放
+type PaymentNotification struct {
+	*powermodel.PowerModel
+
+	PaymentType string `gorm:"comment:支付方式Key;index" json:"paymentType"`
+	NotifyId    string `gorm:"comment:渠道通知Id" json:"notifyId"`
+	Event       string `gorm:"comment:事件 paid/refunded/closed;index:idx_payment_notification_dedupe" json:"event"`
+	// 支付成功为渠道交易号, 退款为渠道退款单号
+	TransactionId string         `gorm:"comment:渠道交易号;index:idx_payment_notification_dedupe" json:"transactionId"`
+	PaymentNumber string         `gorm:"comment:支付单号;index" json:"paymentNumber"`
+	Amount        float64        `gorm:"type:decimal(10,2); comment:通知金额" json:"amount"`
+	Currency      string         `gorm:"comment:币种" json:"currency"`
+	RawHeaders    datatypes.JSON `gorm:"comment:原始请求头" json:"rawHeaders"`
+	RawBody       string         `gorm:"type:text; comment:原始报文" json:"rawBody"`
+	Payload       datatypes.JSON `gorm:"comment:解析后的通知" json:"payload"`
+	Status        string         `gorm:"comment:处理状态;index" json:"status"`
+	Error         string         `gorm:"comment:错误信息" json:"error"`
+	Attempts      int            `gorm:"comment:处理次数" json:"attempts"`
+	ProcessedAt   *time.Time     `gorm:"comment:处理完成时间" json:"processedAt"`
+}
+
+const (
+	PaymentNotificationStatusReceived  = "received"  // 已接收
+	PaymentNotificationStatusInvalid   = "invalid"   // 无法解析或验签失败
+	PaymentNotificationStatusProcessed = "processed" // 已处理
+	PaymentNotificationStatusDuplicate = "duplicate" // 重复通知, 已忽略
+	PaymentNotificationStatusRejected  = "rejected"  // 金额/币种/状态校验不通过, 需人工处理
+	PaymentNotificationStatusFailed    = "failed"    // 处理出错, 等待渠道重试或人工重放
+)
+
+// 渠道结算币种
+const PaymentCurrencyCNY = "CNY"
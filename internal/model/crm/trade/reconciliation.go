@@ -0,0 +1,65 @@
+package trade
+
+import (
+	"PowerX/internal/model/powermodel"
+	"time"
+)
+
+// 支付渠道账单与支付单的对账批次, 一个渠道一个账单日一次
+type PaymentReconciliation struct {
+	*powermodel.PowerModel
+
+	Items []*PaymentReconciliationItem `gorm:"foreignKey:ReconciliationId;references:Id" json:"items"`
+
+	PaymentType    string     `gorm:"comment:支付方式Key;index" json:"paymentType"`
+	BillDate       string     `gorm:"comment:账单日期 YYYY-MM-DD;index" json:"billDate"`
+	Source         string     `gorm:"comment:账单来源 download/upload" json:"source"`
+	Status         string     `gorm:"comment:对账状态;index" json:"status"`
+	BillCount      int        `gorm:"comment:账单交易笔数" json:"billCount"`
+	BillAmount     float64    `gorm:"type:decimal(12,2); comment:账单成功交易金额" json:"billAmount"`
+	PaymentCount   int        `gorm:"comment:支付单笔数" json:"paymentCount"`
+	PaymentAmount  float64    `gorm:"type:decimal(12,2); comment:支付单已支付金额" json:"paymentAmount"`
+	MatchedCount   int        `gorm:"comment:一致笔数" json:"matchedCount"`
+	ExceptionCount int        `gorm:"comment:差异笔数" json:"exceptionCount"`
+	HealedCount    int        `gorm:"comment:自动修复笔数" json:"healedCount"`
+	ErrorMessage   string     `gorm:"comment:失败原因" json:"errorMessage"`
+	FinishedAt     *time.Time `gorm:"comment:完成时间" json:"finishedAt"`
+}
+
+// 对账明细, 一致的记录同样保存便于追溯
+type PaymentReconciliationItem struct {
+	*powermodel.PowerModel
+
+	ReconciliationId int64   `gorm:"comment:对账批次Id;index" json:"reconciliationId"`
+	PaymentId        int64   `gorm:"comment:支付单Id;index" json:"paymentId"`
+	PaymentNumber    string  `gorm:"comment:支付单号;index" json:"paymentNumber"`
+	ReferenceNumber  string  `gorm:"comment:渠道交易号" json:"referenceNumber"`
+	BillTradeState   string  `gorm:"comment:账单交易状态" json:"billTradeState"`
+	BillAmount       float64 `gorm:"type:decimal(10,2); comment:账单金额" json:"billAmount"`
+	RefundAmount     float64 `gorm:"type:decimal(10,2); comment:账单退款金额" json:"refundAmount"`
+	PaymentAmount    float64 `gorm:"type:decimal(10,2); comment:支付单金额" json:"paymentAmount"`
+	PaymentStatus    string  `gorm:"comment:支付单状态Key" json:"paymentStatus"`
+	Result           string  `gorm:"comment:对账结果;index" json:"result"`
+	Remark           string  `gorm:"comment:说明" json:"remark"`
+}
+
+const (
+	ReconciliationSourceDownload = "download"
+	ReconciliationSourceUpload   = "upload"
+)
+
+const (
+	ReconciliationStatusProcessing = "processing"
+	ReconciliationStatusCompleted  = "completed"
+	ReconciliationStatusFailed     = "failed"
+)
+
+const (
+	ReconciliationResultMatched        = "matched"         // 一致
+	ReconciliationResultMissingPayment = "missing_payment" // 渠道有交易, 系统无支付单
+	ReconciliationResultMissingBill    = "missing_bill"    // 系统已支付, 渠道账单无交易
+	ReconciliationResultDuplicate      = "duplicate"       // 同一支付单在账单中重复成功
+	ReconciliationResultAmountMismatch = "amount_mismatch" // 金额不一致
+	ReconciliationResultStatusMismatch = "status_mismatch" // 状态不一致
+	ReconciliationResultHealed         = "healed"          // 丢失通知, 已查单修复
+)
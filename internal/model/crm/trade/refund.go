@@ -16,6 +16,7 @@ type RefundOrder struct {
 	//ResellerId     int64   `gorm:"comment:reseller_uuid" json:"resellerId"`
 	CustomerId     int64        `gorm:"comment:客户Id; index" json:"customerId"`
 	OrderId        int64        `gorm:"comment:订单号Id; index" json:"orderId"`
+	PaymentId      int64        `gorm:"comment:退款的支付单Id; index" json:"paymentId"`
 	RefundNumber   string       `gorm:"comment:退款订单号; index" json:"refundNumber"`
 	RefundStatus   RefundStatus `gorm:"comment:退款状态" json:"refundStatus"`
 	RefundAmount   float64      `gorm:"type:decimal(10,2); comment:退款金额" json:"refundAmount"`
@@ -35,16 +36,20 @@ const (
 	RefundStatusFailed    RefundStatus = 3 // 退款失败
 )
 
-// 退款订单项
+// 退款订单项, 退款完成时按订单项记录分摊的退款金额, 组合商品按组件明细记录
 type RefundOrderItem struct {
 	*powermodel.PowerModel
 
-	RefundOrder *RefundOrder `gorm:"foreignKey:RefundOrderId;references:Id" json:"order"`
+	RefundOrder        *RefundOrder        `gorm:"foreignKey:RefundOrderId;references:Id" json:"order"`
+	OrderItemComponent *OrderItemComponent `gorm:"foreignKey:OrderItemComponentId;references:Id" json:"orderItemComponent"`
 
 	// 退款项信息
-	RefundOrderId int64        `gorm:"comment:退款订单Id; index" json:"refundOrderId"`
-	RefundNumber  string       `gorm:"comment:退款订单号; index" json:"refundNumber"`
-	RefundStatus  RefundStatus `gorm:"comment:退款状态" json:"refundStatus"`
-	RefundAmount  float64      `gorm:"type:decimal(10,2); comment:退款金额" json:"refundAmount"`
-	RefundDate    time.Time    `gorm:"comment:退款日期" json:"refundDate"`
+	RefundOrderId        int64        `gorm:"comment:退款订单Id; index" json:"refundOrderId"`
+	RefundNumber         string       `gorm:"comment:退款订单号; index" json:"refundNumber"`
+	OrderId              int64        `gorm:"comment:订单Id; index" json:"orderId"`
+	OrderItemId          int64        `gorm:"comment:订单项Id; index" json:"orderItemId"`
+	OrderItemComponentId int64        `gorm:"comment:组合商品组件明细Id, 普通订单项为0; index" json:"orderItemComponentId"`
+	RefundStatus         RefundStatus `gorm:"comment:退款状态" json:"refundStatus"`
+	RefundAmount         float64      `gorm:"type:decimal(10,2); comment:退款金额" json:"refundAmount"`
+	RefundDate           time.Time    `gorm:"comment:退款日期" json:"refundDate"`
 }
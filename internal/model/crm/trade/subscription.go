@@ -0,0 +1,54 @@
+package trade
+
+import (
+	"PowerX/internal/model/crm/customerdomain"
+	"PowerX/internal/model/powermodel"
+	"github.com/ArtisanCloud/PowerLibs/v3/object"
+	"github.com/golang-module/carbon/v2"
+	"time"
+)
+
+// 客户的订阅, 由已付清的订阅订单生成, 按计费周期自动生成续费订单并扣款
+type Subscription struct {
+	*powermodel.PowerModel
+
+	Customer    *customerdomain.Customer `gorm:"foreignKey:CustomerId;references:Id" json:"customer"`
+	OriginOrder *Order                   `gorm:"foreignKey:OriginOrderId;references:Id" json:"originOrder"`
+
+	CustomerId         int64      `gorm:"comment:客户Id;index" json:"customerId"`
+	SubscriptionNumber string     `gorm:"comment:订阅编号;unique" json:"subscriptionNumber"`
+	OriginOrderId      int64      `gorm:"comment:首期订单Id, 续费订单按其订单项及收货地址生成;index" json:"originOrderId"`
+	PendingOrderId     int64      `gorm:"comment:待支付的续费订单Id;index" json:"pendingOrderId"`
+	ProductName        string     `gorm:"comment:订阅产品名称" json:"productName"`
+	Amount             float64    `gorm:"type:decimal(10,2); comment:每期金额" json:"amount"`
+	IntervalUnit       string     `gorm:"comment:计费周期单位" json:"intervalUnit"`
+	IntervalCount      int        `gorm:"comment:每期包含的计费周期单位数" json:"intervalCount"`
+	TotalCycles        int        `gorm:"comment:总期数, 0为不限期" json:"totalCycles"`
+	PaidCycles         int        `gorm:"comment:已付期数" json:"paidCycles"`
+	Status             string     `gorm:"comment:订阅状态;index" json:"status"`
+	CurrentPeriodStart time.Time  `gorm:"comment:当期开始时间" json:"currentPeriodStart"`
+	CurrentPeriodEnd   time.Time  `gorm:"comment:当期结束时间" json:"currentPeriodEnd"`
+	NextChargeAt       *time.Time `gorm:"comment:下次扣费时间;index" json:"nextChargeAt"`
+	CancelAtPeriodEnd  bool       `gorm:"comment:当期结束后取消" json:"cancelAtPeriodEnd"`
+	DunningAttempts    int        `gorm:"comment:续费扣款失败次数" json:"dunningAttempts"`
+	NextRetryAt        *time.Time `gorm:"comment:下次重试扣款时间;index" json:"nextRetryAt"`
+	LastFailReason     string     `gorm:"comment:最近扣款失败原因" json:"lastFailReason"`
+	PausedAt           *time.Time `gorm:"comment:暂停时间" json:"pausedAt"`
+	CancelledAt        *time.Time `gorm:"comment:取消时间" json:"cancelledAt"`
+	EndedAt            *time.Time `gorm:"comment:结束时间" json:"endedAt"`
+	CancelReason       string     `gorm:"comment:取消原因" json:"cancelReason"`
+}
+
+const SubscriptionUniqueId = powermodel.UniqueId
+
+const (
+	SubscriptionStatusActive    = "_active"    // 生效中
+	SubscriptionStatusPaused    = "_paused"    // 已暂停
+	SubscriptionStatusPastDue   = "_past_due"  // 续费逾期, 催缴中
+	SubscriptionStatusCancelled = "_cancelled" // 已取消
+	SubscriptionStatusExpired   = "_expired"   // 期满结束
+)
+
+func GenerateSubscriptionNumber() string {
+	return "SB" + carbon.Now().Format("YmdHis") + object.QuickRandom(6)
+}
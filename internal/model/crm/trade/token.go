@@ -24,6 +24,18 @@ type TokenBalance struct {
 
 const TokenBalanceUniqueId = powermodel.UniqueId
 
+// TokenLedger 代币余额流水, 支付扣减为负数, 退款退回为正数
+type TokenLedger struct {
+	powermodel.PowerModel
+
+	CustomerId    int64   `gorm:"comment:客户Id; index" json:"customerId"`
+	Amount        float64 `gorm:"type:decimal(10,2); comment:变动金额, 扣减为负数" json:"amount"`
+	Balance       float64 `gorm:"type:decimal(10,2); comment:变动后余额" json:"balance"`
+	PaymentNumber string  `gorm:"comment:支付单单号; index" json:"paymentNumber"`
+	RefundNumber  string  `gorm:"comment:退款单号; index" json:"refundNumber"`
+	Remark        string  `gorm:"comment:备注" json:"remark"`
+}
+
 type TokenExchangeRecord struct {
 	database.PowerModel
 
@@ -26,6 +26,13 @@ type Category struct {
 
 const CategoryUniqueId = powermodel.UniqueId
 
+// 列表接口允许排序及筛选的字段
+var CategoryQueryFields = powermodel.CommonQueryFields.Merge(powermodel.QueryFields{
+	"name": {Column: "name", Type: powermodel.QueryFieldTypeString, Sortable: true, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterLike}},
+	"pId":  {Column: "p_id", Type: powermodel.QueryFieldTypeInt, Filters: []string{powermodel.QueryFilterEq, powermodel.QueryFilterIn}},
+	"sort": {Column: "sort", Type: powermodel.QueryFieldTypeInt, Sortable: true},
+}).WithTable("categories")
+
 func (mdl *Category) GetCategoryIds(categories []*Category) []int64 {
 	uniqueIds := make(map[int64]bool)
 	arrayIds := []int64{}
@@ -25,6 +25,7 @@ type MediaSet struct {
 
 const MediaUsageCover = "_cover"
 const MediaUsageDetail = "_detail"
+const MediaUsageReview = "_review"
 
 func GetImageIds(pivots []*PivotMediaResourceToObject) ([]int64, []*types.SortIdItem) {
 	arrayIds := []int64{}
@@ -0,0 +1,304 @@
+package powermodel
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 列表接口的排序及筛选条件, 只允许白名单中的字段, 列名由白名单提供, 不使用请求中的原始字符串
+
+const (
+	QueryFieldTypeString = "string"
+	QueryFieldTypeInt    = "int"
+	QueryFieldTypeFloat  = "float"
+	QueryFieldTypeBool   = "bool"
+	QueryFieldTypeTime   = "time"
+)
+
+const (
+	QueryFilterEq    = "eq"
+	QueryFilterIn    = "in"
+	QueryFilterRange = "range"
+	QueryFilterLike  = "like"
+)
+
+const (
+	QuerySpecMaxSorts    = 5
+	QuerySpecMaxFilters  = 10
+	QuerySpecMaxInValues = 100
+)
+
+type QueryField struct {
+	Column   string
+	Type     string
+	Sortable bool
+	Filters  []string
+}
+
+// QueryFields 模型的字段白名单, key为请求中使用的字段名
+type QueryFields map[string]QueryField
+
+var CommonQueryFields = QueryFields{
+	"id":        {Column: "id", Type: QueryFieldTypeInt, Sortable: true, Filters: []string{QueryFilterEq, QueryFilterIn}},
+	"createdAt": {Column: "created_at", Type: QueryFieldTypeTime, Sortable: true, Filters: []string{QueryFilterRange}},
+	"updatedAt": {Column: "updated_at", Type: QueryFieldTypeTime, Sortable: true, Filters: []string{QueryFilterRange}},
+}
+
+// Merge 返回合并后的新白名单, 后者覆盖同名字段
+func (fields QueryFields) Merge(others QueryFields) QueryFields {
+	merged := QueryFields{}
+	for name, field := range fields {
+		merged[name] = field
+	}
+	for name, field := range others {
+		merged[name] = field
+	}
+	return merged
+}
+
+// WithTable 返回以表名限定列名的新白名单, 避免关联查询时列名冲突
+func (fields QueryFields) WithTable(table string) QueryFields {
+	qualified := QueryFields{}
+	for name, field := range fields {
+		if !strings.Contains(field.Column, ".") {
+			field.Column = table + "." + field.Column
+		}
+		qualified[name] = field
+	}
+	return qualified
+}
+
+func (field QueryField) allowFilter(op string) bool {
+	for _, filter := range field.Filters {
+		if filter == op {
+			return true
+		}
+	}
+	return false
+}
+
+type QuerySort struct {
+	Field  string
+	Column string
+	Desc   bool
+}
+
+type QueryFilter struct {
+	Field  string
+	Column string
+	Op     string
+	// range的两个值为下限和上限, nil表示不限
+	Values []interface{}
+}
+
+type QuerySpec struct {
+	Sorts   []*QuerySort
+	Filters []*QueryFilter
+}
+
+// NewQuerySpec 解析请求中的排序和筛选条件
+//
+// 排序: "sort desc,createdAt" 或 "-sort,createdAt", 默认升序
+// 筛选: "status:eq:1;id:in:1,2,3;createdAt:range:2023-01-01,2023-02-01;name:like:咖啡", range的上下限可以留空
+func NewQuerySpec(fields QueryFields, orderBy string, filters string) (*QuerySpec, error) {
+	spec := &QuerySpec{
+		Sorts:   []*QuerySort{},
+		Filters: []*QueryFilter{},
+	}
+
+	sorts, err := ParseQuerySorts(fields, orderBy)
+	if err != nil {
+		return nil, err
+	}
+	spec.Sorts = sorts
+
+	queryFilters, err := ParseQueryFilters(fields, filters)
+	if err != nil {
+		return nil, err
+	}
+	spec.Filters = queryFilters
+
+	return spec, nil
+}
+
+func ParseQuerySorts(fields QueryFields, orderBy string) ([]*QuerySort, error) {
+	sorts := []*QuerySort{}
+	for _, item := range strings.Split(orderBy, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		parts := strings.Fields(item)
+		if len(parts) > 2 {
+			return nil, fmt.Errorf("排序格式错误: %s", item)
+		}
+		name := parts[0]
+		desc := false
+		if strings.HasPrefix(name, "-") {
+			name = strings.TrimPrefix(name, "-")
+			desc = true
+		}
+		if len(parts) == 2 {
+			switch strings.ToLower(parts[1]) {
+			case "asc":
+			case "desc":
+				desc = true
+			default:
+				return nil, fmt.Errorf("不支持的排序方向: %s", parts[1])
+			}
+		}
+
+		field, ok := fields[name]
+		if !ok || !field.Sortable {
+			return nil, fmt.Errorf("不支持的排序字段: %s", name)
+		}
+		sorts = append(sorts, &QuerySort{Field: name, Column: field.Column, Desc: desc})
+	}
+	if len(sorts) > QuerySpecMaxSorts {
+		return nil, fmt.Errorf("排序字段不能超过%d个", QuerySpecMaxSorts)
+	}
+	return sorts, nil
+}
+
+func ParseQueryFilters(fields QueryFields, filters string) ([]*QueryFilter, error) {
+	queryFilters := []*QueryFilter{}
+	for _, item := range strings.Split(filters, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		parts := strings.SplitN(item, ":", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("筛选格式错误: %s", item)
+		}
+		name, op, rawValue := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), parts[2]
+
+		field, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("不支持的筛选字段: %s", name)
+		}
+		if !field.allowFilter(op) {
+			return nil, fmt.Errorf("字段%s不支持筛选方式: %s", name, op)
+		}
+
+		filter := &QueryFilter{Field: name, Column: field.Column, Op: op}
+		switch op {
+		case QueryFilterEq:
+			value, err := parseQueryValue(field.Type, rawValue)
+			if err != nil {
+				return nil, fmt.Errorf("字段%s的值无效: %s", name, rawValue)
+			}
+			filter.Values = []interface{}{value}
+
+		case QueryFilterIn:
+			rawValues := strings.Split(rawValue, ",")
+			if len(rawValues) > QuerySpecMaxInValues {
+				return nil, fmt.Errorf("字段%s的筛选值不能超过%d个", name, QuerySpecMaxInValues)
+			}
+			for _, raw := range rawValues {
+				value, err := parseQueryValue(field.Type, raw)
+				if err != nil {
+					return nil, fmt.Errorf("字段%s的值无效: %s", name, raw)
+				}
+				filter.Values = append(filter.Values, value)
+			}
+
+		case QueryFilterRange:
+			bounds := strings.Split(rawValue, ",")
+			if len(bounds) != 2 || (strings.TrimSpace(bounds[0]) == "" && strings.TrimSpace(bounds[1]) == "") {
+				return nil, fmt.Errorf("字段%s的范围格式错误: %s", name, rawValue)
+			}
+			for _, bound := range bounds {
+				if strings.TrimSpace(bound) == "" {
+					filter.Values = append(filter.Values, nil)
+					continue
+				}
+				value, err := parseQueryValue(field.Type, bound)
+				if err != nil {
+					return nil, fmt.Errorf("字段%s的值无效: %s", name, bound)
+				}
+				filter.Values = append(filter.Values, value)
+			}
+
+		case QueryFilterLike:
+			if field.Type != QueryFieldTypeString || strings.TrimSpace(rawValue) == "" {
+				return nil, fmt.Errorf("字段%s的值无效: %s", name, rawValue)
+			}
+			filter.Values = []interface{}{escapeLikeValue(strings.TrimSpace(rawValue))}
+
+		default:
+			return nil, fmt.Errorf("不支持的筛选方式: %s", op)
+		}
+
+		queryFilters = append(queryFilters, filter)
+	}
+	if len(queryFilters) > QuerySpecMaxFilters {
+		return nil, fmt.Errorf("筛选条件不能超过%d个", QuerySpecMaxFilters)
+	}
+	return queryFilters, nil
+}
+
+var queryTimeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+
+func parseQueryValue(fieldType string, raw string) (interface{}, error) {
+	raw = strings.TrimSpace(raw)
+	switch fieldType {
+	case QueryFieldTypeInt:
+		return strconv.ParseInt(raw, 10, 64)
+	case QueryFieldTypeFloat:
+		return strconv.ParseFloat(raw, 64)
+	case QueryFieldTypeBool:
+		return strconv.ParseBool(raw)
+	case QueryFieldTypeTime:
+		for _, layout := range queryTimeLayouts {
+			if t, err := time.ParseInLocation(layout, raw, time.Local); err == nil {
+				return t, nil
+			}
+		}
+		return nil, fmt.Errorf("invalid time %s", raw)
+	default:
+		return raw, nil
+	}
+}
+
+func escapeLikeValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// Apply 将筛选和排序条件加到查询上, spec为nil时不做处理; 排序在调用方的默认排序之前
+func (spec *QuerySpec) Apply(db *gorm.DB) *gorm.DB {
+	if spec == nil {
+		return db
+	}
+
+	for _, filter := range spec.Filters {
+		column := clause.Column{Name: filter.Column}
+		switch filter.Op {
+		case QueryFilterEq:
+			db = db.Where(clause.Eq{Column: column, Value: filter.Values[0]})
+		case QueryFilterIn:
+			db = db.Where(clause.IN{Column: column, Values: filter.Values})
+		case QueryFilterRange:
+			if filter.Values[0] != nil {
+				db = db.Where(clause.Gte{Column: column, Value: filter.Values[0]})
+			}
+			if filter.Values[1] != nil {
+				db = db.Where(clause.Lte{Column: column, Value: filter.Values[1]})
+			}
+		case QueryFilterLike:
+			db = db.Where(clause.Like{Column: column, Value: "%" + filter.Values[0].(string) + "%"})
+		}
+	}
+
+	for _, sort := range spec.Sorts {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: sort.Column}, Desc: sort.Desc})
+	}
+
+	return db
+}
@@ -0,0 +1,104 @@
+package powermodel
+
+import (
+	"testing"
+	"time"
+)
+
+var testQueryFields = CommonQueryFields.Merge(QueryFields{
+	"name":   {Column: "name", Type: QueryFieldTypeString, Sortable: true, Filters: []string{QueryFilterEq, QueryFilterLike}},
+	"sort":   {Column: "sort", Type: QueryFieldTypeInt, Sortable: true},
+	"price":  {Column: "unit_price", Type: QueryFieldTypeFloat, Filters: []string{QueryFilterRange}},
+	"status": {Column: "status", Type: QueryFieldTypeInt, Filters: []string{QueryFilterEq, QueryFilterIn}},
+})
+
+func TestParseQuerySorts(t *testing.T) {
+
+	sorts, err := ParseQuerySorts(testQueryFields, "sort desc, -createdAt,name ASC")
+	if err != nil {
+		t.Fatalf("parse sorts: %v", err)
+	}
+	want := []QuerySort{
+		{Field: "sort", Column: "sort", Desc: true},
+		{Field: "createdAt", Column: "created_at", Desc: true},
+		{Field: "name", Column: "name", Desc: false},
+	}
+	if len(sorts) != len(want) {
+		t.Fatalf("got %d sorts, want %d", len(sorts), len(want))
+	}
+	for i, sort := range sorts {
+		if *sort != want[i] {
+			t.Errorf("sort %d = %+v, want %+v", i, *sort, want[i])
+		}
+	}
+
+	invalid := []string{
+		"id; drop table products",
+		"price desc",
+		"name sideways",
+		"unknown",
+		"name desc extra",
+	}
+	for _, orderBy := range invalid {
+		if _, err := ParseQuerySorts(testQueryFields, orderBy); err == nil {
+			t.Errorf("orderBy %q should be rejected", orderBy)
+		}
+	}
+}
+
+func TestParseQueryFilters(t *testing.T) {
+
+	filters, err := ParseQueryFilters(testQueryFields, "status:in:1,2;price:range:,99.5;createdAt:range:2023-01-01,;name:like:50%_off")
+	if err != nil {
+		t.Fatalf("parse filters: %v", err)
+	}
+	if len(filters) != 4 {
+		t.Fatalf("got %d filters, want 4", len(filters))
+	}
+	if filters[0].Column != "status" || len(filters[0].Values) != 2 || filters[0].Values[1] != int64(2) {
+		t.Errorf("in filter = %+v", filters[0])
+	}
+	if filters[1].Column != "unit_price" || filters[1].Values[0] != nil || filters[1].Values[1] != 99.5 {
+		t.Errorf("price range filter = %+v", filters[1])
+	}
+	if start, ok := filters[2].Values[0].(time.Time); !ok || start.Format("2006-01-02") != "2023-01-01" || filters[2].Values[1] != nil {
+		t.Errorf("time range filter = %+v", filters[2])
+	}
+	if filters[3].Values[0] != `50\%\_off` {
+		t.Errorf("like filter value = %v", filters[3].Values[0])
+	}
+
+	invalid := []string{
+		"unknown:eq:1",
+		"sort:eq:1",
+		"status:like:1",
+		"status:eq:abc",
+		"price:range:,",
+		"price:range:1",
+		"name:eq",
+	}
+	for _, filter := range invalid {
+		if _, err := ParseQueryFilters(testQueryFields, filter); err == nil {
+			t.Errorf("filter %q should be rejected", filter)
+		}
+	}
+}
+
+func TestQueryFieldsWithTable(t *testing.T) {
+
+	fields := testQueryFields.WithTable("products")
+	if fields["createdAt"].Column != "products.created_at" || fields["price"].Column != "products.unit_price" {
+		t.Errorf("qualified fields = %+v", fields)
+	}
+	if testQueryFields["createdAt"].Column != "created_at" {
+		t.Errorf("WithTable should not modify the original fields")
+	}
+}
+
+func TestQuerySpecApplyNil(t *testing.T) {
+
+	var spec *QuerySpec
+	if db := spec.Apply(nil); db != nil {
+		t.Errorf("nil spec should return db unchanged")
+	}
+}
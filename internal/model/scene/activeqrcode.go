@@ -4,6 +4,7 @@ import (
 	"PowerX/internal/model"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"time"
 )
 
 type SceneQrcode struct {
@@ -35,6 +36,15 @@ func (e SceneQrcode) TableName() string {
 	return `scene_qrcodes`
 }
 
+// IsExpired
+//
+//	@Description: ExpiryDate为0时永久有效
+//	@receiver e
+//	@return bool
+func (e SceneQrcode) IsExpired() bool {
+	return e.ExpiryDate > 0 && time.Now().Unix() > e.ExpiryDate
+}
+
 // Query
 //
 //	@Description:
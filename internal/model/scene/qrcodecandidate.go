@@ -0,0 +1,117 @@
+package scene
+
+import (
+	"PowerX/internal/model"
+	"gorm.io/gorm"
+)
+
+const (
+	// 候选二维码状态
+	CandidateStateWaiting   = 1
+	CandidateStateUsing     = 2
+	CandidateStateExhausted = 3
+
+	// 企业微信群上限
+	DefaultGroupMemberCap = 200
+)
+
+type SceneQrcodeCandidate struct {
+	model.Model
+	QId       string `gorm:"comment:唯一标识;index:idx_candidate_qid;column:qid" json:"qid"`
+	Link      string `gorm:"comment:群二维码图片;column:link" json:"link"`
+	ChatId    string `gorm:"comment:客户群ID;column:chat_id" json:"chat_id"`
+	MemberCap int    `gorm:"comment:群人数上限;column:member_cap" json:"member_cap"`
+	ScanCount int    `gorm:"comment:已展示次数;column:scan_count" json:"scan_count"`
+	Sort      int    `gorm:"comment:顺序;column:sort" json:"sort"`
+	State     int    `gorm:"comment:状态1:等待 2:使用中 3:已满;column:state" json:"state"`
+}
+
+// TableName
+//
+//	@Description:
+//	@receiver e
+//	@return string
+func (e SceneQrcodeCandidate) TableName() string {
+	return `scene_qrcode_candidates`
+}
+
+// FindByQid
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param qid
+//	@return candidates
+func (e *SceneQrcodeCandidate) FindByQid(db *gorm.DB, qid string) (candidates []*SceneQrcodeCandidate) {
+
+	err := db.Table(e.TableName()).Where(`qid = ?`, qid).Order(`sort ASC, id ASC`).Find(&candidates).Error
+	if err != nil {
+		panic(err)
+	}
+	return candidates
+}
+
+// FindUsingByQid
+//
+//	@Description: 当前使用中的二维码
+//	@receiver e
+//	@param db
+//	@param qid
+//	@return candidate
+func (e *SceneQrcodeCandidate) FindUsingByQid(db *gorm.DB, qid string) (candidate *SceneQrcodeCandidate) {
+
+	err := db.Table(e.TableName()).Where(`qid = ? AND state = ?`, qid, CandidateStateUsing).Limit(1).Find(&candidate).Error
+	if err != nil {
+		panic(err)
+	}
+	return candidate
+}
+
+// Replace
+//
+//	@Description: 覆盖候选二维码
+//	@receiver e
+//	@param db
+//	@param qid
+//	@param candidates
+//	@return error
+func (e *SceneQrcodeCandidate) Replace(db *gorm.DB, qid string, candidates []*SceneQrcodeCandidate) error {
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(e.TableName()).Where(`qid = ?`, qid).Delete(&SceneQrcodeCandidate{}).Error; err != nil {
+			return err
+		}
+		if len(candidates) == 0 {
+			return nil
+		}
+		return tx.Table(e.TableName()).Create(&candidates).Error
+	})
+
+}
+
+// IncreaseScan
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param id
+//	@return error
+func (e *SceneQrcodeCandidate) IncreaseScan(db *gorm.DB, id int64) error {
+
+	return db.Table(e.TableName()).Where(`id = ?`, id).Update(`scan_count`, gorm.Expr(`scan_count + ?`, 1)).Error
+
+}
+
+// UpdateState
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param id
+//	@param state
+//	@return error
+func (e *SceneQrcodeCandidate) UpdateState(db *gorm.DB, id int64, state int) error {
+
+	return db.Table(e.TableName()).Where(`id = ?`, id).Update(`state`, state).Error
+
+}
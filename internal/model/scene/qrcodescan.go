@@ -0,0 +1,135 @@
+package scene
+
+import (
+	"PowerX/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type SceneQrcodeScanLog struct {
+	model.Model
+	QId        string    `gorm:"comment:唯一标识;index:idx_scan_qid;column:qid" json:"qid"`
+	Link       string    `gorm:"comment:本次展示的二维码;column:link" json:"link"`
+	Ip         string    `gorm:"comment:IP;column:ip" json:"ip"`
+	UserAgent  string    `gorm:"comment:UserAgent;column:user_agent" json:"user_agent"`
+	Referrer   string    `gorm:"comment:来源页;column:referrer" json:"referrer"`
+	CustomerId int64     `gorm:"comment:已登录客户ID;index:idx_scan_customer_id;column:customer_id" json:"customer_id"`
+	ScannedAt  time.Time `gorm:"comment:扫码时间;index:idx_scan_scanned_at;column:scanned_at" json:"scanned_at"`
+}
+
+// TableName
+//
+//	@Description:
+//	@receiver e
+//	@return string
+func (e SceneQrcodeScanLog) TableName() string {
+	return `scene_qrcode_scan_logs`
+}
+
+// Create
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param log
+//	@return error
+func (e *SceneQrcodeScanLog) Create(db *gorm.DB, log *SceneQrcodeScanLog) error {
+
+	return db.Table(e.TableName()).Create(log).Error
+
+}
+
+// IsFirstScanOfDay
+//
+//	@Description: 访客当天是否首次扫码(登录客户按客户,否则按IP)
+//	@receiver e
+//	@param db
+//	@param qid
+//	@param customerId
+//	@param ip
+//	@param day
+//	@return bool
+func (e *SceneQrcodeScanLog) IsFirstScanOfDay(db *gorm.DB, qid string, customerId int64, ip string, day time.Time) bool {
+
+	var count int64
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	query := db.Table(e.TableName()).
+		Where(`qid = ? AND scanned_at >= ? AND scanned_at < ?`, qid, start, start.AddDate(0, 0, 1))
+	if customerId > 0 {
+		query = query.Where(`customer_id = ?`, customerId)
+	} else {
+		query = query.Where(`ip = ?`, ip)
+	}
+	_ = query.Count(&count).Error
+
+	return count == 0
+}
+
+type SceneQrcodeDailyStatistics struct {
+	model.Model
+	QId                string `gorm:"comment:唯一标识;uniqueIndex:idx_qid_date;column:qid" json:"qid"`
+	Date               string `gorm:"comment:日期(YYYY-MM-DD);uniqueIndex:idx_qid_date;column:date" json:"date"`
+	ScanCount          int    `gorm:"comment:扫码次数;column:scan_count" json:"scan_count"`
+	UniqueVisitorCount int    `gorm:"comment:独立访客;column:unique_visitor_count" json:"unique_visitor_count"`
+}
+
+// TableName
+//
+//	@Description:
+//	@receiver e
+//	@return string
+func (e SceneQrcodeDailyStatistics) TableName() string {
+	return `scene_qrcode_daily_statistics`
+}
+
+// Increase
+//
+//	@Description: 当日汇总+1
+//	@receiver e
+//	@param db
+//	@param qid
+//	@param date
+//	@param unique
+//	@return error
+func (e *SceneQrcodeDailyStatistics) Increase(db *gorm.DB, qid string, date string, unique bool) error {
+
+	visitor := 0
+	if unique {
+		visitor = 1
+	}
+	return db.Table(e.TableName()).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "qid"}, {Name: "date"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			`scan_count`:           gorm.Expr(e.TableName()+`.scan_count + ?`, 1),
+			`unique_visitor_count`: gorm.Expr(e.TableName()+`.unique_visitor_count + ?`, visitor),
+			`updated_at`:           time.Now(),
+		}),
+	}).Create(&SceneQrcodeDailyStatistics{
+		QId:                qid,
+		Date:               date,
+		ScanCount:          1,
+		UniqueVisitorCount: visitor,
+	}).Error
+
+}
+
+// FindByDateRange
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param qid
+//	@param startDate
+//	@param endDate
+//	@return statistics
+func (e *SceneQrcodeDailyStatistics) FindByDateRange(db *gorm.DB, qid string, startDate string, endDate string) (statistics []*SceneQrcodeDailyStatistics) {
+
+	err := db.Table(e.TableName()).
+		Where(`qid = ? AND date >= ? AND date <= ?`, qid, startDate, endDate).
+		Order(`date ASC`).Find(&statistics).Error
+	if err != nil {
+		panic(err)
+	}
+	return statistics
+}
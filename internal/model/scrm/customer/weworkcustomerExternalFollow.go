@@ -84,3 +84,65 @@ func (e WeWorkExternalContactFollow) FindFollowByExternalUserId(db *gorm.DB, ext
 	return follow
 
 }
+
+//
+// FindFollowsByUserId
+//  @Description: 员工跟进的客户
+//  @receiver e
+//  @param db
+//  @param userId
+//  @return follows
+//
+func (e WeWorkExternalContactFollow) FindFollowsByUserId(db *gorm.DB, userId string) (follows []*WeWorkExternalContactFollow) {
+
+	err := db.Model(e).Where(`user_id = ?`, userId).Find(&follows).Error
+	if err != nil {
+		panic(err)
+	}
+	return follows
+
+}
+
+//
+// CountByUserIds
+//  @Description: 员工跟进客户数
+//  @receiver e
+//  @param db
+//  @param userIds
+//  @return load
+//
+func (e WeWorkExternalContactFollow) CountByUserIds(db *gorm.DB, userIds []string) (load map[string]int) {
+
+	var rows []struct {
+		UserId string
+		Total  int
+	}
+	err := db.Model(e).Select(`user_id, count(*) AS total`).Where(`user_id IN ?`, userIds).Group(`user_id`).Scan(&rows).Error
+	if err != nil {
+		panic(err)
+	}
+	load = make(map[string]int, len(userIds))
+	for _, row := range rows {
+		load[row.UserId] = row.Total
+	}
+	return load
+
+}
+
+//
+// UpdateUserId
+//  @Description: 客户接替后将原跟进员工的跟进记录更新为接替员工
+//  @receiver e
+//  @param db
+//  @param externalUserId
+//  @param handoverUserId
+//  @param userId
+//
+func (e WeWorkExternalContactFollow) UpdateUserId(db *gorm.DB, externalUserId string, handoverUserId string, userId string) {
+
+	err := db.Model(e).Where(`external_user_id = ? AND user_id = ?`, externalUserId, handoverUserId).Update(`user_id`, userId).Error
+	if err != nil {
+		panic(err)
+	}
+
+}
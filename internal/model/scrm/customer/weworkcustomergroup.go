@@ -0,0 +1,323 @@
+package customer
+
+import (
+	"PowerX/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// 客户群状态
+	GroupStatusNormal    = 1
+	GroupStatusDismissed = 2
+
+	// 群成员状态
+	GroupMemberStatusIn   = 1
+	GroupMemberStatusQuit = 2
+
+	// 群成员变动
+	GroupMemberActionJoin = 1
+	GroupMemberActionQuit = 2
+)
+
+type WeWorkCustomerGroup struct {
+	model.Model
+
+	ChatId      string `gorm:"comment:客户群ID;column:chat_id;unique" json:"chat_id"`
+	Name        string `gorm:"comment:群名;column:name" json:"name"`
+	Owner       string `gorm:"comment:群主;index:idx_group_owner;column:owner" json:"owner"`
+	CreateTime  int    `gorm:"comment:创建时间;column:create_time" json:"create_time"`
+	Notice      string `gorm:"comment:群公告;column:notice" json:"notice"`
+	AdminList   string `gorm:"comment:管理员(逗号隔开);column:admin_list" json:"admin_list"`
+	MemberCount int    `gorm:"comment:群人数;column:member_count" json:"member_count"`
+	Status      int    `gorm:"comment:状态1:正常 2:已解散;column:status" json:"status"`
+}
+
+// TableName
+//
+//	@Description:
+//	@receiver e
+//	@return string
+func (e WeWorkCustomerGroup) TableName() string {
+	return `we_work_customer_groups`
+}
+
+// Action
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param groups
+func (e *WeWorkCustomerGroup) Action(db *gorm.DB, groups []*WeWorkCustomerGroup) {
+
+	err := db.Table(e.TableName()).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chat_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{`name`, `owner`, `create_time`, `notice`, `admin_list`, `member_count`, `status`, `updated_at`}),
+	}).Create(&groups).Error
+	if err != nil {
+		panic(err)
+	}
+
+}
+
+// FindChatIds
+//
+//	@Description: 未解散的客户群
+//	@receiver e
+//	@param db
+//	@return ids
+func (e *WeWorkCustomerGroup) FindChatIds(db *gorm.DB) (ids []string) {
+
+	err := db.Table(e.TableName()).Where(`status = ?`, GroupStatusNormal).Pluck(`chat_id`, &ids).Error
+	if err != nil {
+		panic(err)
+	}
+	return ids
+
+}
+
+// FindOwners
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@return owners
+func (e *WeWorkCustomerGroup) FindOwners(db *gorm.DB) (owners []string) {
+
+	err := db.Table(e.TableName()).Distinct(`owner`).Where(`status = ?`, GroupStatusNormal).Pluck(`owner`, &owners).Error
+	if err != nil {
+		panic(err)
+	}
+	return owners
+
+}
+
+// Dismiss
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param chatIds
+func (e *WeWorkCustomerGroup) Dismiss(db *gorm.DB, chatIds []string) {
+
+	if len(chatIds) == 0 {
+		return
+	}
+	err := db.Table(e.TableName()).Where(`chat_id IN ?`, chatIds).Update(`status`, GroupStatusDismissed).Error
+	if err != nil {
+		panic(err)
+	}
+
+}
+
+type WeWorkCustomerGroupMember struct {
+	model.Model
+
+	ChatId        string `gorm:"comment:客户群ID;uniqueIndex:idx_group_member;column:chat_id" json:"chat_id"`
+	UserId        string `gorm:"comment:成员ID(员工userid或客户external_userid);uniqueIndex:idx_group_member;column:user_id" json:"user_id"`
+	Type          int    `gorm:"comment:成员类型1:企业成员 2:外部联系人;column:type" json:"type"`
+	JoinTime      int    `gorm:"comment:入群时间;column:join_time" json:"join_time"`
+	JoinScene     int    `gorm:"comment:入群方式1:直接邀请 2:邀请链接 3:扫描群二维码;column:join_scene" json:"join_scene"`
+	State         string `gorm:"comment:入群渠道State;index:idx_group_member_state;column:state" json:"state"`
+	Invitor       string `gorm:"comment:邀请者;column:invitor" json:"invitor"`
+	GroupNickname string `gorm:"comment:群昵称;column:group_nickname" json:"group_nickname"`
+	Name          string `gorm:"comment:名字;column:name" json:"name"`
+	UnionId       string `gorm:"comment:UnionId;column:union_id" json:"union_id"`
+	Status        int    `gorm:"comment:状态1:在群 2:已退群;column:status" json:"status"`
+	QuitTime      int64  `gorm:"comment:退群时间;column:quit_time" json:"quit_time"`
+}
+
+// TableName
+//
+//	@Description:
+//	@receiver e
+//	@return string
+func (e WeWorkCustomerGroupMember) TableName() string {
+	return `we_work_customer_group_members`
+}
+
+// FindByChatId
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param chatId
+//	@return members
+func (e *WeWorkCustomerGroupMember) FindByChatId(db *gorm.DB, chatId string) (members []*WeWorkCustomerGroupMember) {
+
+	err := db.Table(e.TableName()).Where(`chat_id = ?`, chatId).Order(`join_time ASC`).Find(&members).Error
+	if err != nil {
+		panic(err)
+	}
+	return members
+
+}
+
+// Action
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param members
+func (e *WeWorkCustomerGroupMember) Action(db *gorm.DB, members []*WeWorkCustomerGroupMember) {
+
+	if len(members) == 0 {
+		return
+	}
+	err := db.Table(e.TableName()).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chat_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{`type`, `join_time`, `join_scene`, `state`, `invitor`, `group_nickname`, `name`, `union_id`, `status`, `quit_time`, `updated_at`}),
+	}).CreateInBatches(&members, 100).Error
+	if err != nil {
+		panic(err)
+	}
+
+}
+
+type WeWorkCustomerGroupMemberLog struct {
+	model.Model
+
+	ChatId     string `gorm:"comment:客户群ID;index:idx_group_member_log_chat_id;column:chat_id" json:"chat_id"`
+	UserId     string `gorm:"comment:成员ID;column:user_id" json:"user_id"`
+	Type       int    `gorm:"comment:成员类型1:企业成员 2:外部联系人;column:type" json:"type"`
+	Action     int    `gorm:"comment:变动1:入群 2:退群;column:action" json:"action"`
+	JoinScene  int    `gorm:"comment:入群方式;column:join_scene" json:"join_scene"`
+	QuitScene  int    `gorm:"comment:退群方式0:自己退群 1:群主/群管理员移出;column:quit_scene" json:"quit_scene"`
+	State      string `gorm:"comment:入群渠道State;index:idx_group_member_log_state;column:state" json:"state"`
+	ActionTime int64  `gorm:"comment:变动时间;index:idx_group_member_log_action_time;column:action_time" json:"action_time"`
+}
+
+// TableName
+//
+//	@Description:
+//	@receiver e
+//	@return string
+func (e WeWorkCustomerGroupMemberLog) TableName() string {
+	return `we_work_customer_group_member_logs`
+}
+
+// Create
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param logs
+func (e *WeWorkCustomerGroupMemberLog) Create(db *gorm.DB, logs []*WeWorkCustomerGroupMemberLog) {
+
+	if len(logs) == 0 {
+		return
+	}
+	err := db.Table(e.TableName()).CreateInBatches(&logs, 100).Error
+	if err != nil {
+		panic(err)
+	}
+
+}
+
+type WeWorkCustomerGroupTag struct {
+	model.Model
+
+	ChatId string `gorm:"comment:客户群ID;uniqueIndex:idx_group_tag;column:chat_id" json:"chat_id"`
+	Tag    string `gorm:"comment:群标签;uniqueIndex:idx_group_tag;index:idx_group_tag_name;column:tag" json:"tag"`
+}
+
+// TableName
+//
+//	@Description:
+//	@receiver e
+//	@return string
+func (e WeWorkCustomerGroupTag) TableName() string {
+	return `we_work_customer_group_tags`
+}
+
+// Replace
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param chatId
+//	@param tags
+//	@return error
+func (e *WeWorkCustomerGroupTag) Replace(db *gorm.DB, chatId string, tags []string) error {
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Table(e.TableName()).Where(`chat_id = ?`, chatId).Delete(&WeWorkCustomerGroupTag{}).Error; err != nil {
+			return err
+		}
+		var rows []*WeWorkCustomerGroupTag
+		for _, tag := range tags {
+			rows = append(rows, &WeWorkCustomerGroupTag{ChatId: chatId, Tag: tag})
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Table(e.TableName()).Create(&rows).Error
+	})
+
+}
+
+// FindByChatIds
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param chatIds
+//	@return tags
+func (e *WeWorkCustomerGroupTag) FindByChatIds(db *gorm.DB, chatIds []string) (tags map[string][]string) {
+
+	var rows []*WeWorkCustomerGroupTag
+	err := db.Table(e.TableName()).Where(`chat_id IN ?`, chatIds).Order(`id ASC`).Find(&rows).Error
+	if err != nil {
+		panic(err)
+	}
+	tags = make(map[string][]string)
+	for _, row := range rows {
+		tags[row.ChatId] = append(tags[row.ChatId], row.Tag)
+	}
+	return tags
+
+}
+
+type WeWorkCustomerGroupStatistic struct {
+	model.Model
+
+	Owner        string `gorm:"comment:群主;uniqueIndex:idx_group_statistic;column:owner" json:"owner"`
+	Date         string `gorm:"comment:日期(YYYY-MM-DD);uniqueIndex:idx_group_statistic;column:date" json:"date"`
+	NewChatCnt   int    `gorm:"comment:新增客户群数量;column:new_chat_cnt" json:"new_chat_cnt"`
+	ChatTotal    int    `gorm:"comment:截至当天客户群总数量;column:chat_total" json:"chat_total"`
+	ChatHasMsg   int    `gorm:"comment:发过消息的客户群数量;column:chat_has_msg" json:"chat_has_msg"`
+	NewMemberCnt int    `gorm:"comment:新增群人数;column:new_member_cnt" json:"new_member_cnt"`
+	MemberTotal  int    `gorm:"comment:截至当天客户群总人数;column:member_total" json:"member_total"`
+	MemberHasMsg int    `gorm:"comment:发过消息的群成员数(活跃);column:member_has_msg" json:"member_has_msg"`
+	MsgTotal     int    `gorm:"comment:客户群消息总数;column:msg_total" json:"msg_total"`
+}
+
+// TableName
+//
+//	@Description:
+//	@receiver e
+//	@return string
+func (e WeWorkCustomerGroupStatistic) TableName() string {
+	return `we_work_customer_group_statistics`
+}
+
+// Action
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param statistics
+func (e *WeWorkCustomerGroupStatistic) Action(db *gorm.DB, statistics []*WeWorkCustomerGroupStatistic) {
+
+	if len(statistics) == 0 {
+		return
+	}
+	err := db.Table(e.TableName()).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "owner"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{`new_chat_cnt`, `chat_total`, `chat_has_msg`, `new_member_cnt`, `member_total`, `member_has_msg`, `msg_total`, `updated_at`}),
+	}).Create(&statistics).Error
+	if err != nil {
+		panic(err)
+	}
+
+}
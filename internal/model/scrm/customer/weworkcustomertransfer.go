@@ -0,0 +1,105 @@
+package customer
+
+import (
+	"PowerX/internal/model"
+	"gorm.io/gorm"
+)
+
+const (
+	// 继承对象
+	TransferTypeCustomer  = 1
+	TransferTypeGroupChat = 2
+
+	// 继承方式
+	TransferModeOnJob    = 1
+	TransferModeResigned = 2
+
+	// 接替状态(1-5与企业微信一致)
+	TransferStatusSuccess  = 1 // 接替完毕
+	TransferStatusWaiting  = 2 // 等待接替
+	TransferStatusRefused  = 3 // 客户拒绝
+	TransferStatusLimited  = 4 // 接替成员客户达到上限
+	TransferStatusNoRecord = 5 // 无接替记录
+	TransferStatusFailed   = 9 // 接口调用失败
+)
+
+type WeWorkCustomerTransfer struct {
+	model.Model
+
+	Type           int    `gorm:"comment:类型1:客户 2:客户群;column:type" json:"type"`
+	Mode           int    `gorm:"comment:方式1:在职继承 2:离职继承;column:mode" json:"mode"`
+	HandoverUserId string `gorm:"comment:原跟进员工;index:idx_transfer_handover;column:handover_user_id" json:"handover_user_id"`
+	TakeoverUserId string `gorm:"comment:接替员工;index:idx_transfer_takeover;column:takeover_user_id" json:"takeover_user_id"`
+	ExternalUserId string `gorm:"comment:客户ID;index:idx_transfer_external_user_id;column:external_user_id" json:"external_user_id"`
+	ChatId         string `gorm:"comment:客户群ID;column:chat_id" json:"chat_id"`
+	Status         int    `gorm:"comment:状态1:接替完毕 2:等待接替 3:客户拒绝 4:接替成员客户达到上限 5:无接替记录 9:失败;index:idx_transfer_status;column:status" json:"status"`
+	TakeoverTime   int64  `gorm:"comment:接替时间;column:takeover_time" json:"takeover_time"`
+	Error          string `gorm:"comment:错误信息;column:error" json:"error"`
+}
+
+// TableName
+//
+//	@Description:
+//	@receiver e
+//	@return string
+func (e WeWorkCustomerTransfer) TableName() string {
+	return `we_work_customer_transfers`
+}
+
+// Create
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param transfers
+func (e *WeWorkCustomerTransfer) Create(db *gorm.DB, transfers []*WeWorkCustomerTransfer) {
+
+	if len(transfers) == 0 {
+		return
+	}
+	err := db.Table(e.TableName()).CreateInBatches(&transfers, 100).Error
+	if err != nil {
+		panic(err)
+	}
+
+}
+
+// FindWaiting
+//
+//	@Description: 等待接替的客户
+//	@receiver e
+//	@param db
+//	@return transfers
+func (e *WeWorkCustomerTransfer) FindWaiting(db *gorm.DB) (transfers []*WeWorkCustomerTransfer) {
+
+	err := db.Table(e.TableName()).
+		Where(`type = ? AND status = ?`, TransferTypeCustomer, TransferStatusWaiting).
+		Order(`id ASC`).Find(&transfers).Error
+	if err != nil {
+		panic(err)
+	}
+	return transfers
+
+}
+
+// UpdateStatus
+//
+//	@Description: 按客户更新最近一次等待中的接替状态
+//	@receiver e
+//	@param db
+//	@param takeoverUserId
+//	@param externalUserId
+//	@param value
+func (e *WeWorkCustomerTransfer) UpdateStatus(db *gorm.DB, takeoverUserId string, externalUserId string, value map[string]interface{}) {
+
+	query := db.Table(e.TableName()).
+		Where(`type = ? AND external_user_id = ? AND status = ?`, TransferTypeCustomer, externalUserId, TransferStatusWaiting)
+	if takeoverUserId != `` {
+		query = query.Where(`takeover_user_id = ?`, takeoverUserId)
+	}
+	err := query.UpdateColumns(value).Error
+	if err != nil {
+		panic(err)
+	}
+
+}
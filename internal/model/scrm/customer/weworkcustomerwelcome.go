@@ -0,0 +1,160 @@
+package customer
+
+import (
+	"PowerX/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// 欢迎语附件类型
+	WelcomeAttachmentTypeNone        = 0
+	WelcomeAttachmentTypeImage       = 1
+	WelcomeAttachmentTypeMiniProgram = 2
+	WelcomeAttachmentTypeLink        = 3
+
+	// 规则状态
+	WelcomeRuleStateEnable  = 1
+	WelcomeRuleStateDisable = 2
+	WelcomeRuleStateDelete  = 3
+
+	// 执行状态
+	WelcomeLogStatusSuccess = 1
+	WelcomeLogStatusFailed  = 2
+	WelcomeLogStatusSkipped = 3
+)
+
+type WeWorkCustomerWelcomeRule struct {
+	model.Model
+
+	Name     string `gorm:"comment:规则名称;column:name" json:"name"`
+	Qid      string `gorm:"comment:场景码唯一标识;index:idx_welcome_qid;column:qid" json:"qid"`
+	State    string `gorm:"comment:联系我State;index:idx_welcome_state;column:state" json:"state"`
+	UserId   string `gorm:"comment:员工ID;index:idx_welcome_user_id;column:user_id" json:"user_id"`
+	Priority int    `gorm:"comment:优先级(越大越优先);column:priority" json:"priority"`
+	// message
+	Text           string `gorm:"comment:欢迎语文本;column:text" json:"text"`
+	AttachmentType int    `gorm:"comment:附件类型:0:无,1:图片,2:小程序,3:链接;column:attachment_type" json:"attachment_type"`
+	ResourceId     int64  `gorm:"comment:图片资源ID(we_work_resources);column:resource_id" json:"resource_id"`
+	LinkTitle      string `gorm:"comment:链接标题;column:link_title" json:"link_title"`
+	LinkPicUrl     string `gorm:"comment:链接封面;column:link_pic_url" json:"link_pic_url"`
+	LinkDesc       string `gorm:"comment:链接描述;column:link_desc" json:"link_desc"`
+	LinkUrl        string `gorm:"comment:链接地址;column:link_url" json:"link_url"`
+	MiniAppId      string `gorm:"comment:小程序AppID;column:mini_app_id" json:"mini_app_id"`
+	MiniTitle      string `gorm:"comment:小程序标题;column:mini_title" json:"mini_title"`
+	MiniPage       string `gorm:"comment:小程序页面;column:mini_page" json:"mini_page"`
+	MiniPicMediaId string `gorm:"comment:小程序封面MediaID;column:mini_pic_media_id" json:"mini_pic_media_id"`
+	// action
+	TagIds   string `gorm:"comment:自动打标签(逗号隔开);column:tag_ids" json:"tag_ids"`
+	GroupQid string `gorm:"comment:邀请入群的群活码Qid;column:group_qid" json:"group_qid"`
+	Status   int    `gorm:"comment:状态1:启用 2:禁用 3:删除;column:status" json:"status"`
+}
+
+// TableName
+//
+//	@Description:
+//	@receiver e
+//	@return string
+func (e WeWorkCustomerWelcomeRule) TableName() string {
+	return `we_work_customer_welcome_rules`
+}
+
+// Query
+//
+//	@Description: 所有启用规则(优先级倒序)
+//	@receiver e
+//	@param db
+//	@return rules
+func (e WeWorkCustomerWelcomeRule) Query(db *gorm.DB) (rules []*WeWorkCustomerWelcomeRule) {
+
+	err := db.Model(e).Where(`status = ?`, WelcomeRuleStateEnable).Order(`priority DESC, id DESC`).Find(&rules).Error
+	if err != nil {
+		panic(err)
+	}
+	return rules
+
+}
+
+// Action
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param rules
+func (e *WeWorkCustomerWelcomeRule) Action(db *gorm.DB, rules []*WeWorkCustomerWelcomeRule) {
+
+	err := db.Table(e.TableName()).Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "id"}}, UpdateAll: true}).Create(&rules).Error
+	if err != nil {
+		panic(err)
+	}
+
+}
+
+// UpdateColumn
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param id
+//	@param value
+func (e *WeWorkCustomerWelcomeRule) UpdateColumn(db *gorm.DB, id int64, value map[string]interface{}) {
+
+	err := db.Table(e.TableName()).Where(`id = ?`, id).UpdateColumns(&value).Error
+	if err != nil {
+		panic(err)
+	}
+
+}
+
+// FindById
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param id
+//	@return rule
+func (e *WeWorkCustomerWelcomeRule) FindById(db *gorm.DB, id int64) (rule *WeWorkCustomerWelcomeRule) {
+
+	err := db.Table(e.TableName()).Where(`id = ? AND status < ?`, id, WelcomeRuleStateDelete).Find(&rule).Error
+	if err != nil {
+		panic(err)
+	}
+	return rule
+}
+
+type WeWorkCustomerWelcomeLog struct {
+	model.Model
+
+	RuleId         int64  `gorm:"comment:规则ID;index:idx_welcome_log_rule_id;column:rule_id" json:"rule_id"`
+	ExternalUserId string `gorm:"comment:客户ID;index:idx_welcome_log_external_user_id;column:external_user_id" json:"external_user_id"`
+	UserId         string `gorm:"comment:员工ID;column:user_id" json:"user_id"`
+	State          string `gorm:"comment:添加渠道State;column:state" json:"state"`
+	WelcomeStatus  int    `gorm:"comment:欢迎语状态1:成功 2:失败 3:跳过;column:welcome_status" json:"welcome_status"`
+	TagStatus      int    `gorm:"comment:打标签状态1:成功 2:失败 3:跳过;column:tag_status" json:"tag_status"`
+	GroupStatus    int    `gorm:"comment:邀请入群状态1:成功 2:失败 3:跳过;column:group_status" json:"group_status"`
+	Error          string `gorm:"comment:错误信息;column:error" json:"error"`
+}
+
+// TableName
+//
+//	@Description:
+//	@receiver e
+//	@return string
+func (e WeWorkCustomerWelcomeLog) TableName() string {
+	return `we_work_customer_welcome_logs`
+}
+
+// Create
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param log
+func (e *WeWorkCustomerWelcomeLog) Create(db *gorm.DB, log *WeWorkCustomerWelcomeLog) {
+
+	err := db.Table(e.TableName()).Create(log).Error
+	if err != nil {
+		panic(err)
+	}
+
+}
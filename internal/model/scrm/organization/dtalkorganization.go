@@ -0,0 +1,92 @@
+package organization
+
+import (
+	"PowerX/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DTalkDepartment struct {
+	model.Model
+
+	DTalkDepId      int64  `gorm:"comment:部门ID;column:d_talk_dep_id;unique" json:"d_talk_dep_id"`
+	Name            string `gorm:"comment:部门名称;column:name" json:"name"`
+	DTalkParentId   int64  `gorm:"comment:上级部门ID;column:d_talk_parent_id" json:"d_talk_parent_id"`
+	Order           int64  `gorm:"comment:Order;column:order" json:"order"`
+	RefDepartmentId int64  `gorm:"comment:-;column:ref_department_id" json:"ref_department_id"`
+}
+
+// TableName
+//
+//	@Description:
+//	@receiver e
+//	@return string
+func (e DTalkDepartment) TableName() string {
+	return `d_talk_departments`
+}
+
+// Action
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param departments
+func (e *DTalkDepartment) Action(db *gorm.DB, departments []*DTalkDepartment) {
+
+	if len(departments) == 0 {
+		return
+	}
+	err := db.Table(e.TableName()).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "d_talk_dep_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{`name`, `d_talk_parent_id`, `order`, `updated_at`}),
+	}).CreateInBatches(&departments, 100).Error
+	if err != nil {
+		panic(err)
+	}
+
+}
+
+type DTalkEmployee struct {
+	model.Model
+
+	DTalkUserId   string `gorm:"comment:员工ID;column:d_talk_user_id;unique" json:"d_talk_user_id"`
+	UnionId       string `gorm:"comment:UnionId;column:union_id" json:"union_id"`
+	Name          string `gorm:"comment:员工名称;column:name" json:"name"`
+	Title         string `gorm:"comment:职位;column:title" json:"title"`
+	Mobile        string `gorm:"comment:员工电话;column:mobile" json:"mobile"`
+	Email         string `gorm:"comment:邮箱;column:email" json:"email"`
+	Avatar        string `gorm:"comment:头像;column:avatar" json:"avatar"`
+	DTalkDepIds   string `gorm:"comment:所属部门(逗号隔开);column:d_talk_dep_ids" json:"d_talk_dep_ids"`
+	Active        bool   `gorm:"comment:是否激活;column:active" json:"active"`
+	RefEmployeeId int64  `gorm:"comment:RefEmployeeId;column:ref_employee_id" json:"ref_employee_id"`
+}
+
+// TableName
+//
+//	@Description:
+//	@receiver e
+//	@return string
+func (e DTalkEmployee) TableName() string {
+	return `d_talk_employees`
+}
+
+// Action
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param employees
+func (e *DTalkEmployee) Action(db *gorm.DB, employees []*DTalkEmployee) {
+
+	if len(employees) == 0 {
+		return
+	}
+	err := db.Table(e.TableName()).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "d_talk_user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{`union_id`, `name`, `title`, `mobile`, `email`, `avatar`, `d_talk_dep_ids`, `active`, `updated_at`}),
+	}).CreateInBatches(&employees, 100).Error
+	if err != nil {
+		panic(err)
+	}
+
+}
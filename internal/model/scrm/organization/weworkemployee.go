@@ -74,3 +74,20 @@ func (e WeWorkEmployee) Action(db *gorm.DB, employees []*WeWorkEmployee) {
 	}
 
 }
+
+// FindByWeWorkUserId
+//
+//	@Description:
+//	@receiver e
+//	@param db
+//	@param userId
+//	@return employee
+func (e WeWorkEmployee) FindByWeWorkUserId(db *gorm.DB, userId string) (employee *WeWorkEmployee) {
+
+	err := db.Table(e.TableName()).Where(`we_work_user_id = ?`, userId).Limit(1).Find(&employee).Error
+	if err != nil {
+		panic(err)
+	}
+	return employee
+
+}
@@ -0,0 +1,108 @@
+package wechat
+
+import (
+	"PowerX/internal/model"
+	"gorm.io/datatypes"
+	"time"
+)
+
+// 订单事件通知模板, 一个事件可同时配置小程序订阅消息与公众号模板消息
+// https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/mp-message-management/subscribe-message/sendMessage.html
+// https://developers.weixin.qq.com/doc/offiaccount/Message_Management/Template_Message_Interface.html
+type WechatNotificationTemplate struct {
+	model.Model
+
+	Name       string `gorm:"comment:名称" json:"name"`
+	Event      string `gorm:"comment:事件;index" json:"event"`
+	Channel    string `gorm:"comment:渠道 mp_subscribe/oa_template;index" json:"channel"`
+	TemplateId string `gorm:"comment:微信模板ID;index" json:"templateId"`
+	// 小程序订阅消息跳转页面 / 公众号模板消息跳转链接, 支持{{变量}}
+	Page string `gorm:"comment:跳转页面" json:"page"`
+	// 公众号模板消息跳转小程序
+	MiniProgramAppId    string `gorm:"comment:跳转小程序AppId" json:"miniProgramAppId"`
+	MiniProgramPagePath string `gorm:"comment:跳转小程序页面" json:"miniProgramPagePath"`
+	// 模板字段 -> 取值, 如 {"character_string1": "{{orderNumber}}", "thing2": "您的订单已发货"}
+	FieldMappings datatypes.JSON `gorm:"comment:字段映射" json:"fieldMappings"`
+	Status        int8           `gorm:"comment:状态1:启用 2:禁用" json:"status"`
+}
+
+// 客户在小程序内对订阅消息的授权, 一次性订阅每次同意只能下发一条
+type WechatMPSubscription struct {
+	model.Model
+
+	CustomerId     int64      `gorm:"comment:客户ID;uniqueIndex:idx_mp_subscription" json:"customerId"`
+	TemplateId     string     `gorm:"comment:微信模板ID;uniqueIndex:idx_mp_subscription" json:"templateId"`
+	OpenId         string     `gorm:"comment:小程序OpenId;index" json:"openId"`
+	LastStatus     string     `gorm:"comment:最近一次授权结果 accept/reject/ban/filter" json:"lastStatus"`
+	AcceptCount    int        `gorm:"comment:累计同意次数" json:"acceptCount"`
+	RejectCount    int        `gorm:"comment:累计拒绝次数" json:"rejectCount"`
+	AvailableCount int        `gorm:"comment:剩余可下发次数" json:"availableCount"`
+	LastDecidedAt  *time.Time `gorm:"comment:最近授权时间" json:"lastDecidedAt"`
+}
+
+// 每条通知的下发记录
+type WechatNotificationLog struct {
+	model.Model
+
+	NotificationTemplateId int64          `gorm:"comment:通知模板ID;index" json:"notificationTemplateId"`
+	Event                  string         `gorm:"comment:事件;index" json:"event"`
+	Channel                string         `gorm:"comment:渠道" json:"channel"`
+	TemplateId             string         `gorm:"comment:微信模板ID" json:"templateId"`
+	CustomerId             int64          `gorm:"comment:客户ID;index" json:"customerId"`
+	OrderId                int64          `gorm:"comment:订单ID;index" json:"orderId"`
+	OpenId                 string         `gorm:"comment:接收者OpenId" json:"openId"`
+	Page                   string         `gorm:"comment:跳转页面" json:"page"`
+	Data                   datatypes.JSON `gorm:"comment:消息内容" json:"data"`
+	Status                 int8           `gorm:"comment:状态;index" json:"status"`
+	Attempts               int            `gorm:"comment:已尝试次数" json:"attempts"`
+	LastError              string         `gorm:"comment:最近错误" json:"lastError"`
+	MsgId                  string         `gorm:"comment:微信消息ID" json:"msgId"`
+	NextRetryAt            *time.Time     `gorm:"comment:下次重试时间;index" json:"nextRetryAt"`
+	SentAt                 *time.Time     `gorm:"comment:发送成功时间" json:"sentAt"`
+}
+
+const (
+	NotificationEventOrderPaid     = "order_paid"
+	NotificationEventOrderShipped  = "order_shipped"
+	NotificationEventOrderRefunded = "order_refunded"
+	// 订阅续费扣款失败, 提醒客户补缴续费订单
+	NotificationEventOrderRenewalFailed = "order_renewal_failed"
+)
+
+const (
+	NotificationChannelMPSubscribe = "mp_subscribe"
+	NotificationChannelOATemplate  = "oa_template"
+)
+
+const (
+	NotificationTemplateStatusEnable  = 1
+	NotificationTemplateStatusDisable = 2
+)
+
+const (
+	MPSubscriptionAccept = "accept"
+	MPSubscriptionReject = "reject"
+	MPSubscriptionBan    = "ban"
+	MPSubscriptionFilter = "filter"
+)
+
+const (
+	NotificationLogStatusPending  = 1 // 待发送
+	NotificationLogStatusSending  = 2 // 发送中
+	NotificationLogStatusSent     = 3 // 已发送
+	NotificationLogStatusRetrying = 4 // 失败待重试
+	NotificationLogStatusFailed   = 5 // 失败(不再重试)
+	NotificationLogStatusSkipped  = 6 // 跳过(未订阅/未绑定)
+)
+
+// 最多尝试次数
+const NotificationMaxAttempts = 5
+
+func IsNotificationEvent(event string) bool {
+	switch event {
+	case NotificationEventOrderPaid, NotificationEventOrderShipped, NotificationEventOrderRefunded,
+		NotificationEventOrderRenewalFailed:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package wechat
+
+import (
+	"PowerX/internal/model"
+)
+
+// 公众号自动回复规则
+// https://developers.weixin.qq.com/doc/offiaccount/Message_Management/Passive_user_reply_message.html
+type WechatOAAutoReplyRule struct {
+	model.Model
+
+	Name      string `gorm:"comment:规则名称" json:"name"`
+	MatchType int8   `gorm:"comment:匹配类型;index" json:"matchType"`
+	Keyword   string `gorm:"comment:关键词/菜单Key;index" json:"keyword"`
+	ReplyType string `gorm:"comment:回复类型" json:"replyType"`
+	// text
+	Content string `gorm:"comment:文本内容" json:"content"`
+	// image的MediaId, miniprogrampage的封面MediaId
+	MediaId string `gorm:"comment:素材MediaId" json:"mediaId"`
+	// news
+	Title       string `gorm:"comment:图文标题" json:"title"`
+	Description string `gorm:"comment:图文描述" json:"description"`
+	Url         string `gorm:"comment:图文链接" json:"url"`
+	PicUrl      string `gorm:"comment:图文封面" json:"picUrl"`
+	// miniprogrampage
+	AppId    string `gorm:"comment:小程序AppId" json:"appId"`
+	PagePath string `gorm:"comment:小程序页面" json:"pagePath"`
+
+	Priority int  `gorm:"comment:优先级(越大越优先)" json:"priority"`
+	Status   int8 `gorm:"comment:状态1:启用 2:禁用" json:"status"`
+}
+
+const (
+	OAAutoReplyMatchTypeExact     = 1 // 关键词全匹配
+	OAAutoReplyMatchTypeFuzzy     = 2 // 关键词半匹配(包含)
+	OAAutoReplyMatchTypeSubscribe = 3 // 关注回复
+	OAAutoReplyMatchTypeClick     = 4 // 菜单点击Key
+)
+
+const (
+	OAAutoReplyTypeText        = "text"
+	OAAutoReplyTypeImage       = "image"
+	OAAutoReplyTypeNews        = "news"
+	OAAutoReplyTypeMiniProgram = "miniprogrampage"
+)
+
+const (
+	OAAutoReplyStatusEnable  = 1
+	OAAutoReplyStatusDisable = 2
+)
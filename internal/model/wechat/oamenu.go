@@ -0,0 +1,54 @@
+package wechat
+
+import (
+	"PowerX/internal/model"
+	"gorm.io/datatypes"
+	"time"
+)
+
+// 公众号菜单, 默认菜单只有一个, 个性化菜单按匹配规则区分
+// https://developers.weixin.qq.com/doc/offiaccount/Custom_Menus/Creating_Custom-Defined_Menu.html
+type WechatOAMenu struct {
+	model.Model
+
+	Name     string `gorm:"comment:菜单名称" json:"name"`
+	MenuType int8   `gorm:"comment:菜单类型 1:默认 2:个性化;index" json:"menuType"`
+	OAMenuMatchRule
+	DraftVersion     int        `gorm:"comment:草稿版本号" json:"draftVersion"`
+	PublishedVersion int        `gorm:"comment:已发布版本号" json:"publishedVersion"`
+	WechatMenuId     string     `gorm:"comment:微信个性化菜单ID" json:"wechatMenuId"`
+	PublishedAt      *time.Time `gorm:"comment:发布时间" json:"publishedAt"`
+
+	Versions []*WechatOAMenuVersion `gorm:"foreignKey:MenuId;references:Id" json:"versions"`
+}
+
+// 个性化菜单匹配规则, 不能全为空
+type OAMenuMatchRule struct {
+	TagId              string `gorm:"comment:用户标签ID" json:"tagId"`
+	Sex                string `gorm:"comment:性别 1:男 2:女" json:"sex"`
+	ClientPlatformType string `gorm:"comment:客户端 1:iOS 2:Android 3:Others" json:"clientPlatformType"`
+	Country            string `json:"country"`
+	Province           string `json:"province"`
+	City               string `json:"city"`
+	Language           string `json:"language"`
+}
+
+// 菜单版本, 每次保存草稿生成一个新版本
+type WechatOAMenuVersion struct {
+	model.Model
+
+	MenuId  int64          `gorm:"comment:菜单ID;uniqueIndex:idx_oa_menu_version" json:"menuId"`
+	Version int            `gorm:"comment:版本号;uniqueIndex:idx_oa_menu_version" json:"version"`
+	Buttons datatypes.JSON `gorm:"comment:按钮(微信菜单结构)" json:"buttons"`
+	Remark  string         `gorm:"comment:备注" json:"remark"`
+}
+
+const (
+	OAMenuTypeDefault     = 1
+	OAMenuTypeConditional = 2
+)
+
+func (mdl *OAMenuMatchRule) IsEmpty() bool {
+	return mdl.TagId == "" && mdl.Sex == "" && mdl.ClientPlatformType == "" &&
+		mdl.Country == "" && mdl.Province == "" && mdl.City == "" && mdl.Language == ""
+}
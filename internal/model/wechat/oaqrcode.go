@@ -0,0 +1,51 @@
+package wechat
+
+import (
+	"PowerX/internal/model"
+	"time"
+)
+
+// 公众号带参二维码, 统一使用字符串场景值
+// https://developers.weixin.qq.com/doc/offiaccount/Account_Management/Generating_a_Parametric_QR_Code.html
+type WechatOAQrcode struct {
+	model.Model
+
+	Name          string     `gorm:"comment:名称" json:"name"`
+	Channel       string     `gorm:"comment:渠道;index" json:"channel"`
+	Campaign      string     `gorm:"comment:活动;index" json:"campaign"`
+	QrType        int8       `gorm:"comment:类型 1:临时 2:永久" json:"qrType"`
+	SceneStr      string     `gorm:"comment:场景值;unique" json:"sceneStr"`
+	ExpireSeconds int        `gorm:"comment:有效秒数(临时)" json:"expireSeconds"`
+	Ticket        string     `gorm:"comment:二维码ticket" json:"ticket"`
+	Url           string     `gorm:"comment:二维码解析后的地址" json:"url"`
+	ExpiredAt     *time.Time `gorm:"comment:过期时间" json:"expiredAt"`
+}
+
+// 扫码/关注/取关事件, 用于按二维码统计增长与留存
+type WechatOAQrcodeEvent struct {
+	model.Model
+
+	QrcodeId int64  `gorm:"comment:二维码ID;index" json:"qrcodeId"`
+	SceneStr string `gorm:"comment:场景值" json:"sceneStr"`
+	OpenId   string `gorm:"comment:粉丝OpenId;index" json:"openId"`
+	Event    string `gorm:"comment:事件 subscribe/scan/unsubscribe" json:"event"`
+	IsNew    bool   `gorm:"comment:是否首次关注" json:"isNew"`
+}
+
+const (
+	OAQrcodeTypeTemporary = 1
+	OAQrcodeTypeForever   = 2
+
+	// 临时二维码最长30天
+	OAQrcodeMaxExpireSeconds = 30 * 24 * 3600
+)
+
+const (
+	OAQrcodeEventSubscribe   = "subscribe"
+	OAQrcodeEventScan        = "scan"
+	OAQrcodeEventUnsubscribe = "unsubscribe"
+)
+
+func (mdl *WechatOAQrcode) IsExpired() bool {
+	return mdl.ExpiredAt != nil && mdl.ExpiredAt.Before(time.Now())
+}
@@ -13,6 +13,7 @@ type WechatOACustomer struct {
 	Customer *customerdomain2.Customer `gorm:"foreignKey:OpenId;references:OpenIdInWeChatOfficialAccount" json:"customer"`
 
 	model.Model
+	UniqueID       string         `gorm:"unique" json:"uniqueId"`
 	Subscribe      int            `json:"subscribe"`
 	SessionKey     string         `json:"-"`
 	OpenId         string         `json:"openId"`
@@ -26,3 +27,10 @@ type WechatOACustomer struct {
 	QrScene        int            `json:"qrScene"`
 	QrSceneStr     string         `json:"qrSceneStr"`
 }
+
+const WechatOACustomerUniqueId = "unique_id"
+
+// 公众号粉丝以OpenId唯一
+func (mdl *WechatOACustomer) GetComposedUniqueID() string {
+	return mdl.OpenId
+}
@@ -712,6 +712,7 @@ type ListCategoryTreeRequest struct {
 	NeedChildren bool     `form:"needChildren,optional"`
 	Names        []string `form:"name,optional"`
 	OrderBy      string   `form:"orderBy,optional"`
+	Filters      string   `form:"filters,optional"`
 }
 
 type ListCategoryTreeReply struct {
@@ -801,6 +802,7 @@ type ListLeadsPageRequest struct {
 	Sources    []int  `form:"sources,optional"`
 	Statuses   []int  `form:"statuses,optional"`
 	OrderBy    string `form:"orderBy,optional"`
+	Filters    string `form:"filters,optional"`
 	PageIndex  int    `form:"pageIndex,optional"`
 	PageSize   int    `form:"pageSize,optional"`
 }
@@ -903,6 +905,7 @@ type ListCustomersPageRequest struct {
 	Sources    []int  `form:"sources,optional"`
 	Statuses   []int  `form:"statuses,optional"`
 	OrderBy    string `form:"orderBy,optional"`
+	Filters    string `form:"filters,optional"`
 	PageIndex  int    `form:"pageIndex,optional"`
 	PageSize   int    `form:"pageSize,optional"`
 }
@@ -1158,6 +1161,7 @@ type ListStoresPageRequest struct {
 	Ids       []int64 `form:"ids,optional"`
 	LikeName  string  `form:"likeName,optional"`
 	OrderBy   string  `form:"orderBy,optional"`
+	Filters   string  `form:"filters,optional"`
 	PageIndex int     `form:"pageIndex,optional"`
 	PageSize  int     `form:"pageSize,optional"`
 }
@@ -1211,6 +1215,71 @@ type AssignStoreManagerReply struct {
 	Store
 }
 
+type StoreSKU struct {
+	SkuId        int64  `json:"skuId"`
+	SkuNo        string `json:"skuNo,optional"`
+	IsAvailable  bool   `json:"isAvailable,optional"`
+	Inventory    *int   `json:"inventory,optional"`
+	SkuInventory int    `json:"skuInventory,optional"`
+}
+
+type StoreProduct struct {
+	Id          int64          `json:"id,optional"`
+	StoreId     int64          `json:"storeId,optional"`
+	ProductId   int64          `json:"productId,optional"`
+	ProductName string         `json:"productName,optional"`
+	SPU         string         `json:"spu,optional"`
+	CoverImage  *MediaResource `json:"coverImage,optional"`
+	IsAvailable bool           `json:"isAvailable,optional"`
+	SKUs        []*StoreSKU    `json:"skus,optional"`
+	CreatedAt   string         `json:"createdAt,optional"`
+}
+
+type ListStoreProductsPageRequest struct {
+	StoreId    int64   `path:"id"`
+	ProductIds []int64 `form:"productIds,optional"`
+	PageIndex  int     `form:"pageIndex,optional"`
+	PageSize   int     `form:"pageSize,optional"`
+}
+
+type ListStoreProductsPageReply struct {
+	List      []*StoreProduct `json:"list"`
+	PageIndex int             `json:"pageIndex"`
+	PageSize  int             `json:"pageSize"`
+	Total     int64           `json:"total"`
+}
+
+type AssignStoreProductsRequest struct {
+	StoreId     int64   `path:"id"`
+	ProductIds  []int64 `json:"productIds"`
+	IsAvailable bool    `json:"isAvailable,optional"`
+}
+
+type AssignStoreProductsReply struct {
+	StoreId    int64   `json:"storeId"`
+	ProductIds []int64 `json:"productIds"`
+}
+
+type RemoveStoreProductRequest struct {
+	StoreId   int64 `path:"id"`
+	ProductId int64 `path:"productId"`
+}
+
+type RemoveStoreProductReply struct {
+	StoreId   int64 `json:"storeId"`
+	ProductId int64 `json:"productId"`
+}
+
+type UpsertStoreSKUsRequest struct {
+	StoreId int64       `path:"id"`
+	SKUs    []*StoreSKU `json:"skus"`
+}
+
+type UpsertStoreSKUsReply struct {
+	StoreId int64       `json:"storeId"`
+	SKUs    []*StoreSKU `json:"skus"`
+}
+
 type ListMGMRulesPageRequest struct {
 	MGMRuleTypes []int8   `form:"mgmTypes,optional"`
 	Keys         []string `form:"keys,optional"`
@@ -1431,6 +1500,10 @@ type Product struct {
 	Description             string                         `json:"description,optional"`
 	AllowedSellQuantity     int                            `json:"allowedSellQuantity,optional"`
 	ValidityPeriodDays      int                            `json:"validityPeriodDays,optional"`
+	BillingIntervalUnit     string                         `json:"billingIntervalUnit,optional"`
+	BillingIntervalCount    int                            `json:"billingIntervalCount,optional"`
+	BillingCycles           int                            `json:"billingCycles,optional"`
+	IsBundle                bool                           `json:"isBundle,optional"`
 	SaleStartDate           string                         `json:"saleStartDate,optional"`
 	SaleEndDate             string                         `json:"saleEndDate,optional"`
 	ApprovalStatus          int                            `json:"approvalStatus,optional"`
@@ -1453,6 +1526,8 @@ type Product struct {
 	ActivePriceEntry        *ActivePriceEntry              `json:"activePriceBookEntry,optional"`
 	PriceBookEntries        []*PriceBookEntry              `json:"priceBookEntries,optional"`
 	SKUs                    []*SKU                         `json:"skus,optional"`
+	BundleItems             []*ProductBundleItem           `json:"bundleItems,optional"`
+	BundleAvailableQuantity int                            `json:"bundleAvailableQuantity,optional"`
 	*ProductAttribute
 	ViewedCount int `json:"viewedCount,optional"`
 }
@@ -1466,7 +1541,9 @@ type ListProductsPageRequest struct {
 	Keys               []string `form:"keys,optional"`
 	ProductCategoryId  int      `form:"productCategoryId,optional"`
 	ProductCategoryIds []int    `form:"productCategoryIds,optional"`
+	StoreId            int64    `form:"storeId,optional"`
 	OrderBy            string   `form:"orderBy,optional"`
+	Filters            string   `form:"filters,optional"`
 	PageIndex          int      `form:"pageIndex,optional"`
 	PageSize           int      `form:"pageSize,optional"`
 }
@@ -1488,6 +1565,7 @@ type CreateProductReply struct {
 
 type GetProductRequest struct {
 	ProductId int64 `path:"id"`
+	StoreId   int64 `form:"storeId,optional"`
 }
 
 type GetProductReply struct {
@@ -1501,6 +1579,7 @@ type PutProductRequest struct {
 
 type PutProductReply struct {
 	*Product
+	PendingRevisionId int64 `json:"pendingRevisionId,optional"`
 }
 
 type PatchProductRequest struct {
@@ -1620,6 +1699,7 @@ type ListProductCategoryTreeRequest struct {
 	NeedChildren bool     `form:"needChildren,optional"`
 	Names        []string `form:"name,optional"`
 	OrderBy      string   `form:"orderBy,optional"`
+	Filters      string   `form:"filters,optional"`
 }
 
 type ListProductCategoryTreeReply struct {
@@ -1706,11 +1786,34 @@ type CreateProductSpecificReply struct {
 }
 
 type ConfigProductSpecificRequest struct {
+	ProductId        int64             `json:"productId,optional"`
 	ProductSpecifics []ProductSpecific `json:"productSpecifics"`
 }
 
 type ConfigProductSpecificReply struct {
-	Result bool `json:"result"`
+	Result    bool           `json:"result"`
+	SKUMatrix *SKUMatrixDiff `json:"skuMatrix,optional"`
+}
+
+type SKUMatrixItem struct {
+	SkuId          int64    `json:"skuId,optional"`
+	SkuNo          string   `json:"skuNo,optional"`
+	OptionIds      []int64  `json:"optionIds"`
+	OptionNames    []string `json:"optionNames"`
+	Inventory      int      `json:"inventory,optional"`
+	OpenOrderCount int      `json:"openOrderCount,optional"`
+}
+
+type SKUMatrixDiff struct {
+	Added         []*SKUMatrixItem `json:"added"`
+	Unchanged     []*SKUMatrixItem `json:"unchanged"`
+	Updated       []*SKUMatrixItem `json:"updated"`
+	Removed       []*SKUMatrixItem `json:"removed"`
+	BlockedSkuNos []string         `json:"blockedSkuNos"`
+}
+
+type PreviewConfigProductSpecificReply struct {
+	*SKUMatrixDiff
 }
 
 type GetProductSpecificRequest struct {
@@ -1757,6 +1860,7 @@ type SKU struct {
 	ListPrice  float64 `json:"listPrice,optional"`
 	IsActive   bool    `json:"isActive,optional"`
 	OptionsIds []int64 `json:"optionsIds,optional"`
+	IsRetired  bool    `json:"isRetired,optional"`
 }
 
 type ListSKUPageRequest struct {
@@ -1844,20 +1948,22 @@ type PriceBookEntrySpecific struct {
 }
 
 type PriceBookEntry struct {
-	Id            int64             `json:"id,optional"`
-	UniqueID      string            `json:"uniqueID,optional"`
-	PriceBookId   int64             `json:"priceBookId"`
-	ProductId     int64             `json:"productId"`
-	SkuId         int64             `json:"skuId,optional"`
-	UnitPrice     float64           `json:"unitPrice"`
-	ListPrice     float64           `json:"listPrice,optional"`
-	IsActive      bool              `json:"isActive, optional"`
-	PriceConfigs  []*PriceConfig    `json:"priceConfigs, optional"`
-	SKUEntries    []*PriceBookEntry `json:"skuEntries, optional"`
-	PriceBookName string            `json:"priceBookName,optional"`
-	ProductName   string            `json:"productName,optional"`
-	SPU           string            `json:"spu,optional"`
-	Discount      float32           `json:"discount,optional"`
+	Id             int64             `json:"id,optional"`
+	UniqueID       string            `json:"uniqueID,optional"`
+	PriceBookId    int64             `json:"priceBookId"`
+	ProductId      int64             `json:"productId"`
+	SkuId          int64             `json:"skuId,optional"`
+	UnitPrice      float64           `json:"unitPrice"`
+	ListPrice      float64           `json:"listPrice,optional"`
+	IsActive       bool              `json:"isActive, optional"`
+	BundlePricing  string            `json:"bundlePricing,optional"`
+	BundleDiscount float64           `json:"bundleDiscount,optional"`
+	PriceConfigs   []*PriceConfig    `json:"priceConfigs, optional"`
+	SKUEntries     []*PriceBookEntry `json:"skuEntries, optional"`
+	PriceBookName  string            `json:"priceBookName,optional"`
+	ProductName    string            `json:"productName,optional"`
+	SPU            string            `json:"spu,optional"`
+	Discount       float32           `json:"discount,optional"`
 }
 
 type ListPriceBookEntriesPageRequest struct {
@@ -1909,6 +2015,38 @@ type DeletePriceBookEntryReply struct {
 	Id int64 `json:"id"`
 }
 
+type ProductBundleItem struct {
+	Id          int64  `json:"id,optional"`
+	ProductId   int64  `json:"productId,optional"`
+	SkuId       int64  `json:"skuId"`
+	Quantity    int    `json:"quantity"`
+	Sort        int    `json:"sort,optional"`
+	ProductName string `json:"productName,optional"`
+	SkuNo       string `json:"skuNo,optional"`
+	Inventory   int    `json:"inventory,optional"`
+}
+
+type GetProductBundleRequest struct {
+	ProductId int64 `path:"id"`
+}
+
+type GetProductBundleReply struct {
+	ProductId         int64                `json:"productId"`
+	BundleItems       []*ProductBundleItem `json:"bundleItems"`
+	AvailableQuantity int                  `json:"availableQuantity"`
+}
+
+type ConfigProductBundleRequest struct {
+	ProductId   int64                `path:"id"`
+	BundleItems []*ProductBundleItem `json:"bundleItems"`
+}
+
+type ConfigProductBundleReply struct {
+	ProductId         int64                `json:"productId"`
+	BundleItems       []*ProductBundleItem `json:"bundleItems"`
+	AvailableQuantity int                  `json:"availableQuantity"`
+}
+
 type Artisan struct {
 	Id             int64            `json:"id,optional"`
 	EmployeeId     int64            `json:"employeeId,optional"`
@@ -1936,6 +2074,7 @@ type ListArtisansPageRequest struct {
 	StoreIds  []int64 `form:"storeIds,optional"`
 	LikeName  string  `form:"likeName,optional"`
 	OrderBy   string  `form:"orderBy,optional"`
+	Filters   string  `form:"filters,optional"`
 	PageIndex int     `form:"pageIndex,optional"`
 	PageSize  int     `form:"pageSize,optional"`
 }
@@ -1989,6 +2128,385 @@ type BindArtisansToStoresReply struct {
 	PivotIds []int64 `json:"pivotIds"`
 }
 
+type RebuildProductSearchIndexRequest struct {
+}
+
+type RebuildProductSearchIndexReply struct {
+	Count int `json:"count"`
+}
+
+type ProductRevision struct {
+	Id          int64    `json:"id,optional"`
+	ProductId   int64    `json:"productId,optional"`
+	Status      string   `json:"status,optional"`
+	Product     *Product `json:"product,optional"`
+	SubmittedBy int64    `json:"submittedBy,optional"`
+	ReviewedBy  int64    `json:"reviewedBy,optional"`
+	ReviewedAt  string   `json:"reviewedAt,optional"`
+	Comment     string   `json:"comment,optional"`
+	CreatedAt   string   `json:"createdAt,optional"`
+	UpdatedAt   string   `json:"updatedAt,optional"`
+}
+
+type ProductApprovalRecord struct {
+	Id             int64  `json:"id,optional"`
+	ProductId      int64  `json:"productId,optional"`
+	RevisionId     int64  `json:"revisionId,optional"`
+	Action         string `json:"action,optional"`
+	ApprovalStatus string `json:"approvalStatus,optional"`
+	OperatorId     int64  `json:"operatorId,optional"`
+	Comment        string `json:"comment,optional"`
+	CreatedAt      string `json:"createdAt,optional"`
+}
+
+type SubmitProductApprovalRequest struct {
+	ProductId int64  `path:"id"`
+	Comment   string `json:"comment,optional"`
+}
+
+type SubmitProductApprovalReply struct {
+	ProductId      int64 `json:"productId"`
+	ApprovalStatus int   `json:"approvalStatus"`
+}
+
+type ApproveProductRequest struct {
+	ProductId int64  `path:"id"`
+	Comment   string `json:"comment,optional"`
+}
+
+type ApproveProductReply struct {
+	ProductId      int64 `json:"productId"`
+	ApprovalStatus int   `json:"approvalStatus"`
+	RevisionId     int64 `json:"revisionId,optional"`
+}
+
+type RejectProductRequest struct {
+	ProductId int64  `path:"id"`
+	Comment   string `json:"comment"`
+}
+
+type RejectProductReply struct {
+	ProductId int64 `json:"productId"`
+}
+
+type GetProductPendingRevisionRequest struct {
+	ProductId int64 `path:"id"`
+}
+
+type GetProductPendingRevisionReply struct {
+	*ProductRevision
+}
+
+type ListProductApprovalRecordsRequest struct {
+	ProductId int64 `path:"id"`
+}
+
+type ListProductApprovalRecordsReply struct {
+	List []*ProductApprovalRecord `json:"list"`
+}
+
+type ProductImportRowError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,optional"`
+	Message string `json:"message"`
+}
+
+type ProductImportJob struct {
+	Id              int64                    `json:"id,optional"`
+	FileName        string                   `json:"fileName,optional"`
+	Format          string                   `json:"format,optional"`
+	DryRun          bool                     `json:"dryRun,optional"`
+	Status          string                   `json:"status,optional"`
+	TotalRows       int                      `json:"totalRows,optional"`
+	ProcessedRows   int                      `json:"processedRows,optional"`
+	FailedRows      int                      `json:"failedRows,optional"`
+	CreatedProducts int                      `json:"createdProducts,optional"`
+	UpdatedProducts int                      `json:"updatedProducts,optional"`
+	RowErrors       []*ProductImportRowError `json:"rowErrors,optional"`
+	Message         string                   `json:"message,optional"`
+	OperatorId      int64                    `json:"operatorId,optional"`
+	StartedAt       string                   `json:"startedAt,optional"`
+	FinishedAt      string                   `json:"finishedAt,optional"`
+	CreatedAt       string                   `json:"createdAt,optional"`
+}
+
+type ImportProductCatalogRequest struct {
+	DryRun bool `form:"dryRun,optional"`
+}
+
+type ImportProductCatalogReply struct {
+	*ProductImportJob
+}
+
+type GetProductImportJobRequest struct {
+	Id int64 `path:"id"`
+}
+
+type GetProductImportJobReply struct {
+	*ProductImportJob
+}
+
+type ExportProductCatalogRequest struct {
+	Format     string `form:"format,optional,options=csv|xlsx"`
+	LikeName   string `form:"likeName,optional"`
+	CategoryId int    `form:"categoryId,optional"`
+}
+
+type ExportProductCatalogReply struct {
+	Content  []byte `json:"content"`
+	FileName string `json:"fileName"`
+	FileSize int    `json:"fileSize"`
+	FileType string `json:"fileType"`
+}
+
+type DownloadProductCatalogTemplateRequest struct {
+	Format string `form:"format,optional,options=csv|xlsx"`
+}
+
+type DownloadProductCatalogTemplateReply struct {
+	Content  []byte `json:"content"`
+	FileName string `json:"fileName"`
+	FileSize int    `json:"fileSize"`
+	FileType string `json:"fileType"`
+}
+
+type ProductReview struct {
+	Id           int64            `json:"id,optional"`
+	ProductId    int64            `json:"productId,optional"`
+	SkuId        int64            `json:"skuId,optional"`
+	OrderId      int64            `json:"orderId,optional"`
+	OrderItemId  int64            `json:"orderItemId,optional"`
+	CustomerId   int64            `json:"customerId,optional"`
+	CustomerName string           `json:"customerName,optional"`
+	IsAnonymous  bool             `json:"isAnonymous,optional"`
+	SkuNo        string           `json:"skuNo,optional"`
+	Rating       int              `json:"rating,optional"`
+	Content      string           `json:"content,optional"`
+	Images       []*MediaResource `json:"images,optional"`
+	Status       string           `json:"status,optional"`
+	HelpfulCount int              `json:"helpfulCount,optional"`
+	HiddenReason string           `json:"hiddenReason,optional"`
+	ModeratedBy  int64            `json:"moderatedBy,optional"`
+	ModeratedAt  string           `json:"moderatedAt,optional"`
+	Reply        string           `json:"reply,optional"`
+	RepliedAt    string           `json:"repliedAt,optional"`
+	CreatedAt    string           `json:"createdAt,optional"`
+}
+
+type ListProductReviewsPageRequest struct {
+	ProductId  int64    `form:"productId,optional"`
+	CustomerId int64    `form:"customerId,optional"`
+	Statuses   []string `form:"statuses,optional"`
+	Rating     int      `form:"rating,optional"`
+	SortBy     string   `form:"sortBy,optional"`
+	PageIndex  int      `form:"pageIndex,optional"`
+	PageSize   int      `form:"pageSize,optional"`
+}
+
+type ListProductReviewsPageReply struct {
+	List      []*ProductReview `json:"list"`
+	PageIndex int              `json:"pageIndex"`
+	PageSize  int              `json:"pageSize"`
+	Total     int64            `json:"total"`
+}
+
+type GetProductReviewRequest struct {
+	ProductReviewId int64 `path:"id"`
+}
+
+type GetProductReviewReply struct {
+	*ProductReview
+}
+
+type ApproveProductReviewRequest struct {
+	ProductReviewId int64 `path:"id"`
+}
+
+type ApproveProductReviewReply struct {
+	*ProductReview
+}
+
+type HideProductReviewRequest struct {
+	ProductReviewId int64  `path:"id"`
+	HiddenReason    string `json:"hiddenReason,optional"`
+}
+
+type HideProductReviewReply struct {
+	*ProductReview
+}
+
+type ReplyProductReviewRequest struct {
+	ProductReviewId int64  `path:"id"`
+	Reply           string `json:"reply"`
+}
+
+type ReplyProductReviewReply struct {
+	*ProductReview
+}
+
+type ArtisanSchedule struct {
+	Id          int64  `json:"id,optional"`
+	StoreId     int64  `json:"storeId,optional"`
+	ArtisanId   int64  `json:"artisanId,optional"`
+	Weekday     int    `json:"weekday"`
+	StartTime   string `json:"startTime"`
+	EndTime     string `json:"endTime"`
+	SlotMinutes int    `json:"slotMinutes,optional"`
+}
+
+type ArtisanLeave struct {
+	Id        int64  `json:"id,optional"`
+	ArtisanId int64  `json:"artisanId,optional"`
+	StartAt   string `json:"startAt"`
+	EndAt     string `json:"endAt"`
+	Reason    string `json:"reason,optional"`
+	CreatedAt string `json:"createdAt,optional"`
+}
+
+type Booking struct {
+	Id              int64  `json:"id,optional"`
+	StoreId         int64  `json:"storeId"`
+	ArtisanId       int64  `json:"artisanId"`
+	ArtisanName     string `json:"artisanName,optional"`
+	CustomerId      int64  `json:"customerId,optional"`
+	OrderId         int64  `json:"orderId,optional"`
+	OrderItemId     int64  `json:"orderItemId"`
+	ProductId       int64  `json:"productId,optional"`
+	ProductName     string `json:"productName,optional"`
+	StartAt         string `json:"startAt"`
+	EndAt           string `json:"endAt,optional"`
+	Status          string `json:"status,optional"`
+	Comment         string `json:"comment,optional"`
+	RescheduleCount int    `json:"rescheduleCount,optional"`
+	CancelReason    string `json:"cancelReason,optional"`
+	CancelledAt     string `json:"cancelledAt,optional"`
+	CompletedAt     string `json:"completedAt,optional"`
+	CreatedAt       string `json:"createdAt,optional"`
+}
+
+type BookingTimeSlot struct {
+	StartAt string `json:"startAt"`
+	EndAt   string `json:"endAt"`
+}
+
+type ListArtisanSchedulesRequest struct {
+	StoreId   int64 `path:"id"`
+	ArtisanId int64 `path:"artisanId"`
+}
+
+type ListArtisanSchedulesReply struct {
+	List []*ArtisanSchedule `json:"list"`
+}
+
+type ReplaceArtisanSchedulesRequest struct {
+	StoreId   int64              `path:"id"`
+	ArtisanId int64              `path:"artisanId"`
+	Schedules []*ArtisanSchedule `json:"schedules,optional"`
+}
+
+type ReplaceArtisanSchedulesReply struct {
+	List []*ArtisanSchedule `json:"list"`
+}
+
+type ListArtisanLeavesRequest struct {
+	ArtisanId int64  `path:"id"`
+	StartAt   string `form:"startAt,optional"`
+	EndAt     string `form:"endAt,optional"`
+}
+
+type ListArtisanLeavesReply struct {
+	List []*ArtisanLeave `json:"list"`
+}
+
+type CreateArtisanLeaveRequest struct {
+	ArtisanId int64  `path:"id"`
+	StartAt   string `json:"startAt"`
+	EndAt     string `json:"endAt"`
+	Reason    string `json:"reason,optional"`
+}
+
+type CreateArtisanLeaveReply struct {
+	ArtisanLeaveId int64 `json:"id"`
+}
+
+type DeleteArtisanLeaveRequest struct {
+	ArtisanLeaveId int64 `path:"id"`
+}
+
+type DeleteArtisanLeaveReply struct {
+	ArtisanLeaveId int64 `json:"id"`
+}
+
+type GetStoreBookingCalendarRequest struct {
+	StoreId   int64  `path:"id"`
+	StartDate string `form:"startDate"`
+	EndDate   string `form:"endDate"`
+	ArtisanId int64  `form:"artisanId,optional"`
+}
+
+type GetStoreBookingCalendarReply struct {
+	StoreId   int64              `json:"storeId"`
+	Schedules []*ArtisanSchedule `json:"schedules"`
+	Bookings  []*Booking         `json:"bookings"`
+}
+
+type GetArtisanBookingCalendarRequest struct {
+	ArtisanId int64  `path:"id"`
+	StartDate string `form:"startDate"`
+	EndDate   string `form:"endDate"`
+}
+
+type GetArtisanBookingCalendarReply struct {
+	ArtisanId int64           `json:"artisanId"`
+	Bookings  []*Booking      `json:"bookings"`
+	Leaves    []*ArtisanLeave `json:"leaves"`
+}
+
+type ListBookingsPageRequest struct {
+	StoreId    int64    `form:"storeId,optional"`
+	ArtisanId  int64    `form:"artisanId,optional"`
+	CustomerId int64    `form:"customerId,optional"`
+	OrderId    int64    `form:"orderId,optional"`
+	Statuses   []string `form:"statuses,optional"`
+	StartAt    string   `form:"startAt,optional"`
+	EndAt      string   `form:"endAt,optional"`
+	PageIndex  int      `form:"pageIndex,optional"`
+	PageSize   int      `form:"pageSize,optional"`
+}
+
+type ListBookingsPageReply struct {
+	List      []*Booking `json:"list"`
+	PageIndex int        `json:"pageIndex"`
+	PageSize  int        `json:"pageSize"`
+	Total     int64      `json:"total"`
+}
+
+type CancelBookingRequest struct {
+	BookingId int64  `path:"id"`
+	Reason    string `json:"reason,optional"`
+}
+
+type CancelBookingReply struct {
+	Booking *Booking `json:"booking"`
+}
+
+type RescheduleBookingRequest struct {
+	BookingId int64  `path:"id"`
+	StartAt   string `json:"startAt"`
+}
+
+type RescheduleBookingReply struct {
+	Booking *Booking `json:"booking"`
+}
+
+type CompleteBookingRequest struct {
+	BookingId int64 `path:"id"`
+}
+
+type CompleteBookingReply struct {
+	Booking *Booking `json:"booking"`
+}
+
 type ShippingAddress struct {
 	Id           int64  `json:"id,optional"`
 	CustomerId   int64  `json:"customerId,optional"`
@@ -2273,91 +2791,323 @@ type DeleteWarehouseResponse struct {
 	WarehouseId int64 `json:"warehouseId"`
 }
 
-type ContractWayGroupNode struct {
-	Id        int64                  `json:"id"`
-	GroupName string                 `json:"groupName"`
-	Children  []ContractWayGroupNode `json:"children"`
+type PaymentReconciliationItem struct {
+	Id              int64   `json:"id"`
+	PaymentId       int64   `json:"paymentId"`
+	PaymentNumber   string  `json:"paymentNumber"`
+	ReferenceNumber string  `json:"referenceNumber"`
+	BillTradeState  string  `json:"billTradeState"`
+	BillAmount      float64 `json:"billAmount"`
+	RefundAmount    float64 `json:"refundAmount"`
+	PaymentAmount   float64 `json:"paymentAmount"`
+	PaymentStatus   string  `json:"paymentStatus"`
+	Result          string  `json:"result"`
+	Remark          string  `json:"remark"`
+}
+
+type PaymentReconciliation struct {
+	Id             int64                        `json:"id"`
+	PaymentType    string                       `json:"paymentType"`
+	BillDate       string                       `json:"billDate"`
+	Source         string                       `json:"source"`
+	Status         string                       `json:"status"`
+	BillCount      int                          `json:"billCount"`
+	BillAmount     float64                      `json:"billAmount"`
+	PaymentCount   int                          `json:"paymentCount"`
+	PaymentAmount  float64                      `json:"paymentAmount"`
+	MatchedCount   int                          `json:"matchedCount"`
+	ExceptionCount int                          `json:"exceptionCount"`
+	HealedCount    int                          `json:"healedCount"`
+	ErrorMessage   string                       `json:"errorMessage"`
+	CreatedAt      string                       `json:"createdAt"`
+	FinishedAt     string                       `json:"finishedAt"`
+	Items          []*PaymentReconciliationItem `json:"items,omitempty"`
+}
+
+type ListPaymentReconciliationsPageRequest struct {
+	PaymentType string `form:"paymentType,optional"`
+	BillDate    string `form:"billDate,optional"`
+	Status      string `form:"status,optional"`
+	PageIndex   int    `form:"pageIndex,optional"`
+	PageSize    int    `form:"pageSize,optional"`
 }
 
-type GetContractWayGroupTreeRequest struct {
+type ListPaymentReconciliationsPageReply struct {
+	List      []*PaymentReconciliation `json:"list"`
+	PageIndex int                      `json:"pageIndex"`
+	PageSize  int                      `json:"pageSize"`
+	Total     int64                    `json:"total"`
 }
 
-type GetContractWayGroupTreeReply struct {
-	GroupTree ContractWayGroupNode `json:"tree"`
+type GetPaymentReconciliationRequest struct {
+	ReconciliationId int64    `path:"id"`
+	Results          []string `form:"results,optional"`
 }
 
-type ContractWayGroup struct {
-	Id        int64  `json:"id"`
-	GroupName string `json:"groupName"`
+type GetPaymentReconciliationReply struct {
+	*PaymentReconciliation
 }
 
-type GetContractWayGroupListRequest struct {
-	GroupName string `form:"groupName,optional"`
+type CreatePaymentReconciliationRequest struct {
+	BillDate string `json:"billDate"`
 }
 
-type GetContractWayGroupListReply struct {
-	Groups []ContractWayGroup `json:"groups"`
+type CreatePaymentReconciliationReply struct {
+	*PaymentReconciliation
 }
 
-type GetContractWaysRequest struct {
-	EmployeeId int64  `form:"employeeId,optional"`
-	Name       string `form:"name,optional"`
-	StartDate  string `form:"startDate,optional"`
-	EndDate    string `form:"endDate,optional"`
-	PageIndex  int    `form:"pageIndex"`
-	PageSize   int    `form:"pageSize"`
+type UploadPaymentReconciliationReply struct {
+	*PaymentReconciliation
 }
 
-type GetContractWaysReply struct {
-	List      []ContractWay `json:"list"`
-	PageIndex int           `json:"pageIndex"`
-	PageSize  int           `json:"pageSize"`
-	Total     int64         `json:"total"`
+type Invoice struct {
+	Id             int64          `json:"id,optional"`
+	OrderId        int64          `json:"orderId,optional"`
+	OrderNumber    string         `json:"orderNumber,optional"`
+	CustomerId     int64          `json:"customerId,optional"`
+	RequestNumber  string         `json:"requestNumber,optional"`
+	Kind           string         `json:"kind,optional"`
+	BlueInvoiceId  int64          `json:"blueInvoiceId,optional"`
+	TitleType      string         `json:"titleType,optional"`
+	Title          string         `json:"title,optional"`
+	TaxIdNumber    string         `json:"taxIdNumber,optional"`
+	Email          string         `json:"email,optional"`
+	Amount         float64        `json:"amount,optional"`
+	ReversedAmount float64        `json:"reversedAmount,optional"`
+	Status         string         `json:"status,optional"`
+	InvoiceCode    string         `json:"invoiceCode,optional"`
+	InvoiceNumber  string         `json:"invoiceNumber,optional"`
+	IssuedAt       string         `json:"issuedAt,optional"`
+	PdfResource    *MediaResource `json:"pdfResource,optional"`
+	RejectReason   string         `json:"rejectReason,optional"`
+	Remark         string         `json:"remark,optional"`
+	CreatedAt      string         `json:"createdAt,optional"`
 }
 
-type ContractWay struct {
-	Id            int64    `json:"id"`
-	Type          int      `json:"type"`
-	Scene         int      `json:"scene"`
-	Style         string   `json:"style,optional"`
-	Remark        string   `json:"remark,optional"`
-	SkipVerify    bool     `json:"skipVerify,optional"`
-	State         string   `json:"state,optional"`
-	Users         []string `json:"users,optional"`
-	Parties       []int64  `json:"parties,optional"`
-	IsTemp        bool     `json:"isTemp,optional"`
-	ExpiresIn     int      `json:"expiresIn,optional"`
-	ChatExpiresIn int      `json:"chatExpiresIn,optional"`
-	UnionId       string   `json:"unionId,optional"`
-	IsExclusive   bool     `json:"isExclusive,optional"`
-	Conclusions   string   `json:"conclusions,optional"`
+type ListInvoicesPageRequest struct {
+	OrderId       int64    `form:"orderId,optional"`
+	Kind          string   `form:"kind,optional"`
+	Statuses      []string `form:"statuses,optional"`
+	RequestNumber string   `form:"requestNumber,optional"`
+	StartAt       string   `form:"startAt,optional"`
+	EndAt         string   `form:"endAt,optional"`
+	PageIndex     int      `form:"pageIndex,optional"`
+	PageSize      int      `form:"pageSize,optional"`
 }
 
-type CreateContractWayRequest struct {
-	Type          int      `json:"type"`
-	Scene         int      `json:"scene"`
-	Style         string   `json:"style,optional"`
-	Remark        string   `json:"remark,optional"`
-	SkipVerify    bool     `json:"skipVerify,optional"`
-	State         string   `json:"state,optional"`
-	Users         []string `json:"users,optional"`
-	Parties       []int64  `json:"parties,optional"`
-	IsTemp        bool     `json:"isTemp,optional"`
-	ExpiresIn     int      `json:"expiresIn,optional"`
-	ChatExpiresIn int      `json:"chatExpiresIn,optional"`
-	UnionId       string   `json:"unionId,optional"`
-	IsExclusive   bool     `json:"isExclusive,optional"`
-	Conclusions   string   `json:"conclusions,optional"`
+type ListInvoicesPageReply struct {
+	List      []*Invoice `json:"list"`
+	PageIndex int        `json:"pageIndex"`
+	PageSize  int        `json:"pageSize"`
+	Total     int64      `json:"total"`
 }
 
-type CreateContractWayReply struct {
-	Id int64 `json:"id"`
+type ExportInvoicesRequest struct {
+	Kind     string   `form:"kind,optional"`
+	Statuses []string `form:"statuses,optional"`
+	StartAt  string   `form:"startAt"`
+	EndAt    string   `form:"endAt"`
 }
 
-type UpdateContractWayRequest struct {
-	Id            int64    `path:"id"`
-	Type          int      `json:"type,optional"`
-	Scene         int      `json:"scene,optional"`
+type ExportInvoicesReply struct {
+	Content  []byte `json:"content"`
+	FileName string `json:"fileName"`
+	FileSize int    `json:"fileSize"`
+	FileType string `json:"fileType"`
+}
+
+type GetInvoiceRequest struct {
+	InvoiceId int64 `path:"id"`
+}
+
+type GetInvoiceReply struct {
+	*Invoice
+}
+
+type IssueInvoiceRequest struct {
+	InvoiceId     int64  `path:"id"`
+	InvoiceCode   string `json:"invoiceCode,optional"`
+	InvoiceNumber string `json:"invoiceNumber"`
+	PdfResourceId int64  `json:"pdfResourceId,optional"`
+	IssuedAt      string `json:"issuedAt,optional"`
+	Remark        string `json:"remark,optional"`
+}
+
+type IssueInvoiceReply struct {
+	*Invoice
+}
+
+type RejectInvoiceRequest struct {
+	InvoiceId int64  `path:"id"`
+	Reason    string `json:"reason"`
+}
+
+type RejectInvoiceReply struct {
+	*Invoice
+}
+
+type Subscription struct {
+	Id                 int64   `json:"id,optional"`
+	SubscriptionNumber string  `json:"subscriptionNumber,optional"`
+	CustomerId         int64   `json:"customerId,optional"`
+	OriginOrderId      int64   `json:"originOrderId,optional"`
+	OriginOrderNumber  string  `json:"originOrderNumber,optional"`
+	PendingOrderId     int64   `json:"pendingOrderId,optional"`
+	ProductName        string  `json:"productName,optional"`
+	Amount             float64 `json:"amount,optional"`
+	IntervalUnit       string  `json:"intervalUnit,optional"`
+	IntervalCount      int     `json:"intervalCount,optional"`
+	TotalCycles        int     `json:"totalCycles,optional"`
+	PaidCycles         int     `json:"paidCycles,optional"`
+	Status             string  `json:"status,optional"`
+	CurrentPeriodStart string  `json:"currentPeriodStart,optional"`
+	CurrentPeriodEnd   string  `json:"currentPeriodEnd,optional"`
+	NextChargeAt       string  `json:"nextChargeAt,optional"`
+	CancelAtPeriodEnd  bool    `json:"cancelAtPeriodEnd,optional"`
+	DunningAttempts    int     `json:"dunningAttempts,optional"`
+	NextRetryAt        string  `json:"nextRetryAt,optional"`
+	LastFailReason     string  `json:"lastFailReason,optional"`
+	PausedAt           string  `json:"pausedAt,optional"`
+	CancelledAt        string  `json:"cancelledAt,optional"`
+	EndedAt            string  `json:"endedAt,optional"`
+	CancelReason       string  `json:"cancelReason,optional"`
+	CreatedAt          string  `json:"createdAt,optional"`
+}
+
+type ListSubscriptionsPageRequest struct {
+	CustomerId         int64    `form:"customerId,optional"`
+	Statuses           []string `form:"statuses,optional"`
+	SubscriptionNumber string   `form:"subscriptionNumber,optional"`
+	PageIndex          int      `form:"pageIndex,optional"`
+	PageSize           int      `form:"pageSize,optional"`
+}
+
+type ListSubscriptionsPageReply struct {
+	List      []*Subscription `json:"list"`
+	PageIndex int             `json:"pageIndex"`
+	PageSize  int             `json:"pageSize"`
+	Total     int64           `json:"total"`
+}
+
+type GetSubscriptionRequest struct {
+	SubscriptionId int64 `path:"id"`
+}
+
+type GetSubscriptionReply struct {
+	*Subscription
+}
+
+type PauseSubscriptionRequest struct {
+	SubscriptionId int64 `path:"id"`
+}
+
+type PauseSubscriptionReply struct {
+	*Subscription
+}
+
+type ResumeSubscriptionRequest struct {
+	SubscriptionId int64 `path:"id"`
+}
+
+type ResumeSubscriptionReply struct {
+	*Subscription
+}
+
+type CancelSubscriptionRequest struct {
+	SubscriptionId int64  `path:"id"`
+	Immediately    bool   `json:"immediately,optional"`
+	Reason         string `json:"reason,optional"`
+}
+
+type CancelSubscriptionReply struct {
+	*Subscription
+}
+
+type ContractWayGroupNode struct {
+	Id        int64                  `json:"id"`
+	GroupName string                 `json:"groupName"`
+	Children  []ContractWayGroupNode `json:"children"`
+}
+
+type GetContractWayGroupTreeRequest struct {
+}
+
+type GetContractWayGroupTreeReply struct {
+	GroupTree ContractWayGroupNode `json:"tree"`
+}
+
+type ContractWayGroup struct {
+	Id        int64  `json:"id"`
+	GroupName string `json:"groupName"`
+}
+
+type GetContractWayGroupListRequest struct {
+	GroupName string `form:"groupName,optional"`
+}
+
+type GetContractWayGroupListReply struct {
+	Groups []ContractWayGroup `json:"groups"`
+}
+
+type GetContractWaysRequest struct {
+	EmployeeId int64  `form:"employeeId,optional"`
+	Name       string `form:"name,optional"`
+	StartDate  string `form:"startDate,optional"`
+	EndDate    string `form:"endDate,optional"`
+	PageIndex  int    `form:"pageIndex"`
+	PageSize   int    `form:"pageSize"`
+}
+
+type GetContractWaysReply struct {
+	List      []ContractWay `json:"list"`
+	PageIndex int           `json:"pageIndex"`
+	PageSize  int           `json:"pageSize"`
+	Total     int64         `json:"total"`
+}
+
+type ContractWay struct {
+	Id            int64    `json:"id"`
+	Type          int      `json:"type"`
+	Scene         int      `json:"scene"`
+	Style         string   `json:"style,optional"`
+	Remark        string   `json:"remark,optional"`
+	SkipVerify    bool     `json:"skipVerify,optional"`
+	State         string   `json:"state,optional"`
+	Users         []string `json:"users,optional"`
+	Parties       []int64  `json:"parties,optional"`
+	IsTemp        bool     `json:"isTemp,optional"`
+	ExpiresIn     int      `json:"expiresIn,optional"`
+	ChatExpiresIn int      `json:"chatExpiresIn,optional"`
+	UnionId       string   `json:"unionId,optional"`
+	IsExclusive   bool     `json:"isExclusive,optional"`
+	Conclusions   string   `json:"conclusions,optional"`
+}
+
+type CreateContractWayRequest struct {
+	Type          int      `json:"type"`
+	Scene         int      `json:"scene"`
+	Style         string   `json:"style,optional"`
+	Remark        string   `json:"remark,optional"`
+	SkipVerify    bool     `json:"skipVerify,optional"`
+	State         string   `json:"state,optional"`
+	Users         []string `json:"users,optional"`
+	Parties       []int64  `json:"parties,optional"`
+	IsTemp        bool     `json:"isTemp,optional"`
+	ExpiresIn     int      `json:"expiresIn,optional"`
+	ChatExpiresIn int      `json:"chatExpiresIn,optional"`
+	UnionId       string   `json:"unionId,optional"`
+	IsExclusive   bool     `json:"isExclusive,optional"`
+	Conclusions   string   `json:"conclusions,optional"`
+}
+
+type CreateContractWayReply struct {
+	Id int64 `json:"id"`
+}
+
+type UpdateContractWayRequest struct {
+	Id            int64    `path:"id"`
+	Type          int      `json:"type,optional"`
+	Scene         int      `json:"scene,optional"`
 	Style         string   `json:"style,optional"`
 	Remark        string   `json:"remark,optional"`
 	SkipVerify    bool     `json:"skipVerify,optional"`
@@ -2454,6 +3204,87 @@ type WeWorkDepartment struct {
 	RefDepartmentId  int64    `json:"refDepartmentId"`
 }
 
+type WeWorkEmployeeHandoverRequest struct {
+	UserId string `path:"userId"` // 离职/在职员工
+}
+
+type WeWorkEmployeeHandoverReply struct {
+	UserId     string                             `json:"userId"`
+	Customers  []*WeWorkEmployeeHandoverCustomer  `json:"customers"`
+	GroupChats []*WeWorkEmployeeHandoverGroupChat `json:"groupChats"`
+}
+
+type WeWorkEmployeeHandoverCustomer struct {
+	ExternalUserId string `json:"externalUserId"`
+	Remark         string `json:"remark"`
+	CreateTime     int    `json:"createTime"`
+}
+
+type WeWorkEmployeeHandoverGroupChat struct {
+	ChatId      string `json:"chatId"`
+	Name        string `json:"name"`
+	MemberCount int    `json:"memberCount"`
+}
+
+type TransferWeWorkEmployeeRequest struct {
+	UserId             string                        `path:"userId"`                                   // 原跟进员工
+	Resigned           bool                          `json:"resigned,optional"`                        // true:离职继承 false:在职继承
+	Strategy           string                        `json:"strategy,optional,options=manual|balance"` // manual:按指定接替人 balance:按接替人负载均衡
+	TakeoverUserIds    []string                      `json:"takeoverUserIds,optional"`                 // balance 时的接替人范围
+	Customers          []*TransferWeWorkEmployeeItem `json:"customers,optional"`                       // 为空且 balance 时分配全部客户
+	GroupChats         []*TransferWeWorkEmployeeItem `json:"groupChats,optional"`                      // 为空且 balance 时分配全部客户群
+	TransferSuccessMsg string                        `json:"transferSuccessMsg,optional"`              // 在职继承提示语
+	DisableEmployee    bool                          `json:"disableEmployee,optional"`                 // 同时禁用关联的系统员工
+}
+
+type TransferWeWorkEmployeeItem struct {
+	ExternalUserId string `json:"externalUserId,optional"`
+	ChatId         string `json:"chatId,optional"`
+	TakeoverUserId string `json:"takeoverUserId,optional"` // manual 时必填
+}
+
+type TransferWeWorkEmployeeReply struct {
+	Status         string `json:"status"`
+	CustomerCount  int    `json:"customerCount"`
+	GroupChatCount int    `json:"groupChatCount"`
+	FailedCount    int    `json:"failedCount"`
+}
+
+type ListWeWorkCustomerTransferRequest struct {
+	HandoverUserId string `json:"handoverUserId,optional"`
+	TakeoverUserId string `json:"takeoverUserId,optional"`
+	Type           int    `json:"type,optional"` // 1:客户 2:客户群
+	Status         int    `json:"status,optional"`
+	PageIndex      int    `form:"pageIndex,optional"`
+	PageSize       int    `form:"pageSize,optional"`
+}
+
+type ListWeWorkCustomerTransferReply struct {
+	List      []*WeWorkCustomerTransfer `json:"list"`
+	PageIndex int                       `json:"pageIndex"`
+	PageSize  int                       `json:"pageSize"`
+	Total     int64                     `json:"total"`
+}
+
+type WeWorkCustomerTransfer struct {
+	Id             int64  `json:"id"`
+	Type           int    `json:"type"` // 1:客户 2:客户群
+	Mode           int    `json:"mode"` // 1:在职继承 2:离职继承
+	HandoverUserId string `json:"handoverUserId"`
+	TakeoverUserId string `json:"takeoverUserId"`
+	ExternalUserId string `json:"externalUserId"`
+	ChatId         string `json:"chatId"`
+	Status         int    `json:"status"` // 1:接替完毕 2:等待接替 3:客户拒绝 4:接替成员客户达到上限 5:无接替记录 9:失败
+	TakeoverTime   int64  `json:"takeoverTime"`
+	Error          string `json:"error"`
+	CreatedAt      string `json:"createdAt"`
+}
+
+type SyncWeWorkCustomerTransferReply struct {
+	Status  string `json:"status"`
+	Updated int    `json:"updated"`
+}
+
 type AppGroupListRequest struct {
 	ChatId string `form:"chatId,optional"`
 }
@@ -2735,6 +3566,226 @@ type WeWorkAddMsgTemplateResponse struct {
 	MsgId    string   `json:"msgId"`
 }
 
+type SyncWeWorkCustomerGroupReply struct {
+	Status string `json:"status"`
+	Count  int    `json:"count"`
+}
+
+type ActionWeWorkCustomerGroupReply struct {
+	Status string `json:"status"`
+}
+
+type ListWeWorkCustomerGroupPageRequest struct {
+	Name      string   `json:"name,optional"`   // 群名
+	Owners    []string `json:"owners,optional"` // 群主
+	Tags      []string `json:"tags,optional"`   // 群标签(任一)
+	Status    int      `json:"status,optional"` // 1:正常 2:已解散
+	PageIndex int      `form:"pageIndex,optional"`
+	PageSize  int      `form:"pageSize,optional"`
+}
+
+type ListWeWorkCustomerGroupPageReply struct {
+	List      []*WeWorkCustomerGroup `json:"list"`
+	PageIndex int                    `json:"pageIndex"`
+	PageSize  int                    `json:"pageSize"`
+	Total     int64                  `json:"total"`
+}
+
+type WeWorkCustomerGroup struct {
+	ChatId      string   `json:"chatId"`
+	Name        string   `json:"name"`
+	Owner       string   `json:"owner"`
+	CreateTime  int      `json:"createTime"`
+	Notice      string   `json:"notice"`
+	AdminList   []string `json:"adminList"`
+	MemberCount int      `json:"memberCount"`
+	Status      int      `json:"status"`
+	Tags        []string `json:"tags"`
+}
+
+type WeWorkCustomerGroupMemberRequest struct {
+	ChatId string `path:"chatId"`
+	Status int    `form:"status,optional"` // 1:在群 2:已退群
+}
+
+type WeWorkCustomerGroupMemberReply struct {
+	List []*WeWorkCustomerGroupMember `json:"list"`
+}
+
+type WeWorkCustomerGroupMember struct {
+	UserId        string `json:"userId"`
+	Type          int    `json:"type"` // 1:企业成员 2:外部联系人
+	JoinTime      int    `json:"joinTime"`
+	JoinScene     int    `json:"joinScene"` // 1:直接邀请 2:邀请链接 3:扫描群二维码
+	State         string `json:"state"`     // 入群渠道(场景码)
+	Invitor       string `json:"invitor"`
+	GroupNickname string `json:"groupNickname"`
+	Name          string `json:"name"`
+	Status        int    `json:"status"`
+	QuitTime      int64  `json:"quitTime"`
+}
+
+type ListWeWorkCustomerGroupMemberLogRequest struct {
+	ChatId    string `json:"chatId,optional"`
+	Action    int    `json:"action,optional"` // 1:入群 2:退群
+	State     string `json:"state,optional"`  // 入群渠道(场景码)
+	StartTime int64  `json:"startTime,optional"`
+	EndTime   int64  `json:"endTime,optional"`
+	PageIndex int    `form:"pageIndex,optional"`
+	PageSize  int    `form:"pageSize,optional"`
+}
+
+type ListWeWorkCustomerGroupMemberLogReply struct {
+	List      []*WeWorkCustomerGroupMemberLog `json:"list"`
+	PageIndex int                             `json:"pageIndex"`
+	PageSize  int                             `json:"pageSize"`
+	Total     int64                           `json:"total"`
+}
+
+type WeWorkCustomerGroupMemberLog struct {
+	Id         int64  `json:"id"`
+	ChatId     string `json:"chatId"`
+	UserId     string `json:"userId"`
+	Type       int    `json:"type"`
+	Action     int    `json:"action"`
+	JoinScene  int    `json:"joinScene"`
+	QuitScene  int    `json:"quitScene"`
+	State      string `json:"state"`
+	ActionTime int64  `json:"actionTime"`
+}
+
+type ActionWeWorkCustomerGroupTagRequest struct {
+	ChatId string   `path:"chatId"`
+	Tags   []string `json:"tags"`
+}
+
+type SyncWeWorkCustomerGroupStatisticRequest struct {
+	Date string `json:"date,optional"` // YYYY-MM-DD, 默认昨天
+}
+
+type ListWeWorkCustomerGroupStatisticRequest struct {
+	Owners    []string `json:"owners,optional"`
+	StartDate string   `json:"startDate"`
+	EndDate   string   `json:"endDate"`
+}
+
+type ListWeWorkCustomerGroupStatisticReply struct {
+	List []*WeWorkCustomerGroupStatistic `json:"list"`
+}
+
+type WeWorkCustomerGroupStatistic struct {
+	Owner        string `json:"owner"`
+	Date         string `json:"date"`
+	NewChatCnt   int    `json:"newChatCnt"`
+	ChatTotal    int    `json:"chatTotal"`
+	ChatHasMsg   int    `json:"chatHasMsg"`
+	NewMemberCnt int    `json:"newMemberCnt"`
+	MemberTotal  int    `json:"memberTotal"`
+	MemberHasMsg int    `json:"memberHasMsg"` // 活跃成员
+	MsgTotal     int    `json:"msgTotal"`
+}
+
+type ListWeWorkCustomerWelcomeRuleRequest struct {
+	Name      string `json:"name,optional"`   // 规则名称
+	Qid       string `json:"qid,optional"`    // 场景码
+	State     string `json:"state,optional"`  // 联系我State
+	UserId    string `json:"userId,optional"` // 员工ID
+	Status    int    `json:"status,optional"` // 状态1：启用 2：禁用
+	PageIndex int    `form:"pageIndex,optional"`
+	PageSize  int    `form:"pageSize,optional"`
+}
+
+type ListWeWorkCustomerWelcomeRuleReply struct {
+	List      []*WeWorkCustomerWelcomeRule `json:"list"`
+	PageIndex int                          `json:"pageIndex"`
+	PageSize  int                          `json:"pageSize"`
+	Total     int64                        `json:"total"`
+}
+
+type WeWorkCustomerWelcomeRule struct {
+	Id             int64                            `json:"id"`
+	Name           string                           `json:"name"`           // 规则名称
+	Qid            string                           `json:"qid"`            // 场景码Qid
+	State          string                           `json:"state"`          // 联系我State
+	UserId         string                           `json:"userId"`         // 员工ID
+	Priority       int                              `json:"priority"`       // 优先级
+	Text           string                           `json:"text"`           // 欢迎语文本
+	AttachmentType int                              `json:"attachmentType"` // 附件类型 0:无 1:图片 2:小程序 3:链接
+	ResourceId     int64                            `json:"resourceId"`     // 图片资源ID
+	Link           WeWorkCustomerWelcomeLink        `json:"link"`           // 链接
+	MiniProgram    WeWorkCustomerWelcomeMiniProgram `json:"miniProgram"`    // 小程序
+	TagIds         []string                         `json:"tagIds"`         // 自动打标签
+	GroupQid       string                           `json:"groupQid"`       // 邀请入群的群活码
+	Status         int                              `json:"status"`         // 状态1：启用 2：禁用
+	CreatedAt      string                           `json:"createdAt"`
+}
+
+type WeWorkCustomerWelcomeLink struct {
+	Title  string `json:"title,optional"`
+	PicUrl string `json:"picUrl,optional"`
+	Desc   string `json:"desc,optional"`
+	Url    string `json:"url,optional"`
+}
+
+type WeWorkCustomerWelcomeMiniProgram struct {
+	AppId      string `json:"appId,optional"`
+	Title      string `json:"title,optional"`
+	Page       string `json:"page,optional"`
+	PicMediaId string `json:"picMediaId,optional"`
+}
+
+type WeWorkCustomerWelcomeRuleRequest struct {
+	Id             int64                            `path:"id,optional"`                             // 更新操作使用
+	Name           string                           `json:"name"`                                    // 规则名称
+	Qid            string                           `json:"qid,optional"`                            // 场景码Qid
+	State          string                           `json:"state,optional"`                          // 联系我State
+	UserId         string                           `json:"userId,optional"`                         // 员工ID
+	Priority       int                              `json:"priority,optional"`                       // 优先级
+	Text           string                           `json:"text,optional"`                           // 欢迎语文本
+	AttachmentType int                              `json:"attachmentType,optional,options=0|1|2|3"` // 附件类型 0:无 1:图片 2:小程序 3:链接
+	ResourceId     int64                            `json:"resourceId,optional"`                     // 图片资源ID
+	Link           WeWorkCustomerWelcomeLink        `json:"link,optional"`                           // 链接
+	MiniProgram    WeWorkCustomerWelcomeMiniProgram `json:"miniProgram,optional"`                    // 小程序
+	TagIds         []string                         `json:"tagIds,optional"`                         // 自动打标签
+	GroupQid       string                           `json:"groupQid,optional"`                       // 邀请入群的群活码
+}
+
+type ActionWeWorkCustomerWelcomeRuleRequest struct {
+	Id int64 `path:"id"`
+}
+
+type ActionWeWorkCustomerWelcomeRuleReply struct {
+	Status string `json:"status"`
+}
+
+type ListWeWorkCustomerWelcomeLogRequest struct {
+	RuleId         int64  `json:"ruleId,optional"`
+	ExternalUserId string `json:"externalUserId,optional"`
+	UserId         string `json:"userId,optional"`
+	PageIndex      int    `form:"pageIndex,optional"`
+	PageSize       int    `form:"pageSize,optional"`
+}
+
+type ListWeWorkCustomerWelcomeLogReply struct {
+	List      []*WeWorkCustomerWelcomeLog `json:"list"`
+	PageIndex int                         `json:"pageIndex"`
+	PageSize  int                         `json:"pageSize"`
+	Total     int64                       `json:"total"`
+}
+
+type WeWorkCustomerWelcomeLog struct {
+	Id             int64  `json:"id"`
+	RuleId         int64  `json:"ruleId"`
+	ExternalUserId string `json:"externalUserId"`
+	UserId         string `json:"userId"`
+	State          string `json:"state"`
+	WelcomeStatus  int    `json:"welcomeStatus"` // 1:成功 2:失败 3:跳过
+	TagStatus      int    `json:"tagStatus"`
+	GroupStatus    int    `json:"groupStatus"`
+	Error          string `json:"error"`
+	CreatedAt      string `json:"createdAt"`
+}
+
 type GroupRobotMsgNewsArticlesRequest struct {
 	Key         string `json:"key"` // 机器人key
 	Title       string `json:"title"`
@@ -2747,6 +3798,18 @@ type GroupRobotMsgNewsArticlesReply struct {
 	Messaage string `json:"messaage"`
 }
 
+type WorkNoticeRequest struct {
+	UserIds []string `json:"userIds"` // 接收员工ID
+	Title   string   `json:"title,optional"`
+	Content string   `json:"content"`
+	Url     string   `json:"url,optional"`
+}
+
+type WorkNoticeReply struct {
+	Provider string `json:"provider"` // 当前服务商 wework/dingtalk
+	Status   string `json:"status"`
+}
+
 type CreateWeWorkSourceImageReply struct {
 	Link string `json:"link"`
 }
@@ -2823,6 +3886,50 @@ type ActionRequest struct {
 	SceneQrcodeLink string `json:"sceneQrcodeLink,optional"`
 }
 
+type SceneQrcodeAnalyticsRequest struct {
+	Qid       string `path:"qid"`
+	StartDate string `form:"startDate,optional"` // YYYY-MM-DD, 默认近7天
+	EndDate   string `form:"endDate,optional"`   // YYYY-MM-DD, 默认今天
+}
+
+type SceneQrcodeAnalyticsReply struct {
+	Qid                     string                       `json:"qid"`
+	TotalScanCount          int                          `json:"totalScanCount"`          // 扫码次数
+	TotalUniqueVisitorCount int                          `json:"totalUniqueVisitorCount"` // 独立访客
+	TotalAddedCount         int                          `json:"totalAddedCount"`         // 添加客户数
+	ConversionRate          float64                      `json:"conversionRate"`          // 添加客户数/独立访客
+	Series                  []*SceneQrcodeDailyAnalytics `json:"series"`
+}
+
+type SceneQrcodeDailyAnalytics struct {
+	Date               string `json:"date"`
+	ScanCount          int    `json:"scanCount"`
+	UniqueVisitorCount int    `json:"uniqueVisitorCount"`
+	AddedCount         int    `json:"addedCount"`
+}
+
+type SceneQrcodeCandidateRequest struct {
+	Qid string `path:"qid"`
+}
+
+type SceneQrcodeCandidateReply struct {
+	List []*SceneQrcodeCandidate `json:"list"`
+}
+
+type SceneQrcodeCandidate struct {
+	Id        int64  `json:"id,optional"`
+	Link      string `json:"link"`               // 群二维码图片
+	ChatId    string `json:"chatId,optional"`    // 客户群ID(用于人数上限判断)
+	MemberCap int    `json:"memberCap,optional"` // 群人数上限(默认200)
+	ScanCount int    `json:"scanCount,optional"` // 已展示次数
+	State     int    `json:"state,optional"`     // 1:等待 2:使用中 3:已满
+}
+
+type ReplaceSceneQrcodeCandidateRequest struct {
+	Qid  string                  `path:"qid"`
+	List []*SceneQrcodeCandidate `json:"list"`
+}
+
 type ListWeWorkTagGroupPageRequest struct {
 	GroupId   string `json:"groupId,optional"`
 	GroupName string `json:"groupName,optional"`
@@ -2939,6 +4046,7 @@ type OASubButton struct {
 	Url      string `json:"url,omitempty,optional"`
 	AppID    string `json:"appid,omitempty,optional"`
 	PagePath string `json:"pagepath,omitempty,optional"`
+	MediaId  string `json:"media_id,omitempty,optional"`
 }
 
 type OAButton struct {
@@ -2948,21 +4056,25 @@ type OAButton struct {
 	Url         string         `json:"url,omitempty,optional"`
 	AppID       string         `json:"appid,omitempty,optional"`
 	PagePath    string         `json:"pagepath,omitempty,optional"`
+	MediaId     string         `json:"media_id,omitempty,optional"`
 	OASubButton []*OASubButton `json:"sub_button,optional"`
 	Id          int            `json:"id,optional"`
 }
 
 type MatchRule struct {
-	TagId    string `json:"tag_id,optional"`
-	Sex      string `json:"sex,optional"`
-	Country  string `json:"country,optional"`
-	Province string `json:"province,optional"`
-	City     string `json:"city,optional"`
-	Language string `json:"language,optional"`
+	TagId              string `json:"tag_id,optional"`
+	Sex                string `json:"sex,optional"`
+	Country            string `json:"country,optional"`
+	Province           string `json:"province,optional"`
+	City               string `json:"city,optional"`
+	Language           string `json:"language,optional"`
+	ClientPlatformType string `json:"client_platform_type,optional"`
 }
 
 type OAMenu struct {
 	Id        int64       `json:"id,optional"`
+	Name      string      `json:"name,optional"`
+	Remark    string      `json:"remark,optional"`
 	OAButton  []*OAButton `json:"button,optional"`
 	MatchRule *MatchRule  `json:"matchrule,optional"`
 }
@@ -2995,6 +4107,94 @@ type DeleteMenuReply struct {
 	Data    interface{} `json:"data"`
 }
 
+type OAMenuDraft struct {
+	Id               int64      `json:"id"`
+	Name             string     `json:"name"`
+	MenuType         int8       `json:"menuType"` // 1:默认 2:个性化
+	MatchRule        *MatchRule `json:"matchrule"`
+	DraftVersion     int        `json:"draftVersion"`
+	PublishedVersion int        `json:"publishedVersion"`
+	WechatMenuId     string     `json:"wechatMenuId"`
+	PublishedAt      string     `json:"publishedAt"`
+	CreatedAt        string     `json:"createdAt"`
+}
+
+type OAMenuVersion struct {
+	Version   int         `json:"version"`
+	Remark    string      `json:"remark"`
+	OAButton  []*OAButton `json:"button"`
+	CreatedAt string      `json:"createdAt"`
+}
+
+type ListOAMenuDraftsReply struct {
+	List []*OAMenuDraft `json:"list"`
+}
+
+type GetOAMenuDraftRequest struct {
+	Id int64 `path:"id"`
+}
+
+type GetOAMenuDraftReply struct {
+	*OAMenuDraft
+	Versions []*OAMenuVersion `json:"versions"`
+}
+
+type UpdateOAMenuDraftRequest struct {
+	MenuId int64 `path:"id"`
+	OAMenu
+}
+
+type UpdateOAMenuDraftReply struct {
+	Id           int64 `json:"id"`
+	DraftVersion int   `json:"draftVersion"`
+}
+
+type PublishOAMenuDraftRequest struct {
+	Id      int64 `path:"id"`
+	Version int   `json:"version,optional"` // 不传发布当前草稿
+}
+
+type PublishOAMenuDraftReply struct {
+	Id               int64 `json:"id"`
+	PublishedVersion int   `json:"publishedVersion"`
+}
+
+type RollbackOAMenuDraftRequest struct {
+	Id      int64 `path:"id"`
+	Version int   `json:"version"`
+	Publish bool  `json:"publish,optional"` // 回滚后立即发布
+}
+
+type RollbackOAMenuDraftReply struct {
+	Id               int64 `json:"id"`
+	DraftVersion     int   `json:"draftVersion"`
+	PublishedVersion int   `json:"publishedVersion"`
+}
+
+type DiffOAMenuDraftRequest struct {
+	Id int64 `path:"id"`
+}
+
+type OAMenuDiff struct {
+	Path   string `json:"path"`
+	Action string `json:"action"` // added/removed/changed
+	Remote string `json:"remote"`
+	Local  string `json:"local"`
+}
+
+type DiffOAMenuDraftReply struct {
+	Same bool          `json:"same"`
+	List []*OAMenuDiff `json:"list"`
+}
+
+type DeleteOAMenuDraftRequest struct {
+	Id int64 `path:"id"`
+}
+
+type DeleteOAMenuDraftReply struct {
+	Id int64 `json:"id"`
+}
+
 type GetOAMediaListRequest struct {
 	Offset    int64  `json:"offset,optional"`
 	Count     int64  `json:"count,optional"`
@@ -3043,6 +4243,243 @@ type DeleteOAMediaReply struct {
 	Data    interface{} `json:"data"`
 }
 
+type OAAutoReplyRule struct {
+	Id          int64  `json:"id,optional"`
+	Name        string `json:"name"`
+	MatchType   int8   `json:"matchType,options=1|2|3|4"` // 1:关键词全匹配 2:关键词半匹配 3:关注回复 4:菜单点击
+	Keyword     string `json:"keyword,optional"`          // 关键词/菜单Key
+	ReplyType   string `json:"replyType,options=text|image|news|miniprogrampage"`
+	Content     string `json:"content,optional"`
+	MediaId     string `json:"mediaId,optional"` // 图片MediaId/小程序封面MediaId
+	Title       string `json:"title,optional"`
+	Description string `json:"description,optional"`
+	Url         string `json:"url,optional"`
+	PicUrl      string `json:"picUrl,optional"`
+	AppId       string `json:"appId,optional"`
+	PagePath    string `json:"pagePath,optional"`
+	Priority    int    `json:"priority,optional"`
+	Status      int8   `json:"status,optional,options=0|1|2"` // 1:启用 2:禁用
+	CreatedAt   string `json:"createdAt,optional"`
+}
+
+type ListOAAutoReplyRulesPageRequest struct {
+	MatchTypes []int8 `form:"matchTypes,optional"`
+	Status     int8   `form:"status,optional"`
+	LikeName   string `form:"likeName,optional"`
+	PageIndex  int    `form:"pageIndex,optional"`
+	PageSize   int    `form:"pageSize,optional"`
+}
+
+type ListOAAutoReplyRulesPageReply struct {
+	List      []*OAAutoReplyRule `json:"list"`
+	PageIndex int                `json:"pageIndex"`
+	PageSize  int                `json:"pageSize"`
+	Total     int64              `json:"total"`
+}
+
+type CreateOAAutoReplyRuleRequest struct {
+	OAAutoReplyRule
+}
+
+type CreateOAAutoReplyRuleReply struct {
+	Id int64 `json:"id"`
+}
+
+type UpdateOAAutoReplyRuleRequest struct {
+	RuleId int64 `path:"id"`
+	OAAutoReplyRule
+}
+
+type UpdateOAAutoReplyRuleReply struct {
+	Id int64 `json:"id"`
+}
+
+type DeleteOAAutoReplyRuleRequest struct {
+	Id int64 `path:"id"`
+}
+
+type DeleteOAAutoReplyRuleReply struct {
+	Id int64 `json:"id"`
+}
+
+type OAQrcode struct {
+	Id            int64  `json:"id"`
+	Name          string `json:"name"`
+	Channel       string `json:"channel"`
+	Campaign      string `json:"campaign"`
+	QrType        int8   `json:"qrType"` // 1:临时 2:永久
+	SceneStr      string `json:"sceneStr"`
+	ExpireSeconds int    `json:"expireSeconds"`
+	Ticket        string `json:"ticket"`
+	Url           string `json:"url"`      // 二维码解析后的地址
+	ImageUrl      string `json:"imageUrl"` // 二维码图片
+	ExpiredAt     string `json:"expiredAt"`
+	Expired       bool   `json:"expired"`
+	CreatedAt     string `json:"createdAt"`
+}
+
+type ListOAQrcodesPageRequest struct {
+	Channel   string `form:"channel,optional"`
+	Campaign  string `form:"campaign,optional"`
+	QrType    int8   `form:"qrType,optional"`
+	LikeName  string `form:"likeName,optional"`
+	PageIndex int    `form:"pageIndex,optional"`
+	PageSize  int    `form:"pageSize,optional"`
+}
+
+type ListOAQrcodesPageReply struct {
+	List      []*OAQrcode `json:"list"`
+	PageIndex int         `json:"pageIndex"`
+	PageSize  int         `json:"pageSize"`
+	Total     int64       `json:"total"`
+}
+
+type CreateOAQrcodeRequest struct {
+	Name          string `json:"name"`
+	Channel       string `json:"channel,optional"`
+	Campaign      string `json:"campaign,optional"`
+	QrType        int8   `json:"qrType,options=1|2"`     // 1:临时 2:永久
+	SceneStr      string `json:"sceneStr,optional"`      // 不传自动生成, 最长64字符
+	ExpireSeconds int    `json:"expireSeconds,optional"` // 临时码有效期, 最长30天
+}
+
+type CreateOAQrcodeReply struct {
+	*OAQrcode
+}
+
+type DeleteOAQrcodeRequest struct {
+	Id int64 `path:"id"`
+}
+
+type DeleteOAQrcodeReply struct {
+	Id int64 `json:"id"`
+}
+
+type GetOAQrcodeStatisticsRequest struct {
+	Id        int64  `path:"id"`
+	StartDate string `form:"startDate,optional"` // YYYY-MM-DD, 默认近7天
+	EndDate   string `form:"endDate,optional"`
+}
+
+type OAQrcodeDailyStatistics struct {
+	Date         string `json:"date"`
+	Scans        int    `json:"scans"`        // 扫码次数(含扫码关注)
+	Subscribes   int    `json:"subscribes"`   // 扫码关注
+	NewFans      int    `json:"newFans"`      // 首次关注
+	Unsubscribes int    `json:"unsubscribes"` // 取关
+	Retained     int    `json:"retained"`     // 当日关注且当前仍关注
+}
+
+type GetOAQrcodeStatisticsReply struct {
+	Scans         int                        `json:"scans"`
+	Subscribes    int                        `json:"subscribes"`
+	NewFans       int                        `json:"newFans"`
+	Unsubscribes  int                        `json:"unsubscribes"`
+	Retained      int                        `json:"retained"`
+	RetentionRate float64                    `json:"retentionRate"`
+	Daily         []*OAQrcodeDailyStatistics `json:"daily"`
+}
+
+type NotificationTemplate struct {
+	Id                  int64             `json:"id,optional"`
+	Name                string            `json:"name"`
+	Event               string            `json:"event,options=order_paid|order_shipped|order_refunded|order_renewal_failed"`
+	Channel             string            `json:"channel,options=mp_subscribe|oa_template"`
+	TemplateId          string            `json:"templateId"`
+	Page                string            `json:"page,optional"`             // 小程序页面/公众号跳转链接, 支持{{变量}}
+	MiniProgramAppId    string            `json:"miniProgramAppId,optional"` // 公众号模板消息跳转小程序
+	MiniProgramPagePath string            `json:"miniProgramPagePath,optional"`
+	FieldMappings       map[string]string `json:"fieldMappings"`                 // 模板字段 -> 取值, 可用变量: orderId orderNumber amount listPrice productName quantity carrier trackingCode customerName comment createdAt time refundAmount refundReason retryAt failReason
+	Status              int8              `json:"status,optional,options=0|1|2"` // 1:启用 2:禁用
+	CreatedAt           string            `json:"createdAt,optional"`
+}
+
+type ListNotificationTemplatesPageRequest struct {
+	Events    []string `form:"events,optional"`
+	Channel   string   `form:"channel,optional"`
+	Status    int8     `form:"status,optional"`
+	LikeName  string   `form:"likeName,optional"`
+	PageIndex int      `form:"pageIndex,optional"`
+	PageSize  int      `form:"pageSize,optional"`
+}
+
+type ListNotificationTemplatesPageReply struct {
+	List      []*NotificationTemplate `json:"list"`
+	PageIndex int                     `json:"pageIndex"`
+	PageSize  int                     `json:"pageSize"`
+	Total     int64                   `json:"total"`
+}
+
+type CreateNotificationTemplateRequest struct {
+	NotificationTemplate
+}
+
+type CreateNotificationTemplateReply struct {
+	Id int64 `json:"id"`
+}
+
+type UpdateNotificationTemplateRequest struct {
+	TemplateId int64 `path:"id"`
+	NotificationTemplate
+}
+
+type UpdateNotificationTemplateReply struct {
+	Id int64 `json:"id"`
+}
+
+type DeleteNotificationTemplateRequest struct {
+	Id int64 `path:"id"`
+}
+
+type DeleteNotificationTemplateReply struct {
+	Id int64 `json:"id"`
+}
+
+type NotificationLog struct {
+	Id                     int64  `json:"id"`
+	NotificationTemplateId int64  `json:"notificationTemplateId"`
+	Event                  string `json:"event"`
+	Channel                string `json:"channel"`
+	TemplateId             string `json:"templateId"`
+	CustomerId             int64  `json:"customerId"`
+	OrderId                int64  `json:"orderId"`
+	OpenId                 string `json:"openId"`
+	Page                   string `json:"page"`
+	Data                   string `json:"data"`
+	Status                 int8   `json:"status"` // 1:待发送 2:发送中 3:已发送 4:失败待重试 5:失败 6:跳过
+	Attempts               int    `json:"attempts"`
+	LastError              string `json:"lastError"`
+	MsgId                  string `json:"msgId"`
+	NextRetryAt            string `json:"nextRetryAt"`
+	SentAt                 string `json:"sentAt"`
+	CreatedAt              string `json:"createdAt"`
+}
+
+type ListNotificationLogsPageRequest struct {
+	Event      string `form:"event,optional"`
+	Channel    string `form:"channel,optional"`
+	Statuses   []int8 `form:"statuses,optional"`
+	CustomerId int64  `form:"customerId,optional"`
+	OrderId    int64  `form:"orderId,optional"`
+	PageIndex  int    `form:"pageIndex,optional"`
+	PageSize   int    `form:"pageSize,optional"`
+}
+
+type ListNotificationLogsPageReply struct {
+	List      []*NotificationLog `json:"list"`
+	PageIndex int                `json:"pageIndex"`
+	PageSize  int                `json:"pageSize"`
+	Total     int64              `json:"total"`
+}
+
+type ResendNotificationLogRequest struct {
+	Id int64 `path:"id"`
+}
+
+type ResendNotificationLogReply struct {
+	Id int64 `json:"id"`
+}
+
 type MPCustomerLoginRequest struct {
 	Code string `json:"code"`
 }
@@ -3081,19 +4518,21 @@ type ListProductCategoriesReply struct {
 }
 
 type ProductStatistics struct {
-	Id                    int64 `json:"id,optional"`
-	ProductId             int64 `json:"productId"`
-	SoldAmount            int64 `json:"soldAmount,optional"`
-	InventoryQuantity     int64 `json:"inventoryQuantity,optional"`
-	ViewCount             int64 `json:"viewCount,optional"`
-	BaseSoldAmount        int64 `json:"baseSoldAmount,optional"`
-	BaseInventoryQuantity int64 `json:"baseInventoryQuantity,optional"`
-	BaseViewCount         int64 `json:"baseViewCount,optional"`
+	Id                    int64   `json:"id,optional"`
+	ProductId             int64   `json:"productId"`
+	SoldAmount            int64   `json:"soldAmount,optional"`
+	InventoryQuantity     int64   `json:"inventoryQuantity,optional"`
+	ViewCount             int64   `json:"viewCount,optional"`
+	BaseSoldAmount        int64   `json:"baseSoldAmount,optional"`
+	BaseInventoryQuantity int64   `json:"baseInventoryQuantity,optional"`
+	BaseViewCount         int64   `json:"baseViewCount,optional"`
+	ReviewCount           int64   `json:"reviewCount,optional"`
+	AverageRating         float64 `json:"averageRating,optional"`
 }
 
 type ListProductStatisticsPageRequest struct {
 	LikeName  string `form:"likeName,optional"`
-	ProductId int64  `form:"productId"`
+	ProductId int64  `form:"productId,optional"`
 	OrderBy   string `form:"orderBy,optional"`
 	PageIndex int    `form:"pageIndex,optional"`
 	PageSize  int    `form:"pageSize,optional"`
@@ -3148,6 +4587,147 @@ type DeleteProductStatisticsReply struct {
 	ProductStatisticsId int64 `json:"id"`
 }
 
+type ProductStatisticsSnapshot struct {
+	SnapshotDate      string  `json:"snapshotDate"`
+	SoldAmount        int64   `json:"soldAmount"`
+	InventoryQuantity int64   `json:"inventoryQuantity"`
+	ViewCount         int64   `json:"viewCount"`
+	ReviewCount       int64   `json:"reviewCount"`
+	AverageRating     float64 `json:"averageRating"`
+	DailySoldAmount   int64   `json:"dailySoldAmount"`
+	DailyViewCount    int64   `json:"dailyViewCount"`
+}
+
+type ListProductStatisticsSnapshotsRequest struct {
+	ProductId int64  `path:"id"`
+	StartDate string `form:"startDate,optional"`
+	EndDate   string `form:"endDate,optional"`
+}
+
+type ListProductStatisticsSnapshotsReply struct {
+	ProductId             int64                        `json:"productId"`
+	BaseSoldAmount        int64                        `json:"baseSoldAmount"`
+	BaseInventoryQuantity int64                        `json:"baseInventoryQuantity"`
+	BaseViewCount         int64                        `json:"baseViewCount"`
+	List                  []*ProductStatisticsSnapshot `json:"list"`
+}
+
+type SyncProductStatisticsInventoryRequest struct {
+	ProductId int64 `path:"id"`
+}
+
+type SyncProductStatisticsInventoryReply struct {
+	*ProductStatistics
+}
+
+type SearchProductsRequest struct {
+	Keyword         string   `form:"keyword,optional"`
+	CategoryIds     []int64  `form:"categoryIds,optional"`
+	SpecificOptions []string `form:"specificOptions,optional"`
+	SalesChannelId  int64    `form:"salesChannelId,optional"`
+	MinPrice        float64  `form:"minPrice,optional"`
+	MaxPrice        float64  `form:"maxPrice,optional"`
+	SortBy          string   `form:"sortBy,optional,options=price_asc|price_desc|newest"`
+	PageIndex       int      `form:"pageIndex,optional"`
+	PageSize        int      `form:"pageSize,optional"`
+}
+
+type ProductSearchFacet struct {
+	Key   string `json:"key"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+type ProductSearchFacets struct {
+	Categories      []ProductSearchFacet `json:"categories"`
+	PriceBands      []ProductSearchFacet `json:"priceBands"`
+	SpecificOptions []ProductSearchFacet `json:"specificOptions"`
+}
+
+type SearchProductsReply struct {
+	List      []Product           `json:"list"`
+	PageIndex int                 `json:"pageIndex"`
+	PageSize  int                 `json:"pageSize"`
+	Total     int64               `json:"total"`
+	Facets    ProductSearchFacets `json:"facets"`
+}
+
+type SuggestProductsRequest struct {
+	Keyword string `form:"keyword"`
+	Limit   int    `form:"limit,optional"`
+}
+
+type ProductSearchSuggestion struct {
+	Type string `json:"type"`
+	Id   int64  `json:"id"`
+	Text string `json:"text"`
+}
+
+type SuggestProductsReply struct {
+	List []ProductSearchSuggestion `json:"list"`
+}
+
+type ListMPProductReviewsPageRequest struct {
+	ProductId  int64  `path:"id"`
+	Rating     int    `form:"rating,optional"`
+	WithImages bool   `form:"withImages,optional"`
+	SortBy     string `form:"sortBy,optional"`
+	PageIndex  int    `form:"pageIndex,optional"`
+	PageSize   int    `form:"pageSize,optional"`
+}
+
+type CreateProductReviewRequest struct {
+	OrderItemId int64   `json:"orderItemId"`
+	Rating      int     `json:"rating"`
+	Content     string  `json:"content,optional"`
+	IsAnonymous bool    `json:"isAnonymous,optional"`
+	ImageIds    []int64 `json:"imageIds,optional"`
+}
+
+type CreateProductReviewReply struct {
+	*ProductReview
+}
+
+type UploadProductReviewImageReply struct {
+	*MediaResource
+}
+
+type MarkProductReviewHelpfulRequest struct {
+	ProductReviewId int64 `path:"id"`
+}
+
+type MarkProductReviewHelpfulReply struct {
+	*ProductReview
+}
+
+type ListBookingSlotsRequest struct {
+	StoreId   int64  `path:"id"`
+	ArtisanId int64  `path:"artisanId"`
+	Date      string `form:"date"`
+}
+
+type ListBookingSlotsReply struct {
+	List []*BookingTimeSlot `json:"list"`
+}
+
+type CreateBookingRequest struct {
+	StoreId     int64  `json:"storeId"`
+	ArtisanId   int64  `json:"artisanId"`
+	OrderItemId int64  `json:"orderItemId"`
+	StartAt     string `json:"startAt"`
+	Comment     string `json:"comment,optional"`
+}
+
+type CreateBookingReply struct {
+	Booking *Booking `json:"booking"`
+}
+
+type ListMPBookingsPageRequest struct {
+	Statuses  []string `form:"statuses,optional"`
+	PageIndex int      `form:"pageIndex,optional"`
+	PageSize  int      `form:"pageSize,optional"`
+}
+
 type Cart struct {
 	Id         int64       `json:"id", optional"`
 	CustomerId int64       `json:"customerId", optional"`
@@ -3222,6 +4802,7 @@ type ClearCartItemsReply struct {
 }
 
 type CreateOrderByProductsRequest struct {
+	StoreId           int64   `json:"storeId,optional"`
 	PriceBookId       int64   `json:"PriceBookId,optional,emptyomit"`
 	ProductIds        []int64 `json:"productIds"`
 	SkuIds            []int64 `json:"skuIds"`
@@ -3236,6 +4817,7 @@ type CreateOrderByProductsReply struct {
 }
 
 type CreateOrderByCartItemsRequest struct {
+	StoreId           int64   `json:"storeId,optional"`
 	CartItemIds       []int64 `json:"cartItemIds"`
 	ShippingAddressId int64   `json:"shippingAddressId"`
 	Comment           string  `json:"comment"`
@@ -3256,51 +4838,69 @@ type CancelOrderReply struct {
 }
 
 type OrderItem struct {
-	Id               int64          `json:"id,optional"`
-	OrderId          int64          `json:"orderId,optional"`
-	PriceBookEntryId int64          `json:"priceBookEntryId,optional"`
-	CustomerId       int64          `json:"customerId,optional"`
-	Type             int            `json:"type,optional"`
-	Status           int            `json:"status,optional"`
-	Quantity         int            `json:"quantity,optional"`
-	UnitPrice        float64        `json:"unitPrice,optional"`
-	ListPrice        float64        `json:"listPrice,optional"`
-	SellingPrice     float64        `json:"sellingPrice,optional"`
-	CoverImage       *MediaResource `json:"coverImage,optional"`
-	ProductName      string         `json:"productName,optional"`
-	SkuNo            string         `json:"skuNo,optional"`
+	Id               int64                 `json:"id,optional"`
+	OrderId          int64                 `json:"orderId,optional"`
+	PriceBookEntryId int64                 `json:"priceBookEntryId,optional"`
+	CustomerId       int64                 `json:"customerId,optional"`
+	Type             int                   `json:"type,optional"`
+	Status           int                   `json:"status,optional"`
+	Quantity         int                   `json:"quantity,optional"`
+	UnitPrice        float64               `json:"unitPrice,optional"`
+	ListPrice        float64               `json:"listPrice,optional"`
+	SellingPrice     float64               `json:"sellingPrice,optional"`
+	CoverImage       *MediaResource        `json:"coverImage,optional"`
+	ProductName      string                `json:"productName,optional"`
+	SkuNo            string                `json:"skuNo,optional"`
+	Components       []*OrderItemComponent `json:"components,optional"`
+}
+
+type OrderItemComponent struct {
+	Id              int64   `json:"id,optional"`
+	OrderItemId     int64   `json:"orderItemId,optional"`
+	ProductId       int64   `json:"productId,optional"`
+	SkuId           int64   `json:"skuId,optional"`
+	ProductName     string  `json:"productName,optional"`
+	SkuNo           string  `json:"skuNo,optional"`
+	Quantity        int     `json:"quantity,optional"`
+	AllocatedAmount float64 `json:"allocatedAmount,optional"`
 }
 
 type Order struct {
-	Id             int64        `json:"id,optional"`
-	CustomerId     int64        `json:"customerId,optional"`
-	CartId         int64        `json:"cartId,optional"`
-	PaymentType    int          `json:"paymentType,optional"`
-	Type           int          `json:"type,optional"`
-	Status         int          `json:"status,optional"`
-	OrderNumber    string       `json:"orderNumber,optional"`
-	Discount       float64      `json:"discount,optional"`
-	ListPrice      float64      `json:"listPrice,optional"`
-	UnitPrice      float64      `json:"unitPrice,optional"`
-	Comment        string       `json:"comment,optional"`
-	CompletedAt    string       `json:"completedAt,optional,omitempty"`
-	CancelledAt    string       `json:"cancelledAt,optional,omitempty"`
-	ShippingMethod string       `json:"shippingMethod,optional,omitempty"`
-	CreatedAt      string       `json:"createdAt,optional,omitempty"`
-	OrderItems     []*OrderItem `json:"orderItems,optional"`
-	Payments       []*Payment   `json:"payments,optional"`
-	Logistics      *Logistics   `json:"logistics,optional"`
+	Id                int64        `json:"id,optional"`
+	CustomerId        int64        `json:"customerId,optional"`
+	CartId            int64        `json:"cartId,optional"`
+	StoreId           int64        `json:"storeId,optional"`
+	SubscriptionId    int64        `json:"subscriptionId,optional"`
+	PaymentType       int          `json:"paymentType,optional"`
+	Type              int          `json:"type,optional"`
+	Status            int          `json:"status,optional"`
+	OrderNumber       string       `json:"orderNumber,optional"`
+	Discount          float64      `json:"discount,optional"`
+	ListPrice         float64      `json:"listPrice,optional"`
+	UnitPrice         float64      `json:"unitPrice,optional"`
+	PaidAmount        float64      `json:"paidAmount,optional"`
+	OutstandingAmount float64      `json:"outstandingAmount,optional"`
+	Comment           string       `json:"comment,optional"`
+	CompletedAt       string       `json:"completedAt,optional,omitempty"`
+	CancelledAt       string       `json:"cancelledAt,optional,omitempty"`
+	ShippingMethod    string       `json:"shippingMethod,optional,omitempty"`
+	CreatedAt         string       `json:"createdAt,optional,omitempty"`
+	OrderItems        []*OrderItem `json:"orderItems,optional"`
+	Payments          []*Payment   `json:"payments,optional"`
+	Logistics         *Logistics   `json:"logistics,optional"`
 }
 
 type ListOrdersPageRequest struct {
-	TypeIds   []int  `form:"typeIds,optional,omitempty"`
-	StatusIds []int  `form:"statusIds,optional,omitempty"`
-	Name      string `form:"name,optional,omitempty"`
-	StartAt   string `form:"startAt,optional,omitempty"`
-	EndAt     string `form:"endAt,optional,omitempty"`
-	OrderBy   string `form:"orderBy,optional,omitempty"`
-	PageIndex int    `form:"pageIndex,optional,omitempty"`
-	PageSize  int    `form:"pageSize,optional,omitempty"`
+	TypeIds   []int   `form:"typeIds,optional,omitempty"`
+	StatusIds []int   `form:"statusIds,optional,omitempty"`
+	StoreIds  []int64 `form:"storeIds,optional,omitempty"`
+	Name      string  `form:"name,optional,omitempty"`
+	StartAt   string  `form:"startAt,optional,omitempty"`
+	EndAt     string  `form:"endAt,optional,omitempty"`
+	OrderBy   string  `form:"orderBy,optional,omitempty"`
+	Filters   string  `form:"filters,optional,omitempty"`
+	PageIndex int     `form:"pageIndex,optional,omitempty"`
+	PageSize  int     `form:"pageSize,optional,omitempty"`
 }
 
 type ListOrdersPageReply struct {
@@ -3310,6 +4910,38 @@ type ListOrdersPageReply struct {
 	Total     int64    `json:"total"`
 }
 
+type ListStoreOrdersPageRequest struct {
+	StoreId   int64  `form:"storeId,optional"`
+	StatusIds []int  `form:"statusIds,optional,omitempty"`
+	Name      string `form:"name,optional,omitempty"`
+	PageIndex int    `form:"pageIndex,optional,omitempty"`
+	PageSize  int    `form:"pageSize,optional,omitempty"`
+}
+
+type GetStoreOrderRequest struct {
+	OrderId int64 `path:"id"`
+}
+
+type ShipStoreOrderRequest struct {
+	OrderId      int64  `path:"id"`
+	Carrier      string `json:"carrier,optional"`
+	TrackingCode string `json:"trackingCode,optional"`
+}
+
+type ShipStoreOrderReply struct {
+	OrderId int64 `json:"orderId"`
+	Status  int   `json:"status"`
+}
+
+type DeliverStoreOrderRequest struct {
+	OrderId int64 `path:"id"`
+}
+
+type DeliverStoreOrderReply struct {
+	OrderId int64 `json:"orderId"`
+	Status  int   `json:"status"`
+}
+
 type ExportOrdersRequest struct {
 	Name      string `form:"name,optional"`
 	StartAt   string `form:"startAt"`
@@ -3411,6 +5043,7 @@ type Payment struct {
 	PaymentDate     string         `json:"paymentDate,optional"`
 	PaymentType     int            `json:"paymentType,optional"`
 	PaidAmount      float64        `json:"paidAmount,optional"`
+	RefundedAmount  float64        `json:"refundedAmount,optional"`
 	PaymentNumber   string         `json:"paymentNumber,optional"`
 	ReferenceNumber string         `json:"referenceNumber,optional"`
 	Status          int            `json:"status,optional"`
@@ -3421,6 +5054,7 @@ type ListPaymentsPageRequest struct {
 	PaymentType string   `form:"paymentType,optional"`
 	Keys        []string `form:"keys,optional"`
 	OrderBy     string   `form:"orderBy,optional"`
+	Filters     string   `form:"filters,optional"`
 	PageIndex   int      `form:"pageIndex,optional"`
 	PageSize    int      `form:"pageSize,optional"`
 }
@@ -3474,15 +5108,109 @@ type DeletePaymentReply struct {
 	PaymentId int64 `json:"id"`
 }
 
+type ConfirmOfflinePaymentRequest struct {
+	PaymentId       int64  `path:"id"`
+	ReferenceNumber string `json:"referenceNumber"`
+	Remark          string `json:"remark,optional"`
+}
+
+type ConfirmOfflinePaymentReply struct {
+	*Payment
+}
+
+type RefundPaymentRequest struct {
+	PaymentId int64   `path:"id"`
+	Amount    float64 `json:"amount"`
+	Reason    string  `json:"reason,optional"`
+}
+
+type RefundPaymentReply struct {
+	RefundNumber      string                    `json:"refundNumber"`
+	ReferenceNumber   string                    `json:"referenceNumber"`
+	State             string                    `json:"state"`
+	Amount            float64                   `json:"amount"`
+	BundleAllocations []*RefundBundleAllocation `json:"bundleAllocations"`
+}
+
+type RefundBundleAllocation struct {
+	OrderItemId int64   `json:"orderItemId"`
+	ComponentId int64   `json:"componentId"`
+	SkuNo       string  `json:"skuNo"`
+	Quantity    int     `json:"quantity"`
+	Amount      float64 `json:"amount"`
+}
+
+type ClosePaymentRequest struct {
+	PaymentId int64 `path:"id"`
+}
+
+type ClosePaymentReply struct {
+	*Payment
+}
+
+type PaymentNotification struct {
+	Id            int64   `json:"id"`
+	PaymentType   string  `json:"paymentType"`
+	NotifyId      string  `json:"notifyId"`
+	Event         string  `json:"event"`
+	TransactionId string  `json:"transactionId"`
+	PaymentNumber string  `json:"paymentNumber"`
+	Amount        float64 `json:"amount"`
+	Currency      string  `json:"currency"`
+	RawHeaders    string  `json:"rawHeaders,omitempty"`
+	RawBody       string  `json:"rawBody,omitempty"`
+	Payload       string  `json:"payload,omitempty"`
+	Status        string  `json:"status"`
+	Error         string  `json:"error"`
+	Attempts      int     `json:"attempts"`
+	CreatedAt     string  `json:"createdAt"`
+	ProcessedAt   string  `json:"processedAt"`
+}
+
+type ListPaymentNotificationsPageRequest struct {
+	PaymentType   string `form:"paymentType,optional"`
+	PaymentNumber string `form:"paymentNumber,optional"`
+	Status        string `form:"status,optional"`
+	PageIndex     int    `form:"pageIndex,optional"`
+	PageSize      int    `form:"pageSize,optional"`
+}
+
+type ListPaymentNotificationsPageReply struct {
+	List      []*PaymentNotification `json:"list"`
+	PageIndex int                    `json:"pageIndex"`
+	PageSize  int                    `json:"pageSize"`
+	Total     int64                  `json:"total"`
+}
+
+type GetPaymentNotificationRequest struct {
+	NotificationId int64 `path:"id"`
+}
+
+type GetPaymentNotificationReply struct {
+	*PaymentNotification
+}
+
+type ReplayPaymentNotificationRequest struct {
+	NotificationId int64 `path:"id"`
+}
+
+type ReplayPaymentNotificationReply struct {
+	*PaymentNotification
+}
+
 type CreatePaymentFromOrderRequest struct {
-	OrderId     int64  `json:"orderId"`
-	PaymentType int    `json:"paymentType"`
-	Comment     string `json:"comment,optional"`
+	OrderId     int64   `json:"orderId"`
+	PaymentType int     `json:"paymentType"`
+	Amount      float64 `json:"amount,optional"`
+	Comment     string  `json:"comment,optional"`
 }
 
 type CreatePaymentFromOrderRequestReply struct {
-	PaymentId int64       `json:"paymentId"`
-	Data      interface{} `json:"data"`
+	PaymentId         int64       `json:"paymentId"`
+	Amount            float64     `json:"amount"`
+	Paid              bool        `json:"paid"`
+	OutstandingAmount float64     `json:"outstandingAmount"`
+	Data              interface{} `json:"data"`
 }
 
 type UpdatePaymentRequest struct {
@@ -3494,6 +5222,49 @@ type UpdatePaymentReply struct {
 	*Payment
 }
 
+type RequestInvoiceRequest struct {
+	OrderId     int64  `json:"orderId"`
+	TitleType   string `json:"titleType"`
+	Title       string `json:"title"`
+	TaxIdNumber string `json:"taxIdNumber,optional"`
+	Email       string `json:"email"`
+	Remark      string `json:"remark,optional"`
+}
+
+type RequestInvoiceReply struct {
+	*Invoice
+}
+
+type CancelInvoiceRequest struct {
+	InvoiceId int64 `path:"id"`
+}
+
+type CancelInvoiceReply struct {
+	*Invoice
+}
+
+type ListSubscribeTemplatesRequest struct {
+	Events []string `form:"events,optional"`
+}
+
+type SubscribeTemplate struct {
+	Event      string `json:"event"`
+	TemplateId string `json:"templateId"`
+	Name       string `json:"name"`
+}
+
+type ListSubscribeTemplatesReply struct {
+	List []*SubscribeTemplate `json:"list"`
+}
+
+type RecordSubscriptionsRequest struct {
+	Results map[string]string `json:"results"` // wx.requestSubscribeMessage的返回, 模板ID -> accept/reject/ban/filter
+}
+
+type RecordSubscriptionsReply struct {
+	Accepted []string `json:"accepted"`
+}
+
 type CustomerLoginRequest struct {
 	Account  string `json:"account"`
 	Password string `json:"password"`
@@ -3607,7 +5378,12 @@ type SceneQrcodeActiveReply struct {
 }
 
 type SceneRequest struct {
-	Qid string `path:"qid"` // 唯一标识
+	Qid           string `path:"qid"` // 唯一标识
+	UserAgent     string `header:"User-Agent,optional"`
+	Referer       string `header:"Referer,optional"`
+	ForwardedFor  string `header:"X-Forwarded-For,optional"`
+	RealIp        string `header:"X-Real-Ip,optional"`
+	Authorization string `header:"Authorization,optional"` // 已登录客户可携带token
 }
 
 type Route struct {
@@ -36,6 +36,13 @@ type PowerXUseCase struct {
 	RegisterCode          *customerDomainUC.RegisterCodeUseCase
 	Product               *productUC.ProductUseCase
 	ProductStatistics     *productUC.ProductStatisticsUseCase
+	ProductSearch         *productUC.ProductSearchUseCase
+	ProductApproval       *productUC.ProductApprovalUseCase
+	ProductCatalog        *productUC.ProductCatalogUseCase
+	ProductBundle         *productUC.ProductBundleUseCase
+	ProductReview         *productUC.ProductReviewUseCase
+	StoreProduct          *productUC.StoreProductUseCase
+	Booking               *productUC.BookingUseCase
 	ProductSpecific       *productUC.ProductSpecificUseCase
 	SKU                   *productUC.SKUUseCase
 	ProductCategory       *productUC.ProductCategoryUseCase
@@ -48,10 +55,15 @@ type PowerXUseCase struct {
 	Cart                  *tradeUC.CartUseCase
 	Order                 *tradeUC.OrderUseCase
 	Payment               *tradeUC.PaymentUseCase
+	PaymentReconciliation *tradeUC.PaymentReconciliationUseCase
+	OrderExpiry           *tradeUC.OrderExpiryUseCase
+	Invoice               *tradeUC.InvoiceUseCase
+	Subscription          *tradeUC.SubscriptionUseCase
 	Logistics             *tradeUC.LogisticsUseCase
 	RefundOrder           *tradeUC.RefundOrderUseCase
 	WechatMP              *wechat.WechatMiniProgramUseCase
 	WechatOA              *wechat.WechatOfficialAccountUseCase
+	WechatNotification    *wechat.WechatNotificationUseCase
 	//WeWork                *powerx.WeWorkUseCase
 	SCRM          *scrm.SCRMUseCase
 	MediaResource *powerx.MediaResourceUseCase
@@ -110,7 +122,14 @@ func NewPowerXUseCase(conf *config.Config) (uc *PowerXUseCase, clean func()) {
 
 	// 加载产品服务UseCase
 	uc.ProductSpecific = productUC.NewProductSpecificUseCase(db)
-	uc.ProductStatistics = productUC.NewProductStatisticsUseCase(db)
+	uc.ProductStatistics = productUC.NewProductStatisticsUseCase(db, uc.redis)
+	uc.ProductSearch = productUC.NewProductSearchUseCase(db)
+	uc.ProductApproval = productUC.NewProductApprovalUseCase(db)
+	uc.ProductCatalog = productUC.NewProductCatalogUseCase(db)
+	uc.ProductBundle = productUC.NewProductBundleUseCase(db)
+	uc.ProductReview = productUC.NewProductReviewUseCase(db)
+	uc.StoreProduct = productUC.NewStoreProductUseCase(db)
+	uc.Booking = productUC.NewBookingUseCase(db)
 	uc.SKU = productUC.NewSKUUseCase(db)
 	uc.Product = productUC.NewProductUseCase(db)
 	uc.ProductCategory = productUC.NewProductCategoryUseCase(db)
@@ -124,6 +143,7 @@ func NewPowerXUseCase(conf *config.Config) (uc *PowerXUseCase, clean func()) {
 	uc.Cart = tradeUC.NewCartUseCase(db)
 	uc.Order = tradeUC.NewOrderUseCase(db)
 	uc.Payment = tradeUC.NewPaymentUseCase(db, conf)
+	uc.Invoice = tradeUC.NewInvoiceUseCase(db)
 	uc.RefundOrder = tradeUC.NewRefundOrderUseCase(db)
 	uc.Logistics = tradeUC.NewLogisticsUseCase(db)
 
@@ -131,6 +151,13 @@ func NewPowerXUseCase(conf *config.Config) (uc *PowerXUseCase, clean func()) {
 	//uc.WeWork = powerx.NewWeWorkUseCase(db, conf)
 	uc.WechatMP = wechat.NewWechatMiniProgramUseCase(db, conf)
 	uc.WechatOA = wechat.NewWechatOfficialAccountUseCase(db, conf)
+	uc.WechatNotification = wechat.NewWechatNotificationUseCase(db, uc.WechatMP, uc.WechatOA)
+	uc.PaymentReconciliation = tradeUC.NewPaymentReconciliationUseCase(db, uc.Payment, uc.Order, uc.WechatNotification)
+	uc.OrderExpiry = tradeUC.NewOrderExpiryUseCase(db, uc.Payment, uc.Order)
+	uc.Subscription = tradeUC.NewSubscriptionUseCase(db, uc.Payment, uc.Order, uc.WechatNotification)
+	uc.Payment.AddOrderPaidHook(uc.ProductStatistics.HandleOrderPaid)
+	uc.Payment.AddOrderRefundedHook(uc.ProductStatistics.HandleOrderRefunded)
+	uc.Payment.AddOrderRefundedHook(uc.Booking.HandleOrderRefunded)
 
 	// 加载市场UseCase
 	uc.Media = market.NewMediaUseCase(db)
@@ -143,6 +170,13 @@ func NewPowerXUseCase(conf *config.Config) (uc *PowerXUseCase, clean func()) {
 	c := cron.New()
 	uc.SCRM = scrm.NewSCRMUseCase(db, conf, c, uc.redis)
 	uc.SCRM.Schedule()
+	uc.WechatNotification.Schedule(c)
+	uc.Payment.Schedule(c)
+	uc.PaymentReconciliation.Schedule(c)
+	uc.OrderExpiry.Schedule(c)
+	uc.Subscription.Schedule(c)
+	uc.ProductSearch.Schedule(c)
+	uc.ProductStatistics.Schedule(c)
 
 	// 加载Scene
 	uc.Scene = scrm.NewSceneUseCase(db, uc.redis)
@@ -28,7 +28,7 @@ type FindManyCustomersOption struct {
 	Mobile     string
 	Statuses   []int
 	Sources    []int
-	OrderBy    string
+	QuerySpec  *powermodel.QuerySpec
 	types.PageEmbedOption
 }
 
@@ -48,10 +48,8 @@ func (uc *CustomerUseCase) buildFindQueryNoPage(db *gorm.DB, opt *FindManyCustom
 	if len(opt.Sources) > 0 {
 		db = db.Where("source IN ?", opt.Sources)
 	}
+	db = opt.QuerySpec.Apply(db)
 	orderBy := "id desc"
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
-	}
 	db.Order(orderBy)
 
 	return db
@@ -26,7 +26,7 @@ type FindManyLeadsOption struct {
 	LikeMobile string
 	Statuses   []int
 	Sources    []int
-	OrderBy    string
+	QuerySpec  *powermodel.QuerySpec
 	types.PageEmbedOption
 }
 
@@ -43,10 +43,8 @@ func (uc *LeadUseCase) buildFindQueryNoPage(db *gorm.DB, opt *FindManyLeadsOptio
 	if len(opt.Sources) > 0 {
 		db = db.Where("source IN ?", opt.Sources)
 	}
+	db = opt.QuerySpec.Apply(db)
 	orderBy := "id desc"
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
-	}
 	db.Order(orderBy)
 
 	return db
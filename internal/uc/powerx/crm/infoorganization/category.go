@@ -21,7 +21,7 @@ func NewCategoryUseCase(db *gorm.DB) *CategoryUseCase {
 }
 
 type FindCategoryOption struct {
-	OrderBy     string
+	QuerySpec   *powermodel.QuerySpec
 	CategoryPId int
 	Limit       int
 	Ids         []int64
@@ -39,10 +39,8 @@ func (uc *CategoryUseCase) buildFindQueryNoPage(query *gorm.DB, opt *FindCategor
 		query.Limit(opt.Limit)
 	}
 
+	query = opt.QuerySpec.Apply(query)
 	orderBy := "sort desc, id "
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
-	}
 	query.Order(orderBy)
 
 	return query
@@ -21,11 +21,11 @@ func NewLabelUseCase(db *gorm.DB) *LabelUseCase {
 }
 
 type FindLabelOption struct {
-	OrderBy  string
-	LabelPId int
-	Limit    int
-	Ids      []int64
-	Names    []string
+	QuerySpec *powermodel.QuerySpec
+	LabelPId  int
+	Limit     int
+	Ids       []int64
+	Names     []string
 }
 
 func (uc *LabelUseCase) buildFindQueryNoPage(query *gorm.DB, opt *FindLabelOption) *gorm.DB {
@@ -39,10 +39,8 @@ func (uc *LabelUseCase) buildFindQueryNoPage(query *gorm.DB, opt *FindLabelOptio
 		query.Limit(opt.Limit)
 	}
 
+	query = opt.QuerySpec.Apply(query)
 	orderBy := "sort desc, id "
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
-	}
 	query.Order(orderBy)
 
 	return query
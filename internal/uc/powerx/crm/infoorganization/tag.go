@@ -21,11 +21,11 @@ func NewTagUseCase(db *gorm.DB) *TagUseCase {
 }
 
 type FindTagOption struct {
-	OrderBy string
-	TagPId  int
-	Limit   int
-	Ids     []int64
-	Names   []string
+	QuerySpec *powermodel.QuerySpec
+	TagPId    int
+	Limit     int
+	Ids       []int64
+	Names     []string
 }
 
 func (uc *TagUseCase) buildFindQueryNoPage(query *gorm.DB, opt *FindTagOption) *gorm.DB {
@@ -39,10 +39,8 @@ func (uc *TagUseCase) buildFindQueryNoPage(query *gorm.DB, opt *FindTagOption) *
 		query.Limit(opt.Limit)
 	}
 
+	query = opt.QuerySpec.Apply(query)
 	orderBy := "sort desc, id "
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
-	}
 	query.Order(orderBy)
 
 	return query
@@ -22,10 +22,10 @@ func NewMediaUseCase(db *gorm.DB) *MediaUseCase {
 }
 
 type FindManyMediasOption struct {
-	Types    []int8
-	Ids      []int64
-	LikeName string
-	OrderBy  string
+	Types     []int8
+	Ids       []int64
+	LikeName  string
+	QuerySpec *powermodel.QuerySpec
 	types.PageEmbedOption
 }
 
@@ -41,10 +41,8 @@ func (uc *MediaUseCase) buildFindQueryNoPage(db *gorm.DB, opt *FindManyMediasOpt
 	if opt.LikeName != "" {
 		db = db.Where("title LIKE ?", "%"+opt.LikeName+"%")
 	}
+	db = opt.QuerySpec.Apply(db)
 	orderBy := "id desc"
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
-	}
 	db.Order(orderBy)
 	return db
 }
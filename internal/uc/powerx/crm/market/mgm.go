@@ -22,10 +22,10 @@ func NewMGMRuleUseCase(db *gorm.DB) *MGMRuleUseCase {
 }
 
 type FindManyMGMRulesOption struct {
-	Types    []int8
-	Ids      []int64
-	LikeName string
-	OrderBy  string
+	Types     []int8
+	Ids       []int64
+	LikeName  string
+	QuerySpec *powermodel.QuerySpec
 	types.PageEmbedOption
 }
 
@@ -41,10 +41,8 @@ func (uc *MGMRuleUseCase) buildFindQueryNoPage(db *gorm.DB, opt *FindManyMGMRule
 	if opt.LikeName != "" {
 		db = db.Where("title LIKE ?", "%"+opt.LikeName+"%")
 	}
+	db = opt.QuerySpec.Apply(db)
 	orderBy := "id desc"
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
-	}
 	db.Order(orderBy)
 	return db
 }
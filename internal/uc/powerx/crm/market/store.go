@@ -23,9 +23,10 @@ func NewStoreUseCase(db *gorm.DB) *StoreUseCase {
 }
 
 type FindManyStoresOption struct {
-	LikeName string
-	Ids      []int64
-	OrderBy  string
+	LikeName        string
+	Ids             []int64
+	StoreEmployeeId int64
+	QuerySpec       *powermodel.QuerySpec
 	types.PageEmbedOption
 }
 
@@ -39,10 +40,12 @@ func (uc *StoreUseCase) buildFindQueryNoPage(db *gorm.DB, opt *FindManyStoresOpt
 		db = db.Where("id in ?", opt.Ids)
 	}
 
-	orderBy := "id desc"
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
+	if opt.StoreEmployeeId > 0 {
+		db = db.Where("store_employee_id = ?", opt.StoreEmployeeId)
 	}
+
+	db = opt.QuerySpec.Apply(db)
+	orderBy := "id desc"
 	db.Order(orderBy)
 
 	return db
@@ -113,6 +116,17 @@ func (uc *StoreUseCase) FindManyStores(ctx context.Context, opt *FindManyStoresO
 	}, nil
 }
 
+// GetManagedStoreIds 员工作为店长管理的门店Id
+func (uc *StoreUseCase) GetManagedStoreIds(ctx context.Context, employeeId int64) []int64 {
+	storeIds := []int64{}
+	if err := uc.db.WithContext(ctx).Model(&model.Store{}).
+		Where("store_employee_id = ?", employeeId).
+		Pluck("id", &storeIds).Error; err != nil {
+		panic(errors.Wrap(err, "find managed stores failed"))
+	}
+	return storeIds
+}
+
 func (uc *StoreUseCase) CreateStore(ctx context.Context, store *model.Store) error {
 
 	if err := uc.db.WithContext(ctx).
@@ -24,11 +24,11 @@ func NewArtisanUseCase(db *gorm.DB) *ArtisanUseCase {
 }
 
 type FindManyArtisanOption struct {
-	LikeName string
-	OrderBy  string
-	Ids      []int64
-	StoreIds []int64
-	Names    []string
+	LikeName  string
+	QuerySpec *powermodel.QuerySpec
+	Ids       []int64
+	StoreIds  []int64
+	Names     []string
 	types.PageEmbedOption
 }
 
@@ -45,10 +45,8 @@ func (uc *ArtisanUseCase) buildFindQueryNoPage(query *gorm.DB, opt *FindManyArti
 			Where("pivot_store_to_artisan.store_id in (?)", opt.StoreIds)
 	}
 
+	query = opt.QuerySpec.Apply(query)
 	orderBy := "id desc"
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
-	}
 	query.Order(orderBy)
 
 	return query
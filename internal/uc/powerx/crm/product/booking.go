@@ -0,0 +1,553 @@
+package product
+
+import (
+	"PowerX/internal/model/crm/market"
+	model "PowerX/internal/model/crm/product"
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/types"
+	"PowerX/internal/types/errorx"
+	"PowerX/internal/uc/powerx"
+	"PowerX/pkg/slicex"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// 服务预约: 元匠按门店排班, 可预约时间段由门店营业时间、元匠排班、请假和已有预约计算,
+// 客户使用已支付的服务订单项预约, 每个订单项可预约的次数为购买数量
+type BookingUseCase struct {
+	db *gorm.DB
+}
+
+func NewBookingUseCase(db *gorm.DB) *BookingUseCase {
+	return &BookingUseCase{
+		db: db,
+	}
+}
+
+type FindManyBookingsOption struct {
+	StoreId    int64
+	ArtisanId  int64
+	CustomerId int64
+	OrderId    int64
+	Statuses   []string
+	StartAt    time.Time
+	EndAt      time.Time
+	types.PageEmbedOption
+}
+
+// 可以预约服务的订单状态, 即已付清之后的状态
+var bookableOrderStatuses = []string{
+	trade.OrderStatusToBeShipped,
+	trade.OrderStatusShipping,
+	trade.OrderStatusDelivered,
+	trade.OrderStatusCompleted,
+}
+
+// ValidateArtisanSchedule 校验排班的星期、时间段及预约时间段长度
+func ValidateArtisanSchedule(schedule *model.ArtisanSchedule) error {
+	if schedule.Weekday < 0 || schedule.Weekday > 6 {
+		return errorx.WithCause(errorx.ErrBadRequest, "星期必须在0到6之间")
+	}
+	start, err := model.ParseClock(schedule.StartTime)
+	if err != nil {
+		return errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+	end, err := model.ParseClock(schedule.EndTime)
+	if err != nil {
+		return errorx.WithCause(errorx.ErrBadRequest, err.Error())
+	}
+	if schedule.SlotMinutes <= 0 {
+		schedule.SlotMinutes = model.DefaultBookingSlotMinutes
+	}
+	if end-start < schedule.SlotMinutes {
+		return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("排班%s-%s不足一个预约时间段", schedule.StartTime, schedule.EndTime))
+	}
+	return nil
+}
+
+func (uc *BookingUseCase) buildFindQueryNoPage(db *gorm.DB, opt *FindManyBookingsOption) *gorm.DB {
+	if opt.StoreId > 0 {
+		db = db.Where("store_id = ?", opt.StoreId)
+	}
+	if opt.ArtisanId > 0 {
+		db = db.Where("artisan_id = ?", opt.ArtisanId)
+	}
+	if opt.CustomerId > 0 {
+		db = db.Where("customer_id = ?", opt.CustomerId)
+	}
+	if opt.OrderId > 0 {
+		db = db.Where("order_id = ?", opt.OrderId)
+	}
+	if len(opt.Statuses) > 0 {
+		db = db.Where("status IN ?", opt.Statuses)
+	}
+	if !opt.StartAt.IsZero() {
+		db = db.Where("end_at > ?", opt.StartAt)
+	}
+	if !opt.EndAt.IsZero() {
+		db = db.Where("start_at < ?", opt.EndAt)
+	}
+	return db
+}
+
+func (uc *BookingUseCase) PreloadItems(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Artisan").
+		Preload("Product")
+}
+
+// FindAllBookings 按开始时间排序, 用于门店及元匠的日历
+func (uc *BookingUseCase) FindAllBookings(ctx context.Context, opt *FindManyBookingsOption) []*model.Booking {
+	bookings := []*model.Booking{}
+	query := uc.db.WithContext(ctx).Model(&model.Booking{})
+	query = uc.buildFindQueryNoPage(query, opt)
+	query = uc.PreloadItems(query)
+	if err := query.Order("start_at, id").Find(&bookings).Error; err != nil {
+		panic(errors.Wrap(err, "find all bookings failed"))
+	}
+	return bookings
+}
+
+func (uc *BookingUseCase) FindManyBookings(ctx context.Context, opt *FindManyBookingsOption) types.Page[*model.Booking] {
+	var bookings []*model.Booking
+	var count int64
+	query := uc.db.WithContext(ctx).Model(&model.Booking{})
+	query = uc.buildFindQueryNoPage(query, opt)
+	if err := query.Count(&count).Error; err != nil {
+		panic(errors.Wrap(err, "find many bookings failed"))
+	}
+
+	opt.DefaultPageIfNotSet()
+	if opt.PageIndex != 0 && opt.PageSize != 0 {
+		query.Offset((opt.PageIndex - 1) * opt.PageSize).Limit(opt.PageSize)
+	}
+
+	query = uc.PreloadItems(query)
+	if err := query.Order("start_at desc, id desc").Find(&bookings).Error; err != nil {
+		panic(errors.Wrap(err, "find many bookings failed"))
+	}
+	return types.Page[*model.Booking]{
+		List:      bookings,
+		PageIndex: opt.PageIndex,
+		PageSize:  opt.PageSize,
+		Total:     count,
+	}
+}
+
+func (uc *BookingUseCase) GetBooking(ctx context.Context, id int64) (*model.Booking, error) {
+	booking := &model.Booking{}
+	if err := uc.PreloadItems(uc.db.WithContext(ctx)).First(booking, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.WithCause(errorx.ErrNotFoundObject, "未找到预约")
+		}
+		panic(err)
+	}
+	return booking, nil
+}
+
+// IsArtisanInStore 元匠是否绑定到门店
+func (uc *BookingUseCase) IsArtisanInStore(ctx context.Context, storeId int64, artisanId int64) bool {
+	var count int64
+	if err := uc.db.WithContext(ctx).Model(&model.PivotStoreToArtisan{}).
+		Where("store_id = ? AND artisan_id = ?", storeId, artisanId).
+		Count(&count).Error; err != nil {
+		panic(err)
+	}
+	return count > 0
+}
+
+func (uc *BookingUseCase) FindArtisanSchedules(ctx context.Context, storeId int64, artisanId int64) []*model.ArtisanSchedule {
+	schedules := []*model.ArtisanSchedule{}
+	query := uc.db.WithContext(ctx).Where("store_id = ?", storeId)
+	if artisanId > 0 {
+		query = query.Where("artisan_id = ?", artisanId)
+	}
+	if err := query.Order("artisan_id, weekday, start_time").Find(&schedules).Error; err != nil {
+		panic(errors.Wrap(err, "find artisan schedules failed"))
+	}
+	return schedules
+}
+
+// ReplaceArtisanSchedules
+//
+//	@Description: 整体替换元匠在门店的每周排班, 已有预约不受影响
+//	@receiver uc
+//	@param ctx
+//	@param storeId
+//	@param artisanId
+//	@param schedules
+//	@return error
+func (uc *BookingUseCase) ReplaceArtisanSchedules(ctx context.Context, storeId int64, artisanId int64, schedules []*model.ArtisanSchedule) error {
+	if !uc.IsArtisanInStore(ctx, storeId, artisanId) {
+		return errorx.WithCause(errorx.ErrBadRequest, "元匠未绑定该门店")
+	}
+	for _, schedule := range schedules {
+		if err := ValidateArtisanSchedule(schedule); err != nil {
+			return err
+		}
+		schedule.StoreId = storeId
+		schedule.ArtisanId = artisanId
+	}
+	for i, a := range schedules {
+		for _, b := range schedules[i+1:] {
+			if a.Weekday != b.Weekday {
+				continue
+			}
+			startA, _ := model.ParseClock(a.StartTime)
+			endA, _ := model.ParseClock(a.EndTime)
+			startB, _ := model.ParseClock(b.StartTime)
+			endB, _ := model.ParseClock(b.EndTime)
+			if startA < endB && startB < endA {
+				return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("星期%d的排班时段有重叠", a.Weekday))
+			}
+		}
+	}
+
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().
+			Where("store_id = ? AND artisan_id = ?", storeId, artisanId).
+			Delete(&model.ArtisanSchedule{}).Error; err != nil {
+			return err
+		}
+		if len(schedules) == 0 {
+			return nil
+		}
+		return tx.Create(&schedules).Error
+	})
+	if err != nil {
+		panic(errors.Wrap(err, "replace artisan schedules failed"))
+	}
+	return nil
+}
+
+// FindArtisanLeaves 与时间范围重叠的请假, 开始或结束为零值时不限制
+func (uc *BookingUseCase) FindArtisanLeaves(ctx context.Context, artisanId int64, startAt time.Time, endAt time.Time) []*model.ArtisanLeave {
+	leaves := []*model.ArtisanLeave{}
+	query := uc.db.WithContext(ctx).Where("artisan_id = ?", artisanId)
+	if !startAt.IsZero() {
+		query = query.Where("end_at > ?", startAt)
+	}
+	if !endAt.IsZero() {
+		query = query.Where("start_at < ?", endAt)
+	}
+	if err := query.Order("start_at").Find(&leaves).Error; err != nil {
+		panic(errors.Wrap(err, "find artisan leaves failed"))
+	}
+	return leaves
+}
+
+// CreateArtisanLeave 请假期间已有预约时需要先改期或取消预约
+func (uc *BookingUseCase) CreateArtisanLeave(ctx context.Context, leave *model.ArtisanLeave) error {
+	if !leave.StartAt.Before(leave.EndAt) {
+		return errorx.WithCause(errorx.ErrBadRequest, "请假结束时间必须晚于开始时间")
+	}
+
+	var count int64
+	if err := uc.db.WithContext(ctx).Model(&model.Booking{}).
+		Where("artisan_id = ? AND status = ?", leave.ArtisanId, model.BookingStatusBooked).
+		Where("start_at < ? AND end_at > ?", leave.EndAt, leave.StartAt).
+		Count(&count).Error; err != nil {
+		panic(err)
+	}
+	if count > 0 {
+		return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("请假期间已有%d个预约, 请先改期或取消", count))
+	}
+
+	if err := uc.db.WithContext(ctx).Create(leave).Error; err != nil {
+		panic(errors.Wrap(err, "create artisan leave failed"))
+	}
+	return nil
+}
+
+func (uc *BookingUseCase) DeleteArtisanLeave(ctx context.Context, id int64) error {
+	result := uc.db.WithContext(ctx).Delete(&model.ArtisanLeave{}, id)
+	if err := result.Error; err != nil {
+		panic(err)
+	}
+	if result.RowsAffected == 0 {
+		return errorx.WithCause(errorx.ErrDeleteObjectNotFound, "未找到请假记录")
+	}
+	return nil
+}
+
+// GetAvailableSlots 元匠在门店某天可预约的时间段
+func (uc *BookingUseCase) GetAvailableSlots(ctx context.Context, storeId int64, artisanId int64, date time.Time) ([]*model.TimeSlot, error) {
+	if !uc.IsArtisanInStore(ctx, storeId, artisanId) {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "元匠未绑定该门店")
+	}
+	return uc.computeAvailableSlots(ctx, uc.db, storeId, artisanId, date, 0)
+}
+
+// computeAvailableSlots 按元匠在所有门店的预约计算占用, excludeBookingId用于改期时排除预约自身
+func (uc *BookingUseCase) computeAvailableSlots(ctx context.Context, db *gorm.DB, storeId int64, artisanId int64, date time.Time, excludeBookingId int64) ([]*model.TimeSlot, error) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+	now := time.Now()
+	if day.After(now.AddDate(0, 0, model.BookingMaxAdvanceDays)) {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("最多提前%d天预约", model.BookingMaxAdvanceDays))
+	}
+	dayEnd := day.AddDate(0, 0, 1)
+
+	store := &market.Store{}
+	if err := db.WithContext(ctx).First(store, storeId).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.WithCause(errorx.ErrNotFoundObject, "未找到门店")
+		}
+		panic(err)
+	}
+
+	schedules := []*model.ArtisanSchedule{}
+	if err := db.WithContext(ctx).
+		Where("store_id = ? AND artisan_id = ? AND weekday = ?", storeId, artisanId, int(day.Weekday())).
+		Find(&schedules).Error; err != nil {
+		panic(err)
+	}
+
+	leaves := []*model.ArtisanLeave{}
+	if err := db.WithContext(ctx).
+		Where("artisan_id = ? AND start_at < ? AND end_at > ?", artisanId, dayEnd, day).
+		Find(&leaves).Error; err != nil {
+		panic(err)
+	}
+
+	bookings := []*model.Booking{}
+	if err := db.WithContext(ctx).
+		Where("artisan_id = ? AND status = ? AND id <> ?", artisanId, model.BookingStatusBooked, excludeBookingId).
+		Where("start_at < ? AND end_at > ?", dayEnd, day).
+		Find(&bookings).Error; err != nil {
+		panic(err)
+	}
+
+	return model.ComputeBookingSlots(day, store.StartWork, store.EndWork, schedules, leaves, bookings, now), nil
+}
+
+// lockArtisan 锁定元匠, 同一元匠的预约、改期串行执行, 避免重复占用时间段
+func lockArtisan(ctx context.Context, tx *gorm.DB, artisanId int64) error {
+	artisan := &model.Artisan{}
+	err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(artisan, artisanId).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errorx.WithCause(errorx.ErrNotFoundObject, "未找到元匠")
+	}
+	return err
+}
+
+// CreateBooking
+//
+//	@Description: 客户使用自己已付清的服务订单项预约元匠在门店的时间段, 门店订单只能在下单门店预约
+//	@receiver uc
+//	@param ctx
+//	@param customerId
+//	@param booking 需要填写门店、元匠、订单项、开始时间和备注
+//	@return *model.Booking
+//	@return error
+func (uc *BookingUseCase) CreateBooking(ctx context.Context, customerId int64, booking *model.Booking) (*model.Booking, error) {
+	orderItem := &trade.OrderItem{}
+	if err := uc.db.WithContext(ctx).Preload("Order").First(orderItem, booking.OrderItemId).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "未找到订单项")
+		}
+		panic(err)
+	}
+	if orderItem.Order == nil || orderItem.Order.CustomerId != customerId {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "只能使用自己的订单预约")
+	}
+	ucDD := powerx.NewDataDictionaryUseCase(uc.db)
+	if !slicex.Contains(bookableOrderStatuses, ucDD.GetCachedDDById(ctx, orderItem.Order.Status).Key) {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "订单付款后才能预约")
+	}
+	if orderItem.Order.StoreId > 0 && orderItem.Order.StoreId != booking.StoreId {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "只能在下单门店预约")
+	}
+
+	itemProduct, ok := findOrderItemProducts(ctx, uc.db, orderItem.Order.CartId > 0, []*trade.OrderItem{orderItem})[orderItem.Id]
+	if !ok {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "未找到订单商品")
+	}
+	mdlProduct := &model.Product{}
+	if err := uc.db.WithContext(ctx).Unscoped().First(mdlProduct, itemProduct.ProductId).Error; err != nil {
+		panic(err)
+	}
+	if ucDD.GetCachedDDById(ctx, mdlProduct.Type).Key != model.ProductTypeService {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "只有服务类商品可以预约")
+	}
+	if !uc.IsArtisanInStore(ctx, booking.StoreId, booking.ArtisanId) {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "元匠未绑定该门店")
+	}
+
+	booking.Id = 0
+	booking.CustomerId = customerId
+	booking.OrderId = orderItem.OrderId
+	booking.ProductId = itemProduct.ProductId
+	booking.Status = model.BookingStatusBooked
+	booking.RescheduleCount = 0
+
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockArtisan(ctx, tx, booking.ArtisanId); err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&model.Booking{}).
+			Where("order_item_id = ? AND status IN ?", orderItem.Id, []string{model.BookingStatusBooked, model.BookingStatusCompleted}).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if int(count) >= orderItem.Quantity {
+			return errorx.WithCause(errorx.ErrBadRequest, "该订单项的预约次数已用完")
+		}
+
+		slots, err := uc.computeAvailableSlots(ctx, tx, booking.StoreId, booking.ArtisanId, booking.StartAt, 0)
+		if err != nil {
+			return err
+		}
+		slot := model.FindTimeSlot(slots, booking.StartAt)
+		if slot == nil {
+			return errorx.WithCause(errorx.ErrBadRequest, "该时间段不可预约")
+		}
+		booking.EndAt = slot.EndAt
+
+		return tx.Omit(clause.Associations).Create(booking).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return booking, nil
+}
+
+// RescheduleBooking
+//
+//	@Description: 预约改期到同一门店同一元匠的其他时间段, 客户改期需在截止时间前且不超过改期次数
+//	@receiver uc
+//	@param ctx
+//	@param booking
+//	@param startAt 新的开始时间
+//	@param byCustomer 是否客户操作, 门店操作不受截止时间及改期次数限制
+//	@return error
+func (uc *BookingUseCase) RescheduleBooking(ctx context.Context, booking *model.Booking, startAt time.Time, byCustomer bool) error {
+	if booking.Status != model.BookingStatusBooked {
+		return errorx.WithCause(errorx.ErrBadRequest, "只有已预约状态可以改期")
+	}
+	if byCustomer {
+		if !booking.CanCustomerChange(time.Now()) {
+			return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("预约开始前%d小时内不能改期", int(model.BookingChangeDeadline.Hours())))
+		}
+		if booking.RescheduleCount >= model.BookingMaxRescheduleCount {
+			return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("每个预约最多改期%d次", model.BookingMaxRescheduleCount))
+		}
+	}
+
+	return uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockArtisan(ctx, tx, booking.ArtisanId); err != nil {
+			return err
+		}
+		slots, err := uc.computeAvailableSlots(ctx, tx, booking.StoreId, booking.ArtisanId, startAt, booking.Id)
+		if err != nil {
+			return err
+		}
+		slot := model.FindTimeSlot(slots, startAt)
+		if slot == nil {
+			return errorx.WithCause(errorx.ErrBadRequest, "该时间段不可预约")
+		}
+
+		// 按原状态更新, 期间已取消或完成的预约不能改期; 客户改期按原改期次数更新, 避免并发改期超过次数
+		query := tx.Model(&model.Booking{}).Where("id = ? AND status = ?", booking.Id, model.BookingStatusBooked)
+		columns := map[string]interface{}{
+			"start_at": slot.StartAt,
+			"end_at":   slot.EndAt,
+		}
+		if byCustomer {
+			query = query.Where("reschedule_count = ?", booking.RescheduleCount)
+			columns["reschedule_count"] = booking.RescheduleCount + 1
+		}
+		result := query.Updates(columns)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errorx.WithCause(errorx.ErrBadRequest, "预约状态已变更, 请刷新后重试")
+		}
+
+		booking.StartAt = slot.StartAt
+		booking.EndAt = slot.EndAt
+		if byCustomer {
+			booking.RescheduleCount++
+		}
+		return nil
+	})
+}
+
+// CancelBooking 取消预约后订单项的预约次数退回, 客户取消需在截止时间前
+func (uc *BookingUseCase) CancelBooking(ctx context.Context, booking *model.Booking, reason string, byCustomer bool) error {
+	if booking.Status != model.BookingStatusBooked {
+		return errorx.WithCause(errorx.ErrBadRequest, "只有已预约状态可以取消")
+	}
+	if byCustomer && !booking.CanCustomerChange(time.Now()) {
+		return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("预约开始前%d小时内不能取消", int(model.BookingChangeDeadline.Hours())))
+	}
+
+	now := time.Now()
+	result := uc.db.WithContext(ctx).Model(&model.Booking{}).
+		Where("id = ? AND status = ?", booking.Id, model.BookingStatusBooked).
+		Updates(map[string]interface{}{
+			"status":        model.BookingStatusCancelled,
+			"cancel_reason": reason,
+			"cancelled_at":  &now,
+		})
+	if result.Error != nil {
+		panic(errors.Wrap(result.Error, "cancel booking failed"))
+	}
+	if result.RowsAffected == 0 {
+		return errorx.WithCause(errorx.ErrBadRequest, "预约状态已变更, 请刷新后重试")
+	}
+
+	booking.Status = model.BookingStatusCancelled
+	booking.CancelReason = reason
+	booking.CancelledAt = &now
+	return nil
+}
+
+// CompleteBooking 服务开始后门店确认完成
+func (uc *BookingUseCase) CompleteBooking(ctx context.Context, booking *model.Booking) error {
+	if booking.Status != model.BookingStatusBooked {
+		return errorx.WithCause(errorx.ErrBadRequest, "只有已预约状态可以完成")
+	}
+	now := time.Now()
+	if now.Before(booking.StartAt) {
+		return errorx.WithCause(errorx.ErrBadRequest, "预约开始后才能完成")
+	}
+
+	result := uc.db.WithContext(ctx).Model(&model.Booking{}).
+		Where("id = ? AND status = ?", booking.Id, model.BookingStatusBooked).
+		Updates(map[string]interface{}{
+			"status":       model.BookingStatusCompleted,
+			"completed_at": &now,
+		})
+	if result.Error != nil {
+		panic(errors.Wrap(result.Error, "complete booking failed"))
+	}
+	if result.RowsAffected == 0 {
+		return errorx.WithCause(errorx.ErrBadRequest, "预约状态已变更, 请刷新后重试")
+	}
+
+	booking.Status = model.BookingStatusCompleted
+	booking.CompletedAt = &now
+	return nil
+}
+
+// HandleOrderRefunded 服务订单全额退款后取消未完成的预约
+func (uc *BookingUseCase) HandleOrderRefunded(ctx context.Context, order *trade.Order) {
+	now := time.Now()
+	err := uc.db.WithContext(ctx).Model(&model.Booking{}).
+		Where("order_id = ? AND status = ?", order.Id, model.BookingStatusBooked).
+		Updates(map[string]interface{}{
+			"status":        model.BookingStatusCancelled,
+			"cancel_reason": "订单已退款",
+			"cancelled_at":  &now,
+		}).Error
+	if err != nil {
+		panic(errors.Wrap(err, "cancel refunded order bookings failed"))
+	}
+}
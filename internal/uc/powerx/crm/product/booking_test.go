@@ -0,0 +1,53 @@
+package product
+
+import (
+	model "PowerX/internal/model/crm/product"
+	"context"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestChangeBookingWithStaleStatus(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		Logger:                                   logger.Discard,
+		DisableForeignKeyConstraintWhenMigrating: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Migrator().CreateTable(&model.Booking{}); err != nil {
+		t.Fatal(err)
+	}
+	uc := NewBookingUseCase(db)
+
+	startAt := time.Now().Add(-time.Hour)
+	booking := &model.Booking{StoreId: 1, ArtisanId: 1, CustomerId: 1, OrderItemId: 1, StartAt: startAt, EndAt: startAt.Add(time.Hour), Status: model.BookingStatusBooked}
+	db.Create(booking)
+
+	// 两个请求读取到同一条已预约记录, 先完成的请求生效
+	completing := *booking
+	cancelling := *booking
+	if err = uc.CompleteBooking(ctx, &completing); err != nil {
+		t.Fatal(err)
+	}
+	if err = uc.CancelBooking(ctx, &cancelling, "客户取消", false); err == nil {
+		t.Errorf("cancel completed booking should fail")
+	}
+	if err = uc.CompleteBooking(ctx, booking); err == nil {
+		t.Errorf("complete booking twice should fail")
+	}
+
+	saved := &model.Booking{}
+	db.First(saved, booking.Id)
+	if saved.Status != model.BookingStatusCompleted || saved.CancelledAt != nil {
+		t.Errorf("booking status = %s, cancelledAt = %v", saved.Status, saved.CancelledAt)
+	}
+	if cancelling.Status != model.BookingStatusBooked {
+		t.Errorf("failed cancel should not change booking status, got %s", cancelling.Status)
+	}
+}
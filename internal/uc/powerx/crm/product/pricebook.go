@@ -22,10 +22,10 @@ func NewPriceBookUseCase(db *gorm.DB) *PriceBookUseCase {
 }
 
 type FindPriceBookOption struct {
-	OrderBy string
-	Ids     []int64
-	Names   []string
-	StoreId int64
+	QuerySpec *powermodel.QuerySpec
+	Ids       []int64
+	Names     []string
+	StoreId   int64
 	types.PageEmbedOption
 }
 
@@ -38,13 +38,12 @@ func (uc *PriceBookUseCase) buildFindQueryNoPage(query *gorm.DB, opt *FindPriceB
 	}
 
 	if opt.StoreId > 0 {
-		query.Where("store_id", opt.StoreId)
+		// 价格手册的门店字段列名为storeId
+		query.Where(`"storeId" = ?`, opt.StoreId)
 	}
 
+	query = opt.QuerySpec.Apply(query)
 	orderBy := "id desc"
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
-	}
 	query.Order(orderBy)
 
 	return query
@@ -140,6 +139,48 @@ func (uc *PriceBookUseCase) GetPriceBook(ctx context.Context, id int64) (*produc
 	return &priceBook, nil
 }
 
+// GetStorePriceBook 门店配置了价格手册时使用门店价格手册, 否则使用标准价格手册
+func (uc *PriceBookUseCase) GetStorePriceBook(ctx context.Context, storeId int64) (*product.PriceBook, error) {
+	if storeId > 0 {
+		priceBook, err := uc.FindOnePriceBook(ctx, &FindPriceBookOption{StoreId: storeId})
+		if err == nil {
+			return priceBook, nil
+		}
+	}
+	return uc.GetStandardPriceBook(ctx)
+}
+
+// ResolveOrderPriceBook
+//
+//	@Description: 客户下单使用的价格手册, 门店订单只能使用门店的价格手册, 非门店订单不能使用门店专属的价格手册
+//	@receiver uc
+//	@param ctx
+//	@param storeId 下单门店, 为0时不限门店
+//	@param priceBookId 客户指定的价格手册, 为0时使用门店价格手册
+//	@return *product.PriceBook
+//	@return error
+func (uc *PriceBookUseCase) ResolveOrderPriceBook(ctx context.Context, storeId int64, priceBookId int64) (*product.PriceBook, error) {
+	storePriceBook, err := uc.GetStorePriceBook(ctx, storeId)
+	if err != nil {
+		return nil, err
+	}
+	if priceBookId <= 0 || priceBookId == storePriceBook.Id {
+		return storePriceBook, nil
+	}
+	if storeId > 0 {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "价格手册与门店不匹配")
+	}
+
+	priceBook, err := uc.GetPriceBook(ctx, priceBookId)
+	if err != nil {
+		return nil, err
+	}
+	if priceBook.StoreId > 0 {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "价格手册与门店不匹配")
+	}
+	return priceBook, nil
+}
+
 func (uc *PriceBookUseCase) DeletePriceBook(ctx context.Context, id int64) error {
 	result := uc.db.WithContext(ctx).Delete(&product.PriceBook{}, id)
 	if err := result.Error; err != nil {
@@ -0,0 +1,61 @@
+package product
+
+import (
+	"PowerX/internal/model/crm/product"
+	"context"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestResolveOrderPriceBook(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Migrator().CreateTable(&product.PriceBook{}); err != nil {
+		t.Fatal(err)
+	}
+	standard := &product.PriceBook{IsStandard: true, Name: "标准"}
+	storeBook := &product.PriceBook{Name: "门店A", StoreId: 1}
+	otherStoreBook := &product.PriceBook{Name: "门店B", StoreId: 2}
+	campaign := &product.PriceBook{Name: "活动"}
+	db.Create([]*product.PriceBook{standard, storeBook, otherStoreBook, campaign})
+
+	uc := NewPriceBookUseCase(db)
+	cases := []struct {
+		name        string
+		storeId     int64
+		priceBookId int64
+		expected    int64
+	}{
+		{"门店订单使用门店价格手册", 1, 0, storeBook.Id},
+		{"门店订单指定本门店价格手册", 1, storeBook.Id, storeBook.Id},
+		{"门店订单不能使用其他门店价格手册", 1, otherStoreBook.Id, 0},
+		{"门店订单不能使用其他价格手册", 1, campaign.Id, 0},
+		{"未配置价格手册的门店使用标准价格手册", 3, 0, standard.Id},
+		{"未配置价格手册的门店不能指定价格手册", 3, campaign.Id, 0},
+		{"非门店订单使用标准价格手册", 0, 0, standard.Id},
+		{"非门店订单指定公共价格手册", 0, campaign.Id, campaign.Id},
+		{"非门店订单不能使用门店价格手册", 0, storeBook.Id, 0},
+	}
+	for _, c := range cases {
+		priceBook, err := uc.ResolveOrderPriceBook(ctx, c.storeId, c.priceBookId)
+		if c.expected == 0 {
+			if err == nil {
+				t.Errorf("%s: expected error, got price book %d", c.name, priceBook.Id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		if priceBook.Id != c.expected {
+			t.Errorf("%s: price book = %d, expected %d", c.name, priceBook.Id, c.expected)
+		}
+	}
+}
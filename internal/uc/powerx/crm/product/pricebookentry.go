@@ -22,7 +22,7 @@ func NewPriceBookEntryUseCase(db *gorm.DB) *PriceBookEntryUseCase {
 }
 
 type FindPriceBookEntryOption struct {
-	OrderBy     string
+	QuerySpec   *powermodel.QuerySpec
 	Ids         []int64
 	PriceBookId int64
 	ProductIds  []int64
@@ -51,10 +51,8 @@ func (uc *PriceBookEntryUseCase) buildFindQueryNoPage(query *gorm.DB, opt *FindP
 		query.Where("sku_id = ?", 0)
 	}
 
+	query = opt.QuerySpec.Apply(query)
 	orderBy := "id desc"
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
-	}
 	query.Order(orderBy)
 
 	return query
@@ -97,6 +95,55 @@ func (uc *PriceBookEntryUseCase) FindManyPriceBookEntries(ctx context.Context, o
 
 }
 
+// FindManyPriceBookEntriesWithFallback 按opt中的价格手册查找价格条目, 缺失的产品及SKU使用备用价格手册的条目
+func (uc *PriceBookEntryUseCase) FindManyPriceBookEntriesWithFallback(ctx context.Context, opt *FindPriceBookEntryOption, fallbackPriceBookId int64) []*product.PriceBookEntry {
+	entries := uc.FindManyPriceBookEntries(ctx, opt).List
+	if fallbackPriceBookId <= 0 || fallbackPriceBookId == opt.PriceBookId {
+		return entries
+	}
+	fallbackOpt := *opt
+	fallbackOpt.PriceBookId = fallbackPriceBookId
+	return product.MergePriceBookEntries(entries, uc.FindManyPriceBookEntries(ctx, &fallbackOpt).List)
+}
+
+// ApplyPriceBookToProducts 价格手册中有条目的产品及SKU使用该手册的价格, 其余沿用已加载的价格
+func (uc *PriceBookEntryUseCase) ApplyPriceBookToProducts(ctx context.Context, priceBookId int64, products []*product.Product) {
+	productIds := []int64{}
+	for _, p := range products {
+		productIds = append(productIds, p.Id)
+	}
+	if priceBookId <= 0 || len(productIds) == 0 {
+		return
+	}
+
+	entries := []*product.PriceBookEntry{}
+	if err := uc.db.WithContext(ctx).
+		Where("price_book_id = ? AND product_id IN ?", priceBookId, productIds).
+		Find(&entries).Error; err != nil {
+		panic(errors.Wrap(err, "find price book entries failed"))
+	}
+	mapProductEntries := map[int64]*product.PriceBookEntry{}
+	mapSkuEntries := map[int64]*product.PriceBookEntry{}
+	for _, entry := range entries {
+		if entry.SkuId == 0 {
+			mapProductEntries[entry.ProductId] = entry
+		} else {
+			mapSkuEntries[entry.SkuId] = entry
+		}
+	}
+
+	for _, p := range products {
+		if entry, ok := mapProductEntries[p.Id]; ok {
+			p.PriceBookEntries = append([]*product.PriceBookEntry{entry}, p.PriceBookEntries...)
+		}
+		for _, sku := range p.SKUs {
+			if entry, ok := mapSkuEntries[sku.Id]; ok {
+				sku.PriceBookEntry = entry
+			}
+		}
+	}
+}
+
 func (uc *PriceBookEntryUseCase) FindOnePriceBookEntry(ctx context.Context, opt *FindPriceBookEntryOption) (*product.PriceBookEntry, error) {
 	var mpCustomer *product.PriceBookEntry
 	query := uc.db.WithContext(ctx).Model(&product.PriceBookEntry{})
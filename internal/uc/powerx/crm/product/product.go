@@ -7,13 +7,14 @@ import (
 	"PowerX/internal/model/powermodel"
 	"PowerX/internal/types"
 	"PowerX/internal/types/errorx"
+	"PowerX/internal/uc/powerx"
 	"PowerX/pkg/datetime/carbonx"
 	"PowerX/pkg/slicex"
 	"context"
 	"encoding/json"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
+	"sort"
 	"strings"
 	"time"
 )
@@ -35,10 +36,12 @@ type FindManyProductsOption struct {
 	SkuIds        []int64
 	Ids           []int64
 	NeedActivated bool
+	OnlyOnSale    bool  // 只返回审核通过、已激活且在售卖时间内的产品
+	StoreId       int64 // 只返回已上架到门店且门店可售的产品
 	CategoryId    int
 	CategoryIds   []int
 	LikeName      string
-	OrderBy       string
+	QuerySpec     *powermodel.QuerySpec
 	StartAt       time.Time
 	EndAt         time.Time
 	types.PageEmbedOption
@@ -75,6 +78,21 @@ func (uc *ProductUseCase) buildFindQueryNoPage(db *gorm.DB, opt *FindManyProduct
 		db = db.Where("is_activated = ?", true)
 	}
 
+	if opt.OnlyOnSale {
+		ucDD := powerx.NewDataDictionaryUseCase(uc.db)
+		approvedId := ucDD.GetCachedDDId(db.Statement.Context, model2.TypeApprovalStatus, model2.ApprovalStatusSuccess)
+		db = db.
+			Where("products.approval_status = ? AND products.is_activated = ?", approvedId, true).
+			Scopes(model.WhereInSalePeriod(time.Now()))
+	}
+
+	if opt.StoreId > 0 {
+		storeProducts := uc.db.Model(&model.StoreProduct{}).
+			Select("product_id").
+			Where("store_id = ? AND is_available = ?", opt.StoreId, true)
+		db = db.Where("products.id IN (?)", storeProducts)
+	}
+
 	// 先考虑单个品类检索，在考虑多个品类检索
 	if opt.CategoryId > 0 || len(opt.CategoryIds) > 0 {
 		categoryIds := opt.CategoryIds
@@ -92,10 +110,8 @@ func (uc *ProductUseCase) buildFindQueryNoPage(db *gorm.DB, opt *FindManyProduct
 		db = db.Where("name LIKE ?", "%"+opt.LikeName+"%")
 	}
 
+	db = opt.QuerySpec.Apply(db)
 	orderBy := "sort desc, id "
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
-	}
 	db.Order(orderBy)
 
 	return db
@@ -112,9 +128,13 @@ func (uc *ProductUseCase) PreloadItems(db *gorm.DB) *gorm.DB {
 		Preload("PivotDetailImages", "media_usage = ?", media.MediaUsageDetail, mediaResourceSortBy).Preload("PivotDetailImages.MediaResource").
 		Preload("ProductCategories").
 		Preload("PriceBookEntries.PriceBook").
+		Preload("SKUs", "is_retired = ?", false, func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
 		Preload("SKUs.PriceBookEntry").
 		Preload("SKUs.PivotSkuToSpecificOptions").
 		Preload("ProductSpecifics.Options").
+		Preload("BundleItems", func(db *gorm.DB) *gorm.DB { return db.Order("sort, id") }).
+		Preload("BundleItems.SKU").
+		Preload("BundleItems.Product").
 		Preload("PivotSalesChannels.DataDictionaryItem", "type=?", model2.TypeSalesChannel).
 		Preload("PivotPromoteChannels.DataDictionaryItem", "type=?", model2.TypePromoteChannel)
 
@@ -314,44 +334,15 @@ func (uc *ProductUseCase) ClearAssociations(db *gorm.DB, product *model.Product)
 
 }
 
+// ReFactSKUs 按产品当前的规格更新SKU, 规格组合未变的SKU保留其Id、价格和库存
 func (uc *ProductUseCase) ReFactSKUs(ctx context.Context, product *model.Product) error {
-
-	db := uc.db.WithContext(ctx)
-	err := db.Transaction(func(tx *gorm.DB) error {
-
-		// upsert skus
-		skus := uc.GenerateSKUsFromSpecifics(context.Background(), product)
-		if len(skus) > 0 {
-			err := db.Model(&model.SKU{}).
-				//Debug().
-				Clauses(clause.OnConflict{
-					Columns:   []clause.Column{{Name: model.SkuUniqueId}},
-					DoUpdates: clause.AssignmentColumns(powermodel.GetModelFields(&model.SKU{})),
-				}).
-				Create(&skus).Error
-			if err != nil {
-				return err
-			}
-		}
-
-		// upsert PivotSKUsFromSpecifics
-		pivots := uc.GeneratePivotSKUsFromSpecifics(context.Background(), product.ProductSpecifics, skus)
-		if len(pivots) > 0 {
-			err := db.Model(&model.PivotSkuToSpecificOption{}).
-				//Debug().
-				Clauses(clause.OnConflict{
-					Columns:   []clause.Column{{Name: model.PivotPivotSkuToSpecificOptionsUniqueId}},
-					DoUpdates: clause.AssignmentColumns(powermodel.GetModelFields(&model.PivotSkuToSpecificOption{})),
-				}).Create(&pivots).Error
-			if err != nil {
-				panic(errors.Wrap(err, "init sku pivots failed"))
-			}
-
-		}
-
-		return nil
-	})
-
+	specifics := product.ProductSpecifics
+	sort.SliceStable(specifics, func(i, j int) bool { return specifics[i].Id < specifics[j].Id })
+	for _, specific := range specifics {
+		options := specific.Options
+		sort.SliceStable(options, func(i, j int) bool { return options[i].Id < options[j].Id })
+	}
+	_, err := uc.ApplySKUMatrix(ctx, product.Id, specifics)
 	return err
 }
 
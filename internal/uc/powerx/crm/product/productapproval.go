@@ -0,0 +1,387 @@
+package product
+
+import (
+	model2 "PowerX/internal/model"
+	model "PowerX/internal/model/crm/product"
+	"PowerX/internal/types/errorx"
+	"PowerX/internal/uc/powerx"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"time"
+)
+
+// 产品审核流程: 草稿或被驳回的产品提交审核, 审核通过后才能上架;
+// 已上架产品的修改保存为待审核修订, 审核通过后再更新线上产品
+type ProductApprovalUseCase struct {
+	db *gorm.DB
+}
+
+func NewProductApprovalUseCase(db *gorm.DB) *ProductApprovalUseCase {
+	return &ProductApprovalUseCase{
+		db: db,
+	}
+}
+
+// GetApprovalStatusId 审核状态在数据字典中的Id
+func (uc *ProductApprovalUseCase) GetApprovalStatusId(ctx context.Context, status string) int {
+	ucDD := powerx.NewDataDictionaryUseCase(uc.db)
+	return ucDD.GetCachedDDId(ctx, model2.TypeApprovalStatus, status)
+}
+
+// GetApprovalStatus 产品的审核状态, 未提交过审核的草稿返回空字符串
+func (uc *ProductApprovalUseCase) GetApprovalStatus(ctx context.Context, product *model.Product) string {
+	if product.ApprovalStatus == 0 {
+		return ""
+	}
+	ucDD := powerx.NewDataDictionaryUseCase(uc.db)
+	item, err := ucDD.GetDataDictionaryItemById(ctx, product.ApprovalStatus)
+	if err != nil || item.Type != model2.TypeApprovalStatus {
+		return ""
+	}
+	return item.Key
+}
+
+func (uc *ProductApprovalUseCase) IsProductApproved(ctx context.Context, product *model.Product) bool {
+	return uc.GetApprovalStatus(ctx, product) == model2.ApprovalStatusSuccess
+}
+
+// CheckProductsOnSale 加购及下单时校验产品已审核通过、已激活且处于售卖时间内, 与客户端展示的规则一致
+func (uc *ProductApprovalUseCase) CheckProductsOnSale(ctx context.Context, productIds []int64) error {
+	if len(productIds) == 0 {
+		return nil
+	}
+	products := []*model.Product{}
+	if err := uc.db.WithContext(ctx).Where("id IN ?", productIds).Find(&products).Error; err != nil {
+		panic(errors.Wrap(err, "find products on sale failed"))
+	}
+	mapProducts := map[int64]*model.Product{}
+	for _, product := range products {
+		mapProducts[product.Id] = product
+	}
+
+	now := time.Now()
+	for _, productId := range productIds {
+		product, ok := mapProducts[productId]
+		if !ok {
+			return errorx.WithCause(errorx.ErrBadRequest, "未找到产品")
+		}
+		if !product.IsActivated || !product.IsInSalePeriod(now) || !uc.IsProductApproved(ctx, product) {
+			return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("商品%s当前不可售", product.Name))
+		}
+	}
+	return nil
+}
+
+func (uc *ProductApprovalUseCase) getProduct(ctx context.Context, productId int64) (*model.Product, error) {
+	product := &model.Product{}
+	if err := uc.db.WithContext(ctx).First(product, productId).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "未找到产品")
+		}
+		panic(err)
+	}
+	return product, nil
+}
+
+// changeApprovalStatus 按当前状态条件更新产品审核状态并记录, 状态已被并发修改时返回false
+func (uc *ProductApprovalUseCase) changeApprovalStatus(ctx context.Context, product *model.Product, to string, record *model.ProductApprovalRecord) bool {
+	fromId := product.ApprovalStatus
+	toId := uc.GetApprovalStatusId(ctx, to)
+
+	changed := false
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Product{}).
+			Where("id = ? AND approval_status = ?", product.Id, fromId).
+			Update("approval_status", toId)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		record.ProductId = product.Id
+		record.ApprovalStatus = to
+		if err := tx.Create(record).Error; err != nil {
+			return err
+		}
+		changed = true
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	if changed {
+		product.ApprovalStatus = toId
+	}
+	return changed
+}
+
+// SubmitProduct
+//
+//	@Description: 草稿或被驳回的产品提交审核
+//	@receiver uc
+//	@param ctx
+//	@param productId
+//	@param operatorId 提交员工Id
+//	@param comment
+//	@return *model.Product
+//	@return error
+func (uc *ProductApprovalUseCase) SubmitProduct(ctx context.Context, productId int64, operatorId int64, comment string) (*model.Product, error) {
+	product, err := uc.getProduct(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	status := uc.GetApprovalStatus(ctx, product)
+	if status != "" && status != model2.ApprovalStatusReject {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "只能提交草稿或被驳回的产品")
+	}
+
+	record := &model.ProductApprovalRecord{
+		Action:     model.ProductApprovalActionSubmit,
+		OperatorId: operatorId,
+		Comment:    comment,
+	}
+	if !uc.changeApprovalStatus(ctx, product, model2.ApprovalStatusApply, record) {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "产品审核状态已变更, 请刷新后重试")
+	}
+	return product, nil
+}
+
+// ApproveProduct
+//
+//	@Description: 审核通过待审核的产品, 已上架产品的修订通过ApproveRevision审核
+//	@receiver uc
+//	@param ctx
+//	@param productId
+//	@param reviewerId 审核员工Id
+//	@param comment
+//	@return *model.Product
+//	@return error
+func (uc *ProductApprovalUseCase) ApproveProduct(ctx context.Context, productId int64, reviewerId int64, comment string) (*model.Product, error) {
+	product, err := uc.getProduct(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	if uc.GetApprovalStatus(ctx, product) != model2.ApprovalStatusApply {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "该产品不属于待审核状态")
+	}
+
+	record := &model.ProductApprovalRecord{
+		Action:     model.ProductApprovalActionApprove,
+		OperatorId: reviewerId,
+		Comment:    comment,
+	}
+	if !uc.changeApprovalStatus(ctx, product, model2.ApprovalStatusSuccess, record) {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "产品审核状态已变更, 请刷新后重试")
+	}
+	return product, nil
+}
+
+// RejectProduct
+//
+//	@Description: 驳回产品审核, 已上架产品驳回的是待审核修订, 线上产品保持不变
+//	@receiver uc
+//	@param ctx
+//	@param productId
+//	@param reviewerId 审核员工Id
+//	@param comment 驳回原因, 必填
+//	@return error
+func (uc *ProductApprovalUseCase) RejectProduct(ctx context.Context, productId int64, reviewerId int64, comment string) error {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return errorx.WithCause(errorx.ErrBadRequest, "请填写驳回原因")
+	}
+	product, err := uc.getProduct(ctx, productId)
+	if err != nil {
+		return err
+	}
+
+	if revision := uc.GetPendingRevision(ctx, productId); revision != nil {
+		record := &model.ProductApprovalRecord{
+			ProductId:      productId,
+			RevisionId:     revision.Id,
+			Action:         model.ProductApprovalActionReject,
+			ApprovalStatus: model2.ApprovalStatusReject,
+			OperatorId:     reviewerId,
+			Comment:        comment,
+		}
+		if !uc.reviewRevision(ctx, uc.db, revision, model2.ApprovalStatusReject, record) {
+			return errorx.WithCause(errorx.ErrBadRequest, "修订审核状态已变更, 请刷新后重试")
+		}
+		return nil
+	}
+
+	if uc.GetApprovalStatus(ctx, product) != model2.ApprovalStatusApply {
+		return errorx.WithCause(errorx.ErrBadRequest, "该产品不属于待审核状态")
+	}
+	record := &model.ProductApprovalRecord{
+		Action:     model.ProductApprovalActionReject,
+		OperatorId: reviewerId,
+		Comment:    comment,
+	}
+	if !uc.changeApprovalStatus(ctx, product, model2.ApprovalStatusReject, record) {
+		return errorx.WithCause(errorx.ErrBadRequest, "产品审核状态已变更, 请刷新后重试")
+	}
+	return nil
+}
+
+// GetPendingRevision 产品待审核的修订, 没有时返回nil
+func (uc *ProductApprovalUseCase) GetPendingRevision(ctx context.Context, productId int64) *model.ProductRevision {
+	return findPendingRevision(uc.db.WithContext(ctx), productId)
+}
+
+// lockPendingRevision 在事务内锁定产品待审核的修订, 审核、驳回及覆盖修订互斥
+func lockPendingRevision(tx *gorm.DB, productId int64) *model.ProductRevision {
+	return findPendingRevision(tx.Clauses(clause.Locking{Strength: "UPDATE"}), productId)
+}
+
+func findPendingRevision(db *gorm.DB, productId int64) *model.ProductRevision {
+	revision := &model.ProductRevision{}
+	err := db.
+		Where("product_id = ? AND status = ?", productId, model2.ApprovalStatusApply).
+		Order("id desc").
+		First(revision).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		panic(err)
+	}
+	return revision
+}
+
+// ReviseProduct
+//
+//	@Description: 保存已上架产品的修改为待审核修订, 已有待审核修订时覆盖其内容
+//	@receiver uc
+//	@param ctx
+//	@param productId
+//	@param payload 修改后的产品内容
+//	@param operatorId 提交员工Id
+//	@return *model.ProductRevision
+func (uc *ProductApprovalUseCase) ReviseProduct(ctx context.Context, productId int64, payload datatypes.JSON, operatorId int64) *model.ProductRevision {
+	var revision *model.ProductRevision
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		revision = lockPendingRevision(tx, productId)
+		if revision == nil {
+			revision = &model.ProductRevision{
+				ProductId: productId,
+				Status:    model2.ApprovalStatusApply,
+			}
+		}
+		revision.Payload = payload
+		revision.SubmittedBy = operatorId
+
+		if err := tx.Save(revision).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.ProductApprovalRecord{
+			ProductId:      productId,
+			RevisionId:     revision.Id,
+			Action:         model.ProductApprovalActionRevise,
+			ApprovalStatus: model2.ApprovalStatusApply,
+			OperatorId:     operatorId,
+		}).Error
+	})
+	if err != nil {
+		panic(err)
+	}
+	return revision
+}
+
+// ApprovePendingRevision
+//
+//	@Description: 审核通过产品待审核的修订, 锁定修订后由apply将修订内容更新到线上产品, 再标记修订审核通过并记录,
+//	期间修订不会被覆盖或驳回; 没有待审核修订时返回nil
+//	@receiver uc
+//	@param ctx
+//	@param productId
+//	@param reviewerId 审核员工Id
+//	@param comment
+//	@param apply 将修订内容更新到线上产品, 返回错误时修订保持待审核
+//	@return *model.ProductRevision
+//	@return error
+func (uc *ProductApprovalUseCase) ApprovePendingRevision(ctx context.Context, productId int64, reviewerId int64, comment string,
+	apply func(revision *model.ProductRevision) error,
+) (*model.ProductRevision, error) {
+	var revision *model.ProductRevision
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		revision = lockPendingRevision(tx, productId)
+		if revision == nil {
+			return nil
+		}
+		if err := apply(revision); err != nil {
+			return err
+		}
+
+		record := &model.ProductApprovalRecord{
+			ProductId:      revision.ProductId,
+			RevisionId:     revision.Id,
+			Action:         model.ProductApprovalActionApprove,
+			ApprovalStatus: model2.ApprovalStatusSuccess,
+			OperatorId:     reviewerId,
+			Comment:        comment,
+		}
+		if !uc.reviewRevision(ctx, tx, revision, model2.ApprovalStatusSuccess, record) {
+			return errorx.WithCause(errorx.ErrBadRequest, "修订审核状态已变更, 请刷新后重试")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+func (uc *ProductApprovalUseCase) reviewRevision(ctx context.Context, db *gorm.DB, revision *model.ProductRevision, to string, record *model.ProductApprovalRecord) bool {
+	reviewedAt := time.Now()
+	changed := false
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.ProductRevision{}).
+			Where("id = ? AND status = ?", revision.Id, model2.ApprovalStatusApply).
+			Updates(map[string]interface{}{
+				"status":      to,
+				"reviewed_by": record.OperatorId,
+				"reviewed_at": reviewedAt,
+				"comment":     record.Comment,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if err := tx.Create(record).Error; err != nil {
+			return err
+		}
+		changed = true
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	if changed {
+		revision.Status = to
+		revision.ReviewedBy = record.OperatorId
+		revision.ReviewedAt = &reviewedAt
+		revision.Comment = record.Comment
+	}
+	return changed
+}
+
+// FindApprovalRecords 产品的审核历史, 按时间倒序
+func (uc *ProductApprovalUseCase) FindApprovalRecords(ctx context.Context, productId int64) []*model.ProductApprovalRecord {
+	records := []*model.ProductApprovalRecord{}
+	if err := uc.db.WithContext(ctx).
+		Where("product_id = ?", productId).
+		Order("id desc").
+		Find(&records).Error; err != nil {
+		panic(err)
+	}
+	return records
+}
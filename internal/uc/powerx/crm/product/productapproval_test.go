@@ -0,0 +1,67 @@
+package product
+
+import (
+	model2 "PowerX/internal/model"
+	model "PowerX/internal/model/crm/product"
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/datatypes"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestApprovePendingRevision(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Migrator().CreateTable(&model.ProductRevision{}, &model.ProductApprovalRecord{}); err != nil {
+		t.Fatal(err)
+	}
+	uc := NewProductApprovalUseCase(db)
+	first := uc.ReviseProduct(ctx, 1, datatypes.JSON(`{"name":"v1"}`), 1)
+
+	// 更新线上产品失败时修订保持待审核
+	_, err = uc.ApprovePendingRevision(ctx, 1, 2, "", func(revision *model.ProductRevision) error {
+		return errors.New("update product failed")
+	})
+	if err == nil || uc.GetPendingRevision(ctx, 1) == nil {
+		t.Fatalf("revision should stay pending when apply fails, err = %v", err)
+	}
+
+	var applied string
+	revision, err := uc.ApprovePendingRevision(ctx, 1, 2, "通过", func(revision *model.ProductRevision) error {
+		applied = string(revision.Payload)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revision.Id != first.Id || revision.Status != model2.ApprovalStatusSuccess || applied != `{"name":"v1"}` {
+		t.Errorf("approved revision = %+v, applied = %s", revision, applied)
+	}
+
+	// 审核通过后的修改保存为新的修订, 不会覆盖已通过的修订
+	second := uc.ReviseProduct(ctx, 1, datatypes.JSON(`{"name":"v2"}`), 1)
+	if second.Id == first.Id {
+		t.Errorf("revise after approval should create a new revision")
+	}
+	saved := &model.ProductRevision{}
+	db.First(saved, first.Id)
+	if saved.Status != model2.ApprovalStatusSuccess || string(saved.Payload) != `{"name":"v1"}` {
+		t.Errorf("approved revision changed: %+v", saved)
+	}
+
+	// 没有待审核修订时不调用apply
+	revision, err = uc.ApprovePendingRevision(ctx, 2, 2, "", func(revision *model.ProductRevision) error {
+		t.Errorf("apply should not be called without pending revision")
+		return nil
+	})
+	if err != nil || revision != nil {
+		t.Errorf("revision = %v, err = %v", revision, err)
+	}
+}
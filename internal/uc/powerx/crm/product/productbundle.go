@@ -0,0 +1,256 @@
+package product
+
+import (
+	model "PowerX/internal/model/crm/product"
+	"PowerX/internal/types/errorx"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// 组合商品: 由多个组件SKU按数量组成, 价格在价格手册中按固定价格或组件合计减优惠定价,
+// 可售数量由组件库存决定, 下单时拆分为组件明细扣减库存
+type ProductBundleUseCase struct {
+	db *gorm.DB
+}
+
+func NewProductBundleUseCase(db *gorm.DB) *ProductBundleUseCase {
+	return &ProductBundleUseCase{
+		db: db,
+	}
+}
+
+// BundleComponentPrice 组件在价格手册中的单价, 没有SKU价格条目时取组件产品的价格条目
+type BundleComponentPrice struct {
+	Item      *model.ProductBundleItem
+	UnitPrice float64
+	ListPrice float64
+}
+
+func (uc *ProductBundleUseCase) PreloadItems(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("SKU").
+		Preload("Product")
+}
+
+// FindBundleItems 按组合商品分组返回组件, 组件按排序和Id排列
+func (uc *ProductBundleUseCase) FindBundleItems(ctx context.Context, bundleProductIds []int64) map[int64][]*model.ProductBundleItem {
+	mapItems := map[int64][]*model.ProductBundleItem{}
+	if len(bundleProductIds) == 0 {
+		return mapItems
+	}
+
+	items := []*model.ProductBundleItem{}
+	query := uc.db.WithContext(ctx).Model(&model.ProductBundleItem{}).
+		Where("bundle_product_id IN ?", bundleProductIds).
+		Order("sort, id")
+	if err := uc.PreloadItems(query).Find(&items).Error; err != nil {
+		panic(errors.Wrap(err, "find bundle items failed"))
+	}
+	for _, item := range items {
+		mapItems[item.BundleProductId] = append(mapItems[item.BundleProductId], item)
+	}
+	return mapItems
+}
+
+func (uc *ProductBundleUseCase) GetBundleItems(ctx context.Context, bundleProductId int64) []*model.ProductBundleItem {
+	items := uc.FindBundleItems(ctx, []int64{bundleProductId})[bundleProductId]
+	if items == nil {
+		items = []*model.ProductBundleItem{}
+	}
+	return items
+}
+
+// GetBundleAvailableQuantity 组合商品按组件库存可售的份数
+func (uc *ProductBundleUseCase) GetBundleAvailableQuantity(ctx context.Context, bundleProductId int64) int {
+	return model.BundleAvailableQuantity(uc.GetBundleItems(ctx, bundleProductId))
+}
+
+// ConfigBundleItems
+//
+//	@Description: 全量配置组合商品的组件, 并按新组件重新计算合计减优惠定价的价格条目
+//	@receiver uc
+//	@param ctx
+//	@param bundleProductId
+//	@param items 组件SKU及每份数量
+//	@return []*model.ProductBundleItem
+//	@return error
+func (uc *ProductBundleUseCase) ConfigBundleItems(ctx context.Context, bundleProductId int64, items []*model.ProductBundleItem) ([]*model.ProductBundleItem, error) {
+	bundle := &model.Product{}
+	if err := uc.db.WithContext(ctx).First(bundle, bundleProductId).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "未找到产品")
+		}
+		panic(err)
+	}
+	if !bundle.IsBundle {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "该产品不是组合商品")
+	}
+	if len(items) == 0 {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "组合商品至少需要一个组件")
+	}
+
+	skuIds := []int64{}
+	for _, item := range items {
+		skuIds = append(skuIds, item.SkuId)
+	}
+	skus := []*model.SKU{}
+	if err := uc.db.WithContext(ctx).Where("id IN ?", skuIds).Find(&skus).Error; err != nil {
+		panic(err)
+	}
+	mapSKUs := map[int64]*model.SKU{}
+	for _, sku := range skus {
+		mapSKUs[sku.Id] = sku
+	}
+	bundleProductIds := []int64{}
+	err := uc.db.WithContext(ctx).Model(&model.Product{}).
+		Where("id IN (?) AND is_bundle = ?", uc.db.Model(&model.SKU{}).Select("product_id").Where("id IN ?", skuIds), true).
+		Pluck("id", &bundleProductIds).Error
+	if err != nil {
+		panic(err)
+	}
+	isBundleProduct := map[int64]bool{bundleProductId: true}
+	for _, id := range bundleProductIds {
+		isBundleProduct[id] = true
+	}
+
+	configured := map[int64]bool{}
+	for i, item := range items {
+		sku, ok := mapSKUs[item.SkuId]
+		if !ok {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("未找到组件SKU: %d", item.SkuId))
+		}
+		if configured[item.SkuId] {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "组件SKU重复: "+sku.SkuNo)
+		}
+		configured[item.SkuId] = true
+		if item.Quantity <= 0 {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "组件数量必须大于0: "+sku.SkuNo)
+		}
+		if sku.IsRetired {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "组件SKU已停用: "+sku.SkuNo)
+		}
+		if isBundleProduct[sku.ProductId] {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "组合商品不能包含组合商品: "+sku.SkuNo)
+		}
+		item.Id = 0
+		item.BundleProductId = bundleProductId
+		item.ProductId = sku.ProductId
+		if item.Sort == 0 {
+			item.Sort = i
+		}
+	}
+
+	err = uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("bundle_product_id = ?", bundleProductId).Delete(&model.ProductBundleItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&items).Error
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	uc.RefreshBundleEntryPrices(ctx, bundleProductId)
+
+	return uc.GetBundleItems(ctx, bundleProductId), nil
+}
+
+// GetComponentPrices 组件在指定价格手册中的价格, 顺序与组件一致
+func (uc *ProductBundleUseCase) GetComponentPrices(ctx context.Context, priceBookId int64, items []*model.ProductBundleItem) []*BundleComponentPrice {
+	skuIds := []int64{}
+	productIds := []int64{}
+	for _, item := range items {
+		skuIds = append(skuIds, item.SkuId)
+		productIds = append(productIds, item.ProductId)
+	}
+
+	entries := []*model.PriceBookEntry{}
+	if len(items) > 0 {
+		err := uc.db.WithContext(ctx).Model(&model.PriceBookEntry{}).
+			Where("price_book_id = ? AND is_active = ?", priceBookId, true).
+			Where("(sku_id IN ? OR (product_id IN ? AND sku_id = 0))", skuIds, productIds).
+			Find(&entries).Error
+		if err != nil {
+			panic(err)
+		}
+	}
+	skuEntries := map[int64]*model.PriceBookEntry{}
+	productEntries := map[int64]*model.PriceBookEntry{}
+	for _, entry := range entries {
+		if entry.SkuId > 0 {
+			skuEntries[entry.SkuId] = entry
+		} else {
+			productEntries[entry.ProductId] = entry
+		}
+	}
+
+	prices := []*BundleComponentPrice{}
+	for _, item := range items {
+		price := &BundleComponentPrice{Item: item}
+		entry, ok := skuEntries[item.SkuId]
+		if !ok {
+			entry = productEntries[item.ProductId]
+		}
+		if entry != nil {
+			price.UnitPrice = entry.UnitPrice
+			price.ListPrice = entry.ListPrice
+		}
+		prices = append(prices, price)
+	}
+	return prices
+}
+
+// SumComponentPrices 组件单价和标价乘以数量的合计
+func SumComponentPrices(prices []*BundleComponentPrice) (unitTotal float64, listTotal float64) {
+	for _, price := range prices {
+		unitTotal += price.UnitPrice * float64(price.Item.Quantity)
+		listTotal += price.ListPrice * float64(price.Item.Quantity)
+	}
+	return unitTotal, listTotal
+}
+
+// RefreshBundleEntryPrices 组件或组件价格变化后, 重新计算合计减优惠定价的价格条目
+func (uc *ProductBundleUseCase) RefreshBundleEntryPrices(ctx context.Context, bundleProductId int64) {
+	entries := []*model.PriceBookEntry{}
+	err := uc.db.WithContext(ctx).
+		Where("product_id = ? AND sku_id = 0 AND bundle_pricing = ?", bundleProductId, model.BundlePricingSumMinusDiscount).
+		Find(&entries).Error
+	if err != nil {
+		panic(err)
+	}
+	if len(entries) == 0 {
+		return
+	}
+
+	items := uc.GetBundleItems(ctx, bundleProductId)
+	for _, entry := range entries {
+		unitTotal, listTotal := SumComponentPrices(uc.GetComponentPrices(ctx, entry.PriceBookId, items))
+		err = uc.db.WithContext(ctx).Model(entry).Updates(map[string]interface{}{
+			"unit_price": model.ComputeBundleUnitPrice(entry, unitTotal),
+			"list_price": listTotal,
+		}).Error
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RefreshBundlesByProducts 价格条目变化后, 重新计算这些产品本身及包含它们的组合商品的价格条目
+func (uc *ProductBundleUseCase) RefreshBundlesByProducts(ctx context.Context, productIds []int64) {
+	if len(productIds) == 0 {
+		return
+	}
+	bundleProductIds := []int64{}
+	err := uc.db.WithContext(ctx).Model(&model.ProductBundleItem{}).
+		Where("product_id IN ? OR bundle_product_id IN ?", productIds, productIds).
+		Distinct().
+		Pluck("bundle_product_id", &bundleProductIds).Error
+	if err != nil {
+		panic(err)
+	}
+	for _, bundleProductId := range bundleProductIds {
+		uc.RefreshBundleEntryPrices(ctx, bundleProductId)
+	}
+}
@@ -0,0 +1,1123 @@
+package product
+
+import (
+	model "PowerX/internal/model/crm/product"
+	"PowerX/internal/model/media"
+	"PowerX/internal/types/errorx"
+	"PowerX/internal/uc/powerx"
+	"PowerX/pkg/datetime/carbonx"
+	"PowerX/pkg/excelx"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 产品目录的批量导入导出, 每行一个SKU, 同一产品货号的多行属于同一产品, 产品字段取第一个非空值;
+// 导入时空单元格表示不修改现有值
+type ProductCatalogUseCase struct {
+	db *gorm.DB
+}
+
+func NewProductCatalogUseCase(db *gorm.DB) *ProductCatalogUseCase {
+	return &ProductCatalogUseCase{
+		db: db,
+	}
+}
+
+const (
+	CatalogColumnSPU           = "产品货号"
+	CatalogColumnName          = "产品名称"
+	CatalogColumnType          = "产品类型"
+	CatalogColumnPlan          = "产品计划"
+	CatalogColumnCategories    = "品类"
+	CatalogColumnDescription   = "产品描述"
+	CatalogColumnIsActivated   = "是否激活"
+	CatalogColumnCanSellOnline = "允许线上销售"
+	CatalogColumnSaleStartDate = "售卖开始时间"
+	CatalogColumnSaleEndDate   = "售卖结束时间"
+	CatalogColumnSort          = "排序"
+	CatalogColumnCoverImages   = "封面图"
+	CatalogColumnDetailImages  = "详情图"
+	CatalogColumnUnitPrice     = "标准价"
+	CatalogColumnListPrice     = "零售价"
+	CatalogColumnSkuNo         = "SKU编号"
+	CatalogColumnSpecifics     = "规格"
+	CatalogColumnSkuInventory  = "SKU库存"
+	CatalogColumnSkuUnitPrice  = "SKU标准价"
+	CatalogColumnSkuListPrice  = "SKU零售价"
+)
+
+var CatalogHeaders = []string{
+	CatalogColumnSPU,
+	CatalogColumnName,
+	CatalogColumnType,
+	CatalogColumnPlan,
+	CatalogColumnCategories,
+	CatalogColumnDescription,
+	CatalogColumnIsActivated,
+	CatalogColumnCanSellOnline,
+	CatalogColumnSaleStartDate,
+	CatalogColumnSaleEndDate,
+	CatalogColumnSort,
+	CatalogColumnCoverImages,
+	CatalogColumnDetailImages,
+	CatalogColumnUnitPrice,
+	CatalogColumnListPrice,
+	CatalogColumnSkuNo,
+	CatalogColumnSpecifics,
+	CatalogColumnSkuInventory,
+	CatalogColumnSkuUnitPrice,
+	CatalogColumnSkuListPrice,
+}
+
+// 模板中的示例数据, 一个产品的两个SKU
+var catalogTemplateRows = [][]string{
+	{"SPU-001", "拿铁咖啡", model.ProductTypeGoods, model.ProductPlanOnce, "饮品/咖啡|新品", "香浓拿铁", "是", "是", "2023-01-01", "",
+		"0", "https://example.com/cover.jpg", "https://example.com/detail-1.jpg|https://example.com/detail-2.jpg", "25", "30",
+		"SPU-001-M-HOT", "杯型:中杯;温度:热", "100", "25", "30"},
+	{"SPU-001", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "SPU-001-L-HOT", "杯型:大杯;温度:热", "80", "28", "33"},
+}
+
+const (
+	// 多个值之间的分隔符, 如多个品类或图片
+	catalogListSeparator = "|"
+	// 品类路径的分隔符, 从顶级品类开始
+	catalogCategorySeparator = "/"
+	// 多个规格之间的分隔符, 规格名和规格项以冒号分隔
+	catalogSpecificSeparator = ";"
+)
+
+const (
+	CatalogMaxFileSize = 10 << 20
+	CatalogMaxRows     = 20000
+)
+
+type CatalogSpecificOption struct {
+	Specific string
+	Option   string
+}
+
+type CatalogSKU struct {
+	Row       int
+	SkuNo     string
+	Options   []*CatalogSpecificOption
+	Inventory *int
+	UnitPrice *float64
+	ListPrice *float64
+}
+
+type CatalogProduct struct {
+	Rows            []int
+	SPU             string
+	Name            string
+	TypeKey         string
+	PlanKey         string
+	CategoryPaths   [][]string
+	Description     *string
+	IsActivated     *bool
+	CanSellOnline   *bool
+	SaleStartDate   *time.Time
+	SaleEndDate     *time.Time
+	Sort            *int
+	CoverImageUrls  []string
+	DetailImageUrls []string
+	UnitPrice       *float64
+	ListPrice       *float64
+	SKUs            []*CatalogSKU
+
+	// 以下由ValidateCatalogProducts填写
+	Failed   bool
+	Existing *model.Product
+	TypeId   int
+	PlanId   int
+}
+
+func (p *CatalogProduct) HasPrices() bool {
+	if p.UnitPrice != nil || p.ListPrice != nil {
+		return true
+	}
+	for _, sku := range p.SKUs {
+		if sku.UnitPrice != nil || sku.ListPrice != nil {
+			return true
+		}
+	}
+	return false
+}
+
+type CatalogParseResult struct {
+	Products  []*CatalogProduct
+	TotalRows int
+	RowErrors []*model.ProductImportRowError
+}
+
+// AddProductError 记录产品的错误, 该产品的所有行都不会导入
+func (r *CatalogParseResult) AddProductError(p *CatalogProduct, column string, message string) {
+	p.Failed = true
+	r.RowErrors = append(r.RowErrors, &model.ProductImportRowError{Row: p.Rows[0], Column: column, Message: message})
+}
+
+// FailedRows 有错误的行数, 包括出错产品的所有行
+func (r *CatalogParseResult) FailedRows() int {
+	rows := map[int]bool{}
+	for _, rowError := range r.RowErrors {
+		rows[rowError.Row] = true
+	}
+	for _, p := range r.Products {
+		if p.Failed {
+			for _, row := range p.Rows {
+				rows[row] = true
+			}
+		}
+	}
+	return len(rows)
+}
+
+// ValidProducts 没有错误的产品
+func (r *CatalogParseResult) ValidProducts() []*CatalogProduct {
+	products := []*CatalogProduct{}
+	for _, p := range r.Products {
+		if !p.Failed {
+			products = append(products, p)
+		}
+	}
+	return products
+}
+
+// GetCatalogFileFormat 根据文件名判断导入文件格式, 不支持时返回空字符串
+func GetCatalogFileFormat(fileName string) string {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".csv":
+		return model.ProductImportFormatCSV
+	case ".xlsx":
+		return model.ProductImportFormatXLSX
+	default:
+		return ""
+	}
+}
+
+func ReadCatalogFile(format string, content []byte) ([][]string, error) {
+	switch format {
+	case model.ProductImportFormatCSV:
+		reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xEF\xBB\xBF"))))
+		reader.FieldsPerRecord = -1
+		return reader.ReadAll()
+	case model.ProductImportFormatXLSX:
+		return excelx.ReadRows(content)
+	default:
+		return nil, fmt.Errorf("不支持的文件格式: %s", format)
+	}
+}
+
+func WriteCatalogFile(format string, rows [][]string) (content []byte, contentType string, err error) {
+	switch format {
+	case model.ProductImportFormatCSV:
+		buffer := &bytes.Buffer{}
+		// 写入BOM, Excel打开时不乱码
+		buffer.WriteString("\xEF\xBB\xBF")
+		writer := csv.NewWriter(buffer)
+		if err = writer.WriteAll(rows); err != nil {
+			return nil, "", err
+		}
+		return buffer.Bytes(), "text/csv", nil
+	case model.ProductImportFormatXLSX:
+		content, err = excelx.WriteRows("产品目录", rows)
+		return content, excelx.ContentType, err
+	default:
+		return nil, "", fmt.Errorf("不支持的文件格式: %s", format)
+	}
+}
+
+// GetCatalogTemplateRows 导入模板, 包含表头及示例数据
+func GetCatalogTemplateRows() [][]string {
+	return append([][]string{CatalogHeaders}, catalogTemplateRows...)
+}
+
+func parseCatalogBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "是", "true", "1", "y", "yes":
+		return true, nil
+	case "否", "false", "0", "n", "no":
+		return false, nil
+	default:
+		return false, fmt.Errorf("无效的是否值: %s", value)
+	}
+}
+
+var catalogTimeLayouts = []string{carbonx.GoDatetimeFormat, carbonx.GoDateFormat, "2006/01/02 15:04:05", "2006/01/02", time.RFC3339}
+
+func parseCatalogTime(value string) (time.Time, error) {
+	for _, layout := range catalogTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	// xlsx中的日期单元格保存为序列值
+	if serial, err := strconv.ParseFloat(value, 64); err == nil && serial > 0 {
+		return excelx.SerialToTime(serial), nil
+	}
+	return time.Time{}, fmt.Errorf("无效的时间: %s", value)
+}
+
+func parseCatalogPrice(value string) (float64, error) {
+	price, err := strconv.ParseFloat(value, 64)
+	if err != nil || price < 0 {
+		return 0, fmt.Errorf("无效的价格: %s", value)
+	}
+	return price, nil
+}
+
+func splitCatalogList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, catalogListSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ParseCatalogSpecifics 解析规格, 如 "颜色:红;尺码:L"
+func ParseCatalogSpecifics(value string) ([]*CatalogSpecificOption, error) {
+	options := []*CatalogSpecificOption{}
+	seen := map[string]bool{}
+	value = strings.ReplaceAll(value, "；", catalogSpecificSeparator)
+	for _, item := range strings.Split(value, catalogSpecificSeparator) {
+		item = strings.TrimSpace(strings.ReplaceAll(item, "：", ":"))
+		if item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("规格格式错误: %s, 应为 规格名:规格项", item)
+		}
+		option := &CatalogSpecificOption{Specific: strings.TrimSpace(parts[0]), Option: strings.TrimSpace(parts[1])}
+		if seen[option.Specific] {
+			return nil, fmt.Errorf("规格重复: %s", option.Specific)
+		}
+		seen[option.Specific] = true
+		options = append(options, option)
+	}
+	return options, nil
+}
+
+func catalogSpecificKey(options []*CatalogSpecificOption, withOption bool) string {
+	keys := []string{}
+	for _, option := range options {
+		if withOption {
+			keys = append(keys, option.Specific+":"+option.Option)
+		} else {
+			keys = append(keys, option.Specific)
+		}
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, catalogSpecificSeparator)
+}
+
+// ParseCatalogRows
+//
+//	@Description: 解析导入文件的行, 第一行为表头, 按表头名称定位列, 产品货号和产品名称为必填列; 只做格式校验, 不访问数据库
+//	@param rows
+//	@return *CatalogParseResult
+func ParseCatalogRows(rows [][]string) *CatalogParseResult {
+	result := &CatalogParseResult{
+		Products:  []*CatalogProduct{},
+		RowErrors: []*model.ProductImportRowError{},
+	}
+	if len(rows) == 0 {
+		result.RowErrors = append(result.RowErrors, &model.ProductImportRowError{Row: 1, Message: "文件为空"})
+		return result
+	}
+
+	header := map[string]int{}
+	for i, name := range rows[0] {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\xEF\xBB\xBF"))
+		if name != "" {
+			header[name] = i
+		}
+	}
+	for _, column := range []string{CatalogColumnSPU, CatalogColumnName} {
+		if _, ok := header[column]; !ok {
+			result.RowErrors = append(result.RowErrors, &model.ProductImportRowError{Row: 1, Column: column, Message: "缺少必填列"})
+		}
+	}
+	if len(result.RowErrors) > 0 {
+		return result
+	}
+
+	mapProducts := map[string]*CatalogProduct{}
+	skuRows := map[string]int{}
+	for i, row := range rows[1:] {
+		rowNumber := i + 2
+		cell := func(column string) string {
+			index, ok := header[column]
+			if !ok || index >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[index])
+		}
+
+		isBlank := true
+		for _, value := range row {
+			if strings.TrimSpace(value) != "" {
+				isBlank = false
+				break
+			}
+		}
+		if isBlank {
+			continue
+		}
+		result.TotalRows++
+
+		spu := cell(CatalogColumnSPU)
+		if spu == "" {
+			result.RowErrors = append(result.RowErrors, &model.ProductImportRowError{Row: rowNumber, Column: CatalogColumnSPU, Message: "产品货号不能为空"})
+			continue
+		}
+		p, ok := mapProducts[spu]
+		if !ok {
+			p = &CatalogProduct{SPU: spu}
+			mapProducts[spu] = p
+			result.Products = append(result.Products, p)
+		}
+		p.Rows = append(p.Rows, rowNumber)
+
+		addError := func(column string, message string) {
+			p.Failed = true
+			result.RowErrors = append(result.RowErrors, &model.ProductImportRowError{Row: rowNumber, Column: column, Message: message})
+		}
+
+		// 产品字段取第一个非空值
+		if value := cell(CatalogColumnName); value != "" && p.Name == "" {
+			p.Name = value
+		}
+		if value := cell(CatalogColumnType); value != "" && p.TypeKey == "" {
+			p.TypeKey = value
+		}
+		if value := cell(CatalogColumnPlan); value != "" && p.PlanKey == "" {
+			p.PlanKey = value
+		}
+		if value := cell(CatalogColumnCategories); value != "" && len(p.CategoryPaths) == 0 {
+			for _, item := range splitCatalogList(value) {
+				names := []string{}
+				for _, name := range strings.Split(item, catalogCategorySeparator) {
+					if name = strings.TrimSpace(name); name != "" {
+						names = append(names, name)
+					}
+				}
+				if len(names) > 0 {
+					p.CategoryPaths = append(p.CategoryPaths, names)
+				}
+			}
+		}
+		if value := cell(CatalogColumnDescription); value != "" && p.Description == nil {
+			p.Description = &value
+		}
+		for column, target := range map[string]**bool{
+			CatalogColumnIsActivated:   &p.IsActivated,
+			CatalogColumnCanSellOnline: &p.CanSellOnline,
+		} {
+			if value := cell(column); value != "" && *target == nil {
+				if b, err := parseCatalogBool(value); err != nil {
+					addError(column, err.Error())
+				} else {
+					*target = &b
+				}
+			}
+		}
+		for column, target := range map[string]**time.Time{
+			CatalogColumnSaleStartDate: &p.SaleStartDate,
+			CatalogColumnSaleEndDate:   &p.SaleEndDate,
+		} {
+			if value := cell(column); value != "" && *target == nil {
+				if t, err := parseCatalogTime(value); err != nil {
+					addError(column, err.Error())
+				} else {
+					*target = &t
+				}
+			}
+		}
+		if value := cell(CatalogColumnSort); value != "" && p.Sort == nil {
+			if sortIndex, err := strconv.Atoi(value); err != nil {
+				addError(CatalogColumnSort, "无效的排序: "+value)
+			} else {
+				p.Sort = &sortIndex
+			}
+		}
+		for column, target := range map[string]*[]string{
+			CatalogColumnCoverImages:  &p.CoverImageUrls,
+			CatalogColumnDetailImages: &p.DetailImageUrls,
+		} {
+			if value := cell(column); value != "" && len(*target) == 0 {
+				urls := splitCatalogList(value)
+				for _, url := range urls {
+					if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+						addError(column, "无效的图片地址: "+url)
+					}
+				}
+				*target = urls
+			}
+		}
+		for column, target := range map[string]**float64{
+			CatalogColumnUnitPrice: &p.UnitPrice,
+			CatalogColumnListPrice: &p.ListPrice,
+		} {
+			if value := cell(column); value != "" && *target == nil {
+				if price, err := parseCatalogPrice(value); err != nil {
+					addError(column, err.Error())
+				} else {
+					*target = &price
+				}
+			}
+		}
+
+		// SKU字段
+		skuNo := cell(CatalogColumnSkuNo)
+		specifics := cell(CatalogColumnSpecifics)
+		if skuNo == "" {
+			for _, column := range []string{CatalogColumnSpecifics, CatalogColumnSkuInventory, CatalogColumnSkuUnitPrice, CatalogColumnSkuListPrice} {
+				if cell(column) != "" {
+					addError(CatalogColumnSkuNo, "填写SKU信息时必须填写SKU编号")
+					break
+				}
+			}
+			continue
+		}
+		if firstRow, ok := skuRows[skuNo]; ok {
+			addError(CatalogColumnSkuNo, fmt.Sprintf("SKU编号与第%d行重复", firstRow))
+			continue
+		}
+		skuRows[skuNo] = rowNumber
+
+		sku := &CatalogSKU{Row: rowNumber, SkuNo: skuNo}
+		if specifics == "" {
+			addError(CatalogColumnSpecifics, "SKU必须填写规格")
+		} else if options, err := ParseCatalogSpecifics(specifics); err != nil {
+			addError(CatalogColumnSpecifics, err.Error())
+		} else {
+			sku.Options = options
+		}
+		if value := cell(CatalogColumnSkuInventory); value != "" {
+			if inventory, err := strconv.Atoi(value); err != nil || inventory < 0 {
+				addError(CatalogColumnSkuInventory, "无效的库存: "+value)
+			} else {
+				sku.Inventory = &inventory
+			}
+		}
+		for column, target := range map[string]**float64{
+			CatalogColumnSkuUnitPrice: &sku.UnitPrice,
+			CatalogColumnSkuListPrice: &sku.ListPrice,
+		} {
+			if value := cell(column); value != "" {
+				if price, err := parseCatalogPrice(value); err != nil {
+					addError(column, err.Error())
+				} else {
+					*target = &price
+				}
+			}
+		}
+
+		// 同一产品的SKU规格名必须一致, 规格组合不能重复
+		if len(sku.Options) > 0 {
+			for _, other := range p.SKUs {
+				if len(other.Options) == 0 {
+					continue
+				}
+				if catalogSpecificKey(other.Options, false) != catalogSpecificKey(sku.Options, false) {
+					addError(CatalogColumnSpecifics, fmt.Sprintf("规格与第%d行的SKU不一致", other.Row))
+					break
+				}
+				if catalogSpecificKey(other.Options, true) == catalogSpecificKey(sku.Options, true) {
+					addError(CatalogColumnSpecifics, fmt.Sprintf("规格组合与第%d行的SKU重复", other.Row))
+					break
+				}
+			}
+		}
+		p.SKUs = append(p.SKUs, sku)
+	}
+
+	for _, p := range result.Products {
+		if p.Name == "" {
+			result.AddProductError(p, CatalogColumnName, "产品名称不能为空")
+		}
+		if p.SaleStartDate != nil && p.SaleEndDate != nil && p.SaleEndDate.Before(*p.SaleStartDate) {
+			result.AddProductError(p, CatalogColumnSaleEndDate, "售卖结束时间不能早于开始时间")
+		}
+	}
+
+	return result
+}
+
+// ValidateCatalogProducts
+//
+//	@Description: 按数据库校验解析后的产品, 填写已存在的产品及类型计划Id, 有错误的产品标记为失败
+//	@receiver uc
+//	@param ctx
+//	@param result
+func (uc *ProductCatalogUseCase) ValidateCatalogProducts(ctx context.Context, result *CatalogParseResult) {
+	products := result.ValidProducts()
+	if len(products) == 0 {
+		return
+	}
+
+	// 按产品货号匹配已存在的产品
+	spus := []string{}
+	for _, p := range products {
+		spus = append(spus, p.SPU)
+	}
+	existingProducts := []*model.Product{}
+	if err := uc.db.WithContext(ctx).Where("spu IN ?", spus).Order("id").Find(&existingProducts).Error; err != nil {
+		panic(err)
+	}
+	mapExisting := map[string]*model.Product{}
+	for _, existing := range existingProducts {
+		if _, ok := mapExisting[existing.SPU]; ok {
+			continue
+		}
+		mapExisting[existing.SPU] = existing
+	}
+
+	// SKU编号不能属于其他产品
+	skuNos := []string{}
+	for _, p := range products {
+		for _, sku := range p.SKUs {
+			skuNos = append(skuNos, sku.SkuNo)
+		}
+	}
+	mapSkuProductIds := map[string]int64{}
+	if len(skuNos) > 0 {
+		skus := []*model.SKU{}
+		if err := uc.db.WithContext(ctx).Where("sku_no IN ?", skuNos).Find(&skus).Error; err != nil {
+			panic(err)
+		}
+		for _, sku := range skus {
+			mapSkuProductIds[sku.SkuNo] = sku.ProductId
+		}
+	}
+
+	ucDD := powerx.NewDataDictionaryUseCase(uc.db)
+	getDDId := func(itemType string, key string) int {
+		item, err := ucDD.GetDataDictionaryItem(ctx, itemType, key)
+		if err != nil {
+			return 0
+		}
+		return int(item.Id)
+	}
+	hasStandardPriceBook := getStandardPriceBookId(ctx, uc.db) > 0
+
+	for _, p := range products {
+		p.Existing = mapExisting[p.SPU]
+
+		// 新产品未填写类型和计划时默认为一次性商品
+		typeKey, planKey := p.TypeKey, p.PlanKey
+		if p.Existing == nil && typeKey == "" {
+			typeKey = model.ProductTypeGoods
+		}
+		if p.Existing == nil && planKey == "" {
+			planKey = model.ProductPlanOnce
+		}
+		if typeKey != "" {
+			if p.TypeId = getDDId(model.TypeProductType, typeKey); p.TypeId == 0 {
+				result.AddProductError(p, CatalogColumnType, "未知的产品类型: "+typeKey)
+			}
+		}
+		if planKey != "" {
+			if p.PlanId = getDDId(model.TypeProductPlan, planKey); p.PlanId == 0 {
+				result.AddProductError(p, CatalogColumnPlan, "未知的产品计划: "+planKey)
+			}
+		}
+
+		for _, sku := range p.SKUs {
+			productId, ok := mapSkuProductIds[sku.SkuNo]
+			if ok && (p.Existing == nil || productId != p.Existing.Id) {
+				p.Failed = true
+				result.RowErrors = append(result.RowErrors, &model.ProductImportRowError{
+					Row: sku.Row, Column: CatalogColumnSkuNo, Message: "SKU编号已被其他产品使用",
+				})
+			}
+		}
+
+		if p.HasPrices() && !hasStandardPriceBook {
+			result.AddProductError(p, CatalogColumnUnitPrice, "未设置标准价格手册, 无法导入价格")
+		}
+	}
+}
+
+// FindOrCreateCategoryByPath 按品类路径查找品类, 不存在的品类逐级创建
+func (uc *ProductCatalogUseCase) FindOrCreateCategoryByPath(ctx context.Context, names []string) *model.ProductCategory {
+	var category *model.ProductCategory
+	var pId int64
+	for _, name := range names {
+		category = &model.ProductCategory{}
+		err := uc.db.WithContext(ctx).Where("p_id = ? AND name = ?", pId, name).Order("id").First(category).Error
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				panic(err)
+			}
+			category = &model.ProductCategory{PId: pId, Name: name}
+			if err = uc.db.WithContext(ctx).Create(category).Error; err != nil {
+				panic(err)
+			}
+		}
+		pId = category.Id
+	}
+	return category
+}
+
+// FindOrCreateMediaResourcesByUrls 按地址查找媒体资源, 不存在的地址作为外部资源创建, 按地址顺序返回
+func (uc *ProductCatalogUseCase) FindOrCreateMediaResourcesByUrls(ctx context.Context, urls []string) []*media.MediaResource {
+	resources := []*media.MediaResource{}
+	if len(urls) == 0 {
+		return resources
+	}
+	existing := []*media.MediaResource{}
+	if err := uc.db.WithContext(ctx).Where("url IN ?", urls).Order("id").Find(&existing).Error; err != nil {
+		panic(err)
+	}
+	mapResources := map[string]*media.MediaResource{}
+	for _, resource := range existing {
+		if _, ok := mapResources[resource.Url]; !ok {
+			mapResources[resource.Url] = resource
+		}
+	}
+	for _, url := range urls {
+		resource, ok := mapResources[url]
+		if !ok {
+			resource = &media.MediaResource{
+				Filename:     path.Base(url),
+				Url:          url,
+				ResourceType: "image",
+			}
+			if err := uc.db.WithContext(ctx).Create(resource).Error; err != nil {
+				panic(err)
+			}
+			mapResources[url] = resource
+		}
+		resources = append(resources, resource)
+	}
+	return resources
+}
+
+// SaveCatalogSKUs
+//
+//	@Description: 保存产品的规格及SKU, 规格和规格项按名称匹配, 不存在时创建; SKU先按编号匹配, 再按规格组合匹配;
+//	同时更新产品所有SKU的规格关联, 以及标准价格手册中的价格
+//	@receiver uc
+//	@param ctx
+//	@param productId
+//	@param p
+//	@return error
+func (uc *ProductCatalogUseCase) SaveCatalogSKUs(ctx context.Context, productId int64, p *CatalogProduct) error {
+	priceBookId := getStandardPriceBookId(ctx, uc.db)
+
+	return uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if p.UnitPrice != nil || p.ListPrice != nil {
+			if err := upsertCatalogPrice(tx, priceBookId, productId, 0, p.UnitPrice, p.ListPrice); err != nil {
+				return err
+			}
+		}
+		if len(p.SKUs) == 0 {
+			return nil
+		}
+
+		specifics := []*model.ProductSpecific{}
+		err := tx.Preload("Options", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+			Where("product_id = ?", productId).
+			Order("id").
+			Find(&specifics).Error
+		if err != nil {
+			return err
+		}
+
+		findSpecific := func(name string) (*model.ProductSpecific, error) {
+			for _, specific := range specifics {
+				if specific.Name == name {
+					return specific, nil
+				}
+			}
+			specific := &model.ProductSpecific{ProductId: productId, Name: name}
+			if err := tx.Create(specific).Error; err != nil {
+				return nil, err
+			}
+			specifics = append(specifics, specific)
+			return specific, nil
+		}
+		findOption := func(specific *model.ProductSpecific, name string) (*model.SpecificOption, error) {
+			for _, option := range specific.Options {
+				if option.Name == name {
+					return option, nil
+				}
+			}
+			option := &model.SpecificOption{ProductSpecificId: specific.Id, Name: name, IsActivated: true}
+			if err := tx.Create(option).Error; err != nil {
+				return nil, err
+			}
+			specific.Options = append(specific.Options, option)
+			return option, nil
+		}
+
+		// 先创建规格和规格项, 规格项Id按规格顺序排列, 与生成SKU时一致
+		mapSkuOptions := map[*CatalogSKU]map[int64]int64{}
+		for _, catalogSKU := range p.SKUs {
+			mapOptions := map[int64]int64{}
+			for _, item := range catalogSKU.Options {
+				specific, err := findSpecific(item.Specific)
+				if err != nil {
+					return err
+				}
+				option, err := findOption(specific, item.Option)
+				if err != nil {
+					return err
+				}
+				mapOptions[specific.Id] = option.Id
+			}
+			mapSkuOptions[catalogSKU] = mapOptions
+		}
+
+		for _, catalogSKU := range p.SKUs {
+			optionIds := []int64{}
+			for _, specific := range specifics {
+				if optionId, ok := mapSkuOptions[catalogSKU][specific.Id]; ok {
+					optionIds = append(optionIds, optionId)
+				}
+			}
+			sku := &model.SKU{ProductId: productId}
+			sku.OptionIds, _ = json.Marshal(optionIds)
+			uniqueId := sku.GetComposedUniqueID()
+
+			existing := &model.SKU{}
+			err := tx.Where("product_id = ? AND sku_no = ?", productId, catalogSKU.SkuNo).First(existing).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				err = tx.Where("product_id = ? AND index_unique_id = ?", productId, uniqueId).First(existing).Error
+			}
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+
+			if existing.Id > 0 {
+				var count int64
+				err = tx.Model(&model.SKU{}).
+					Where("product_id = ? AND index_unique_id = ? AND id <> ?", productId, uniqueId, existing.Id).
+					Count(&count).Error
+				if err != nil {
+					return err
+				}
+				if count > 0 {
+					return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("SKU %s 的规格组合与其他SKU重复", catalogSKU.SkuNo))
+				}
+				values := map[string]interface{}{
+					"sku_no":          catalogSKU.SkuNo,
+					"option_ids":      sku.OptionIds,
+					"index_unique_id": uniqueId,
+					"is_retired":      false,
+					"retired_at":      nil,
+				}
+				if catalogSKU.Inventory != nil {
+					values["inventory"] = *catalogSKU.Inventory
+				}
+				if err = tx.Model(existing).Updates(values).Error; err != nil {
+					return err
+				}
+				sku.Id = existing.Id
+			} else {
+				sku.SkuNo = catalogSKU.SkuNo
+				sku.UniqueID = uniqueId
+				if catalogSKU.Inventory != nil {
+					sku.Inventory = *catalogSKU.Inventory
+				}
+				if err = tx.Create(sku).Error; err != nil {
+					return err
+				}
+			}
+
+			if catalogSKU.UnitPrice != nil || catalogSKU.ListPrice != nil {
+				if err = upsertCatalogPrice(tx, priceBookId, productId, sku.Id, catalogSKU.UnitPrice, catalogSKU.ListPrice); err != nil {
+					return err
+				}
+			}
+		}
+
+		// 新增规格项后, 产品所有SKU的规格关联都需要补齐
+		skus := []*model.SKU{}
+		if err = tx.Where("product_id = ?", productId).Find(&skus).Error; err != nil {
+			return err
+		}
+		pivots := NewProductUseCase(tx).GeneratePivotSKUsFromSpecifics(ctx, specifics, skus)
+		if len(pivots) > 0 {
+			err = tx.Model(&model.PivotSkuToSpecificOption{}).
+				Clauses(clause.OnConflict{
+					Columns:   []clause.Column{{Name: model.PivotPivotSkuToSpecificOptionsUniqueId}},
+					DoUpdates: clause.AssignmentColumns([]string{"is_activated", "updated_at"}),
+				}).Create(&pivots).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// upsertCatalogPrice 更新价格手册条目, 未填写的价格保持不变, 新条目未填写零售价时与标准价相同
+func upsertCatalogPrice(tx *gorm.DB, priceBookId int64, productId int64, skuId int64, unitPrice *float64, listPrice *float64) error {
+	entry := &model.PriceBookEntry{
+		PriceBookId: priceBookId,
+		ProductId:   productId,
+		SkuId:       skuId,
+		IsActive:    true,
+	}
+	entry.UniqueID = entry.GetComposedUniqueID()
+	columns := []string{"is_active", "updated_at"}
+	if unitPrice != nil {
+		entry.UnitPrice = *unitPrice
+		columns = append(columns, "unit_price")
+	}
+	if listPrice != nil {
+		entry.ListPrice = *listPrice
+		columns = append(columns, "list_price")
+	} else {
+		entry.ListPrice = entry.UnitPrice
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: model.PriceBookEntryUniqueId}},
+		DoUpdates: clause.AssignmentColumns(columns),
+	}).Create(entry).Error
+}
+
+func (uc *ProductCatalogUseCase) CreateImportJob(ctx context.Context, job *model.ProductImportJob) {
+	job.Status = model.ProductImportStatusPending
+	if err := uc.db.WithContext(ctx).Create(job).Error; err != nil {
+		panic(err)
+	}
+}
+
+func (uc *ProductCatalogUseCase) GetImportJob(ctx context.Context, id int64) (*model.ProductImportJob, error) {
+	job := &model.ProductImportJob{}
+	if err := uc.db.WithContext(ctx).Omit("content").First(job, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "未找到导入任务")
+		}
+		panic(err)
+	}
+	return job, nil
+}
+
+// StartImportJob 将等待处理的任务标记为处理中并返回任务及文件内容, 任务已被处理时返回nil
+func (uc *ProductCatalogUseCase) StartImportJob(ctx context.Context, id int64) *model.ProductImportJob {
+	startedAt := time.Now()
+	result := uc.db.WithContext(ctx).Model(&model.ProductImportJob{}).
+		Where("id = ? AND status = ?", id, model.ProductImportStatusPending).
+		Updates(map[string]interface{}{
+			"status":     model.ProductImportStatusProcessing,
+			"started_at": startedAt,
+		})
+	if result.Error != nil {
+		panic(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil
+	}
+	job := &model.ProductImportJob{}
+	if err := uc.db.WithContext(ctx).First(job, id).Error; err != nil {
+		panic(err)
+	}
+	return job
+}
+
+// UpdateImportJobProgress 更新处理进度
+func (uc *ProductCatalogUseCase) UpdateImportJobProgress(ctx context.Context, job *model.ProductImportJob) {
+	err := uc.db.WithContext(ctx).Model(&model.ProductImportJob{}).
+		Where("id = ?", job.Id).
+		Updates(map[string]interface{}{
+			"total_rows":       job.TotalRows,
+			"processed_rows":   job.ProcessedRows,
+			"failed_rows":      job.FailedRows,
+			"created_products": job.CreatedProducts,
+			"updated_products": job.UpdatedProducts,
+		}).Error
+	if err != nil {
+		panic(err)
+	}
+}
+
+// FinishImportJob 保存处理结果, message不为空时任务标记为失败
+func (uc *ProductCatalogUseCase) FinishImportJob(ctx context.Context, job *model.ProductImportJob, rowErrors []*model.ProductImportRowError, message string) {
+	finishedAt := time.Now()
+	job.Status = model.ProductImportStatusCompleted
+	if message != "" {
+		job.Status = model.ProductImportStatusFailed
+	}
+	if rowErrors == nil {
+		rowErrors = []*model.ProductImportRowError{}
+	}
+	job.RowErrors, _ = json.Marshal(rowErrors)
+	job.Message = message
+	job.FinishedAt = &finishedAt
+
+	err := uc.db.WithContext(ctx).Model(&model.ProductImportJob{}).
+		Where("id = ?", job.Id).
+		Updates(map[string]interface{}{
+			"status":           job.Status,
+			"total_rows":       job.TotalRows,
+			"processed_rows":   job.ProcessedRows,
+			"failed_rows":      job.FailedRows,
+			"created_products": job.CreatedProducts,
+			"updated_products": job.UpdatedProducts,
+			"row_errors":       job.RowErrors,
+			"message":          job.Message,
+			"finished_at":      finishedAt,
+			// 处理完成后不再需要保留文件内容
+			"content": nil,
+		}).Error
+	if err != nil {
+		panic(err)
+	}
+}
+
+// BuildCatalogRows 将产品转换为导出的行, 包含表头; 产品需预加载品类, 图片, 价格, 规格及SKU
+func (uc *ProductCatalogUseCase) BuildCatalogRows(ctx context.Context, products []*model.Product) [][]string {
+	categories := loadCategories(ctx, uc.db)
+	priceBookId := getStandardPriceBookId(ctx, uc.db)
+
+	ucDD := powerx.NewDataDictionaryUseCase(uc.db)
+	ddKeys := map[int]string{}
+	getDDKey := func(id int) string {
+		if id == 0 {
+			return ""
+		}
+		if key, ok := ddKeys[id]; ok {
+			return key
+		}
+		key := ""
+		if item, err := ucDD.GetDataDictionaryItemById(ctx, id); err == nil {
+			key = item.Key
+		}
+		ddKeys[id] = key
+		return key
+	}
+
+	rows := [][]string{CatalogHeaders}
+	for _, product := range products {
+		rows = append(rows, TransformProductToCatalogRows(product, categories, priceBookId, getDDKey(product.Type), getDDKey(product.Plan))...)
+	}
+	return rows
+}
+
+// FormatCatalogTime 未设置的售卖时间导出为空
+func FormatCatalogTime(t time.Time) string {
+	if !t.After(model.ProductSaleDateUnsetBefore) {
+		return ""
+	}
+	return t.Format(carbonx.GoDatetimeFormat)
+}
+
+func formatCatalogPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', -1, 64)
+}
+
+func formatCatalogBool(b bool) string {
+	if b {
+		return "是"
+	}
+	return "否"
+}
+
+// TransformProductToCatalogRows 产品字段只写在第一行, 每个SKU一行, 没有SKU的产品只有一行
+func TransformProductToCatalogRows(product *model.Product, categories map[int64]*model.ProductCategory, priceBookId int64, typeKey string, planKey string) [][]string {
+	categoryPaths := []string{}
+	for _, category := range product.ProductCategories {
+		names := []string{}
+		for _, node := range GetCategoryChain(categories, category.Id) {
+			names = append(names, node.Name)
+		}
+		if len(names) > 0 {
+			categoryPaths = append(categoryPaths, strings.Join(names, catalogCategorySeparator))
+		}
+	}
+	imageUrls := func(pivots []*media.PivotMediaResourceToObject) string {
+		urls := []string{}
+		for _, pivot := range pivots {
+			if pivot.MediaResource != nil && pivot.MediaResource.Url != "" {
+				urls = append(urls, pivot.MediaResource.Url)
+			}
+		}
+		return strings.Join(urls, catalogListSeparator)
+	}
+
+	unitPrice, listPrice := "", ""
+	for _, entry := range product.PriceBookEntries {
+		if entry.PriceBookId == priceBookId && entry.SkuId == 0 {
+			unitPrice, listPrice = formatCatalogPrice(entry.UnitPrice), formatCatalogPrice(entry.ListPrice)
+		}
+	}
+
+	productColumns := map[string]string{
+		CatalogColumnSPU:           product.SPU,
+		CatalogColumnName:          product.Name,
+		CatalogColumnType:          typeKey,
+		CatalogColumnPlan:          planKey,
+		CatalogColumnCategories:    strings.Join(categoryPaths, catalogListSeparator),
+		CatalogColumnDescription:   product.Description,
+		CatalogColumnIsActivated:   formatCatalogBool(product.IsActivated),
+		CatalogColumnCanSellOnline: formatCatalogBool(product.CanSellOnline),
+		CatalogColumnSaleStartDate: FormatCatalogTime(product.SaleStartDate),
+		CatalogColumnSaleEndDate:   FormatCatalogTime(product.SaleEndDate),
+		CatalogColumnSort:          strconv.Itoa(product.Sort),
+		CatalogColumnCoverImages:   imageUrls(product.PivotCoverImages),
+		CatalogColumnDetailImages:  imageUrls(product.PivotDetailImages),
+		CatalogColumnUnitPrice:     unitPrice,
+		CatalogColumnListPrice:     listPrice,
+	}
+
+	mapOptions := map[int64]*CatalogSpecificOption{}
+	for _, specific := range product.ProductSpecifics {
+		for _, option := range specific.Options {
+			mapOptions[option.Id] = &CatalogSpecificOption{Specific: specific.Name, Option: option.Name}
+		}
+	}
+
+	toRow := func(columns map[string]string) []string {
+		row := make([]string, len(CatalogHeaders))
+		for i, header := range CatalogHeaders {
+			row[i] = columns[header]
+		}
+		return row
+	}
+
+	if len(product.SKUs) == 0 {
+		return [][]string{toRow(productColumns)}
+	}
+
+	rows := [][]string{}
+	for i, sku := range product.SKUs {
+		columns := map[string]string{CatalogColumnSPU: product.SPU}
+		if i == 0 {
+			columns = productColumns
+		}
+		optionIds := []int64{}
+		_ = json.Unmarshal(sku.OptionIds, &optionIds)
+		specifics := []string{}
+		for _, optionId := range optionIds {
+			if option, ok := mapOptions[optionId]; ok {
+				specifics = append(specifics, option.Specific+":"+option.Option)
+			}
+		}
+		columns[CatalogColumnSkuNo] = sku.SkuNo
+		columns[CatalogColumnSpecifics] = strings.Join(specifics, catalogSpecificSeparator)
+		columns[CatalogColumnSkuInventory] = strconv.Itoa(sku.Inventory)
+		if sku.PriceBookEntry != nil && sku.PriceBookEntry.PriceBookId == priceBookId {
+			columns[CatalogColumnSkuUnitPrice] = formatCatalogPrice(sku.PriceBookEntry.UnitPrice)
+			columns[CatalogColumnSkuListPrice] = formatCatalogPrice(sku.PriceBookEntry.ListPrice)
+		}
+		rows = append(rows, toRow(columns))
+	}
+	return rows
+}
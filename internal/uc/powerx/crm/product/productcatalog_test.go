@@ -0,0 +1,106 @@
+package product
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCatalogRows(t *testing.T) {
+
+	result := ParseCatalogRows(GetCatalogTemplateRows())
+	if len(result.RowErrors) > 0 {
+		t.Fatalf("template rows errors = %+v", result.RowErrors[0])
+	}
+	if result.TotalRows != 2 || len(result.Products) != 1 {
+		t.Fatalf("total rows = %d, products = %d, want 2 and 1", result.TotalRows, len(result.Products))
+	}
+
+	p := result.Products[0]
+	if p.SPU != "SPU-001" || p.Name != "拿铁咖啡" || !reflect.DeepEqual(p.Rows, []int{2, 3}) {
+		t.Errorf("product = %s %s rows %v", p.SPU, p.Name, p.Rows)
+	}
+	if want := [][]string{{"饮品", "咖啡"}, {"新品"}}; !reflect.DeepEqual(p.CategoryPaths, want) {
+		t.Errorf("category paths = %v, want %v", p.CategoryPaths, want)
+	}
+	if p.IsActivated == nil || !*p.IsActivated || p.SaleStartDate == nil || p.SaleEndDate != nil {
+		t.Errorf("product flags or dates not parsed")
+	}
+	if len(p.DetailImageUrls) != 2 || p.UnitPrice == nil || *p.UnitPrice != 25 {
+		t.Errorf("images or prices not parsed")
+	}
+	if len(p.SKUs) != 2 || p.SKUs[1].SkuNo != "SPU-001-L-HOT" || *p.SKUs[1].Inventory != 80 || *p.SKUs[1].ListPrice != 33 {
+		t.Fatalf("skus not parsed")
+	}
+	if want := []*CatalogSpecificOption{{"杯型", "大杯"}, {"温度", "热"}}; !reflect.DeepEqual(p.SKUs[1].Options, want) {
+		t.Errorf("sku options = %v, want %v", p.SKUs[1].Options, want)
+	}
+}
+
+func TestParseCatalogRowsErrors(t *testing.T) {
+
+	result := ParseCatalogRows([][]string{{"产品名称"}})
+	if len(result.RowErrors) != 1 || result.RowErrors[0].Column != CatalogColumnSPU {
+		t.Errorf("missing spu column errors = %+v", result.RowErrors)
+	}
+
+	rows := [][]string{
+		{CatalogColumnSPU, CatalogColumnName, CatalogColumnIsActivated, CatalogColumnSkuNo, CatalogColumnSpecifics, CatalogColumnSkuUnitPrice},
+		{"A", "产品A", "也许", "", "", ""},
+		{"B", "产品B", "", "B-1", "颜色:红", "10"},
+		{"B", "", "", "B-2", "尺码:L", ""},
+		{"C", "产品C", "", "B-1", "颜色:蓝", ""},
+		{"D", "", "", "D-1", "颜色", "-1"},
+		{"", "", "", "", "", ""},
+		{"E", "产品E", "否", "", "", ""},
+		{"", "无货号", "", "", "", ""},
+	}
+	result = ParseCatalogRows(rows)
+	if result.TotalRows != 7 {
+		t.Errorf("total rows = %d, want 7", result.TotalRows)
+	}
+
+	type rowError struct {
+		Row    int
+		Column string
+	}
+	got := []rowError{}
+	for _, e := range result.RowErrors {
+		got = append(got, rowError{e.Row, e.Column})
+	}
+	want := []rowError{
+		{2, CatalogColumnIsActivated},
+		{4, CatalogColumnSpecifics},
+		{5, CatalogColumnSkuNo},
+		{6, CatalogColumnSpecifics},
+		{6, CatalogColumnSkuUnitPrice},
+		{9, CatalogColumnSPU},
+		{6, CatalogColumnName},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("row errors = %v, want %v", got, want)
+	}
+
+	valid := result.ValidProducts()
+	if len(valid) != 1 || valid[0].SPU != "E" || *valid[0].IsActivated {
+		t.Errorf("valid products = %v", valid)
+	}
+	if failed := result.FailedRows(); failed != 6 {
+		t.Errorf("failed rows = %d, want 6", failed)
+	}
+}
+
+func TestParseCatalogSpecifics(t *testing.T) {
+
+	got, err := ParseCatalogSpecifics("颜色：红； 尺码:L;")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []*CatalogSpecificOption{{"颜色", "红"}, {"尺码", "L"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("specifics = %v, want %v", got, want)
+	}
+	for _, value := range []string{"颜色:红;颜色:蓝", "颜色:", "红"} {
+		if _, err = ParseCatalogSpecifics(value); err == nil {
+			t.Errorf("specifics %q should be invalid", value)
+		}
+	}
+}
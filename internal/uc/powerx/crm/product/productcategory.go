@@ -21,7 +21,7 @@ func NewProductCategoryUseCase(db *gorm.DB) *ProductCategoryUseCase {
 }
 
 type FindProductCategoryOption struct {
-	OrderBy     string
+	QuerySpec   *powermodel.QuerySpec
 	CategoryPId int
 	Limit       int
 	Ids         []int64
@@ -39,10 +39,8 @@ func (uc *ProductCategoryUseCase) buildFindQueryNoPage(query *gorm.DB, opt *Find
 		query.Limit(opt.Limit)
 	}
 
+	query = opt.QuerySpec.Apply(query)
 	orderBy := "sort desc, id "
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
-	}
 	query.Order(orderBy)
 
 	return query
@@ -0,0 +1,344 @@
+package product
+
+import (
+	"PowerX/internal/model/crm/customerdomain"
+	model "PowerX/internal/model/crm/product"
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/model/media"
+	"PowerX/internal/types"
+	"PowerX/internal/types/errorx"
+	"PowerX/internal/uc/powerx"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 产品评价: 客户对已完成订单的订单项评分、填写内容和上传图片, 每个订单项只能评价一次,
+// 评价需要审核通过后才在小程序展示, 并计入产品统计的评分和评价数
+type ProductReviewUseCase struct {
+	db *gorm.DB
+}
+
+func NewProductReviewUseCase(db *gorm.DB) *ProductReviewUseCase {
+	return &ProductReviewUseCase{
+		db: db,
+	}
+}
+
+type FindManyProductReviewsOption struct {
+	ProductId  int64
+	CustomerId int64
+	Statuses   []string
+	Rating     int
+	WithImages bool
+	SortBy     string
+	types.PageEmbedOption
+}
+
+func (uc *ProductReviewUseCase) buildFindQueryNoPage(db *gorm.DB, opt *FindManyProductReviewsOption) *gorm.DB {
+	if opt.ProductId > 0 {
+		db = db.Where("product_id = ?", opt.ProductId)
+	}
+	if opt.CustomerId > 0 {
+		db = db.Where("customer_id = ?", opt.CustomerId)
+	}
+	if len(opt.Statuses) > 0 {
+		db = db.Where("status IN ?", opt.Statuses)
+	}
+	if opt.Rating > 0 {
+		db = db.Where("rating = ?", opt.Rating)
+	}
+	if opt.WithImages {
+		db = db.Where("EXISTS (?)", uc.db.Model(&media.PivotMediaResourceToObject{}).
+			Select("1").
+			Where("object_type = ? AND object_id = product_reviews.id", model.TableNameProductReview))
+	}
+
+	switch opt.SortBy {
+	case model.ProductReviewSortHelpful:
+		db = db.Order("helpful_count DESC, id DESC")
+	default:
+		db = db.Order("id DESC")
+	}
+
+	return db
+}
+
+func (uc *ProductReviewUseCase) PreloadItems(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("PivotImages", "media_usage = ?", media.MediaUsageReview, func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort")
+		}).
+		Preload("PivotImages.MediaResource")
+}
+
+func (uc *ProductReviewUseCase) FindManyProductReviews(ctx context.Context, opt *FindManyProductReviewsOption) types.Page[*model.ProductReview] {
+	var reviews []*model.ProductReview
+	var count int64
+	query := uc.db.WithContext(ctx).Model(&model.ProductReview{})
+
+	query = uc.buildFindQueryNoPage(query, opt)
+	if err := query.Count(&count).Error; err != nil {
+		panic(errors.Wrap(err, "find many product reviews failed"))
+	}
+
+	opt.DefaultPageIfNotSet()
+	if opt.PageIndex != 0 && opt.PageSize != 0 {
+		query.Offset((opt.PageIndex - 1) * opt.PageSize).Limit(opt.PageSize)
+	}
+
+	query = uc.PreloadItems(query)
+	if err := query.Find(&reviews).Error; err != nil {
+		panic(errors.Wrap(err, "find many product reviews failed"))
+	}
+	return types.Page[*model.ProductReview]{
+		List:      reviews,
+		PageIndex: opt.PageIndex,
+		PageSize:  opt.PageSize,
+		Total:     count,
+	}
+}
+
+func (uc *ProductReviewUseCase) GetProductReview(ctx context.Context, id int64) (*model.ProductReview, error) {
+	review := &model.ProductReview{}
+	if err := uc.PreloadItems(uc.db.WithContext(ctx)).First(review, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "未找到评价")
+		}
+		panic(err)
+	}
+	return review, nil
+}
+
+// ValidateProductReview 校验评分、内容长度和图片数量
+func ValidateProductReview(review *model.ProductReview, imageCount int) error {
+	if review.Rating < model.ProductReviewMinRating || review.Rating > model.ProductReviewMaxRating {
+		return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("评分必须在%d到%d之间", model.ProductReviewMinRating, model.ProductReviewMaxRating))
+	}
+	if utf8.RuneCountInString(review.Content) > model.ProductReviewMaxContentLen {
+		return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("评价内容不能超过%d字", model.ProductReviewMaxContentLen))
+	}
+	if imageCount > model.ProductReviewMaxImages {
+		return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("评价图片不能超过%d张", model.ProductReviewMaxImages))
+	}
+	return nil
+}
+
+// CreateProductReview
+//
+//	@Description: 客户评价自己已完成订单中的订单项, 产品和规格从订单项解析, 评价创建后待审核
+//	@receiver uc
+//	@param ctx
+//	@param customer 当前登录客户
+//	@param review 需要填写订单项Id、评分、内容和是否匿名
+//	@param imageIds 客户自己上传的图片资源Id
+//	@return *model.ProductReview
+//	@return error
+func (uc *ProductReviewUseCase) CreateProductReview(ctx context.Context, customer *customerdomain.Customer, review *model.ProductReview, imageIds []int64) (*model.ProductReview, error) {
+	if err := ValidateProductReview(review, len(imageIds)); err != nil {
+		return nil, err
+	}
+
+	orderItem := &trade.OrderItem{}
+	if err := uc.db.WithContext(ctx).Preload("Order").First(orderItem, review.OrderItemId).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "未找到订单项")
+		}
+		panic(err)
+	}
+	if orderItem.Order == nil || orderItem.Order.CustomerId != customer.Id {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "只能评价自己购买的商品")
+	}
+	ucDD := powerx.NewDataDictionaryUseCase(uc.db)
+	if orderItem.Order.Status != ucDD.GetCachedDDId(ctx, trade.TypeOrderStatus, trade.OrderStatusCompleted) {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "订单完成后才能评价")
+	}
+
+	var count int64
+	if err := uc.db.WithContext(ctx).Model(&model.ProductReview{}).Where("order_item_id = ?", orderItem.Id).Count(&count).Error; err != nil {
+		panic(err)
+	}
+	if count > 0 {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "该商品已评价过")
+	}
+
+	itemProduct, ok := findOrderItemProducts(ctx, uc.db, orderItem.Order.CartId > 0, []*trade.OrderItem{orderItem})[orderItem.Id]
+	if !ok {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "未找到订单商品")
+	}
+
+	images := []*media.MediaResource{}
+	if len(imageIds) > 0 {
+		if err := uc.db.WithContext(ctx).Where("id IN ? AND customer_id = ?", imageIds, customer.Id).Find(&images).Error; err != nil {
+			panic(err)
+		}
+		if len(images) != len(imageIds) {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "评价图片无效")
+		}
+	}
+
+	review.Id = 0
+	review.ProductId = itemProduct.ProductId
+	review.SkuId = itemProduct.SkuId
+	review.OrderId = orderItem.OrderId
+	review.CustomerId = customer.Id
+	review.CustomerName = customer.Name
+	review.SkuNo = orderItem.SkuNo
+	review.Status = model.ProductReviewStatusPending
+	review.HelpfulCount = 0
+
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(review).Error; err != nil {
+			return err
+		}
+		if len(images) == 0 {
+			return nil
+		}
+		mapImages := map[int64]*media.MediaResource{}
+		for _, image := range images {
+			mapImages[image.Id] = image
+		}
+		sortedImages := []*media.MediaResource{}
+		for _, id := range imageIds {
+			sortedImages = append(sortedImages, mapImages[id])
+		}
+		pivots, err := (&media.PivotMediaResourceToObject{}).MakeMorphPivotsFromObjectToMediaResources(review, sortedImages, media.MediaUsageReview)
+		if err != nil {
+			return err
+		}
+		for i, pivot := range pivots {
+			pivot.Sort = i
+		}
+		return tx.Create(&pivots).Error
+	})
+	if err != nil {
+		// todo use errors.Is() when gorm update ErrDuplicatedKey
+		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "该商品已评价过")
+		}
+		panic(err)
+	}
+
+	return uc.GetProductReview(ctx, review.Id)
+}
+
+// ModerateProductReview 审核通过或隐藏评价, 状态变化后刷新产品的评分统计
+func (uc *ProductReviewUseCase) ModerateProductReview(ctx context.Context, id int64, status string, hiddenReason string, moderatorId int64) (*model.ProductReview, error) {
+	if status != model.ProductReviewStatusApproved && status != model.ProductReviewStatusHidden {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "审核状态无效")
+	}
+	review, err := uc.GetProductReview(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if status == model.ProductReviewStatusApproved {
+		hiddenReason = ""
+	}
+
+	now := time.Now()
+	err = uc.db.WithContext(ctx).Model(&model.ProductReview{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":        status,
+		"hidden_reason": hiddenReason,
+		"moderated_by":  moderatorId,
+		"moderated_at":  &now,
+	}).Error
+	if err != nil {
+		panic(err)
+	}
+
+	if review.Status != status {
+		uc.RefreshProductReviewStatistics(ctx, review.ProductId)
+	}
+
+	return uc.GetProductReview(ctx, id)
+}
+
+// ReplyProductReview 商家回复评价, 重复回复会覆盖之前的回复
+func (uc *ProductReviewUseCase) ReplyProductReview(ctx context.Context, id int64, reply string, replierId int64) (*model.ProductReview, error) {
+	if reply == "" {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "回复内容不能为空")
+	}
+	if utf8.RuneCountInString(reply) > model.ProductReviewMaxContentLen {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("回复内容不能超过%d字", model.ProductReviewMaxContentLen))
+	}
+	if _, err := uc.GetProductReview(ctx, id); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err := uc.db.WithContext(ctx).Model(&model.ProductReview{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"reply":      reply,
+		"replied_by": replierId,
+		"replied_at": &now,
+	}).Error
+	if err != nil {
+		panic(err)
+	}
+
+	return uc.GetProductReview(ctx, id)
+}
+
+// MarkProductReviewHelpful 客户标记已通过的评价有用, 重复标记不重复计数
+func (uc *ProductReviewUseCase) MarkProductReviewHelpful(ctx context.Context, id int64, customerId int64) (*model.ProductReview, error) {
+	review := &model.ProductReview{}
+	if err := uc.db.WithContext(ctx).First(review, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "未找到评价")
+		}
+		panic(err)
+	}
+	if review.Status != model.ProductReviewStatusApproved {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "未找到评价")
+	}
+
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.ProductReviewHelpful{
+			ReviewId:   id,
+			CustomerId: customerId,
+		})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Model(&model.ProductReview{}).Where("id = ?", id).
+			UpdateColumn("helpful_count", gorm.Expr("helpful_count + 1")).Error
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return uc.GetProductReview(ctx, id)
+}
+
+// RefreshProductReviewStatistics 按已通过的评价重新计算产品的评价数和平均评分
+func (uc *ProductReviewUseCase) RefreshProductReviewStatistics(ctx context.Context, productId int64) {
+	var result struct {
+		ReviewCount int64
+		RatingSum   int64
+	}
+	err := uc.db.WithContext(ctx).Model(&model.ProductReview{}).
+		Select("COUNT(*) AS review_count, COALESCE(SUM(rating), 0) AS rating_sum").
+		Where("product_id = ? AND status = ?", productId, model.ProductReviewStatusApproved).
+		Scan(&result).Error
+	if err != nil {
+		panic(err)
+	}
+
+	statistics := &model.ProductStatistics{
+		ProductId:     productId,
+		ReviewCount:   result.ReviewCount,
+		RatingSum:     result.RatingSum,
+		AverageRating: model.AverageRating(result.RatingSum, result.ReviewCount),
+	}
+	err = uc.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: model.ProductStatisticsUniqueId}},
+		DoUpdates: clause.AssignmentColumns(append([]string{"updated_at"}, model.ProductStatisticsReviewColumns...)),
+	}).Create(statistics).Error
+	if err != nil {
+		panic(err)
+	}
+}
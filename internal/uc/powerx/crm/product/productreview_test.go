@@ -0,0 +1,17 @@
+package product
+
+import (
+	model "PowerX/internal/model/crm/product"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestValidateProductReview(t *testing.T) {
+	assert.NoError(t, ValidateProductReview(&model.ProductReview{Rating: 5, Content: "很好"}, 3))
+	assert.Error(t, ValidateProductReview(&model.ProductReview{Rating: 0}, 0))
+	assert.Error(t, ValidateProductReview(&model.ProductReview{Rating: 6}, 0))
+	assert.Error(t, ValidateProductReview(&model.ProductReview{Rating: 4}, model.ProductReviewMaxImages+1))
+	assert.NoError(t, ValidateProductReview(&model.ProductReview{Rating: 4, Content: strings.Repeat("好", model.ProductReviewMaxContentLen)}, 0))
+	assert.Error(t, ValidateProductReview(&model.ProductReview{Rating: 4, Content: strings.Repeat("好", model.ProductReviewMaxContentLen+1)}, 0))
+}
@@ -0,0 +1,735 @@
+package product
+
+import (
+	model2 "PowerX/internal/model"
+	model "PowerX/internal/model/crm/product"
+	"PowerX/internal/types"
+	"PowerX/internal/uc/powerx"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type ProductSearchUseCase struct {
+	db *gorm.DB
+}
+
+func NewProductSearchUseCase(db *gorm.DB) *ProductSearchUseCase {
+	return &ProductSearchUseCase{
+		db: db,
+	}
+}
+
+// 每次增量索引处理的产品数量
+const ProductSearchIndexBatchSize = 500
+
+const (
+	tsQueryExpr  = "plainto_tsquery('" + model.ProductSearchConfig + "', ?)"
+	tsVectorExpr = "setweight(to_tsvector('" + model.ProductSearchConfig + "', ?), 'A') || " +
+		"setweight(to_tsvector('" + model.ProductSearchConfig + "', ?), 'B') || " +
+		"setweight(to_tsvector('" + model.ProductSearchConfig + "', ?), 'C') || " +
+		"setweight(to_tsvector('" + model.ProductSearchConfig + "', ?), 'D')"
+)
+
+const (
+	ProductSearchSortByRelevance = ""
+	ProductSearchSortByPriceAsc  = "price_asc"
+	ProductSearchSortByPriceDesc = "price_desc"
+	ProductSearchSortByNewest    = "newest"
+)
+
+// 价格区间, Max为0表示不设上限
+type ProductSearchPriceBand struct {
+	Min float64
+	Max float64
+}
+
+var ProductSearchPriceBands = []ProductSearchPriceBand{
+	{0, 50}, {50, 100}, {100, 200}, {200, 500}, {500, 0},
+}
+
+func (b ProductSearchPriceBand) Key() string {
+	if b.Max <= 0 {
+		return fmt.Sprintf("%g-", b.Min)
+	}
+	return fmt.Sprintf("%g-%g", b.Min, b.Max)
+}
+
+func (b ProductSearchPriceBand) Name() string {
+	if b.Max <= 0 {
+		return fmt.Sprintf("%g元以上", b.Min)
+	}
+	return fmt.Sprintf("%g-%g元", b.Min, b.Max)
+}
+
+var htmlTagRegexp = regexp.MustCompile(`<[^>]*>`)
+
+// StripHTML 去除产品描述中的HTML标签
+func StripHTML(text string) string {
+	return html.UnescapeString(htmlTagRegexp.ReplaceAllString(text, " "))
+}
+
+// TokenizeSearchText 中文按二元组切分, 文档另外保留单字以便单字检索; 字母数字按连续片段切分并转小写
+func TokenizeSearchText(text string, forQuery bool) []string {
+	tokens := []string{}
+	seen := map[string]bool{}
+	add := func(token string) {
+		if token != "" && !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+
+	han := []rune{}
+	word := []rune{}
+	flushHan := func() {
+		if len(han) == 1 {
+			add(string(han))
+		} else {
+			for i := 0; i+1 < len(han); i++ {
+				add(string(han[i : i+2]))
+			}
+			if !forQuery {
+				for _, r := range han {
+					add(string(r))
+				}
+			}
+		}
+		han = han[:0]
+	}
+	flushWord := func() {
+		add(string(word))
+		word = word[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flushWord()
+			han = append(han, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushHan()
+			word = append(word, unicode.ToLower(r))
+		default:
+			flushHan()
+			flushWord()
+		}
+	}
+	flushHan()
+	flushWord()
+
+	return tokens
+}
+
+// GetCategoryChain 返回品类及其上级品类, 从顶级品类开始
+func GetCategoryChain(categories map[int64]*model.ProductCategory, id int64) []*model.ProductCategory {
+	chain := []*model.ProductCategory{}
+	visited := map[int64]bool{}
+	for id > 0 && !visited[id] {
+		category, ok := categories[id]
+		if !ok {
+			break
+		}
+		visited[id] = true
+		chain = append([]*model.ProductCategory{category}, chain...)
+		id = category.PId
+	}
+	return chain
+}
+
+func loadCategories(ctx context.Context, db *gorm.DB) map[int64]*model.ProductCategory {
+	categories := []*model.ProductCategory{}
+	if err := db.WithContext(ctx).Find(&categories).Error; err != nil {
+		panic(err)
+	}
+	mapCategories := map[int64]*model.ProductCategory{}
+	for _, category := range categories {
+		mapCategories[category.Id] = category
+	}
+	return mapCategories
+}
+
+func getStandardPriceBookId(ctx context.Context, db *gorm.DB) int64 {
+	priceBook := &model.PriceBook{}
+	err := db.WithContext(ctx).Where("is_standard = ?", true).First(priceBook).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0
+		}
+		panic(err)
+	}
+	return priceBook.Id
+}
+
+func toJSONArray(values interface{}) []byte {
+	data, _ := json.Marshal(values)
+	return data
+}
+
+// IndexProduct 重建单个产品的检索文档, 产品不存在或已删除时移除文档
+func (uc *ProductSearchUseCase) IndexProduct(ctx context.Context, productId int64) {
+	uc.indexProducts(ctx, []int64{productId})
+}
+
+func (uc *ProductSearchUseCase) indexProducts(ctx context.Context, productIds []int64) {
+	if len(productIds) == 0 {
+		return
+	}
+	// 以开始索引的时间为准, 索引过程中发生的变更在下一轮处理
+	indexedAt := time.Now()
+
+	products := []*model.Product{}
+	err := uc.db.WithContext(ctx).
+		Preload("ProductCategories").
+		Preload("ProductSpecifics.Options").
+		Preload("PriceBookEntries").
+		Preload("PivotSalesChannels", "data_dictionary_type = ?", model2.TypeSalesChannel).
+		Preload("PivotSalesChannels.DataDictionaryItem").
+		Where("id IN ?", productIds).
+		Find(&products).Error
+	if err != nil {
+		panic(err)
+	}
+
+	categories := loadCategories(ctx, uc.db)
+	standardPriceBookId := getStandardPriceBookId(ctx, uc.db)
+	ucDD := powerx.NewDataDictionaryUseCase(uc.db)
+	approvedStatusId := ucDD.GetCachedDDId(ctx, model2.TypeApprovalStatus, model2.ApprovalStatusSuccess)
+
+	existIds := map[int64]bool{}
+	for _, product := range products {
+		existIds[product.Id] = true
+		uc.indexProduct(ctx, product, categories, standardPriceBookId, approvedStatusId, indexedAt)
+	}
+
+	removedIds := []int64{}
+	for _, id := range productIds {
+		if !existIds[id] {
+			removedIds = append(removedIds, id)
+		}
+	}
+	if len(removedIds) > 0 {
+		err = uc.db.WithContext(ctx).Unscoped().
+			Where("product_id IN ?", removedIds).
+			Delete(&model.ProductSearchDocument{}).Error
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (uc *ProductSearchUseCase) indexProduct(ctx context.Context, product *model.Product, categories map[int64]*model.ProductCategory, standardPriceBookId int64, approvedStatusId int, indexedAt time.Time) {
+
+	// 品类包含上级品类, 搜索上级品类时可以命中
+	categoryIds := []string{}
+	categoryPaths := []string{}
+	seenCategory := map[int64]bool{}
+	for _, category := range product.ProductCategories {
+		names := []string{}
+		for _, node := range GetCategoryChain(categories, category.Id) {
+			names = append(names, node.Name)
+			if !seenCategory[node.Id] {
+				seenCategory[node.Id] = true
+				categoryIds = append(categoryIds, strconv.FormatInt(node.Id, 10))
+			}
+		}
+		if len(names) > 0 {
+			categoryPaths = append(categoryPaths, strings.Join(names, "/"))
+		}
+	}
+
+	specificOptions := []string{}
+	for _, specific := range product.ProductSpecifics {
+		for _, option := range specific.Options {
+			specificOptions = append(specificOptions, specific.Name+":"+option.Name)
+		}
+	}
+
+	salesChannelIds := []string{}
+	salesChannelNames := []string{}
+	for _, pivot := range product.PivotSalesChannels {
+		if pivot.DataDictionaryItem == nil {
+			continue
+		}
+		salesChannelIds = append(salesChannelIds, strconv.FormatInt(pivot.DataDictionaryItem.Id, 10))
+		salesChannelNames = append(salesChannelNames, pivot.DataDictionaryItem.Name)
+	}
+
+	// 价格区间取标准价格手册中激活的价格, 没有激活的价格时取全部
+	var minPrice, maxPrice float64
+	prices := []float64{}
+	allPrices := []float64{}
+	for _, entry := range product.PriceBookEntries {
+		if entry.PriceBookId != standardPriceBookId {
+			continue
+		}
+		allPrices = append(allPrices, entry.UnitPrice)
+		if entry.IsActive {
+			prices = append(prices, entry.UnitPrice)
+		}
+	}
+	if len(prices) == 0 {
+		prices = allPrices
+	}
+	for i, price := range prices {
+		if i == 0 || price < minPrice {
+			minPrice = price
+		}
+		if i == 0 || price > maxPrice {
+			maxPrice = price
+		}
+	}
+
+	weightA := TokenizeSearchText(product.Name+" "+product.SPU, false)
+	weightB := TokenizeSearchText(strings.Join(categoryPaths, " "), false)
+	weightC := TokenizeSearchText(strings.Join(append(specificOptions, salesChannelNames...), " "), false)
+	weightD := TokenizeSearchText(StripHTML(product.Description), false)
+
+	doc := &model.ProductSearchDocument{
+		ProductId:       product.Id,
+		Name:            product.Name,
+		SPU:             product.SPU,
+		CategoryIds:     toJSONArray(categoryIds),
+		CategoryPath:    strings.Join(categoryPaths, ","),
+		SpecificOptions: toJSONArray(specificOptions),
+		SalesChannelIds: toJSONArray(salesChannelIds),
+		MinPrice:        minPrice,
+		MaxPrice:        maxPrice,
+		IsActivated:     product.IsActivated,
+		CanSellOnline:   product.CanSellOnline,
+		IsApproved:      product.ApprovalStatus == approvedStatusId,
+		SaleStartDate:   product.SaleStartDate,
+		SaleEndDate:     product.SaleEndDate,
+		Sort:            product.Sort,
+		Tokens:          strings.Join(append(append(append(weightA, weightB...), weightC...), weightD...), " "),
+		IndexedAt:       indexedAt,
+	}
+
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit("search_vector").
+			Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: model.ProductSearchDocumentUniqueId}},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"name", "spu", "category_ids", "category_path", "specific_options", "sales_channel_ids",
+					"min_price", "max_price", "is_activated", "can_sell_online", "is_approved", "sale_start_date", "sale_end_date", "sort", "tokens",
+					"indexed_at", "updated_at",
+				}),
+			}).
+			Create(doc).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&model.ProductSearchDocument{}).
+			Where("product_id = ?", product.Id).
+			Update("search_vector", gorm.Expr(tsVectorExpr,
+				strings.Join(weightA, " "),
+				strings.Join(weightB, " "),
+				strings.Join(weightC, " "),
+				strings.Join(weightD, " "),
+			)).Error
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// RebuildIndex 重建全部产品的检索文档, 并清理已不存在产品的文档
+func (uc *ProductSearchUseCase) RebuildIndex(ctx context.Context) (count int) {
+	var lastId int64
+	for {
+		ids := []int64{}
+		err := uc.db.WithContext(ctx).Model(&model.Product{}).
+			Where("id > ?", lastId).
+			Order("id ASC").
+			Limit(ProductSearchIndexBatchSize).
+			Pluck("id", &ids).Error
+		if err != nil {
+			panic(err)
+		}
+		if len(ids) == 0 {
+			break
+		}
+		uc.indexProducts(ctx, ids)
+		count += len(ids)
+		lastId = ids[len(ids)-1]
+	}
+	uc.removeOrphanDocuments(ctx)
+
+	return count
+}
+
+func (uc *ProductSearchUseCase) removeOrphanDocuments(ctx context.Context) {
+	err := uc.db.WithContext(ctx).Unscoped().
+		Where("product_id NOT IN (?)", uc.db.Model(&model.Product{}).Select("id")).
+		Delete(&model.ProductSearchDocument{}).Error
+	if err != nil {
+		panic(err)
+	}
+}
+
+// IndexChangedProducts 增量索引, 处理尚未索引或产品, SKU, 价格, 规格, 品类, 销售渠道在上次索引后有变更的产品
+func (uc *ProductSearchUseCase) IndexChangedProducts(ctx context.Context) (count int) {
+	db := uc.db.WithContext(ctx)
+
+	changedAfterIndexed := "(updated_at > d.indexed_at OR deleted_at > d.indexed_at)"
+	changed := func(mdl interface{}, condition string, args ...interface{}) *gorm.DB {
+		return uc.db.Unscoped().Model(mdl).Select("1").
+			Where(condition, args...).
+			Where(changedAfterIndexed)
+	}
+
+	// 品类改名或调整层级会影响所有下级品类的产品路径, 按品类的最近变更时间整体判断
+	var categoryChangedAt sql.NullTime
+	err := db.Unscoped().Model(&model.ProductCategory{}).
+		Select("MAX(GREATEST(updated_at, COALESCE(deleted_at, updated_at)))").
+		Row().Scan(&categoryChangedAt)
+	if err != nil {
+		panic(err)
+	}
+
+	ids := []int64{}
+	err = db.Unscoped().
+		Table(model.TableNameProduct+" AS p").
+		Joins("LEFT JOIN product_search_documents AS d ON d.product_id = p.id").
+		Where("d.id IS NULL AND p.deleted_at IS NULL").
+		Or(db.Where("d.id IS NOT NULL").
+			Where(db.Where("p.updated_at > d.indexed_at").
+				Or("p.deleted_at IS NOT NULL").
+				Or("d.indexed_at < ?", categoryChangedAt.Time).
+				Or("EXISTS (?)", changed(&model.SKU{}, "product_id = p.id")).
+				Or("EXISTS (?)", changed(&model.PriceBookEntry{}, "product_id = p.id")).
+				Or("EXISTS (?)", changed(&model.ProductSpecific{}, "product_id = p.id")).
+				Or("EXISTS (?)", changed(&model.SpecificOption{}, "product_specific_id IN (?)",
+					uc.db.Unscoped().Model(&model.ProductSpecific{}).Select("id").Where("product_id = p.id"))).
+				Or("EXISTS (?)", changed(&model.PivotProductToProductCategory{}, "product_id = p.id")).
+				Or("EXISTS (?)", changed(&model2.PivotDataDictionaryToObject{}, "object_type = ? AND object_id = p.id", model.TableNameProduct)),
+			)).
+		Order("p.id ASC").
+		Limit(ProductSearchIndexBatchSize).
+		Pluck("p.id", &ids).Error
+	if err != nil {
+		panic(err)
+	}
+
+	uc.indexProducts(ctx, ids)
+	uc.removeOrphanDocuments(ctx)
+
+	return len(ids)
+}
+
+func (uc *ProductSearchUseCase) Schedule(c *cron.Cron) {
+	_, _ = c.AddFunc(`* * * * *`, func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logx.Errorf("product search index panic: %v", r)
+			}
+		}()
+		uc.IndexChangedProducts(context.Background())
+	})
+}
+
+type SearchProductsOption struct {
+	Keyword         string
+	CategoryIds     []int64
+	SpecificOptions []string
+	SalesChannelId  int64
+	MinPrice        float64
+	MaxPrice        float64
+	OnlyOnSale      bool
+	SortBy          string
+	types.PageEmbedOption
+}
+
+type ProductSearchFacet struct {
+	Key   string
+	Name  string
+	Count int64
+}
+
+type ProductSearchFacets struct {
+	Categories      []*ProductSearchFacet
+	PriceBands      []*ProductSearchFacet
+	SpecificOptions []*ProductSearchFacet
+}
+
+type ProductSearchResult struct {
+	ProductIds []int64
+	PageIndex  int
+	PageSize   int
+	Total      int64
+	Facets     *ProductSearchFacets
+}
+
+const (
+	productSearchFacetCategory       = "category"
+	productSearchFacetPrice          = "price"
+	productSearchFacetSpecificOption = "specificOption"
+)
+
+// 分面统计的数量上限
+const productSearchFacetLimit = 50
+
+func int64sToStrings(values []int64) []string {
+	strs := make([]string, 0, len(values))
+	for _, value := range values {
+		strs = append(strs, strconv.FormatInt(value, 10))
+	}
+	return strs
+}
+
+// 统计分面时排除该分面自身的筛选条件, 使其他选项的数量保持可见
+func (uc *ProductSearchUseCase) buildSearchQuery(db *gorm.DB, opt *SearchProductsOption, query string, excludeFacet string) *gorm.DB {
+	if query != "" {
+		db = db.Where("search_vector @@ "+tsQueryExpr, query)
+	}
+	if opt.OnlyOnSale {
+		db = db.Where("is_activated = ? AND can_sell_online = ? AND is_approved = ?", true, true, true).
+			Scopes(model.WhereInSalePeriod(time.Now()))
+	}
+	if opt.SalesChannelId > 0 {
+		db = db.Where("EXISTS (SELECT 1 FROM jsonb_array_elements_text(sales_channel_ids) AS sc WHERE sc.value = ?)",
+			strconv.FormatInt(opt.SalesChannelId, 10))
+	}
+	if excludeFacet != productSearchFacetCategory && len(opt.CategoryIds) > 0 {
+		db = db.Where("EXISTS (SELECT 1 FROM jsonb_array_elements_text(category_ids) AS c WHERE c.value IN ?)",
+			int64sToStrings(opt.CategoryIds))
+	}
+	if excludeFacet != productSearchFacetSpecificOption && len(opt.SpecificOptions) > 0 {
+		db = db.Where("EXISTS (SELECT 1 FROM jsonb_array_elements_text(specific_options) AS so WHERE so.value IN ?)",
+			opt.SpecificOptions)
+	}
+	if excludeFacet != productSearchFacetPrice {
+		if opt.MinPrice > 0 {
+			db = db.Where("min_price >= ?", opt.MinPrice)
+		}
+		if opt.MaxPrice > 0 {
+			db = db.Where("min_price < ?", opt.MaxPrice)
+		}
+	}
+	return db
+}
+
+func (uc *ProductSearchUseCase) SearchProducts(ctx context.Context, opt *SearchProductsOption) (*ProductSearchResult, error) {
+	opt.DefaultPageIfNotSet()
+	result := &ProductSearchResult{
+		ProductIds: []int64{},
+		PageIndex:  opt.PageIndex,
+		PageSize:   opt.PageSize,
+		Facets: &ProductSearchFacets{
+			Categories:      []*ProductSearchFacet{},
+			PriceBands:      []*ProductSearchFacet{},
+			SpecificOptions: []*ProductSearchFacet{},
+		},
+	}
+
+	query := strings.Join(TokenizeSearchText(opt.Keyword, true), " ")
+	// 关键词只有标点符号等无法检索的字符
+	if strings.TrimSpace(opt.Keyword) != "" && query == "" {
+		return result, nil
+	}
+
+	db := uc.buildSearchQuery(uc.db.WithContext(ctx).Model(&model.ProductSearchDocument{}), opt, query, "")
+	if err := db.Count(&result.Total).Error; err != nil {
+		panic(err)
+	}
+
+	if query != "" {
+		db = db.Select("product_id, ts_rank_cd(search_vector, "+tsQueryExpr+") AS rank", query)
+	} else {
+		db = db.Select("product_id")
+	}
+	switch opt.SortBy {
+	case ProductSearchSortByPriceAsc:
+		db = db.Order("min_price ASC")
+	case ProductSearchSortByPriceDesc:
+		db = db.Order("max_price DESC")
+	case ProductSearchSortByNewest:
+		db = db.Order("product_id DESC")
+	default:
+		if query != "" {
+			db = db.Order("rank DESC")
+		}
+	}
+	db = db.Order("sort DESC").Order("product_id DESC")
+
+	if err := db.Offset((opt.PageIndex-1)*opt.PageSize).Limit(opt.PageSize).
+		Pluck("product_id", &result.ProductIds).Error; err != nil {
+		panic(err)
+	}
+
+	result.Facets.Categories = uc.countCategoryFacets(ctx, opt, query)
+	result.Facets.SpecificOptions = uc.countJSONFacets(ctx, opt, query, "specific_options", productSearchFacetSpecificOption)
+	result.Facets.PriceBands = uc.countPriceBandFacets(ctx, opt, query)
+
+	return result, nil
+}
+
+func (uc *ProductSearchUseCase) countJSONFacets(ctx context.Context, opt *SearchProductsOption, query string, column string, facet string) []*ProductSearchFacet {
+	rows := []*ProductSearchFacet{}
+	db := uc.db.WithContext(ctx).
+		Table("product_search_documents, jsonb_array_elements_text(product_search_documents." + column + ") AS facet").
+		Where("product_search_documents.deleted_at IS NULL")
+	db = uc.buildSearchQuery(db, opt, query, facet)
+	err := db.Select("facet.value AS key, facet.value AS name, COUNT(*) AS count").
+		Group("facet.value").
+		Order("count DESC").
+		Limit(productSearchFacetLimit).
+		Scan(&rows).Error
+	if err != nil {
+		panic(err)
+	}
+	return rows
+}
+
+func (uc *ProductSearchUseCase) countCategoryFacets(ctx context.Context, opt *SearchProductsOption, query string) []*ProductSearchFacet {
+	facets := uc.countJSONFacets(ctx, opt, query, "category_ids", productSearchFacetCategory)
+	if len(facets) == 0 {
+		return facets
+	}
+
+	ids := []int64{}
+	for _, facet := range facets {
+		id, _ := strconv.ParseInt(facet.Key, 10, 64)
+		ids = append(ids, id)
+	}
+	categories := []*model.ProductCategory{}
+	if err := uc.db.WithContext(ctx).Where("id IN ?", ids).Find(&categories).Error; err != nil {
+		panic(err)
+	}
+	names := map[string]string{}
+	for _, category := range categories {
+		names[strconv.FormatInt(category.Id, 10)] = category.Name
+	}
+
+	// 品类已删除的分面不再展示
+	namedFacets := []*ProductSearchFacet{}
+	for _, facet := range facets {
+		if name, ok := names[facet.Key]; ok {
+			facet.Name = name
+			namedFacets = append(namedFacets, facet)
+		}
+	}
+	return namedFacets
+}
+
+func (uc *ProductSearchUseCase) countPriceBandFacets(ctx context.Context, opt *SearchProductsOption, query string) []*ProductSearchFacet {
+	expr := "CASE"
+	args := []interface{}{}
+	for i, band := range ProductSearchPriceBands {
+		if band.Max > 0 {
+			expr += fmt.Sprintf(" WHEN min_price >= ? AND min_price < ? THEN %d", i)
+			args = append(args, band.Min, band.Max)
+		} else {
+			expr += fmt.Sprintf(" WHEN min_price >= ? THEN %d", i)
+			args = append(args, band.Min)
+		}
+	}
+	expr += " ELSE -1 END"
+
+	rows := []struct {
+		Band  int
+		Count int64
+	}{}
+	db := uc.buildSearchQuery(uc.db.WithContext(ctx).Model(&model.ProductSearchDocument{}), opt, query, productSearchFacetPrice)
+	err := db.Select(expr+" AS band, COUNT(*) AS count", args...).
+		Group("band").
+		Scan(&rows).Error
+	if err != nil {
+		panic(err)
+	}
+
+	counts := map[int]int64{}
+	for _, row := range rows {
+		counts[row.Band] = row.Count
+	}
+	facets := []*ProductSearchFacet{}
+	for i, band := range ProductSearchPriceBands {
+		facets = append(facets, &ProductSearchFacet{
+			Key:   band.Key(),
+			Name:  band.Name(),
+			Count: counts[i],
+		})
+	}
+	return facets
+}
+
+const (
+	ProductSearchSuggestionTypeProduct  = "product"
+	ProductSearchSuggestionTypeCategory = "category"
+)
+
+type ProductSearchSuggestion struct {
+	Type string
+	Id   int64
+	Text string
+}
+
+func escapeLike(keyword string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(keyword)
+}
+
+// SuggestProducts 输入联想, 前缀匹配的产品名称优先, 其次是品类名称
+func (uc *ProductSearchUseCase) SuggestProducts(ctx context.Context, keyword string, limit int) []*ProductSearchSuggestion {
+	suggestions := []*ProductSearchSuggestion{}
+	keyword = strings.TrimSpace(keyword)
+	if keyword == "" {
+		return suggestions
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	like := escapeLike(keyword)
+
+	docs := []*model.ProductSearchDocument{}
+	err := uc.db.WithContext(ctx).
+		Select("product_id", "name").
+		Where("is_activated = ? AND can_sell_online = ? AND is_approved = ?", true, true, true).
+		Scopes(model.WhereInSalePeriod(time.Now())).
+		Where("name ILIKE ?", "%"+like+"%").
+		Order(clause.OrderBy{Expression: gorm.Expr("CASE WHEN name ILIKE ? THEN 0 ELSE 1 END, sort DESC, product_id DESC", like+"%")}).
+		Limit(limit).
+		Find(&docs).Error
+	if err != nil {
+		panic(err)
+	}
+	for _, doc := range docs {
+		suggestions = append(suggestions, &ProductSearchSuggestion{
+			Type: ProductSearchSuggestionTypeProduct,
+			Id:   doc.ProductId,
+			Text: doc.Name,
+		})
+	}
+
+	if len(suggestions) < limit {
+		categories := []*model.ProductCategory{}
+		err = uc.db.WithContext(ctx).
+			Where("name ILIKE ?", "%"+like+"%").
+			Order(clause.OrderBy{Expression: gorm.Expr("CASE WHEN name ILIKE ? THEN 0 ELSE 1 END, sort DESC, id ASC", like+"%")}).
+			Limit(limit - len(suggestions)).
+			Find(&categories).Error
+		if err != nil {
+			panic(err)
+		}
+		for _, category := range categories {
+			suggestions = append(suggestions, &ProductSearchSuggestion{
+				Type: ProductSearchSuggestionTypeCategory,
+				Id:   category.Id,
+				Text: category.Name,
+			})
+		}
+	}
+
+	return suggestions
+}
@@ -0,0 +1,80 @@
+package product
+
+import (
+	"PowerX/internal/model/crm/product"
+	"PowerX/internal/model/powermodel"
+	"reflect"
+	"testing"
+)
+
+func TestTokenizeSearchText(t *testing.T) {
+
+	cases := []struct {
+		text     string
+		forQuery bool
+		want     []string
+	}{
+		{"拿铁咖啡", true, []string{"拿铁", "铁咖", "咖啡"}},
+		{"拿铁咖啡", false, []string{"拿铁", "铁咖", "咖啡", "拿", "铁", "咖", "啡"}},
+		{"茶", true, []string{"茶"}},
+		{"iPhone15 Pro, 手机壳", true, []string{"iphone15", "pro", "手机", "机壳"}},
+		{"SPU-001 spu", false, []string{"spu", "001"}},
+		{"  !!  ", true, []string{}},
+	}
+	for _, c := range cases {
+		got := TokenizeSearchText(c.text, c.forQuery)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("tokenize %q forQuery %v = %v, want %v", c.text, c.forQuery, got, c.want)
+		}
+	}
+}
+
+func TestStripHTML(t *testing.T) {
+
+	got := StripHTML(`<p>香浓<b>拿铁</b></p>&amp;奶泡`)
+	if want := " 香浓 拿铁  &奶泡"; got != want {
+		t.Errorf("strip html = %q, want %q", got, want)
+	}
+}
+
+func TestGetCategoryChain(t *testing.T) {
+
+	newCategory := func(id int64, pId int64, name string) *product.ProductCategory {
+		return &product.ProductCategory{PowerModel: powermodel.PowerModel{Id: id}, PId: pId, Name: name}
+	}
+	categories := map[int64]*product.ProductCategory{
+		1: newCategory(1, 0, "饮品"),
+		2: newCategory(2, 1, "咖啡"),
+		3: newCategory(3, 2, "拿铁"),
+		// 数据异常形成环时不应死循环
+		4: newCategory(4, 5, "甲"),
+		5: newCategory(5, 4, "乙"),
+	}
+
+	names := func(chain []*product.ProductCategory) []string {
+		result := []string{}
+		for _, category := range chain {
+			result = append(result, category.Name)
+		}
+		return result
+	}
+	if got := names(GetCategoryChain(categories, 3)); !reflect.DeepEqual(got, []string{"饮品", "咖啡", "拿铁"}) {
+		t.Errorf("category chain = %v", got)
+	}
+	if got := names(GetCategoryChain(categories, 4)); !reflect.DeepEqual(got, []string{"乙", "甲"}) {
+		t.Errorf("cyclic category chain = %v", got)
+	}
+	if got := GetCategoryChain(categories, 9); len(got) != 0 {
+		t.Errorf("missing category chain = %v", got)
+	}
+}
+
+func TestProductSearchPriceBand(t *testing.T) {
+
+	if key := (ProductSearchPriceBand{50, 100}).Key(); key != "50-100" {
+		t.Errorf("band key = %s", key)
+	}
+	if key := (ProductSearchPriceBand{500, 0}).Key(); key != "500-" {
+		t.Errorf("unbounded band key = %s", key)
+	}
+}
@@ -23,7 +23,7 @@ func NewProductSpecificUseCase(db *gorm.DB) *ProductSpecificUseCase {
 }
 
 type FindProductSpecificOption struct {
-	OrderBy   string
+	QuerySpec *powermodel.QuerySpec
 	Ids       []int64
 	ProductId int64
 	types.PageEmbedOption
@@ -39,10 +39,8 @@ func (uc *ProductSpecificUseCase) buildFindQueryNoPage(query *gorm.DB, opt *Find
 
 	}
 
+	query = opt.QuerySpec.Apply(query)
 	orderBy := "id desc"
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
-	}
 	query.Order(orderBy)
 
 	return query
@@ -7,22 +7,25 @@ import (
 	"PowerX/internal/types/errorx"
 	"context"
 	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/stores/redis"
 	"gorm.io/gorm"
 	"strings"
 )
 
 type ProductStatisticsUseCase struct {
 	db *gorm.DB
+	kv *redis.Redis
 }
 
-func NewProductStatisticsUseCase(db *gorm.DB) *ProductStatisticsUseCase {
+func NewProductStatisticsUseCase(db *gorm.DB, kv *redis.Redis) *ProductStatisticsUseCase {
 	return &ProductStatisticsUseCase{
 		db: db,
+		kv: kv,
 	}
 }
 
 type FindProductStatisticsOption struct {
-	OrderBy   string
+	QuerySpec *powermodel.QuerySpec
 	Ids       []int64
 	ProductId int64
 	types.PageEmbedOption
@@ -38,18 +41,14 @@ func (uc *ProductStatisticsUseCase) buildFindQueryNoPage(query *gorm.DB, opt *Fi
 
 	}
 
+	query = opt.QuerySpec.Apply(query)
 	orderBy := "id desc"
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
-	}
 	query.Order(orderBy)
 
 	return query
 }
 
 func (uc *ProductStatisticsUseCase) PreloadItems(db *gorm.DB) *gorm.DB {
-	db = db.
-		Preload("Options")
 	return db
 }
 
@@ -118,7 +117,9 @@ func (uc *ProductStatisticsUseCase) UpsertProductStatistics(ctx context.Context,
 
 func (uc *ProductStatisticsUseCase) UpsertProductStatisticses(ctx context.Context, productStatisticses []*product.ProductStatistics) ([]*product.ProductStatistics, error) {
 
-	err := powermodel.UpsertModelsOnUniqueID(uc.db.WithContext(ctx), &product.ProductStatistics{}, product.ProductStatisticsUniqueId, productStatisticses, nil, true)
+	// 实际统计由系统计算, 配置只更新展示偏移量
+	fields := append([]string{"updated_at"}, product.ProductStatisticsBaseColumns...)
+	err := powermodel.UpsertModelsOnUniqueID(uc.db.WithContext(ctx), &product.ProductStatistics{}, product.ProductStatisticsUniqueId, productStatisticses, fields, true)
 
 	if err != nil {
 		panic(errors.Wrap(err, "batch upsert productStatistics failed"))
@@ -128,11 +129,26 @@ func (uc *ProductStatisticsUseCase) UpsertProductStatisticses(ctx context.Contex
 }
 
 func (uc *ProductStatisticsUseCase) PatchProductStatistics(ctx context.Context, id int64, ProductStatistics *product.ProductStatistics) {
-	if err := uc.db.WithContext(ctx).Model(&product.ProductStatistics{}).Where(id).Updates(&ProductStatistics).Error; err != nil {
+	if err := uc.db.WithContext(ctx).Model(&product.ProductStatistics{}).Where(id).Updates(ProductStatistics).Error; err != nil {
 		panic(err)
 	}
 }
 
+// UpdateProductStatisticsBase 全量更新展示偏移量, 实际统计由系统计算不可修改
+func (uc *ProductStatisticsUseCase) UpdateProductStatisticsBase(ctx context.Context, id int64, productStatistics *product.ProductStatistics) (*product.ProductStatistics, error) {
+	result := uc.db.WithContext(ctx).Model(&product.ProductStatistics{}).
+		Where("id = ?", id).
+		Select(product.ProductStatisticsBaseColumns).
+		Updates(productStatistics)
+	if result.Error != nil {
+		panic(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "未找到产品统计记录")
+	}
+	return uc.GetProductStatistics(ctx, id)
+}
+
 func (uc *ProductStatisticsUseCase) GetProductStatistics(ctx context.Context, id int64) (*product.ProductStatistics, error) {
 	var ProductStatistics product.ProductStatistics
 	if err := uc.db.WithContext(ctx).First(&ProductStatistics, id).Error; err != nil {
@@ -0,0 +1,394 @@
+package product
+
+import (
+	"PowerX/internal/model/crm/product"
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/uc/powerx"
+	"context"
+	"github.com/robfig/cron/v3"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strconv"
+	"time"
+)
+
+// 产品实际统计的计算: 订单付清时累加销量, 订单全额退款时扣减销量; 小程序详情访问先在Redis中累计,
+// 定时汇总到浏览量; 库存定时按仓库库存或SKU库存同步; 每日生成统计快照
+
+const ProductViewCountRedisKey = "powerx:product:statistics:views"
+
+const ProductStatisticsSnapshotBatchSize = 500
+
+// orderItemProduct 订单项对应的产品和SKU
+type orderItemProduct struct {
+	ProductId int64
+	SkuId     int64
+}
+
+// findOrderItemProducts 购物车下单的订单项价格条目Id字段保存的是SKU Id, 直接下单的是价格条目Id
+func findOrderItemProducts(ctx context.Context, db *gorm.DB, isCartOrder bool, items []*trade.OrderItem) map[int64]*orderItemProduct {
+	mapProducts := map[int64]*orderItemProduct{}
+	ids := []int64{}
+	for _, item := range items {
+		ids = append(ids, item.PriceBookEntryId)
+	}
+	if len(ids) == 0 {
+		return mapProducts
+	}
+
+	mapRefers := map[int64]*orderItemProduct{}
+	if isCartOrder {
+		skus := []*product.SKU{}
+		if err := db.WithContext(ctx).Unscoped().Where("id IN ?", ids).Find(&skus).Error; err != nil {
+			panic(err)
+		}
+		for _, sku := range skus {
+			mapRefers[sku.Id] = &orderItemProduct{ProductId: sku.ProductId, SkuId: sku.Id}
+		}
+	} else {
+		entries := []*product.PriceBookEntry{}
+		if err := db.WithContext(ctx).Unscoped().Where("id IN ?", ids).Find(&entries).Error; err != nil {
+			panic(err)
+		}
+		for _, entry := range entries {
+			mapRefers[entry.Id] = &orderItemProduct{ProductId: entry.ProductId, SkuId: entry.SkuId}
+		}
+	}
+
+	for _, item := range items {
+		if refer, ok := mapRefers[item.PriceBookEntryId]; ok {
+			mapProducts[item.Id] = refer
+		}
+	}
+	return mapProducts
+}
+
+// orderProductQuantities 订单中各产品的购买数量, 组合商品同时计入组合商品和组件产品
+func orderProductQuantities(items []*trade.OrderItem, mapProducts map[int64]*orderItemProduct) map[int64]int64 {
+	quantities := map[int64]int64{}
+	for _, item := range items {
+		if refer, ok := mapProducts[item.Id]; ok {
+			quantities[refer.ProductId] += int64(item.Quantity)
+		}
+		for _, component := range item.Components {
+			quantities[component.ProductId] += int64(component.Quantity)
+		}
+	}
+	return quantities
+}
+
+func (uc *ProductStatisticsUseCase) getOrderProductQuantities(ctx context.Context, order *trade.Order) map[int64]int64 {
+	items := []*trade.OrderItem{}
+	if err := uc.db.WithContext(ctx).Preload("Components").Where("order_id = ?", order.Id).Find(&items).Error; err != nil {
+		panic(err)
+	}
+	return orderProductQuantities(items, findOrderItemProducts(ctx, uc.db, order.CartId > 0, items))
+}
+
+// increaseStatistics 按产品累加统计字段, 产品没有统计记录时创建
+func (uc *ProductStatisticsUseCase) increaseStatistics(ctx context.Context, column string, deltas map[int64]int64) {
+	for productId, delta := range deltas {
+		if delta == 0 {
+			continue
+		}
+		statistics := newProductStatisticsWithColumn(productId, column, delta)
+		err := uc.db.WithContext(ctx).
+			Model(&product.ProductStatistics{}).
+			Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: product.ProductStatisticsUniqueId}},
+				DoUpdates: clause.Set{
+					{Column: clause.Column{Name: column}, Value: gorm.Expr("GREATEST(? + ?, 0)", clause.Column{Table: clause.CurrentTable, Name: column}, delta)},
+					{Column: clause.Column{Name: "updated_at"}, Value: time.Now()},
+				},
+			}).
+			Create(statistics).Error
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// newProductStatisticsWithColumn 创建统计记录时的初始值, 不能为负数
+func newProductStatisticsWithColumn(productId int64, column string, value int64) *product.ProductStatistics {
+	statistics := &product.ProductStatistics{ProductId: productId}
+	if value < 0 {
+		value = 0
+	}
+	switch column {
+	case "sold_amount":
+		statistics.SoldAmount = value
+	case "view_count":
+		statistics.ViewCount = value
+	case "inventory_quantity":
+		statistics.InventoryQuantity = value
+	}
+	return statistics
+}
+
+// HandleOrderPaid 订单付清后累加产品销量, 作为支付的订单付清回调注册
+func (uc *ProductStatisticsUseCase) HandleOrderPaid(ctx context.Context, order *trade.Order) {
+	quantities := uc.getOrderProductQuantities(ctx, order)
+	uc.increaseStatistics(ctx, "sold_amount", quantities)
+
+	productIds := []int64{}
+	for productId := range quantities {
+		productIds = append(productIds, productId)
+	}
+	uc.SyncProductInventories(ctx, productIds)
+}
+
+// HandleOrderRefunded 订单全额退款后扣减付清时累加的销量, 未付清就退款的订单不扣减; 部分退款无法对应到数量, 不扣减
+func (uc *ProductStatisticsUseCase) HandleOrderRefunded(ctx context.Context, order *trade.Order) {
+	ucDD := powerx.NewDataDictionaryUseCase(uc.db)
+	var count int64
+	err := uc.db.WithContext(ctx).Model(&trade.OrderStatusTransition{}).
+		Where("order_id = ? AND to_status = ?", order.Id, ucDD.GetCachedDDId(ctx, trade.TypeOrderStatus, trade.OrderStatusToBeShipped)).
+		Count(&count).Error
+	if err != nil {
+		panic(err)
+	}
+	if count == 0 {
+		return
+	}
+
+	quantities := uc.getOrderProductQuantities(ctx, order)
+	for productId, quantity := range quantities {
+		quantities[productId] = -quantity
+	}
+	uc.increaseStatistics(ctx, "sold_amount", quantities)
+}
+
+// RecordProductView 小程序产品详情访问计数先累计在Redis, 由定时任务汇总, Redis不可用时丢弃本次计数
+func (uc *ProductStatisticsUseCase) RecordProductView(ctx context.Context, productId int64) {
+	if uc.kv == nil || productId <= 0 {
+		return
+	}
+	if _, err := uc.kv.HincrbyCtx(ctx, ProductViewCountRedisKey, strconv.FormatInt(productId, 10), 1); err != nil {
+		logx.WithContext(ctx).Errorf("record product %d view failed: %v", productId, err)
+	}
+}
+
+// 原子地取出并删除累计的访问计数, 多个实例同时汇总时每个计数只会被一个实例取到
+const takeProductViewsScript = `
+local values = redis.call('HGETALL', KEYS[1])
+redis.call('DEL', KEYS[1])
+return values`
+
+// FlushProductViews 取出Redis中累计的访问数汇总到浏览量, 取出后新增的访问重新累计, 留到下次汇总
+func (uc *ProductStatisticsUseCase) FlushProductViews(ctx context.Context) int {
+	if uc.kv == nil {
+		return 0
+	}
+	values, err := uc.kv.EvalCtx(ctx, takeProductViewsScript, []string{ProductViewCountRedisKey})
+	if err != nil {
+		panic(err)
+	}
+
+	deltas := parseProductViewCounts(values)
+	if len(deltas) > 0 {
+		uc.increaseStatistics(ctx, "view_count", deltas)
+	}
+	return len(deltas)
+}
+
+// parseProductViewCounts 解析HGETALL返回的产品Id与访问数交替排列的列表
+func parseProductViewCounts(values interface{}) map[int64]int64 {
+	deltas := map[int64]int64{}
+	list, _ := values.([]interface{})
+	for i := 0; i+1 < len(list); i += 2 {
+		field, _ := list[i].(string)
+		value, _ := list[i+1].(string)
+		productId, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			continue
+		}
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || count <= 0 {
+			continue
+		}
+		deltas[productId] += count
+	}
+	return deltas
+}
+
+// GetProductInventories
+//
+//	@Description: 产品的可售库存, 有仓库库存记录的产品按仓库库存合计, 否则按未停用SKU的库存合计, 组合商品按组件库存可售的份数
+//	@receiver uc
+//	@param ctx
+//	@param productIds 为空时计算全部产品
+//	@return map[int64]int64
+func (uc *ProductStatisticsUseCase) GetProductInventories(ctx context.Context, productIds []int64) map[int64]int64 {
+	type inventoryRow struct {
+		ProductId int64
+		Quantity  int64
+	}
+
+	inventories := map[int64]int64{}
+	products := []*product.Product{}
+	query := uc.db.WithContext(ctx).Model(&product.Product{}).Select("id", "is_bundle")
+	if len(productIds) > 0 {
+		query = query.Where("id IN ?", productIds)
+	}
+	if err := query.Find(&products).Error; err != nil {
+		panic(err)
+	}
+	bundleProductIds := []int64{}
+	for _, p := range products {
+		inventories[p.Id] = 0
+		if p.IsBundle {
+			bundleProductIds = append(bundleProductIds, p.Id)
+		}
+	}
+
+	skuRows := []*inventoryRow{}
+	query = uc.db.WithContext(ctx).Model(&product.SKU{}).
+		Select("product_id, COALESCE(SUM(inventory), 0) AS quantity").
+		Where("is_retired = ?", false).
+		Group("product_id")
+	if len(productIds) > 0 {
+		query = query.Where("product_id IN ?", productIds)
+	}
+	if err := query.Scan(&skuRows).Error; err != nil {
+		panic(err)
+	}
+	for _, row := range skuRows {
+		if _, ok := inventories[row.ProductId]; ok {
+			inventories[row.ProductId] = row.Quantity
+		}
+	}
+
+	warehouseRows := []*inventoryRow{}
+	query = uc.db.WithContext(ctx).Model(&trade.Inventory{}).
+		Select("product_id, COALESCE(SUM(quantity), 0) AS quantity").
+		Group("product_id")
+	if len(productIds) > 0 {
+		query = query.Where("product_id IN ?", productIds)
+	}
+	if err := query.Scan(&warehouseRows).Error; err != nil {
+		panic(err)
+	}
+	for _, row := range warehouseRows {
+		if _, ok := inventories[row.ProductId]; ok {
+			inventories[row.ProductId] = row.Quantity
+		}
+	}
+
+	ucBundle := NewProductBundleUseCase(uc.db)
+	for bundleProductId, items := range ucBundle.FindBundleItems(ctx, bundleProductIds) {
+		inventories[bundleProductId] = int64(product.BundleAvailableQuantity(items))
+	}
+
+	return inventories
+}
+
+// SyncProductInventories 把产品的可售库存同步到统计, productIds为空时同步全部产品
+func (uc *ProductStatisticsUseCase) SyncProductInventories(ctx context.Context, productIds []int64) int {
+	inventories := uc.GetProductInventories(ctx, productIds)
+	if len(inventories) == 0 {
+		return 0
+	}
+
+	statisticses := []*product.ProductStatistics{}
+	for productId, quantity := range inventories {
+		statisticses = append(statisticses, &product.ProductStatistics{
+			ProductId:         productId,
+			InventoryQuantity: quantity,
+		})
+	}
+	err := uc.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: product.ProductStatisticsUniqueId}},
+		DoUpdates: clause.AssignmentColumns([]string{"inventory_quantity", "updated_at"}),
+	}).CreateInBatches(&statisticses, ProductStatisticsSnapshotBatchSize).Error
+	if err != nil {
+		panic(err)
+	}
+	return len(statisticses)
+}
+
+// SnapshotProductStatistics 生成指定日期的统计快照, 重复执行时覆盖当日快照
+func (uc *ProductStatisticsUseCase) SnapshotProductStatistics(ctx context.Context, date time.Time) int {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	count := 0
+
+	statisticses := []*product.ProductStatistics{}
+	err := uc.db.WithContext(ctx).Model(&product.ProductStatistics{}).
+		FindInBatches(&statisticses, ProductStatisticsSnapshotBatchSize, func(tx *gorm.DB, batch int) error {
+			productIds := []int64{}
+			for _, statistics := range statisticses {
+				productIds = append(productIds, statistics.ProductId)
+			}
+
+			previousSnapshots := []*product.ProductStatisticsSnapshot{}
+			err := uc.db.WithContext(ctx).
+				Where("product_id IN ? AND snapshot_date = ?", productIds, day.AddDate(0, 0, -1)).
+				Find(&previousSnapshots).Error
+			if err != nil {
+				return err
+			}
+			mapPrevious := map[int64]*product.ProductStatisticsSnapshot{}
+			for _, snapshot := range previousSnapshots {
+				mapPrevious[snapshot.ProductId] = snapshot
+			}
+
+			snapshots := []*product.ProductStatisticsSnapshot{}
+			for _, statistics := range statisticses {
+				snapshots = append(snapshots, product.MakeProductStatisticsSnapshot(statistics, mapPrevious[statistics.ProductId], day))
+			}
+			count += len(snapshots)
+
+			return uc.db.WithContext(ctx).Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "product_id"}, {Name: "snapshot_date"}},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"sold_amount", "inventory_quantity", "view_count", "review_count", "average_rating",
+					"daily_sold_amount", "daily_view_count", "updated_at",
+				}),
+			}).Create(&snapshots).Error
+		}).Error
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// FindProductStatisticsSnapshots 产品在日期范围内的每日快照, 按日期升序
+func (uc *ProductStatisticsUseCase) FindProductStatisticsSnapshots(ctx context.Context, productId int64, startDate time.Time, endDate time.Time) []*product.ProductStatisticsSnapshot {
+	snapshots := []*product.ProductStatisticsSnapshot{}
+	query := uc.db.WithContext(ctx).Where("product_id = ?", productId)
+	if !startDate.IsZero() {
+		query = query.Where("snapshot_date >= ?", startDate)
+	}
+	if !endDate.IsZero() {
+		query = query.Where("snapshot_date <= ?", endDate)
+	}
+	if err := query.Order("snapshot_date").Find(&snapshots).Error; err != nil {
+		panic(err)
+	}
+	return snapshots
+}
+
+func (uc *ProductStatisticsUseCase) Schedule(c *cron.Cron) {
+	run := func(name string, job func(ctx context.Context)) func() {
+		return func() {
+			defer func() {
+				if r := recover(); r != nil {
+					logx.Errorf("product statistics %s panic: %v", name, r)
+				}
+			}()
+			job(context.Background())
+		}
+	}
+
+	_, _ = c.AddFunc(`* * * * *`, run("flush views", func(ctx context.Context) {
+		uc.FlushProductViews(ctx)
+	}))
+	_, _ = c.AddFunc(`0 * * * *`, run("sync inventories", func(ctx context.Context) {
+		uc.SyncProductInventories(ctx, nil)
+	}))
+	// 凌晨生成前一日的快照
+	_, _ = c.AddFunc(`5 0 * * *`, run("snapshot", func(ctx context.Context) {
+		uc.FlushProductViews(ctx)
+		uc.SnapshotProductStatistics(ctx, time.Now().AddDate(0, 0, -1))
+	}))
+}
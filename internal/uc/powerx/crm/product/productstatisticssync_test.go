@@ -0,0 +1,36 @@
+package product
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/model/powermodel"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOrderProductQuantities(t *testing.T) {
+	items := []*trade.OrderItem{
+		{PowerModel: &powermodel.PowerModel{Id: 1}, Quantity: 2},
+		{PowerModel: &powermodel.PowerModel{Id: 2}, Quantity: 1},
+		// 组合商品同时计入组件产品
+		{PowerModel: &powermodel.PowerModel{Id: 3}, Quantity: 2, Components: []*trade.OrderItemComponent{
+			{ProductId: 10, Quantity: 4},
+			{ProductId: 11, Quantity: 2},
+		}},
+		// 找不到价格条目的订单项不计入
+		{PowerModel: &powermodel.PowerModel{Id: 4}, Quantity: 5},
+	}
+	mapProducts := map[int64]*orderItemProduct{
+		1: {ProductId: 10},
+		2: {ProductId: 10},
+		3: {ProductId: 20},
+	}
+
+	assert.Equal(t, map[int64]int64{10: 7, 11: 2, 20: 2}, orderProductQuantities(items, mapProducts))
+}
+
+func TestParseProductViewCounts(t *testing.T) {
+	// 非法的产品Id及不大于0的计数不汇总
+	values := []interface{}{"1", "3", "2", "0", "abc", "5", "3", "-2", "4", "7"}
+	assert.Equal(t, map[int64]int64{1: 3, 4: 7}, parseProductViewCounts(values))
+	assert.Equal(t, map[int64]int64{}, parseProductViewCounts(nil))
+}
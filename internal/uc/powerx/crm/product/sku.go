@@ -23,7 +23,7 @@ func NewSKUUseCase(db *gorm.DB) *SKUUseCase {
 }
 
 type FindSKUOption struct {
-	OrderBy   string
+	QuerySpec *powermodel.QuerySpec
 	Ids       []int64
 	ProductId int64
 	types.PageEmbedOption
@@ -39,10 +39,8 @@ func (uc *SKUUseCase) buildFindQueryNoPage(query *gorm.DB, opt *FindSKUOption) *
 
 	}
 
+	query = opt.QuerySpec.Apply(query)
 	orderBy := "id desc"
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
-	}
 	query.Order(orderBy)
 
 	return query
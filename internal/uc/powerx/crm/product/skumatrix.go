@@ -0,0 +1,603 @@
+package product
+
+import (
+	model "PowerX/internal/model/crm/product"
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/types/errorx"
+	"PowerX/internal/uc/powerx"
+	"PowerX/pkg/securityx"
+	"context"
+	"encoding/json"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SKU矩阵由产品规格的规格项组合而成, 规格变更时按组合比较现有SKU和新的组合:
+// 未变的组合保留SKU及其价格和库存, 新增的组合创建SKU, 移除的组合停用SKU,
+// 新增或删除整个规格时, 现有SKU按其余规格项匹配后保留
+
+// 未完结的订单状态, 有这些订单的SKU不能停用
+var skuOpenOrderStatuses = []string{
+	trade.OrderStatusPending,
+	trade.OrderStatusToBePaid,
+	trade.OrderStatusConfirmed,
+	trade.OrderStatusToBeShipped,
+	trade.OrderStatusShipping,
+	trade.OrderStatusRefunding,
+}
+
+type SKUMatrixCombination struct {
+	// 按规格顺序排列, 预览时未保存的规格项为负数的临时Id
+	OptionIds   []int64
+	OptionNames []string
+	// 对应的现有SKU, 新增组合恢复已停用的SKU时也不为空
+	SKU *model.SKU
+	// 移除的组合中SKU未完结的订单数
+	OpenOrderCount int
+}
+
+func (c *SKUMatrixCombination) OptionIdsJSON() []byte {
+	data, _ := json.Marshal(c.OptionIds)
+	return data
+}
+
+type SKUMatrixDiff struct {
+	Added     []*SKUMatrixCombination
+	Unchanged []*SKUMatrixCombination
+	// 规格增删后保留的SKU, 规格组合随之更新
+	Updated []*SKUMatrixCombination
+	Removed []*SKUMatrixCombination
+}
+
+// BlockedSkuNos 有未完结订单而不能停用的SKU编号
+func (d *SKUMatrixDiff) BlockedSkuNos() []string {
+	skuNos := []string{}
+	for _, c := range d.Removed {
+		if c.OpenOrderCount > 0 {
+			skuNos = append(skuNos, c.SKU.SkuNo)
+		}
+	}
+	return skuNos
+}
+
+func skuOptionKey(optionIds []int64) string {
+	ids := append([]int64{}, optionIds...)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	keys := []string{}
+	for _, id := range ids {
+		keys = append(keys, strconv.FormatInt(id, 10))
+	}
+	return strings.Join(keys, ",")
+}
+
+// GenerateSKUOptionCombinations 按规格顺序生成所有规格项组合, 任一规格没有规格项时没有组合
+func GenerateSKUOptionCombinations(specifics []*model.ProductSpecific) [][]int64 {
+	if len(specifics) == 0 {
+		return [][]int64{}
+	}
+	combinations := [][]int64{{}}
+	for _, specific := range specifics {
+		next := [][]int64{}
+		for _, combination := range combinations {
+			for _, option := range specific.Options {
+				next = append(next, append(append([]int64{}, combination...), option.Id))
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// DiffSKUMatrix
+//
+//	@Description: 比较规格组合和现有SKU, 先按相同的规格项集合匹配, 优先匹配未停用的SKU;
+//	未匹配的SKU去掉已删除规格的规格项, 并为新增的规格补上第一个规格项后再匹配, 仍未匹配的SKU被移除
+//	@param specifics 变更后的规格, 按顺序排列
+//	@param skus 产品的所有SKU, 包括已停用的
+//	@param optionSpecificIds 变更前规格项Id到规格Id的映射
+//	@return *SKUMatrixDiff
+func DiffSKUMatrix(specifics []*model.ProductSpecific, skus []*model.SKU, optionSpecificIds map[int64]int64) *SKUMatrixDiff {
+	diff := &SKUMatrixDiff{
+		Added:     []*SKUMatrixCombination{},
+		Unchanged: []*SKUMatrixCombination{},
+		Updated:   []*SKUMatrixCombination{},
+		Removed:   []*SKUMatrixCombination{},
+	}
+
+	specificIds := map[int64]bool{}
+	optionNames := map[int64]string{}
+	for _, specific := range specifics {
+		specificIds[specific.Id] = true
+		for _, option := range specific.Options {
+			optionNames[option.Id] = specific.Name + ":" + option.Name
+		}
+	}
+
+	combinations := []*SKUMatrixCombination{}
+	mapCombinations := map[string]*SKUMatrixCombination{}
+	for _, optionIds := range GenerateSKUOptionCombinations(specifics) {
+		combination := &SKUMatrixCombination{OptionIds: optionIds, OptionNames: []string{}}
+		for _, id := range optionIds {
+			combination.OptionNames = append(combination.OptionNames, optionNames[id])
+		}
+		combinations = append(combinations, combination)
+		mapCombinations[skuOptionKey(optionIds)] = combination
+	}
+
+	sortedSKUs := append([]*model.SKU{}, skus...)
+	sort.SliceStable(sortedSKUs, func(i, j int) bool {
+		if sortedSKUs[i].IsRetired != sortedSKUs[j].IsRetired {
+			return !sortedSKUs[i].IsRetired
+		}
+		return sortedSKUs[i].Id < sortedSKUs[j].Id
+	})
+
+	// 相同规格项集合的SKU直接保留
+	unmatched := []*model.SKU{}
+	skuOptionIds := map[int64][]int64{}
+	for _, sku := range sortedSKUs {
+		optionIds := []int64{}
+		_ = json.Unmarshal(sku.OptionIds, &optionIds)
+		skuOptionIds[sku.Id] = optionIds
+
+		combination, ok := mapCombinations[skuOptionKey(optionIds)]
+		if !ok || combination.SKU != nil {
+			if !sku.IsRetired {
+				unmatched = append(unmatched, sku)
+			}
+			continue
+		}
+		combination.SKU = sku
+		if sku.IsRetired {
+			// 组合恢复, 重新启用停用的SKU
+			diff.Added = append(diff.Added, combination)
+		} else if string(combination.OptionIdsJSON()) != string(sku.OptionIds) {
+			diff.Updated = append(diff.Updated, combination)
+		} else {
+			diff.Unchanged = append(diff.Unchanged, combination)
+		}
+	}
+
+	// 规格增删后, 其余规格项相同的SKU保留
+	firstOptionIds := map[int64]int64{}
+	for _, specific := range specifics {
+		if len(specific.Options) > 0 {
+			firstOptionIds[specific.Id] = specific.Options[0].Id
+		}
+	}
+	for _, sku := range unmatched {
+		optionIds := []int64{}
+		coveredSpecifics := map[int64]bool{}
+		for _, id := range skuOptionIds[sku.Id] {
+			specificId, ok := optionSpecificIds[id]
+			if ok && !specificIds[specificId] {
+				continue
+			}
+			coveredSpecifics[specificId] = true
+			optionIds = append(optionIds, id)
+		}
+		for _, specific := range specifics {
+			if !coveredSpecifics[specific.Id] {
+				optionIds = append(optionIds, firstOptionIds[specific.Id])
+			}
+		}
+
+		combination, ok := mapCombinations[skuOptionKey(optionIds)]
+		if ok && combination.SKU == nil {
+			combination.SKU = sku
+			diff.Updated = append(diff.Updated, combination)
+			continue
+		}
+
+		removed := &SKUMatrixCombination{OptionIds: skuOptionIds[sku.Id], OptionNames: []string{}, SKU: sku}
+		for _, id := range removed.OptionIds {
+			if name, ok := optionNames[id]; ok {
+				removed.OptionNames = append(removed.OptionNames, name)
+			}
+		}
+		diff.Removed = append(diff.Removed, removed)
+	}
+
+	for _, combination := range combinations {
+		if combination.SKU == nil {
+			diff.Added = append(diff.Added, combination)
+		}
+	}
+
+	return diff
+}
+
+// prepareSKUMatrixSpecifics 校验变更后的规格, 返回副本, 未保存的规格和规格项使用负数临时Id
+func prepareSKUMatrixSpecifics(productId int64, specifics []*model.ProductSpecific, existing []*model.ProductSpecific) ([]*model.ProductSpecific, error) {
+	existingSpecificIds := map[int64]bool{}
+	existingOptionIds := map[int64]bool{}
+	for _, specific := range existing {
+		existingSpecificIds[specific.Id] = true
+		for _, option := range specific.Options {
+			existingOptionIds[option.Id] = true
+		}
+	}
+
+	var tempId int64
+	prepared := []*model.ProductSpecific{}
+	specificNames := map[string]bool{}
+	for _, specific := range specifics {
+		name := strings.TrimSpace(specific.Name)
+		if name == "" {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "规格名称不能为空")
+		}
+		if specificNames[name] {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "规格名称重复: "+name)
+		}
+		specificNames[name] = true
+		if specific.Id > 0 && !existingSpecificIds[specific.Id] {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("规格%d不属于该产品", specific.Id))
+		}
+
+		copied := &model.ProductSpecific{ProductId: productId, Name: name, Options: []*model.SpecificOption{}}
+		copied.Id = specific.Id
+		if copied.Id == 0 {
+			tempId--
+			copied.Id = tempId
+		}
+
+		optionNames := map[string]bool{}
+		for _, option := range specific.Options {
+			optionName := strings.TrimSpace(option.Name)
+			if optionName == "" {
+				return nil, errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("规格%s的规格项名称不能为空", name))
+			}
+			if optionNames[optionName] {
+				return nil, errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("规格%s的规格项重复: %s", name, optionName))
+			}
+			optionNames[optionName] = true
+			if option.Id > 0 && !existingOptionIds[option.Id] {
+				return nil, errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("规格项%d不属于该产品", option.Id))
+			}
+
+			copiedOption := &model.SpecificOption{ProductSpecificId: copied.Id, Name: optionName, IsActivated: option.IsActivated}
+			copiedOption.Id = option.Id
+			if copiedOption.Id == 0 {
+				tempId--
+				copiedOption.Id = tempId
+			}
+			copied.Options = append(copied.Options, copiedOption)
+		}
+		prepared = append(prepared, copied)
+	}
+	return prepared, nil
+}
+
+func (uc *ProductUseCase) loadSKUMatrix(db *gorm.DB, productId int64) ([]*model.ProductSpecific, []*model.SKU, map[int64]int64, error) {
+	specifics := []*model.ProductSpecific{}
+	err := db.Preload("Options", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Where("product_id = ?", productId).
+		Order("id").
+		Find(&specifics).Error
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	skus := []*model.SKU{}
+	if err = db.Where("product_id = ?", productId).Order("id").Find(&skus).Error; err != nil {
+		return nil, nil, nil, err
+	}
+	optionSpecificIds := map[int64]int64{}
+	for _, specific := range specifics {
+		for _, option := range specific.Options {
+			optionSpecificIds[option.Id] = specific.Id
+		}
+	}
+	return specifics, skus, optionSpecificIds, nil
+}
+
+// countSKUOpenOrders 统计移除的SKU未完结的订单数, 订单项按价格条目或SKU编号关联SKU
+func (uc *ProductUseCase) countSKUOpenOrders(ctx context.Context, db *gorm.DB, diff *SKUMatrixDiff) error {
+	if len(diff.Removed) == 0 {
+		return nil
+	}
+
+	skuIds := []int64{}
+	mapSkuNos := map[string]int64{}
+	for _, c := range diff.Removed {
+		skuIds = append(skuIds, c.SKU.Id)
+		if c.SKU.SkuNo != "" {
+			mapSkuNos[c.SKU.SkuNo] = c.SKU.Id
+		}
+	}
+	skuNos := []string{}
+	for skuNo := range mapSkuNos {
+		skuNos = append(skuNos, skuNo)
+	}
+
+	entries := []*model.PriceBookEntry{}
+	if err := db.Where("sku_id IN ?", skuIds).Find(&entries).Error; err != nil {
+		return err
+	}
+	mapEntrySkuIds := map[int64]int64{}
+	entryIds := []int64{}
+	for _, entry := range entries {
+		mapEntrySkuIds[entry.Id] = entry.SkuId
+		entryIds = append(entryIds, entry.Id)
+	}
+
+	ucDD := powerx.NewDataDictionaryUseCase(uc.db)
+	statusIds := []int{}
+	for _, status := range skuOpenOrderStatuses {
+		if item, err := ucDD.GetDataDictionaryItem(ctx, trade.TypeOrderStatus, status); err == nil {
+			statusIds = append(statusIds, int(item.Id))
+		}
+	}
+	if len(statusIds) == 0 {
+		return nil
+	}
+
+	query := db.Model(&trade.OrderItem{}).
+		Where("order_id IN (?)", db.Model(&trade.Order{}).Select("id").Where("status IN ?", statusIds))
+	if len(entryIds) > 0 && len(skuNos) > 0 {
+		query = query.Where("price_book_entry_id IN ? OR sku_no IN ?", entryIds, skuNos)
+	} else if len(entryIds) > 0 {
+		query = query.Where("price_book_entry_id IN ?", entryIds)
+	} else if len(skuNos) > 0 {
+		query = query.Where("sku_no IN ?", skuNos)
+	} else {
+		return nil
+	}
+	items := []*trade.OrderItem{}
+	if err := query.Find(&items).Error; err != nil {
+		return err
+	}
+
+	orders := map[int64]map[int64]bool{}
+	for _, item := range items {
+		skuId, ok := mapEntrySkuIds[item.PriceBookEntryId]
+		if !ok {
+			if skuId, ok = mapSkuNos[item.SkuNo]; !ok {
+				continue
+			}
+		}
+		if orders[skuId] == nil {
+			orders[skuId] = map[int64]bool{}
+		}
+		orders[skuId][item.OrderId] = true
+	}
+	for _, c := range diff.Removed {
+		c.OpenOrderCount = len(orders[c.SKU.Id])
+	}
+	return nil
+}
+
+// PreviewSKUMatrix 预览规格变更后SKU的变化, 不做修改
+func (uc *ProductUseCase) PreviewSKUMatrix(ctx context.Context, productId int64, specifics []*model.ProductSpecific) (*SKUMatrixDiff, error) {
+	db := uc.db.WithContext(ctx)
+	existing, skus, optionSpecificIds, err := uc.loadSKUMatrix(db, productId)
+	if err != nil {
+		panic(err)
+	}
+	prepared, err := prepareSKUMatrixSpecifics(productId, specifics, existing)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := DiffSKUMatrix(prepared, skus, optionSpecificIds)
+	if err = uc.countSKUOpenOrders(ctx, db, diff); err != nil {
+		panic(err)
+	}
+	return diff, nil
+}
+
+// ApplySKUMatrix
+//
+//	@Description: 保存产品的全部规格, 请求中没有的规格和规格项被删除, 然后按比较结果更新SKU; 移除的SKU有未完结订单时不做修改并返回错误
+//	@receiver uc
+//	@param ctx
+//	@param productId
+//	@param specifics 产品的全部规格
+//	@return *SKUMatrixDiff
+//	@return error
+func (uc *ProductUseCase) ApplySKUMatrix(ctx context.Context, productId int64, specifics []*model.ProductSpecific) (*SKUMatrixDiff, error) {
+	product := &model.Product{}
+	if err := uc.db.WithContext(ctx).First(product, productId).Error; err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "未找到产品")
+	}
+	if product.IsBundle {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "组合商品不能配置规格, 请配置组件")
+	}
+
+	var diff *SKUMatrixDiff
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		existing, _, optionSpecificIds, err := uc.loadSKUMatrix(tx, productId)
+		if err != nil {
+			return err
+		}
+		prepared, err := prepareSKUMatrixSpecifics(productId, specifics, existing)
+		if err != nil {
+			return err
+		}
+
+		saved, err := saveSKUMatrixSpecifics(tx, productId, prepared)
+		if err != nil {
+			return err
+		}
+		_, skus, _, err := uc.loadSKUMatrix(tx, productId)
+		if err != nil {
+			return err
+		}
+
+		diff = DiffSKUMatrix(saved, skus, optionSpecificIds)
+		if err = uc.countSKUOpenOrders(ctx, tx, diff); err != nil {
+			return err
+		}
+		if blocked := diff.BlockedSkuNos(); len(blocked) > 0 {
+			return errorx.WithCause(errorx.ErrBadRequest, "以下SKU有未完结的订单, 不能移除: "+strings.Join(blocked, "、"))
+		}
+
+		return uc.applySKUMatrixDiff(tx, product, saved, diff)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
+// saveSKUMatrixSpecifics 保存规格和规格项, 临时Id替换为保存后的Id, 删除请求中没有的规格和规格项
+func saveSKUMatrixSpecifics(tx *gorm.DB, productId int64, specifics []*model.ProductSpecific) ([]*model.ProductSpecific, error) {
+	specificIds := []int64{}
+	optionIds := []int64{}
+	for _, specific := range specifics {
+		if specific.Id < 0 {
+			specific.Id = 0
+			if err := tx.Omit("Options").Create(specific).Error; err != nil {
+				return nil, err
+			}
+		} else if err := tx.Model(&model.ProductSpecific{}).Where("id = ?", specific.Id).Update("name", specific.Name).Error; err != nil {
+			return nil, err
+		}
+		specificIds = append(specificIds, specific.Id)
+
+		for _, option := range specific.Options {
+			option.ProductSpecificId = specific.Id
+			if option.Id < 0 {
+				option.Id = 0
+				if err := tx.Create(option).Error; err != nil {
+					return nil, err
+				}
+			} else {
+				err := tx.Model(&model.SpecificOption{}).Where("id = ?", option.Id).Updates(map[string]interface{}{
+					"product_specific_id": option.ProductSpecificId,
+					"name":                option.Name,
+					"is_activated":        option.IsActivated,
+				}).Error
+				if err != nil {
+					return nil, err
+				}
+			}
+			optionIds = append(optionIds, option.Id)
+		}
+	}
+
+	// 删除不再使用的规格项和规格
+	allSpecificIds := tx.Model(&model.ProductSpecific{}).Select("id").Where("product_id = ?", productId)
+	deleteOptions := tx.Where("product_specific_id IN (?)", allSpecificIds)
+	if len(optionIds) > 0 {
+		deleteOptions = deleteOptions.Where("id NOT IN ?", optionIds)
+	}
+	if err := deleteOptions.Delete(&model.SpecificOption{}).Error; err != nil {
+		return nil, err
+	}
+	deleteSpecifics := tx.Where("product_id = ?", productId)
+	if len(specificIds) > 0 {
+		deleteSpecifics = deleteSpecifics.Where("id NOT IN ?", specificIds)
+	}
+	if err := deleteSpecifics.Delete(&model.ProductSpecific{}).Error; err != nil {
+		return nil, err
+	}
+
+	return specifics, nil
+}
+
+func (uc *ProductUseCase) applySKUMatrixDiff(tx *gorm.DB, product *model.Product, specifics []*model.ProductSpecific, diff *SKUMatrixDiff) error {
+	now := time.Now()
+
+	// 停用移除的SKU及其价格条目, 先停用并更换唯一Id, 以释放规格组合
+	for _, c := range diff.Removed {
+		retiredUniqueId := securityx.HashStringData(fmt.Sprintf("%s-retired-%d", c.SKU.UniqueID.String, c.SKU.Id))
+		err := tx.Model(&model.SKU{}).Where("id = ?", c.SKU.Id).Updates(map[string]interface{}{
+			"index_unique_id": retiredUniqueId,
+			"is_retired":      true,
+			"retired_at":      now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&model.PriceBookEntry{}).Where("sku_id = ?", c.SKU.Id).Update("is_active", false).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, c := range diff.Updated {
+		sku := &model.SKU{ProductId: product.Id, OptionIds: c.OptionIdsJSON()}
+		err := tx.Model(&model.SKU{}).Where("id = ?", c.SKU.Id).Updates(map[string]interface{}{
+			"option_ids":      sku.OptionIds,
+			"index_unique_id": sku.GetComposedUniqueID(),
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, c := range diff.Added {
+		// 恢复停用的SKU
+		if c.SKU != nil {
+			sku := &model.SKU{ProductId: product.Id, OptionIds: c.OptionIdsJSON()}
+			err := tx.Model(&model.SKU{}).Where("id = ?", c.SKU.Id).Updates(map[string]interface{}{
+				"option_ids":      sku.OptionIds,
+				"index_unique_id": sku.GetComposedUniqueID(),
+				"is_retired":      false,
+				"retired_at":      nil,
+			}).Error
+			if err != nil {
+				return err
+			}
+			if err = tx.Model(&model.PriceBookEntry{}).Where("sku_id = ?", c.SKU.Id).Update("is_active", true).Error; err != nil {
+				return err
+			}
+			continue
+		}
+
+		names := []string{product.SPU}
+		for _, name := range c.OptionNames {
+			names = append(names, name[strings.Index(name, ":")+1:])
+		}
+		sku := &model.SKU{
+			ProductId: product.Id,
+			SkuNo:     strings.Join(names, "-"),
+			OptionIds: c.OptionIdsJSON(),
+		}
+		sku.UniqueID = sku.GetComposedUniqueID()
+		if err := tx.Create(sku).Error; err != nil {
+			return err
+		}
+		c.SKU = sku
+	}
+
+	// 重新生成在用SKU的规格关联, 删除已删除规格项和停用SKU的关联
+	activeSKUs := []*model.SKU{}
+	if err := tx.Where("product_id = ? AND is_retired = ?", product.Id, false).Find(&activeSKUs).Error; err != nil {
+		return err
+	}
+	activeSkuIds := []int64{0}
+	for _, sku := range activeSKUs {
+		activeSkuIds = append(activeSkuIds, sku.Id)
+	}
+	optionIds := []int64{0}
+	for _, specific := range specifics {
+		for _, option := range specific.Options {
+			optionIds = append(optionIds, option.Id)
+		}
+	}
+	err := tx.Unscoped().
+		Where("product_id = ?", product.Id).
+		Where("sku_id NOT IN ? OR specific_option_id NOT IN ?", activeSkuIds, optionIds).
+		Delete(&model.PivotSkuToSpecificOption{}).Error
+	if err != nil {
+		return err
+	}
+	pivots := uc.GeneratePivotSKUsFromSpecifics(context.Background(), specifics, activeSKUs)
+	if len(pivots) > 0 {
+		err = tx.Model(&model.PivotSkuToSpecificOption{}).
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: model.PivotPivotSkuToSpecificOptionsUniqueId}},
+				DoUpdates: clause.AssignmentColumns([]string{"is_activated", "updated_at"}),
+			}).Create(&pivots).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
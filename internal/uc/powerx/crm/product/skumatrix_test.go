@@ -0,0 +1,116 @@
+package product
+
+import (
+	model "PowerX/internal/model/crm/product"
+	"reflect"
+	"testing"
+)
+
+func newTestSpecific(id int64, name string, options map[int64]string, order ...int64) *model.ProductSpecific {
+	specific := &model.ProductSpecific{Name: name}
+	specific.Id = id
+	for _, optionId := range order {
+		option := &model.SpecificOption{ProductSpecificId: id, Name: options[optionId]}
+		option.Id = optionId
+		specific.Options = append(specific.Options, option)
+	}
+	return specific
+}
+
+func newTestSKU(id int64, optionIds string, isRetired bool) *model.SKU {
+	sku := &model.SKU{OptionIds: []byte(optionIds), IsRetired: isRetired}
+	sku.Id = id
+	return sku
+}
+
+func TestGenerateSKUOptionCombinations(t *testing.T) {
+	specifics := []*model.ProductSpecific{
+		newTestSpecific(1, "颜色", map[int64]string{11: "红", 12: "蓝"}, 11, 12),
+		newTestSpecific(2, "尺码", map[int64]string{21: "S", 22: "M"}, 21, 22),
+	}
+	want := [][]int64{{11, 21}, {11, 22}, {12, 21}, {12, 22}}
+	if got := GenerateSKUOptionCombinations(specifics); !reflect.DeepEqual(got, want) {
+		t.Errorf("combinations = %v, want %v", got, want)
+	}
+
+	specifics = append(specifics, newTestSpecific(3, "材质", nil))
+	if got := GenerateSKUOptionCombinations(specifics); len(got) != 0 {
+		t.Errorf("combinations with empty specific = %v, want none", got)
+	}
+	if got := GenerateSKUOptionCombinations(nil); len(got) != 0 {
+		t.Errorf("combinations without specifics = %v, want none", got)
+	}
+}
+
+func TestDiffSKUMatrix(t *testing.T) {
+	optionSpecificIds := map[int64]int64{11: 1, 12: 1, 13: 1, 21: 2, 22: 2}
+
+	// 删除规格项"蓝", 新增规格项"绿"
+	specifics := []*model.ProductSpecific{
+		newTestSpecific(1, "颜色", map[int64]string{11: "红", -1: "绿"}, 11, -1),
+		newTestSpecific(2, "尺码", map[int64]string{21: "S", 22: "M"}, 21, 22),
+	}
+	skus := []*model.SKU{
+		newTestSKU(101, "[11,21]", false),
+		newTestSKU(102, "[22,11]", false),
+		newTestSKU(103, "[12,21]", false),
+		newTestSKU(104, "[12,22]", false),
+	}
+	diff := DiffSKUMatrix(specifics, skus, optionSpecificIds)
+
+	if len(diff.Unchanged) != 1 || diff.Unchanged[0].SKU.Id != 101 {
+		t.Errorf("unchanged = %+v", diff.Unchanged)
+	}
+	if len(diff.Updated) != 1 || diff.Updated[0].SKU.Id != 102 || !reflect.DeepEqual(diff.Updated[0].OptionIds, []int64{11, 22}) {
+		t.Errorf("updated = %+v", diff.Updated)
+	}
+	if len(diff.Removed) != 2 || diff.Removed[0].SKU.Id != 103 || diff.Removed[1].SKU.Id != 104 {
+		t.Errorf("removed = %+v", diff.Removed)
+	}
+	if len(diff.Added) != 2 || diff.Added[0].SKU != nil || !reflect.DeepEqual(diff.Added[0].OptionNames, []string{"颜色:绿", "尺码:S"}) {
+		t.Errorf("added = %+v", diff.Added)
+	}
+}
+
+func TestDiffSKUMatrixRestoreAndNewSpecific(t *testing.T) {
+	optionSpecificIds := map[int64]int64{11: 1, 12: 1}
+
+	// 恢复已停用的"蓝", 并新增规格"尺码"
+	specifics := []*model.ProductSpecific{
+		newTestSpecific(1, "颜色", map[int64]string{11: "红", 12: "蓝"}, 11, 12),
+		newTestSpecific(-1, "尺码", map[int64]string{-2: "S", -3: "M"}, -2, -3),
+	}
+	skus := []*model.SKU{
+		newTestSKU(101, "[11]", false),
+		newTestSKU(102, "[12]", true),
+	}
+	diff := DiffSKUMatrix(specifics, skus, optionSpecificIds)
+
+	// 停用的SKU规格组合不再匹配, 作为新增组合
+	if len(diff.Updated) != 1 || diff.Updated[0].SKU.Id != 101 || !reflect.DeepEqual(diff.Updated[0].OptionIds, []int64{11, -2}) {
+		t.Errorf("updated = %+v", diff.Updated)
+	}
+	if len(diff.Added) != 3 || len(diff.Removed) != 0 || len(diff.Unchanged) != 0 {
+		t.Errorf("added = %d, removed = %d, unchanged = %d", len(diff.Added), len(diff.Removed), len(diff.Unchanged))
+	}
+
+	// 规格不变时, 停用SKU的组合恢复
+	specifics = specifics[:1]
+	diff = DiffSKUMatrix(specifics, skus, optionSpecificIds)
+	if len(diff.Added) != 1 || diff.Added[0].SKU == nil || diff.Added[0].SKU.Id != 102 {
+		t.Errorf("restored = %+v", diff.Added)
+	}
+	if len(diff.Unchanged) != 1 || diff.Unchanged[0].SKU.Id != 101 {
+		t.Errorf("unchanged = %+v", diff.Unchanged)
+	}
+}
+
+func TestSKUMatrixDiffBlockedSkuNos(t *testing.T) {
+	diff := &SKUMatrixDiff{Removed: []*SKUMatrixCombination{
+		{SKU: &model.SKU{SkuNo: "SPU-红"}, OpenOrderCount: 2},
+		{SKU: &model.SKU{SkuNo: "SPU-蓝"}},
+	}}
+	if got := diff.BlockedSkuNos(); !reflect.DeepEqual(got, []string{"SPU-红"}) {
+		t.Errorf("blocked = %v", got)
+	}
+}
@@ -0,0 +1,372 @@
+package product
+
+import (
+	"PowerX/internal/model/crm/product"
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/types"
+	"PowerX/internal/types/errorx"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StoreProductUseCase struct {
+	db *gorm.DB
+}
+
+func NewStoreProductUseCase(db *gorm.DB) *StoreProductUseCase {
+	return &StoreProductUseCase{
+		db: db,
+	}
+}
+
+type FindManyStoreProductsOption struct {
+	StoreId    int64
+	ProductIds []int64
+	types.PageEmbedOption
+}
+
+// StoreOrderLine 门店下单的商品行, 组合商品没有SKU
+type StoreOrderLine struct {
+	ProductId   int64
+	SkuId       int64
+	ProductName string
+	Quantity    int
+}
+
+func (uc *StoreProductUseCase) FindManyStoreProducts(ctx context.Context, opt *FindManyStoreProductsOption) types.Page[*product.StoreProduct] {
+	var storeProducts []*product.StoreProduct
+	var count int64
+	query := uc.db.WithContext(ctx).Model(&product.StoreProduct{}).
+		Where("store_id = ?", opt.StoreId)
+	if len(opt.ProductIds) > 0 {
+		query = query.Where("product_id IN ?", opt.ProductIds)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		panic(errors.Wrap(err, "find many store products failed"))
+	}
+
+	opt.DefaultPageIfNotSet()
+	if opt.PageIndex != 0 && opt.PageSize != 0 {
+		query.Offset((opt.PageIndex - 1) * opt.PageSize).Limit(opt.PageSize)
+	}
+
+	if err := query.
+		Preload("Product.PivotCoverImages.MediaResource").
+		Preload("Product.SKUs", "is_retired = ?", false, func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Order("id desc").
+		Find(&storeProducts).Error; err != nil {
+		panic(errors.Wrap(err, "find many store products failed"))
+	}
+	return types.Page[*product.StoreProduct]{
+		List:      storeProducts,
+		PageIndex: opt.PageIndex,
+		PageSize:  opt.PageSize,
+		Total:     count,
+	}
+}
+
+// FindStoreSKUs 门店对产品SKU的覆盖配置, 按SKUId索引
+func (uc *StoreProductUseCase) FindStoreSKUs(ctx context.Context, storeId int64, productIds []int64) map[int64]*product.StoreSKU {
+	storeSKUs := []*product.StoreSKU{}
+	if err := uc.db.WithContext(ctx).
+		Where("store_id = ? AND product_id IN ?", storeId, productIds).
+		Find(&storeSKUs).Error; err != nil {
+		panic(errors.Wrap(err, "find store skus failed"))
+	}
+	mapStoreSKUs := map[int64]*product.StoreSKU{}
+	for _, storeSKU := range storeSKUs {
+		mapStoreSKUs[storeSKU.SkuId] = storeSKU
+	}
+	return mapStoreSKUs
+}
+
+// IsProductAvailableInStore 产品是否已上架到门店且门店可售
+func (uc *StoreProductUseCase) IsProductAvailableInStore(ctx context.Context, storeId int64, productId int64) bool {
+	var count int64
+	if err := uc.db.WithContext(ctx).Model(&product.StoreProduct{}).
+		Where("store_id = ? AND product_id = ? AND is_available = ?", storeId, productId, true).
+		Count(&count).Error; err != nil {
+		panic(err)
+	}
+	return count > 0
+}
+
+// AssignStoreProducts
+//
+//	@Description: 产品上架到门店, 已上架的产品只更新可售状态
+//	@receiver uc
+//	@param ctx
+//	@param storeId
+//	@param productIds
+//	@param isAvailable
+//	@return error
+func (uc *StoreProductUseCase) AssignStoreProducts(ctx context.Context, storeId int64, productIds []int64, isAvailable bool) error {
+	uniqueIds := map[int64]bool{}
+	for _, productId := range productIds {
+		uniqueIds[productId] = true
+	}
+	productIds = []int64{}
+	for productId := range uniqueIds {
+		productIds = append(productIds, productId)
+	}
+
+	var count int64
+	if err := uc.db.WithContext(ctx).Model(&product.Product{}).
+		Where("id IN ?", productIds).
+		Count(&count).Error; err != nil {
+		panic(err)
+	}
+	if int(count) != len(productIds) {
+		return errorx.WithCause(errorx.ErrBadRequest, "存在无效的产品")
+	}
+
+	storeProducts := []*product.StoreProduct{}
+	for _, productId := range productIds {
+		storeProducts = append(storeProducts, &product.StoreProduct{
+			StoreId:     storeId,
+			ProductId:   productId,
+			IsAvailable: isAvailable,
+		})
+	}
+	err := uc.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "store_id"}, {Name: "product_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"is_available", "updated_at"}),
+		}).
+		Create(&storeProducts).Error
+	if err != nil {
+		panic(errors.Wrap(err, "assign store products failed"))
+	}
+	return nil
+}
+
+// RemoveStoreProducts 产品从门店下架, 同时清除门店对其SKU的覆盖配置
+func (uc *StoreProductUseCase) RemoveStoreProducts(ctx context.Context, storeId int64, productIds []int64) {
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().
+			Where("store_id = ? AND product_id IN ?", storeId, productIds).
+			Delete(&product.StoreSKU{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().
+			Where("store_id = ? AND product_id IN ?", storeId, productIds).
+			Delete(&product.StoreProduct{}).Error
+	})
+	if err != nil {
+		panic(errors.Wrap(err, "remove store products failed"))
+	}
+}
+
+// UpsertStoreSKUs
+//
+//	@Description: 配置门店SKU的可售状态及库存, SKU所属产品需已上架到门店
+//	@receiver uc
+//	@param ctx
+//	@param storeId
+//	@param storeSKUs
+//	@return error
+func (uc *StoreProductUseCase) UpsertStoreSKUs(ctx context.Context, storeId int64, storeSKUs []*product.StoreSKU) error {
+	skuIds := []int64{}
+	for _, storeSKU := range storeSKUs {
+		if storeSKU.Inventory != nil && *storeSKU.Inventory < 0 {
+			return errorx.WithCause(errorx.ErrBadRequest, "门店库存不能小于0")
+		}
+		skuIds = append(skuIds, storeSKU.SkuId)
+	}
+
+	skus := []*product.SKU{}
+	if err := uc.db.WithContext(ctx).Where("id IN ?", skuIds).Find(&skus).Error; err != nil {
+		panic(err)
+	}
+	mapSkus := map[int64]*product.SKU{}
+	for _, sku := range skus {
+		mapSkus[sku.Id] = sku
+	}
+
+	productIds := []int64{}
+	for _, storeSKU := range storeSKUs {
+		sku, ok := mapSkus[storeSKU.SkuId]
+		if !ok {
+			return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("SKU %d不存在", storeSKU.SkuId))
+		}
+		storeSKU.StoreId = storeId
+		storeSKU.ProductId = sku.ProductId
+		productIds = append(productIds, sku.ProductId)
+	}
+
+	var assignedProductIds []int64
+	if err := uc.db.WithContext(ctx).Model(&product.StoreProduct{}).
+		Where("store_id = ? AND product_id IN ?", storeId, productIds).
+		Pluck("product_id", &assignedProductIds).Error; err != nil {
+		panic(err)
+	}
+	assigned := map[int64]bool{}
+	for _, productId := range assignedProductIds {
+		assigned[productId] = true
+	}
+	for _, storeSKU := range storeSKUs {
+		if !assigned[storeSKU.ProductId] {
+			return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("%s所属产品未上架到该门店", mapSkus[storeSKU.SkuId].SkuNo))
+		}
+	}
+
+	err := uc.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "store_id"}, {Name: "sku_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"product_id", "is_available", "inventory", "updated_at"}),
+		}).
+		Create(&storeSKUs).Error
+	if err != nil {
+		panic(errors.Wrap(err, "upsert store skus failed"))
+	}
+	return nil
+}
+
+// ApplyStoreOverrides 按门店配置覆盖产品SKU的库存, 门店不可售的SKU不再返回
+func (uc *StoreProductUseCase) ApplyStoreOverrides(ctx context.Context, storeId int64, products []*product.Product) {
+	productIds := []int64{}
+	for _, p := range products {
+		productIds = append(productIds, p.Id)
+	}
+	if len(productIds) == 0 {
+		return
+	}
+	mapStoreSKUs := uc.FindStoreSKUs(ctx, storeId, productIds)
+	for _, p := range products {
+		skus := []*product.SKU{}
+		for _, sku := range p.SKUs {
+			inventory, isAvailable := product.ResolveStoreSKUInventory(sku, mapStoreSKUs[sku.Id])
+			if !isAvailable {
+				continue
+			}
+			sku.Inventory = inventory
+			skus = append(skus, sku)
+		}
+		p.SKUs = skus
+	}
+}
+
+// ApplyStoreContext 产品按门店价格手册定价, 并按门店配置覆盖SKU的可售及库存
+func (uc *StoreProductUseCase) ApplyStoreContext(ctx context.Context, storeId int64, products []*product.Product) {
+	priceBook, err := NewPriceBookUseCase(uc.db).GetStorePriceBook(ctx, storeId)
+	if err == nil && !priceBook.IsStandard {
+		NewPriceBookEntryUseCase(uc.db).ApplyPriceBookToProducts(ctx, priceBook.Id, products)
+	}
+	uc.ApplyStoreOverrides(ctx, storeId, products)
+}
+
+// ReserveStoreInventories
+//
+//	@Description: 门店下单时校验商品在门店可售, 并扣减配置了门店库存的SKU, 需在下单事务内调用
+//	@receiver uc
+//	@param ctx
+//	@param storeId
+//	@param lines
+//	@return error
+func (uc *StoreProductUseCase) ReserveStoreInventories(ctx context.Context, storeId int64, lines []*StoreOrderLine) error {
+	productIds := []int64{}
+	for _, line := range lines {
+		productIds = append(productIds, line.ProductId)
+	}
+
+	var availableProductIds []int64
+	if err := uc.db.WithContext(ctx).Model(&product.StoreProduct{}).
+		Where("store_id = ? AND product_id IN ? AND is_available = ?", storeId, productIds, true).
+		Pluck("product_id", &availableProductIds).Error; err != nil {
+		return err
+	}
+	available := map[int64]bool{}
+	for _, productId := range availableProductIds {
+		available[productId] = true
+	}
+
+	mapStoreSKUs := uc.FindStoreSKUs(ctx, storeId, productIds)
+	for _, line := range lines {
+		if !available[line.ProductId] {
+			return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("商品%s在当前门店不可售", line.ProductName))
+		}
+		storeSKU := mapStoreSKUs[line.SkuId]
+		if line.SkuId == 0 || storeSKU == nil {
+			continue
+		}
+		if !storeSKU.IsAvailable {
+			return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("商品%s的规格在当前门店不可售", line.ProductName))
+		}
+		if storeSKU.Inventory == nil {
+			continue
+		}
+		result := uc.db.WithContext(ctx).Model(&product.StoreSKU{}).
+			Where("id = ? AND inventory >= ?", storeSKU.Id, line.Quantity).
+			UpdateColumn("inventory", gorm.Expr("inventory - ?", line.Quantity))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("商品%s在当前门店库存不足", line.ProductName))
+		}
+	}
+	return nil
+}
+
+// ReleaseStoreInventories
+//
+//	@Description: 门店订单取消或全额退款时退回扣减的门店库存, 只退回配置了门店库存的SKU, 需在订单事务内调用
+//	@receiver uc
+//	@param ctx
+//	@param storeId
+//	@param lines
+//	@return error
+func (uc *StoreProductUseCase) ReleaseStoreInventories(ctx context.Context, storeId int64, lines []*StoreOrderLine) error {
+	productIds := []int64{}
+	for _, line := range lines {
+		productIds = append(productIds, line.ProductId)
+	}
+	if len(productIds) == 0 {
+		return nil
+	}
+
+	mapStoreSKUs := uc.FindStoreSKUs(ctx, storeId, productIds)
+	for _, line := range lines {
+		storeSKU := mapStoreSKUs[line.SkuId]
+		if line.SkuId == 0 || storeSKU == nil || storeSKU.Inventory == nil || line.Quantity <= 0 {
+			continue
+		}
+		err := uc.db.WithContext(ctx).Model(&product.StoreSKU{}).
+			Where("id = ? AND inventory IS NOT NULL", storeSKU.Id).
+			UpdateColumn("inventory", gorm.Expr("inventory + ?", line.Quantity)).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReleaseOrderStoreInventories 按订单项退回门店订单扣减的门店库存
+func (uc *StoreProductUseCase) ReleaseOrderStoreInventories(ctx context.Context, order *trade.Order) error {
+	if order.StoreId == 0 {
+		return nil
+	}
+	items := []*trade.OrderItem{}
+	if err := uc.db.WithContext(ctx).Where("order_id = ?", order.Id).Find(&items).Error; err != nil {
+		return err
+	}
+
+	mapProducts := findOrderItemProducts(ctx, uc.db, order.CartId > 0, items)
+	lines := []*StoreOrderLine{}
+	for _, item := range items {
+		refer, ok := mapProducts[item.Id]
+		if !ok {
+			continue
+		}
+		lines = append(lines, &StoreOrderLine{
+			ProductId:   refer.ProductId,
+			SkuId:       refer.SkuId,
+			ProductName: item.ProductName,
+			Quantity:    item.Quantity,
+		})
+	}
+	return uc.ReleaseStoreInventories(ctx, order.StoreId, lines)
+}
@@ -25,7 +25,7 @@ type FindManyCartsOption struct {
 	CustomerId int64
 	Ids        []int64
 	LikeName   string
-	OrderBy    string
+	QuerySpec  *powermodel.QuerySpec
 	types.PageEmbedOption
 }
 
@@ -34,7 +34,7 @@ type FindManyCartItemsOption struct {
 	CartIds    []int64
 	Ids        []int64
 	LikeName   string
-	OrderBy    string
+	QuerySpec  *powermodel.QuerySpec
 	types.PageEmbedOption
 }
 
@@ -52,10 +52,8 @@ func (uc *CartUseCase) buildFindQueryNoPage(db *gorm.DB, opt *FindManyCartsOptio
 		db = db.Where("name LIKE ?", "%"+opt.LikeName+"%")
 	}
 
+	db = opt.QuerySpec.Apply(db)
 	orderBy := "id desc"
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
-	}
 	db.Order(orderBy)
 
 	return db
@@ -81,10 +79,8 @@ func (uc *CartUseCase) buildFindQueryNoPageForCartItems(db *gorm.DB, opt *FindMa
 		db = db.Where("name LIKE ?", "%"+opt.LikeName+"%")
 	}
 
+	db = opt.QuerySpec.Apply(db)
 	orderBy := "id desc"
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
-	}
 	db.Order(orderBy)
 
 	return db
@@ -0,0 +1,428 @@
+package trade
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/types"
+	"PowerX/internal/types/errorx"
+	"PowerX/internal/uc/powerx"
+	"PowerX/pkg/slicex"
+	"context"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type InvoiceUseCase struct {
+	db *gorm.DB
+}
+
+func NewInvoiceUseCase(db *gorm.DB) *InvoiceUseCase {
+	return &InvoiceUseCase{
+		db: db,
+	}
+}
+
+type FindManyInvoicesOption struct {
+	CustomerId    int64
+	OrderId       int64
+	Kind          string
+	Statuses      []string
+	RequestNumber string
+	StartAt       time.Time
+	EndAt         time.Time
+	types.PageEmbedOption
+}
+
+// 可以申请开票的订单状态, 即已付清之后的状态
+var invoiceableOrderStatuses = []string{
+	trade.OrderStatusToBeShipped,
+	trade.OrderStatusShipping,
+	trade.OrderStatusDelivered,
+	trade.OrderStatusCompleted,
+}
+
+var taxIdNumberPattern = regexp.MustCompile(`^[0-9A-Z]{15}$|^[0-9A-Z]{17}$|^[0-9A-Z]{18}$|^[0-9A-Z]{20}$`)
+
+// ValidateInvoiceTitle
+//
+//	@Description: 校验开票抬头, 单位抬头必须填写纳税人识别号
+//	@param invoice
+//	@return error
+func ValidateInvoiceTitle(invoice *trade.Invoice) error {
+	invoice.Title = strings.TrimSpace(invoice.Title)
+	invoice.TaxIdNumber = strings.ToUpper(strings.TrimSpace(invoice.TaxIdNumber))
+	invoice.Email = strings.TrimSpace(invoice.Email)
+
+	switch invoice.TitleType {
+	case trade.InvoiceTitleTypePersonal:
+	case trade.InvoiceTitleTypeCompany:
+		if !taxIdNumberPattern.MatchString(invoice.TaxIdNumber) {
+			return errorx.WithCause(errorx.ErrBadRequest, "纳税人识别号格式不正确")
+		}
+	default:
+		return errorx.WithCause(errorx.ErrBadRequest, "抬头类型不正确")
+	}
+	if invoice.Title == "" {
+		return errorx.WithCause(errorx.ErrBadRequest, "请填写发票抬头")
+	}
+	if invoice.TaxIdNumber != "" && !taxIdNumberPattern.MatchString(invoice.TaxIdNumber) {
+		return errorx.WithCause(errorx.ErrBadRequest, "纳税人识别号格式不正确")
+	}
+	at := strings.Index(invoice.Email, "@")
+	if at <= 0 || at == len(invoice.Email)-1 {
+		return errorx.WithCause(errorx.ErrBadRequest, "请填写正确的接收邮箱")
+	}
+	return nil
+}
+
+// GetReversibleAmount 蓝字发票尚可红冲的金额
+func GetReversibleAmount(blue *trade.Invoice, refundAmount float64) float64 {
+	remain := math.Round((blue.Amount-blue.ReversedAmount)*100) / 100
+	if refundAmount > remain {
+		return remain
+	}
+	return math.Round(refundAmount*100) / 100
+}
+
+func (uc *InvoiceUseCase) buildFindQueryNoPage(db *gorm.DB, opt *FindManyInvoicesOption) *gorm.DB {
+	if opt.CustomerId > 0 {
+		db = db.Where("customer_id = ?", opt.CustomerId)
+	}
+	if opt.OrderId > 0 {
+		db = db.Where("order_id = ?", opt.OrderId)
+	}
+	if opt.Kind != "" {
+		db = db.Where("kind = ?", opt.Kind)
+	}
+	if len(opt.Statuses) > 0 {
+		db = db.Where("status IN ?", opt.Statuses)
+	}
+	if opt.RequestNumber != "" {
+		db = db.Where("request_number = ?", opt.RequestNumber)
+	}
+	if !opt.StartAt.IsZero() {
+		db = db.Where("created_at >= ?", opt.StartAt)
+	}
+	if !opt.EndAt.IsZero() {
+		db = db.Where("created_at < ?", opt.EndAt)
+	}
+	return db
+}
+
+func (uc *InvoiceUseCase) FindManyInvoices(ctx context.Context, opt *FindManyInvoicesOption) (pageList types.Page[*trade.Invoice], err error) {
+	opt.DefaultPageIfNotSet()
+	var invoices []*trade.Invoice
+	db := uc.buildFindQueryNoPage(uc.db.WithContext(ctx).Model(&trade.Invoice{}), opt)
+
+	var count int64
+	if err := db.Count(&count).Error; err != nil {
+		panic(err)
+	}
+
+	if opt.PageIndex != 0 && opt.PageSize != 0 {
+		db.Offset((opt.PageIndex - 1) * opt.PageSize).Limit(opt.PageSize)
+	}
+	if err := db.Preload("PdfResource").Order("id desc").Find(&invoices).Error; err != nil {
+		panic(err)
+	}
+
+	return types.Page[*trade.Invoice]{
+		List:      invoices,
+		PageIndex: opt.PageIndex,
+		PageSize:  opt.PageSize,
+		Total:     count,
+	}, nil
+}
+
+// FindAllInvoices 导出用, 附带订单号
+func (uc *InvoiceUseCase) FindAllInvoices(ctx context.Context, opt *FindManyInvoicesOption) (invoices []*trade.Invoice, err error) {
+	db := uc.buildFindQueryNoPage(uc.db.WithContext(ctx).Model(&trade.Invoice{}), opt)
+	if err = db.Preload("Order").Preload("PdfResource").Order("id asc").Find(&invoices).Error; err != nil {
+		panic(err)
+	}
+	return invoices, nil
+}
+
+func (uc *InvoiceUseCase) GetInvoice(ctx context.Context, id int64) (*trade.Invoice, error) {
+	var invoice = &trade.Invoice{}
+	err := uc.db.WithContext(ctx).
+		Preload("Order").
+		Preload("PdfResource").
+		First(invoice, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "未找到发票")
+		}
+		panic(err)
+	}
+	return invoice, nil
+}
+
+// findActiveBlueInvoice 订单当前有效的蓝字发票(待开票或已开票)
+func (uc *InvoiceUseCase) findActiveBlueInvoice(db *gorm.DB, orderId int64) *trade.Invoice {
+	var invoice = &trade.Invoice{}
+	err := db.Where("order_id = ? AND kind = ? AND status IN ?", orderId, trade.InvoiceKindBlue,
+		[]string{trade.InvoiceStatusPending, trade.InvoiceStatusIssued}).
+		Order("id desc").
+		First(invoice).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		panic(err)
+	}
+	return invoice
+}
+
+// RequestInvoice
+//
+//	@Description: 客户为已付款的订单申请蓝字发票, 每个订单同时只能有一张有效的蓝字发票
+//	@receiver uc
+//	@param ctx
+//	@param order
+//	@param invoice 抬头信息
+//	@return error
+func (uc *InvoiceUseCase) RequestInvoice(ctx context.Context, order *trade.Order, invoice *trade.Invoice) error {
+	ucDD := powerx.NewDataDictionaryUseCase(uc.db)
+	orderStatus := ucDD.GetCachedDDById(ctx, order.Status)
+	if !slicex.Contains(invoiceableOrderStatuses, orderStatus.Key) {
+		return errorx.WithCause(errorx.ErrBadRequest, "订单付款后才能申请开票")
+	}
+	if err := ValidateInvoiceTitle(invoice); err != nil {
+		return err
+	}
+
+	// 组合支付上线前的订单没有汇总已付金额
+	amount := order.PaidAmount
+	if amount <= 0 {
+		amount = order.UnitPrice
+	}
+	if amount <= 0 {
+		return errorx.WithCause(errorx.ErrBadRequest, "订单金额为0, 无需开票")
+	}
+
+	invoice.OrderId = order.Id
+	invoice.CustomerId = order.CustomerId
+	invoice.RequestNumber = trade.GenerateInvoiceRequestNumber()
+	invoice.Kind = trade.InvoiceKindBlue
+	invoice.Amount = amount
+	invoice.Status = trade.InvoiceStatusPending
+
+	return uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 锁定订单, 避免重复申请
+		if err := tx.Model(&trade.Order{}).Where("id = ?", order.Id).Update("updated_at", time.Now()).Error; err != nil {
+			return err
+		}
+		if uc.findActiveBlueInvoice(tx, order.Id) != nil {
+			return errorx.WithCause(errorx.ErrBadRequest, "该订单已申请开票")
+		}
+		return tx.Create(invoice).Error
+	})
+}
+
+// CancelInvoice 客户撤销尚未开具的申请
+func (uc *InvoiceUseCase) CancelInvoice(ctx context.Context, customerId int64, id int64) (*trade.Invoice, error) {
+	invoice, err := uc.GetInvoice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.CustomerId != customerId || invoice.Kind != trade.InvoiceKindBlue {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "无权操作该发票")
+	}
+	if !uc.changeStatusFromTo(ctx, invoice, trade.InvoiceStatusPending, trade.InvoiceStatusCancelled, nil) {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "只能撤销待开票的申请")
+	}
+	return invoice, nil
+}
+
+// RejectInvoice 管理员驳回开票申请, 如抬头信息有误
+func (uc *InvoiceUseCase) RejectInvoice(ctx context.Context, id int64, reviewerId int64, reason string) (*trade.Invoice, error) {
+	invoice, err := uc.GetInvoice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]interface{}{
+		"reviewed_by":   reviewerId,
+		"reject_reason": reason,
+	}
+	if !uc.changeStatusFromTo(ctx, invoice, trade.InvoiceStatusPending, trade.InvoiceStatusRejected, values) {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "只能驳回待开票的申请")
+	}
+	invoice.ReviewedBy = reviewerId
+	invoice.RejectReason = reason
+	return invoice, nil
+}
+
+// IssueInvoice
+//
+//	@Description: 管理员登记开具结果; 蓝字发票回写订单支付单明细的发票信息, 红字发票累计到对应蓝字发票的红冲金额
+//	@receiver uc
+//	@param ctx
+//	@param id
+//	@param issued 发票代码, 号码, PDF, 开票时间, 备注
+//	@param reviewerId
+//	@return *trade.Invoice
+//	@return error
+func (uc *InvoiceUseCase) IssueInvoice(ctx context.Context, id int64, issued *trade.Invoice, reviewerId int64) (*trade.Invoice, error) {
+	if strings.TrimSpace(issued.InvoiceNumber) == "" {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "请填写发票号码")
+	}
+	invoice, err := uc.GetInvoice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.Status != trade.InvoiceStatusPending {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "该发票不属于待开票状态")
+	}
+
+	issuedAt := time.Now()
+	if issued.IssuedAt != nil && !issued.IssuedAt.IsZero() {
+		issuedAt = *issued.IssuedAt
+	}
+	values := map[string]interface{}{
+		"invoice_code":    strings.TrimSpace(issued.InvoiceCode),
+		"invoice_number":  strings.TrimSpace(issued.InvoiceNumber),
+		"pdf_resource_id": issued.PdfResourceId,
+		"issued_at":       issuedAt,
+		"reviewed_by":     reviewerId,
+		"remark":          issued.Remark,
+	}
+
+	err = uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&trade.Invoice{}).
+			Where("id = ? AND status = ?", invoice.Id, trade.InvoiceStatusPending).
+			Updates(values)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errorx.WithCause(errorx.ErrBadRequest, "该发票不属于待开票状态")
+		}
+		if err := tx.Model(&trade.Invoice{}).Where("id = ?", invoice.Id).Update("status", trade.InvoiceStatusIssued).Error; err != nil {
+			return err
+		}
+
+		if invoice.Kind == trade.InvoiceKindRed {
+			return uc.reverseBlueInvoice(tx, invoice)
+		}
+
+		// 回写支付单明细上的发票字段, 便于财务按支付单核对
+		var paymentIds []int64
+		if err := tx.Model(&trade.Payment{}).Where("order_id = ?", invoice.OrderId).Pluck("id", &paymentIds).Error; err != nil {
+			return err
+		}
+		if len(paymentIds) == 0 {
+			return nil
+		}
+		return tx.Model(&trade.PaymentItem{}).Where("payment_id IN ?", paymentIds).Updates(map[string]interface{}{
+			"invoice_number":       values["invoice_number"],
+			"invoice_create_time":  issuedAt,
+			"invoice_total_amount": invoice.Amount,
+			"tax_id_number":        invoice.TaxIdNumber,
+		}).Error
+	})
+	if err != nil {
+		if _, ok := err.(*errorx.Error); ok {
+			return nil, err
+		}
+		panic(err)
+	}
+
+	return uc.GetInvoice(ctx, id)
+}
+
+func (uc *InvoiceUseCase) reverseBlueInvoice(tx *gorm.DB, red *trade.Invoice) error {
+	blue := &trade.Invoice{}
+	if err := tx.First(blue, red.BlueInvoiceId).Error; err != nil {
+		return err
+	}
+	blue.ReversedAmount = math.Round((blue.ReversedAmount+red.Amount)*100) / 100
+	values := map[string]interface{}{
+		"reversed_amount": blue.ReversedAmount,
+	}
+	if blue.ReversedAmount >= blue.Amount {
+		values["status"] = trade.InvoiceStatusReversed
+	}
+	return tx.Model(blue).Updates(values).Error
+}
+
+// HandleOrderRefunded
+//
+//	@Description: 订单退款后处理发票; 已开具的蓝字发票生成待开具的红字发票, 尚未开具的申请按剩余金额调整或撤销
+//	@receiver uc
+//	@param ctx
+//	@param order
+//	@param refundAmount
+//	@return *trade.Invoice 生成的红字发票, 无需红冲时为nil
+func (uc *InvoiceUseCase) HandleOrderRefunded(ctx context.Context, order *trade.Order, refundAmount float64) *trade.Invoice {
+	db := uc.db.WithContext(ctx)
+	blue := uc.findActiveBlueInvoice(db, order.Id)
+	if blue == nil || refundAmount <= 0 {
+		return nil
+	}
+
+	if blue.Status == trade.InvoiceStatusPending {
+		remain := math.Round((blue.Amount-refundAmount)*100) / 100
+		values := map[string]interface{}{"amount": remain}
+		if remain <= 0 {
+			values = map[string]interface{}{"status": trade.InvoiceStatusCancelled, "remark": "订单已退款"}
+		}
+		if err := db.Model(blue).Updates(values).Error; err != nil {
+			panic(err)
+		}
+		return nil
+	}
+
+	// 扣除已申请但尚未开具的红字发票
+	var pendingRed float64
+	err := db.Model(&trade.Invoice{}).
+		Where("blue_invoice_id = ? AND kind = ? AND status = ?", blue.Id, trade.InvoiceKindRed, trade.InvoiceStatusPending).
+		Select("COALESCE(SUM(amount), 0)").Scan(&pendingRed).Error
+	if err != nil {
+		panic(err)
+	}
+	amount := GetReversibleAmount(&trade.Invoice{Amount: blue.Amount, ReversedAmount: blue.ReversedAmount + pendingRed}, refundAmount)
+	if amount <= 0 {
+		return nil
+	}
+
+	red := &trade.Invoice{
+		OrderId:       blue.OrderId,
+		CustomerId:    blue.CustomerId,
+		RequestNumber: trade.GenerateInvoiceRequestNumber(),
+		Kind:          trade.InvoiceKindRed,
+		BlueInvoiceId: blue.Id,
+		TitleType:     blue.TitleType,
+		Title:         blue.Title,
+		TaxIdNumber:   blue.TaxIdNumber,
+		Email:         blue.Email,
+		Amount:        amount,
+		Status:        trade.InvoiceStatusPending,
+		Remark:        "订单退款红冲",
+	}
+	if err = db.Create(red).Error; err != nil {
+		panic(err)
+	}
+	return red
+}
+
+func (uc *InvoiceUseCase) changeStatusFromTo(ctx context.Context, invoice *trade.Invoice, from string, to string, values map[string]interface{}) bool {
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	values["status"] = to
+	result := uc.db.WithContext(ctx).Model(&trade.Invoice{}).
+		Where("id = ? AND status = ?", invoice.Id, from).
+		Updates(values)
+	if result.Error != nil {
+		panic(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return false
+	}
+	invoice.Status = to
+	return true
+}
@@ -0,0 +1,48 @@
+package trade
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"testing"
+)
+
+func TestValidateInvoiceTitle(t *testing.T) {
+
+	cases := []struct {
+		name    string
+		invoice *trade.Invoice
+		valid   bool
+	}{
+		{"个人抬头", &trade.Invoice{TitleType: trade.InvoiceTitleTypePersonal, Title: "张三", Email: "a@b.com"}, true},
+		{"单位抬头", &trade.Invoice{TitleType: trade.InvoiceTitleTypeCompany, Title: "某某公司", TaxIdNumber: " 91310000mA1k3c4x5y ", Email: "finance@corp.cn"}, true},
+		{"单位缺少税号", &trade.Invoice{TitleType: trade.InvoiceTitleTypeCompany, Title: "某某公司", Email: "a@b.com"}, false},
+		{"税号格式错误", &trade.Invoice{TitleType: trade.InvoiceTitleTypeCompany, Title: "某某公司", TaxIdNumber: "123", Email: "a@b.com"}, false},
+		{"缺少抬头", &trade.Invoice{TitleType: trade.InvoiceTitleTypePersonal, Title: " ", Email: "a@b.com"}, false},
+		{"邮箱错误", &trade.Invoice{TitleType: trade.InvoiceTitleTypePersonal, Title: "张三", Email: "a@"}, false},
+		{"抬头类型错误", &trade.Invoice{TitleType: "_other", Title: "张三", Email: "a@b.com"}, false},
+	}
+	for _, c := range cases {
+		err := ValidateInvoiceTitle(c.invoice)
+		if (err == nil) != c.valid {
+			t.Errorf("%s: err = %v, want valid %v", c.name, err, c.valid)
+		}
+	}
+
+	invoice := cases[1].invoice
+	if invoice.TaxIdNumber != "91310000MA1K3C4X5Y" {
+		t.Errorf("tax id not normalized: %q", invoice.TaxIdNumber)
+	}
+}
+
+func TestGetReversibleAmount(t *testing.T) {
+
+	blue := &trade.Invoice{Amount: 100, ReversedAmount: 30.5}
+	if amount := GetReversibleAmount(blue, 20); amount != 20 {
+		t.Errorf("partial refund amount = %v, want 20", amount)
+	}
+	if amount := GetReversibleAmount(blue, 80); amount != 69.5 {
+		t.Errorf("refund over remain = %v, want 69.5", amount)
+	}
+	if amount := GetReversibleAmount(&trade.Invoice{Amount: 10, ReversedAmount: 10}, 5); amount != 0 {
+		t.Errorf("fully reversed amount = %v, want 0", amount)
+	}
+}
@@ -24,7 +24,7 @@ func NewLogisticsUseCase(db *gorm.DB) *LogisticsUseCase {
 type FindManyLogisticsOption struct {
 	CustomerId int64
 
-	OrderBy string
+	QuerySpec *powermodel.QuerySpec
 	types.PageEmbedOption
 }
 
@@ -34,10 +34,8 @@ func (uc *LogisticsUseCase) buildFindQueryNoPage(db *gorm.DB, opt *FindManyLogis
 		db = db.Where("customer_id = ?", opt.CustomerId)
 	}
 
+	db = opt.QuerySpec.Apply(db)
 	orderBy := "id desc"
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
-	}
 	db.Order(orderBy)
 
 	return db
@@ -8,6 +8,7 @@ import (
 	"PowerX/internal/types"
 	"PowerX/internal/types/errorx"
 	"PowerX/internal/uc/powerx"
+	productUC "PowerX/internal/uc/powerx/crm/product"
 	"PowerX/pkg/datetime/carbonx"
 	"PowerX/pkg/slicex"
 	"context"
@@ -29,11 +30,12 @@ func NewOrderUseCase(db *gorm.DB) *OrderUseCase {
 
 type FindManyOrdersOption struct {
 	CustomerId   int64
+	StoreIds     []int64
 	Status       []int
 	Type         []int
 	OrderNumbers []string
 	LikeName     string
-	OrderBy      string
+	QuerySpec    *powermodel.QuerySpec
 	StartAt      time.Time
 	EndAt        time.Time
 	types.PageEmbedOption
@@ -49,6 +51,10 @@ func (uc *OrderUseCase) buildFindQueryNoPage(db *gorm.DB, opt *FindManyOrdersOpt
 		db = db.Where("customer_id = ?", opt.CustomerId)
 	}
 
+	if len(opt.StoreIds) > 0 {
+		db = db.Where("store_id IN ?", opt.StoreIds)
+	}
+
 	if len(opt.OrderNumbers) > 0 {
 		db = db.Where("order_number IN ?", opt.OrderNumbers)
 	}
@@ -67,10 +73,8 @@ func (uc *OrderUseCase) buildFindQueryNoPage(db *gorm.DB, opt *FindManyOrdersOpt
 			Where("created_at <= ? ", opt.EndAt.Format(carbonx.GoDatetimeFormat))
 	}
 
+	db = opt.QuerySpec.Apply(db)
 	orderBy := "id desc"
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
-	}
 	db.Order(orderBy)
 
 	return db
@@ -81,6 +85,7 @@ func (uc *OrderUseCase) PreloadItems(db *gorm.DB) *gorm.DB {
 		Preload("Items.ProductBookEntry.SKU").
 		Preload("Items.ProductBookEntry.Product.PivotCoverImages").
 		Preload("Items.CoverImage").
+		Preload("Items.Components").
 		Preload("Payments.Items").
 		Preload("DeliveryAddress").
 		Preload("Logistics")
@@ -147,6 +152,7 @@ func (uc *OrderUseCase) CreateOrder(ctx context.Context, order *trade.Order) err
 
 func (uc *OrderUseCase) CreateOrderByPriceBookEntries(ctx context.Context,
 	customer *customerdomain2.Customer,
+	storeId int64,
 	entries []*product.PriceBookEntry,
 	quantities []int,
 	shippingAddress *trade.ShippingAddress,
@@ -155,12 +161,26 @@ func (uc *OrderUseCase) CreateOrderByPriceBookEntries(ctx context.Context,
 	order := &trade.Order{}
 	db := uc.db.WithContext(ctx)
 
-	// 创建订单，类型为 普通订单
-	orderTypeId := uc.GetOrderTypeId(ctx, trade.OrderTypeNormal)
+	// 只能购买已审核通过、已激活且处于售卖时间内的产品
+	productIds := []int64{}
+	for _, entry := range entries {
+		productIds = append(productIds, entry.ProductId)
+	}
+	if err := productUC.NewProductApprovalUseCase(uc.db).CheckProductsOnSale(ctx, productIds); err != nil {
+		return nil, err
+	}
+
+	// 创建订单，类型为 普通订单, 全部为周期性产品时为 订阅订单
+	orderType, err := uc.GetOrderTypeByEntries(ctx, entries)
+	if err != nil {
+		return nil, err
+	}
+	orderTypeId := uc.GetOrderTypeId(ctx, orderType)
 	// 创建订单，状态为 待处理
 	orderStatusId := uc.GetOrderStatusId(ctx, trade.OrderStatusToBePaid)
+	uc.PriceBundleEntries(ctx, entries)
 
-	err := db.Transaction(func(tx *gorm.DB) error {
+	err = db.Transaction(func(tx *gorm.DB) error {
 		var err error
 
 		// 创建订单
@@ -173,7 +193,24 @@ func (uc *OrderUseCase) CreateOrderByPriceBookEntries(ctx context.Context,
 		)
 		order.Items = orderItems
 
+		// 门店订单校验商品在门店可售并扣减门店库存
+		if storeId > 0 {
+			lines := []*productUC.StoreOrderLine{}
+			for i, entry := range entries {
+				lines = append(lines, &productUC.StoreOrderLine{
+					ProductId:   entry.ProductId,
+					SkuId:       entry.SkuId,
+					ProductName: entry.Product.Name,
+					Quantity:    quantities[i],
+				})
+			}
+			if err = productUC.NewStoreProductUseCase(tx).ReserveStoreInventories(ctx, storeId, lines); err != nil {
+				return err
+			}
+		}
+
 		order.CustomerId = customer.Id
+		order.StoreId = storeId
 		order.Type = orderTypeId
 		order.Status = orderStatusId
 		order.OrderNumber = trade.GenerateOrderNumber()
@@ -189,6 +226,15 @@ func (uc *OrderUseCase) CreateOrderByPriceBookEntries(ctx context.Context,
 			return err
 		}
 
+		// 组合商品拆分为组件明细并扣减组件库存
+		products := []*product.Product{}
+		for _, entry := range entries {
+			products = append(products, entry.Product)
+		}
+		if err = uc.explodeBundleOrderItems(ctx, tx, order, products, entries[0].PriceBookId); err != nil {
+			return err
+		}
+
 		// 创建发货地址
 		deliveryAddress := shippingAddress.MakeDeliveryAddress()
 		deliveryAddress.OrderId = order.Id
@@ -202,13 +248,64 @@ func (uc *OrderUseCase) CreateOrderByPriceBookEntries(ctx context.Context,
 
 	return order, err
 }
+
+// GetOrderTypeByEntries
+//
+//	@Description: 周期性产品按订阅订单下单, 不能与一次性产品或不同计费周期的产品混合下单
+//	@receiver uc
+//	@param ctx
+//	@param entries
+//	@return string 订单类型Key
+//	@return error
+func (uc *OrderUseCase) GetOrderTypeByEntries(ctx context.Context, entries []*product.PriceBookEntry) (string, error) {
+	ucDD := powerx.NewDataDictionaryUseCase(uc.db)
+
+	var periodProduct *product.Product
+	periodCount := 0
+	for _, entry := range entries {
+		if entry.Product == nil || entry.Product.Plan == 0 ||
+			ucDD.GetCachedDDById(ctx, entry.Product.Plan).Key != product.ProductPlanPeriod {
+			continue
+		}
+		periodCount++
+		if periodProduct == nil {
+			periodProduct = entry.Product
+			continue
+		}
+		if !IsSameBillingInterval(periodProduct, entry.Product) {
+			return "", errorx.WithCause(errorx.ErrBadRequest, "不同计费周期的订阅产品需分开下单")
+		}
+	}
+
+	if periodCount == 0 {
+		return trade.OrderTypeNormal, nil
+	}
+	if periodCount < len(entries) {
+		return "", errorx.WithCause(errorx.ErrBadRequest, "订阅产品不能与一次性产品一起下单")
+	}
+	if err := ValidateBillingInterval(periodProduct.BillingIntervalUnit, periodProduct.BillingIntervalCount); err != nil {
+		return "", err
+	}
+	return trade.OrderTypeSubscription, nil
+}
+
 func (uc *OrderUseCase) CreateOrderByCartItems(ctx context.Context,
 	customer *customerdomain2.Customer,
+	storeId int64,
 	cartItems []*trade.CartItem,
 	shippingAddress *trade.ShippingAddress,
 	comment string,
 ) (*trade.Order, *trade.Cart, error) {
 
+	// 只能购买已审核通过、已激活且处于售卖时间内的产品
+	productIds := []int64{}
+	for _, cartItem := range cartItems {
+		productIds = append(productIds, cartItem.ProductId)
+	}
+	if err := productUC.NewProductApprovalUseCase(uc.db).CheckProductsOnSale(ctx, productIds); err != nil {
+		return nil, nil, err
+	}
+
 	order := &trade.Order{}
 	// 创建购物车合集
 	cart := &trade.Cart{
@@ -243,7 +340,24 @@ func (uc *OrderUseCase) CreateOrderByCartItems(ctx context.Context,
 		)
 		order.Items = orderItems
 
+		// 门店订单校验商品在门店可售并扣减门店库存
+		if storeId > 0 {
+			lines := []*productUC.StoreOrderLine{}
+			for _, cartItem := range cartItems {
+				lines = append(lines, &productUC.StoreOrderLine{
+					ProductId:   cartItem.ProductId,
+					SkuId:       cartItem.SkuId,
+					ProductName: cartItem.ProductName,
+					Quantity:    cartItem.Quantity,
+				})
+			}
+			if err = productUC.NewStoreProductUseCase(tx).ReserveStoreInventories(ctx, storeId, lines); err != nil {
+				return err
+			}
+		}
+
 		order.CustomerId = customer.Id
+		order.StoreId = storeId
 		order.CartId = cart.Id
 		order.Type = orderTypeId
 		order.Status = orderStatusId
@@ -260,6 +374,19 @@ func (uc *OrderUseCase) CreateOrderByCartItems(ctx context.Context,
 			return err
 		}
 
+		// 组合商品拆分为组件明细并扣减组件库存, 购物车订单按门店或标准价格手册分摊
+		products := []*product.Product{}
+		for _, cartItem := range cartItems {
+			products = append(products, cartItem.Product)
+		}
+		priceBookId := int64(0)
+		if priceBook, err := productUC.NewPriceBookUseCase(tx).GetStorePriceBook(ctx, storeId); err == nil {
+			priceBookId = priceBook.Id
+		}
+		if err = uc.explodeBundleOrderItems(ctx, tx, order, products, priceBookId); err != nil {
+			return err
+		}
+
 		// 创建发货地址
 		deliveryAddress := shippingAddress.MakeDeliveryAddress()
 		deliveryAddress.OrderId = order.Id
@@ -315,9 +442,14 @@ func (uc *OrderUseCase) MakeOrderItemFromEntry(
 		ListPrice:        entry.ListPrice,
 		Discount:         entry.UnitPrice / entry.ListPrice,
 		ProductName:      entry.Product.Name,
-		SkuNo:            entry.SKU.SkuNo,
 		CoverImageId:     entry.Product.PivotCoverImages[0].MediaResourceId,
 	}
+	// 组合商品没有SKU, 按产品价格条目下单
+	if entry.SKU != nil {
+		orderItem.SkuNo = entry.SKU.SkuNo
+	} else {
+		orderItem.SkuNo = entry.Product.SPU
+	}
 	subUnitTotal = orderItem.UnitPrice * float64(orderItem.Quantity)
 	subListTotal = orderItem.ListPrice * float64(orderItem.Quantity)
 
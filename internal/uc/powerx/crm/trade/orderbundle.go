@@ -0,0 +1,369 @@
+package trade
+
+import (
+	"PowerX/internal/model/crm/product"
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/types/errorx"
+	productUC "PowerX/internal/uc/powerx/crm/product"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ComponentRefundAllocation 退款金额分摊到订单项或组合商品组件明细的结果, 普通订单项的Component为空
+type ComponentRefundAllocation struct {
+	OrderItem *trade.OrderItem
+	Component *trade.OrderItemComponent
+	Amount    float64
+}
+
+// AllocateAmount
+//
+//	@Description: 按权重分摊金额, 精确到分, 按最大余数法分配尾差, 分摊结果合计等于总金额; 权重都不大于0时平均分摊
+//	@param total
+//	@param weights
+//	@return []float64
+func AllocateAmount(total float64, weights []float64) []float64 {
+	amounts := make([]float64, len(weights))
+	if len(weights) == 0 {
+		return amounts
+	}
+
+	sumWeight := 0.0
+	for _, weight := range weights {
+		if weight > 0 {
+			sumWeight += weight
+		}
+	}
+	shares := make([]float64, len(weights))
+	for i, weight := range weights {
+		switch {
+		case sumWeight <= 0:
+			shares[i] = 1 / float64(len(weights))
+		case weight > 0:
+			shares[i] = weight / sumWeight
+		}
+	}
+
+	totalCents := int64(math.Round(total * 100))
+	cents := make([]int64, len(weights))
+	remainders := make([]float64, len(weights))
+	allocated := int64(0)
+	for i, share := range shares {
+		exact := float64(totalCents) * share
+		cents[i] = int64(math.Floor(exact))
+		remainders[i] = exact - float64(cents[i])
+		allocated += cents[i]
+	}
+
+	indexes := make([]int, len(weights))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool { return remainders[indexes[i]] > remainders[indexes[j]] })
+	for i := 0; allocated < totalCents; i = (i + 1) % len(indexes) {
+		cents[indexes[i]]++
+		allocated++
+	}
+
+	for i, cent := range cents {
+		amounts[i] = float64(cent) / 100
+	}
+	return amounts
+}
+
+// PriceBundleEntries 合计减优惠定价的组合商品按下单时的组件价格计算成交价
+func (uc *OrderUseCase) PriceBundleEntries(ctx context.Context, entries []*product.PriceBookEntry) {
+	ucBundle := productUC.NewProductBundleUseCase(uc.db)
+	for _, entry := range entries {
+		if entry.Product == nil || !entry.Product.IsBundle || entry.BundlePricing != product.BundlePricingSumMinusDiscount {
+			continue
+		}
+		items := ucBundle.GetBundleItems(ctx, entry.ProductId)
+		unitTotal, listTotal := productUC.SumComponentPrices(ucBundle.GetComponentPrices(ctx, entry.PriceBookId, items))
+		entry.UnitPrice = product.ComputeBundleUnitPrice(entry, unitTotal)
+		entry.ListPrice = listTotal
+	}
+}
+
+// explodeBundleOrderItems
+//
+//	@Description: 组合商品订单项按组件拆分为明细, 成交金额按组件标价分摊, 并扣减组件SKU库存, 库存不足时返回错误;
+//	订单取消、全额退款或超时未付清时由ReleaseOrderInventories退回组件库存
+//	@receiver uc
+//	@param ctx
+//	@param tx
+//	@param order 已创建的订单
+//	@param products 与订单项顺序一致的产品
+//	@param priceBookId 分摊权重使用的价格手册
+//	@return error
+func (uc *OrderUseCase) explodeBundleOrderItems(ctx context.Context, tx *gorm.DB, order *trade.Order, products []*product.Product, priceBookId int64) error {
+	bundleProductIds := []int64{}
+	for _, p := range products {
+		if p != nil && p.IsBundle {
+			bundleProductIds = append(bundleProductIds, p.Id)
+		}
+	}
+	if len(bundleProductIds) == 0 {
+		return nil
+	}
+
+	ucBundle := productUC.NewProductBundleUseCase(tx)
+	mapBundleItems := ucBundle.FindBundleItems(ctx, bundleProductIds)
+
+	components := []*trade.OrderItemComponent{}
+	for i, orderItem := range order.Items {
+		if products[i] == nil || !products[i].IsBundle {
+			continue
+		}
+		items := mapBundleItems[products[i].Id]
+		if len(items) == 0 {
+			return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("组合商品%s未配置组件", products[i].Name))
+		}
+
+		prices := ucBundle.GetComponentPrices(ctx, priceBookId, items)
+		weights := []float64{}
+		for _, price := range prices {
+			weight := price.ListPrice
+			if weight <= 0 {
+				weight = price.UnitPrice
+			}
+			weights = append(weights, weight*float64(price.Item.Quantity))
+		}
+		amounts := AllocateAmount(orderItem.UnitPrice*float64(orderItem.Quantity), weights)
+
+		for j, item := range items {
+			quantity := item.Quantity * orderItem.Quantity
+			result := tx.Model(&product.SKU{}).
+				Where("id = ? AND inventory >= ?", item.SkuId, quantity).
+				UpdateColumn("inventory", gorm.Expr("inventory - ?", quantity))
+			if result.Error != nil {
+				return result.Error
+			}
+			component := &trade.OrderItemComponent{
+				OrderId:         order.Id,
+				OrderItemId:     orderItem.Id,
+				ProductId:       item.ProductId,
+				SkuId:           item.SkuId,
+				Quantity:        quantity,
+				AllocatedAmount: amounts[j],
+			}
+			if item.Product != nil {
+				component.ProductName = item.Product.Name
+			}
+			if item.SKU != nil {
+				component.SkuNo = item.SKU.SkuNo
+			}
+			if result.RowsAffected == 0 {
+				return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("组合商品%s的组件%s库存不足", products[i].Name, component.SkuNo))
+			}
+			components = append(components, component)
+		}
+		orderItem.Components = components[len(components)-len(items):]
+	}
+
+	return tx.Create(&components).Error
+}
+
+// releaseBundleComponentInventories 退回组合商品下单时扣减的组件SKU库存, 需在订单事务内调用
+func releaseBundleComponentInventories(ctx context.Context, tx *gorm.DB, orderId int64) error {
+	components := []*trade.OrderItemComponent{}
+	if err := tx.WithContext(ctx).Where("order_id = ?", orderId).Find(&components).Error; err != nil {
+		return err
+	}
+	for _, component := range components {
+		if component.SkuId == 0 || component.Quantity <= 0 {
+			continue
+		}
+		err := tx.WithContext(ctx).Model(&product.SKU{}).
+			Where("id = ?", component.SkuId).
+			UpdateColumn("inventory", gorm.Expr("inventory + ?", component.Quantity)).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AllocateRefundToComponents
+//
+//	@Description: 预览订单退款金额的分摊, 扣除各订单项及组件明细已退款的金额后按剩余金额分摊
+//	@receiver uc
+//	@param ctx
+//	@param orderId
+//	@param amount 退款金额, 超过订单剩余可分摊金额时按剩余金额分摊
+//	@return []*ComponentRefundAllocation 组件明细的退款分摊, 订单没有组合商品时为空
+func (uc *OrderUseCase) AllocateRefundToComponents(ctx context.Context, orderId int64, amount float64) []*ComponentRefundAllocation {
+	order, err := findOrderWithComponents(uc.db.WithContext(ctx), orderId)
+	if err != nil {
+		return []*ComponentRefundAllocation{}
+	}
+	refunded, err := findOrderRefundItems(uc.db.WithContext(ctx), orderId)
+	if err != nil {
+		panic(err)
+	}
+	return FilterComponentAllocations(AllocateOrderRefund(order, amount, refunded))
+}
+
+// FindRefundAllocations 退款完成时记录的组件明细退款分摊
+func (uc *OrderUseCase) FindRefundAllocations(ctx context.Context, refundNumber string) []*ComponentRefundAllocation {
+	items := []*trade.RefundOrderItem{}
+	err := uc.db.WithContext(ctx).Preload("OrderItemComponent").
+		Where("refund_number = ? AND order_item_component_id > ?", refundNumber, 0).
+		Order("id").
+		Find(&items).Error
+	if err != nil {
+		panic(err)
+	}
+	allocations := []*ComponentRefundAllocation{}
+	for _, item := range items {
+		if item.OrderItemComponent == nil {
+			continue
+		}
+		allocations = append(allocations, &ComponentRefundAllocation{
+			Component: item.OrderItemComponent,
+			Amount:    item.RefundAmount,
+		})
+	}
+	return allocations
+}
+
+// recordRefundAllocations
+//
+//	@Description: 退款完成时将退款金额分摊到订单项及组件明细并记录为退款订单项, 用于按组件冲减收入; 需在退款事务内调用
+//	@param ctx
+//	@param tx
+//	@param refundOrder 已完成的退款单
+//	@return error
+func recordRefundAllocations(ctx context.Context, tx *gorm.DB, refundOrder *trade.RefundOrder) error {
+	tx = tx.WithContext(ctx)
+	// 锁定订单, 同一订单多笔支付单的退款串行分摊
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&trade.Order{}, refundOrder.OrderId).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	order, err := findOrderWithComponents(tx, refundOrder.OrderId)
+	if err != nil {
+		return err
+	}
+	refunded, err := findOrderRefundItems(tx, refundOrder.OrderId)
+	if err != nil {
+		return err
+	}
+
+	refundItems := []*trade.RefundOrderItem{}
+	for _, allocation := range AllocateOrderRefund(order, refundOrder.RefundAmount, refunded) {
+		if allocation.Amount <= 0 {
+			continue
+		}
+		refundItem := &trade.RefundOrderItem{
+			RefundOrderId: refundOrder.Id,
+			RefundNumber:  refundOrder.RefundNumber,
+			OrderId:       order.Id,
+			OrderItemId:   allocation.OrderItem.Id,
+			RefundStatus:  trade.RefundStatusCompleted,
+			RefundAmount:  allocation.Amount,
+			RefundDate:    time.Now(),
+		}
+		if allocation.Component != nil {
+			refundItem.OrderItemComponentId = allocation.Component.Id
+		}
+		refundItems = append(refundItems, refundItem)
+	}
+	if len(refundItems) == 0 {
+		return nil
+	}
+	return tx.Omit(clause.Associations).Create(&refundItems).Error
+}
+
+func findOrderWithComponents(db *gorm.DB, orderId int64) (*trade.Order, error) {
+	order := &trade.Order{}
+	err := db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Preload("Items.Components", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).First(order, orderId).Error
+	return order, err
+}
+
+func findOrderRefundItems(db *gorm.DB, orderId int64) ([]*trade.RefundOrderItem, error) {
+	items := []*trade.RefundOrderItem{}
+	err := db.Where("order_id = ? AND refund_status = ?", orderId, trade.RefundStatusCompleted).Find(&items).Error
+	return items, err
+}
+
+// AllocateOrderRefund
+//
+//	@Description: 退款金额按订单项及组件明细的剩余金额分摊, 剩余金额为成交金额减去已退款金额; 需要预加载订单项及组件明细
+//	@param order
+//	@param amount 退款金额, 超过剩余金额合计时按剩余金额分摊
+//	@param refunded 订单已完成退款的退款订单项
+//	@return []*ComponentRefundAllocation 普通订单项的分摊Component为空
+func AllocateOrderRefund(order *trade.Order, amount float64, refunded []*trade.RefundOrderItem) []*ComponentRefundAllocation {
+	refundedItems := map[int64]int64{}
+	refundedComponents := map[int64]int64{}
+	for _, item := range refunded {
+		cents := int64(math.Round(item.RefundAmount * 100))
+		if item.OrderItemComponentId > 0 {
+			refundedComponents[item.OrderItemComponentId] += cents
+		} else {
+			refundedItems[item.OrderItemId] += cents
+		}
+	}
+	remaining := func(total float64, refundedCents int64) float64 {
+		cents := int64(math.Round(total*100)) - refundedCents
+		if cents < 0 {
+			cents = 0
+		}
+		return float64(cents) / 100
+	}
+
+	allocations := []*ComponentRefundAllocation{}
+	weights := []float64{}
+	remainingCents := int64(0)
+	for _, item := range order.Items {
+		if len(item.Components) == 0 {
+			weight := remaining(item.UnitPrice*float64(item.Quantity), refundedItems[item.Id])
+			weights = append(weights, weight)
+			remainingCents += int64(math.Round(weight * 100))
+			allocations = append(allocations, &ComponentRefundAllocation{OrderItem: item})
+			continue
+		}
+		for _, component := range item.Components {
+			weight := remaining(component.AllocatedAmount, refundedComponents[component.Id])
+			weights = append(weights, weight)
+			remainingCents += int64(math.Round(weight * 100))
+			allocations = append(allocations, &ComponentRefundAllocation{OrderItem: item, Component: component})
+		}
+	}
+	if remainingCents <= 0 {
+		return []*ComponentRefundAllocation{}
+	}
+	if int64(math.Round(amount*100)) > remainingCents {
+		amount = float64(remainingCents) / 100
+	}
+
+	for i, allocated := range AllocateAmount(amount, weights) {
+		allocations[i].Amount = allocated
+	}
+	return allocations
+}
+
+// FilterComponentAllocations 只保留组合商品组件明细的分摊
+func FilterComponentAllocations(allocations []*ComponentRefundAllocation) []*ComponentRefundAllocation {
+	result := []*ComponentRefundAllocation{}
+	for _, allocation := range allocations {
+		if allocation.Component != nil {
+			result = append(result, allocation)
+		}
+	}
+	return result
+}
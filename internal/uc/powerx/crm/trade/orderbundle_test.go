@@ -0,0 +1,79 @@
+package trade
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/model/powermodel"
+	"reflect"
+	"testing"
+)
+
+func TestAllocateAmount(t *testing.T) {
+
+	// 礼盒199元, 组件标价 100 + 50*2 + 30
+	amounts := AllocateAmount(199, []float64{100, 100, 30})
+	if !reflect.DeepEqual(amounts, []float64{86.52, 86.52, 25.96}) {
+		t.Errorf("amounts = %v", amounts)
+	}
+
+	// 尾差按最大余数分配, 合计等于总金额
+	amounts = AllocateAmount(100, []float64{1, 1, 1})
+	if !reflect.DeepEqual(amounts, []float64{33.34, 33.33, 33.33}) {
+		t.Errorf("amounts = %v", amounts)
+	}
+
+	// 组件都没有价格时平均分摊
+	amounts = AllocateAmount(10, []float64{0, 0})
+	if !reflect.DeepEqual(amounts, []float64{5, 5}) {
+		t.Errorf("amounts = %v", amounts)
+	}
+
+	if amounts = AllocateAmount(10, nil); len(amounts) != 0 {
+		t.Errorf("amounts = %v", amounts)
+	}
+}
+
+func TestAllocateOrderRefund(t *testing.T) {
+
+	// 普通商品100元, 礼盒200元拆分为 120 + 80
+	order := &trade.Order{
+		UnitPrice: 300,
+		Items: []*trade.OrderItem{
+			{PowerModel: &powermodel.PowerModel{Id: 1}, UnitPrice: 50, Quantity: 2},
+			{PowerModel: &powermodel.PowerModel{Id: 2}, UnitPrice: 200, Quantity: 1, Components: []*trade.OrderItemComponent{
+				{PowerModel: &powermodel.PowerModel{Id: 11}, SkuNo: "TEA", AllocatedAmount: 120},
+				{PowerModel: &powermodel.PowerModel{Id: 12}, SkuNo: "CUP", AllocatedAmount: 80},
+			}},
+		},
+	}
+
+	allocations := FilterComponentAllocations(AllocateOrderRefund(order, 150, nil))
+	if len(allocations) != 2 || allocations[0].Component.SkuNo != "TEA" || allocations[0].Amount != 60 || allocations[1].Amount != 40 {
+		t.Errorf("allocations = %+v %+v", allocations[0], allocations[1])
+	}
+
+	// 退款金额超过订单金额时按订单金额分摊
+	allocations = FilterComponentAllocations(AllocateOrderRefund(order, 500, nil))
+	if allocations[0].Amount != 120 || allocations[1].Amount != 80 {
+		t.Errorf("allocations = %+v %+v", allocations[0], allocations[1])
+	}
+
+	// 已退款的金额不再分摊, 按剩余金额分摊
+	refunded := []*trade.RefundOrderItem{
+		{OrderItemId: 1, RefundAmount: 100},
+		{OrderItemId: 2, OrderItemComponentId: 11, RefundAmount: 100},
+	}
+	allocations = AllocateOrderRefund(order, 500, refunded)
+	if len(allocations) != 3 || allocations[0].Amount != 0 || allocations[1].Amount != 20 || allocations[2].Amount != 80 {
+		t.Errorf("allocations = %+v %+v %+v", allocations[0], allocations[1], allocations[2])
+	}
+	refunded = append(refunded, &trade.RefundOrderItem{OrderItemId: 2, OrderItemComponentId: 11, RefundAmount: 20},
+		&trade.RefundOrderItem{OrderItemId: 2, OrderItemComponentId: 12, RefundAmount: 80})
+	if allocations = AllocateOrderRefund(order, 10, refunded); len(allocations) != 0 {
+		t.Errorf("fully refunded order allocations = %v", allocations)
+	}
+
+	order.Items = order.Items[:1]
+	if allocations = FilterComponentAllocations(AllocateOrderRefund(order, 100, nil)); len(allocations) != 0 {
+		t.Errorf("order without bundle allocations = %v", allocations)
+	}
+}
@@ -0,0 +1,109 @@
+package trade
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/model/powermodel"
+	"PowerX/internal/types/errorx"
+	"context"
+	"github.com/robfig/cron/v3"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+	"time"
+)
+
+// 待付款订单的有效期, 超时未付清的订单自动取消并退回下单扣减的库存, 需长于支付单的有效期
+const UnpaidOrderExpireDuration = 2 * time.Hour
+
+type OrderExpiryUseCase struct {
+	db      *gorm.DB
+	payment *PaymentUseCase
+	order   *OrderUseCase
+}
+
+func NewOrderExpiryUseCase(db *gorm.DB, payment *PaymentUseCase, order *OrderUseCase) *OrderExpiryUseCase {
+	return &OrderExpiryUseCase{
+		db:      db,
+		payment: payment,
+		order:   order,
+	}
+}
+
+// CancelExpiredOrders
+//
+//	@Description: 取消超过有效期仍未付清的订单, 关闭未支付的支付单, 退回已付的部分金额及下单扣减的门店库存和组件库存;
+//	订阅续费订单由续费催缴关闭, 不在此处理
+//	@receiver uc
+//	@param ctx
+//	@param now
+//	@return int 取消的订单数量
+func (uc *OrderExpiryUseCase) CancelExpiredOrders(ctx context.Context, now time.Time) int {
+	var orders []*trade.Order
+	err := uc.db.WithContext(ctx).
+		Where("status = ? AND subscription_id = ? AND created_at < ?",
+			uc.order.GetOrderStatusId(ctx, trade.OrderStatusToBePaid), 0,
+			now.Add(-UnpaidOrderExpireDuration)).
+		Order("id").
+		Find(&orders).Error
+	if err != nil {
+		panic(err)
+	}
+
+	cancelled := 0
+	for _, order := range orders {
+		if err = uc.cancelExpiredOrder(ctx, order); err != nil {
+			logx.WithContext(ctx).Errorf("取消超时订单%s失败:%s", order.OrderNumber, err.Error())
+			continue
+		}
+		cancelled++
+	}
+	return cancelled
+}
+
+func (uc *OrderExpiryUseCase) cancelExpiredOrder(ctx context.Context, order *trade.Order) error {
+	uc.payment.ReleaseOrderPayments(ctx, order)
+	// 渠道侧已支付的支付单无法关闭, 以支付通知为准, 下次再处理
+	if summary := uc.payment.GetOrderPaymentSummary(ctx, order); summary.PendingAmount > 0 {
+		return errorx.WithCause(errorx.ErrBadRequest, "订单有未关闭的支付单")
+	}
+
+	fromStatusId := uc.order.GetOrderStatusId(ctx, trade.OrderStatusToBePaid)
+	toStatusId := uc.order.GetOrderStatusId(ctx, trade.OrderStatusCancelled)
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 按原状态更新, 期间已付清的订单不会被取消
+		result := tx.Model(&trade.Order{}).
+			Where("id = ? AND status = ?", order.Id, fromStatusId).
+			Update("status", toStatusId)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errorx.WithCause(errorx.ErrBadRequest, "订单状态已变更")
+		}
+		order.Status = toStatusId
+
+		return tx.Create(&trade.OrderStatusTransition{
+			PowerModel: &powermodel.PowerModel{},
+			OrderId:    order.Id,
+			FromStatus: fromStatusId,
+			ToStatus:   toStatusId,
+			Remark:     "超时未支付",
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	return uc.order.ReleaseOrderInventories(ctx, order)
+}
+
+// Schedule 每10分钟取消超时未付清的订单
+func (uc *OrderExpiryUseCase) Schedule(c *cron.Cron) {
+	_, _ = c.AddFunc(`*/10 * * * *`, func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logx.Errorf("cancel expired orders panic: %v", r)
+			}
+		}()
+		uc.CancelExpiredOrders(context.Background(), time.Now())
+	})
+}
@@ -0,0 +1,114 @@
+package trade
+
+import (
+	"PowerX/internal/model"
+	"PowerX/internal/model/crm/product"
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/model/powermodel"
+	productUC "PowerX/internal/uc/powerx/crm/product"
+	"context"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func newOrderExpiryTestUseCase(t *testing.T) (*OrderExpiryUseCase, *gorm.DB) {
+	db := newInventoryTestDB(t)
+	err := db.Migrator().CreateTable(&model.DataDictionaryItem{}, &trade.Payment{}, &trade.OrderStatusTransition{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Create([]*model.DataDictionaryItem{
+		{Type: trade.TypeOrderStatus, Key: trade.OrderStatusToBePaid},
+		{Type: trade.TypeOrderStatus, Key: trade.OrderStatusCancelled},
+		{Type: trade.TypeOrderStatus, Key: trade.OrderStatusToBeShipped},
+	})
+	order := NewOrderUseCase(db)
+	return NewOrderExpiryUseCase(db, &PaymentUseCase{db: db}, order), db
+}
+
+func TestCancelExpiredOrders(t *testing.T) {
+	ctx := context.Background()
+	uc, db := newOrderExpiryTestUseCase(t)
+	now := time.Now()
+
+	storeId := int64(7)
+	sku := &product.SKU{ProductId: 1, SkuNo: "SKU-1", Inventory: 100}
+	sku.UniqueID.String, sku.UniqueID.Valid = "sku-1", true
+	db.Create(sku)
+	db.Create(&product.StoreProduct{StoreId: storeId, ProductId: 1, IsAvailable: true})
+	inventory := 10
+	storeSKU := &product.StoreSKU{StoreId: storeId, ProductId: 1, SkuId: sku.Id, IsAvailable: true, Inventory: &inventory}
+	db.Create(storeSKU)
+
+	toBePaid := uc.order.GetOrderStatusId(ctx, trade.OrderStatusToBePaid)
+	createOrder := func(orderNumber string, createdAt time.Time, quantity int) *trade.Order {
+		err := productUC.NewStoreProductUseCase(db).ReserveStoreInventories(ctx, storeId, []*productUC.StoreOrderLine{
+			{ProductId: 1, SkuId: sku.Id, ProductName: "咖啡", Quantity: quantity},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		order := &trade.Order{PowerModel: &powermodel.PowerModel{CreatedAt: createdAt}, CartId: 1, StoreId: storeId, OrderNumber: orderNumber, Status: toBePaid}
+		db.Create(order)
+		db.Create(&trade.OrderItem{PowerModel: &powermodel.PowerModel{}, OrderId: order.Id, PriceBookEntryId: sku.Id, ProductName: "咖啡", Quantity: quantity})
+		return order
+	}
+	expired := createOrder("O-EXPIRED", now.Add(-UnpaidOrderExpireDuration-time.Minute), 3)
+	fresh := createOrder("O-FRESH", now.Add(-time.Minute), 2)
+	// 已付清的订单不会取消
+	paid := createOrder("O-PAID", now.Add(-UnpaidOrderExpireDuration-time.Minute), 1)
+	db.Model(paid).Update("status", uc.order.GetOrderStatusId(ctx, trade.OrderStatusToBeShipped))
+	assertStoreInventory(t, db, storeSKU.Id, 4)
+
+	for i := 0; i < 2; i++ {
+		cancelled := uc.CancelExpiredOrders(ctx, now)
+		if expectedCancelled := 1 - i; cancelled != expectedCancelled {
+			t.Errorf("cancelled = %d, expected %d", cancelled, expectedCancelled)
+		}
+		assertStoreInventory(t, db, storeSKU.Id, 7)
+	}
+
+	cancelledId := uc.order.GetOrderStatusId(ctx, trade.OrderStatusCancelled)
+	for _, c := range []struct {
+		order    *trade.Order
+		expected int
+	}{
+		{expired, cancelledId},
+		{fresh, toBePaid},
+	} {
+		order := &trade.Order{}
+		db.First(order, c.order.Id)
+		if order.Status != c.expected {
+			t.Errorf("order %s status = %d, expected %d", order.OrderNumber, order.Status, c.expected)
+		}
+	}
+}
+
+func TestCancelExpiredOrdersWithBundleComponents(t *testing.T) {
+	ctx := context.Background()
+	uc, db := newOrderExpiryTestUseCase(t)
+	now := time.Now()
+
+	// 组合商品下单时已扣减组件库存
+	component := &product.SKU{ProductId: 2, SkuNo: "TEA", Inventory: 8}
+	component.UniqueID.String, component.UniqueID.Valid = "sku-tea", true
+	db.Create(component)
+	order := &trade.Order{
+		PowerModel:  &powermodel.PowerModel{CreatedAt: now.Add(-UnpaidOrderExpireDuration - time.Minute)},
+		OrderNumber: "O-BUNDLE",
+		Status:      uc.order.GetOrderStatusId(ctx, trade.OrderStatusToBePaid),
+	}
+	db.Create(order)
+	db.Create(&trade.OrderItemComponent{PowerModel: &powermodel.PowerModel{}, OrderId: order.Id, SkuId: component.Id, Quantity: 2})
+
+	for i := 0; i < 2; i++ {
+		uc.CancelExpiredOrders(ctx, now)
+	}
+	sku := &product.SKU{}
+	db.First(sku, component.Id)
+	if sku.Inventory != 10 {
+		t.Errorf("component inventory = %d, expected 10", sku.Inventory)
+	}
+}
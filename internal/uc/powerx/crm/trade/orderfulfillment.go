@@ -0,0 +1,66 @@
+package trade
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/types/errorx"
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+// ShipOrder
+//
+//	@Description: 待发货订单发货, 记录物流承运商及单号, 订单转为送货中
+//	@receiver uc
+//	@param ctx
+//	@param order
+//	@param carrier
+//	@param trackingCode
+//	@return *trade.Order
+//	@return error
+func (uc *OrderUseCase) ShipOrder(ctx context.Context, order *trade.Order, carrier string, trackingCode string) (*trade.Order, error) {
+	if !uc.IsOrderStatusSameAs(ctx, order, trade.OrderStatusToBeShipped) {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "只有待发货的订单可以发货")
+	}
+
+	err := uc.upsertOrderLogistics(ctx, order.Id, &trade.Logistics{
+		Status:       trade.LogisticsStatusInTransit,
+		Carrier:      carrier,
+		TrackingCode: trackingCode,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return uc.ChangeOrderStatusFromTo(ctx, order, trade.OrderStatusToBeShipped, trade.OrderStatusShipping)
+}
+
+// DeliverOrder 送货中的订单确认签收
+func (uc *OrderUseCase) DeliverOrder(ctx context.Context, order *trade.Order) (*trade.Order, error) {
+	if !uc.IsOrderStatusSameAs(ctx, order, trade.OrderStatusShipping) {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "只有送货中的订单可以签收")
+	}
+
+	err := uc.upsertOrderLogistics(ctx, order.Id, &trade.Logistics{
+		Status:             trade.LogisticsStatusDelivered,
+		ActualDeliveryDate: time.Now(),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return uc.ChangeOrderStatusFromTo(ctx, order, trade.OrderStatusShipping, trade.OrderStatusDelivered)
+}
+
+// upsertOrderLogistics 按logistics中的非零字段更新订单的物流记录, 订单还没有物流记录时创建
+func (uc *OrderUseCase) upsertOrderLogistics(ctx context.Context, orderId int64, logistics *trade.Logistics) error {
+	return uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&trade.Logistics{}).Where("order_id = ?", orderId).Updates(logistics)
+		if result.Error != nil || result.RowsAffected > 0 {
+			return result.Error
+		}
+
+		logistics.OrderId = orderId
+		return tx.Create(logistics).Error
+	})
+}
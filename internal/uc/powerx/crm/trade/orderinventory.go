@@ -0,0 +1,35 @@
+package trade
+
+import (
+	"PowerX/internal/model/crm/trade"
+	productUC "PowerX/internal/uc/powerx/crm/product"
+	"context"
+	"gorm.io/gorm"
+)
+
+// ReleaseOrderInventories
+//
+//	@Description: 订单取消或全额退款时退回下单扣减的门店库存及组合商品的组件库存, 每个订单只退回一次
+//	@receiver uc
+//	@param ctx
+//	@param order
+//	@return error
+func (uc *OrderUseCase) ReleaseOrderInventories(ctx context.Context, order *trade.Order) error {
+	return uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&trade.Order{}).
+			Where("id = ? AND inventory_released = ?", order.Id, false).
+			Update("inventory_released", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		order.InventoryReleased = true
+
+		if err := productUC.NewStoreProductUseCase(tx).ReleaseOrderStoreInventories(ctx, order); err != nil {
+			return err
+		}
+		return releaseBundleComponentInventories(ctx, tx, order.Id)
+	})
+}
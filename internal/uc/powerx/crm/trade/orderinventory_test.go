@@ -0,0 +1,106 @@
+package trade
+
+import (
+	"PowerX/internal/model/crm/product"
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/model/powermodel"
+	productUC "PowerX/internal/uc/powerx/crm/product"
+	"context"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newInventoryTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		Logger:                                   logger.Discard,
+		DisableForeignKeyConstraintWhenMigrating: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 只建测试用到的表, sqlite的索引名全库唯一, 迁移关联表会出现重名索引
+	err = db.Migrator().CreateTable(
+		&trade.Order{}, &trade.OrderItem{}, &trade.OrderItemComponent{},
+		&product.SKU{}, &product.StoreProduct{}, &product.StoreSKU{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestReleaseOrderInventories(t *testing.T) {
+	ctx := context.Background()
+	db := newInventoryTestDB(t)
+
+	storeId := int64(7)
+	sku := &product.SKU{ProductId: 1, SkuNo: "SKU-1", Inventory: 100}
+	sku.UniqueID.String, sku.UniqueID.Valid = "sku-1", true
+	db.Create(sku)
+	db.Create(&product.StoreProduct{StoreId: storeId, ProductId: 1, IsAvailable: true})
+	inventory := 10
+	storeSKU := &product.StoreSKU{StoreId: storeId, ProductId: 1, SkuId: sku.Id, IsAvailable: true, Inventory: &inventory}
+	db.Create(storeSKU)
+
+	// 下单扣减门店库存
+	err := productUC.NewStoreProductUseCase(db).ReserveStoreInventories(ctx, storeId, []*productUC.StoreOrderLine{
+		{ProductId: 1, SkuId: sku.Id, ProductName: "咖啡", Quantity: 3},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := &trade.Order{PowerModel: &powermodel.PowerModel{}, CartId: 1, StoreId: storeId, OrderNumber: "O-1"}
+	db.Create(order)
+	db.Create(&trade.OrderItem{PowerModel: &powermodel.PowerModel{}, OrderId: order.Id, PriceBookEntryId: sku.Id, ProductName: "咖啡", Quantity: 3})
+	assertStoreInventory(t, db, storeSKU.Id, 7)
+
+	// 取消订单退回门店库存, 重复调用不会重复退回
+	uc := NewOrderUseCase(db)
+	for i := 0; i < 2; i++ {
+		if err = uc.ReleaseOrderInventories(ctx, order); err != nil {
+			t.Fatal(err)
+		}
+		assertStoreInventory(t, db, storeSKU.Id, 10)
+	}
+	if !order.InventoryReleased {
+		t.Errorf("order inventory should be released")
+	}
+}
+
+func TestReleaseOrderInventoriesWithBundleComponents(t *testing.T) {
+	ctx := context.Background()
+	db := newInventoryTestDB(t)
+
+	component := &product.SKU{ProductId: 2, SkuNo: "TEA", Inventory: 8}
+	component.UniqueID.String, component.UniqueID.Valid = "sku-tea", true
+	db.Create(component)
+	order := &trade.Order{PowerModel: &powermodel.PowerModel{}, OrderNumber: "O-2"}
+	db.Create(order)
+	db.Create(&trade.OrderItemComponent{PowerModel: &powermodel.PowerModel{}, OrderId: order.Id, SkuId: component.Id, Quantity: 2})
+
+	uc := NewOrderUseCase(db)
+	for i := 0; i < 2; i++ {
+		if err := uc.ReleaseOrderInventories(ctx, order); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sku := &product.SKU{}
+	db.First(sku, component.Id)
+	if sku.Inventory != 10 {
+		t.Errorf("component inventory = %d, expected 10", sku.Inventory)
+	}
+}
+
+func assertStoreInventory(t *testing.T, db *gorm.DB, storeSKUId int64, expected int) {
+	t.Helper()
+	storeSKU := &product.StoreSKU{}
+	if err := db.First(storeSKU, storeSKUId).Error; err != nil {
+		t.Fatal(err)
+	}
+	if storeSKU.Inventory == nil || *storeSKU.Inventory != expected {
+		t.Errorf("store inventory = %v, expected %d", storeSKU.Inventory, expected)
+	}
+}
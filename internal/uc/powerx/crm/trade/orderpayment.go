@@ -0,0 +1,270 @@
+package trade
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/types/errorx"
+	"PowerX/internal/uc/powerx"
+	"PowerX/internal/uc/powerx/crm/trade/provider"
+	"context"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+	"math"
+)
+
+// OrderPaymentSummary 订单的支付汇总, 一个订单可由多笔支付单(如代币+微信, 定金+尾款)共同完成
+type OrderPaymentSummary struct {
+	TotalAmount       float64
+	PaidAmount        float64
+	PendingAmount     float64
+	RefundedAmount    float64
+	OutstandingAmount float64
+	// 还可以发起支付的金额, 未付金额减去待支付的支付单, 避免并发支付超额
+	PayableAmount float64
+}
+
+// IsFullyPaid 已支付金额是否覆盖订单金额
+func (summary *OrderPaymentSummary) IsFullyPaid() bool {
+	return summary.TotalAmount > 0 && summary.OutstandingAmount <= 0
+}
+
+// PaymentAmount 参与汇总的支付单金额, Status为支付单状态的Key
+type PaymentAmount struct {
+	Status string
+	Amount float64
+	// 已支付的支付单部分退款的累计金额
+	RefundedAmount float64
+}
+
+// SummarizeOrderPayments
+//
+//	@Description: 按分计算, 只有已支付的支付单扣除部分退款后计入已付金额, 待支付/已取消/已退款的不计入
+//	@param totalAmount 订单实际交易价格
+//	@param payments
+//	@return *OrderPaymentSummary
+func SummarizeOrderPayments(totalAmount float64, payments []PaymentAmount) *OrderPaymentSummary {
+	var paid, pending, refunded int64
+	for _, p := range payments {
+		cent := int64(math.Round(p.Amount * 100))
+		switch p.Status {
+		case trade.PaymentStatusPaid:
+			refundedCent := int64(math.Round(p.RefundedAmount * 100))
+			paid += cent - refundedCent
+			refunded += refundedCent
+		case trade.PaymentStatusPending:
+			pending += cent
+		case trade.PaymentStatusRefunded:
+			refunded += cent
+		}
+	}
+
+	total := int64(math.Round(totalAmount * 100))
+	outstanding := total - paid
+	if outstanding < 0 {
+		outstanding = 0
+	}
+	payable := outstanding - pending
+	if payable < 0 {
+		payable = 0
+	}
+
+	return &OrderPaymentSummary{
+		TotalAmount:       float64(total) / 100,
+		PaidAmount:        float64(paid) / 100,
+		PendingAmount:     float64(pending) / 100,
+		RefundedAmount:    float64(refunded) / 100,
+		OutstandingAmount: float64(outstanding) / 100,
+		PayableAmount:     float64(payable) / 100,
+	}
+}
+
+func (uc *PaymentUseCase) findOrderPayments(ctx context.Context, orderId int64) []*trade.Payment {
+	return findOrderPayments(ctx, uc.db, orderId)
+}
+
+func findOrderPayments(ctx context.Context, db *gorm.DB, orderId int64) []*trade.Payment {
+	var payments []*trade.Payment
+	if err := db.WithContext(ctx).Where("order_id = ?", orderId).Find(&payments).Error; err != nil {
+		panic(err)
+	}
+	return payments
+}
+
+// GetOrderPaymentSummary 汇总订单下所有支付单
+func (uc *PaymentUseCase) GetOrderPaymentSummary(ctx context.Context, order *trade.Order) *OrderPaymentSummary {
+	return uc.getOrderPaymentSummary(ctx, uc.db, order)
+}
+
+func (uc *PaymentUseCase) getOrderPaymentSummary(ctx context.Context, db *gorm.DB, order *trade.Order) *OrderPaymentSummary {
+	ucDD := powerx.NewDataDictionaryUseCase(uc.db)
+
+	payments := findOrderPayments(ctx, db, order.Id)
+	amounts := make([]PaymentAmount, 0, len(payments))
+	for _, payment := range payments {
+		amounts = append(amounts, PaymentAmount{
+			Status:         ucDD.GetCachedDDById(ctx, payment.Status).Key,
+			Amount:         payment.PaidAmount,
+			RefundedAmount: payment.RefundedAmount,
+		})
+	}
+
+	return SummarizeOrderPayments(order.UnitPrice, amounts)
+}
+
+// ResolvePayableAmount
+//
+//	@Description: 校验本次支付金额, 不传金额时支付全部可支付金额; 待支付的支付单占用的金额不能重复支付
+//	@param summary
+//	@param amount
+//	@return float64
+//	@return error
+func ResolvePayableAmount(summary *OrderPaymentSummary, amount float64) (float64, error) {
+	if summary.OutstandingAmount <= 0 {
+		return 0, errorx.WithCause(errorx.ErrBadRequest, "该订单已支付完成")
+	}
+	if summary.PayableAmount <= 0 {
+		return 0, errorx.WithCause(errorx.ErrBadRequest, "该订单有待支付的支付单, 请完成支付或稍后重试")
+	}
+	if amount == 0 {
+		return summary.PayableAmount, nil
+	}
+	if amount < 0 || math.Round(amount*100) > math.Round(summary.PayableAmount*100) {
+		return 0, errorx.WithCause(errorx.ErrBadRequest, "支付金额不能超过订单未付金额")
+	}
+	return math.Round(amount*100) / 100, nil
+}
+
+// RefreshOrderPaidAmount 按支付单重新汇总订单的已支付金额
+func (uc *PaymentUseCase) RefreshOrderPaidAmount(ctx context.Context, order *trade.Order) *OrderPaymentSummary {
+	summary := uc.GetOrderPaymentSummary(ctx, order)
+	order.PaidAmount = summary.PaidAmount
+	err := uc.db.WithContext(ctx).Model(&trade.Order{}).
+		Where("id = ?", order.Id).
+		Update("paid_amount", summary.PaidAmount).Error
+	if err != nil {
+		panic(err)
+	}
+	return summary
+}
+
+// SettleOrderAfterPaid
+//
+//	@Description: 支付单到账后汇总订单, 全部付清时订单由待付款变为待发货, 并关闭其余未支付的支付单
+//	@receiver uc
+//	@param ctx
+//	@param order
+//	@return bool 订单是否由本次调用变为已付清
+//	@return error
+func (uc *PaymentUseCase) SettleOrderAfterPaid(ctx context.Context, order *trade.Order) (bool, error) {
+	summary := uc.RefreshOrderPaidAmount(ctx, order)
+	if !summary.IsFullyPaid() {
+		return false, nil
+	}
+
+	ucOrder := NewOrderUseCase(uc.db)
+	if !ucOrder.IsOrderStatusSameAs(ctx, order, trade.OrderStatusToBePaid) {
+		if math.Round(summary.PaidAmount*100) > math.Round(summary.TotalAmount*100) {
+			logx.WithContext(ctx).Errorf("订单%s超额支付, 已付%.2f, 订单金额%.2f", order.OrderNumber, summary.PaidAmount, summary.TotalAmount)
+		}
+		return false, nil
+	}
+	if _, err := ucOrder.ChangeOrderStatusFromTo(ctx, order, trade.OrderStatusToBePaid, trade.OrderStatusToBeShipped); err != nil {
+		return false, err
+	}
+
+	uc.closePendingOrderPayments(ctx, order)
+	uc.runOrderHooks(ctx, order, uc.orderPaidHooks, "付清")
+
+	return true, nil
+}
+
+// runOrderHooks 订单状态已经变更, 回调失败只记录日志, 不影响支付结果
+func (uc *PaymentUseCase) runOrderHooks(ctx context.Context, order *trade.Order, hooks []func(ctx context.Context, order *trade.Order), event string) {
+	for _, hook := range hooks {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					logx.WithContext(ctx).Errorf("订单%s%s回调失败:%v", order.OrderNumber, event, r)
+				}
+			}()
+			hook(ctx, order)
+		}()
+	}
+}
+
+// SettleOrderAfterRefunded
+//
+//	@Description: 支付单退款后汇总订单, 所有支付单都已退款时订单变为已退款; 部分退款只更新已付金额
+//	@receiver uc
+//	@param ctx
+//	@param order
+//	@return bool 订单是否由本次调用变为已退款
+//	@return error
+func (uc *PaymentUseCase) SettleOrderAfterRefunded(ctx context.Context, order *trade.Order) (bool, error) {
+	summary := uc.RefreshOrderPaidAmount(ctx, order)
+	if summary.PaidAmount > 0 {
+		return false, nil
+	}
+
+	ucOrder := NewOrderUseCase(uc.db)
+	if ucOrder.IsOrderStatusSameAs(ctx, order, trade.OrderStatusRefunded) ||
+		ucOrder.IsOrderStatusSameAs(ctx, order, trade.OrderStatusCancelled) {
+		return false, nil
+	}
+
+	ucDD := powerx.NewDataDictionaryUseCase(uc.db)
+	fromStatus := ucDD.GetCachedDDById(ctx, order.Status).Key
+	if _, err := ucOrder.ChangeOrderStatusFromTo(ctx, order, fromStatus, trade.OrderStatusRefunded); err != nil {
+		return false, err
+	}
+	if err := ucOrder.ReleaseOrderInventories(ctx, order); err != nil {
+		logx.WithContext(ctx).Errorf("订单%s退款-退回库存失败:%s", order.OrderNumber, err.Error())
+	}
+
+	uc.runOrderHooks(ctx, order, uc.orderRefundedHooks, "退款")
+
+	return true, nil
+}
+
+// ReleaseOrderPayments
+//
+//	@Description: 未付清的订单取消时, 关闭未支付的支付单, 已支付的部分(如代币, 定金)原路退回
+//	@receiver uc
+//	@param ctx
+//	@param order
+func (uc *PaymentUseCase) ReleaseOrderPayments(ctx context.Context, order *trade.Order) {
+	uc.closePendingOrderPayments(ctx, order)
+
+	for _, payment := range uc.findOrderPayments(ctx, order.Id) {
+		if !uc.IsPaymentStatusSameAs(ctx, payment, trade.PaymentStatusPaid) {
+			continue
+		}
+		amount := math.Round((payment.PaidAmount-payment.RefundedAmount)*100) / 100
+		if amount <= 0 {
+			continue
+		}
+		result, err := uc.RefundPayment(ctx, payment, amount, "订单取消")
+		if err != nil {
+			logx.WithContext(ctx).Errorf("订单取消-退回支付单%s失败:%s", payment.PaymentNumber, err.Error())
+			continue
+		}
+		// 异步退款以渠道退款通知为准
+		if result.State == provider.RefundStateSuccess {
+			if _, _, err = uc.ApplyRefundResult(ctx, result); err != nil {
+				logx.WithContext(ctx).Errorf("订单取消-记录支付单%s退款失败:%s", payment.PaymentNumber, err.Error())
+			}
+		}
+	}
+
+	uc.RefreshOrderPaidAmount(ctx, order)
+}
+
+func (uc *PaymentUseCase) closePendingOrderPayments(ctx context.Context, order *trade.Order) {
+	for _, payment := range uc.findOrderPayments(ctx, order.Id) {
+		if !uc.IsPaymentStatusSameAs(ctx, payment, trade.PaymentStatusPending) {
+			continue
+		}
+		if _, err := uc.ClosePayment(ctx, payment); err != nil {
+			logx.WithContext(ctx).Errorf("关闭订单%s未支付的支付单%s失败:%s", order.OrderNumber, payment.PaymentNumber, err.Error())
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package trade
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"testing"
+)
+
+func TestSummarizeOrderPayments(t *testing.T) {
+
+	// 代币 + 微信组合支付, 微信部分待支付
+	summary := SummarizeOrderPayments(100, []PaymentAmount{
+		{Status: trade.PaymentStatusPaid, Amount: 30.1},
+		{Status: trade.PaymentStatusPending, Amount: 69.9},
+	})
+	if summary.PaidAmount != 30.1 || summary.PendingAmount != 69.9 || summary.OutstandingAmount != 69.9 {
+		t.Errorf("summary = %+v", summary)
+	}
+	if summary.IsFullyPaid() {
+		t.Errorf("order with pending payment should not be fully paid")
+	}
+
+	// 定金 + 尾款, 中途一笔失败后重新支付
+	summary = SummarizeOrderPayments(99.9, []PaymentAmount{
+		{Status: trade.PaymentStatusPaid, Amount: 19.9},
+		{Status: trade.PaymentStatusCancelled, Amount: 80},
+		{Status: trade.PaymentStatusPaid, Amount: 80},
+	})
+	if !summary.IsFullyPaid() || summary.OutstandingAmount != 0 || summary.PaidAmount != 99.9 {
+		t.Errorf("summary = %+v", summary)
+	}
+
+	// 其中一笔退款后, 未付金额恢复
+	summary = SummarizeOrderPayments(99.9, []PaymentAmount{
+		{Status: trade.PaymentStatusRefunded, Amount: 19.9},
+		{Status: trade.PaymentStatusPaid, Amount: 80},
+	})
+	if summary.IsFullyPaid() || summary.OutstandingAmount != 19.9 || summary.RefundedAmount != 19.9 {
+		t.Errorf("summary = %+v", summary)
+	}
+
+	// 超额支付时未付金额不为负
+	summary = SummarizeOrderPayments(10, []PaymentAmount{
+		{Status: trade.PaymentStatusPaid, Amount: 10},
+		{Status: trade.PaymentStatusPaid, Amount: 5},
+	})
+	if summary.OutstandingAmount != 0 || summary.PaidAmount != 15 {
+		t.Errorf("summary = %+v", summary)
+	}
+}
+
+func TestResolvePayableAmount(t *testing.T) {
+
+	// 微信支付单待支付时, 代币只能支付剩余部分
+	summary := SummarizeOrderPayments(100, []PaymentAmount{
+		{Status: trade.PaymentStatusPending, Amount: 60},
+	})
+	if amount, err := ResolvePayableAmount(summary, 0); err != nil || amount != 40 {
+		t.Errorf("amount = %v, err = %v", amount, err)
+	}
+	if _, err := ResolvePayableAmount(summary, 50); err == nil {
+		t.Errorf("amount over payable should be rejected")
+	}
+
+	// 待支付的支付单覆盖全部未付金额时, 不能再发起支付
+	summary = SummarizeOrderPayments(100, []PaymentAmount{
+		{Status: trade.PaymentStatusPaid, Amount: 30},
+		{Status: trade.PaymentStatusPending, Amount: 70},
+	})
+	if _, err := ResolvePayableAmount(summary, 0); err == nil {
+		t.Errorf("order covered by pending payment should not be payable")
+	}
+
+	summary = SummarizeOrderPayments(100, []PaymentAmount{
+		{Status: trade.PaymentStatusPaid, Amount: 100},
+	})
+	if _, err := ResolvePayableAmount(summary, 0); err == nil {
+		t.Errorf("fully paid order should not be payable")
+	}
+}
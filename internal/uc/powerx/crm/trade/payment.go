@@ -8,26 +8,46 @@ import (
 	"PowerX/internal/types"
 	"PowerX/internal/types/errorx"
 	"PowerX/internal/uc/powerx"
+	"PowerX/internal/uc/powerx/crm/trade/provider"
 	"context"
-	"fmt"
+	"github.com/ArtisanCloud/PowerLibs/v3/object"
 	"github.com/ArtisanCloud/PowerWeChat/v3/src/payment"
-	"github.com/ArtisanCloud/PowerWeChat/v3/src/payment/order/request"
-	"github.com/ArtisanCloud/PowerWeChat/v3/src/payment/order/response"
+	"github.com/golang-module/carbon/v2"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"strings"
+	"time"
 )
 
 type PaymentUseCase struct {
 	db        *gorm.DB
 	WXPayment *payment.Payment
+	// 支付方式Key(_wechat/_alipay/_bank/_token) -> 支付渠道
+	Providers map[string]provider.IPaymentProviderInterface
+	// 订单付清后的回调, 如生成订阅
+	orderPaidHooks []func(ctx context.Context, order *trade.Order)
+	// 订单全额退款后的回调, 如扣减销量
+	orderRefundedHooks []func(ctx context.Context, order *trade.Order)
 }
 
-const WXCurrencyUnit = 100
-
 func NewPaymentUseCase(db *gorm.DB, conf *config.Config) *PaymentUseCase {
 
-	// 初始化微信公众号API SDK
+	uc := &PaymentUseCase{
+		db:        db,
+		Providers: map[string]provider.IPaymentProviderInterface{},
+	}
+	uc.RegisterProvider(provider.NewOfflineProvider(conf.Payment.Offline))
+	uc.RegisterProvider(provider.NewTokenProvider(db))
+
+	// 自动化测试不访问真实支付渠道
+	if conf.Payment.Fake {
+		uc.RegisterProvider(provider.NewFakeProvider(trade.PaymentTypeWeChat))
+		uc.RegisterProvider(provider.NewFakeProvider(trade.PaymentTypeAlipay))
+		return uc
+	}
+
+	// 初始化微信支付API SDK
 	wxPayment, err := payment.NewPayment(&payment.UserConfig{
 		AppID:            conf.WechatPay.AppId,
 		MchID:            conf.WechatPay.MchId,
@@ -49,16 +69,59 @@ func NewPaymentUseCase(db *gorm.DB, conf *config.Config) *PaymentUseCase {
 	if err != nil {
 		panic(errors.Wrap(err, "wechat payment init failed"))
 	}
+	uc.WXPayment = wxPayment
+	wechatPay, err := provider.NewWechatPayProvider(wxPayment, conf.WechatPay.PlatformCertPath)
+	if err != nil {
+		panic(errors.Wrap(err, "wechat pay platform certificate load failed"))
+	}
+	uc.RegisterProvider(wechatPay)
 
-	return &PaymentUseCase{
-		db:        db,
-		WXPayment: wxPayment,
+	if conf.Payment.Alipay.AppId != "" {
+		alipay, err := provider.NewAlipayProvider(conf.Payment.Alipay)
+		if err != nil {
+			panic(errors.Wrap(err, "alipay init failed"))
+		}
+		uc.RegisterProvider(alipay)
+	}
+
+	return uc
+}
+
+func (uc *PaymentUseCase) RegisterProvider(p provider.IPaymentProviderInterface) {
+	uc.Providers[p.Name()] = p
+}
+
+// AddOrderPaidHook 注册订单付清后的回调, 回调在订单变为待发货后执行
+func (uc *PaymentUseCase) AddOrderPaidHook(hook func(ctx context.Context, order *trade.Order)) {
+	uc.orderPaidHooks = append(uc.orderPaidHooks, hook)
+}
+
+// AddOrderRefundedHook 注册订单全额退款后的回调, 回调在订单变为已退款后执行
+func (uc *PaymentUseCase) AddOrderRefundedHook(hook func(ctx context.Context, order *trade.Order)) {
+	uc.orderRefundedHooks = append(uc.orderRefundedHooks, hook)
+}
+
+func (uc *PaymentUseCase) GetProviderByName(name string) (provider.IPaymentProviderInterface, error) {
+	p, ok := uc.Providers[name]
+	if !ok {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "支付类型不支持")
+	}
+	return p, nil
+}
+
+// GetProvider 按支付方式(数据字典Id)选择支付渠道
+func (uc *PaymentUseCase) GetProvider(ctx context.Context, paymentTypeId int) (provider.IPaymentProviderInterface, error) {
+	ucDD := powerx.NewDataDictionaryUseCase(uc.db)
+	item, err := ucDD.GetDataDictionaryItemById(ctx, paymentTypeId)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "支付类型不支持")
 	}
+	return uc.GetProviderByName(item.Key)
 }
 
 type FindManyPaymentsOption struct {
 	LikeName  string
-	PaymentBy string
+	QuerySpec *powermodel.QuerySpec
 	types.PageEmbedOption
 }
 
@@ -68,10 +131,8 @@ func (uc *PaymentUseCase) buildFindQueryNoPage(db *gorm.DB, opt *FindManyPayment
 		db = db.Where("name LIKE ?", "%"+opt.LikeName+"%")
 	}
 
+	db = opt.QuerySpec.Apply(db)
 	orderBy := "id desc"
-	if opt.PaymentBy != "" {
-		orderBy = opt.PaymentBy + "," + orderBy
-	}
 	db.Order(orderBy)
 
 	return db
@@ -125,50 +186,104 @@ func (uc *PaymentUseCase) FindManyPayments(ctx context.Context, opt *FindManyPay
 	}, nil
 }
 
-func (uc *PaymentUseCase) CreatePaymentFromOrderByWechat(ctx context.Context,
+// CreatePaymentFromOrder
+//
+//	@Description: 为订单创建一笔支付单, 订单可分多笔支付, amount为0时支付全部未付金额
+//	@receiver uc
+//	@param ctx
+//	@param customer
+//	@param order
+//	@param paymentType
+//	@param amount 本次支付金额
+//	@param openId
+//	@return payment 代币等同步到账的支付单返回时已是已支付状态
+//	@return data 前端拉起支付所需参数
+//	@return err
+func (uc *PaymentUseCase) CreatePaymentFromOrder(ctx context.Context,
 	customer *customerdomain2.Customer, order *trade.Order,
-	openId string, paymentType int,
+	paymentType int, amount float64, openId string,
 ) (payment *trade.Payment, data interface{}, err error) {
 
-	db := uc.db.WithContext(ctx)
+	payProvider, err := uc.GetProvider(ctx, paymentType)
+	if err != nil {
+		return nil, nil, err
+	}
 
+	// 客户重新发起支付时关闭之前未完成的支付单, 释放其占用的未付金额
+	uc.closeSupersededPayments(ctx, order)
+
+	db := uc.db.WithContext(ctx)
+	internal := provider.IsInternalProvider(payProvider)
 	paymentStatusId := uc.GetPaymentStatusId(ctx, trade.PaymentStatusPending)
-	// 创建支付单
-	payment = uc.MakePaymentFromOrder(customer, order, paymentType, paymentStatusId)
+	transaction := &provider.Transaction{
+		OpenId:     openId,
+		CustomerId: customer.Id,
+		ExpireAt:   time.Now().Add(PendingPaymentExpireDuration),
+	}
+	var prepay *provider.Prepay
 	err = db.Transaction(func(tx *gorm.DB) error {
-		// 保存支付单
+		// 锁定订单, 同一订单的支付单串行创建, 已支付及待支付的金额不能被重复支付
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").First(&trade.Order{}, order.Id).Error; err != nil {
+			return err
+		}
+		amount, err = ResolvePayableAmount(uc.getOrderPaymentSummary(ctx, tx, order), amount)
+		if err != nil {
+			return err
+		}
+
+		// 创建支付单
+		payment = uc.MakePaymentFromOrder(customer, order, paymentType, paymentStatusId, amount)
 		err = tx.Model(trade.Payment{}).
 			//Debug().
 			Create(payment).Error
 		if err != nil {
 			return err
 		}
+		transaction.PaymentNumber = payment.PaymentNumber
+		transaction.Amount = payment.PaidAmount
+		transaction.Description = payment.Remark
+
+		// 代币等内部渠道在本事务内扣减, 与支付单一起提交或回滚
+		if internal {
+			transaction.DB = tx
+			prepay, err = payProvider.CreateTransaction(ctx, transaction)
+		}
+		return err
+	})
+	if err != nil {
+		return payment, data, err
+	}
 
-		// 创建微信支付单
-		rsOrder, err := uc.MakeWechatOrder(ctx, payment, openId)
+	// 外部渠道在订单锁之外下单, 下单失败时取消支付单, 不再占用未付金额
+	if !internal {
+		prepay, err = payProvider.CreateTransaction(ctx, transaction)
 		if err != nil {
-			return err
+			uc.MarkPaymentCancelledFromPending(ctx, payment)
+			return payment, data, err
 		}
-
-		if rsOrder.PrepayID == "" {
-			err = errors.New("no Prepay Id generated")
-			return err
+	}
+	if prepay.ReferenceNumber != "" && !prepay.Paid {
+		payment.ReferenceNumber = prepay.ReferenceNumber
+		if err = db.Model(payment).Update("reference_number", prepay.ReferenceNumber).Error; err != nil {
+			panic(err)
 		}
+	}
+	data = prepay.Data
 
-		// config wx Bridge for front end
-		data, err = uc.WXPayment.JSSDK.BridgeConfig(rsOrder.PrepayID, false)
-
-		return err
-	})
+	// 同步到账的渠道直接标记已支付, 由调用方汇总订单
+	if prepay.Paid {
+		uc.MarkPaymentPaidFromPending(ctx, payment, prepay.ReferenceNumber, time.Now())
+	}
 
-	return payment, data, err
+	return payment, data, nil
 }
 
-func (uc *PaymentUseCase) MakePaymentFromOrder(customer *customerdomain2.Customer, order *trade.Order, paymentType int, paymentStatus int) (payment *trade.Payment) {
+func (uc *PaymentUseCase) MakePaymentFromOrder(customer *customerdomain2.Customer, order *trade.Order, paymentType int, paymentStatus int, amount float64) (payment *trade.Payment) {
 	payment = &trade.Payment{
 		OrderId:       order.Id,
 		PaymentType:   paymentType,
-		PaidAmount:    order.UnitPrice,
+		PaidAmount:    amount,
 		PaymentNumber: trade.GeneratePaymentNumber(),
 		Status:        paymentStatus,
 	}
@@ -177,7 +292,7 @@ func (uc *PaymentUseCase) MakePaymentFromOrder(customer *customerdomain2.Custome
 	paymentItems := []*trade.PaymentItem{
 		{
 			Quantity:            quantityOfItems,
-			UnitPrice:           order.UnitPrice,
+			UnitPrice:           amount,
 			PaymentCustomerName: customer.Name,
 		},
 	}
@@ -186,33 +301,6 @@ func (uc *PaymentUseCase) MakePaymentFromOrder(customer *customerdomain2.Custome
 	return payment
 }
 
-func (uc *PaymentUseCase) MakeWechatOrder(ctx context.Context, payment *trade.Payment, openID string) (*response.ResponseUnitfy, error) {
-
-	description := fmt.Sprintf("%f-%s", payment.PaidAmount, payment.PaymentNumber)
-	if payment.Remark != "" {
-		description = payment.Remark
-	}
-
-	mapObject := &request.RequestJSAPIPrepay{
-		Amount: &request.JSAPIAmount{
-			Total: int(payment.PaidAmount * WXCurrencyUnit),
-			//Total: int(0.3 * WXCurrencyUnit),
-			//Currency: "CNY",
-		},
-		Attach:      "订单支付",
-		Description: description,
-		OutTradeNo:  payment.PaymentNumber,
-		Payer: &request.JSAPIPayer{
-			OpenID: openID,
-		},
-	}
-	mapObject.SetNotifyUrl(uc.WXPayment.Config.GetString("notify_url", ""))
-	//mapObject.SetAppID(service.App.Config.GetString("app_id", ""))
-
-	return uc.WXPayment.Order.JSAPITransaction(ctx, mapObject)
-
-}
-
 func (uc *PaymentUseCase) CreatePayment(ctx context.Context, payment *trade.Payment) error {
 
 	if err := uc.db.WithContext(ctx).
@@ -344,12 +432,74 @@ func (uc *PaymentUseCase) ChangePaymentStatusPaid(ctx context.Context, payment *
 	db := uc.db.WithContext(ctx)
 
 	payment.Status = uc.GetPaymentStatusId(ctx, trade.PaymentStatusPaid)
+	if payment.PaymentDate.IsZero() {
+		payment.PaymentDate = time.Now()
+	}
 
 	err := db.Save(payment).Error
 
 	return payment, err
 }
 
+// QueryPaymentTransaction 向支付渠道查询支付单的交易状态
+func (uc *PaymentUseCase) QueryPaymentTransaction(ctx context.Context, payment *trade.Payment) (*provider.TransactionResult, error) {
+	payProvider, err := uc.GetProvider(ctx, payment.PaymentType)
+	if err != nil {
+		return nil, err
+	}
+	return payProvider.QueryTransaction(ctx, payment.PaymentNumber)
+}
+
+// ClosePayment 关闭渠道侧交易并取消支付单
+func (uc *PaymentUseCase) ClosePayment(ctx context.Context, payment *trade.Payment) (*trade.Payment, error) {
+	if !uc.IsPaymentStatusSameAs(ctx, payment, trade.PaymentStatusPending) {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "只能关闭待支付的支付单")
+	}
+	payProvider, err := uc.GetProvider(ctx, payment.PaymentType)
+	if err != nil {
+		return nil, err
+	}
+	if err = payProvider.CloseTransaction(ctx, payment.PaymentNumber); err != nil {
+		return nil, err
+	}
+
+	// 关闭期间收到支付通知时以支付结果为准
+	if !uc.MarkPaymentCancelledFromPending(ctx, payment) {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "支付单状态已变更")
+	}
+	return payment, nil
+}
+
+// ConfirmOfflinePayment 管理员核对银行流水后确认线下转账到账
+func (uc *PaymentUseCase) ConfirmOfflinePayment(ctx context.Context, id int64, referenceNumber string, remark string) (*trade.Payment, error) {
+	var payment = &trade.Payment{}
+	err := uc.db.WithContext(ctx).Preload("Order").First(payment, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "未找到支付单")
+		}
+		panic(err)
+	}
+	if !uc.IsPaymentTypeSameAs(ctx, payment, trade.PaymentTypeBank) {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "只有线下转账的支付单需要确认")
+	}
+	if !uc.IsPaymentStatusSameAs(ctx, payment, trade.PaymentStatusPending) {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "该支付单不属于待支付状态")
+	}
+
+	if !uc.MarkPaymentPaidFromPending(ctx, payment, referenceNumber, time.Now()) {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "该支付单不属于待支付状态")
+	}
+	if remark != "" {
+		payment.Remark = remark
+		if err = uc.db.WithContext(ctx).Model(payment).Update("remark", remark).Error; err != nil {
+			panic(err)
+		}
+	}
+
+	return payment, nil
+}
+
 func (uc *PaymentUseCase) IsPaymentTypeSameAs(ctx context.Context, payment *trade.Payment, paymentType string) bool {
 	ucDD := powerx.NewDataDictionaryUseCase(uc.db)
 
@@ -364,6 +514,10 @@ func (uc *PaymentUseCase) IsPaymentStatusSameAs(ctx context.Context, payment *tr
 
 }
 
+func GenerateRefundNumber() string {
+	return "RF" + carbon.Now().Format("YmdHis") + object.QuickRandom(6)
+}
+
 func (uc *PaymentUseCase) GetPaymentTypeId(ctx context.Context, paymentType string) (paymentTypeId int) {
 	ucDD := powerx.NewDataDictionaryUseCase(uc.db)
 	paymentTypeId = ucDD.GetCachedDDId(ctx, trade.TypePaymentType, paymentType)
@@ -0,0 +1,72 @@
+package trade
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"context"
+	"github.com/robfig/cron/v3"
+	"github.com/zeromicro/go-zero/core/logx"
+	"time"
+)
+
+// 待支付的支付单在渠道侧的有效期, 超时由渠道关闭交易
+const PendingPaymentExpireDuration = 30 * time.Minute
+
+// 渠道关闭交易后再关闭本地支付单的等待时间, 避免与渠道最后时刻的支付冲突
+const pendingPaymentCloseGrace = 5 * time.Minute
+
+// closeSupersededPayments 客户重新发起支付时关闭订单之前未完成的支付单, 线下转账需要管理员确认, 不关闭
+func (uc *PaymentUseCase) closeSupersededPayments(ctx context.Context, order *trade.Order) {
+	for _, payment := range uc.findOrderPayments(ctx, order.Id) {
+		if !uc.IsPaymentStatusSameAs(ctx, payment, trade.PaymentStatusPending) ||
+			uc.IsPaymentTypeSameAs(ctx, payment, trade.PaymentTypeBank) {
+			continue
+		}
+		// 渠道侧已支付等无法关闭时保留支付单, 以支付通知为准
+		if _, err := uc.ClosePayment(ctx, payment); err != nil {
+			logx.WithContext(ctx).Errorf("关闭订单%s之前的支付单%s失败:%s", order.OrderNumber, payment.PaymentNumber, err.Error())
+		}
+	}
+}
+
+// CloseExpiredPayments
+//
+//	@Description: 关闭超过有效期仍未支付的支付单, 释放订单的未付金额; 线下转账需要管理员确认, 不关闭
+//	@receiver uc
+//	@param ctx
+//	@param now
+//	@return int 关闭的支付单数量
+func (uc *PaymentUseCase) CloseExpiredPayments(ctx context.Context, now time.Time) int {
+	var payments []*trade.Payment
+	err := uc.db.WithContext(ctx).
+		Where("status = ? AND payment_type <> ? AND created_at < ?",
+			uc.GetPaymentStatusId(ctx, trade.PaymentStatusPending),
+			uc.GetPaymentTypeId(ctx, trade.PaymentTypeBank),
+			now.Add(-PendingPaymentExpireDuration-pendingPaymentCloseGrace)).
+		Order("id").
+		Find(&payments).Error
+	if err != nil {
+		panic(err)
+	}
+
+	closed := 0
+	for _, payment := range payments {
+		if _, err = uc.ClosePayment(ctx, payment); err != nil {
+			logx.WithContext(ctx).Errorf("关闭超时支付单%s失败:%s", payment.PaymentNumber, err.Error())
+			continue
+		}
+		closed++
+	}
+	return closed
+}
+
+// Schedule 每5分钟关闭超时未支付的支付单
+func (uc *PaymentUseCase) Schedule(c *cron.Cron) {
+	_, _ = c.AddFunc(`*/5 * * * *`, func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logx.Errorf("close expired payments panic: %v", r)
+			}
+		}()
+		uc.CloseExpiredPayments(context.Background(), time.Now())
+	})
+}
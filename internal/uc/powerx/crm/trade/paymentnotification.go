@@ -0,0 +1,156 @@
+package trade
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/types"
+	"PowerX/internal/types/errorx"
+	"context"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type FindManyPaymentNotificationsOption struct {
+	PaymentType   string
+	PaymentNumber string
+	Status        string
+	types.PageEmbedOption
+}
+
+func (uc *PaymentUseCase) FindManyPaymentNotifications(ctx context.Context, opt *FindManyPaymentNotificationsOption) (pageList types.Page[*trade.PaymentNotification], err error) {
+	opt.DefaultPageIfNotSet()
+	var notifications []*trade.PaymentNotification
+	db := uc.db.WithContext(ctx).Model(&trade.PaymentNotification{})
+
+	if opt.PaymentType != "" {
+		db = db.Where("payment_type = ?", opt.PaymentType)
+	}
+	if opt.PaymentNumber != "" {
+		db = db.Where("payment_number = ?", opt.PaymentNumber)
+	}
+	if opt.Status != "" {
+		db = db.Where("status = ?", opt.Status)
+	}
+
+	var count int64
+	if err := db.Count(&count).Error; err != nil {
+		panic(err)
+	}
+
+	if opt.PageIndex != 0 && opt.PageSize != 0 {
+		db.Offset((opt.PageIndex - 1) * opt.PageSize).Limit(opt.PageSize)
+	}
+	if err := db.Order("id desc").Find(&notifications).Error; err != nil {
+		panic(err)
+	}
+
+	return types.Page[*trade.PaymentNotification]{
+		List:      notifications,
+		PageIndex: opt.PageIndex,
+		PageSize:  opt.PageSize,
+		Total:     count,
+	}, nil
+}
+
+func (uc *PaymentUseCase) GetPaymentNotification(ctx context.Context, id int64) (*trade.PaymentNotification, error) {
+	var notification = &trade.PaymentNotification{}
+	if err := uc.db.WithContext(ctx).First(notification, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "未找到支付通知")
+		}
+		panic(err)
+	}
+	return notification, nil
+}
+
+func (uc *PaymentUseCase) CreatePaymentNotification(ctx context.Context, notification *trade.PaymentNotification) {
+	if notification.Status == "" {
+		notification.Status = trade.PaymentNotificationStatusReceived
+	}
+	if err := uc.db.WithContext(ctx).Create(notification).Error; err != nil {
+		panic(err)
+	}
+}
+
+func (uc *PaymentUseCase) SavePaymentNotification(ctx context.Context, notification *trade.PaymentNotification) {
+	if err := uc.db.WithContext(ctx).Save(notification).Error; err != nil {
+		panic(err)
+	}
+}
+
+// IsPaymentNotificationProcessed 同一渠道交易的同一事件是否已经处理过
+func (uc *PaymentUseCase) IsPaymentNotificationProcessed(ctx context.Context, notification *trade.PaymentNotification) bool {
+	if notification.TransactionId == "" {
+		return false
+	}
+	var count int64
+	err := uc.db.WithContext(ctx).Model(&trade.PaymentNotification{}).
+		Where("payment_type = ? AND event = ? AND transaction_id = ? AND status = ? AND id <> ?",
+			notification.PaymentType, notification.Event, notification.TransactionId,
+			trade.PaymentNotificationStatusProcessed, notification.Id).
+		Count(&count).Error
+	if err != nil {
+		panic(err)
+	}
+	return count > 0
+}
+
+// MarkPaymentPaidFromPending
+//
+//	@Description: 仅当支付单仍为待支付时标记为已支付, 并发或重复通知只有一次生效
+//	@receiver uc
+//	@param ctx
+//	@param payment
+//	@param referenceNumber 渠道交易号
+//	@param paidAt
+//	@return bool 是否由本次调用完成状态变更
+func (uc *PaymentUseCase) MarkPaymentPaidFromPending(ctx context.Context, payment *trade.Payment, referenceNumber string, paidAt time.Time) bool {
+	pendingId := uc.GetPaymentStatusId(ctx, trade.PaymentStatusPending)
+	paidId := uc.GetPaymentStatusId(ctx, trade.PaymentStatusPaid)
+	if paidAt.IsZero() {
+		paidAt = time.Now()
+	}
+
+	values := map[string]interface{}{
+		"status":       paidId,
+		"payment_date": paidAt,
+	}
+	if referenceNumber != "" {
+		values["reference_number"] = referenceNumber
+	}
+	result := uc.db.WithContext(ctx).Model(&trade.Payment{}).
+		Where("id = ? AND status = ?", payment.Id, pendingId).
+		Updates(values)
+	if result.Error != nil {
+		panic(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return false
+	}
+
+	payment.Status = paidId
+	payment.PaymentDate = paidAt
+	if referenceNumber != "" {
+		payment.ReferenceNumber = referenceNumber
+	}
+	return true
+}
+
+// MarkPaymentCancelledFromPending 渠道通知交易关闭(超时/支付失败)时取消待支付的支付单, 订单保持待付款可重新支付
+func (uc *PaymentUseCase) MarkPaymentCancelledFromPending(ctx context.Context, payment *trade.Payment) bool {
+	pendingId := uc.GetPaymentStatusId(ctx, trade.PaymentStatusPending)
+	cancelledId := uc.GetPaymentStatusId(ctx, trade.PaymentStatusCancelled)
+
+	result := uc.db.WithContext(ctx).Model(&trade.Payment{}).
+		Where("id = ? AND status = ?", payment.Id, pendingId).
+		Update("status", cancelledId)
+	if result.Error != nil {
+		panic(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return false
+	}
+
+	payment.Status = cancelledId
+	return true
+}
@@ -0,0 +1,577 @@
+package trade
+
+import (
+	"PowerX/internal/model/crm/trade"
+	wechatModel "PowerX/internal/model/wechat"
+	"PowerX/internal/types"
+	"PowerX/internal/types/errorx"
+	"PowerX/internal/uc/powerx"
+	"PowerX/internal/uc/powerx/crm/trade/provider"
+	"PowerX/internal/uc/powerx/wechat"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"github.com/ArtisanCloud/PowerLibs/v3/object"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/kernel/power"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/payment/bill/response"
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type PaymentReconciliationUseCase struct {
+	db           *gorm.DB
+	payment      *PaymentUseCase
+	order        *OrderUseCase
+	notification *wechat.WechatNotificationUseCase
+}
+
+func NewPaymentReconciliationUseCase(db *gorm.DB, payment *PaymentUseCase, order *OrderUseCase,
+	notification *wechat.WechatNotificationUseCase,
+) *PaymentReconciliationUseCase {
+	return &PaymentReconciliationUseCase{
+		db:           db,
+		payment:      payment,
+		order:        order,
+		notification: notification,
+	}
+}
+
+// BillLine 渠道账单中的一笔交易
+type BillLine struct {
+	TradeTime       string
+	ReferenceNumber string
+	PaymentNumber   string
+	TradeState      string
+	Amount          float64
+	RefundNumber    string
+	RefundAmount    float64
+}
+
+const (
+	BillTradeStateSuccess = "SUCCESS"
+	BillTradeStateRefund  = "REFUND"
+	BillTradeStateRevoked = "REVOKED"
+)
+
+const billDateLayout = "2006-01-02"
+
+type FindManyPaymentReconciliationsOption struct {
+	PaymentType string
+	BillDate    string
+	Status      string
+	types.PageEmbedOption
+}
+
+func (uc *PaymentReconciliationUseCase) FindManyPaymentReconciliations(ctx context.Context, opt *FindManyPaymentReconciliationsOption) (pageList types.Page[*trade.PaymentReconciliation], err error) {
+	opt.DefaultPageIfNotSet()
+	var reconciliations []*trade.PaymentReconciliation
+	db := uc.db.WithContext(ctx).Model(&trade.PaymentReconciliation{})
+
+	if opt.PaymentType != "" {
+		db = db.Where("payment_type = ?", opt.PaymentType)
+	}
+	if opt.BillDate != "" {
+		db = db.Where("bill_date = ?", opt.BillDate)
+	}
+	if opt.Status != "" {
+		db = db.Where("status = ?", opt.Status)
+	}
+
+	var count int64
+	if err := db.Count(&count).Error; err != nil {
+		panic(err)
+	}
+
+	if opt.PageIndex != 0 && opt.PageSize != 0 {
+		db.Offset((opt.PageIndex - 1) * opt.PageSize).Limit(opt.PageSize)
+	}
+	if err := db.Order("id desc").Find(&reconciliations).Error; err != nil {
+		panic(err)
+	}
+
+	return types.Page[*trade.PaymentReconciliation]{
+		List:      reconciliations,
+		PageIndex: opt.PageIndex,
+		PageSize:  opt.PageSize,
+		Total:     count,
+	}, nil
+}
+
+// GetPaymentReconciliation 对账报告, results为空时返回全部明细
+func (uc *PaymentReconciliationUseCase) GetPaymentReconciliation(ctx context.Context, id int64, results []string) (*trade.PaymentReconciliation, error) {
+	var reconciliation = &trade.PaymentReconciliation{}
+	err := uc.db.WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			if len(results) > 0 {
+				db = db.Where("result IN ?", results)
+			}
+			return db.Order("id asc")
+		}).
+		First(reconciliation, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, "未找到对账记录")
+		}
+		panic(err)
+	}
+	return reconciliation, nil
+}
+
+// ReconcileWechatBill 下载微信支付交易账单并对账
+func (uc *PaymentReconciliationUseCase) ReconcileWechatBill(ctx context.Context, billDate string) (*trade.PaymentReconciliation, error) {
+	if _, err := time.ParseInLocation(billDateLayout, billDate, time.Local); err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "账单日期格式应为YYYY-MM-DD")
+	}
+
+	content, err := uc.DownloadWechatTradeBill(ctx, billDate)
+	if err != nil {
+		// 下载失败也保留记录, 便于在报告中发现
+		return uc.saveFailedReconciliation(ctx, trade.PaymentTypeWeChat, billDate, trade.ReconciliationSourceDownload, err), err
+	}
+
+	lines, err := ParseWechatTradeBill(content)
+	if err != nil {
+		return uc.saveFailedReconciliation(ctx, trade.PaymentTypeWeChat, billDate, trade.ReconciliationSourceDownload, err), err
+	}
+
+	return uc.ReconcileBill(ctx, trade.PaymentTypeWeChat, billDate, trade.ReconciliationSourceDownload, lines)
+}
+
+// ReconcileUploadedWechatBill 使用商户平台导出的账单文件对账
+func (uc *PaymentReconciliationUseCase) ReconcileUploadedWechatBill(ctx context.Context, billDate string, content []byte) (*trade.PaymentReconciliation, error) {
+	if _, err := time.ParseInLocation(billDateLayout, billDate, time.Local); err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "账单日期格式应为YYYY-MM-DD")
+	}
+
+	lines, err := ParseWechatTradeBill(content)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "账单文件解析失败:"+err.Error())
+	}
+
+	return uc.ReconcileBill(ctx, trade.PaymentTypeWeChat, billDate, trade.ReconciliationSourceUpload, lines)
+}
+
+// DownloadWechatTradeBill 申请并下载某日的全部交易账单(未压缩)
+func (uc *PaymentReconciliationUseCase) DownloadWechatTradeBill(ctx context.Context, billDate string) ([]byte, error) {
+	wxPayment := uc.payment.WXPayment
+	if wxPayment == nil {
+		return nil, errors.New("wechat payment not configured")
+	}
+
+	// 申请交易账单为GET接口
+	result := &response.ResponseBillGet{}
+	_, err := wxPayment.Bill.Request(ctx, wxPayment.Bill.Wrap("/v3/bill/tradebill"), &object.StringMap{
+		"bill_date": billDate,
+		"bill_type": "ALL",
+	}, http.MethodGet, &object.HashMap{}, false, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	if result.DownloadURL == "" {
+		return nil, errors.Errorf("apply trade bill failed: %s %s", result.Code, result.Message)
+	}
+
+	file, err := os.CreateTemp("", "wx-tradebill-*.csv")
+	if err != nil {
+		return nil, err
+	}
+	_ = file.Close()
+	defer os.Remove(file.Name())
+
+	_, err = wxPayment.Bill.DownloadBill(ctx, &power.RequestDownload{
+		HashType:    result.HashType,
+		HashValue:   result.HashValue,
+		DownloadURL: result.DownloadURL,
+	}, file.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(file.Name())
+}
+
+// ReconcileBill 账单逐笔与支付单核对, 并对丢失通知的支付单查单修复
+func (uc *PaymentReconciliationUseCase) ReconcileBill(ctx context.Context, paymentType string, billDate string, source string, lines []*BillLine) (*trade.PaymentReconciliation, error) {
+
+	day, err := time.ParseInLocation(billDateLayout, billDate, time.Local)
+	if err != nil {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "账单日期格式应为YYYY-MM-DD")
+	}
+	paymentTypeId := uc.payment.GetPaymentTypeId(ctx, paymentType)
+	paidStatusId := uc.payment.GetPaymentStatusId(ctx, trade.PaymentStatusPaid)
+
+	// 候选支付单: 账单中出现的, 以及当日在系统中已支付的
+	numbers := []string{}
+	for _, line := range lines {
+		if line.PaymentNumber != "" {
+			numbers = append(numbers, line.PaymentNumber)
+		}
+	}
+	var payments []*trade.Payment
+	db := uc.db.WithContext(ctx).Model(&trade.Payment{}).Preload("Order").
+		Where("payment_type = ?", paymentTypeId)
+	if len(numbers) > 0 {
+		db = db.Where(uc.db.Where("payment_number IN ?", numbers).
+			Or("status = ? AND payment_date >= ? AND payment_date < ?", paidStatusId, day, day.AddDate(0, 0, 1)))
+	} else {
+		db = db.Where("status = ? AND payment_date >= ? AND payment_date < ?", paidStatusId, day, day.AddDate(0, 0, 1))
+	}
+	if err = db.Find(&payments).Error; err != nil {
+		panic(err)
+	}
+
+	ucDD := powerx.NewDataDictionaryUseCase(uc.db)
+	statusKeys := map[int]string{}
+	statusKey := func(payment *trade.Payment) string {
+		if key, ok := statusKeys[payment.Status]; ok {
+			return key
+		}
+		key := ""
+		if item, err := ucDD.GetDataDictionaryItemById(ctx, payment.Status); err == nil {
+			key = item.Key
+		}
+		statusKeys[payment.Status] = key
+		return key
+	}
+
+	items := MatchBillLines(lines, payments, statusKey)
+
+	// 渠道已成功而支付单仍待支付, 多半是通知丢失, 以查单结果为准修复
+	paymentById := map[int64]*trade.Payment{}
+	for _, payment := range payments {
+		paymentById[payment.Id] = payment
+	}
+	for _, item := range items {
+		if item.Result != trade.ReconciliationResultStatusMismatch ||
+			item.BillTradeState != BillTradeStateSuccess || item.PaymentStatus != trade.PaymentStatusPending {
+			continue
+		}
+		if err := uc.healPaidPayment(ctx, paymentById[item.PaymentId]); err != nil {
+			item.Remark = item.Remark + "; 修复失败:" + err.Error()
+			continue
+		}
+		item.Result = trade.ReconciliationResultHealed
+		item.PaymentStatus = trade.PaymentStatusPaid
+	}
+
+	now := time.Now()
+	reconciliation := &trade.PaymentReconciliation{
+		PaymentType: paymentType,
+		BillDate:    billDate,
+		Source:      source,
+		Status:      trade.ReconciliationStatusCompleted,
+		Items:       items,
+		FinishedAt:  &now,
+	}
+	for _, line := range lines {
+		if line.TradeState == BillTradeStateSuccess {
+			reconciliation.BillCount++
+			reconciliation.BillAmount += line.Amount
+		}
+	}
+	for _, payment := range payments {
+		if payment.Status == paidStatusId {
+			reconciliation.PaymentCount++
+			reconciliation.PaymentAmount += payment.PaidAmount
+		}
+	}
+	for _, item := range items {
+		switch item.Result {
+		case trade.ReconciliationResultMatched:
+			reconciliation.MatchedCount++
+		case trade.ReconciliationResultHealed:
+			reconciliation.HealedCount++
+		default:
+			reconciliation.ExceptionCount++
+		}
+	}
+	reconciliation.BillAmount = math.Round(reconciliation.BillAmount*100) / 100
+	reconciliation.PaymentAmount = math.Round(reconciliation.PaymentAmount*100) / 100
+
+	if err = uc.db.WithContext(ctx).Create(reconciliation).Error; err != nil {
+		panic(err)
+	}
+
+	return reconciliation, nil
+}
+
+func (uc *PaymentReconciliationUseCase) healPaidPayment(ctx context.Context, payment *trade.Payment) error {
+	if payment == nil {
+		return errors.New("payment not found")
+	}
+
+	result, err := uc.payment.QueryPaymentTransaction(ctx, payment)
+	if err != nil {
+		return err
+	}
+	if result.State != provider.TransactionStatePaid {
+		return errors.Errorf("transaction state is %s", result.State)
+	}
+	if math.Round(result.Amount*100) != math.Round(payment.PaidAmount*100) {
+		return errors.Errorf("transaction amount %.2f differs from payment", result.Amount)
+	}
+
+	// 期间通知到达已处理, 不再重复推进订单
+	if !uc.payment.MarkPaymentPaidFromPending(ctx, payment, result.ReferenceNumber, result.PaidAt) {
+		return nil
+	}
+
+	// order如果状态修改出错，可以在另外的机制处理，不能干预payment的记录状态
+	if payment.Order != nil {
+		settled, err := uc.payment.SettleOrderAfterPaid(ctx, payment.Order)
+		if err != nil {
+			logx.Errorf("对账修复-记录订单状态跳变:%s,错误信息：%s", payment.PaymentNumber, err.Error())
+		} else if settled {
+			uc.notification.NotifyOrderEvent(wechatModel.NotificationEventOrderPaid, payment.OrderId, nil)
+		}
+	}
+
+	return nil
+}
+
+func (uc *PaymentReconciliationUseCase) saveFailedReconciliation(ctx context.Context, paymentType string, billDate string, source string, cause error) *trade.PaymentReconciliation {
+	now := time.Now()
+	reconciliation := &trade.PaymentReconciliation{
+		PaymentType:  paymentType,
+		BillDate:     billDate,
+		Source:       source,
+		Status:       trade.ReconciliationStatusFailed,
+		ErrorMessage: cause.Error(),
+		FinishedAt:   &now,
+	}
+	if err := uc.db.WithContext(ctx).Create(reconciliation).Error; err != nil {
+		panic(err)
+	}
+	return reconciliation
+}
+
+// Schedule 每天10点核对前一日的微信支付账单(微信9点后生成账单)
+func (uc *PaymentReconciliationUseCase) Schedule(c *cron.Cron) {
+	if uc.payment.WXPayment == nil {
+		return
+	}
+	_, _ = c.AddFunc(`0 10 * * *`, func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logx.Errorf("payment reconciliation panic: %v", r)
+			}
+		}()
+		billDate := time.Now().AddDate(0, 0, -1).Format(billDateLayout)
+		if _, err := uc.ReconcileWechatBill(context.Background(), billDate); err != nil {
+			logx.Errorf("payment reconciliation %s failed: %v", billDate, err)
+		}
+	})
+}
+
+// MatchBillLines
+//
+//	@Description: 账单与支付单逐笔核对
+//	@param lines 账单交易
+//	@param payments 候选支付单
+//	@param statusKey 支付单状态Key
+//	@return []*trade.PaymentReconciliationItem
+func MatchBillLines(lines []*BillLine, payments []*trade.Payment, statusKey func(payment *trade.Payment) string) []*trade.PaymentReconciliationItem {
+
+	byNumber := map[string]*trade.Payment{}
+	byReference := map[string]*trade.Payment{}
+	for _, payment := range payments {
+		byNumber[payment.PaymentNumber] = payment
+		if payment.ReferenceNumber != "" {
+			byReference[payment.ReferenceNumber] = payment
+		}
+	}
+	find := func(line *BillLine) *trade.Payment {
+		if payment, ok := byNumber[line.PaymentNumber]; ok {
+			return payment
+		}
+		return byReference[line.ReferenceNumber]
+	}
+
+	// 按支付单号与交易状态分组, 保留账单顺序
+	type group struct {
+		state string
+		lines []*BillLine
+	}
+	var groups []*group
+	index := map[string]*group{}
+	for _, line := range lines {
+		key := line.TradeState + "|" + line.PaymentNumber
+		if line.PaymentNumber == "" {
+			key = line.TradeState + "|ref:" + line.ReferenceNumber
+		}
+		g, ok := index[key]
+		if !ok {
+			g = &group{state: line.TradeState}
+			index[key] = g
+			groups = append(groups, g)
+		}
+		g.lines = append(g.lines, line)
+	}
+
+	cent := func(amount float64) int64 {
+		return int64(math.Round(amount * 100))
+	}
+
+	seen := map[int64]bool{}
+	items := []*trade.PaymentReconciliationItem{}
+	for _, g := range groups {
+		line := g.lines[0]
+		item := &trade.PaymentReconciliationItem{
+			PaymentNumber:   line.PaymentNumber,
+			ReferenceNumber: line.ReferenceNumber,
+			BillTradeState:  g.state,
+			BillAmount:      line.Amount,
+		}
+		for _, l := range g.lines {
+			item.RefundAmount += l.RefundAmount
+		}
+		items = append(items, item)
+
+		payment := find(line)
+		if payment == nil {
+			item.Result = trade.ReconciliationResultMissingPayment
+			item.Remark = "渠道有交易, 系统无支付单"
+			continue
+		}
+		item.PaymentId = payment.Id
+		item.PaymentAmount = payment.PaidAmount
+		item.PaymentStatus = statusKey(payment)
+		if g.state == BillTradeStateSuccess {
+			seen[payment.Id] = true
+		}
+
+		switch g.state {
+		case BillTradeStateSuccess:
+			switch {
+			case len(g.lines) > 1:
+				item.Result = trade.ReconciliationResultDuplicate
+				item.Remark = fmt.Sprintf("账单中有%d笔成功交易", len(g.lines))
+			case cent(line.Amount) != cent(payment.PaidAmount):
+				item.Result = trade.ReconciliationResultAmountMismatch
+				item.Remark = fmt.Sprintf("账单金额%.2f, 支付单金额%.2f", line.Amount, payment.PaidAmount)
+			case item.PaymentStatus == trade.PaymentStatusPaid || item.PaymentStatus == trade.PaymentStatusRefunded:
+				item.Result = trade.ReconciliationResultMatched
+			default:
+				item.Result = trade.ReconciliationResultStatusMismatch
+				item.Remark = "渠道已支付, 支付单状态为" + item.PaymentStatus
+			}
+
+		case BillTradeStateRefund:
+			if cent(item.RefundAmount) >= cent(payment.PaidAmount) && item.PaymentStatus != trade.PaymentStatusRefunded {
+				item.Result = trade.ReconciliationResultStatusMismatch
+				item.Remark = "渠道已全额退款, 支付单状态为" + item.PaymentStatus
+			} else {
+				item.Result = trade.ReconciliationResultMatched
+			}
+
+		default:
+			if item.PaymentStatus == trade.PaymentStatusPaid {
+				item.Result = trade.ReconciliationResultStatusMismatch
+				item.Remark = "渠道交易已撤销, 支付单状态为已支付"
+			} else {
+				item.Result = trade.ReconciliationResultMatched
+			}
+		}
+	}
+
+	// 系统已支付但账单中没有成功交易
+	for _, payment := range payments {
+		if seen[payment.Id] || statusKey(payment) != trade.PaymentStatusPaid {
+			continue
+		}
+		if _, inBill := index[BillTradeStateRefund+"|"+payment.PaymentNumber]; inBill {
+			continue
+		}
+		items = append(items, &trade.PaymentReconciliationItem{
+			PaymentId:       payment.Id,
+			PaymentNumber:   payment.PaymentNumber,
+			ReferenceNumber: payment.ReferenceNumber,
+			PaymentAmount:   payment.PaidAmount,
+			PaymentStatus:   trade.PaymentStatusPaid,
+			Result:          trade.ReconciliationResultMissingBill,
+			Remark:          "支付单已支付, 渠道账单无交易",
+		})
+	}
+
+	return items
+}
+
+// ParseWechatTradeBill
+//
+//	@Description: 解析微信支付交易账单(ALL), 字段值以`开头, 末尾为汇总数据
+//	@param content
+//	@return []*BillLine
+//	@return error
+func ParseWechatTradeBill(content []byte) ([]*BillLine, error) {
+
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "read bill header failed")
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range []string{"微信订单号", "商户订单号", "交易状态"} {
+		if _, ok := columns[name]; !ok {
+			return nil, errors.Errorf("bill column %s not found", name)
+		}
+	}
+	amountColumn := "订单金额"
+	if _, ok := columns[amountColumn]; !ok {
+		amountColumn = "应结订单金额"
+	}
+
+	lines := []*BillLine{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		// 汇总区
+		if len(record) > 0 && strings.HasPrefix(strings.TrimSpace(record[0]), "总") {
+			break
+		}
+		value := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(record[i]), "`"))
+		}
+		amount := func(name string) float64 {
+			f, _ := strconv.ParseFloat(value(name), 64)
+			return f
+		}
+
+		lines = append(lines, &BillLine{
+			TradeTime:       value("交易时间"),
+			ReferenceNumber: value("微信订单号"),
+			PaymentNumber:   value("商户订单号"),
+			TradeState:      value("交易状态"),
+			Amount:          amount(amountColumn),
+			RefundNumber:    value("商户退款单号"),
+			RefundAmount:    amount("退款金额"),
+		})
+	}
+
+	return lines, nil
+}
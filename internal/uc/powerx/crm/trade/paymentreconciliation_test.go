@@ -0,0 +1,82 @@
+package trade
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/model/powermodel"
+	"testing"
+)
+
+const wechatTradeBill = "\xef\xbb\xbf交易时间,公众账号ID,商户号,特约商户号,设备号,微信订单号,商户订单号,用户标识,交易类型,交易状态,付款银行,货币种类,应结订单金额,代金券金额,微信退款单号,商户退款单号,退款金额,充值券退款金额,退款类型,退款状态,商品名称,商户数据包,手续费,费率,订单金额,申请退款金额,费率备注\n" +
+	"`2023-10-01 10:00:00,`wx1,`1600000000,`0,`,`4200001,`PO1,`o1,`JSAPI,`SUCCESS,`OTHERS,`CNY,`9.90,`0.00,`0,`0,`0.00,`0.00,`,`,`商品,`,`0.05000,`0.60%,`9.90,`0.00,`\n" +
+	"`2023-10-01 11:00:00,`wx1,`1600000000,`0,`,`4200002,`PO2,`o2,`JSAPI,`SUCCESS,`OTHERS,`CNY,`20.00,`0.00,`0,`0,`0.00,`0.00,`,`,`商品,`,`0.12000,`0.60%,`20.00,`0.00,`\n" +
+	"`2023-10-01 12:00:00,`wx1,`1600000000,`0,`,`4200003,`PO3,`o3,`JSAPI,`REFUND,`OTHERS,`CNY,`0.00,`0.00,`5000001,`RF3,`5.00,`0.00,`ORIGINAL,`SUCCESS,`商品,`,`-0.03000,`0.60%,`5.00,`5.00,`\n" +
+	"总交易单数,应结订单总金额,退款总金额,充值券退款总金额,手续费总金额,订单总金额,申请退款总金额\n" +
+	"`3,`29.90,`5.00,`0.00,`0.14000,`34.90,`5.00\n"
+
+func TestParseWechatTradeBill(t *testing.T) {
+
+	lines, err := ParseWechatTradeBill([]byte(wechatTradeBill))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("lines = %d, want 3", len(lines))
+	}
+	if lines[0].PaymentNumber != "PO1" || lines[0].ReferenceNumber != "4200001" || lines[0].TradeState != BillTradeStateSuccess || lines[0].Amount != 9.9 {
+		t.Errorf("line 0 = %+v", lines[0])
+	}
+	if lines[2].TradeState != BillTradeStateRefund || lines[2].RefundAmount != 5 || lines[2].RefundNumber != "RF3" {
+		t.Errorf("line 2 = %+v", lines[2])
+	}
+
+	if _, err = ParseWechatTradeBill([]byte("a,b,c\n1,2,3\n")); err == nil {
+		t.Errorf("bill without required columns should fail")
+	}
+}
+
+func TestMatchBillLines(t *testing.T) {
+
+	payment := func(id int64, number string, amount float64, status int) *trade.Payment {
+		return &trade.Payment{PowerModel: &powermodel.PowerModel{Id: id}, PaymentNumber: number, PaidAmount: amount, Status: status}
+	}
+	const pending, paid, refunded = 1, 2, 3
+	keys := map[int]string{pending: trade.PaymentStatusPending, paid: trade.PaymentStatusPaid, refunded: trade.PaymentStatusRefunded}
+	statusKey := func(p *trade.Payment) string { return keys[p.Status] }
+
+	lines := []*BillLine{
+		{PaymentNumber: "PO1", ReferenceNumber: "R1", TradeState: BillTradeStateSuccess, Amount: 9.9},
+		{PaymentNumber: "PO2", ReferenceNumber: "R2", TradeState: BillTradeStateSuccess, Amount: 20},
+		{PaymentNumber: "PO3", ReferenceNumber: "R3", TradeState: BillTradeStateSuccess, Amount: 5},
+		{PaymentNumber: "PO4", ReferenceNumber: "R4", TradeState: BillTradeStateSuccess, Amount: 1},
+		{PaymentNumber: "PO4", ReferenceNumber: "R4", TradeState: BillTradeStateSuccess, Amount: 1},
+		{PaymentNumber: "PO9", ReferenceNumber: "R9", TradeState: BillTradeStateSuccess, Amount: 3},
+		{PaymentNumber: "PO5", ReferenceNumber: "R5", TradeState: BillTradeStateRefund, RefundAmount: 8},
+	}
+	payments := []*trade.Payment{
+		payment(1, "PO1", 9.9, paid),
+		payment(2, "PO2", 19.9, paid),
+		payment(3, "PO3", 5, pending),
+		payment(4, "PO4", 1, paid),
+		payment(5, "PO5", 8, paid),
+		payment(6, "PO6", 7, paid),
+	}
+
+	items := MatchBillLines(lines, payments, statusKey)
+	want := map[string]string{
+		"PO1": trade.ReconciliationResultMatched,
+		"PO2": trade.ReconciliationResultAmountMismatch,
+		"PO3": trade.ReconciliationResultStatusMismatch,
+		"PO4": trade.ReconciliationResultDuplicate,
+		"PO9": trade.ReconciliationResultMissingPayment,
+		"PO5": trade.ReconciliationResultStatusMismatch,
+		"PO6": trade.ReconciliationResultMissingBill,
+	}
+	if len(items) != len(want) {
+		t.Fatalf("items = %d, want %d", len(items), len(want))
+	}
+	for _, item := range items {
+		if item.Result != want[item.PaymentNumber] {
+			t.Errorf("%s: result = %s, want %s", item.PaymentNumber, item.Result, want[item.PaymentNumber])
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package trade
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/types/errorx"
+	"PowerX/internal/uc/powerx/crm/trade/provider"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"math"
+	"time"
+)
+
+// 占用支付单可退金额的退款状态, 失败的退款释放占用
+var activeRefundStatuses = []trade.RefundStatus{
+	trade.RefundStatusPending,
+	trade.RefundStatusProcessed,
+	trade.RefundStatusCompleted,
+}
+
+// GetRefundableAmount 按分计算支付单剩余可退金额, refundAmounts为处理中及已完成的退款
+func GetRefundableAmount(paidAmount float64, refundAmounts []float64) float64 {
+	refundable := int64(math.Round(paidAmount * 100))
+	for _, amount := range refundAmounts {
+		refundable -= int64(math.Round(amount * 100))
+	}
+	if refundable < 0 {
+		refundable = 0
+	}
+	return float64(refundable) / 100
+}
+
+// lockPayment 在事务内锁定支付单, 同一支付单的退款串行处理
+func lockPayment(tx *gorm.DB, paymentId int64) (*trade.Payment, error) {
+	payment := &trade.Payment{}
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(payment, paymentId).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "未找到支付单")
+	}
+	return payment, err
+}
+
+func findActiveRefundAmounts(tx *gorm.DB, paymentId int64) ([]float64, error) {
+	var amounts []float64
+	err := tx.Model(&trade.RefundOrder{}).
+		Where("payment_id = ? AND refund_status IN ?", paymentId, activeRefundStatuses).
+		Pluck("refund_amount", &amounts).Error
+	return amounts, err
+}
+
+// RefundPayment
+//
+//	@Description: 登记退款单并向支付渠道申请退款, 退款金额不能超过支付单剩余可退金额; 支付单的累计退款以退款结果(同步结果或渠道通知)为准
+//	@receiver uc
+//	@param ctx
+//	@param payment
+//	@param amount
+//	@param reason
+//	@return *provider.RefundResult
+//	@return error
+func (uc *PaymentUseCase) RefundPayment(ctx context.Context, payment *trade.Payment, amount float64, reason string) (*provider.RefundResult, error) {
+	payProvider, err := uc.GetProvider(ctx, payment.PaymentType)
+	if err != nil {
+		return nil, err
+	}
+	amount = math.Round(amount*100) / 100
+	if amount <= 0 {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "退款金额不正确")
+	}
+
+	refundOrder := &trade.RefundOrder{
+		OrderId:        payment.OrderId,
+		PaymentId:      payment.Id,
+		RefundNumber:   GenerateRefundNumber(),
+		RefundStatus:   trade.RefundStatusProcessed,
+		RefundAmount:   amount,
+		RefundReason:   reason,
+		RefundApproved: true,
+	}
+	err = uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		locked, err := lockPayment(tx, payment.Id)
+		if err != nil {
+			return err
+		}
+		if !uc.IsPaymentStatusSameAs(ctx, locked, trade.PaymentStatusPaid) {
+			return errorx.WithCause(errorx.ErrBadRequest, "只能对已支付的支付单退款")
+		}
+		amounts, err := findActiveRefundAmounts(tx, locked.Id)
+		if err != nil {
+			return err
+		}
+		refundable := GetRefundableAmount(locked.PaidAmount, amounts)
+		if math.Round(amount*100) > math.Round(refundable*100) {
+			return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("退款金额超过可退金额%.2f", refundable))
+		}
+
+		var order trade.Order
+		if err = tx.Select("id", "customer_id").First(&order, locked.OrderId).Error; err == nil {
+			refundOrder.CustomerId = order.CustomerId
+		}
+		return tx.Omit(clause.Associations).Create(refundOrder).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := payProvider.Refund(ctx, &provider.Refund{
+		PaymentNumber: payment.PaymentNumber,
+		RefundNumber:  refundOrder.RefundNumber,
+		Amount:        amount,
+		TotalAmount:   payment.PaidAmount,
+		Reason:        reason,
+	})
+	if err != nil {
+		uc.updateRefundOrderStatus(ctx, refundOrder, trade.RefundStatusFailed)
+		return nil, err
+	}
+	if result.State == provider.RefundStateFailed {
+		uc.updateRefundOrderStatus(ctx, refundOrder, trade.RefundStatusFailed)
+	}
+
+	return result, nil
+}
+
+func (uc *PaymentUseCase) updateRefundOrderStatus(ctx context.Context, refundOrder *trade.RefundOrder, status trade.RefundStatus) {
+	refundOrder.RefundStatus = status
+	err := uc.db.WithContext(ctx).Model(&trade.RefundOrder{}).
+		Where("id = ?", refundOrder.Id).
+		Update("refund_status", status).Error
+	if err != nil {
+		panic(errors.Wrap(err, "update refund order status failed"))
+	}
+}
+
+// ApplyRefundResult
+//
+//	@Description: 按退款结果更新退款单及支付单的累计退款金额, 并记录退款在订单项及组件明细的分摊; 累计退款达到支付金额时支付单变为已退款; 重复的退款结果不再累计
+//	@receiver uc
+//	@param ctx
+//	@param result
+//	@return payment
+//	@return applied 本次调用是否累计了退款金额
+//	@return err
+func (uc *PaymentUseCase) ApplyRefundResult(ctx context.Context, result *provider.RefundResult) (payment *trade.Payment, applied bool, err error) {
+	payment, err = uc.GetPaymentByNumber(ctx, result.PaymentNumber)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		locked, err := lockPayment(tx, payment.Id)
+		if err != nil {
+			return err
+		}
+
+		refundOrder := &trade.RefundOrder{}
+		err = tx.Where("payment_id = ? AND refund_number = ?", locked.Id, result.RefundNumber).First(refundOrder).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 在渠道商户后台发起的退款, 按通知补登记退款单
+			refundOrder = &trade.RefundOrder{
+				OrderId:        locked.OrderId,
+				PaymentId:      locked.Id,
+				RefundNumber:   result.RefundNumber,
+				RefundStatus:   trade.RefundStatusProcessed,
+				RefundAmount:   result.Amount,
+				RefundReason:   "渠道发起的退款",
+				RefundApproved: true,
+			}
+			if payment.Order != nil {
+				refundOrder.CustomerId = payment.Order.CustomerId
+			}
+			if err = tx.Omit(clause.Associations).Create(refundOrder).Error; err != nil {
+				return err
+			}
+		}
+		if refundOrder.RefundStatus == trade.RefundStatusCompleted || refundOrder.RefundStatus == trade.RefundStatusFailed {
+			return nil
+		}
+
+		if result.State == provider.RefundStateFailed {
+			return tx.Model(refundOrder).Update("refund_status", trade.RefundStatusFailed).Error
+		}
+		if result.State != provider.RefundStateSuccess {
+			return nil
+		}
+
+		refunded := math.Round((locked.RefundedAmount+refundOrder.RefundAmount)*100) / 100
+		if math.Round(refunded*100) > math.Round(locked.PaidAmount*100) {
+			return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("支付单%s累计退款%.2f超过支付金额%.2f", locked.PaymentNumber, refunded, locked.PaidAmount))
+		}
+		err = tx.Model(refundOrder).Updates(map[string]interface{}{
+			"refund_status": trade.RefundStatusCompleted,
+			"refund_date":   time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+		if err = recordRefundAllocations(ctx, tx, refundOrder); err != nil {
+			return err
+		}
+
+		columns := map[string]interface{}{"refunded_amount": refunded}
+		status := locked.Status
+		if math.Round(refunded*100) >= math.Round(locked.PaidAmount*100) && uc.IsPaymentStatusSameAs(ctx, locked, trade.PaymentStatusPaid) {
+			status = uc.GetPaymentStatusId(ctx, trade.PaymentStatusRefunded)
+			columns["status"] = status
+		}
+		if err = tx.Model(&trade.Payment{}).Where("id = ?", locked.Id).Updates(columns).Error; err != nil {
+			return err
+		}
+
+		payment.RefundedAmount = refunded
+		payment.Status = status
+		applied = true
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return payment, applied, nil
+}
@@ -0,0 +1,36 @@
+package trade
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"testing"
+)
+
+func TestGetRefundableAmount(t *testing.T) {
+
+	// 100代币已退50, 再退50后不能继续退款
+	if got := GetRefundableAmount(100, []float64{50}); got != 50 {
+		t.Errorf("refundable = %v", got)
+	}
+	if got := GetRefundableAmount(100, []float64{50, 50}); got != 0 {
+		t.Errorf("refundable = %v", got)
+	}
+
+	// 按分计算, 避免浮点误差
+	if got := GetRefundableAmount(0.3, []float64{0.1, 0.1}); got != 0.1 {
+		t.Errorf("refundable = %v", got)
+	}
+	if got := GetRefundableAmount(10, []float64{20}); got != 0 {
+		t.Errorf("refundable = %v", got)
+	}
+}
+
+func TestSummarizeOrderPaymentsWithPartialRefund(t *testing.T) {
+
+	// 已支付的支付单部分退款后, 订单已付金额扣除退款
+	summary := SummarizeOrderPayments(100, []PaymentAmount{
+		{Status: trade.PaymentStatusPaid, Amount: 100, RefundedAmount: 30},
+	})
+	if summary.PaidAmount != 70 || summary.RefundedAmount != 30 || summary.OutstandingAmount != 30 {
+		t.Errorf("summary = %+v", summary)
+	}
+}
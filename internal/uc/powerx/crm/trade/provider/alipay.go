@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"PowerX/internal/config"
+	"PowerX/internal/model/crm/trade"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type alipayProvider struct {
+	client *alipayClient
+}
+
+// NewAlipayProvider
+//
+//	@Description: 支付宝手机网站支付
+//	@param conf
+//	@return IPaymentProviderInterface
+//	@return error
+func NewAlipayProvider(conf config.Alipay) (IPaymentProviderInterface, error) {
+	client, err := newAlipayClient(conf.GatewayUrl, conf.AppId, conf.PrivateKey, conf.AlipayPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	client.notifyUrl = conf.NotifyUrl
+	client.returnUrl = conf.ReturnUrl
+	client.debug = conf.HttpDebug
+
+	return &alipayProvider{client: client}, nil
+}
+
+func (p *alipayProvider) Name() string {
+	return trade.PaymentTypeAlipay
+}
+
+func (p *alipayProvider) CreateTransaction(ctx context.Context, transaction *Transaction) (*Prepay, error) {
+
+	subject := transaction.Description
+	if subject == "" {
+		subject = transaction.PaymentNumber
+	}
+
+	params := map[string]string{
+		`out_trade_no`: transaction.PaymentNumber,
+		`total_amount`: formatAlipayAmount(transaction.Amount),
+		`subject`:      subject,
+		`product_code`: `QUICK_WAP_WAY`,
+	}
+	if !transaction.ExpireAt.IsZero() {
+		params[`time_expire`] = transaction.ExpireAt.Format(`2006-01-02 15:04:05`)
+	}
+	payUrl, err := p.client.pageUrl(`alipay.trade.wap.pay`, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Prepay{
+		Data: map[string]string{`payUrl`: payUrl},
+	}, nil
+}
+
+func (p *alipayProvider) QueryTransaction(ctx context.Context, paymentNumber string) (*TransactionResult, error) {
+
+	reply := struct {
+		TradeNo     string `json:"trade_no"`
+		OutTradeNo  string `json:"out_trade_no"`
+		TradeStatus string `json:"trade_status"`
+		TotalAmount string `json:"total_amount"`
+		SendPayDate string `json:"send_pay_date"`
+	}{}
+	err := p.client.do(ctx, `alipay.trade.query`, map[string]string{`out_trade_no`: paymentNumber}, &reply)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, _ := strconv.ParseFloat(reply.TotalAmount, 64)
+	paidAt, _ := time.ParseInLocation(`2006-01-02 15:04:05`, reply.SendPayDate, time.Local)
+
+	return &TransactionResult{
+		PaymentNumber:   paymentNumber,
+		ReferenceNumber: reply.TradeNo,
+		State:           alipayTradeState(reply.TradeStatus),
+		Amount:          amount,
+		PaidAt:          paidAt,
+	}, nil
+}
+
+func (p *alipayProvider) CloseTransaction(ctx context.Context, paymentNumber string) error {
+	reply := struct{}{}
+	return p.client.do(ctx, `alipay.trade.close`, map[string]string{`out_trade_no`: paymentNumber}, &reply)
+}
+
+func (p *alipayProvider) Refund(ctx context.Context, refund *Refund) (*RefundResult, error) {
+
+	reply := struct {
+		TradeNo    string `json:"trade_no"`
+		FundChange string `json:"fund_change"`
+		RefundFee  string `json:"refund_fee"`
+	}{}
+	err := p.client.do(ctx, `alipay.trade.refund`, map[string]string{
+		`out_trade_no`:   refund.PaymentNumber,
+		`out_request_no`: refund.RefundNumber,
+		`refund_amount`:  formatAlipayAmount(refund.Amount),
+		`refund_reason`:  refund.Reason,
+	}, &reply)
+	if err != nil {
+		return nil, err
+	}
+
+	// 支付宝退款为同步结果, fund_change=Y 表示本次退款资金已变化
+	state := RefundStateProcessing
+	if reply.FundChange == `Y` {
+		state = RefundStateSuccess
+	}
+
+	return &RefundResult{
+		PaymentNumber:   refund.PaymentNumber,
+		RefundNumber:    refund.RefundNumber,
+		ReferenceNumber: reply.TradeNo,
+		State:           state,
+		Amount:          refund.Amount,
+	}, nil
+}
+
+func (p *alipayProvider) HandleNotification(w http.ResponseWriter, r *http.Request, handle func(notification *Notification) error) (err error) {
+
+	defer func() {
+		// 应答非success时支付宝会重新通知
+		if err != nil {
+			_, _ = fmt.Fprint(w, `fail`)
+		}
+	}()
+
+	if err = r.ParseForm(); err != nil {
+		return err
+	}
+	if err = p.client.verifyNotification(r.PostForm); err != nil {
+		return errors.Wrap(err, "alipay notification verify failed")
+	}
+
+	form := r.PostForm
+	notification := &Notification{NotifyId: form.Get(`notify_id`)}
+	if form.Get(`refund_fee`) != `` && form.Get(`out_biz_no`) != `` {
+		// 退款成功同样通过交易异步通知告知
+		refundFee, _ := strconv.ParseFloat(form.Get(`refund_fee`), 64)
+		notification.Event = NotificationEventRefunded
+		notification.Refund = &RefundResult{
+			PaymentNumber:   form.Get(`out_trade_no`),
+			RefundNumber:    form.Get(`out_biz_no`),
+			ReferenceNumber: form.Get(`trade_no`),
+			State:           RefundStateSuccess,
+			Amount:          refundFee,
+		}
+	} else {
+		amount, _ := strconv.ParseFloat(form.Get(`total_amount`), 64)
+		paidAt, _ := time.ParseInLocation(`2006-01-02 15:04:05`, form.Get(`gmt_payment`), time.Local)
+		transaction := &TransactionResult{
+			PaymentNumber:   form.Get(`out_trade_no`),
+			ReferenceNumber: form.Get(`trade_no`),
+			State:           alipayTradeState(form.Get(`trade_status`)),
+			Amount:          amount,
+			Currency:        `CNY`,
+			PaidAt:          paidAt,
+		}
+		notification.Transaction = transaction
+		switch transaction.State {
+		case TransactionStatePaid:
+			notification.Event = NotificationEventPaid
+		case TransactionStateClosed:
+			notification.Event = NotificationEventClosed
+		default:
+			// 等待付款等中间状态无需处理
+			_, _ = fmt.Fprint(w, `success`)
+			return nil
+		}
+	}
+
+	// 处理失败已经记录在通知中, 不再作为解析错误返回
+	reply := `success`
+	if handle(notification) != nil {
+		reply = `fail`
+	}
+	_, _ = fmt.Fprint(w, reply)
+	return nil
+}
+
+func alipayTradeState(status string) TransactionState {
+	switch status {
+	case `TRADE_SUCCESS`, `TRADE_FINISHED`:
+		return TransactionStatePaid
+	case `TRADE_CLOSED`:
+		return TransactionStateClosed
+	}
+	return TransactionStatePending
+}
+
+func formatAlipayAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
@@ -0,0 +1,321 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ResponseAlipay
+// @Description: 支付宝开放平台接口公共返回
+type ResponseAlipay struct {
+	Code    string `json:"code"`
+	Msg     string `json:"msg"`
+	SubCode string `json:"sub_code,omitempty"`
+	SubMsg  string `json:"sub_msg,omitempty"`
+}
+
+// Error
+//
+//	@Description: code 10000 表示调用成功
+//	@receiver r
+//	@return error
+func (r ResponseAlipay) Error() error {
+	if r.Code == `10000` {
+		return nil
+	}
+	return fmt.Errorf(`alipay code: %s, msg: %s, sub_code: %s, sub_msg: %s`, r.Code, r.Msg, r.SubCode, r.SubMsg)
+}
+
+type alipayClient struct {
+	gatewayUrl string
+	appId      string
+	notifyUrl  string
+	returnUrl  string
+	privateKey *rsa.PrivateKey
+	publicKey  *rsa.PublicKey
+	debug      bool
+	http       *http.Client
+	now        func() time.Time
+}
+
+func newAlipayClient(gatewayUrl, appId, privateKey, alipayPublicKey string) (*alipayClient, error) {
+
+	priKey, err := parseAlipayPrivateKey(privateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse alipay app private key failed")
+	}
+	pubKey, err := parseAlipayPublicKey(alipayPublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse alipay public key failed")
+	}
+	if gatewayUrl == `` {
+		gatewayUrl = `https://openapi.alipay.com/gateway.do`
+	}
+
+	return &alipayClient{
+		gatewayUrl: gatewayUrl,
+		appId:      appId,
+		privateKey: priKey,
+		publicKey:  pubKey,
+		http:       &http.Client{Timeout: 30 * time.Second},
+		now:        time.Now,
+	}, nil
+}
+
+// commonParams
+//
+//	@Description: 公共请求参数并签名
+//	@receiver c
+//	@param method
+//	@param biz 业务参数
+//	@return url.Values
+//	@return error
+func (c *alipayClient) commonParams(method string, biz interface{}) (url.Values, error) {
+
+	bizContent, err := json.Marshal(biz)
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{
+		`app_id`:      {c.appId},
+		`method`:      {method},
+		`format`:      {`JSON`},
+		`charset`:     {`utf-8`},
+		`sign_type`:   {`RSA2`},
+		`timestamp`:   {c.now().Format(`2006-01-02 15:04:05`)},
+		`version`:     {`1.0`},
+		`biz_content`: {string(bizContent)},
+	}
+	if c.notifyUrl != `` {
+		params.Set(`notify_url`, c.notifyUrl)
+	}
+	if c.returnUrl != `` && strings.HasSuffix(method, `.pay`) {
+		params.Set(`return_url`, c.returnUrl)
+	}
+
+	sign, err := c.sign(alipaySignContent(params))
+	if err != nil {
+		return nil, err
+	}
+	params.Set(`sign`, sign)
+
+	return params, nil
+}
+
+// pageUrl
+//
+//	@Description: 跳转类接口(如手机网站支付)只需生成带签名的网关地址
+//	@receiver c
+//	@param method
+//	@param biz
+//	@return string
+//	@return error
+func (c *alipayClient) pageUrl(method string, biz interface{}) (string, error) {
+	params, err := c.commonParams(method, biz)
+	if err != nil {
+		return ``, err
+	}
+	return c.gatewayUrl + `?` + params.Encode(), nil
+}
+
+// do
+//
+//	@Description: 调用支付宝接口并验签返回
+//	@receiver c
+//	@param ctx
+//	@param method
+//	@param biz
+//	@param out 响应节点
+//	@return error
+func (c *alipayClient) do(ctx context.Context, method string, biz interface{}, out interface{}) error {
+
+	params, err := c.commonParams(method, biz)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.gatewayUrl, strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(`Content-Type`, `application/x-www-form-urlencoded;charset=utf-8`)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if c.debug {
+		logx.Infof(`alipay %s response: %s`, method, string(body))
+	}
+
+	reply := map[string]json.RawMessage{}
+	if err = json.Unmarshal(body, &reply); err != nil {
+		return errors.Wrapf(err, `alipay %s decode failed`, method)
+	}
+	node, ok := reply[strings.ReplaceAll(method, `.`, `_`)+`_response`]
+	if !ok {
+		node, ok = reply[`error_response`]
+		if !ok {
+			return fmt.Errorf(`alipay %s unexpected response: %s`, method, string(body))
+		}
+	}
+
+	// 签名覆盖响应节点的原始JSON文本
+	var sign string
+	_ = json.Unmarshal(reply[`sign`], &sign)
+	if sign != `` {
+		if err = c.verify(string(node), sign); err != nil {
+			return errors.Wrapf(err, `alipay %s response verify failed`, method)
+		}
+	}
+
+	base := ResponseAlipay{}
+	if err = json.Unmarshal(node, &base); err != nil {
+		return err
+	}
+	if err = base.Error(); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(node, out)
+}
+
+func (c *alipayClient) sign(content string) (string, error) {
+	hashed := sha256.Sum256([]byte(content))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, c.privateKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		return ``, err
+	}
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+func (c *alipayClient) verify(content string, sign string) error {
+	signature, err := base64.StdEncoding.DecodeString(sign)
+	if err != nil {
+		return err
+	}
+	hashed := sha256.Sum256([]byte(content))
+	return rsa.VerifyPKCS1v15(c.publicKey, crypto.SHA256, hashed[:], signature)
+}
+
+// verifyNotification
+//
+//	@Description: 异步通知验签, 剔除sign与sign_type后按参数名排序拼接
+//	@receiver c
+//	@param form
+//	@return error
+func (c *alipayClient) verifyNotification(form url.Values) error {
+	sign := form.Get(`sign`)
+	if sign == `` {
+		return errors.New(`alipay notification missing sign`)
+	}
+	if form.Get(`app_id`) != `` && form.Get(`app_id`) != c.appId {
+		return errors.New(`alipay notification app_id mismatch`)
+	}
+	params := url.Values{}
+	for key, values := range form {
+		if key == `sign_type` {
+			continue
+		}
+		params[key] = values
+	}
+	return c.verify(alipaySignContent(params), sign)
+}
+
+// alipaySignContent
+//
+//	@Description: 待签名字符串, 参数名升序, 忽略sign与空值
+//	@param params
+//	@return string
+func alipaySignContent(params url.Values) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		if key == `sign` || params.Get(key) == `` {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte('&')
+		}
+		buf.WriteString(key)
+		buf.WriteByte('=')
+		buf.WriteString(params.Get(key))
+	}
+	return buf.String()
+}
+
+// 支付宝后台生成的密钥通常不带PEM头
+func decodeAlipayKey(key string, blockType string) ([]byte, error) {
+	key = strings.TrimSpace(key)
+	if !strings.HasPrefix(key, `-----BEGIN`) {
+		key = fmt.Sprintf("-----BEGIN %s-----\n%s\n-----END %s-----", blockType, key, blockType)
+	}
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, errors.New(`invalid pem key`)
+	}
+	return block.Bytes, nil
+}
+
+func parseAlipayPrivateKey(key string) (*rsa.PrivateKey, error) {
+	der, err := decodeAlipayKey(key, `PRIVATE KEY`)
+	if err != nil {
+		return nil, err
+	}
+	if priKey, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return priKey, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+	priKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New(`alipay private key is not rsa`)
+	}
+	return priKey, nil
+}
+
+func parseAlipayPublicKey(key string) (*rsa.PublicKey, error) {
+	der, err := decodeAlipayKey(key, `PUBLIC KEY`)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New(`alipay public key is not rsa`)
+	}
+	return pubKey, nil
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// FakeProvider
+// @Description: 内存支付渠道, 供自动化测试使用(Payment.Fake), 不访问任何外部服务
+type FakeProvider struct {
+	name string
+
+	mu           sync.Mutex
+	transactions map[string]*TransactionResult
+	refunds      map[string]*RefundResult
+}
+
+func NewFakeProvider(name string) *FakeProvider {
+	return &FakeProvider{
+		name:         name,
+		transactions: map[string]*TransactionResult{},
+		refunds:      map[string]*RefundResult{},
+	}
+}
+
+func (p *FakeProvider) Name() string {
+	return p.name
+}
+
+func (p *FakeProvider) CreateTransaction(ctx context.Context, transaction *Transaction) (*Prepay, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.transactions[transaction.PaymentNumber]; ok {
+		return nil, errors.Errorf("fake transaction %s already exists", transaction.PaymentNumber)
+	}
+	referenceNumber := fmt.Sprintf("FAKE%s", transaction.PaymentNumber)
+	p.transactions[transaction.PaymentNumber] = &TransactionResult{
+		PaymentNumber:   transaction.PaymentNumber,
+		ReferenceNumber: referenceNumber,
+		State:           TransactionStatePending,
+		Amount:          transaction.Amount,
+	}
+
+	return &Prepay{
+		ReferenceNumber: referenceNumber,
+		Data:            map[string]string{"paymentNumber": transaction.PaymentNumber},
+	}, nil
+}
+
+func (p *FakeProvider) QueryTransaction(ctx context.Context, paymentNumber string) (*TransactionResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	transaction, ok := p.transactions[paymentNumber]
+	if !ok {
+		return nil, errors.Errorf("fake transaction %s not found", paymentNumber)
+	}
+	result := *transaction
+	return &result, nil
+}
+
+func (p *FakeProvider) CloseTransaction(ctx context.Context, paymentNumber string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	transaction, ok := p.transactions[paymentNumber]
+	if !ok {
+		return errors.Errorf("fake transaction %s not found", paymentNumber)
+	}
+	if transaction.State != TransactionStatePending {
+		return errors.Errorf("fake transaction %s is %s", paymentNumber, transaction.State)
+	}
+	transaction.State = TransactionStateClosed
+	return nil
+}
+
+func (p *FakeProvider) Refund(ctx context.Context, refund *Refund) (*RefundResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	transaction, ok := p.transactions[refund.PaymentNumber]
+	if !ok || transaction.State != TransactionStatePaid {
+		return nil, errors.Errorf("fake transaction %s is not paid", refund.PaymentNumber)
+	}
+	refunded := 0.0
+	for _, r := range p.refunds {
+		if r.PaymentNumber == refund.PaymentNumber {
+			refunded += r.Amount
+		}
+	}
+	if toCent(refunded+refund.Amount) > toCent(transaction.Amount) {
+		return nil, errors.New("fake refund amount exceeds paid amount")
+	}
+
+	result := &RefundResult{
+		PaymentNumber:   refund.PaymentNumber,
+		RefundNumber:    refund.RefundNumber,
+		ReferenceNumber: fmt.Sprintf("FAKE%s", refund.RefundNumber),
+		State:           RefundStateSuccess,
+		Amount:          refund.Amount,
+	}
+	p.refunds[refund.RefundNumber] = result
+	if toCent(refunded+refund.Amount) == toCent(transaction.Amount) {
+		transaction.State = TransactionStateRefunded
+	}
+	return result, nil
+}
+
+// Pay
+//
+//	@Description: 模拟用户完成支付
+//	@receiver p
+//	@param paymentNumber
+//	@return *Notification 可直接作为回调内容
+//	@return error
+func (p *FakeProvider) Pay(paymentNumber string) (*Notification, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	transaction, ok := p.transactions[paymentNumber]
+	if !ok {
+		return nil, errors.Errorf("fake transaction %s not found", paymentNumber)
+	}
+	if transaction.State != TransactionStatePending {
+		return nil, errors.Errorf("fake transaction %s is %s", paymentNumber, transaction.State)
+	}
+	transaction.State = TransactionStatePaid
+	transaction.PaidAt = time.Now()
+
+	result := *transaction
+	return &Notification{Event: NotificationEventPaid, Transaction: &result}, nil
+}
+
+// HandleNotification
+//
+//	@Description: 回调内容即 Notification 的JSON
+func (p *FakeProvider) HandleNotification(w http.ResponseWriter, r *http.Request, handle func(notification *Notification) error) error {
+
+	notification := &Notification{}
+	err := json.NewDecoder(r.Body).Decode(notification)
+	if err == nil && notification.Transaction == nil && notification.Refund == nil {
+		err = errors.New("empty notification")
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprint(w, "fail")
+		return errors.Wrap(err, "invalid fake notification")
+	}
+
+	if err = handle(notification); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = fmt.Fprint(w, "fail")
+		return nil
+	}
+	_, _ = fmt.Fprint(w, "success")
+	return nil
+}
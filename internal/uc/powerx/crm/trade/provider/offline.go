@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"PowerX/internal/config"
+	"PowerX/internal/model/crm/trade"
+	"context"
+	"net/http"
+)
+
+type offlineProvider struct {
+	conf config.OfflinePay
+}
+
+// NewOfflineProvider
+//
+//	@Description: 线下银行转账, 下单只返回收款信息, 由管理员核对后确认到账
+//	@param conf
+//	@return IPaymentProviderInterface
+func NewOfflineProvider(conf config.OfflinePay) IPaymentProviderInterface {
+	return &offlineProvider{conf: conf}
+}
+
+func (p *offlineProvider) Name() string {
+	return trade.PaymentTypeBank
+}
+
+func (p *offlineProvider) CreateTransaction(ctx context.Context, transaction *Transaction) (*Prepay, error) {
+	return &Prepay{
+		Data: map[string]interface{}{
+			"bankName":      p.conf.BankName,
+			"accountName":   p.conf.AccountName,
+			"accountNumber": p.conf.AccountNumber,
+			"remark":        p.conf.Remark,
+			"paymentNumber": transaction.PaymentNumber,
+			"amount":        transaction.Amount,
+		},
+		RequireConfirmation: true,
+	}, nil
+}
+
+// QueryTransaction
+//
+//	@Description: 线下转账没有渠道侧交易, 到账状态以管理员确认为准
+func (p *offlineProvider) QueryTransaction(ctx context.Context, paymentNumber string) (*TransactionResult, error) {
+	return nil, ErrNotSupported
+}
+
+func (p *offlineProvider) CloseTransaction(ctx context.Context, paymentNumber string) error {
+	return nil
+}
+
+// Refund
+//
+//	@Description: 线下退款由财务处理, 这里仅登记
+func (p *offlineProvider) Refund(ctx context.Context, refund *Refund) (*RefundResult, error) {
+	return &RefundResult{
+		PaymentNumber: refund.PaymentNumber,
+		RefundNumber:  refund.RefundNumber,
+		State:         RefundStateProcessing,
+		Amount:        refund.Amount,
+	}, nil
+}
+
+func (p *offlineProvider) HandleNotification(w http.ResponseWriter, r *http.Request, handle func(notification *Notification) error) error {
+	w.WriteHeader(http.StatusNotFound)
+	return ErrNotSupported
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"math"
+	"net/http"
+	"time"
+)
+
+// IPaymentProviderInterface
+// @Description: 支付渠道(微信/支付宝/线下转账)公共能力, 支付单只依赖此接口, 按支付方式选择实现
+type IPaymentProviderInterface interface {
+	//
+	// Name
+	//  @Description: 支付方式, 对应数据字典 _payment_type 的Key
+	//  @return string
+	//
+	Name() string
+	//
+	// CreateTransaction
+	//  @Description: 在渠道侧下单
+	//  @param ctx
+	//  @param transaction
+	//  @return *Prepay 前端拉起支付所需参数
+	//  @return error
+	//
+	CreateTransaction(ctx context.Context, transaction *Transaction) (*Prepay, error)
+	//
+	// QueryTransaction
+	//  @Description: 按支付单号查询渠道交易状态
+	//  @param ctx
+	//  @param paymentNumber
+	//  @return *TransactionResult
+	//  @return error
+	//
+	QueryTransaction(ctx context.Context, paymentNumber string) (*TransactionResult, error)
+	//
+	// CloseTransaction
+	//  @Description: 关闭未支付的交易
+	//  @param ctx
+	//  @param paymentNumber
+	//  @return error
+	//
+	CloseTransaction(ctx context.Context, paymentNumber string) error
+	//
+	// Refund
+	//  @Description: 申请退款
+	//  @param ctx
+	//  @param refund
+	//  @return *RefundResult
+	//  @return error
+	//
+	Refund(ctx context.Context, refund *Refund) (*RefundResult, error)
+	//
+	// HandleNotification
+	//  @Description: 验签并解析渠道异步通知, 交给handle处理后按渠道要求应答
+	//  @param w
+	//  @param r
+	//  @param handle 返回error时告知渠道处理失败, 由渠道重试
+	//  @return error 通知无法解析或验签失败, 此时已按渠道要求应答失败
+	//
+	HandleNotification(w http.ResponseWriter, r *http.Request, handle func(notification *Notification) error) error
+}
+
+// IInternalProviderInterface
+// @Description: 代币等内部渠道, 下单不调用外部接口
+type IInternalProviderInterface interface {
+	IsInternal() bool
+}
+
+// IsInternalProvider 内部渠道在支付单事务内下单, 外部渠道在事务外下单, 避免持有订单锁时等待渠道接口
+func IsInternalProvider(p IPaymentProviderInterface) bool {
+	internal, ok := p.(IInternalProviderInterface)
+	return ok && internal.IsInternal()
+}
+
+var ErrNotSupported = errors.New("payment provider does not support this operation")
+
+type Transaction struct {
+	PaymentNumber string
+	Amount        float64
+	Description   string
+	// 微信JSAPI支付需要支付者OpenId
+	OpenId   string
+	ClientIp string
+	// 代币支付需要扣减客户余额
+	CustomerId int64
+	// 支付单所在的事务, 代币等内部渠道在此事务内扣减, 与支付单一起提交或回滚
+	DB *gorm.DB
+	// 交易结束时间, 超时未支付由渠道关闭交易, 为空时使用渠道默认
+	ExpireAt time.Time
+}
+
+type Prepay struct {
+	// 渠道预支付标识, 如微信prepay_id
+	ReferenceNumber string
+	Data            interface{}
+	// 线下转账等需要管理员确认到账
+	RequireConfirmation bool
+	// 代币等内部渠道下单即完成支付, 无需等待渠道通知
+	Paid bool
+}
+
+type TransactionState string
+
+const (
+	TransactionStatePending  TransactionState = "pending"
+	TransactionStatePaid     TransactionState = "paid"
+	TransactionStateClosed   TransactionState = "closed"
+	TransactionStateRefunded TransactionState = "refunded"
+)
+
+type TransactionResult struct {
+	PaymentNumber string
+	// 渠道交易号
+	ReferenceNumber string
+	State           TransactionState
+	Amount          float64
+	Currency        string
+	PaidAt          time.Time
+}
+
+type Refund struct {
+	PaymentNumber string
+	RefundNumber  string
+	Amount        float64
+	TotalAmount   float64
+	Reason        string
+}
+
+type RefundState string
+
+const (
+	RefundStateProcessing RefundState = "processing"
+	RefundStateSuccess    RefundState = "success"
+	RefundStateFailed     RefundState = "failed"
+)
+
+type RefundResult struct {
+	PaymentNumber   string
+	RefundNumber    string
+	ReferenceNumber string
+	State           RefundState
+	Amount          float64
+}
+
+type NotificationEvent string
+
+const (
+	NotificationEventPaid     NotificationEvent = "paid"
+	NotificationEventRefunded NotificationEvent = "refunded"
+	NotificationEventClosed   NotificationEvent = "closed"
+)
+
+type Notification struct {
+	// 渠道通知Id
+	NotifyId    string
+	Event       NotificationEvent
+	Transaction *TransactionResult
+	Refund      *RefundResult
+}
+
+// 元 -> 分
+func toCent(amount float64) int {
+	return int(math.Round(amount * 100))
+}
+
+// 分 -> 元
+func fromCent(cent int64) float64 {
+	return float64(cent) / 100
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"PowerX/internal/config"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAlipaySignContent(t *testing.T) {
+
+	params := url.Values{
+		"b":    {"2"},
+		"a":    {"1"},
+		"sign": {"xxx"},
+		"c":    {""},
+	}
+	if got := alipaySignContent(params); got != "a=1&b=2" {
+		t.Errorf("sign content = %q", got)
+	}
+}
+
+func TestAlipayNotificationVerify(t *testing.T) {
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	client, err := newAlipayClient("", "2021001",
+		base64.StdEncoding.EncodeToString(x509.MarshalPKCS1PrivateKey(key)),
+		base64.StdEncoding.EncodeToString(pub),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form := url.Values{
+		"app_id":       {"2021001"},
+		"out_trade_no": {"PO1"},
+		"trade_no":     {"2023"},
+		"trade_status": {"TRADE_SUCCESS"},
+		"total_amount": {"9.90"},
+		"sign_type":    {"RSA2"},
+	}
+	sign, err := client.sign(alipaySignContent(url.Values{
+		"app_id": form["app_id"], "out_trade_no": form["out_trade_no"], "trade_no": form["trade_no"],
+		"trade_status": form["trade_status"], "total_amount": form["total_amount"],
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	form.Set("sign", sign)
+
+	p := &alipayProvider{client: client}
+	var got *Notification
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/webhook/alipay/pay/", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	err = p.HandleNotification(w, r, func(notification *Notification) error {
+		got = notification
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Body.String() != "success" {
+		t.Errorf("reply = %q", w.Body.String())
+	}
+	if got == nil || got.Event != NotificationEventPaid || got.Transaction.Amount != 9.9 || got.Transaction.ReferenceNumber != "2023" {
+		t.Errorf("notification = %+v", got)
+	}
+
+	// 篡改金额后验签失败
+	form.Set("total_amount", "0.01")
+	r = httptest.NewRequest(http.MethodPost, "/webhook/alipay/pay/", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err = p.HandleNotification(httptest.NewRecorder(), r, func(*Notification) error { return nil }); err == nil {
+		t.Errorf("tampered notification should fail verify")
+	}
+}
+
+func TestFakeProvider(t *testing.T) {
+
+	ctx := context.Background()
+	p := NewFakeProvider("_wechat")
+
+	if _, err := p.CreateTransaction(ctx, &Transaction{PaymentNumber: "PO1", Amount: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Refund(ctx, &Refund{PaymentNumber: "PO1", RefundNumber: "RF0", Amount: 1}); err == nil {
+		t.Errorf("refund before paid should fail")
+	}
+	notification, err := p.Pay("PO1")
+	if err != nil || notification.Event != NotificationEventPaid {
+		t.Fatalf("pay: %v %+v", err, notification)
+	}
+	if err = p.CloseTransaction(ctx, "PO1"); err == nil {
+		t.Errorf("close paid transaction should fail")
+	}
+
+	if _, err = p.Refund(ctx, &Refund{PaymentNumber: "PO1", RefundNumber: "RF1", Amount: 4}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = p.Refund(ctx, &Refund{PaymentNumber: "PO1", RefundNumber: "RF2", Amount: 7}); err == nil {
+		t.Errorf("refund exceeding paid amount should fail")
+	}
+	if _, err = p.Refund(ctx, &Refund{PaymentNumber: "PO1", RefundNumber: "RF3", Amount: 6}); err != nil {
+		t.Fatal(err)
+	}
+	result, _ := p.QueryTransaction(ctx, "PO1")
+	if result.State != TransactionStateRefunded {
+		t.Errorf("state = %s, want refunded", result.State)
+	}
+}
+
+func TestToCent(t *testing.T) {
+	if toCent(0.29) != 29 || toCent(19.99) != 1999 {
+		t.Errorf("toCent rounding: %d %d", toCent(0.29), toCent(19.99))
+	}
+}
+
+func TestIsInternalProvider(t *testing.T) {
+	if !IsInternalProvider(NewTokenProvider(nil)) {
+		t.Errorf("token provider should be internal")
+	}
+	if IsInternalProvider(NewFakeProvider("_wechat")) || IsInternalProvider(NewOfflineProvider(config.OfflinePay{})) {
+		t.Errorf("external providers should not be internal")
+	}
+}
+
+func TestWechatPayVerifySignature(t *testing.T) {
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	platformKey, err := parseWechatPlatformKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1696125600, 0)
+	p := &wechatPayProvider{platformKey: platformKey, now: func() time.Time { return now }}
+	body := []byte(`{"id":"n1","event_type":"TRANSACTION.SUCCESS"}`)
+	header := func(timestamp time.Time, payload []byte) http.Header {
+		ts := strconv.FormatInt(timestamp.Unix(), 10)
+		hashed := sha256.Sum256([]byte(ts + "\n" + "nonce" + "\n" + string(payload) + "\n"))
+		signature, _ := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+		h := http.Header{}
+		h.Set("Wechatpay-Timestamp", ts)
+		h.Set("Wechatpay-Nonce", "nonce")
+		h.Set("Wechatpay-Signature", base64.StdEncoding.EncodeToString(signature))
+		return h
+	}
+
+	if err = p.verifySignature(header(now, body), body); err != nil {
+		t.Errorf("valid signature rejected: %v", err)
+	}
+	if err = p.verifySignature(header(now, body), []byte(`{"id":"n2"}`)); err == nil {
+		t.Errorf("tampered body should fail")
+	}
+	if err = p.verifySignature(header(now.Add(-10*time.Minute), body), body); err == nil {
+		t.Errorf("expired timestamp should fail")
+	}
+	if err = p.verifySignature(http.Header{}, body); err == nil {
+		t.Errorf("missing headers should fail")
+	}
+
+	// 未配置平台公钥时跳过
+	if err = (&wechatPayProvider{}).verifySignature(http.Header{}, body); err != nil {
+		t.Errorf("verify without platform key: %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/types/errorx"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"net/http"
+)
+
+type tokenProvider struct {
+	db *gorm.DB
+}
+
+// NewTokenProvider
+//
+//	@Description: 代币余额支付, 1代币抵扣1元, 下单时直接扣减余额, 可与其他支付方式组合支付同一订单
+//	@param db
+//	@return IPaymentProviderInterface
+func NewTokenProvider(db *gorm.DB) IPaymentProviderInterface {
+	return &tokenProvider{db: db}
+}
+
+func (p *tokenProvider) Name() string {
+	return trade.PaymentTypeToken
+}
+
+func (p *tokenProvider) CreateTransaction(ctx context.Context, transaction *Transaction) (*Prepay, error) {
+	if transaction.CustomerId == 0 {
+		return nil, errors.New("token payment requires customer")
+	}
+
+	// 在支付单事务内扣减, 支付单保存失败时余额一起回滚
+	db := p.db
+	if transaction.DB != nil {
+		db = transaction.DB
+	}
+
+	// 余额不足时不扣减, 并发下单只有一笔能成功
+	balance := &trade.TokenBalance{}
+	result := db.WithContext(ctx).Model(balance).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "balance"}}}).
+		Where("customer_id = ? AND balance >= ?", transaction.CustomerId, transaction.Amount).
+		Update("balance", gorm.Expr("balance - ?", transaction.Amount))
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "代币余额不足")
+	}
+
+	err := db.WithContext(ctx).Create(&trade.TokenLedger{
+		CustomerId:    transaction.CustomerId,
+		Amount:        -transaction.Amount,
+		Balance:       balance.Balance,
+		PaymentNumber: transaction.PaymentNumber,
+		Remark:        "代币支付",
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &Prepay{
+		ReferenceNumber: fmt.Sprintf("TK%s", transaction.PaymentNumber),
+		Data: map[string]interface{}{
+			"paymentNumber": transaction.PaymentNumber,
+			"amount":        transaction.Amount,
+		},
+		Paid: true,
+	}, nil
+}
+
+// QueryTransaction
+//
+//	@Description: 代币支付下单即完成, 没有渠道侧交易
+func (p *tokenProvider) QueryTransaction(ctx context.Context, paymentNumber string) (*TransactionResult, error) {
+	return nil, ErrNotSupported
+}
+
+// IsInternal 代币在支付单事务内扣减余额
+func (p *tokenProvider) IsInternal() bool {
+	return true
+}
+
+func (p *tokenProvider) CloseTransaction(ctx context.Context, paymentNumber string) error {
+	return nil
+}
+
+// Refund
+//
+//	@Description: 退回代币余额, 同步完成
+func (p *tokenProvider) Refund(ctx context.Context, refund *Refund) (*RefundResult, error) {
+	payment := &trade.Payment{}
+	err := p.db.WithContext(ctx).Preload("Order").
+		Where("payment_number = ?", refund.PaymentNumber).
+		First(payment).Error
+	if err != nil {
+		return nil, err
+	}
+	if payment.Order == nil {
+		return nil, errors.Errorf("token payment %s has no order", refund.PaymentNumber)
+	}
+	customerId := payment.Order.CustomerId
+
+	err = p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 同一退款单只退回一次
+		var count int64
+		if err := tx.Model(&trade.TokenLedger{}).
+			Where("refund_number = ?", refund.RefundNumber).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		balance := &trade.TokenBalance{}
+		result := tx.Model(balance).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "balance"}}}).
+			Where("customer_id = ?", customerId).
+			Update("balance", gorm.Expr("balance + ?", refund.Amount))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			balance = &trade.TokenBalance{
+				CustomerId: customerId,
+				Balance:    refund.Amount,
+			}
+			if err := tx.Create(balance).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(&trade.TokenLedger{
+			CustomerId:    customerId,
+			Amount:        refund.Amount,
+			Balance:       balance.Balance,
+			PaymentNumber: refund.PaymentNumber,
+			RefundNumber:  refund.RefundNumber,
+			Remark:        "代币退款",
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RefundResult{
+		PaymentNumber:   refund.PaymentNumber,
+		RefundNumber:    refund.RefundNumber,
+		ReferenceNumber: fmt.Sprintf("TK%s", refund.RefundNumber),
+		State:           RefundStateSuccess,
+		Amount:          refund.Amount,
+	}, nil
+}
+
+func (p *tokenProvider) HandleNotification(w http.ResponseWriter, r *http.Request, handle func(notification *Notification) error) error {
+	w.WriteHeader(http.StatusNotFound)
+	return ErrNotSupported
+}
@@ -0,0 +1,360 @@
+package provider
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/kernel/models"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/payment"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/payment/notify/request"
+	request2 "github.com/ArtisanCloud/PowerWeChat/v3/src/payment/order/request"
+	request3 "github.com/ArtisanCloud/PowerWeChat/v3/src/payment/refund/request"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 回调时间戳允许的偏差
+const wechatNotifyTolerance = 5 * time.Minute
+
+type wechatPayProvider struct {
+	app *payment.Payment
+	// 微信支付平台公钥, 为空时只依赖APIv3密钥解密校验
+	platformKey *rsa.PublicKey
+	now         func() time.Time
+}
+
+// NewWechatPayProvider
+//
+//	@Description: 微信支付(JSAPI), 复用已初始化的PowerWeChat支付实例
+//	@param app
+//	@param platformCertPath 微信支付平台证书或公钥路径, 可为空
+//	@return IPaymentProviderInterface
+//	@return error
+func NewWechatPayProvider(app *payment.Payment, platformCertPath string) (IPaymentProviderInterface, error) {
+	p := &wechatPayProvider{app: app, now: time.Now}
+	if platformCertPath != "" {
+		content, err := os.ReadFile(platformCertPath)
+		if err != nil {
+			return nil, err
+		}
+		if p.platformKey, err = parseWechatPlatformKey(content); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+func (p *wechatPayProvider) Name() string {
+	return trade.PaymentTypeWeChat
+}
+
+func (p *wechatPayProvider) CreateTransaction(ctx context.Context, transaction *Transaction) (*Prepay, error) {
+
+	if transaction.OpenId == "" {
+		return nil, errors.New("wechat pay requires payer openid")
+	}
+
+	description := transaction.Description
+	if description == "" {
+		description = fmt.Sprintf("%.2f-%s", transaction.Amount, transaction.PaymentNumber)
+	}
+
+	mapObject := &request2.RequestJSAPIPrepay{
+		Amount: &request2.JSAPIAmount{
+			Total: toCent(transaction.Amount),
+		},
+		Attach:      "订单支付",
+		Description: description,
+		OutTradeNo:  transaction.PaymentNumber,
+		Payer: &request2.JSAPIPayer{
+			OpenID: transaction.OpenId,
+		},
+	}
+	if !transaction.ExpireAt.IsZero() {
+		mapObject.TimeExpire = transaction.ExpireAt.Format(time.RFC3339)
+	}
+	mapObject.SetNotifyUrl(p.app.Config.GetString("notify_url", ""))
+
+	rs, err := p.app.Order.JSAPITransaction(ctx, mapObject)
+	if err != nil {
+		return nil, err
+	}
+	if rs.PrepayID == "" {
+		return nil, errors.Errorf("no Prepay Id generated: %s %s", rs.Code, rs.Message)
+	}
+
+	// config wx Bridge for front end
+	data, err := p.app.JSSDK.BridgeConfig(rs.PrepayID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Prepay{
+		ReferenceNumber: rs.PrepayID,
+		Data:            data,
+	}, nil
+}
+
+func (p *wechatPayProvider) QueryTransaction(ctx context.Context, paymentNumber string) (*TransactionResult, error) {
+
+	rs, err := p.app.Order.QueryByOutTradeNumber(ctx, paymentNumber)
+	if err != nil {
+		return nil, err
+	}
+	if rs.Code != "" {
+		return nil, errors.Errorf("wechat pay query failed: %s %s", rs.Code, rs.Message)
+	}
+
+	result := &TransactionResult{
+		PaymentNumber:   paymentNumber,
+		ReferenceNumber: rs.TransactionID,
+		State:           wechatTradeState(rs.TradeState),
+	}
+	if rs.Amount != nil {
+		if total, ok := (*rs.Amount)["total"].(float64); ok {
+			result.Amount = fromCent(int64(total))
+		}
+	}
+	result.PaidAt, _ = time.Parse(time.RFC3339, rs.SuccessTime)
+
+	return result, nil
+}
+
+func (p *wechatPayProvider) CloseTransaction(ctx context.Context, paymentNumber string) error {
+
+	rs, err := p.app.Order.Close(ctx, paymentNumber)
+	if err != nil {
+		return err
+	}
+	if rs.Code != "" {
+		return errors.Errorf("wechat pay close failed: %s %s", rs.Code, rs.Message)
+	}
+	return nil
+}
+
+func (p *wechatPayProvider) Refund(ctx context.Context, refund *Refund) (*RefundResult, error) {
+
+	rs, err := p.app.Refund.Refund(ctx, &request3.RequestRefund{
+		OutTradeNo:  refund.PaymentNumber,
+		OutRefundNo: refund.RefundNumber,
+		Reason:      refund.Reason,
+		NotifyUrl:   p.app.Config.GetString("notify_url", ""),
+		Amount: &request3.RefundAmount{
+			Refund:   toCent(refund.Amount),
+			Total:    toCent(refund.TotalAmount),
+			Currency: "CNY",
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rs.Code != "" {
+		return nil, errors.Errorf("wechat pay refund failed: %s %s", rs.Code, rs.Message)
+	}
+
+	return &RefundResult{
+		PaymentNumber:   refund.PaymentNumber,
+		RefundNumber:    refund.RefundNumber,
+		ReferenceNumber: rs.RefundID,
+		State:           wechatRefundState(rs.Status),
+		Amount:          refund.Amount,
+	}, nil
+}
+
+func (p *wechatPayProvider) HandleNotification(w http.ResponseWriter, r *http.Request, handle func(notification *Notification) error) (err error) {
+
+	defer func() {
+		if err != nil {
+			wechatNotifyFail(w, err)
+		}
+	}()
+
+	// 支付与退款通知共用回调地址, 先读出事件类型再交给对应的解密处理
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewBuffer(body))
+
+	if err = p.verifySignature(r.Header, body); err != nil {
+		return err
+	}
+
+	envelope := struct {
+		Id        string `json:"id"`
+		EventType string `json:"event_type"`
+	}{}
+	if err = json.Unmarshal(body, &envelope); err != nil {
+		return errors.Wrap(err, "invalid wechat pay notification")
+	}
+
+	var res *http.Response
+	if strings.HasPrefix(envelope.EventType, "REFUND.") {
+		res, err = p.app.HandleRefundedNotify(r, func(message *request.RequestNotify, refund *models.Refund, fail func(message string)) interface{} {
+			if refund == nil || refund.OutTradeNo == "" {
+				return "no content notify"
+			}
+			result := &RefundResult{
+				PaymentNumber:   refund.OutTradeNo,
+				RefundNumber:    refund.OutRefundNo,
+				ReferenceNumber: refund.RefundID,
+				State:           wechatRefundState(refund.RefundStatus),
+			}
+			if refund.Amount != nil {
+				result.Amount = fromCent(refund.Amount.Refund)
+			}
+			return strictResult(handle(&Notification{NotifyId: envelope.Id, Event: NotificationEventRefunded, Refund: result}))
+		})
+	} else {
+		res, err = p.app.HandlePaidNotify(r, func(message *request.RequestNotify, transaction *models.Transaction, fail func(message string)) interface{} {
+			if transaction == nil || transaction.OutTradeNo == "" {
+				return "no content notify"
+			}
+			// 仅处理支付成功, 其余事件直接应答
+			if message.EventType != "TRANSACTION.SUCCESS" {
+				return true
+			}
+			result := &TransactionResult{
+				PaymentNumber:   transaction.OutTradeNo,
+				ReferenceNumber: transaction.TransactionID,
+				State:           wechatTradeState(transaction.TradeState),
+			}
+			if transaction.Amount != nil {
+				result.Amount = fromCent(transaction.Amount.Total)
+				result.Currency = transaction.Amount.Currency
+			}
+			result.PaidAt, _ = time.Parse(time.RFC3339, transaction.SuccessTime)
+			return strictResult(handle(&Notification{NotifyId: envelope.Id, Event: NotificationEventPaid, Transaction: result}))
+		})
+	}
+	// 这里可能是因为不是微信官方调用的，无法正常解析出transaction和message
+	if res == nil {
+		if err == nil {
+			err = errors.New("invalid wechat pay notification")
+		}
+		return err
+	}
+
+	// 这里根据之前返回的是true或者fail，框架这边自动会帮你回复微信
+	// 处理失败已经记录在通知中, 不再作为解析错误返回
+	_ = res.Write(w)
+	return nil
+}
+
+// verifySignature
+//
+//	@Description: 校验回调签名, 签名串为 时间戳\n随机串\n报文主体\n
+//	@receiver p
+//	@param header
+//	@param body
+//	@return error
+func (p *wechatPayProvider) verifySignature(header http.Header, body []byte) error {
+	if p.platformKey == nil {
+		return nil
+	}
+
+	timestamp := header.Get("Wechatpay-Timestamp")
+	nonce := header.Get("Wechatpay-Nonce")
+	signature := header.Get("Wechatpay-Signature")
+	if timestamp == "" || nonce == "" || signature == "" {
+		return errors.New("wechat pay notification missing signature headers")
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "invalid wechat pay notification timestamp")
+	}
+	if diff := p.now().Sub(time.Unix(seconds, 0)); diff > wechatNotifyTolerance || diff < -wechatNotifyTolerance {
+		return errors.New("wechat pay notification timestamp expired")
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return errors.Wrap(err, "invalid wechat pay notification signature")
+	}
+	hashed := sha256.Sum256([]byte(timestamp + "\n" + nonce + "\n" + string(body) + "\n"))
+	if err = rsa.VerifyPKCS1v15(p.platformKey, crypto.SHA256, hashed[:], decoded); err != nil {
+		return errors.Wrap(err, "wechat pay notification signature mismatch")
+	}
+	return nil
+}
+
+// 按微信支付要求以非200状态码应答失败, 微信会按策略重新通知
+func wechatNotifyFail(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	reply, _ := json.Marshal(map[string]string{"code": "FAIL", "message": err.Error()})
+	_, _ = w.Write(reply)
+}
+
+// 平台证书(CERTIFICATE)或微信支付公钥(PUBLIC KEY)
+func parseWechatPlatformKey(content []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errors.New("invalid wechat pay platform certificate")
+	}
+
+	var parsed interface{}
+	if block.Type == "CERTIFICATE" {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		parsed = cert.PublicKey
+	} else {
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		parsed = key
+	}
+
+	key, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("wechat pay platform key is not rsa")
+	}
+	return key, nil
+}
+
+// 处理结果转为PowerWeChat约定的应答: true成功, 字符串为失败原因
+func strictResult(err error) interface{} {
+	if err != nil {
+		return err.Error()
+	}
+	return true
+}
+
+func wechatTradeState(state string) TransactionState {
+	switch state {
+	case "SUCCESS":
+		return TransactionStatePaid
+	case "REFUND":
+		return TransactionStateRefunded
+	case "CLOSED", "REVOKED", "PAYERROR":
+		return TransactionStateClosed
+	}
+	return TransactionStatePending
+}
+
+func wechatRefundState(status string) RefundState {
+	switch status {
+	case "SUCCESS":
+		return RefundStateSuccess
+	case "CLOSED", "ABNORMAL":
+		return RefundStateFailed
+	}
+	return RefundStateProcessing
+}
@@ -22,8 +22,8 @@ func NewRefundOrderUseCase(db *gorm.DB) *RefundOrderUseCase {
 }
 
 type FindManyRefundOrdersOption struct {
-	LikeName      string
-	RefundOrderBy string
+	LikeName  string
+	QuerySpec *powermodel.QuerySpec
 	types.PageEmbedOption
 }
 
@@ -33,10 +33,8 @@ func (uc *RefundOrderUseCase) buildFindQueryNoPage(db *gorm.DB, opt *FindManyRef
 		db = db.Where("name LIKE ?", "%"+opt.LikeName+"%")
 	}
 
+	db = opt.QuerySpec.Apply(db)
 	orderBy := "id desc"
-	if opt.RefundOrderBy != "" {
-		orderBy = opt.RefundOrderBy + "," + orderBy
-	}
 	db.Order(orderBy)
 
 	return db
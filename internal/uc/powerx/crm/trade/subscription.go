@@ -0,0 +1,603 @@
+package trade
+
+import (
+	"PowerX/internal/model/crm/customerdomain"
+	"PowerX/internal/model/crm/product"
+	"PowerX/internal/model/crm/trade"
+	wechatModel "PowerX/internal/model/wechat"
+	"PowerX/internal/types"
+	"PowerX/internal/types/errorx"
+	"PowerX/internal/uc/powerx/wechat"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+	"time"
+)
+
+type SubscriptionUseCase struct {
+	db           *gorm.DB
+	payment      *PaymentUseCase
+	order        *OrderUseCase
+	notification *wechat.WechatNotificationUseCase
+}
+
+func NewSubscriptionUseCase(db *gorm.DB, payment *PaymentUseCase, order *OrderUseCase,
+	notification *wechat.WechatNotificationUseCase,
+) *SubscriptionUseCase {
+	uc := &SubscriptionUseCase{
+		db:           db,
+		payment:      payment,
+		order:        order,
+		notification: notification,
+	}
+	// 订阅订单付清后生成订阅, 续费订单付清后续期
+	payment.AddOrderPaidHook(uc.HandleOrderPaid)
+	return uc
+}
+
+type FindManySubscriptionsOption struct {
+	CustomerId         int64
+	Statuses           []string
+	SubscriptionNumber string
+	types.PageEmbedOption
+}
+
+// SubscriptionDunningRetryDays 续费扣款失败后第N次重试距失败的天数, 重试用尽后取消订阅
+var SubscriptionDunningRetryDays = []int{1, 3, 5}
+
+// ValidateBillingInterval 校验周期性产品的计费周期
+func ValidateBillingInterval(unit string, count int) error {
+	switch unit {
+	case product.BillingIntervalDay, product.BillingIntervalWeek, product.BillingIntervalMonth, product.BillingIntervalYear:
+	default:
+		return errorx.WithCause(errorx.ErrBadRequest, "周期性产品的计费周期单位不正确")
+	}
+	if count <= 0 {
+		return errorx.WithCause(errorx.ErrBadRequest, "周期性产品的计费周期必须大于0")
+	}
+	return nil
+}
+
+// IsSameBillingInterval 两个周期性产品的计费周期及期数是否一致
+func IsSameBillingInterval(a *product.Product, b *product.Product) bool {
+	return a.BillingIntervalUnit == b.BillingIntervalUnit &&
+		a.BillingIntervalCount == b.BillingIntervalCount &&
+		a.BillingCycles == b.BillingCycles
+}
+
+// AddBillingInterval
+//
+//	@Description: 计算下一期的开始时间, 按月/年计费时月末对齐, 如1月31日的下一期为2月28日
+//	@param t 当期开始时间
+//	@param unit
+//	@param count
+//	@return time.Time
+func AddBillingInterval(t time.Time, unit string, count int) time.Time {
+	switch unit {
+	case product.BillingIntervalDay:
+		return t.AddDate(0, 0, count)
+	case product.BillingIntervalWeek:
+		return t.AddDate(0, 0, 7*count)
+	case product.BillingIntervalYear:
+		count = 12 * count
+	case product.BillingIntervalMonth:
+	default:
+		return t
+	}
+
+	firstDay := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	target := firstDay.AddDate(0, count, 0)
+	lastDay := target.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return target.AddDate(0, 0, day-1)
+}
+
+// GetDunningRetryAt
+//
+//	@Description: 按已失败次数计算下次重试扣款时间
+//	@param attempts 已失败次数
+//	@param failedAt
+//	@return time.Time
+//	@return bool 是否还可以重试
+func GetDunningRetryAt(attempts int, failedAt time.Time) (time.Time, bool) {
+	if attempts <= 0 || attempts > len(SubscriptionDunningRetryDays) {
+		return time.Time{}, false
+	}
+	return failedAt.AddDate(0, 0, SubscriptionDunningRetryDays[attempts-1]), true
+}
+
+func failReasonOf(err error) string {
+	if e, ok := err.(*errorx.Error); ok {
+		return e.Msg
+	}
+	return err.Error()
+}
+
+func (uc *SubscriptionUseCase) buildFindQueryNoPage(db *gorm.DB, opt *FindManySubscriptionsOption) *gorm.DB {
+	if opt.CustomerId > 0 {
+		db = db.Where("customer_id = ?", opt.CustomerId)
+	}
+	if len(opt.Statuses) > 0 {
+		db = db.Where("status IN ?", opt.Statuses)
+	}
+	if opt.SubscriptionNumber != "" {
+		db = db.Where("subscription_number = ?", opt.SubscriptionNumber)
+	}
+	return db
+}
+
+func (uc *SubscriptionUseCase) FindManySubscriptions(ctx context.Context, opt *FindManySubscriptionsOption) (pageList types.Page[*trade.Subscription], err error) {
+	opt.DefaultPageIfNotSet()
+	var subscriptions []*trade.Subscription
+	db := uc.buildFindQueryNoPage(uc.db.WithContext(ctx).Model(&trade.Subscription{}), opt)
+
+	var count int64
+	if err := db.Count(&count).Error; err != nil {
+		panic(err)
+	}
+
+	if opt.PageIndex != 0 && opt.PageSize != 0 {
+		db.Offset((opt.PageIndex - 1) * opt.PageSize).Limit(opt.PageSize)
+	}
+	if err := db.Preload("OriginOrder").Order("id desc").Find(&subscriptions).Error; err != nil {
+		panic(err)
+	}
+
+	return types.Page[*trade.Subscription]{
+		List:      subscriptions,
+		PageIndex: opt.PageIndex,
+		PageSize:  opt.PageSize,
+		Total:     count,
+	}, nil
+}
+
+func (uc *SubscriptionUseCase) GetSubscription(ctx context.Context, id int64) (*trade.Subscription, error) {
+	var subscription trade.Subscription
+	if err := uc.db.WithContext(ctx).Preload("OriginOrder").First(&subscription, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.WithCause(errorx.ErrNotFoundObject, "未找到订阅")
+		}
+		panic(err)
+	}
+	return &subscription, nil
+}
+
+// GetCustomerSubscription 客户只能操作自己的订阅
+func (uc *SubscriptionUseCase) GetCustomerSubscription(ctx context.Context, customerId int64, id int64) (*trade.Subscription, error) {
+	subscription, err := uc.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.CustomerId != customerId {
+		return nil, errorx.WithCause(errorx.ErrNotFoundObject, "未找到订阅")
+	}
+	return subscription, nil
+}
+
+func (uc *SubscriptionUseCase) updateSubscription(ctx context.Context, subscription *trade.Subscription, fromStatus string, values map[string]interface{}) error {
+	result := uc.db.WithContext(ctx).Model(&trade.Subscription{}).
+		Where("id = ? AND status = ?", subscription.Id, fromStatus).
+		Updates(values)
+	if result.Error != nil {
+		panic(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errorx.WithCause(errorx.ErrBadRequest, "订阅状态已变更, 请刷新后重试")
+	}
+	return nil
+}
+
+// HandleOrderPaid 订单付清后的回调
+func (uc *SubscriptionUseCase) HandleOrderPaid(ctx context.Context, order *trade.Order) {
+	if order.SubscriptionId > 0 {
+		uc.renewSubscription(ctx, order)
+		return
+	}
+	if uc.order.IsOrderTypeSameAs(ctx, order, trade.OrderTypeSubscription) {
+		uc.createSubscriptionFromOrder(ctx, order)
+	}
+}
+
+// createSubscriptionFromOrder 首期订单付清, 当期从付清时开始
+func (uc *SubscriptionUseCase) createSubscriptionFromOrder(ctx context.Context, order *trade.Order) {
+	var items []*trade.OrderItem
+	err := uc.db.WithContext(ctx).
+		Preload("ProductBookEntry.Product").
+		Where("order_id = ?", order.Id).
+		Find(&items).Error
+	if err != nil {
+		panic(err)
+	}
+
+	var periodProduct *product.Product
+	for _, item := range items {
+		if item.ProductBookEntry != nil && item.ProductBookEntry.Product != nil {
+			periodProduct = item.ProductBookEntry.Product
+			break
+		}
+	}
+	if periodProduct == nil {
+		logx.WithContext(ctx).Errorf("订阅订单%s未找到周期性产品, 无法生成订阅", order.OrderNumber)
+		return
+	}
+
+	productName := periodProduct.Name
+	if len(items) > 1 {
+		productName = fmt.Sprintf("%s等%d件商品", productName, len(items))
+	}
+	now := time.Now()
+	periodEnd := AddBillingInterval(now, periodProduct.BillingIntervalUnit, periodProduct.BillingIntervalCount)
+	subscription := &trade.Subscription{
+		CustomerId:         order.CustomerId,
+		SubscriptionNumber: trade.GenerateSubscriptionNumber(),
+		OriginOrderId:      order.Id,
+		ProductName:        productName,
+		Amount:             order.UnitPrice,
+		IntervalUnit:       periodProduct.BillingIntervalUnit,
+		IntervalCount:      periodProduct.BillingIntervalCount,
+		TotalCycles:        periodProduct.BillingCycles,
+		PaidCycles:         1,
+		Status:             trade.SubscriptionStatusActive,
+		CurrentPeriodStart: now,
+		CurrentPeriodEnd:   periodEnd,
+		NextChargeAt:       &periodEnd,
+	}
+
+	err = uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(subscription).Error; err != nil {
+			return err
+		}
+		return tx.Model(&trade.Order{}).Where("id = ?", order.Id).
+			Update("subscription_id", subscription.Id).Error
+	})
+	if err != nil {
+		panic(err)
+	}
+	order.SubscriptionId = subscription.Id
+}
+
+// renewSubscription 续费订单付清, 订阅续期一期; 新一期紧接上一期, 逾期付款不顺延
+func (uc *SubscriptionUseCase) renewSubscription(ctx context.Context, order *trade.Order) {
+	subscription, err := uc.GetSubscription(ctx, order.SubscriptionId)
+	if err != nil || subscription.PendingOrderId != order.Id {
+		return
+	}
+
+	periodStart := subscription.CurrentPeriodEnd
+	periodEnd := AddBillingInterval(periodStart, subscription.IntervalUnit, subscription.IntervalCount)
+	err = uc.updateSubscription(ctx, subscription, subscription.Status, map[string]interface{}{
+		"status":               trade.SubscriptionStatusActive,
+		"paid_cycles":          subscription.PaidCycles + 1,
+		"pending_order_id":     0,
+		"current_period_start": periodStart,
+		"current_period_end":   periodEnd,
+		"next_charge_at":       periodEnd,
+		"dunning_attempts":     0,
+		"next_retry_at":        nil,
+		"last_fail_reason":     "",
+	})
+	if err != nil {
+		logx.WithContext(ctx).Errorf("订阅%s续期失败:%s", subscription.SubscriptionNumber, err.Error())
+	}
+}
+
+// PauseSubscription 暂停后不再扣费, 恢复时当期结束时间顺延暂停的时长
+func (uc *SubscriptionUseCase) PauseSubscription(ctx context.Context, subscription *trade.Subscription) error {
+	if subscription.Status != trade.SubscriptionStatusActive || subscription.PendingOrderId > 0 {
+		return errorx.WithCause(errorx.ErrBadRequest, "只有生效中且没有待支付续费订单的订阅可以暂停")
+	}
+	now := time.Now()
+	return uc.updateSubscription(ctx, subscription, trade.SubscriptionStatusActive, map[string]interface{}{
+		"status":         trade.SubscriptionStatusPaused,
+		"paused_at":      now,
+		"next_charge_at": nil,
+	})
+}
+
+// ResumeSubscription 恢复暂停的订阅, 或撤销当期结束后取消
+func (uc *SubscriptionUseCase) ResumeSubscription(ctx context.Context, subscription *trade.Subscription) error {
+	switch {
+	case subscription.Status == trade.SubscriptionStatusPaused:
+		periodEnd := subscription.CurrentPeriodEnd
+		if subscription.PausedAt != nil {
+			periodEnd = periodEnd.Add(time.Since(*subscription.PausedAt))
+		}
+		return uc.updateSubscription(ctx, subscription, trade.SubscriptionStatusPaused, map[string]interface{}{
+			"status":             trade.SubscriptionStatusActive,
+			"paused_at":          nil,
+			"current_period_end": periodEnd,
+			"next_charge_at":     periodEnd,
+		})
+
+	case subscription.Status == trade.SubscriptionStatusActive && subscription.CancelAtPeriodEnd:
+		return uc.updateSubscription(ctx, subscription, trade.SubscriptionStatusActive, map[string]interface{}{
+			"cancel_at_period_end": false,
+			"cancelled_at":         nil,
+			"cancel_reason":        "",
+		})
+	}
+	return errorx.WithCause(errorx.ErrBadRequest, "该订阅无需恢复")
+}
+
+// CancelSubscription
+//
+//	@Description: 生效中的订阅默认在当期结束后取消, 已付的当期继续有效; 暂停或逾期的订阅立即取消
+//	@receiver uc
+//	@param ctx
+//	@param subscription
+//	@param immediately 立即取消, 关闭待支付的续费订单
+//	@param reason
+//	@return error
+func (uc *SubscriptionUseCase) CancelSubscription(ctx context.Context, subscription *trade.Subscription, immediately bool, reason string) error {
+	switch subscription.Status {
+	case trade.SubscriptionStatusActive:
+		if !immediately && subscription.PendingOrderId == 0 {
+			if subscription.CancelAtPeriodEnd {
+				return errorx.WithCause(errorx.ErrBadRequest, "该订阅已设置当期结束后取消")
+			}
+			return uc.updateSubscription(ctx, subscription, trade.SubscriptionStatusActive, map[string]interface{}{
+				"cancel_at_period_end": true,
+				"cancelled_at":         time.Now(),
+				"cancel_reason":        reason,
+			})
+		}
+	case trade.SubscriptionStatusPaused, trade.SubscriptionStatusPastDue:
+	default:
+		return errorx.WithCause(errorx.ErrBadRequest, "该订阅已结束")
+	}
+
+	return uc.endSubscription(ctx, subscription, trade.SubscriptionStatusCancelled, reason)
+}
+
+// endSubscription 结束订阅, 关闭未付清的续费订单
+func (uc *SubscriptionUseCase) endSubscription(ctx context.Context, subscription *trade.Subscription, toStatus string, reason string) error {
+	now := time.Now()
+	values := map[string]interface{}{
+		"status":           toStatus,
+		"pending_order_id": 0,
+		"next_charge_at":   nil,
+		"next_retry_at":    nil,
+		"ended_at":         now,
+	}
+	if toStatus == trade.SubscriptionStatusCancelled {
+		values["cancel_reason"] = reason
+		if subscription.CancelledAt == nil {
+			values["cancelled_at"] = now
+		}
+	}
+	if err := uc.updateSubscription(ctx, subscription, subscription.Status, values); err != nil {
+		return err
+	}
+
+	if subscription.PendingOrderId > 0 {
+		uc.releaseRenewalOrder(ctx, subscription.PendingOrderId)
+	}
+	return nil
+}
+
+func (uc *SubscriptionUseCase) releaseRenewalOrder(ctx context.Context, orderId int64) {
+	order, err := uc.order.GetOrder(ctx, orderId)
+	if err != nil || !uc.order.IsOrderStatusSameAs(ctx, order, trade.OrderStatusToBePaid) {
+		return
+	}
+	uc.payment.ReleaseOrderPayments(ctx, order)
+	if _, err = uc.order.ChangeOrderStatusFromTo(ctx, order, trade.OrderStatusToBePaid, trade.OrderStatusCancelled); err != nil {
+		logx.WithContext(ctx).Errorf("关闭续费订单%s失败:%s", order.OrderNumber, err.Error())
+		return
+	}
+	if err = uc.order.ReleaseOrderInventories(ctx, order); err != nil {
+		logx.WithContext(ctx).Errorf("关闭续费订单%s-退回库存失败:%s", order.OrderNumber, err.Error())
+	}
+}
+
+// createRenewalOrder 按首期订单的订单项和收货地址生成待付款的续费订单, 价格沿用首期价格
+func (uc *SubscriptionUseCase) createRenewalOrder(ctx context.Context, subscription *trade.Subscription) (*trade.Order, error) {
+	var origin trade.Order
+	err := uc.db.WithContext(ctx).
+		Preload("Items").
+		Preload("DeliveryAddress").
+		First(&origin, subscription.OriginOrderId).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "find subscription origin order failed")
+	}
+
+	orderTypeId := uc.order.GetOrderTypeId(ctx, trade.OrderTypeSubscription)
+	orderStatusId := uc.order.GetOrderStatusId(ctx, trade.OrderStatusToBePaid)
+	order := &trade.Order{
+		CustomerId:     subscription.CustomerId,
+		SubscriptionId: subscription.Id,
+		Type:           orderTypeId,
+		Status:         orderStatusId,
+		OrderNumber:    trade.GenerateOrderNumber(),
+		UnitPrice:      origin.UnitPrice,
+		ListPrice:      origin.ListPrice,
+		Discount:       origin.Discount,
+		Comment:        fmt.Sprintf("订阅%s第%d期续费", subscription.SubscriptionNumber, subscription.PaidCycles+1),
+		ShippingMethod: origin.ShippingMethod,
+	}
+	for _, item := range origin.Items {
+		order.Items = append(order.Items, &trade.OrderItem{
+			PriceBookEntryId: item.PriceBookEntryId,
+			CustomerId:       item.CustomerId,
+			CoverImageId:     item.CoverImageId,
+			Type:             orderTypeId,
+			Status:           orderStatusId,
+			ProductName:      item.ProductName,
+			SkuNo:            item.SkuNo,
+			Quantity:         item.Quantity,
+			UnitPrice:        item.UnitPrice,
+			ListPrice:        item.ListPrice,
+			Discount:         item.Discount,
+		})
+	}
+
+	err = uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(order).Error; err != nil {
+			return err
+		}
+		if origin.DeliveryAddress != nil {
+			deliveryAddress := *origin.DeliveryAddress
+			deliveryAddress.PowerModel = nil
+			deliveryAddress.OrderId = order.Id
+			if err := tx.Create(&deliveryAddress).Error; err != nil {
+				return err
+			}
+		}
+		result := tx.Model(&trade.Subscription{}).
+			Where("id = ? AND pending_order_id = 0", subscription.Id).
+			Update("pending_order_id", order.Id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("subscription renewal order already created")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.PendingOrderId = order.Id
+	return order, nil
+}
+
+// chargeRenewal 使用代币余额自动扣款, 扣款失败进入催缴, 客户也可以自行支付续费订单
+func (uc *SubscriptionUseCase) chargeRenewal(ctx context.Context, subscription *trade.Subscription) {
+	var order trade.Order
+	if err := uc.db.WithContext(ctx).First(&order, subscription.PendingOrderId).Error; err != nil {
+		panic(err)
+	}
+	if !uc.order.IsOrderStatusSameAs(ctx, &order, trade.OrderStatusToBePaid) {
+		return
+	}
+	// 客户单独查询, 避免订单状态变更时连带保存客户
+	var customer customerdomain.Customer
+	if err := uc.db.WithContext(ctx).First(&customer, order.CustomerId).Error; err != nil {
+		panic(err)
+	}
+
+	tokenTypeId := uc.payment.GetPaymentTypeId(ctx, trade.PaymentTypeToken)
+	payment, _, err := uc.payment.CreatePaymentFromOrder(ctx, &customer, &order, tokenTypeId, 0, "")
+	if err == nil && uc.payment.IsPaymentStatusSameAs(ctx, payment, trade.PaymentStatusPaid) {
+		var settled bool
+		settled, err = uc.payment.SettleOrderAfterPaid(ctx, &order)
+		if err == nil {
+			if settled {
+				uc.notification.NotifyOrderEvent(wechatModel.NotificationEventOrderPaid, order.Id, nil)
+			}
+			return
+		}
+	}
+
+	reason := "续费扣款失败"
+	if err != nil {
+		reason = failReasonOf(err)
+	}
+	uc.handleRenewalFailed(ctx, subscription, &order, reason)
+}
+
+// handleRenewalFailed 按催缴计划安排重试, 重试用尽后取消订阅并关闭续费订单
+func (uc *SubscriptionUseCase) handleRenewalFailed(ctx context.Context, subscription *trade.Subscription, order *trade.Order, reason string) {
+	attempts := subscription.DunningAttempts + 1
+	retryAt, ok := GetDunningRetryAt(attempts, time.Now())
+	if !ok {
+		subscription.DunningAttempts = attempts
+		if err := uc.endSubscription(ctx, subscription, trade.SubscriptionStatusCancelled, "续费多次扣款失败: "+reason); err != nil {
+			logx.WithContext(ctx).Errorf("订阅%s催缴结束取消失败:%s", subscription.SubscriptionNumber, err.Error())
+		}
+		return
+	}
+
+	err := uc.updateSubscription(ctx, subscription, subscription.Status, map[string]interface{}{
+		"status":           trade.SubscriptionStatusPastDue,
+		"dunning_attempts": attempts,
+		"next_retry_at":    retryAt,
+		"last_fail_reason": reason,
+	})
+	if err != nil {
+		logx.WithContext(ctx).Errorf("订阅%s记录扣款失败出错:%s", subscription.SubscriptionNumber, err.Error())
+		return
+	}
+
+	uc.notification.NotifyOrderEvent(wechatModel.NotificationEventOrderRenewalFailed, order.Id, map[string]string{
+		"retryAt":    retryAt.Format("2006-01-02 15:04"),
+		"failReason": reason,
+	})
+}
+
+// processDueSubscription 到达扣费时间的订阅: 期满或设置了当期结束后取消的订阅结束, 其余生成续费订单并扣款
+func (uc *SubscriptionUseCase) processDueSubscription(ctx context.Context, subscription *trade.Subscription) {
+	if subscription.CancelAtPeriodEnd {
+		_ = uc.endSubscription(ctx, subscription, trade.SubscriptionStatusCancelled, subscription.CancelReason)
+		return
+	}
+	if subscription.TotalCycles > 0 && subscription.PaidCycles >= subscription.TotalCycles {
+		_ = uc.endSubscription(ctx, subscription, trade.SubscriptionStatusExpired, "")
+		return
+	}
+
+	if _, err := uc.createRenewalOrder(ctx, subscription); err != nil {
+		logx.WithContext(ctx).Errorf("订阅%s生成续费订单失败:%s", subscription.SubscriptionNumber, err.Error())
+		return
+	}
+	uc.chargeRenewal(ctx, subscription)
+}
+
+// ProcessSubscriptions 处理到期续费和催缴重试, 返回处理的订阅数
+func (uc *SubscriptionUseCase) ProcessSubscriptions(ctx context.Context, now time.Time) int {
+	var dueSubscriptions []*trade.Subscription
+	err := uc.db.WithContext(ctx).
+		Where("status = ? AND pending_order_id = 0 AND next_charge_at <= ?", trade.SubscriptionStatusActive, now).
+		Order("next_charge_at").
+		Find(&dueSubscriptions).Error
+	if err != nil {
+		panic(err)
+	}
+
+	var retrySubscriptions []*trade.Subscription
+	err = uc.db.WithContext(ctx).
+		Where("status = ? AND pending_order_id > 0 AND next_retry_at <= ?", trade.SubscriptionStatusPastDue, now).
+		Order("next_retry_at").
+		Find(&retrySubscriptions).Error
+	if err != nil {
+		panic(err)
+	}
+
+	for _, subscription := range dueSubscriptions {
+		uc.safeProcess(ctx, subscription, uc.processDueSubscription)
+	}
+	for _, subscription := range retrySubscriptions {
+		uc.safeProcess(ctx, subscription, uc.chargeRenewal)
+	}
+	return len(dueSubscriptions) + len(retrySubscriptions)
+}
+
+func (uc *SubscriptionUseCase) safeProcess(ctx context.Context, subscription *trade.Subscription,
+	process func(ctx context.Context, subscription *trade.Subscription),
+) {
+	defer func() {
+		if r := recover(); r != nil {
+			logx.WithContext(ctx).Errorf("订阅%s处理失败:%v", subscription.SubscriptionNumber, r)
+		}
+	}()
+	process(ctx, subscription)
+}
+
+// Schedule 每10分钟处理到期续费和催缴重试
+func (uc *SubscriptionUseCase) Schedule(c *cron.Cron) {
+	_, _ = c.AddFunc(`*/10 * * * *`, func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logx.Errorf("subscription renewal panic: %v", r)
+			}
+		}()
+		uc.ProcessSubscriptions(context.Background(), time.Now())
+	})
+}
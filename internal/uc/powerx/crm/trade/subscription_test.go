@@ -0,0 +1,60 @@
+package trade
+
+import (
+	"PowerX/internal/model/crm/product"
+	"testing"
+	"time"
+)
+
+func TestAddBillingInterval(t *testing.T) {
+
+	layout := "2006-01-02 15:04"
+	cases := []struct {
+		start string
+		unit  string
+		count int
+		want  string
+	}{
+		{"2023-01-10 08:30", product.BillingIntervalDay, 10, "2023-01-20 08:30"},
+		{"2023-01-10 08:30", product.BillingIntervalWeek, 2, "2023-01-24 08:30"},
+		{"2023-01-31 08:30", product.BillingIntervalMonth, 1, "2023-02-28 08:30"},
+		{"2024-01-31 08:30", product.BillingIntervalMonth, 1, "2024-02-29 08:30"},
+		{"2023-03-31 08:30", product.BillingIntervalMonth, 3, "2023-06-30 08:30"},
+		{"2023-11-15 08:30", product.BillingIntervalMonth, 2, "2024-01-15 08:30"},
+		{"2024-02-29 08:30", product.BillingIntervalYear, 1, "2025-02-28 08:30"},
+	}
+	for _, c := range cases {
+		start, _ := time.ParseInLocation(layout, c.start, time.Local)
+		got := AddBillingInterval(start, c.unit, c.count).Format(layout)
+		if got != c.want {
+			t.Errorf("%s + %d %s = %s, want %s", c.start, c.count, c.unit, got, c.want)
+		}
+	}
+}
+
+func TestGetDunningRetryAt(t *testing.T) {
+
+	failedAt := time.Date(2023, 5, 1, 10, 0, 0, 0, time.Local)
+	for i, days := range SubscriptionDunningRetryDays {
+		retryAt, ok := GetDunningRetryAt(i+1, failedAt)
+		if !ok || !retryAt.Equal(failedAt.AddDate(0, 0, days)) {
+			t.Errorf("attempt %d retry at %v, ok %v", i+1, retryAt, ok)
+		}
+	}
+	if _, ok := GetDunningRetryAt(len(SubscriptionDunningRetryDays)+1, failedAt); ok {
+		t.Errorf("retry should stop after %d attempts", len(SubscriptionDunningRetryDays))
+	}
+}
+
+func TestValidateBillingInterval(t *testing.T) {
+
+	if err := ValidateBillingInterval(product.BillingIntervalMonth, 1); err != nil {
+		t.Errorf("valid interval got err %v", err)
+	}
+	if err := ValidateBillingInterval("quarter", 1); err == nil {
+		t.Errorf("unknown unit should be invalid")
+	}
+	if err := ValidateBillingInterval(product.BillingIntervalWeek, 0); err == nil {
+		t.Errorf("zero count should be invalid")
+	}
+}
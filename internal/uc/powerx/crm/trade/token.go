@@ -24,7 +24,7 @@ func NewTokenUseCase(db *gorm.DB) *TokenUseCase {
 type FindManyTokensOption struct {
 	CustomerId int64
 
-	OrderBy string
+	QuerySpec *powermodel.QuerySpec
 	types.PageEmbedOption
 }
 
@@ -34,10 +34,8 @@ func (uc *TokenUseCase) buildFindQueryNoPage(db *gorm.DB, opt *FindManyTokensOpt
 		db = db.Where("customer_id = ?", opt.CustomerId)
 	}
 
+	db = opt.QuerySpec.Apply(db)
 	orderBy := "id desc"
-	if opt.OrderBy != "" {
-		orderBy = opt.OrderBy + "," + orderBy
-	}
 	db.Order(orderBy)
 
 	return db
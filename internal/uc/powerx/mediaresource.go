@@ -31,6 +31,7 @@ type MediaResourceUseCase struct {
 }
 
 const BucketMediaResourceProduct = "bucket.product"
+const BucketMediaResourceReview = "bucket.review"
 
 func NewMediaResourceUseCase(db *gorm.DB, conf *config.Config) *MediaResourceUseCase {
 	// 使用Minio API SDK
@@ -159,6 +160,25 @@ func (uc *MediaResourceUseCase) MakeMediaResource(ctx context.Context, bucket st
 	return
 }
 
+// MakeCustomerMediaResource 客户上传的媒体资源, 记录所属客户以便校验引用
+func (uc *MediaResourceUseCase) MakeCustomerMediaResource(ctx context.Context, customerId int64, bucket string, handle *multipart.FileHeader) (resource *media.MediaResource, err error) {
+
+	if uc.OSSClient != nil {
+		resource, err = uc.MakeOSSResource(ctx, bucket, handle)
+	} else {
+		resource, err = uc.MakeLocalResource(ctx, bucket, handle)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	resource.CustomerId = customerId
+	err = uc.CreateMediaResource(ctx, resource)
+
+	return
+}
+
 func (uc *MediaResourceUseCase) MakeLocalResource(ctx context.Context, bucket string, handle *multipart.FileHeader) (resource *media.MediaResource, err error) {
 
 	// 获取文件名和文件大小
@@ -0,0 +1,194 @@
+package dtalk
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/zeromicro/go-zero/core/logx"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 提前刷新access_token, 避免临界过期
+const tokenRefreshAhead = 5 * time.Minute
+
+// ResponseDTalk
+// @Description: 钉钉接口公共返回
+type ResponseDTalk struct {
+	ErrCode   int    `json:"errcode"`
+	ErrMsg    string `json:"errmsg"`
+	RequestId string `json:"request_id,omitempty"`
+}
+
+// Error
+//
+//	@Description:
+//	@receiver r
+//	@return error
+func (r ResponseDTalk) Error() error {
+	if r.ErrCode == 0 {
+		return nil
+	}
+	return fmt.Errorf(`dtalk errcode: %d, errmsg: %s`, r.ErrCode, r.ErrMsg)
+}
+
+type client struct {
+	baseUrl     string
+	appKey      string
+	appSecret   string
+	robotSecret string
+	debug       bool
+	http        *http.Client
+	now         func() time.Time
+
+	mu       sync.Mutex
+	token    string
+	expireAt time.Time
+}
+
+// accessToken
+//
+//	@Description: 企业内部应用access_token, 内存缓存
+//	@receiver c
+//	@param ctx
+//	@return string
+//	@return error
+func (c *client) accessToken(ctx context.Context) (string, error) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != `` && c.now().Before(c.expireAt) {
+		return c.token, nil
+	}
+
+	reply := struct {
+		ResponseDTalk
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}{}
+	query := url.Values{`appkey`: {c.appKey}, `appsecret`: {c.appSecret}}
+	if err := c.do(ctx, http.MethodGet, `/gettoken`, query, nil, &reply); err != nil {
+		return ``, err
+	}
+	if err := reply.Error(); err != nil {
+		return ``, err
+	}
+	c.token = reply.AccessToken
+	c.expireAt = c.now().Add(time.Duration(reply.ExpiresIn)*time.Second - tokenRefreshAhead)
+
+	return c.token, nil
+
+}
+
+// post
+//
+//	@Description: 携带access_token调用开放接口
+//	@receiver c
+//	@param ctx
+//	@param path
+//	@param body
+//	@param out
+//	@return error
+func (c *client) post(ctx context.Context, path string, body interface{}, out interface{}) error {
+
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodPost, path, url.Values{`access_token`: {token}}, body, out)
+
+}
+
+// robot
+//
+//	@Description: 自定义机器人, 配置了加签密钥时追加timestamp/sign
+//	@receiver c
+//	@param ctx
+//	@param accessToken
+//	@param body
+//	@param out
+//	@return error
+func (c *client) robot(ctx context.Context, accessToken string, body interface{}, out interface{}) error {
+
+	query := url.Values{`access_token`: {accessToken}}
+	if c.robotSecret != `` {
+		timestamp := strconv.FormatInt(c.now().UnixMilli(), 10)
+		query.Set(`timestamp`, timestamp)
+		query.Set(`sign`, robotSign(timestamp, c.robotSecret))
+	}
+	return c.do(ctx, http.MethodPost, `/robot/send`, query, body, out)
+
+}
+
+// do
+//
+//	@Description:
+//	@receiver c
+//	@param ctx
+//	@param method
+//	@param path
+//	@param query
+//	@param body
+//	@param out
+//	@return error
+func (c *client) do(ctx context.Context, method string, path string, query url.Values, body interface{}, out interface{}) error {
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(c.baseUrl, `/`)+path+`?`+query.Encode(), reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		request.Header.Set(`Content-Type`, `application/json`)
+	}
+
+	response, err := c.http.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if c.debug {
+		logx.Debugf(`dtalk %s %s: %s`, method, path, data)
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf(`dtalk http status: %d`, response.StatusCode)
+	}
+	return json.Unmarshal(data, out)
+
+}
+
+// robotSign
+//
+//	@Description: HmacSHA256(timestamp+"\n"+secret), base64
+//	@param timestamp
+//	@param secret
+//	@return string
+func robotSign(timestamp string, secret string) string {
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "\n" + secret))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+}
@@ -0,0 +1,61 @@
+package dtalk
+
+import (
+	"PowerX/internal/config"
+	"PowerX/internal/model/scrm/organization"
+	"context"
+	"gorm.io/gorm"
+	"net/http"
+	"time"
+)
+
+var DTalk IDTalkInterface = new(dtalkUseCase)
+
+type dtalkUseCase struct {
+	db      *gorm.DB
+	ctx     context.Context
+	agentId int64
+	client  *client
+	modelDTalkOrganization
+}
+
+type (
+	modelDTalkOrganization struct {
+		department organization.DTalkDepartment
+		employee   organization.DTalkEmployee
+	}
+)
+
+// Repo
+//
+//	@Description:
+//	@param db
+//	@param conf
+//	@return IDTalkInterface
+func Repo(db *gorm.DB, conf config.DingTalk) IDTalkInterface {
+
+	return &dtalkUseCase{
+		db:      db,
+		ctx:     context.TODO(),
+		agentId: conf.AgentId,
+		client: &client{
+			baseUrl:     conf.BaseUrl,
+			appKey:      conf.AppKey,
+			appSecret:   conf.AppSecret,
+			robotSecret: conf.RobotSecret,
+			debug:       conf.HttpDebug,
+			http:        &http.Client{Timeout: 10 * time.Second},
+			now:         time.Now,
+		},
+	}
+
+}
+
+// Name
+//
+//	@Description:
+//	@receiver this
+//	@return string
+func (this *dtalkUseCase) Name() string {
+	return config.SCRMProviderDingTalk
+}
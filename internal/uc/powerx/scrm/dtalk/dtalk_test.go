@@ -0,0 +1,198 @@
+package dtalk
+
+import (
+	"PowerX/internal/uc/powerx/scrm/provider"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// stubServer 模拟钉钉开放平台
+type stubServer struct {
+	*httptest.Server
+	tokenCalls int32
+	lastPath   string
+	lastQuery  map[string]string
+	lastBody   map[string]interface{}
+}
+
+func newStubServer(t *testing.T) *stubServer {
+	stub := &stubServer{}
+	stub.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stub.lastPath = r.URL.Path
+		stub.lastQuery = map[string]string{}
+		for key := range r.URL.Query() {
+			stub.lastQuery[key] = r.URL.Query().Get(key)
+		}
+		stub.lastBody = map[string]interface{}{}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			_ = json.Unmarshal(data, &stub.lastBody)
+		}
+
+		if r.URL.Path != `/gettoken` && r.URL.Path != `/robot/send` && r.URL.Query().Get(`access_token`) != `token` {
+			write(w, `{"errcode":88,"errmsg":"invalid token"}`)
+			return
+		}
+
+		switch r.URL.Path {
+		case `/gettoken`:
+			atomic.AddInt32(&stub.tokenCalls, 1)
+			write(w, `{"errcode":0,"access_token":"token","expires_in":7200}`)
+		case `/topapi/v2/department/get`:
+			write(w, `{"errcode":0,"result":{"dept_id":1,"name":"root"}}`)
+		case `/topapi/v2/department/listsub`:
+			switch stub.lastBody[`dept_id`] {
+			case float64(1):
+				write(w, `{"errcode":0,"result":[{"dept_id":2,"name":"a","parent_id":1},{"dept_id":3,"name":"b","parent_id":1}]}`)
+			case float64(2):
+				write(w, `{"errcode":0,"result":[{"dept_id":4,"name":"a1","parent_id":2}]}`)
+			default:
+				write(w, `{"errcode":0,"result":[]}`)
+			}
+		case `/topapi/v2/user/list`:
+			if stub.lastBody[`cursor`] == float64(0) {
+				write(w, `{"errcode":0,"result":{"has_more":true,"next_cursor":100,"list":[{"userid":"u1","dept_id_list":[2]}]}}`)
+				return
+			}
+			write(w, `{"errcode":0,"result":{"has_more":false,"list":[{"userid":"u2","dept_id_list":[2,3]}]}}`)
+		case `/robot/send`:
+			if r.URL.Query().Get(`access_token`) == `bad` {
+				write(w, `{"errcode":310000,"errmsg":"sign not match"}`)
+				return
+			}
+			write(w, `{"errcode":0,"errmsg":"ok"}`)
+		case `/topapi/message/corpconversation/asyncsend_v2`:
+			write(w, `{"errcode":0,"task_id":256}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(stub.Close)
+	return stub
+}
+
+func write(w http.ResponseWriter, body string) {
+	_, _ = fmt.Fprint(w, body)
+}
+
+func newTestUseCase(stub *stubServer, robotSecret string) *dtalkUseCase {
+	return &dtalkUseCase{
+		ctx:     context.Background(),
+		agentId: 1001,
+		client: &client{
+			baseUrl:     stub.URL,
+			appKey:      `key`,
+			appSecret:   `secret`,
+			robotSecret: robotSecret,
+			http:        stub.Client(),
+			now:         func() time.Time { return time.UnixMilli(1700000000000) },
+		},
+	}
+}
+
+func TestAccessTokenCached(t *testing.T) {
+	stub := newStubServer(t)
+	uc := newTestUseCase(stub, ``)
+
+	for i := 0; i < 3; i++ {
+		if _, err := uc.PullListDTalkEmployeeRequest(context.Background(), 3); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if stub.tokenCalls != 1 {
+		t.Fatalf(`gettoken called %d times, want 1`, stub.tokenCalls)
+	}
+}
+
+func TestPullListDTalkDepartmentRequest(t *testing.T) {
+	stub := newStubServer(t)
+	uc := newTestUseCase(stub, ``)
+
+	departments, err := uc.PullListDTalkDepartmentRequest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []int64
+	for _, department := range departments {
+		ids = append(ids, department.DeptId)
+	}
+	if fmt.Sprint(ids) != `[1 2 3 4]` {
+		t.Fatalf(`departments = %v`, ids)
+	}
+}
+
+func TestPullListDTalkEmployeeRequestPaging(t *testing.T) {
+	stub := newStubServer(t)
+	uc := newTestUseCase(stub, ``)
+
+	users, err := uc.PullListDTalkEmployeeRequest(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 2 || users[0].UserId != `u1` || users[1].UserId != `u2` {
+		t.Fatalf(`users = %+v`, users)
+	}
+	if model := transferDTalkUserToModel(users[1]); model.DTalkDepIds != `2,3` {
+		t.Fatalf(`dep ids = %s`, model.DTalkDepIds)
+	}
+}
+
+func TestSendRobotArticleSigned(t *testing.T) {
+	stub := newStubServer(t)
+	uc := newTestUseCase(stub, `SEC`)
+
+	_, err := uc.SendRobotArticle(context.Background(), `robot`, &provider.Article{
+		Title: `title`,
+		Url:   `https://powerx.io`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stub.lastQuery[`timestamp`] != `1700000000000` {
+		t.Fatalf(`timestamp = %s`, stub.lastQuery[`timestamp`])
+	}
+	if stub.lastQuery[`sign`] != robotSign(`1700000000000`, `SEC`) {
+		t.Fatalf(`sign = %s`, stub.lastQuery[`sign`])
+	}
+	if stub.lastBody[`msgtype`] != `link` {
+		t.Fatalf(`msgtype = %v`, stub.lastBody[`msgtype`])
+	}
+}
+
+func TestSendRobotArticleError(t *testing.T) {
+	stub := newStubServer(t)
+	uc := newTestUseCase(stub, ``)
+
+	if _, err := uc.SendRobotArticle(context.Background(), `bad`, &provider.Article{}); err == nil {
+		t.Fatal(`want errcode surfaced as error`)
+	}
+}
+
+func TestSendWorkNotice(t *testing.T) {
+	stub := newStubServer(t)
+	uc := newTestUseCase(stub, ``)
+
+	err := uc.SendWorkNotice(context.Background(), &provider.Notice{
+		UserIds: []string{`u1`, `u2`},
+		Title:   `title`,
+		Content: `content`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stub.lastPath != `/topapi/message/corpconversation/asyncsend_v2` {
+		t.Fatalf(`path = %s`, stub.lastPath)
+	}
+	if stub.lastBody[`userid_list`] != `u1,u2` || stub.lastBody[`agent_id`] != float64(1001) {
+		t.Fatalf(`body = %v`, stub.lastBody)
+	}
+	if err = uc.SendWorkNotice(context.Background(), &provider.Notice{}); err == nil {
+		t.Fatal(`want error for empty user list`)
+	}
+}
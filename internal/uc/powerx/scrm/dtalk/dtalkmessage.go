@@ -0,0 +1,147 @@
+package dtalk
+
+import (
+	"PowerX/internal/uc/powerx/scrm/provider"
+	"context"
+	"errors"
+	"strings"
+)
+
+type (
+	// RobotMessage
+	// @Description: https://open.dingtalk.com/document/robots/custom-robot-access
+	RobotMessage struct {
+		MsgType  string           `json:"msgtype"`
+		Text     *MessageText     `json:"text,omitempty"`
+		Link     *MessageLink     `json:"link,omitempty"`
+		Markdown *MessageMarkdown `json:"markdown,omitempty"`
+	}
+
+	// WorkNoticeMessage
+	// @Description: https://open.dingtalk.com/document/orgapp/asynchronous-sending-of-enterprise-session-messages
+	WorkNoticeMessage struct {
+		MsgType  string           `json:"msgtype"`
+		Text     *MessageText     `json:"text,omitempty"`
+		Link     *MessageLink     `json:"link,omitempty"`
+		Markdown *MessageMarkdown `json:"markdown,omitempty"`
+	}
+
+	MessageText struct {
+		Content string `json:"content"`
+	}
+
+	MessageLink struct {
+		Title      string `json:"title"`
+		Text       string `json:"text"`
+		MessageUrl string `json:"messageUrl"`
+		PicUrl     string `json:"picUrl,omitempty"`
+	}
+
+	MessageMarkdown struct {
+		Title string `json:"title"`
+		Text  string `json:"text"`
+	}
+)
+
+// PushDTalkRobotRequest
+//
+//	@Description:
+//	@receiver this
+//	@param ctx
+//	@param accessToken
+//	@param message
+//	@return error
+func (this *dtalkUseCase) PushDTalkRobotRequest(ctx context.Context, accessToken string, message *RobotMessage) error {
+
+	reply := ResponseDTalk{}
+	if err := this.client.robot(ctx, accessToken, message, &reply); err != nil {
+		return err
+	}
+	return reply.Error()
+
+}
+
+// PushDTalkWorkNoticeRequest
+//
+//	@Description:
+//	@receiver this
+//	@param ctx
+//	@param userIds
+//	@param message
+//	@return taskId
+//	@return err
+func (this *dtalkUseCase) PushDTalkWorkNoticeRequest(ctx context.Context, userIds []string, message *WorkNoticeMessage) (taskId int64, err error) {
+
+	if len(userIds) == 0 {
+		return 0, errors.New(`dtalk.work.notice.user.empty`)
+	}
+	reply := struct {
+		ResponseDTalk
+		TaskId int64 `json:"task_id"`
+	}{}
+	err = this.client.post(ctx, `/topapi/message/corpconversation/asyncsend_v2`, map[string]interface{}{
+		`agent_id`:    this.agentId,
+		`userid_list`: strings.Join(userIds, `,`),
+		`msg`:         message,
+	}, &reply)
+	if err != nil {
+		return 0, err
+	}
+	return reply.TaskId, reply.Error()
+
+}
+
+// SendRobotArticle
+//
+//	@Description: 对应企业微信机器人图文
+//	@receiver this
+//	@param ctx
+//	@param key
+//	@param article
+//	@return string
+//	@return error
+func (this *dtalkUseCase) SendRobotArticle(ctx context.Context, key string, article *provider.Article) (string, error) {
+
+	err := this.PushDTalkRobotRequest(ctx, key, &RobotMessage{
+		MsgType: `link`,
+		Link: &MessageLink{
+			Title:      article.Title,
+			Text:       article.Description,
+			MessageUrl: article.Url,
+			PicUrl:     article.PicUrl,
+		},
+	})
+	if err != nil {
+		return ``, err
+	}
+	return `ok`, nil
+
+}
+
+// SendWorkNotice
+//
+//	@Description:
+//	@receiver this
+//	@param ctx
+//	@param notice
+//	@return error
+func (this *dtalkUseCase) SendWorkNotice(ctx context.Context, notice *provider.Notice) error {
+
+	message := &WorkNoticeMessage{
+		MsgType: `text`,
+		Text:    &MessageText{Content: notice.Content},
+	}
+	if notice.Url != `` {
+		message = &WorkNoticeMessage{
+			MsgType: `link`,
+			Link: &MessageLink{
+				Title:      notice.Title,
+				Text:       notice.Content,
+				MessageUrl: notice.Url,
+			},
+		}
+	}
+	_, err := this.PushDTalkWorkNoticeRequest(ctx, notice.UserIds, message)
+	return err
+
+}
@@ -0,0 +1,202 @@
+package dtalk
+
+import (
+	"PowerX/internal/model/scrm/organization"
+	"context"
+	"strconv"
+	"strings"
+)
+
+// 根部门
+const rootDepartmentId = 1
+
+type (
+	Department struct {
+		DeptId   int64  `json:"dept_id"`
+		Name     string `json:"name"`
+		ParentId int64  `json:"parent_id"`
+		Order    int64  `json:"order"`
+	}
+
+	User struct {
+		UserId     string  `json:"userid"`
+		UnionId    string  `json:"unionid"`
+		Name       string  `json:"name"`
+		Avatar     string  `json:"avatar"`
+		Mobile     string  `json:"mobile"`
+		Email      string  `json:"email"`
+		Title      string  `json:"title"`
+		DeptIdList []int64 `json:"dept_id_list"`
+		Active     bool    `json:"active"`
+	}
+)
+
+// PullListDTalkDepartmentRequest
+//
+//	@Description: 自根部门逐层拉取子部门
+//	@receiver this
+//	@param ctx
+//	@return departments
+//	@return err
+func (this *dtalkUseCase) PullListDTalkDepartmentRequest(ctx context.Context) (departments []*Department, err error) {
+
+	root := struct {
+		ResponseDTalk
+		Result *Department `json:"result"`
+	}{}
+	if err = this.client.post(ctx, `/topapi/v2/department/get`, map[string]interface{}{`dept_id`: rootDepartmentId}, &root); err != nil {
+		return nil, err
+	}
+	if err = root.Error(); err != nil {
+		return nil, err
+	}
+	if root.Result != nil {
+		departments = append(departments, root.Result)
+	}
+
+	queue := []int64{rootDepartmentId}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+
+		reply := struct {
+			ResponseDTalk
+			Result []*Department `json:"result"`
+		}{}
+		if err = this.client.post(ctx, `/topapi/v2/department/listsub`, map[string]interface{}{`dept_id`: parent}, &reply); err != nil {
+			return nil, err
+		}
+		if err = reply.Error(); err != nil {
+			return nil, err
+		}
+		for _, department := range reply.Result {
+			departments = append(departments, department)
+			queue = append(queue, department.DeptId)
+		}
+	}
+
+	return departments, nil
+
+}
+
+// PullListDTalkEmployeeRequest
+//
+//	@Description:
+//	@receiver this
+//	@param ctx
+//	@param depId
+//	@return users
+//	@return err
+func (this *dtalkUseCase) PullListDTalkEmployeeRequest(ctx context.Context, depId int64) (users []*User, err error) {
+
+	var cursor int64
+	for {
+		reply := struct {
+			ResponseDTalk
+			Result struct {
+				HasMore    bool    `json:"has_more"`
+				NextCursor int64   `json:"next_cursor"`
+				List       []*User `json:"list"`
+			} `json:"result"`
+		}{}
+		err = this.client.post(ctx, `/topapi/v2/user/list`, map[string]interface{}{
+			`dept_id`: depId,
+			`cursor`:  cursor,
+			`size`:    100,
+		}, &reply)
+		if err != nil {
+			return nil, err
+		}
+		if err = reply.Error(); err != nil {
+			return nil, err
+		}
+		users = append(users, reply.Result.List...)
+		if !reply.Result.HasMore {
+			break
+		}
+		cursor = reply.Result.NextCursor
+	}
+
+	return users, nil
+
+}
+
+// PullSyncDTalkDepartmentsAndEmployeesRequest
+//
+//	@Description:
+//	@receiver this
+//	@param ctx
+//	@return error
+func (this *dtalkUseCase) PullSyncDTalkDepartmentsAndEmployeesRequest(ctx context.Context) error {
+
+	departments, err := this.PullListDTalkDepartmentRequest(ctx)
+	if err != nil {
+		return err
+	}
+
+	var deps []*organization.DTalkDepartment
+	employees := make(map[string]*organization.DTalkEmployee)
+	for _, department := range departments {
+		deps = append(deps, &organization.DTalkDepartment{
+			DTalkDepId:    department.DeptId,
+			Name:          department.Name,
+			DTalkParentId: department.ParentId,
+			Order:         department.Order,
+		})
+		users, err := this.PullListDTalkEmployeeRequest(ctx, department.DeptId)
+		if err != nil {
+			return err
+		}
+		// 员工可能属于多个部门, 按userid去重
+		for _, user := range users {
+			employees[user.UserId] = transferDTalkUserToModel(user)
+		}
+	}
+
+	this.modelDTalkOrganization.department.Action(this.db, deps)
+	list := make([]*organization.DTalkEmployee, 0, len(employees))
+	for _, employee := range employees {
+		list = append(list, employee)
+	}
+	this.modelDTalkOrganization.employee.Action(this.db, list)
+
+	return nil
+
+}
+
+// SyncOrganization
+//
+//	@Description:
+//	@receiver this
+//	@param ctx
+//	@return error
+func (this *dtalkUseCase) SyncOrganization(ctx context.Context) error {
+
+	return this.PullSyncDTalkDepartmentsAndEmployeesRequest(ctx)
+
+}
+
+// transferDTalkUserToModel
+//
+//	@Description:
+//	@param user
+//	@return *organization.DTalkEmployee
+func transferDTalkUserToModel(user *User) *organization.DTalkEmployee {
+
+	var deps []string
+	for _, id := range user.DeptIdList {
+		deps = append(deps, strconv.FormatInt(id, 10))
+	}
+	return &organization.DTalkEmployee{
+		DTalkUserId: user.UserId,
+		UnionId:     user.UnionId,
+		Name:        user.Name,
+		Title:       user.Title,
+		Mobile:      user.Mobile,
+		Email:       user.Email,
+		Avatar:      user.Avatar,
+		DTalkDepIds: strings.Join(deps, `,`),
+		Active:      user.Active,
+	}
+
+}
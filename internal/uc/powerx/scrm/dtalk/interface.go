@@ -1,5 +1,74 @@
-package wechat
+package dtalk
+
+import (
+	"PowerX/internal/uc/powerx/scrm/provider"
+	"context"
+)
 
 type IDTalkInterface interface {
+	//
+	//  @Description: 公共SCRM能力
+	//
+	provider.IProviderInterface
+	//
+	//  @Description: organization
+	//
+	iDTalkOrganizationInterface
+	//
+	//  @Description: message
+	//
+	iDTalkMessageInterface
+}
+
+// iDTalkOrganizationInterface
+// @Description: 通讯录
+type iDTalkOrganizationInterface interface {
+	//
+	// PullListDTalkDepartmentRequest
+	//  @Description: 拉取全部部门(含根部门)
+	//  @param ctx
+	//  @return departments
+	//  @return err
+	//
+	PullListDTalkDepartmentRequest(ctx context.Context) (departments []*Department, err error)
+	//
+	// PullListDTalkEmployeeRequest
+	//  @Description: 拉取部门员工
+	//  @param ctx
+	//  @param depId
+	//  @return users
+	//  @return err
+	//
+	PullListDTalkEmployeeRequest(ctx context.Context, depId int64) (users []*User, err error)
+	//
+	// PullSyncDTalkDepartmentsAndEmployeesRequest
+	//  @Description: 同步组织架构到本地
+	//  @param ctx
+	//  @return error
+	//
+	PullSyncDTalkDepartmentsAndEmployeesRequest(ctx context.Context) error
 }
 
+// iDTalkMessageInterface
+// @Description: 消息
+type iDTalkMessageInterface interface {
+	//
+	// PushDTalkRobotRequest
+	//  @Description: 自定义机器人
+	//  @param ctx
+	//  @param accessToken
+	//  @param message
+	//  @return error
+	//
+	PushDTalkRobotRequest(ctx context.Context, accessToken string, message *RobotMessage) error
+	//
+	// PushDTalkWorkNoticeRequest
+	//  @Description: 工作通知
+	//  @param ctx
+	//  @param userIds
+	//  @param message
+	//  @return taskId
+	//  @return err
+	//
+	PushDTalkWorkNoticeRequest(ctx context.Context, userIds []string, message *WorkNoticeMessage) (taskId int64, err error)
+}
@@ -0,0 +1,57 @@
+package provider
+
+import "context"
+
+// IProviderInterface
+// @Description: SCRM服务商(企业微信/钉钉)公共能力, 管理端组织同步与消息接口只依赖此接口
+type IProviderInterface interface {
+	//
+	// Name
+	//  @Description: 服务商标识
+	//  @return string
+	//
+	Name() string
+	//
+	// SyncOrganization
+	//  @Description: 同步部门与员工
+	//  @param ctx
+	//  @return error
+	//
+	SyncOrganization(ctx context.Context) error
+	//
+	// SendRobotArticle
+	//  @Description: 群机器人发送图文
+	//  @param ctx
+	//  @param key 企业微信机器人key/钉钉机器人access_token
+	//  @param article
+	//  @return string 服务商返回信息
+	//  @return error
+	//
+	SendRobotArticle(ctx context.Context, key string, article *Article) (string, error)
+	//
+	// SendWorkNotice
+	//  @Description: 应用消息/工作通知
+	//  @param ctx
+	//  @param notice
+	//  @return error
+	//
+	SendWorkNotice(ctx context.Context, notice *Notice) error
+}
+
+// Article
+// @Description: 图文
+type Article struct {
+	Title       string
+	Description string
+	Url         string
+	PicUrl      string
+}
+
+// Notice
+// @Description: 工作通知
+type Notice struct {
+	UserIds []string
+	Title   string
+	Content string
+	Url     string
+}
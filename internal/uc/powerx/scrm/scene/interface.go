@@ -1,6 +1,9 @@
 package scene
 
-import "PowerX/internal/model/scene"
+import (
+	"PowerX/internal/model/scene"
+	"time"
+)
 
 type IsceneInterface interface {
 
@@ -26,4 +29,44 @@ type iQrcodeInterface interface {
 	//  @param qid
 	//
 	IncreaseSceneCpaNumber(qid string)
+	//
+	// RecordSceneQrcodeScan
+	//  @Description: 记录扫码(明细/日汇总/CPA)
+	//  @param log
+	//  @return *scene.SceneQrcodeCandidate 当前使用中的候选码
+	//  @return error
+	//
+	RecordSceneQrcodeScan(log *scene.SceneQrcodeScanLog) (*scene.SceneQrcodeCandidate, error)
+	//
+	// SwitchSceneQrcodeCandidate
+	//  @Description: 切换到下一个候选群码
+	//  @param qid
+	//  @return *scene.SceneQrcodeCandidate
+	//  @return error
+	//
+	SwitchSceneQrcodeCandidate(qid string) (*scene.SceneQrcodeCandidate, error)
+	//
+	// FindSceneQrcodeCandidates
+	//  @Description: 候选群码
+	//  @param qid
+	//  @return []*scene.SceneQrcodeCandidate
+	//
+	FindSceneQrcodeCandidates(qid string) []*scene.SceneQrcodeCandidate
+	//
+	// ReplaceSceneQrcodeCandidates
+	//  @Description: 覆盖候选群码
+	//  @param qid
+	//  @param candidates
+	//  @return error
+	//
+	ReplaceSceneQrcodeCandidates(qid string, candidates []*scene.SceneQrcodeCandidate) error
+	//
+	// FindSceneQrcodeAnalytics
+	//  @Description: 扫码统计(按天)
+	//  @param qid
+	//  @param start
+	//  @param end
+	//  @return []*SceneQrcodeDailyAnalytics
+	//
+	FindSceneQrcodeAnalytics(qid string, start time.Time, end time.Time) []*SceneQrcodeDailyAnalytics
 }
@@ -0,0 +1,184 @@
+package scene
+
+import (
+	"PowerX/internal/model/scene"
+	"errors"
+	"time"
+)
+
+const analyticsDateLayout = `2006-01-02`
+
+// SceneQrcodeDailyAnalytics
+// @Description: 单日扫码/转化
+type SceneQrcodeDailyAnalytics struct {
+	Date               string
+	ScanCount          int
+	UniqueVisitorCount int
+	AddedCount         int
+}
+
+// RecordSceneQrcodeScan
+//
+//	@Description:
+//	@receiver this
+//	@param log
+//	@return *scene.SceneQrcodeCandidate
+//	@return error
+func (this *sceneUseCase) RecordSceneQrcodeScan(log *scene.SceneQrcodeScanLog) (*scene.SceneQrcodeCandidate, error) {
+
+	if log.ScannedAt.IsZero() {
+		log.ScannedAt = time.Now()
+	}
+	unique := this.modelSceneQrcode.scanLog.IsFirstScanOfDay(this.db, log.QId, log.CustomerId, log.Ip, log.ScannedAt)
+
+	if err := this.modelSceneQrcode.scanLog.Create(this.db, log); err != nil {
+		return nil, err
+	}
+	if err := this.modelSceneQrcode.statistics.Increase(this.db, log.QId, log.ScannedAt.Format(analyticsDateLayout), unique); err != nil {
+		return nil, err
+	}
+	this.modelSceneQrcode.qrcode.IncreaseCpa(this.db, log.QId)
+
+	candidate := this.modelSceneQrcode.candidate.FindUsingByQid(this.db, log.QId)
+	if candidate == nil || candidate.Id == 0 {
+		return nil, nil
+	}
+	if err := this.modelSceneQrcode.candidate.IncreaseScan(this.db, candidate.Id); err != nil {
+		return nil, err
+	}
+	candidate.ScanCount++
+
+	return candidate, nil
+
+}
+
+// SwitchSceneQrcodeCandidate
+//
+//	@Description: 当前群码置为已满, 下一个等待中的群码接替真实二维码
+//	@receiver this
+//	@param qid
+//	@return *scene.SceneQrcodeCandidate
+//	@return error
+func (this *sceneUseCase) SwitchSceneQrcodeCandidate(qid string) (*scene.SceneQrcodeCandidate, error) {
+
+	var next *scene.SceneQrcodeCandidate
+	for _, candidate := range this.modelSceneQrcode.candidate.FindByQid(this.db, qid) {
+		switch candidate.State {
+		case scene.CandidateStateUsing:
+			if err := this.modelSceneQrcode.candidate.UpdateState(this.db, candidate.Id, scene.CandidateStateExhausted); err != nil {
+				return nil, err
+			}
+		case scene.CandidateStateWaiting:
+			if next == nil {
+				next = candidate
+			}
+		}
+	}
+	if next == nil {
+		return nil, errors.New(`scene.qrcode.candidate.exhausted`)
+	}
+	if err := this.modelSceneQrcode.candidate.UpdateState(this.db, next.Id, scene.CandidateStateUsing); err != nil {
+		return nil, err
+	}
+	next.State = scene.CandidateStateUsing
+	this.modelSceneQrcode.qrcode.UpdateColumn(this.db, qid, map[string]interface{}{
+		`real_qrcode_link`: next.Link,
+	})
+
+	return next, nil
+
+}
+
+// FindSceneQrcodeCandidates
+//
+//	@Description:
+//	@receiver this
+//	@param qid
+//	@return []*scene.SceneQrcodeCandidate
+func (this *sceneUseCase) FindSceneQrcodeCandidates(qid string) []*scene.SceneQrcodeCandidate {
+
+	return this.modelSceneQrcode.candidate.FindByQid(this.db, qid)
+
+}
+
+// ReplaceSceneQrcodeCandidates
+//
+//	@Description: 第一个候选码立即生效
+//	@receiver this
+//	@param qid
+//	@param candidates
+//	@return error
+func (this *sceneUseCase) ReplaceSceneQrcodeCandidates(qid string, candidates []*scene.SceneQrcodeCandidate) error {
+
+	if qrcode := this.modelSceneQrcode.qrcode.FindByQid(this.db, qid); qrcode == nil || qrcode.Id == 0 {
+		return errors.New(`scene.qrcode.not.found`)
+	}
+	for index, candidate := range candidates {
+		candidate.QId = qid
+		candidate.Sort = index
+		candidate.State = scene.CandidateStateWaiting
+		if candidate.MemberCap <= 0 {
+			candidate.MemberCap = scene.DefaultGroupMemberCap
+		}
+	}
+	if len(candidates) > 0 {
+		candidates[0].State = scene.CandidateStateUsing
+	}
+	if err := this.modelSceneQrcode.candidate.Replace(this.db, qid, candidates); err != nil {
+		return err
+	}
+	if len(candidates) > 0 {
+		this.modelSceneQrcode.qrcode.UpdateColumn(this.db, qid, map[string]interface{}{
+			`real_qrcode_link`: candidates[0].Link,
+		})
+	}
+	return nil
+
+}
+
+// FindSceneQrcodeAnalytics
+//
+//	@Description: 转化=通过该活码(state=qid)添加的外部联系人
+//	@receiver this
+//	@param qid
+//	@param start
+//	@param end
+//	@return []*SceneQrcodeDailyAnalytics
+func (this *sceneUseCase) FindSceneQrcodeAnalytics(qid string, start time.Time, end time.Time) []*SceneQrcodeDailyAnalytics {
+
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
+
+	series := make([]*SceneQrcodeDailyAnalytics, 0)
+	index := make(map[string]*SceneQrcodeDailyAnalytics)
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		item := &SceneQrcodeDailyAnalytics{Date: day.Format(analyticsDateLayout)}
+		series = append(series, item)
+		index[item.Date] = item
+	}
+
+	statistics := this.modelSceneQrcode.statistics.FindByDateRange(this.db, qid, start.Format(analyticsDateLayout), end.Format(analyticsDateLayout))
+	for _, val := range statistics {
+		if item, ok := index[val.Date]; ok {
+			item.ScanCount = val.ScanCount
+			item.UniqueVisitorCount = val.UniqueVisitorCount
+		}
+	}
+
+	var createTimes []int
+	err := this.db.Table(this.modelSceneQrcode.follow.TableName()).
+		Where(`state = ? AND create_time >= ? AND create_time < ?`, qid, start.Unix(), end.AddDate(0, 0, 1).Unix()).
+		Pluck(`create_time`, &createTimes).Error
+	if err != nil {
+		panic(err)
+	}
+	for _, createTime := range createTimes {
+		date := time.Unix(int64(createTime), 0).In(start.Location()).Format(analyticsDateLayout)
+		if item, ok := index[date]; ok {
+			item.AddedCount++
+		}
+	}
+
+	return series
+
+}
@@ -2,6 +2,7 @@ package scene
 
 import (
 	"PowerX/internal/model/scene"
+	"PowerX/internal/model/scrm/customer"
 	"context"
 	"github.com/zeromicro/go-zero/core/stores/redis"
 	"gorm.io/gorm"
@@ -17,7 +18,11 @@ type sceneUseCase struct {
 }
 type (
 	modelSceneQrcode struct {
-		qrcode scene.SceneQrcode
+		qrcode     scene.SceneQrcode
+		scanLog    scene.SceneQrcodeScanLog
+		statistics scene.SceneQrcodeDailyStatistics
+		candidate  scene.SceneQrcodeCandidate
+		follow     customer.WeWorkExternalContactFollow
 	}
 )
 
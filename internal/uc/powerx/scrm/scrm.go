@@ -2,6 +2,8 @@ package scrm
 
 import (
 	"PowerX/internal/config"
+	"PowerX/internal/uc/powerx/scrm/dtalk"
+	"PowerX/internal/uc/powerx/scrm/provider"
 	"PowerX/internal/uc/powerx/scrm/wechat"
 	"fmt"
 	"github.com/ArtisanCloud/PowerWeChat/v3/src/work"
@@ -18,7 +20,9 @@ type SCRMUseCase struct {
 	Cron   *cron.Cron
 	Wework *work.Work
 	Wechat wechat.IWechatInterface
-	//DTalk
+	DTalk  dtalk.IDTalkInterface
+	// Provider 当前启用的服务商(SCRM.Provider)
+	Provider provider.IProviderInterface
 }
 
 func NewSCRMUseCase(db *gorm.DB, conf *config.Config, c *cron.Cron, kv *redis.Redis) *SCRMUseCase {
@@ -37,12 +41,20 @@ func NewSCRMUseCase(db *gorm.DB, conf *config.Config, c *cron.Cron, kv *redis.Re
 	if err != nil {
 		panic(err)
 	}
-	return &SCRMUseCase{
+	uc := &SCRMUseCase{
 		db:     db,
 		Cron:   c,
 		Wework: wework,
 		Wechat: wechat.Repo(db, wework, kv),
+		DTalk:  dtalk.Repo(db, conf.DingTalk),
 	}
+	switch conf.SCRM.Provider {
+	case config.SCRMProviderDingTalk:
+		uc.Provider = uc.DTalk
+	default:
+		uc.Provider = &weworkProvider{agentId: conf.WeWork.AgentId, wechat: uc.Wechat}
+	}
+	return uc
 }
 
 // Schedule
@@ -24,7 +24,9 @@ import (
 	"github.com/ArtisanCloud/PowerWeChat/v3/src/work/message/appChat/response"
 	appReq "github.com/ArtisanCloud/PowerWeChat/v3/src/work/message/request"
 	appResp "github.com/ArtisanCloud/PowerWeChat/v3/src/work/message/response"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/work/server/handlers/models"
 	"mime/multipart"
+	"time"
 )
 
 type IWechatInterface interface {
@@ -71,6 +73,18 @@ type IWechatInterface interface {
 	//  @Description: tag
 	//
 	iTagInterface
+	//
+	//  @Description: welcome
+	//
+	iWelcomeInterface
+	//
+	//  @Description: transfer
+	//
+	iTransferInterface
+	//
+	//  @Description: customer group chat
+	//
+	iCustomerGroupInterface
 }
 
 // iWeWorkDepartmentInterface
@@ -134,6 +148,14 @@ type iWeWorkCustomerInterface interface {
 	//  @return err
 	//
 	PullListWeWorkCustomerGroupRequest(opt *customerGroupReq.RequestGroupChatList) (list []*customerGroupResp.ResponseGroupChatGet, err error)
+	//
+	// PullWeWorkCustomerGroupMemberCount
+	//  @Description: 客户群当前人数
+	//  @param chatID
+	//  @return count
+	//  @return err
+	//
+	PullWeWorkCustomerGroupMemberCount(chatID string) (count int, err error)
 
 	//
 	// FindManyWechatCustomerPage
@@ -416,3 +438,187 @@ type iTagInterface interface {
 	//
 	ActionWeWorkCustomerTagRequest(option *tagReq.RequestTagMarkTag) (*kresp.ResponseWork, error)
 }
+
+//
+//  iWelcomeInterface
+//  @Description: 欢迎语
+//
+type iWelcomeInterface interface {
+	//
+	// FindWeWorkCustomerWelcomeRulePage
+	//  @Description: 欢迎语规则分页
+	//  @param option
+	//  @return reply
+	//  @return err
+	//
+	FindWeWorkCustomerWelcomeRulePage(option *types.PageOption[types.ListWeWorkCustomerWelcomeRuleRequest]) (reply *types.Page[*customer.WeWorkCustomerWelcomeRule], err error)
+	//
+	// CreateWeWorkCustomerWelcomeRule
+	//  @Description: 创建欢迎语规则
+	//  @param opt
+	//  @return err
+	//
+	CreateWeWorkCustomerWelcomeRule(opt *types.WeWorkCustomerWelcomeRuleRequest) (err error)
+	//
+	// UpdateWeWorkCustomerWelcomeRule
+	//  @Description: 更新欢迎语规则
+	//  @param opt
+	//  @return err
+	//
+	UpdateWeWorkCustomerWelcomeRule(opt *types.WeWorkCustomerWelcomeRuleRequest) (err error)
+	//
+	// ActionWeWorkCustomerWelcomeRule
+	//  @Description: 启用，禁用，删除
+	//  @param id
+	//  @param action
+	//  @return error
+	//
+	ActionWeWorkCustomerWelcomeRule(id int64, action int) error
+	//
+	// FindWeWorkCustomerWelcomeLogPage
+	//  @Description: 欢迎语执行记录
+	//  @param option
+	//  @return reply
+	//  @return err
+	//
+	FindWeWorkCustomerWelcomeLogPage(option *types.PageOption[types.ListWeWorkCustomerWelcomeLogRequest]) (reply *types.Page[*customer.WeWorkCustomerWelcomeLog], err error)
+	//
+	// InvokeWeWorkCustomerWelcomeRule
+	//  @Description: 添加外部联系人事件
+	//  @param event
+	//  @return error
+	//
+	InvokeWeWorkCustomerWelcomeRule(event *models.EventExternalUserAdd) error
+}
+
+//
+//  iTransferInterface
+//  @Description: 在职/离职继承
+//
+type iTransferInterface interface {
+	//
+	// FindWeWorkEmployeeHandover
+	//  @Description: 员工名下的客户与客户群
+	//  @param userId
+	//  @return customers
+	//  @return chats
+	//  @return err
+	//
+	FindWeWorkEmployeeHandover(userId string) (customers []*customer.WeWorkExternalContactFollow, chats []*customerGroupResp.GroupChat, err error)
+	//
+	// TransferWeWorkEmployee
+	//  @Description: 分配客户与客户群
+	//  @param opt
+	//  @return reply
+	//  @return err
+	//
+	TransferWeWorkEmployee(opt *types.TransferWeWorkEmployeeRequest) (reply *types.TransferWeWorkEmployeeReply, err error)
+	//
+	// SyncWeWorkCustomerTransferResult
+	//  @Description: 同步客户接替状态
+	//  @return updated
+	//  @return err
+	//
+	SyncWeWorkCustomerTransferResult() (updated int, err error)
+	//
+	// HandleWeWorkCustomerTransferFail
+	//  @Description: 客户接替失败事件
+	//  @param event
+	//
+	HandleWeWorkCustomerTransferFail(event *models.EventExternalTransferFail)
+	//
+	// FindWeWorkCustomerTransferPage
+	//  @Description: 继承记录
+	//  @param option
+	//  @return reply
+	//  @return err
+	//
+	FindWeWorkCustomerTransferPage(option *types.PageOption[types.ListWeWorkCustomerTransferRequest]) (reply *types.Page[*customer.WeWorkCustomerTransfer], err error)
+}
+
+//
+//  iCustomerGroupInterface
+//  @Description: 客户群管理
+//
+type iCustomerGroupInterface interface {
+	//
+	// SyncWeWorkCustomerGroup
+	//  @Description: 全量同步客户群及成员
+	//  @return count
+	//  @return err
+	//
+	SyncWeWorkCustomerGroup() (count int, err error)
+	//
+	// SyncWeWorkCustomerGroupChat
+	//  @Description: 同步单个客户群
+	//  @param chatId
+	//  @param quitScene
+	//  @return error
+	//
+	SyncWeWorkCustomerGroupChat(chatId string, quitScene int) error
+	//
+	// HandleWeWorkCustomerGroupEvent
+	//  @Description: 客户群变更事件
+	//  @param changeType
+	//  @param chatId
+	//  @param quitScene
+	//  @return error
+	//
+	HandleWeWorkCustomerGroupEvent(changeType string, chatId string, quitScene string) error
+	//
+	// FindWeWorkCustomerGroupPage
+	//  @Description: 客户群分页
+	//  @param option
+	//  @return reply
+	//  @return err
+	//
+	FindWeWorkCustomerGroupPage(option *types.PageOption[types.ListWeWorkCustomerGroupPageRequest]) (reply *types.Page[*customer.WeWorkCustomerGroup], err error)
+	//
+	// FindWeWorkCustomerGroupTags
+	//  @Description: 群标签
+	//  @param chatIds
+	//  @return map[string][]string
+	//
+	FindWeWorkCustomerGroupTags(chatIds []string) map[string][]string
+	//
+	// ActionWeWorkCustomerGroupTag
+	//  @Description: 设置群标签
+	//  @param chatId
+	//  @param tags
+	//  @return error
+	//
+	ActionWeWorkCustomerGroupTag(chatId string, tags []string) error
+	//
+	// FindWeWorkCustomerGroupMembers
+	//  @Description: 群成员
+	//  @param chatId
+	//  @param status
+	//  @return members
+	//
+	FindWeWorkCustomerGroupMembers(chatId string, status int) (members []*customer.WeWorkCustomerGroupMember)
+	//
+	// FindWeWorkCustomerGroupMemberLogPage
+	//  @Description: 群成员变动
+	//  @param option
+	//  @return reply
+	//  @return err
+	//
+	FindWeWorkCustomerGroupMemberLogPage(option *types.PageOption[types.ListWeWorkCustomerGroupMemberLogRequest]) (reply *types.Page[*customer.WeWorkCustomerGroupMemberLog], err error)
+	//
+	// SyncWeWorkCustomerGroupStatistic
+	//  @Description: 同步群聊数据统计
+	//  @param day
+	//  @return count
+	//  @return err
+	//
+	SyncWeWorkCustomerGroupStatistic(day time.Time) (count int, err error)
+	//
+	// FindWeWorkCustomerGroupStatistic
+	//  @Description: 群聊数据统计
+	//  @param owners
+	//  @param startDate
+	//  @param endDate
+	//  @return statistics
+	//
+	FindWeWorkCustomerGroupStatistic(owners []string, startDate string, endDate string) (statistics []*customer.WeWorkCustomerGroupStatistic)
+}
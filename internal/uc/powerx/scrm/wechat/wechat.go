@@ -116,7 +116,16 @@ type (
 		group tag.WeWorkTagGroup
 	}
 	modelWeworkCustomer struct {
-		follow customer.WeWorkExternalContactFollow
+		follow     customer.WeWorkExternalContactFollow
+		welcome    customer.WeWorkCustomerWelcomeRule
+		welcomeLog customer.WeWorkCustomerWelcomeLog
+		transfer   customer.WeWorkCustomerTransfer
+		// group chat
+		group          customer.WeWorkCustomerGroup
+		groupMember    customer.WeWorkCustomerGroupMember
+		groupMemberLog customer.WeWorkCustomerGroupMemberLog
+		groupTag       customer.WeWorkCustomerGroupTag
+		groupStatistic customer.WeWorkCustomerGroupStatistic
 	}
 )
 
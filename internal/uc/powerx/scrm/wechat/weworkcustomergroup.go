@@ -41,6 +41,29 @@ func (this *wechatUseCase) PullListWeWorkCustomerGroupRequest(opt *request.Reque
 
 }
 
+// PullWeWorkCustomerGroupMemberCount
+//
+//	@Description:
+//	@receiver this
+//	@param chatID
+//	@return count
+//	@return err
+func (this *wechatUseCase) PullWeWorkCustomerGroupMemberCount(chatID string) (count int, err error) {
+
+    reply, err := this.wework.ExternalContactGroupChat.Get(this.ctx, chatID, 0)
+    if err != nil {
+        return 0, err
+    }
+    if err = this.help.error(`scrm.wework.get.customer.group.error`, reply.ResponseWork); err != nil {
+        return 0, err
+    }
+    if reply.GroupChat != nil {
+        count = len(reply.GroupChat.MemberList)
+    }
+    return count, nil
+
+}
+
 // PushWoWorkCustomerTemplateRequest
 //
 //	@Description:
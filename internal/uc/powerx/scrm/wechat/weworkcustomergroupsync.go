@@ -0,0 +1,408 @@
+package wechat
+
+import (
+	"PowerX/internal/model/scrm/customer"
+	"PowerX/internal/types"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/kernel/power"
+	groupChatReq "github.com/ArtisanCloud/PowerWeChat/v3/src/work/externalContact/groupChat/request"
+	groupChatResp "github.com/ArtisanCloud/PowerWeChat/v3/src/work/externalContact/groupChat/response"
+	statisticReq "github.com/ArtisanCloud/PowerWeChat/v3/src/work/externalContact/statistics/request"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/work/server/handlers/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SyncWeWorkCustomerGroup
+//
+//	@Description: 全量同步客户群及成员, 未返回的群视为已解散
+//	@receiver this
+//	@return count
+//	@return err
+func (this *wechatUseCase) SyncWeWorkCustomerGroup() (count int, err error) {
+
+	opt := &groupChatReq.RequestGroupChatList{Limit: 1000}
+	alive := make(map[string]bool)
+	for {
+		reply, err := this.wework.ExternalContactGroupChat.List(this.ctx, opt)
+		if err != nil {
+			return count, err
+		}
+		if err = this.help.error(`scrm.wework.list.customer.group.error`, reply.ResponseWork); err != nil {
+			return count, err
+		}
+		for _, chat := range reply.GroupChatList {
+			if err = this.SyncWeWorkCustomerGroupChat(chat.ChatID, 0); err != nil {
+				return count, err
+			}
+			alive[chat.ChatID] = true
+			count++
+		}
+		if reply.NextCursor == `` {
+			break
+		}
+		opt.Cursor = reply.NextCursor
+	}
+
+	var dismissed []string
+	for _, chatId := range this.modelWeworkCustomer.group.FindChatIds(this.db) {
+		if !alive[chatId] {
+			dismissed = append(dismissed, chatId)
+		}
+	}
+	this.modelWeworkCustomer.group.Dismiss(this.db, dismissed)
+
+	return count, nil
+
+}
+
+// SyncWeWorkCustomerGroupChat
+//
+//	@Description: 同步单个客户群, 与本地成员对比生成入群/退群记录
+//	@receiver this
+//	@param chatId
+//	@param quitScene
+//	@return error
+func (this *wechatUseCase) SyncWeWorkCustomerGroupChat(chatId string, quitScene int) error {
+
+	reply, err := this.wework.ExternalContactGroupChat.Get(this.ctx, chatId, 1)
+	if err != nil {
+		return err
+	}
+	if err = this.help.error(`scrm.wework.get.customer.group.error`, reply.ResponseWork); err != nil {
+		return err
+	}
+	if reply.GroupChat == nil {
+		return nil
+	}
+	chat := reply.GroupChat
+
+	var admins []string
+	for _, admin := range chat.AdminList {
+		admins = append(admins, admin.UserID)
+	}
+	this.modelWeworkCustomer.group.Action(this.db, []*customer.WeWorkCustomerGroup{{
+		ChatId:      chat.ChatID,
+		Name:        chat.Name,
+		Owner:       chat.Owner,
+		CreateTime:  chat.CreateTime,
+		Notice:      chat.Notice,
+		AdminList:   strings.Join(admins, `,`),
+		MemberCount: len(chat.MemberList),
+		Status:      customer.GroupStatusNormal,
+	}})
+
+	local := make(map[string]*customer.WeWorkCustomerGroupMember)
+	for _, member := range this.modelWeworkCustomer.groupMember.FindByChatId(this.db, chat.ChatID) {
+		local[member.UserId] = member
+	}
+
+	now := time.Now().Unix()
+	var members []*customer.WeWorkCustomerGroupMember
+	var logs []*customer.WeWorkCustomerGroupMemberLog
+	for _, val := range chat.MemberList {
+		member := transferGroupMemberToModel(chat.ChatID, val)
+		if old, ok := local[val.UserID]; !ok || old.Status == customer.GroupMemberStatusQuit {
+			logs = append(logs, &customer.WeWorkCustomerGroupMemberLog{
+				ChatId:     chat.ChatID,
+				UserId:     member.UserId,
+				Type:       member.Type,
+				Action:     customer.GroupMemberActionJoin,
+				JoinScene:  member.JoinScene,
+				State:      member.State,
+				ActionTime: int64(member.JoinTime),
+			})
+		}
+		delete(local, val.UserID)
+		members = append(members, member)
+	}
+	for _, old := range local {
+		if old.Status == customer.GroupMemberStatusQuit {
+			continue
+		}
+		old.Status, old.QuitTime = customer.GroupMemberStatusQuit, now
+		members = append(members, old)
+		logs = append(logs, &customer.WeWorkCustomerGroupMemberLog{
+			ChatId:     old.ChatId,
+			UserId:     old.UserId,
+			Type:       old.Type,
+			Action:     customer.GroupMemberActionQuit,
+			QuitScene:  quitScene,
+			State:      old.State,
+			ActionTime: now,
+		})
+	}
+	this.modelWeworkCustomer.groupMember.Action(this.db, members)
+	this.modelWeworkCustomer.groupMemberLog.Create(this.db, logs)
+
+	return nil
+
+}
+
+// HandleWeWorkCustomerGroupEvent
+//
+//	@Description: 客户群变更事件
+//	@receiver this
+//	@param changeType
+//	@param chatId
+//	@param quitScene
+//	@return error
+func (this *wechatUseCase) HandleWeWorkCustomerGroupEvent(changeType string, chatId string, quitScene string) error {
+
+	if changeType == models.CALLBACK_EVENT_CHANGE_TYPE_DISMISS {
+		this.modelWeworkCustomer.group.Dismiss(this.db, []string{chatId})
+		return nil
+	}
+	scene, _ := strconv.Atoi(quitScene)
+
+	return this.SyncWeWorkCustomerGroupChat(chatId, scene)
+
+}
+
+// FindWeWorkCustomerGroupPage
+//
+//	@Description:
+//	@receiver this
+//	@param option
+//	@return reply
+//	@return err
+func (this *wechatUseCase) FindWeWorkCustomerGroupPage(option *types.PageOption[types.ListWeWorkCustomerGroupPageRequest]) (reply *types.Page[*customer.WeWorkCustomerGroup], err error) {
+
+	var groups []*customer.WeWorkCustomerGroup
+	var count int64
+	query := this.db.WithContext(this.ctx).Model(customer.WeWorkCustomerGroup{})
+
+	option.DefaultPageIfNotSet()
+	if v := option.Option.Name; v != `` {
+		query.Where(`name LIKE ?`, `%`+v+`%`)
+	}
+	if v := option.Option.Owners; len(v) > 0 {
+		query.Where(`owner IN ?`, v)
+	}
+	if v := option.Option.Tags; len(v) > 0 {
+		query.Where(`chat_id IN (?)`, this.db.Model(customer.WeWorkCustomerGroupTag{}).Select(`chat_id`).Where(`tag IN ?`, v))
+	}
+	if v := option.Option.Status; v > 0 {
+		query.Where(`status = ?`, v)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return nil, err
+	}
+	err = query.Offset((option.PageIndex - 1) * option.PageSize).Limit(option.PageSize).Order(`create_time DESC`).Find(&groups).Error
+
+	return &types.Page[*customer.WeWorkCustomerGroup]{
+		List:      groups,
+		PageIndex: option.PageIndex,
+		PageSize:  option.PageSize,
+		Total:     count,
+	}, err
+
+}
+
+// FindWeWorkCustomerGroupTags
+//
+//	@Description:
+//	@receiver this
+//	@param chatIds
+//	@return map[string][]string
+func (this *wechatUseCase) FindWeWorkCustomerGroupTags(chatIds []string) map[string][]string {
+
+	return this.modelWeworkCustomer.groupTag.FindByChatIds(this.db, chatIds)
+
+}
+
+// ActionWeWorkCustomerGroupTag
+//
+//	@Description:
+//	@receiver this
+//	@param chatId
+//	@param tags
+//	@return error
+func (this *wechatUseCase) ActionWeWorkCustomerGroupTag(chatId string, tags []string) error {
+
+	unique := make(map[string]bool)
+	var values []string
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != `` && !unique[tag] {
+			unique[tag] = true
+			values = append(values, tag)
+		}
+	}
+	return this.modelWeworkCustomer.groupTag.Replace(this.db, chatId, values)
+
+}
+
+// FindWeWorkCustomerGroupMembers
+//
+//	@Description:
+//	@receiver this
+//	@param chatId
+//	@param status
+//	@return members
+func (this *wechatUseCase) FindWeWorkCustomerGroupMembers(chatId string, status int) (members []*customer.WeWorkCustomerGroupMember) {
+
+	for _, member := range this.modelWeworkCustomer.groupMember.FindByChatId(this.db, chatId) {
+		if status == 0 || member.Status == status {
+			members = append(members, member)
+		}
+	}
+	return members
+
+}
+
+// FindWeWorkCustomerGroupMemberLogPage
+//
+//	@Description:
+//	@receiver this
+//	@param option
+//	@return reply
+//	@return err
+func (this *wechatUseCase) FindWeWorkCustomerGroupMemberLogPage(option *types.PageOption[types.ListWeWorkCustomerGroupMemberLogRequest]) (reply *types.Page[*customer.WeWorkCustomerGroupMemberLog], err error) {
+
+	var logs []*customer.WeWorkCustomerGroupMemberLog
+	var count int64
+	query := this.db.WithContext(this.ctx).Model(customer.WeWorkCustomerGroupMemberLog{})
+
+	option.DefaultPageIfNotSet()
+	if v := option.Option.ChatId; v != `` {
+		query.Where(`chat_id = ?`, v)
+	}
+	if v := option.Option.Action; v > 0 {
+		query.Where(`action = ?`, v)
+	}
+	if v := option.Option.State; v != `` {
+		query.Where(`state = ?`, v)
+	}
+	if v := option.Option.StartTime; v > 0 {
+		query.Where(`action_time >= ?`, v)
+	}
+	if v := option.Option.EndTime; v > 0 {
+		query.Where(`action_time <= ?`, v)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return nil, err
+	}
+	err = query.Offset((option.PageIndex - 1) * option.PageSize).Limit(option.PageSize).Order(`action_time DESC, id DESC`).Find(&logs).Error
+
+	return &types.Page[*customer.WeWorkCustomerGroupMemberLog]{
+		List:      logs,
+		PageIndex: option.PageIndex,
+		PageSize:  option.PageSize,
+		Total:     count,
+	}, err
+
+}
+
+// SyncWeWorkCustomerGroupStatistic
+//
+//	@Description: 按群主拉取指定日期的群聊数据统计
+//	@receiver this
+//	@param day
+//	@return count
+//	@return err
+func (this *wechatUseCase) SyncWeWorkCustomerGroupStatistic(day time.Time) (count int, err error) {
+
+	begin := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	owners := this.modelWeworkCustomer.group.FindOwners(this.db)
+
+	for len(owners) > 0 {
+		size := len(owners)
+		if size > 100 {
+			size = 100
+		}
+		batch := owners[:size]
+		owners = owners[size:]
+
+		opt := &statisticReq.RequestStatistic{
+			DayBeginTime: begin.Unix(),
+			DayEndTime:   begin.Unix(),
+			OwnerFilter:  &power.HashMap{`userid_list`: batch},
+			Limit:        1000,
+		}
+		for {
+			reply, err := this.wework.ExternalContactStatistics.Statistic(this.ctx, opt)
+			if err != nil {
+				return count, err
+			}
+			if err = this.help.error(`scrm.wework.customer.group.statistic.error`, reply.ResponseWork); err != nil {
+				return count, err
+			}
+			var statistics []*customer.WeWorkCustomerGroupStatistic
+			for _, item := range reply.Items {
+				data, _ := (*item)[`data`].(map[string]interface{})
+				value := power.HashMap(data)
+				statistics = append(statistics, &customer.WeWorkCustomerGroupStatistic{
+					Owner:        hashString(item, `owner`),
+					Date:         begin.Format(`2006-01-02`),
+					NewChatCnt:   hashInt(&value, `new_chat_cnt`),
+					ChatTotal:    hashInt(&value, `chat_total`),
+					ChatHasMsg:   hashInt(&value, `chat_has_msg`),
+					NewMemberCnt: hashInt(&value, `new_member_cnt`),
+					MemberTotal:  hashInt(&value, `member_total`),
+					MemberHasMsg: hashInt(&value, `member_has_msg`),
+					MsgTotal:     hashInt(&value, `msg_total`),
+				})
+			}
+			this.modelWeworkCustomer.groupStatistic.Action(this.db, statistics)
+			count += len(statistics)
+
+			if reply.NextOffset == 0 || reply.NextOffset >= reply.Total {
+				break
+			}
+			opt.Offset = reply.NextOffset
+		}
+	}
+
+	return count, nil
+
+}
+
+// FindWeWorkCustomerGroupStatistic
+//
+//	@Description:
+//	@receiver this
+//	@param owners
+//	@param startDate
+//	@param endDate
+//	@return statistics
+func (this *wechatUseCase) FindWeWorkCustomerGroupStatistic(owners []string, startDate string, endDate string) (statistics []*customer.WeWorkCustomerGroupStatistic) {
+
+	query := this.db.WithContext(this.ctx).Model(customer.WeWorkCustomerGroupStatistic{}).
+		Where(`date >= ? AND date <= ?`, startDate, endDate)
+	if len(owners) > 0 {
+		query.Where(`owner IN ?`, owners)
+	}
+	if err := query.Order(`date ASC, owner ASC`).Find(&statistics).Error; err != nil {
+		panic(err)
+	}
+	return statistics
+
+}
+
+// transferGroupMemberToModel
+//
+//	@Description:
+//	@param chatId
+//	@param member
+//	@return *customer.WeWorkCustomerGroupMember
+func transferGroupMemberToModel(chatId string, member *groupChatResp.Member) *customer.WeWorkCustomerGroupMember {
+
+	invitor := ``
+	if member.Invitor != nil {
+		invitor = member.Invitor.UserID
+	}
+	return &customer.WeWorkCustomerGroupMember{
+		ChatId:        chatId,
+		UserId:        member.UserID,
+		Type:          member.Type,
+		JoinTime:      member.JoinTime,
+		JoinScene:     member.JoinScene,
+		State:         member.State,
+		Invitor:       invitor,
+		GroupNickname: member.GroupNickname,
+		Name:          member.Name,
+		UnionId:       member.UnionID,
+		Status:        customer.GroupMemberStatusIn,
+	}
+
+}
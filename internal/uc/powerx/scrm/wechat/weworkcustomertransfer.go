@@ -0,0 +1,553 @@
+package wechat
+
+import (
+	"PowerX/internal/model/origanzation"
+	"PowerX/internal/model/scrm/customer"
+	"PowerX/internal/types"
+	"errors"
+	"fmt"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/kernel/power"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/kernel/response"
+	groupChatReq "github.com/ArtisanCloud/PowerWeChat/v3/src/work/externalContact/groupChat/request"
+	groupChatResp "github.com/ArtisanCloud/PowerWeChat/v3/src/work/externalContact/groupChat/response"
+	transferReq "github.com/ArtisanCloud/PowerWeChat/v3/src/work/externalContact/transfer/request"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/work/server/handlers/models"
+	"time"
+)
+
+// 企业微信单次继承上限
+const transferBatchSize = 100
+
+type (
+	transferAssignment struct {
+		id       string
+		takeover string
+	}
+	// responseGroupChatOnJobTransfer
+	// @Description: SDK未提供在职继承客户群接口
+	responseGroupChatOnJobTransfer struct {
+		response.ResponseWork
+		FailedChatList []*power.HashMap `json:"failed_chat_list"`
+	}
+	// responseResignedTransferResult
+	// @Description: SDK的离职继承结果未解码next_cursor
+	responseResignedTransferResult struct {
+		response.ResponseWork
+		Customer   []*power.HashMap `json:"customer"`
+		NextCursor string           `json:"next_cursor"`
+	}
+)
+
+// FindWeWorkEmployeeHandover
+//
+//	@Description: 员工名下的客户与客户群
+//	@receiver this
+//	@param userId
+//	@return customers
+//	@return chats
+//	@return err
+func (this *wechatUseCase) FindWeWorkEmployeeHandover(userId string) (customers []*customer.WeWorkExternalContactFollow, chats []*groupChatResp.GroupChat, err error) {
+
+	customers = this.modelWeworkCustomer.follow.FindFollowsByUserId(this.db, userId)
+	chats, err = this.pullWeWorkEmployeeGroupChats(userId)
+
+	return customers, chats, err
+
+}
+
+// TransferWeWorkEmployee
+//
+//	@Description: 在职/离职继承, 客户接替结果异步回写
+//	@receiver this
+//	@param opt
+//	@return reply
+//	@return err
+func (this *wechatUseCase) TransferWeWorkEmployee(opt *types.TransferWeWorkEmployeeRequest) (reply *types.TransferWeWorkEmployeeReply, err error) {
+
+	employee := this.modelWeworkOrganization.employee.FindByWeWorkUserId(this.db, opt.UserId)
+	if employee == nil || employee.Id == 0 {
+		return nil, errors.New(`scrm.wework.transfer.employee.not.found`)
+	}
+
+	mode := customer.TransferModeOnJob
+	if opt.Resigned {
+		mode = customer.TransferModeResigned
+	}
+
+	customers, chats, err := this.transferWeWorkEmployeeAssignments(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*customer.WeWorkCustomerTransfer
+	for takeover, ids := range groupTransferAssignments(customers) {
+		for _, batch := range chunkTransferIds(ids) {
+			records = append(records, this.transferWeWorkCustomers(mode, opt.UserId, takeover, batch, opt.TransferSuccessMsg)...)
+		}
+	}
+	for takeover, ids := range groupTransferAssignments(chats) {
+		for _, batch := range chunkTransferIds(ids) {
+			records = append(records, this.transferWeWorkGroupChats(mode, opt.UserId, takeover, batch)...)
+		}
+	}
+	this.modelWeworkCustomer.transfer.Create(this.db, records)
+
+	reply = &types.TransferWeWorkEmployeeReply{
+		Status:         `success`,
+		CustomerCount:  len(customers),
+		GroupChatCount: len(chats),
+	}
+	for _, record := range records {
+		if record.Status == customer.TransferStatusFailed {
+			reply.FailedCount++
+		}
+	}
+
+	if opt.DisableEmployee && employee.RefEmployeeId > 0 {
+		err = this.db.Model(&origanzation.Employee{}).Where(`id = ?`, employee.RefEmployeeId).
+			Update(`status`, origanzation.EmployeeStatusDisabled).Error
+	}
+
+	return reply, err
+
+}
+
+// SyncWeWorkCustomerTransferResult
+//
+//	@Description: 拉取等待接替客户的最新状态, 接替完毕后更新跟进员工
+//	@receiver this
+//	@return updated
+//	@return err
+func (this *wechatUseCase) SyncWeWorkCustomerTransferResult() (updated int, err error) {
+
+	type pair struct {
+		mode     int
+		handover string
+		takeover string
+	}
+	pairs := make(map[pair]bool)
+	for _, record := range this.modelWeworkCustomer.transfer.FindWaiting(this.db) {
+		pairs[pair{record.Mode, record.HandoverUserId, record.TakeoverUserId}] = true
+	}
+
+	for key := range pairs {
+		cursor := ``
+		for {
+			var list []*power.HashMap
+			if key.mode == customer.TransferModeResigned {
+				result := &responseResignedTransferResult{}
+				_, err := this.wework.ExternalContactTransfer.BaseClient.HttpPostJson(this.ctx, `cgi-bin/externalcontact/resigned/transfer_result`, &transferReq.RequestResignedTransferResult{
+					HandoverUserID: key.handover,
+					TakeoverUserID: key.takeover,
+					Cursor:         cursor,
+				}, nil, nil, result)
+				if err != nil {
+					return updated, err
+				}
+				if err = this.help.error(`scrm.wework.resigned.transfer.result.error`, result.ResponseWork); err != nil {
+					return updated, err
+				}
+				list, cursor = result.Customer, result.NextCursor
+			} else {
+				result, err := this.wework.ExternalContactTransfer.TransferResult(this.ctx, &transferReq.RequestTransferResult{
+					HandoverUserID: key.handover,
+					TakeoverUserID: key.takeover,
+					Cursor:         cursor,
+				})
+				if err != nil {
+					return updated, err
+				}
+				if err = this.help.error(`scrm.wework.transfer.result.error`, result.ResponseWork); err != nil {
+					return updated, err
+				}
+				list, cursor = result.Customer, result.NextCursor
+			}
+
+			for _, item := range list {
+				status := hashInt(item, `status`)
+				if status == 0 || status == customer.TransferStatusWaiting {
+					continue
+				}
+				externalUserId := hashString(item, `external_userid`)
+				this.modelWeworkCustomer.transfer.UpdateStatus(this.db, key.takeover, externalUserId, map[string]interface{}{
+					`status`:        status,
+					`takeover_time`: int64(hashInt(item, `takeover_time`)),
+				})
+				if status == customer.TransferStatusSuccess {
+					this.modelWeworkCustomer.follow.UpdateUserId(this.db, externalUserId, key.handover, key.takeover)
+				}
+				updated++
+			}
+			if cursor == `` {
+				break
+			}
+		}
+	}
+
+	return updated, nil
+
+}
+
+// HandleWeWorkCustomerTransferFail
+//
+//	@Description: 客户接替失败事件
+//	@receiver this
+//	@param event
+func (this *wechatUseCase) HandleWeWorkCustomerTransferFail(event *models.EventExternalTransferFail) {
+
+	status := customer.TransferStatusRefused
+	if event.FailReason == `customer_limit_exceed` {
+		status = customer.TransferStatusLimited
+	}
+	this.modelWeworkCustomer.transfer.UpdateStatus(this.db, event.UserID, event.ExternalUserID, map[string]interface{}{
+		`status`: status,
+		`error`:  event.FailReason,
+	})
+
+}
+
+// FindWeWorkCustomerTransferPage
+//
+//	@Description:
+//	@receiver this
+//	@param option
+//	@return reply
+//	@return err
+func (this *wechatUseCase) FindWeWorkCustomerTransferPage(option *types.PageOption[types.ListWeWorkCustomerTransferRequest]) (reply *types.Page[*customer.WeWorkCustomerTransfer], err error) {
+
+	var transfers []*customer.WeWorkCustomerTransfer
+	var count int64
+	query := this.db.WithContext(this.ctx).Model(customer.WeWorkCustomerTransfer{})
+
+	option.DefaultPageIfNotSet()
+	if v := option.Option.HandoverUserId; v != `` {
+		query.Where(`handover_user_id = ?`, v)
+	}
+	if v := option.Option.TakeoverUserId; v != `` {
+		query.Where(`takeover_user_id = ?`, v)
+	}
+	if v := option.Option.Type; v > 0 {
+		query.Where(`type = ?`, v)
+	}
+	if v := option.Option.Status; v > 0 {
+		query.Where(`status = ?`, v)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return nil, err
+	}
+	err = query.Offset((option.PageIndex - 1) * option.PageSize).Limit(option.PageSize).Order(`id DESC`).Find(&transfers).Error
+
+	return &types.Page[*customer.WeWorkCustomerTransfer]{
+		List:      transfers,
+		PageIndex: option.PageIndex,
+		PageSize:  option.PageSize,
+		Total:     count,
+	}, err
+
+}
+
+// transferWeWorkEmployeeAssignments
+//
+//	@Description: manual按指定接替人, balance按接替人当前客户数分配
+//	@receiver this
+//	@param opt
+//	@return customers
+//	@return chats
+//	@return err
+func (this *wechatUseCase) transferWeWorkEmployeeAssignments(opt *types.TransferWeWorkEmployeeRequest) (customers []*transferAssignment, chats []*transferAssignment, err error) {
+
+	if opt.Strategy != `balance` {
+		for _, item := range opt.Customers {
+			customers = append(customers, &transferAssignment{id: item.ExternalUserId, takeover: item.TakeoverUserId})
+		}
+		for _, item := range opt.GroupChats {
+			chats = append(chats, &transferAssignment{id: item.ChatId, takeover: item.TakeoverUserId})
+		}
+		return customers, chats, nil
+	}
+
+	var customerIds, chatIds []string
+	if len(opt.Customers) > 0 || len(opt.GroupChats) > 0 {
+		for _, item := range opt.Customers {
+			customerIds = append(customerIds, item.ExternalUserId)
+		}
+		for _, item := range opt.GroupChats {
+			chatIds = append(chatIds, item.ChatId)
+		}
+	} else {
+		for _, follow := range this.modelWeworkCustomer.follow.FindFollowsByUserId(this.db, opt.UserId) {
+			customerIds = append(customerIds, follow.ExternalUserId)
+		}
+		groupChats, err := this.pullWeWorkEmployeeGroupChats(opt.UserId)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, chat := range groupChats {
+			chatIds = append(chatIds, chat.ChatID)
+		}
+	}
+
+	load := this.modelWeworkCustomer.follow.CountByUserIds(this.db, opt.TakeoverUserIds)
+	for _, id := range customerIds {
+		takeover := leastLoadedTakeover(opt.TakeoverUserIds, load)
+		load[takeover]++
+		customers = append(customers, &transferAssignment{id: id, takeover: takeover})
+	}
+	chatLoad := make(map[string]int, len(opt.TakeoverUserIds))
+	for _, id := range chatIds {
+		takeover := leastLoadedTakeover(opt.TakeoverUserIds, chatLoad)
+		chatLoad[takeover]++
+		chats = append(chats, &transferAssignment{id: id, takeover: takeover})
+	}
+
+	return customers, chats, nil
+
+}
+
+// transferWeWorkCustomers
+//
+//	@Description:
+//	@receiver this
+//	@param mode
+//	@param handover
+//	@param takeover
+//	@param ids
+//	@param msg
+//	@return records
+func (this *wechatUseCase) transferWeWorkCustomers(mode int, handover string, takeover string, ids []string, msg string) (records []*customer.WeWorkCustomerTransfer) {
+
+	var list []*power.HashMap
+	var err error
+	if mode == customer.TransferModeResigned {
+		result, e := this.wework.ExternalContactTransfer.ResignedTransferCustomer(this.ctx, &transferReq.RequestResignedTransferCustomer{
+			HandoverUserID: handover,
+			TakeoverUserID: takeover,
+			ExternalUserID: ids,
+		})
+		if err = e; err == nil {
+			err = this.help.error(`scrm.wework.resigned.transfer.customer.error`, result.ResponseWork)
+			list = result.Customer
+		}
+	} else {
+		result, e := this.wework.ExternalContactTransfer.TransferCustomer(this.ctx, &transferReq.RequestTransferCustomer{
+			HandoverUserID:     handover,
+			TakeoverUserID:     takeover,
+			ExternalUserID:     ids,
+			TransferSuccessMsg: msg,
+		})
+		if err = e; err == nil {
+			err = this.help.error(`scrm.wework.transfer.customer.error`, result.ResponseWork)
+			list = result.Customer
+		}
+	}
+
+	codes := make(map[string]int, len(list))
+	for _, item := range list {
+		codes[hashString(item, `external_userid`)] = hashInt(item, `errcode`)
+	}
+	for _, id := range ids {
+		record := &customer.WeWorkCustomerTransfer{
+			Type:           customer.TransferTypeCustomer,
+			Mode:           mode,
+			HandoverUserId: handover,
+			TakeoverUserId: takeover,
+			ExternalUserId: id,
+			Status:         customer.TransferStatusWaiting,
+		}
+		if err != nil {
+			record.Status, record.Error = customer.TransferStatusFailed, err.Error()
+		} else if code := codes[id]; code != 0 {
+			record.Status, record.Error = customer.TransferStatusFailed, fmt.Sprintf(`errcode: %d`, code)
+		}
+		records = append(records, record)
+	}
+	return records
+
+}
+
+// transferWeWorkGroupChats
+//
+//	@Description: 客户群继承即时生效
+//	@receiver this
+//	@param mode
+//	@param handover
+//	@param takeover
+//	@param ids
+//	@return records
+func (this *wechatUseCase) transferWeWorkGroupChats(mode int, handover string, takeover string, ids []string) (records []*customer.WeWorkCustomerTransfer) {
+
+	var failed []*power.HashMap
+	var err error
+	if mode == customer.TransferModeResigned {
+		reply, e := this.wework.ExternalContactTransfer.GroupChatTransfer(this.ctx, &transferReq.RequestGroupChatTransfer{
+			ChatIDList: ids,
+			NewOwner:   takeover,
+		})
+		if err = e; err == nil {
+			err = this.help.error(`scrm.wework.resigned.transfer.group.error`, reply.ResponseWork)
+			failed = reply.Customer
+		}
+	} else {
+		reply := &responseGroupChatOnJobTransfer{}
+		_, err = this.wework.ExternalContactGroupChat.BaseClient.HttpPostJson(this.ctx, `cgi-bin/externalcontact/groupchat/onjob_transfer`, &transferReq.RequestGroupChatTransfer{
+			ChatIDList: ids,
+			NewOwner:   takeover,
+		}, nil, nil, reply)
+		if err == nil {
+			err = this.help.error(`scrm.wework.transfer.group.error`, reply.ResponseWork)
+			failed = reply.FailedChatList
+		}
+	}
+
+	reasons := make(map[string]string, len(failed))
+	for _, item := range failed {
+		reasons[hashString(item, `chat_id`)] = hashString(item, `errmsg`)
+	}
+	for _, id := range ids {
+		record := &customer.WeWorkCustomerTransfer{
+			Type:           customer.TransferTypeGroupChat,
+			Mode:           mode,
+			HandoverUserId: handover,
+			TakeoverUserId: takeover,
+			ChatId:         id,
+			Status:         customer.TransferStatusSuccess,
+			TakeoverTime:   time.Now().Unix(),
+		}
+		if err != nil {
+			record.Status, record.TakeoverTime, record.Error = customer.TransferStatusFailed, 0, err.Error()
+		} else if reason, ok := reasons[id]; ok {
+			record.Status, record.TakeoverTime, record.Error = customer.TransferStatusFailed, 0, reason
+		}
+		records = append(records, record)
+	}
+	return records
+
+}
+
+// pullWeWorkEmployeeGroupChats
+//
+//	@Description: 员工为群主的客户群
+//	@receiver this
+//	@param userId
+//	@return chats
+//	@return err
+func (this *wechatUseCase) pullWeWorkEmployeeGroupChats(userId string) (chats []*groupChatResp.GroupChat, err error) {
+
+	opt := &groupChatReq.RequestGroupChatList{
+		OwnerFilter: &power.HashMap{`userid_list`: []string{userId}},
+		Limit:       1000,
+	}
+	for {
+		reply, err := this.wework.ExternalContactGroupChat.List(this.ctx, opt)
+		if err != nil {
+			return chats, err
+		}
+		if err = this.help.error(`scrm.wework.list.customer.group.error`, reply.ResponseWork); err != nil {
+			return chats, err
+		}
+		for _, chat := range reply.GroupChatList {
+			get, err := this.wework.ExternalContactGroupChat.Get(this.ctx, chat.ChatID, 0)
+			if err == nil && get.ErrCode == 0 && get.GroupChat != nil {
+				chats = append(chats, get.GroupChat)
+			} else {
+				chats = append(chats, &groupChatResp.GroupChat{ChatID: chat.ChatID})
+			}
+		}
+		if reply.NextCursor == `` {
+			break
+		}
+		opt.Cursor = reply.NextCursor
+	}
+	return chats, nil
+
+}
+
+// groupTransferAssignments
+//
+//	@Description:
+//	@param assignments
+//	@return map[string][]string
+func groupTransferAssignments(assignments []*transferAssignment) map[string][]string {
+
+	group := make(map[string][]string)
+	for _, val := range assignments {
+		if val.id == `` || val.takeover == `` {
+			continue
+		}
+		group[val.takeover] = append(group[val.takeover], val.id)
+	}
+	return group
+
+}
+
+// chunkTransferIds
+//
+//	@Description:
+//	@param ids
+//	@return [][]string
+func chunkTransferIds(ids []string) (chunks [][]string) {
+
+	for len(ids) > transferBatchSize {
+		chunks = append(chunks, ids[:transferBatchSize])
+		ids = ids[transferBatchSize:]
+	}
+	if len(ids) > 0 {
+		chunks = append(chunks, ids)
+	}
+	return chunks
+
+}
+
+// leastLoadedTakeover
+//
+//	@Description:
+//	@param takeovers
+//	@param load
+//	@return string
+func leastLoadedTakeover(takeovers []string, load map[string]int) (takeover string) {
+
+	for _, val := range takeovers {
+		if takeover == `` || load[val] < load[takeover] {
+			takeover = val
+		}
+	}
+	return takeover
+
+}
+
+// hashString
+//
+//	@Description:
+//	@param h
+//	@param key
+//	@return string
+func hashString(h *power.HashMap, key string) string {
+
+	if h == nil {
+		return ``
+	}
+	val, _ := (*h)[key].(string)
+	return val
+
+}
+
+// hashInt
+//
+//	@Description: json数字解码为float64
+//	@param h
+//	@param key
+//	@return int
+func hashInt(h *power.HashMap, key string) int {
+
+	if h == nil {
+		return 0
+	}
+	switch val := (*h)[key].(type) {
+	case float64:
+		return int(val)
+	case int:
+		return val
+	}
+	return 0
+
+}
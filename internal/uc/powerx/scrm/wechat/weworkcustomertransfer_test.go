@@ -0,0 +1,101 @@
+package wechat
+
+import (
+	"PowerX/internal/model/scrm/customer"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/work"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTransferTestUseCase 企业微信接口指向本地stub, 数据库使用内存sqlite
+func newTransferTestUseCase(t *testing.T, handler http.HandlerFunc) *wechatUseCase {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	logDir := t.TempDir()
+
+	wework, err := work.NewWork(&work.UserConfig{
+		CorpID: `corp`,
+		Secret: `secret`,
+		OAuth:  work.OAuth{Callback: server.URL},
+		Http:   work.Http{BaseURI: server.URL + `/`},
+		Log:    work.Log{Level: `error`, File: logDir + `/info.log`, Error: logDir + `/error.log`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := gorm.Open(sqlite.Open(`file::memory:`), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&customer.WeWorkExternalContactFollow{}, &customer.WeWorkCustomerTransfer{}); err != nil {
+		t.Fatal(err)
+	}
+	return &wechatUseCase{db: db, wework: wework, ctx: context.TODO()}
+}
+
+func TestSyncWeWorkCustomerTransferResult(t *testing.T) {
+
+	var cursors []string
+	uc := newTransferTestUseCase(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case `/cgi-bin/gettoken`:
+			_, _ = w.Write([]byte(`{"errcode":0,"access_token":"token","expires_in":7200}`))
+		case `/cgi-bin/externalcontact/resigned/transfer_result`:
+			body := map[string]string{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			cursors = append(cursors, body[`cursor`])
+			// 离职继承结果分两页返回
+			if body[`cursor`] == `` {
+				_, _ = w.Write([]byte(`{"errcode":0,"customer":[{"external_userid":"wm1","status":1,"takeover_time":1700000000}],"next_cursor":"page2"}`))
+			} else {
+				_, _ = w.Write([]byte(`{"errcode":0,"customer":[{"external_userid":"wm2","status":1,"takeover_time":1700000001}],"next_cursor":""}`))
+			}
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+
+	// wm2已由其他员工跟进, 接替结果不能改写其他员工的跟进记录
+	uc.db.Create([]*customer.WeWorkExternalContactFollow{
+		{ExternalUserId: `wm1`, UserId: `handover`},
+		{ExternalUserId: `wm2`, UserId: `other`},
+	})
+	uc.db.Create([]*customer.WeWorkCustomerTransfer{
+		{Type: customer.TransferTypeCustomer, Mode: customer.TransferModeResigned, HandoverUserId: `handover`, TakeoverUserId: `takeover`, ExternalUserId: `wm1`, Status: customer.TransferStatusWaiting},
+		{Type: customer.TransferTypeCustomer, Mode: customer.TransferModeResigned, HandoverUserId: `handover`, TakeoverUserId: `takeover`, ExternalUserId: `wm2`, Status: customer.TransferStatusWaiting},
+	})
+
+	updated, err := uc.SyncWeWorkCustomerTransferResult()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated != 2 {
+		t.Errorf("updated = %d, want 2", updated)
+	}
+	if len(cursors) != 2 || cursors[1] != `page2` {
+		t.Errorf("cursors = %v, want all pages", cursors)
+	}
+
+	follows := map[string]string{}
+	var rows []*customer.WeWorkExternalContactFollow
+	uc.db.Find(&rows)
+	for _, row := range rows {
+		follows[row.ExternalUserId] = row.UserId
+	}
+	if follows[`wm1`] != `takeover` || follows[`wm2`] != `other` {
+		t.Errorf("follows = %v", follows)
+	}
+
+	var waiting int64
+	uc.db.Model(&customer.WeWorkCustomerTransfer{}).Where(`status = ?`, customer.TransferStatusWaiting).Count(&waiting)
+	if waiting != 0 {
+		t.Errorf("waiting transfers = %d, want 0", waiting)
+	}
+}
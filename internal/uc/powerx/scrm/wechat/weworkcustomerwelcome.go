@@ -0,0 +1,362 @@
+package wechat
+
+import (
+	"PowerX/internal/model/scrm/customer"
+	"PowerX/internal/model/scrm/resource"
+	"PowerX/internal/types"
+	"errors"
+	"fmt"
+	baseResp "github.com/ArtisanCloud/PowerWeChat/v3/src/kernel/response"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/work/externalContact/messageTemplate/request"
+	tagReq "github.com/ArtisanCloud/PowerWeChat/v3/src/work/externalContact/tag/request"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/work/server/handlers/models"
+	"github.com/zeromicro/go-zero/core/logx"
+	"strings"
+	"time"
+)
+
+// FindWeWorkCustomerWelcomeRulePage
+//
+//	@Description: 欢迎语规则
+//	@receiver this
+//	@param option
+//	@return reply
+//	@return err
+func (this *wechatUseCase) FindWeWorkCustomerWelcomeRulePage(option *types.PageOption[types.ListWeWorkCustomerWelcomeRuleRequest]) (reply *types.Page[*customer.WeWorkCustomerWelcomeRule], err error) {
+
+	var rules []*customer.WeWorkCustomerWelcomeRule
+	var count int64
+	query := this.db.WithContext(this.ctx).Model(customer.WeWorkCustomerWelcomeRule{}).Where(`status < ?`, customer.WelcomeRuleStateDelete)
+
+	option.DefaultPageIfNotSet()
+	if v := option.Option.Name; v != `` {
+		query.Where(`name like ?`, "%"+v+"%")
+	}
+	if v := option.Option.Qid; v != `` {
+		query.Where(`qid = ?`, v)
+	}
+	if v := option.Option.State; v != `` {
+		query.Where(`state = ?`, v)
+	}
+	if v := option.Option.UserId; v != `` {
+		query.Where(`user_id = ?`, v)
+	}
+	if v := option.Option.Status; v > 0 {
+		query.Where(`status = ?`, v)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return nil, err
+	}
+	err = query.Offset((option.PageIndex - 1) * option.PageSize).Limit(option.PageSize).Order(`priority DESC, id DESC`).Find(&rules).Error
+
+	return &types.Page[*customer.WeWorkCustomerWelcomeRule]{
+		List:      rules,
+		PageIndex: option.PageIndex,
+		PageSize:  option.PageSize,
+		Total:     count,
+	}, err
+}
+
+// CreateWeWorkCustomerWelcomeRule
+//
+//	@Description: 创建欢迎语规则
+//	@receiver this
+//	@param opt
+//	@return err
+func (this *wechatUseCase) CreateWeWorkCustomerWelcomeRule(opt *types.WeWorkCustomerWelcomeRuleRequest) (err error) {
+
+	rule := transferWelcomeRuleRequestToModel(&customer.WeWorkCustomerWelcomeRule{}, opt)
+	rule.Status = customer.WelcomeRuleStateEnable
+	this.modelWeworkCustomer.welcome.Action(this.db, []*customer.WeWorkCustomerWelcomeRule{rule})
+
+	return err
+}
+
+// UpdateWeWorkCustomerWelcomeRule
+//
+//	@Description: 更新欢迎语规则
+//	@receiver this
+//	@param opt
+//	@return err
+func (this *wechatUseCase) UpdateWeWorkCustomerWelcomeRule(opt *types.WeWorkCustomerWelcomeRuleRequest) (err error) {
+
+	rule := this.modelWeworkCustomer.welcome.FindById(this.db, opt.Id)
+	if rule == nil || rule.Id == 0 {
+		return fmt.Errorf(`scrm.welcome.rule.not.found`)
+	}
+	rule = transferWelcomeRuleRequestToModel(rule, opt)
+	this.modelWeworkCustomer.welcome.Action(this.db, []*customer.WeWorkCustomerWelcomeRule{rule})
+
+	return err
+}
+
+// ActionWeWorkCustomerWelcomeRule
+//
+//	@Description: 启用，禁用，删除
+//	@receiver this
+//	@param id
+//	@param action
+//	@return error
+func (this *wechatUseCase) ActionWeWorkCustomerWelcomeRule(id int64, action int) error {
+
+	column := make(map[string]interface{})
+	column[`status`] = action
+	if action == customer.WelcomeRuleStateDelete {
+		column[`deleted_at`] = time.Now()
+	}
+	this.modelWeworkCustomer.welcome.UpdateColumn(this.db, id, column)
+
+	return nil
+}
+
+// FindWeWorkCustomerWelcomeLogPage
+//
+//	@Description: 欢迎语执行记录
+//	@receiver this
+//	@param option
+//	@return reply
+//	@return err
+func (this *wechatUseCase) FindWeWorkCustomerWelcomeLogPage(option *types.PageOption[types.ListWeWorkCustomerWelcomeLogRequest]) (reply *types.Page[*customer.WeWorkCustomerWelcomeLog], err error) {
+
+	var logs []*customer.WeWorkCustomerWelcomeLog
+	var count int64
+	query := this.db.WithContext(this.ctx).Model(customer.WeWorkCustomerWelcomeLog{})
+
+	option.DefaultPageIfNotSet()
+	if v := option.Option.RuleId; v > 0 {
+		query.Where(`rule_id = ?`, v)
+	}
+	if v := option.Option.ExternalUserId; v != `` {
+		query.Where(`external_user_id = ?`, v)
+	}
+	if v := option.Option.UserId; v != `` {
+		query.Where(`user_id = ?`, v)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return nil, err
+	}
+	err = query.Offset((option.PageIndex - 1) * option.PageSize).Limit(option.PageSize).Order(`id DESC`).Find(&logs).Error
+
+	return &types.Page[*customer.WeWorkCustomerWelcomeLog]{
+		List:      logs,
+		PageIndex: option.PageIndex,
+		PageSize:  option.PageSize,
+		Total:     count,
+	}, err
+}
+
+// InvokeWeWorkCustomerWelcomeRule
+//
+//	@Description: 添加外部联系人事件：发送欢迎语/自动打标签/邀请入群
+//	@receiver this
+//	@param event
+//	@return error
+func (this *wechatUseCase) InvokeWeWorkCustomerWelcomeRule(event *models.EventExternalUserAdd) error {
+
+	rule := this.matchWeWorkCustomerWelcomeRule(event.UserID, event.State)
+	log := &customer.WeWorkCustomerWelcomeLog{
+		ExternalUserId: event.ExternalUserID,
+		UserId:         event.UserID,
+		State:          event.State,
+		WelcomeStatus:  customer.WelcomeLogStatusSkipped,
+		TagStatus:      customer.WelcomeLogStatusSkipped,
+		GroupStatus:    customer.WelcomeLogStatusSkipped,
+	}
+	if rule == nil {
+		return nil
+	}
+	log.RuleId = rule.Id
+
+	var errs []string
+	// welcome message, WelcomeCode 有效期20秒且只能使用一次
+	if event.WelcomeCode != `` {
+		msg := this.welcomeRuleToMessage(rule, event.WelcomeCode)
+		if msg.Text != nil || len(msg.Attachments) > 0 {
+			reply, err := this.wework.ExternalContactMessageTemplate.SendWelcomeMsg(this.ctx, msg)
+			if err == nil {
+				err = this.help.error(`scrm.push.wework.customer.welcome.error`, *reply)
+			}
+			if err != nil {
+				log.WelcomeStatus = customer.WelcomeLogStatusFailed
+				errs = append(errs, err.Error())
+			} else {
+				log.WelcomeStatus = customer.WelcomeLogStatusSuccess
+			}
+			if rule.GroupQid != `` {
+				log.GroupStatus = log.WelcomeStatus
+			}
+		}
+	}
+
+	// corp tags
+	if rule.TagIds != `` {
+		_, err := this.actionWeWorkCustomerTag(&tagReq.RequestTagMarkTag{
+			UserID:         event.UserID,
+			ExternalUserID: event.ExternalUserID,
+			AddTag:         strings.Split(rule.TagIds, `,`),
+		})
+		if err != nil {
+			log.TagStatus = customer.WelcomeLogStatusFailed
+			errs = append(errs, err.Error())
+		} else {
+			log.TagStatus = customer.WelcomeLogStatusSuccess
+		}
+	}
+
+	log.Error = strings.Join(errs, `;`)
+	this.modelWeworkCustomer.welcomeLog.Create(this.db, log)
+	if len(errs) > 0 {
+		return errors.New(log.Error)
+	}
+
+	return nil
+}
+
+// matchWeWorkCustomerWelcomeRule
+//
+//	@Description: 匹配顺序：场景码/State > 员工 > 默认规则
+//	@receiver this
+//	@param userID
+//	@param state
+//	@return *customer.WeWorkCustomerWelcomeRule
+func (this *wechatUseCase) matchWeWorkCustomerWelcomeRule(userID string, state string) *customer.WeWorkCustomerWelcomeRule {
+
+	rules := this.modelWeworkCustomer.welcome.Query(this.db)
+
+	var byUser, byDefault *customer.WeWorkCustomerWelcomeRule
+	for _, rule := range rules {
+		if rule.UserId != `` && rule.UserId != userID {
+			continue
+		}
+		if rule.Qid != `` || rule.State != `` {
+			if state != `` && (rule.Qid == state || rule.State == state) {
+				return rule
+			}
+			continue
+		}
+		if rule.UserId != `` && byUser == nil {
+			byUser = rule
+		}
+		if rule.UserId == `` && byDefault == nil {
+			byDefault = rule
+		}
+	}
+	if byUser != nil {
+		return byUser
+	}
+
+	return byDefault
+}
+
+// welcomeRuleToMessage
+//
+//	@Description:
+//	@receiver this
+//	@param rule
+//	@param welcomeCode
+//	@return *request.RequestSendWelcomeMsg
+func (this *wechatUseCase) welcomeRuleToMessage(rule *customer.WeWorkCustomerWelcomeRule, welcomeCode string) *request.RequestSendWelcomeMsg {
+
+	msg := &request.RequestSendWelcomeMsg{
+		WelcomeCode: welcomeCode,
+		Attachments: []request.MessageTemplateInterface{},
+	}
+	if rule.Text != `` {
+		msg.Text = &request.TextOfMessage{Content: rule.Text}
+	}
+
+	switch rule.AttachmentType {
+	case customer.WelcomeAttachmentTypeImage:
+		var res *resource.WeWorkResource
+		_ = this.db.Model(resource.WeWorkResource{}).Where(`id = ?`, rule.ResourceId).Find(&res).Error
+		if res != nil && res.Url != `` {
+			msg.Attachments = append(msg.Attachments, &request.ImageOfMessage{
+				MsgType: `image`,
+				Image:   &request.Image{PicURL: res.Url},
+			})
+		}
+	case customer.WelcomeAttachmentTypeMiniProgram:
+		msg.Attachments = append(msg.Attachments, &request.MiniProgramOfMessage{
+			MsgType: `miniprogram`,
+			MiniProgram: &request.MiniProgram{
+				Title:      rule.MiniTitle,
+				PicMediaID: rule.MiniPicMediaId,
+				AppID:      rule.MiniAppId,
+				Page:       rule.MiniPage,
+			},
+		})
+	case customer.WelcomeAttachmentTypeLink:
+		msg.Attachments = append(msg.Attachments, &request.LinkOfMessage{
+			MsgType: `link`,
+			Link: &request.Link{
+				Title:  rule.LinkTitle,
+				PicURL: rule.LinkPicUrl,
+				Desc:   rule.LinkDesc,
+				URL:    rule.LinkUrl,
+			},
+		})
+	}
+
+	// 邀请入群：附带群活码
+	if rule.GroupQid != `` {
+		qrcode := this.modelWeworkQrcode.qrcode.FindEnableSceneQrcodeByQid(this.db, rule.GroupQid)
+		if qrcode != nil && qrcode.RealQrcodeLink != `` {
+			msg.Attachments = append(msg.Attachments, &request.ImageOfMessage{
+				MsgType: `image`,
+				Image:   &request.Image{PicURL: qrcode.RealQrcodeLink},
+			})
+		} else {
+			logx.Errorf(`scrm.welcome.rule.group.qrcode.not.found. %s`, rule.GroupQid)
+		}
+	}
+
+	return msg
+}
+
+// actionWeWorkCustomerTag
+//
+//	@Description: 打标签(不panic)
+//	@receiver this
+//	@param option
+//	@return reply
+//	@return err
+func (this *wechatUseCase) actionWeWorkCustomerTag(option *tagReq.RequestTagMarkTag) (reply *baseResp.ResponseWork, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf(`scrm.welcome.mark.tag.error. %v`, r)
+		}
+	}()
+
+	return this.ActionWeWorkCustomerTagRequest(option)
+}
+
+// transferWelcomeRuleRequestToModel
+//
+//	@Description:
+//	@param rule
+//	@param opt
+//	@return *customer.WeWorkCustomerWelcomeRule
+func transferWelcomeRuleRequestToModel(rule *customer.WeWorkCustomerWelcomeRule, opt *types.WeWorkCustomerWelcomeRuleRequest) *customer.WeWorkCustomerWelcomeRule {
+
+	rule.Name = opt.Name
+	rule.Qid = opt.Qid
+	rule.State = opt.State
+	rule.UserId = opt.UserId
+	rule.Priority = opt.Priority
+	rule.Text = opt.Text
+	rule.AttachmentType = opt.AttachmentType
+	rule.ResourceId = opt.ResourceId
+	rule.LinkTitle = opt.Link.Title
+	rule.LinkPicUrl = opt.Link.PicUrl
+	rule.LinkDesc = opt.Link.Desc
+	rule.LinkUrl = opt.Link.Url
+	rule.MiniAppId = opt.MiniProgram.AppId
+	rule.MiniTitle = opt.MiniProgram.Title
+	rule.MiniPage = opt.MiniProgram.Page
+	rule.MiniPicMediaId = opt.MiniProgram.PicMediaId
+	rule.TagIds = strings.Join(opt.TagIds, `,`)
+	rule.GroupQid = opt.GroupQid
+
+	return rule
+}
@@ -0,0 +1,85 @@
+package scrm
+
+import (
+	"PowerX/internal/config"
+	"PowerX/internal/uc/powerx/scrm/provider"
+	"PowerX/internal/uc/powerx/scrm/wechat"
+	"context"
+	botReq "github.com/ArtisanCloud/PowerWeChat/v3/src/work/groupRobot/request"
+	appReq "github.com/ArtisanCloud/PowerWeChat/v3/src/work/message/request"
+	"strings"
+)
+
+// weworkProvider
+// @Description: 企业微信适配SCRM服务商接口
+type weworkProvider struct {
+	agentId int
+	wechat  wechat.IWechatInterface
+}
+
+// Name
+//
+//	@Description:
+//	@receiver this
+//	@return string
+func (this *weworkProvider) Name() string {
+	return config.SCRMProviderWeWork
+}
+
+// SyncOrganization
+//
+//	@Description:
+//	@receiver this
+//	@param ctx
+//	@return error
+func (this *weworkProvider) SyncOrganization(ctx context.Context) error {
+
+	return this.wechat.PullSyncDepartmentsAndEmployeesRequest(ctx)
+
+}
+
+// SendRobotArticle
+//
+//	@Description:
+//	@receiver this
+//	@param ctx
+//	@param key
+//	@param article
+//	@return string
+//	@return error
+func (this *weworkProvider) SendRobotArticle(ctx context.Context, key string, article *provider.Article) (string, error) {
+
+	reply, err := this.wechat.PushWeWorkBotArticlesRequest(key, []*botReq.GroupRobotMsgNewsArticles{
+		{Title: article.Title, Description: article.Description, Url: article.Url, PicUrl: article.PicUrl},
+	})
+	if reply == nil {
+		return ``, err
+	}
+	return reply.Message, err
+
+}
+
+// SendWorkNotice
+//
+//	@Description: 应用图文消息
+//	@receiver this
+//	@param ctx
+//	@param notice
+//	@return error
+func (this *weworkProvider) SendWorkNotice(ctx context.Context, notice *provider.Notice) error {
+
+	_, err := this.wechat.PushAppWeWorkMessageArticlesRequest(&appReq.RequestMessageSendNews{
+		RequestMessageSend: appReq.RequestMessageSend{
+			ToUser:  strings.Join(notice.UserIds, `|`),
+			MsgType: `news`,
+			AgentID: this.agentId,
+		},
+		News: &appReq.RequestNews{
+			Article: []*appReq.RequestNewsArticle{
+				{Title: notice.Title, Description: notice.Content, URL: notice.Url},
+			},
+		},
+	}, 0)
+	return err
+
+}
@@ -0,0 +1,654 @@
+package wechat
+
+import (
+	"PowerX/internal/model/crm/customerdomain"
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/model/wechat"
+	"PowerX/internal/types"
+	"PowerX/internal/types/errorx"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/basicService/subscribeMessage/request"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/kernel/power"
+	templateRequest "github.com/ArtisanCloud/PowerWeChat/v3/src/officialAccount/templateMessage/request"
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// WechatNotificationUseCase 订单事件通知, 通过小程序订阅消息与公众号模板消息下发
+type WechatNotificationUseCase struct {
+	db *gorm.DB
+	MP *WechatMiniProgramUseCase
+	OA *WechatOfficialAccountUseCase
+}
+
+func NewWechatNotificationUseCase(db *gorm.DB, mp *WechatMiniProgramUseCase, oa *WechatOfficialAccountUseCase) *WechatNotificationUseCase {
+	return &WechatNotificationUseCase{
+		db: db,
+		MP: mp,
+		OA: oa,
+	}
+}
+
+type FindManyNotificationTemplatesOption struct {
+	Events   []string
+	Channel  string
+	Status   int8
+	LikeName string
+	types.PageEmbedOption
+}
+
+type FindManyNotificationLogsOption struct {
+	Event      string
+	Channel    string
+	Statuses   []int8
+	CustomerId int64
+	OrderId    int64
+	types.PageEmbedOption
+}
+
+// 发送中超过该时长视为进程中断, 由定时任务接管
+const notificationSendingTimeout = 10 * time.Minute
+
+// 失败后按尝试次数递增的重试间隔
+var notificationRetryDelays = []time.Duration{time.Minute, 5 * time.Minute, 15 * time.Minute, time.Hour}
+
+// 不再重试的微信错误码: 无效openid, 用户拒收/未订阅, 无效模板, 参数错误, 无效页面
+var notificationFatalErrCodes = map[int]bool{
+	40003: true,
+	43101: true,
+	43004: true,
+	40037: true,
+	47003: true,
+	41030: true,
+}
+
+// 订阅消息各类型字段的长度上限
+// https://developers.weixin.qq.com/miniprogram/dev/OpenApiDoc/mp-message-management/subscribe-message/sendMessage.html
+var mpSubscribeFieldMaxLength = map[string]int{
+	"thing":            20,
+	"character_string": 32,
+	"phrase":           5,
+	"name":             10,
+	"letter":           32,
+	"symbol":           5,
+	"number":           32,
+	"amount":           12,
+	"car_number":       8,
+	"phone_number":     17,
+}
+
+func (uc *WechatNotificationUseCase) buildFindTemplateQueryNoPage(db *gorm.DB, opt *FindManyNotificationTemplatesOption) *gorm.DB {
+	if len(opt.Events) > 0 {
+		db = db.Where("event IN ?", opt.Events)
+	}
+	if opt.Channel != "" {
+		db = db.Where("channel = ?", opt.Channel)
+	}
+	if opt.Status > 0 {
+		db = db.Where("status = ?", opt.Status)
+	}
+	if opt.LikeName != "" {
+		db = db.Where("name LIKE ?", "%"+opt.LikeName+"%")
+	}
+	return db.Order("id desc")
+}
+
+func (uc *WechatNotificationUseCase) FindManyNotificationTemplates(ctx context.Context, opt *FindManyNotificationTemplatesOption) types.Page[*wechat.WechatNotificationTemplate] {
+	var templates []*wechat.WechatNotificationTemplate
+	db := uc.db.WithContext(ctx).Model(&wechat.WechatNotificationTemplate{})
+
+	db = uc.buildFindTemplateQueryNoPage(db, opt)
+
+	var count int64
+	if err := db.Count(&count).Error; err != nil {
+		panic(err)
+	}
+
+	opt.DefaultPageIfNotSet()
+	db.Offset((opt.PageIndex - 1) * opt.PageSize).Limit(opt.PageSize)
+
+	if err := db.Find(&templates).Error; err != nil {
+		panic(err)
+	}
+
+	return types.Page[*wechat.WechatNotificationTemplate]{
+		List:      templates,
+		PageIndex: opt.PageIndex,
+		PageSize:  opt.PageSize,
+		Total:     count,
+	}
+}
+
+// FindEnabledNotificationTemplates 小程序端据此发起订阅授权
+func (uc *WechatNotificationUseCase) FindEnabledNotificationTemplates(ctx context.Context, channel string, events []string) []*wechat.WechatNotificationTemplate {
+	var templates []*wechat.WechatNotificationTemplate
+	db := uc.buildFindTemplateQueryNoPage(uc.db.WithContext(ctx).Model(&wechat.WechatNotificationTemplate{}), &FindManyNotificationTemplatesOption{
+		Events:  events,
+		Channel: channel,
+		Status:  wechat.NotificationTemplateStatusEnable,
+	})
+	if err := db.Find(&templates).Error; err != nil {
+		panic(errors.Wrap(err, "find notification templates failed"))
+	}
+	return templates
+}
+
+func (uc *WechatNotificationUseCase) CreateNotificationTemplate(ctx context.Context, template *wechat.WechatNotificationTemplate) error {
+	if err := ValidateNotificationTemplate(template); err != nil {
+		return err
+	}
+	if err := uc.db.WithContext(ctx).Create(template).Error; err != nil {
+		panic(err)
+	}
+	return nil
+}
+
+func (uc *WechatNotificationUseCase) UpdateNotificationTemplate(ctx context.Context, id int64, template *wechat.WechatNotificationTemplate) error {
+	if err := ValidateNotificationTemplate(template); err != nil {
+		return err
+	}
+	result := uc.db.WithContext(ctx).Model(&wechat.WechatNotificationTemplate{}).Where(id).
+		Select("*").Omit("id", "created_at", "deleted_at").Updates(template)
+	if err := result.Error; err != nil {
+		panic(err)
+	}
+	if result.RowsAffected == 0 {
+		return errorx.WithCause(errorx.ErrBadRequest, "未找到通知模板")
+	}
+	return nil
+}
+
+func (uc *WechatNotificationUseCase) DeleteNotificationTemplate(ctx context.Context, id int64) error {
+	result := uc.db.WithContext(ctx).Delete(&wechat.WechatNotificationTemplate{}, id)
+	if err := result.Error; err != nil {
+		panic(err)
+	}
+	if result.RowsAffected == 0 {
+		return errorx.WithCause(errorx.ErrBadRequest, "未找到通知模板")
+	}
+	return nil
+}
+
+// ValidateNotificationTemplate 校验事件、渠道与字段映射
+func ValidateNotificationTemplate(template *wechat.WechatNotificationTemplate) error {
+	if !wechat.IsNotificationEvent(template.Event) {
+		return errorx.WithCause(errorx.ErrBadRequest, "不支持的通知事件")
+	}
+	if template.Channel != wechat.NotificationChannelMPSubscribe && template.Channel != wechat.NotificationChannelOATemplate {
+		return errorx.WithCause(errorx.ErrBadRequest, "不支持的通知渠道")
+	}
+	if template.TemplateId == "" {
+		return errorx.WithCause(errorx.ErrBadRequest, "模板ID不能为空")
+	}
+	mappings := map[string]string{}
+	if len(template.FieldMappings) > 0 {
+		if err := json.Unmarshal(template.FieldMappings, &mappings); err != nil {
+			return errorx.WithCause(errorx.ErrBadRequest, "字段映射格式错误")
+		}
+	}
+	if len(mappings) == 0 {
+		return errorx.WithCause(errorx.ErrBadRequest, "字段映射不能为空")
+	}
+	for key := range mappings {
+		if key == "" {
+			return errorx.WithCause(errorx.ErrBadRequest, "模板字段不能为空")
+		}
+	}
+	if template.Status == 0 {
+		template.Status = wechat.NotificationTemplateStatusEnable
+	}
+	return nil
+}
+
+// RecordMPSubscriptions 记录wx.requestSubscribeMessage的授权结果, results为 模板ID -> accept/reject/ban/filter
+func (uc *WechatNotificationUseCase) RecordMPSubscriptions(ctx context.Context, customer *customerdomain.Customer, results map[string]string) error {
+	if customer.OpenIdInMiniProgram == "" {
+		return errorx.WithCause(errorx.ErrBadRequest, "客户未绑定小程序")
+	}
+	now := time.Now()
+
+	return uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for templateId, status := range results {
+			switch status {
+			case wechat.MPSubscriptionAccept, wechat.MPSubscriptionReject, wechat.MPSubscriptionBan, wechat.MPSubscriptionFilter:
+			default:
+				return errorx.WithCause(errorx.ErrBadRequest, "不支持的授权结果: "+status)
+			}
+
+			var subscription wechat.WechatMPSubscription
+			err := tx.Where("customer_id = ? AND template_id = ?", customer.Id, templateId).First(&subscription).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			subscription.CustomerId = customer.Id
+			subscription.TemplateId = templateId
+			subscription.OpenId = customer.OpenIdInMiniProgram
+			subscription.LastStatus = status
+			subscription.LastDecidedAt = &now
+			switch status {
+			case wechat.MPSubscriptionAccept:
+				subscription.AcceptCount++
+				subscription.AvailableCount++
+			case wechat.MPSubscriptionBan:
+				// 被后台封禁的模板, 此前的授权也无法下发
+				subscription.RejectCount++
+				subscription.AvailableCount = 0
+			default:
+				subscription.RejectCount++
+			}
+			if err = tx.Save(&subscription).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// consumeMPSubscription 占用一次订阅授权, 没有剩余次数时返回false
+func (uc *WechatNotificationUseCase) consumeMPSubscription(ctx context.Context, customerId int64, templateId string) bool {
+	result := uc.db.WithContext(ctx).Model(&wechat.WechatMPSubscription{}).
+		Where("customer_id = ? AND template_id = ? AND available_count > 0", customerId, templateId).
+		Update("available_count", gorm.Expr("available_count - 1"))
+	if result.Error != nil {
+		panic(errors.Wrap(result.Error, "consume mp subscription failed"))
+	}
+	return result.RowsAffected > 0
+}
+
+func (uc *WechatNotificationUseCase) FindManyNotificationLogs(ctx context.Context, opt *FindManyNotificationLogsOption) types.Page[*wechat.WechatNotificationLog] {
+	var logs []*wechat.WechatNotificationLog
+	db := uc.db.WithContext(ctx).Model(&wechat.WechatNotificationLog{})
+
+	if opt.Event != "" {
+		db = db.Where("event = ?", opt.Event)
+	}
+	if opt.Channel != "" {
+		db = db.Where("channel = ?", opt.Channel)
+	}
+	if len(opt.Statuses) > 0 {
+		db = db.Where("status IN ?", opt.Statuses)
+	}
+	if opt.CustomerId > 0 {
+		db = db.Where("customer_id = ?", opt.CustomerId)
+	}
+	if opt.OrderId > 0 {
+		db = db.Where("order_id = ?", opt.OrderId)
+	}
+
+	var count int64
+	if err := db.Count(&count).Error; err != nil {
+		panic(err)
+	}
+
+	opt.DefaultPageIfNotSet()
+	db.Order("id desc").Offset((opt.PageIndex - 1) * opt.PageSize).Limit(opt.PageSize)
+
+	if err := db.Find(&logs).Error; err != nil {
+		panic(err)
+	}
+
+	return types.Page[*wechat.WechatNotificationLog]{
+		List:      logs,
+		PageIndex: opt.PageIndex,
+		PageSize:  opt.PageSize,
+		Total:     count,
+	}
+}
+
+// ResendNotificationLog 手动重发失败的通知, 不受最大重试次数限制
+func (uc *WechatNotificationUseCase) ResendNotificationLog(ctx context.Context, id int64) error {
+	result := uc.db.WithContext(ctx).Model(&wechat.WechatNotificationLog{}).
+		Where("id = ? AND status IN ?", id, []int8{wechat.NotificationLogStatusFailed, wechat.NotificationLogStatusRetrying}).
+		Updates(map[string]interface{}{
+			"status":        wechat.NotificationLogStatusPending,
+			"next_retry_at": nil,
+		})
+	if result.Error != nil {
+		panic(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errorx.WithCause(errorx.ErrBadRequest, "只有发送失败的通知可以重发")
+	}
+
+	uc.goDeliver(id)
+	return nil
+}
+
+// NotifyOrderEvent 异步下发订单事件通知, 不阻塞调用方; extra可补充或覆盖订单变量, 如退款金额
+func (uc *WechatNotificationUseCase) NotifyOrderEvent(event string, orderId int64, extra map[string]string) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logx.Errorf("wechat notification dispatch panic, event: %s, orderId: %d, err: %v", event, orderId, r)
+			}
+		}()
+		ctx := context.Background()
+		logIds, err := uc.DispatchOrderEvent(ctx, event, orderId, extra)
+		if err != nil {
+			logx.Errorf("wechat notification dispatch failed, event: %s, orderId: %d, err: %v", event, orderId, err)
+			return
+		}
+		for _, id := range logIds {
+			uc.deliverNotificationLog(ctx, id)
+		}
+	}()
+}
+
+// DispatchOrderEvent 按事件匹配启用的模板, 为每个渠道生成一条待发送记录
+func (uc *WechatNotificationUseCase) DispatchOrderEvent(ctx context.Context, event string, orderId int64, extra map[string]string) ([]int64, error) {
+	var order trade.Order
+	err := uc.db.WithContext(ctx).
+		Preload("Items").
+		Preload("Logistics").
+		Preload("Customer").
+		First(&order, orderId).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "find order failed")
+	}
+	if order.Customer == nil {
+		return nil, errors.New("order customer not found")
+	}
+
+	templates := uc.FindEnabledNotificationTemplates(ctx, "", []string{event})
+	if len(templates) == 0 {
+		return nil, nil
+	}
+
+	vars := OrderNotificationVariables(&order)
+	for key, value := range extra {
+		vars[key] = value
+	}
+
+	logIds := []int64{}
+	for _, template := range templates {
+		mappings := map[string]string{}
+		_ = json.Unmarshal(template.FieldMappings, &mappings)
+		data, _ := json.Marshal(BuildNotificationData(template.Channel, mappings, vars))
+
+		log := &wechat.WechatNotificationLog{
+			NotificationTemplateId: template.Id,
+			Event:                  event,
+			Channel:                template.Channel,
+			TemplateId:             template.TemplateId,
+			CustomerId:             order.CustomerId,
+			OrderId:                order.Id,
+			Page:                   RenderNotificationValue(template.Page, vars),
+			Data:                   data,
+			Status:                 wechat.NotificationLogStatusPending,
+		}
+
+		switch template.Channel {
+		case wechat.NotificationChannelMPSubscribe:
+			log.OpenId = order.Customer.OpenIdInMiniProgram
+			if log.OpenId != "" && !uc.consumeMPSubscription(ctx, order.CustomerId, template.TemplateId) {
+				log.Status = wechat.NotificationLogStatusSkipped
+				log.LastError = "客户未订阅该消息"
+			}
+		case wechat.NotificationChannelOATemplate:
+			log.OpenId = order.Customer.OpenIdInWeChatOfficialAccount
+		}
+		if log.OpenId == "" {
+			log.Status = wechat.NotificationLogStatusSkipped
+			log.LastError = "客户未绑定对应的微信账号"
+		}
+
+		if err = uc.db.WithContext(ctx).Create(log).Error; err != nil {
+			return logIds, errors.Wrap(err, "create notification log failed")
+		}
+		if log.Status == wechat.NotificationLogStatusPending {
+			logIds = append(logIds, log.Id)
+		}
+	}
+
+	return logIds, nil
+}
+
+func (uc *WechatNotificationUseCase) goDeliver(id int64) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logx.Errorf("wechat notification deliver panic, logId: %d, err: %v", id, r)
+			}
+		}()
+		uc.deliverNotificationLog(context.Background(), id)
+	}()
+}
+
+// claimNotificationLog 将记录置为发送中, 避免即时发送与定时重试重复下发
+func (uc *WechatNotificationUseCase) claimNotificationLog(ctx context.Context, id int64) bool {
+	result := uc.db.WithContext(ctx).Model(&wechat.WechatNotificationLog{}).
+		Where("id = ? AND (status IN ? OR (status = ? AND updated_at < ?))", id,
+			[]int8{wechat.NotificationLogStatusPending, wechat.NotificationLogStatusRetrying},
+			wechat.NotificationLogStatusSending, time.Now().Add(-notificationSendingTimeout)).
+		Update("status", wechat.NotificationLogStatusSending)
+	if result.Error != nil {
+		panic(errors.Wrap(result.Error, "claim notification log failed"))
+	}
+	return result.RowsAffected > 0
+}
+
+func (uc *WechatNotificationUseCase) deliverNotificationLog(ctx context.Context, id int64) {
+	if !uc.claimNotificationLog(ctx, id) {
+		return
+	}
+	var log wechat.WechatNotificationLog
+	if err := uc.db.WithContext(ctx).First(&log, id).Error; err != nil {
+		panic(errors.Wrap(err, "find notification log failed"))
+	}
+
+	log.Attempts++
+	msgId, errCode, err := uc.sendNotification(ctx, &log)
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"attempts": log.Attempts,
+	}
+	switch {
+	case err == nil:
+		updates["status"] = wechat.NotificationLogStatusSent
+		updates["msg_id"] = msgId
+		updates["last_error"] = ""
+		updates["next_retry_at"] = nil
+		updates["sent_at"] = &now
+	case !notificationFatalErrCodes[errCode] && log.Attempts < wechat.NotificationMaxAttempts:
+		nextRetryAt := now.Add(NotificationRetryDelay(log.Attempts))
+		updates["status"] = wechat.NotificationLogStatusRetrying
+		updates["last_error"] = err.Error()
+		updates["next_retry_at"] = &nextRetryAt
+	default:
+		updates["status"] = wechat.NotificationLogStatusFailed
+		updates["last_error"] = err.Error()
+		updates["next_retry_at"] = nil
+	}
+	if err != nil {
+		logx.Errorf("wechat notification send failed, logId: %d, attempts: %d, err: %v", log.Id, log.Attempts, err)
+	}
+
+	if err = uc.db.WithContext(ctx).Model(&log).Updates(updates).Error; err != nil {
+		panic(errors.Wrap(err, "update notification log failed"))
+	}
+}
+
+// sendNotification 返回微信消息ID与错误码, 网络错误时错误码为0
+func (uc *WechatNotificationUseCase) sendNotification(ctx context.Context, log *wechat.WechatNotificationLog) (string, int, error) {
+	data := &power.HashMap{}
+	if err := json.Unmarshal(log.Data, data); err != nil {
+		return "", 0, errors.Wrap(err, "invalid notification data")
+	}
+
+	switch log.Channel {
+	case wechat.NotificationChannelMPSubscribe:
+		res, err := uc.MP.App.SubscribeMessage.Send(ctx, &request.RequestSubscribeMessageSend{
+			ToUser:     log.OpenId,
+			TemplateID: log.TemplateId,
+			Page:       log.Page,
+			Lang:       "zh_CN",
+			Data:       data,
+		})
+		if err != nil {
+			return "", 0, err
+		}
+		if res.ErrCode != 0 {
+			return "", res.ErrCode, fmt.Errorf("%d: %s", res.ErrCode, res.ErrMsg)
+		}
+		return "", 0, nil
+
+	case wechat.NotificationChannelOATemplate:
+		message := &templateRequest.RequestTemlateMessage{
+			ToUser:     log.OpenId,
+			TemplateID: log.TemplateId,
+			URL:        log.Page,
+			Data:       data,
+		}
+		var template wechat.WechatNotificationTemplate
+		err := uc.db.WithContext(ctx).Unscoped().First(&template, log.NotificationTemplateId).Error
+		if err == nil && template.MiniProgramAppId != "" {
+			message.MiniProgram = &templateRequest.MiniProgram{
+				AppID:    template.MiniProgramAppId,
+				PagePath: template.MiniProgramPagePath,
+			}
+		}
+		res, err := uc.OA.App.TemplateMessage.Send(ctx, message)
+		if err != nil {
+			return "", 0, err
+		}
+		if res.ErrCode != 0 {
+			return "", res.ErrCode, fmt.Errorf("%d: %s", res.ErrCode, res.ErrMsg)
+		}
+		return strconv.Itoa(res.MsgID), 0, nil
+	}
+
+	return "", -1, errors.New("unknown notification channel: " + log.Channel)
+}
+
+// RetryDueNotifications 重发到期的失败记录与中断的发送
+func (uc *WechatNotificationUseCase) RetryDueNotifications(ctx context.Context) {
+	var ids []int64
+	now := time.Now()
+	err := uc.db.WithContext(ctx).Model(&wechat.WechatNotificationLog{}).
+		Where("(status = ? AND next_retry_at <= ?) OR (status = ? AND updated_at < ?)",
+			wechat.NotificationLogStatusRetrying, now,
+			wechat.NotificationLogStatusSending, now.Add(-notificationSendingTimeout)).
+		Order("id asc").Limit(200).
+		Pluck("id", &ids).Error
+	if err != nil {
+		logx.Errorf("wechat notification find due logs failed: %v", err)
+		return
+	}
+	for _, id := range ids {
+		uc.deliverNotificationLog(ctx, id)
+	}
+}
+
+// Schedule 每分钟重试一次到期的通知
+func (uc *WechatNotificationUseCase) Schedule(c *cron.Cron) {
+	_, _ = c.AddFunc(`*/1 * * * *`, func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logx.Errorf("wechat notification retry panic: %v", r)
+			}
+		}()
+		uc.RetryDueNotifications(context.Background())
+	})
+}
+
+// NotificationRetryDelay 第attempts次失败后的重试间隔
+func NotificationRetryDelay(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	if attempts > len(notificationRetryDelays) {
+		return notificationRetryDelays[len(notificationRetryDelays)-1]
+	}
+	return notificationRetryDelays[attempts-1]
+}
+
+// OrderNotificationVariables 订单可用于字段映射的变量
+func OrderNotificationVariables(order *trade.Order) map[string]string {
+	vars := map[string]string{
+		"orderId":      strconv.FormatInt(order.Id, 10),
+		"orderNumber":  order.OrderNumber,
+		"amount":       fmt.Sprintf("%.2f", order.UnitPrice),
+		"listPrice":    fmt.Sprintf("%.2f", order.ListPrice),
+		"comment":      order.Comment,
+		"time":         time.Now().Format("2006-01-02 15:04"),
+		"productName":  "",
+		"quantity":     "0",
+		"carrier":      "",
+		"trackingCode": "",
+		"customerName": "",
+	}
+	if order.PowerModel != nil {
+		vars["createdAt"] = order.CreatedAt.Format("2006-01-02 15:04")
+	}
+	quantity := 0
+	for _, item := range order.Items {
+		quantity += item.Quantity
+	}
+	vars["quantity"] = strconv.Itoa(quantity)
+	if len(order.Items) > 0 {
+		vars["productName"] = order.Items[0].ProductName
+		if len(order.Items) > 1 {
+			vars["productName"] = fmt.Sprintf("%s等%d件商品", order.Items[0].ProductName, len(order.Items))
+		}
+	}
+	if order.Logistics != nil {
+		vars["carrier"] = order.Logistics.Carrier
+		vars["trackingCode"] = order.Logistics.TrackingCode
+	}
+	if order.Customer != nil {
+		vars["customerName"] = order.Customer.Name
+	}
+	return vars
+}
+
+// RenderNotificationValue 替换{{变量}}, 未知变量替换为空
+func RenderNotificationValue(tpl string, vars map[string]string) string {
+	var sb strings.Builder
+	for {
+		start := strings.Index(tpl, "{{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(tpl[start:], "}}")
+		if end < 0 {
+			break
+		}
+		sb.WriteString(tpl[:start])
+		sb.WriteString(vars[strings.TrimSpace(tpl[start+2:start+end])])
+		tpl = tpl[start+end+2:]
+	}
+	sb.WriteString(tpl)
+	return sb.String()
+}
+
+// BuildNotificationData 按字段映射生成 {"field": {"value": "..."}}, 订阅消息按字段类型截断
+func BuildNotificationData(channel string, mappings map[string]string, vars map[string]string) power.HashMap {
+	data := power.HashMap{}
+	for key, tpl := range mappings {
+		value := RenderNotificationValue(tpl, vars)
+		if channel == wechat.NotificationChannelMPSubscribe {
+			value = truncateMPSubscribeValue(key, value)
+		}
+		data[key] = power.HashMap{"value": value}
+	}
+	return data
+}
+
+// truncateMPSubscribeValue 字段名形如 thing1, character_string2
+func truncateMPSubscribeValue(key string, value string) string {
+	kind := strings.TrimRight(key, "0123456789")
+	max, ok := mpSubscribeFieldMaxLength[kind]
+	if !ok || utf8.RuneCountInString(value) <= max {
+		return value
+	}
+	return string([]rune(value)[:max])
+}
@@ -0,0 +1,114 @@
+package wechat
+
+import (
+	"PowerX/internal/model/crm/trade"
+	"PowerX/internal/model/powermodel"
+	"PowerX/internal/model/wechat"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/kernel/power"
+	"testing"
+	"time"
+)
+
+func TestRenderNotificationValue(t *testing.T) {
+
+	vars := map[string]string{"orderNumber": "SO123", "amount": "9.90"}
+	cases := map[string]string{
+		"{{orderNumber}}":             "SO123",
+		"订单{{ orderNumber }}已支付":      "订单SO123已支付",
+		"{{amount}}元":                 "9.90元",
+		"{{unknown}}-{{orderNumber}}": "-SO123",
+		"{{broken":                    "{{broken",
+		"纯文本":                         "纯文本",
+	}
+	for tpl, want := range cases {
+		if got := RenderNotificationValue(tpl, vars); got != want {
+			t.Errorf("render %q = %q, want %q", tpl, got, want)
+		}
+	}
+}
+
+func TestBuildNotificationData(t *testing.T) {
+
+	order := &trade.Order{
+		PowerModel:  &powermodel.PowerModel{Id: 7},
+		OrderNumber: "SO20231001",
+		UnitPrice:   12.5,
+		Items: []*trade.OrderItem{
+			{ProductName: "这是一个名字非常非常非常非常长的商品", Quantity: 2},
+			{ProductName: "赠品", Quantity: 1},
+		},
+		Logistics: &trade.Logistics{Carrier: "顺丰", TrackingCode: "SF1001"},
+	}
+	vars := OrderNotificationVariables(order)
+	mappings := map[string]string{
+		"character_string1": "{{orderNumber}}",
+		"thing2":            "{{productName}}",
+		"amount3":           "{{amount}}元",
+		"thing4":            "{{carrier}} {{trackingCode}}",
+	}
+
+	data := BuildNotificationData(wechat.NotificationChannelMPSubscribe, mappings, vars)
+	value := func(key string) string {
+		return data[key].(power.HashMap)["value"].(string)
+	}
+	if value("character_string1") != "SO20231001" {
+		t.Errorf("orderNumber = %q", value("character_string1"))
+	}
+	if []rune(value("thing2"))[0] != '这' || len([]rune(value("thing2"))) != 20 {
+		t.Errorf("thing should be truncated to 20 runes, got %q", value("thing2"))
+	}
+	if value("amount3") != "12.50元" {
+		t.Errorf("amount = %q", value("amount3"))
+	}
+	if value("thing4") != "顺丰 SF1001" {
+		t.Errorf("logistics = %q", value("thing4"))
+	}
+	if vars["quantity"] != "3" {
+		t.Errorf("quantity = %q", vars["quantity"])
+	}
+
+	// 公众号模板消息不截断
+	data = BuildNotificationData(wechat.NotificationChannelOATemplate, mappings, vars)
+	if len([]rune(value("thing2"))) <= 20 {
+		t.Errorf("oa template value should not be truncated, got %q", value("thing2"))
+	}
+}
+
+func TestNotificationRetryDelay(t *testing.T) {
+
+	want := []time.Duration{time.Minute, time.Minute, 5 * time.Minute, 15 * time.Minute, time.Hour, time.Hour}
+	for i, delay := range want {
+		if got := NotificationRetryDelay(i); got != delay {
+			t.Errorf("attempts %d: delay = %v, want %v", i, got, delay)
+		}
+	}
+}
+
+func TestValidateNotificationTemplate(t *testing.T) {
+
+	valid := &wechat.WechatNotificationTemplate{
+		Event:         wechat.NotificationEventOrderPaid,
+		Channel:       wechat.NotificationChannelMPSubscribe,
+		TemplateId:    "tpl",
+		FieldMappings: []byte(`{"thing1":"{{productName}}"}`),
+	}
+	if err := ValidateNotificationTemplate(valid); err != nil {
+		t.Fatalf("valid template rejected: %v", err)
+	}
+	if valid.Status != wechat.NotificationTemplateStatusEnable {
+		t.Errorf("status should default to enable")
+	}
+
+	cases := map[string]*wechat.WechatNotificationTemplate{
+		"event":    {Event: "x", Channel: valid.Channel, TemplateId: "tpl", FieldMappings: valid.FieldMappings},
+		"channel":  {Event: valid.Event, Channel: "sms", TemplateId: "tpl", FieldMappings: valid.FieldMappings},
+		"template": {Event: valid.Event, Channel: valid.Channel, FieldMappings: valid.FieldMappings},
+		"mappings": {Event: valid.Event, Channel: valid.Channel, TemplateId: "tpl", FieldMappings: []byte(`{}`)},
+		"json":     {Event: valid.Event, Channel: valid.Channel, TemplateId: "tpl", FieldMappings: []byte(`[1]`)},
+	}
+	for name, template := range cases {
+		if err := ValidateNotificationTemplate(template); err == nil {
+			t.Errorf("case %s: expected error", name)
+		}
+	}
+}
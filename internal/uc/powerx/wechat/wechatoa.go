@@ -27,6 +27,7 @@ func NewWechatOfficialAccountUseCase(db *gorm.DB, conf *config.Config) *WechatOf
 			Callback: conf.WechatOA.OAuth.Callback,
 			Scopes:   conf.WechatOA.OAuth.Scopes,
 		},
+		Token:     conf.WechatOA.Token,
 		AESKey:    conf.WechatOA.AESKey,
 		HttpDebug: true,
 	})
@@ -123,7 +124,7 @@ func (uc *WechatOfficialAccountUseCase) UpsertOACustomer(ctx context.Context, cu
 
 func (uc *WechatOfficialAccountUseCase) UpsertOACustomers(ctx context.Context, customers []*wechat.WechatOACustomer) ([]*wechat.WechatOACustomer, error) {
 
-	err := powermodel.UpsertModelsOnUniqueID(uc.db.WithContext(ctx), &wechat.WechatOACustomer{}, wechat.WechatMpCustomerUniqueId, customers, nil, false)
+	err := powermodel.UpsertModelsOnUniqueID(uc.db.WithContext(ctx), &wechat.WechatOACustomer{}, wechat.WechatOACustomerUniqueId, customers, nil, false)
 
 	if err != nil {
 		panic(errors.Wrap(err, "batch upsert mp customers failed"))
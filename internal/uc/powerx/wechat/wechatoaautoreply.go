@@ -0,0 +1,127 @@
+package wechat
+
+import (
+	"PowerX/internal/model/wechat"
+	"PowerX/internal/types"
+	"PowerX/internal/types/errorx"
+	"context"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"strings"
+)
+
+type FindManyOAAutoReplyRulesOption struct {
+	MatchTypes []int8
+	Status     int8
+	LikeName   string
+	types.PageEmbedOption
+}
+
+func (uc *WechatOfficialAccountUseCase) buildFindAutoReplyQueryNoPage(db *gorm.DB, opt *FindManyOAAutoReplyRulesOption) *gorm.DB {
+	if len(opt.MatchTypes) > 0 {
+		db = db.Where("match_type IN ?", opt.MatchTypes)
+	}
+	if opt.Status > 0 {
+		db = db.Where("status = ?", opt.Status)
+	}
+	if opt.LikeName != "" {
+		db = db.Where("name LIKE ?", "%"+opt.LikeName+"%")
+	}
+	return db.Order("priority desc, id desc")
+}
+
+func (uc *WechatOfficialAccountUseCase) FindManyOAAutoReplyRules(ctx context.Context, opt *FindManyOAAutoReplyRulesOption) types.Page[*wechat.WechatOAAutoReplyRule] {
+	var rules []*wechat.WechatOAAutoReplyRule
+	db := uc.db.WithContext(ctx).Model(&wechat.WechatOAAutoReplyRule{})
+
+	db = uc.buildFindAutoReplyQueryNoPage(db, opt)
+
+	var count int64
+	if err := db.Count(&count).Error; err != nil {
+		panic(err)
+	}
+
+	opt.DefaultPageIfNotSet()
+	db.Offset((opt.PageIndex - 1) * opt.PageSize).Limit(opt.PageSize)
+
+	if err := db.Find(&rules).Error; err != nil {
+		panic(err)
+	}
+
+	return types.Page[*wechat.WechatOAAutoReplyRule]{
+		List:      rules,
+		PageIndex: opt.PageIndex,
+		PageSize:  opt.PageSize,
+		Total:     count,
+	}
+}
+
+func (uc *WechatOfficialAccountUseCase) CreateOAAutoReplyRule(ctx context.Context, rule *wechat.WechatOAAutoReplyRule) {
+	if err := uc.db.WithContext(ctx).Create(rule).Error; err != nil {
+		panic(err)
+	}
+}
+
+func (uc *WechatOfficialAccountUseCase) UpdateOAAutoReplyRule(ctx context.Context, id int64, rule *wechat.WechatOAAutoReplyRule) error {
+	result := uc.db.WithContext(ctx).Model(&wechat.WechatOAAutoReplyRule{}).Where(id).
+		Select("*").Omit("id", "created_at", "deleted_at").Updates(rule)
+	if err := result.Error; err != nil {
+		panic(err)
+	}
+	if result.RowsAffected == 0 {
+		return errorx.WithCause(errorx.ErrBadRequest, "未找到自动回复规则")
+	}
+	return nil
+}
+
+func (uc *WechatOfficialAccountUseCase) DeleteOAAutoReplyRule(ctx context.Context, id int64) error {
+	result := uc.db.WithContext(ctx).Delete(&wechat.WechatOAAutoReplyRule{}, id)
+	if err := result.Error; err != nil {
+		panic(err)
+	}
+	if result.RowsAffected == 0 {
+		return errorx.WithCause(errorx.ErrBadRequest, "未找到自动回复规则")
+	}
+	return nil
+}
+
+// MatchOAAutoReplyRule 按优先级匹配启用的规则, 全匹配优先于半匹配
+func (uc *WechatOfficialAccountUseCase) MatchOAAutoReplyRule(ctx context.Context, matchType int8, keyword string) (*wechat.WechatOAAutoReplyRule, error) {
+	var rules []*wechat.WechatOAAutoReplyRule
+	db := uc.db.WithContext(ctx).Model(&wechat.WechatOAAutoReplyRule{}).
+		Where("status = ?", wechat.OAAutoReplyStatusEnable)
+
+	switch matchType {
+	case wechat.OAAutoReplyMatchTypeExact, wechat.OAAutoReplyMatchTypeFuzzy:
+		db = db.Where("match_type IN ?", []int8{wechat.OAAutoReplyMatchTypeExact, wechat.OAAutoReplyMatchTypeFuzzy})
+	case wechat.OAAutoReplyMatchTypeClick:
+		db = db.Where("match_type = ? AND keyword = ?", matchType, keyword)
+	default:
+		db = db.Where("match_type = ?", matchType)
+	}
+	if err := db.Order("priority desc, id desc").Find(&rules).Error; err != nil {
+		panic(errors.Wrap(err, "find auto reply rules failed"))
+	}
+
+	keyword = strings.TrimSpace(keyword)
+	var fuzzy *wechat.WechatOAAutoReplyRule
+	for _, rule := range rules {
+		switch rule.MatchType {
+		case wechat.OAAutoReplyMatchTypeExact:
+			if rule.Keyword == keyword {
+				return rule, nil
+			}
+		case wechat.OAAutoReplyMatchTypeFuzzy:
+			if fuzzy == nil && rule.Keyword != "" && strings.Contains(keyword, rule.Keyword) {
+				fuzzy = rule
+			}
+		default:
+			return rule, nil
+		}
+	}
+	if fuzzy != nil {
+		return fuzzy, nil
+	}
+
+	return nil, errorx.ErrRecordNotFound
+}
@@ -0,0 +1,488 @@
+package wechat
+
+import (
+	"PowerX/internal/model/wechat"
+	"PowerX/internal/types/errorx"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/kernel/response"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/officialAccount/menu/request"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"io"
+	"time"
+)
+
+// 微信菜单限制
+// https://developers.weixin.qq.com/doc/offiaccount/Custom_Menus/Creating_Custom-Defined_Menu.html
+const (
+	OAMenuMaxButtons       = 3
+	OAMenuMaxSubButtons    = 5
+	OAMenuMaxNameBytes     = 16
+	OAMenuMaxSubNameBytes  = 60
+	OAMenuMaxKeyBytes      = 128
+	OAMenuMaxUrlBytes      = 1024
+	OAMenuMaxMediaIdLength = 128
+)
+
+// OAMenuDiff 已发布微信菜单与本地草稿的差异
+type OAMenuDiff struct {
+	Path   string
+	Action string
+	Remote string
+	Local  string
+}
+
+type oaMenuGetReply struct {
+	response.ResponseOfficialAccount
+
+	Menu *struct {
+		Buttons []*request.Button `json:"button"`
+	} `json:"menu"`
+	ConditionalMenus []*struct {
+		Buttons []*request.Button `json:"button"`
+		MenuID  int               `json:"menuid"`
+	} `json:"conditionalmenu"`
+}
+
+const (
+	OAMenuDiffActionAdded   = "added"
+	OAMenuDiffActionRemoved = "removed"
+	OAMenuDiffActionChanged = "changed"
+)
+
+// ValidateOAMenuButtons 发布前校验微信菜单限制
+func ValidateOAMenuButtons(buttons []*request.Button) error {
+	if len(buttons) == 0 {
+		return errorx.WithCause(errorx.ErrBadRequest, "菜单不能为空")
+	}
+	if len(buttons) > OAMenuMaxButtons {
+		return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("一级菜单最多%d个", OAMenuMaxButtons))
+	}
+	for i, button := range buttons {
+		path := fmt.Sprintf("button[%d]", i)
+		if err := validateOAMenuName(path, button.Name, OAMenuMaxNameBytes); err != nil {
+			return err
+		}
+		if len(button.SubButtons) > OAMenuMaxSubButtons {
+			return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("%s 二级菜单最多%d个", path, OAMenuMaxSubButtons))
+		}
+		if len(button.SubButtons) > 0 {
+			for j, sub := range button.SubButtons {
+				subPath := fmt.Sprintf("%s.sub_button[%d]", path, j)
+				if err := validateOAMenuName(subPath, sub.Name, OAMenuMaxSubNameBytes); err != nil {
+					return err
+				}
+				if err := validateOAMenuAction(subPath, sub.Type, sub.Key, sub.URL, sub.AppID, sub.PagePath, sub.MediaId); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := validateOAMenuAction(path, button.Type, button.Key, button.URL, button.AppID, button.PagePath, button.MediaId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateOAMenuName(path string, name string, max int) error {
+	if name == "" {
+		return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("%s 名称不能为空", path))
+	}
+	if len(name) > max {
+		return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("%s 名称不能超过%d字节", path, max))
+	}
+	return nil
+}
+
+func validateOAMenuAction(path string, typ string, key string, url string, appId string, pagePath string, mediaId string) error {
+	bad := func(msg string) error {
+		return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("%s %s", path, msg))
+	}
+	switch typ {
+	case "click", "scancode_push", "scancode_waitmsg", "pic_sysphoto", "pic_photo_or_album", "pic_weixin", "location_select":
+		if key == "" || len(key) > OAMenuMaxKeyBytes {
+			return bad(fmt.Sprintf("key必填且不超过%d字节", OAMenuMaxKeyBytes))
+		}
+	case "view":
+		if url == "" || len(url) > OAMenuMaxUrlBytes {
+			return bad(fmt.Sprintf("url必填且不超过%d字节", OAMenuMaxUrlBytes))
+		}
+	case "miniprogram":
+		if url == "" || appId == "" || pagePath == "" {
+			return bad("小程序菜单url、appid、pagepath必填")
+		}
+	case "media_id", "view_limited", "article_id", "article_view_limited":
+		if mediaId == "" || len(mediaId) > OAMenuMaxMediaIdLength {
+			return bad("media_id必填")
+		}
+	case "":
+		return bad("无二级菜单时必须设置类型")
+	default:
+		return bad(fmt.Sprintf("不支持的菜单类型%s", typ))
+	}
+	return nil
+}
+
+// DiffOAMenuButtons 逐个按钮比较远端与本地, 以路径标识差异
+func DiffOAMenuButtons(remote []*request.Button, local []*request.Button) []*OAMenuDiff {
+	diffs := []*OAMenuDiff{}
+	for i := 0; i < len(remote) || i < len(local); i++ {
+		path := fmt.Sprintf("button[%d]", i)
+		switch {
+		case i >= len(remote):
+			diffs = append(diffs, &OAMenuDiff{Path: path, Action: OAMenuDiffActionAdded, Local: local[i].Name})
+		case i >= len(local):
+			diffs = append(diffs, &OAMenuDiff{Path: path, Action: OAMenuDiffActionRemoved, Remote: remote[i].Name})
+		default:
+			r, l := remote[i], local[i]
+			diffs = append(diffs, diffOAMenuFields(path, r.Name, r.Type, r.Key, r.URL, r.AppID, r.PagePath, r.MediaId,
+				l.Name, l.Type, l.Key, l.URL, l.AppID, l.PagePath, l.MediaId)...)
+			diffs = append(diffs, diffOAMenuSubButtons(path, r.SubButtons, l.SubButtons)...)
+		}
+	}
+	return diffs
+}
+
+func diffOAMenuSubButtons(parent string, remote []request.SubButton, local []request.SubButton) []*OAMenuDiff {
+	diffs := []*OAMenuDiff{}
+	for i := 0; i < len(remote) || i < len(local); i++ {
+		path := fmt.Sprintf("%s.sub_button[%d]", parent, i)
+		switch {
+		case i >= len(remote):
+			diffs = append(diffs, &OAMenuDiff{Path: path, Action: OAMenuDiffActionAdded, Local: local[i].Name})
+		case i >= len(local):
+			diffs = append(diffs, &OAMenuDiff{Path: path, Action: OAMenuDiffActionRemoved, Remote: remote[i].Name})
+		default:
+			r, l := remote[i], local[i]
+			diffs = append(diffs, diffOAMenuFields(path, r.Name, r.Type, r.Key, r.URL, r.AppID, r.PagePath, r.MediaId,
+				l.Name, l.Type, l.Key, l.URL, l.AppID, l.PagePath, l.MediaId)...)
+		}
+	}
+	return diffs
+}
+
+func diffOAMenuFields(path string, remote ...string) []*OAMenuDiff {
+	fields := []string{"name", "type", "key", "url", "appid", "pagepath", "media_id"}
+	local := remote[len(fields):]
+	diffs := []*OAMenuDiff{}
+	for i, field := range fields {
+		if remote[i] != local[i] {
+			diffs = append(diffs, &OAMenuDiff{Path: path + "." + field, Action: OAMenuDiffActionChanged, Remote: remote[i], Local: local[i]})
+		}
+	}
+	return diffs
+}
+
+func DecodeOAMenuButtons(version *wechat.WechatOAMenuVersion) []*request.Button {
+	buttons := []*request.Button{}
+	if version == nil || len(version.Buttons) == 0 {
+		return buttons
+	}
+	if err := json.Unmarshal(version.Buttons, &buttons); err != nil {
+		panic(errors.Wrap(err, "decode oa menu buttons failed"))
+	}
+	return buttons
+}
+
+func (uc *WechatOfficialAccountUseCase) FindAllOAMenus(ctx context.Context) []*wechat.WechatOAMenu {
+	var menus []*wechat.WechatOAMenu
+	if err := uc.db.WithContext(ctx).Order("menu_type asc, id asc").Find(&menus).Error; err != nil {
+		panic(err)
+	}
+	return menus
+}
+
+func (uc *WechatOfficialAccountUseCase) GetOAMenu(ctx context.Context, id int64) (*wechat.WechatOAMenu, error) {
+	var menu wechat.WechatOAMenu
+	err := uc.db.WithContext(ctx).
+		Preload("Versions", func(db *gorm.DB) *gorm.DB { return db.Order("version desc") }).
+		First(&menu, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.WithCause(errorx.ErrNotFoundObject, "未找到菜单")
+		}
+		panic(err)
+	}
+	return &menu, nil
+}
+
+func (uc *WechatOfficialAccountUseCase) GetOAMenuVersion(ctx context.Context, menuId int64, version int) (*wechat.WechatOAMenuVersion, error) {
+	var mdl wechat.WechatOAMenuVersion
+	err := uc.db.WithContext(ctx).Where("menu_id = ? AND version = ?", menuId, version).First(&mdl).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.WithCause(errorx.ErrNotFoundObject, "未找到菜单版本")
+		}
+		panic(err)
+	}
+	return &mdl, nil
+}
+
+// SaveOAMenuDraft 保存草稿, 每次保存生成新版本; 草稿允许暂不满足限制, 发布时再强校验
+func (uc *WechatOfficialAccountUseCase) SaveOAMenuDraft(ctx context.Context, menu *wechat.WechatOAMenu, buttons []*request.Button, remark string) (*wechat.WechatOAMenu, error) {
+	if menu.MenuType == wechat.OAMenuTypeConditional && menu.OAMenuMatchRule.IsEmpty() {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "个性化菜单匹配规则不能全为空")
+	}
+	if len(buttons) > OAMenuMaxButtons {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("一级菜单最多%d个", OAMenuMaxButtons))
+	}
+	data, err := json.Marshal(buttons)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if menu.Id == 0 {
+			if menu.MenuType == wechat.OAMenuTypeDefault {
+				var count int64
+				tx.Model(&wechat.WechatOAMenu{}).Where("menu_type = ?", wechat.OAMenuTypeDefault).Count(&count)
+				if count > 0 {
+					return errorx.WithCause(errorx.ErrBadRequest, "默认菜单已存在")
+				}
+			}
+			if err := tx.Create(menu).Error; err != nil {
+				return err
+			}
+		} else {
+			var current wechat.WechatOAMenu
+			if err := tx.First(&current, menu.Id).Error; err != nil {
+				return errorx.WithCause(errorx.ErrNotFoundObject, "未找到菜单")
+			}
+			menu.MenuType = current.MenuType
+			menu.PublishedVersion = current.PublishedVersion
+			menu.WechatMenuId = current.WechatMenuId
+			menu.PublishedAt = current.PublishedAt
+			menu.DraftVersion = current.DraftVersion
+		}
+
+		menu.DraftVersion++
+		version := &wechat.WechatOAMenuVersion{
+			MenuId:  menu.Id,
+			Version: menu.DraftVersion,
+			Buttons: data,
+			Remark:  remark,
+		}
+		if err := tx.Create(version).Error; err != nil {
+			return err
+		}
+		return tx.Model(&wechat.WechatOAMenu{}).Where(menu.Id).
+			Select("name", "tag_id", "sex", "client_platform_type", "country", "province", "city", "language", "draft_version").
+			Updates(menu).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return menu, nil
+}
+
+// PublishOAMenu 发布指定版本(0为当前草稿)到微信
+func (uc *WechatOfficialAccountUseCase) PublishOAMenu(ctx context.Context, id int64, version int) (*wechat.WechatOAMenu, error) {
+	menu, err := uc.GetOAMenu(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if version == 0 {
+		version = menu.DraftVersion
+	}
+	mdlVersion, err := uc.GetOAMenuVersion(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+	buttons := DecodeOAMenuButtons(mdlVersion)
+	if err = ValidateOAMenuButtons(buttons); err != nil {
+		return nil, err
+	}
+	if err = uc.checkOAMenuMediaIds(ctx, buttons); err != nil {
+		return nil, err
+	}
+
+	switch menu.MenuType {
+	case wechat.OAMenuTypeConditional:
+		// 个性化菜单不支持修改, 先删后建
+		if menu.WechatMenuId != "" {
+			if err = uc.deleteOAConditionalMenu(ctx, menu.WechatMenuId); err != nil {
+				return nil, err
+			}
+		}
+		res, err := uc.App.Menu.CreateConditional(ctx, buttons, &request.RequestMatchRule{
+			TagID:              menu.TagId,
+			Sex:                menu.Sex,
+			Country:            menu.Country,
+			Province:           menu.Province,
+			City:               menu.City,
+			ClientPlatformType: menu.ClientPlatformType,
+			Language:           menu.Language,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if res.ErrCode != 0 {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, res.ErrMsg)
+		}
+		menu.WechatMenuId = res.MenuID
+	default:
+		res, err := uc.App.Menu.Create(ctx, buttons)
+		if err != nil {
+			return nil, err
+		}
+		if res.ErrCode != 0 {
+			return nil, errorx.WithCause(errorx.ErrBadRequest, res.ErrMsg)
+		}
+	}
+
+	now := time.Now()
+	menu.PublishedVersion = version
+	menu.PublishedAt = &now
+	err = uc.db.WithContext(ctx).Model(&wechat.WechatOAMenu{}).Where(menu.Id).
+		Select("published_version", "published_at", "wechat_menu_id").Updates(menu).Error
+	if err != nil {
+		panic(err)
+	}
+	return menu, nil
+}
+
+// RollbackOAMenu 以历史版本内容生成新草稿, 可选立即发布
+func (uc *WechatOfficialAccountUseCase) RollbackOAMenu(ctx context.Context, id int64, version int, publish bool) (*wechat.WechatOAMenu, error) {
+	menu, err := uc.GetOAMenu(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	mdlVersion, err := uc.GetOAMenuVersion(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+	menu.Versions = nil
+	menu, err = uc.SaveOAMenuDraft(ctx, menu, DecodeOAMenuButtons(mdlVersion), fmt.Sprintf("rollback from v%d", version))
+	if err != nil {
+		return nil, err
+	}
+	if publish {
+		return uc.PublishOAMenu(ctx, id, menu.DraftVersion)
+	}
+	return menu, nil
+}
+
+// DiffOAMenu 比较微信当前生效菜单与本地草稿
+func (uc *WechatOfficialAccountUseCase) DiffOAMenu(ctx context.Context, id int64) ([]*OAMenuDiff, error) {
+	menu, err := uc.GetOAMenu(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	draft, err := uc.GetOAMenuVersion(ctx, id, menu.DraftVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	// SDK的返回结构缺少media_id, 这里直接按创建时的结构解析
+	res := &oaMenuGetReply{}
+	if _, err = uc.App.Menu.BaseClient.HttpGet(ctx, "cgi-bin/menu/get", nil, nil, res); err != nil {
+		return nil, err
+	}
+	remote := []*request.Button{}
+	switch menu.MenuType {
+	case wechat.OAMenuTypeConditional:
+		for _, conditional := range res.ConditionalMenus {
+			if fmt.Sprint(conditional.MenuID) == menu.WechatMenuId {
+				remote = conditional.Buttons
+			}
+		}
+	default:
+		// 未设置菜单时返回46003
+		if res.ErrCode == 0 && res.Menu != nil {
+			remote = res.Menu.Buttons
+		}
+	}
+
+	return DiffOAMenuButtons(remote, DecodeOAMenuButtons(draft)), nil
+}
+
+// DeleteOAMenu 删除本地菜单, 已发布的同时从微信删除
+func (uc *WechatOfficialAccountUseCase) DeleteOAMenu(ctx context.Context, id int64) error {
+	menu, err := uc.GetOAMenu(ctx, id)
+	if err != nil {
+		return err
+	}
+	if menu.PublishedVersion > 0 {
+		if menu.MenuType == wechat.OAMenuTypeConditional {
+			err = uc.deleteOAConditionalMenu(ctx, menu.WechatMenuId)
+		} else {
+			err = uc.DeleteOAPublishedMenus(ctx)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("menu_id = ?", id).Delete(&wechat.WechatOAMenuVersion{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&wechat.WechatOAMenu{}, id).Error
+	})
+}
+
+// DeleteOAPublishedMenus 删除微信上的全部菜单(含个性化菜单), 本地保留草稿
+func (uc *WechatOfficialAccountUseCase) DeleteOAPublishedMenus(ctx context.Context) error {
+	res, err := uc.App.Menu.Delete(ctx)
+	if err != nil {
+		return err
+	}
+	if res.ErrCode != 0 {
+		return errorx.WithCause(errorx.ErrDeleteObject, res.ErrMsg)
+	}
+	err = uc.db.WithContext(ctx).Model(&wechat.WechatOAMenu{}).Where("published_version > 0").
+		Updates(map[string]interface{}{"published_version": 0, "published_at": nil, "wechat_menu_id": ""}).Error
+	if err != nil {
+		panic(err)
+	}
+	return nil
+}
+
+func (uc *WechatOfficialAccountUseCase) deleteOAConditionalMenu(ctx context.Context, wechatMenuId string) error {
+	var menuId int
+	if _, err := fmt.Sscan(wechatMenuId, &menuId); err != nil {
+		return nil
+	}
+	res, err := uc.App.Menu.DeleteConditional(ctx, menuId)
+	if err != nil {
+		return err
+	}
+	if res.ErrCode != 0 {
+		return errorx.WithCause(errorx.ErrDeleteObject, res.ErrMsg)
+	}
+	return nil
+}
+
+// checkOAMenuMediaIds 确认引用的永久素材存在
+func (uc *WechatOfficialAccountUseCase) checkOAMenuMediaIds(ctx context.Context, buttons []*request.Button) error {
+	mediaIds := []string{}
+	for _, button := range buttons {
+		if button.MediaId != "" {
+			mediaIds = append(mediaIds, button.MediaId)
+		}
+		for _, sub := range button.SubButtons {
+			if sub.MediaId != "" {
+				mediaIds = append(mediaIds, sub.MediaId)
+			}
+		}
+	}
+	for _, mediaId := range mediaIds {
+		res, err := uc.App.Material.Get(ctx, mediaId)
+		if err != nil {
+			return err
+		}
+		body, _ := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		reply := struct {
+			ErrCode int    `json:"errcode"`
+			ErrMsg  string `json:"errmsg"`
+		}{}
+		// 图片等素材直接返回文件内容, 只有JSON响应才可能是错误
+		if json.Unmarshal(body, &reply) == nil && reply.ErrCode != 0 {
+			return errorx.WithCause(errorx.ErrBadRequest, fmt.Sprintf("素材%s无效: %s", mediaId, reply.ErrMsg))
+		}
+	}
+	return nil
+}
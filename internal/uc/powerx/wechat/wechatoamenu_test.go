@@ -0,0 +1,65 @@
+package wechat
+
+import (
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/officialAccount/menu/request"
+	"strings"
+	"testing"
+)
+
+func TestValidateOAMenuButtons(t *testing.T) {
+
+	valid := []*request.Button{
+		{Type: "click", Name: "今日歌曲", Key: "V1001_TODAY_MUSIC"},
+		{Name: "菜单", SubButtons: []request.SubButton{
+			{Type: "view", Name: "搜索", URL: "https://www.soso.com/"},
+			{Type: "miniprogram", Name: "wxa", URL: "https://mp.weixin.qq.com", AppID: "wx286b93c14bbf93aa", PagePath: "pages/lunar/index"},
+			{Type: "media_id", Name: "图片", MediaId: "MEDIA_ID1"},
+		}},
+	}
+	if err := ValidateOAMenuButtons(valid); err != nil {
+		t.Fatalf("valid menu rejected: %v", err)
+	}
+
+	cases := map[string][]*request.Button{
+		"empty":        {},
+		"too many":     {valid[0], valid[0], valid[0], valid[0]},
+		"long name":    {{Type: "click", Name: strings.Repeat("菜", 6), Key: "k"}},
+		"missing key":  {{Type: "click", Name: "a"}},
+		"missing url":  {{Type: "view", Name: "a"}},
+		"missing type": {{Name: "a"}},
+		"media":        {{Type: "media_id", Name: "a"}},
+		"too many sub": {{Name: "a", SubButtons: make([]request.SubButton, 6)}},
+	}
+	for name, buttons := range cases {
+		if err := ValidateOAMenuButtons(buttons); err == nil {
+			t.Errorf("case %s: expected error", name)
+		}
+	}
+}
+
+func TestDiffOAMenuButtons(t *testing.T) {
+
+	remote := []*request.Button{
+		{Type: "click", Name: "a", Key: "k1"},
+		{Name: "b", SubButtons: []request.SubButton{{Type: "view", Name: "b1", URL: "u1"}}},
+	}
+	local := []*request.Button{
+		{Type: "click", Name: "a", Key: "k2"},
+		{Name: "b", SubButtons: []request.SubButton{{Type: "view", Name: "b1", URL: "u1"}, {Type: "view", Name: "b2", URL: "u2"}}},
+		{Type: "click", Name: "c", Key: "k3"},
+	}
+
+	diffs := DiffOAMenuButtons(remote, local)
+	got := []string{}
+	for _, diff := range diffs {
+		got = append(got, diff.Action+":"+diff.Path)
+	}
+	want := []string{"changed:button[0].key", "added:button[1].sub_button[1]", "added:button[2]"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("diff = %v, want %v", got, want)
+	}
+
+	if len(DiffOAMenuButtons(local, local)) != 0 {
+		t.Fatal("identical menus should have no diff")
+	}
+}
@@ -0,0 +1,257 @@
+package wechat
+
+import (
+	"PowerX/internal/model/wechat"
+	"PowerX/internal/types"
+	"PowerX/internal/types/errorx"
+	"PowerX/pkg/stringx"
+	"context"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+// 系统生成的场景值前缀
+const oaQrcodeScenePrefix = "px_"
+
+type FindManyOAQrcodesOption struct {
+	Channel  string
+	Campaign string
+	QrType   int8
+	LikeName string
+	types.PageEmbedOption
+}
+
+// OAQrcodeDailyStatistics 单日扫码与增长
+type OAQrcodeDailyStatistics struct {
+	Date        string
+	Scans       int
+	Subscribes  int
+	NewFans     int
+	Unsubscribe int
+	Retained    int
+}
+
+// OAQrcodeStatistics 区间汇总, Retained为区间内通过该码关注且当前仍关注的粉丝
+type OAQrcodeStatistics struct {
+	Scans       int
+	Subscribes  int
+	NewFans     int
+	Unsubscribe int
+	Retained    int
+	Daily       []*OAQrcodeDailyStatistics
+}
+
+func (uc *WechatOfficialAccountUseCase) buildFindQrcodeQueryNoPage(db *gorm.DB, opt *FindManyOAQrcodesOption) *gorm.DB {
+	if opt.Channel != "" {
+		db = db.Where("channel = ?", opt.Channel)
+	}
+	if opt.Campaign != "" {
+		db = db.Where("campaign = ?", opt.Campaign)
+	}
+	if opt.QrType > 0 {
+		db = db.Where("qr_type = ?", opt.QrType)
+	}
+	if opt.LikeName != "" {
+		db = db.Where("name LIKE ?", "%"+opt.LikeName+"%")
+	}
+	return db.Order("id desc")
+}
+
+func (uc *WechatOfficialAccountUseCase) FindManyOAQrcodes(ctx context.Context, opt *FindManyOAQrcodesOption) types.Page[*wechat.WechatOAQrcode] {
+	var qrcodes []*wechat.WechatOAQrcode
+	db := uc.db.WithContext(ctx).Model(&wechat.WechatOAQrcode{})
+
+	db = uc.buildFindQrcodeQueryNoPage(db, opt)
+
+	var count int64
+	if err := db.Count(&count).Error; err != nil {
+		panic(err)
+	}
+
+	opt.DefaultPageIfNotSet()
+	db.Offset((opt.PageIndex - 1) * opt.PageSize).Limit(opt.PageSize)
+
+	if err := db.Find(&qrcodes).Error; err != nil {
+		panic(err)
+	}
+
+	return types.Page[*wechat.WechatOAQrcode]{
+		List:      qrcodes,
+		PageIndex: opt.PageIndex,
+		PageSize:  opt.PageSize,
+		Total:     count,
+	}
+}
+
+func (uc *WechatOfficialAccountUseCase) GetOAQrcode(ctx context.Context, id int64) (*wechat.WechatOAQrcode, error) {
+	var qrcode wechat.WechatOAQrcode
+	if err := uc.db.WithContext(ctx).First(&qrcode, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.WithCause(errorx.ErrNotFoundObject, "未找到二维码")
+		}
+		panic(err)
+	}
+	return &qrcode, nil
+}
+
+// CreateOAQrcode 向微信申请ticket后保存
+func (uc *WechatOfficialAccountUseCase) CreateOAQrcode(ctx context.Context, qrcode *wechat.WechatOAQrcode) (*wechat.WechatOAQrcode, error) {
+	if qrcode.SceneStr == "" {
+		qrcode.SceneStr = oaQrcodeScenePrefix + stringx.GenerateRandomCode(16)
+	}
+	var count int64
+	uc.db.WithContext(ctx).Model(&wechat.WechatOAQrcode{}).Unscoped().Where("scene_str = ?", qrcode.SceneStr).Count(&count)
+	if count > 0 {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "场景值已存在")
+	}
+
+	switch qrcode.QrType {
+	case wechat.OAQrcodeTypeForever:
+		res, err := uc.App.QRCode.Forever(ctx, qrcode.SceneStr)
+		if err != nil {
+			return nil, err
+		}
+		qrcode.ExpireSeconds = 0
+		qrcode.Ticket, qrcode.Url = res.Ticket, res.Url
+	default:
+		if qrcode.ExpireSeconds <= 0 || qrcode.ExpireSeconds > wechat.OAQrcodeMaxExpireSeconds {
+			qrcode.ExpireSeconds = wechat.OAQrcodeMaxExpireSeconds
+		}
+		res, err := uc.App.QRCode.Temporary(ctx, qrcode.SceneStr, qrcode.ExpireSeconds)
+		if err != nil {
+			return nil, err
+		}
+		expiredAt := time.Now().Add(time.Duration(res.ExpireSeconds) * time.Second)
+		qrcode.QrType = wechat.OAQrcodeTypeTemporary
+		qrcode.Ticket, qrcode.Url, qrcode.ExpiredAt = res.Ticket, res.Url, &expiredAt
+	}
+	if qrcode.Ticket == "" {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "创建公众号二维码失败")
+	}
+
+	if err := uc.db.WithContext(ctx).Create(qrcode).Error; err != nil {
+		panic(err)
+	}
+	return qrcode, nil
+}
+
+func (uc *WechatOfficialAccountUseCase) DeleteOAQrcode(ctx context.Context, id int64) error {
+	result := uc.db.WithContext(ctx).Delete(&wechat.WechatOAQrcode{}, id)
+	if err := result.Error; err != nil {
+		panic(err)
+	}
+	if result.RowsAffected == 0 {
+		return errorx.WithCause(errorx.ErrNotFoundObject, "未找到二维码")
+	}
+	return nil
+}
+
+// OAQrcodeImageUrl 通过ticket换取二维码图片
+func (uc *WechatOfficialAccountUseCase) OAQrcodeImageUrl(qrcode *wechat.WechatOAQrcode) string {
+	return uc.App.QRCode.URL(qrcode.Ticket)
+}
+
+// RecordOAQrcodeEvent 将关注/扫码/取关归因到对应二维码, 非本系统生成的场景值忽略
+func (uc *WechatOfficialAccountUseCase) RecordOAQrcodeEvent(ctx context.Context, sceneStr string, openId string, event string, isNew bool) {
+	if sceneStr == "" {
+		return
+	}
+	var qrcode wechat.WechatOAQrcode
+	// 取关时二维码可能已删除, 仍需归因
+	err := uc.db.WithContext(ctx).Unscoped().Where("scene_str = ?", sceneStr).First(&qrcode).Error
+	if err != nil {
+		return
+	}
+	err = uc.db.WithContext(ctx).Create(&wechat.WechatOAQrcodeEvent{
+		QrcodeId: qrcode.Id,
+		SceneStr: sceneStr,
+		OpenId:   openId,
+		Event:    event,
+		IsNew:    isNew,
+	}).Error
+	if err != nil {
+		panic(errors.Wrap(err, "create oa qrcode event failed"))
+	}
+}
+
+// FindOAQrcodeStatistics 按天汇总[start, end]区间内的二维码事件
+func (uc *WechatOfficialAccountUseCase) FindOAQrcodeStatistics(ctx context.Context, qrcodeId int64, start time.Time, end time.Time) (*OAQrcodeStatistics, error) {
+	if _, err := uc.GetOAQrcode(ctx, qrcodeId); err != nil {
+		return nil, err
+	}
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
+	if end.Before(start) {
+		return nil, errorx.WithCause(errorx.ErrBadRequest, "结束日期不能早于开始日期")
+	}
+
+	var events []*wechat.WechatOAQrcodeEvent
+	err := uc.db.WithContext(ctx).
+		Where("qrcode_id = ? AND created_at >= ? AND created_at < ?", qrcodeId, start, end.AddDate(0, 0, 1)).
+		Order("id asc").Find(&events).Error
+	if err != nil {
+		panic(err)
+	}
+
+	// 仍在关注的粉丝
+	openIds := []string{}
+	for _, event := range events {
+		if event.Event == wechat.OAQrcodeEventSubscribe {
+			openIds = append(openIds, event.OpenId)
+		}
+	}
+	subscribed := map[string]bool{}
+	if len(openIds) > 0 {
+		var fans []string
+		err = uc.db.WithContext(ctx).Model(&wechat.WechatOACustomer{}).
+			Where("open_id IN ? AND subscribe = 1", openIds).Pluck("open_id", &fans).Error
+		if err != nil {
+			panic(err)
+		}
+		for _, openId := range fans {
+			subscribed[openId] = true
+		}
+	}
+
+	statistics := &OAQrcodeStatistics{Daily: []*OAQrcodeDailyStatistics{}}
+	days := map[string]*OAQrcodeDailyStatistics{}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		daily := &OAQrcodeDailyStatistics{Date: day.Format(time.DateOnly)}
+		days[daily.Date] = daily
+		statistics.Daily = append(statistics.Daily, daily)
+	}
+
+	retained := map[string]bool{}
+	for _, event := range events {
+		daily := days[event.CreatedAt.In(start.Location()).Format(time.DateOnly)]
+		if daily == nil {
+			continue
+		}
+		switch event.Event {
+		case wechat.OAQrcodeEventScan:
+			daily.Scans++
+		case wechat.OAQrcodeEventSubscribe:
+			daily.Scans++
+			daily.Subscribes++
+			if event.IsNew {
+				daily.NewFans++
+			}
+			if subscribed[event.OpenId] && !retained[event.OpenId] {
+				retained[event.OpenId] = true
+				daily.Retained++
+			}
+		case wechat.OAQrcodeEventUnsubscribe:
+			daily.Unsubscribe++
+		}
+	}
+	for _, daily := range statistics.Daily {
+		statistics.Scans += daily.Scans
+		statistics.Subscribes += daily.Subscribes
+		statistics.NewFans += daily.NewFans
+		statistics.Unsubscribe += daily.Unsubscribe
+		statistics.Retained += daily.Retained
+	}
+
+	return statistics, nil
+}
@@ -0,0 +1,156 @@
+package wechat
+
+import (
+	"PowerX/internal/model/wechat"
+	"context"
+	"encoding/json"
+	"github.com/ArtisanCloud/PowerLibs/v3/object"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/kernel"
+	"github.com/ArtisanCloud/PowerWeChat/v3/src/kernel/messages"
+	"github.com/zeromicro/go-zero/core/logx"
+	"strconv"
+	"strings"
+)
+
+// 未关注用户扫带参二维码关注时, EventKey带此前缀
+const oaQrScenePrefix = "qrscene_"
+
+// findOrNewOACustomer 保留已有粉丝信息, 避免整行Upsert时覆盖为空值
+func (uc *WechatOfficialAccountUseCase) findOrNewOACustomer(ctx context.Context, openId string) *wechat.WechatOACustomer {
+	customer, err := uc.FindOneOACustomer(ctx, &FindOACustomerOption{OpenIds: []string{openId}})
+	if err != nil {
+		customer = &wechat.WechatOACustomer{OpenId: openId}
+	}
+	customer.Customer = nil
+	customer.UniqueID = customer.GetComposedUniqueID()
+	return customer
+}
+
+// SubscribeOACustomer 关注事件, 拉取粉丝信息并记录带参二维码场景
+func (uc *WechatOfficialAccountUseCase) SubscribeOACustomer(ctx context.Context, openId string, eventKey string) (*wechat.WechatOACustomer, error) {
+	customer := uc.findOrNewOACustomer(ctx, openId)
+	isNew := customer.Id == 0
+	customer.Subscribe = 1
+
+	info, err := uc.App.User.Get(ctx, openId, "zh_CN")
+	if err == nil && info.ErrCode == 0 {
+		customer.UnionId = info.UnionID
+		customer.Language = info.Language
+		customer.SubscribeTime = info.SubscribeTime
+		customer.Remark = info.Remark
+		customer.GroupId = info.GroupID
+		customer.SubscribeScene = info.SubscribeScene
+		customer.QrScene = info.QrScene
+		customer.QrSceneStr = info.QrSceneStr
+		customer.TagIdList, _ = json.Marshal(info.TagIDList)
+	} else {
+		logx.WithContext(ctx).Errorf("wechat oa get user info failed, openId: %s, err: %v", openId, err)
+	}
+
+	scene := ""
+	if strings.HasPrefix(eventKey, oaQrScenePrefix) {
+		scene = strings.TrimPrefix(eventKey, oaQrScenePrefix)
+		uc.fillOAQrScene(customer, scene)
+	}
+
+	customer, err = uc.UpsertOACustomer(ctx, customer)
+	if err != nil {
+		return nil, err
+	}
+	uc.RecordOAQrcodeEvent(ctx, scene, openId, wechat.OAQrcodeEventSubscribe, isNew)
+
+	return customer, nil
+}
+
+// UnsubscribeOACustomer 取消关注事件
+func (uc *WechatOfficialAccountUseCase) UnsubscribeOACustomer(ctx context.Context, openId string) (*wechat.WechatOACustomer, error) {
+	customer := uc.findOrNewOACustomer(ctx, openId)
+	customer.Subscribe = 0
+
+	customer, err := uc.UpsertOACustomer(ctx, customer)
+	if err != nil {
+		return nil, err
+	}
+	// 归因到关注时的二维码
+	uc.RecordOAQrcodeEvent(ctx, customer.QrSceneStr, openId, wechat.OAQrcodeEventUnsubscribe, false)
+
+	return customer, nil
+}
+
+// ScanOACustomer 已关注用户扫带参二维码; QrScene与微信一致只记录关注时的场景, 扫码只记事件
+func (uc *WechatOfficialAccountUseCase) ScanOACustomer(ctx context.Context, openId string, eventKey string) (*wechat.WechatOACustomer, error) {
+	customer := uc.findOrNewOACustomer(ctx, openId)
+	customer.Subscribe = 1
+
+	customer, err := uc.UpsertOACustomer(ctx, customer)
+	if err != nil {
+		return nil, err
+	}
+	uc.RecordOAQrcodeEvent(ctx, eventKey, openId, wechat.OAQrcodeEventScan, false)
+
+	return customer, nil
+}
+
+func (uc *WechatOfficialAccountUseCase) fillOAQrScene(customer *wechat.WechatOACustomer, scene string) {
+	if scene == "" {
+		return
+	}
+	if id, err := strconv.Atoi(scene); err == nil {
+		customer.QrScene = id
+		customer.QrSceneStr = ""
+		return
+	}
+	customer.QrScene = 0
+	customer.QrSceneStr = scene
+}
+
+// BuildOAAutoReply 将规则转为被动回复消息; 小程序卡片不支持被动回复, 改用客服消息异步下发
+func (uc *WechatOfficialAccountUseCase) BuildOAAutoReply(ctx context.Context, openId string, rule *wechat.WechatOAAutoReplyRule) interface{} {
+	switch rule.ReplyType {
+	case wechat.OAAutoReplyTypeText:
+		return messages.NewText(rule.Content)
+
+	case wechat.OAAutoReplyTypeImage:
+		return messages.NewImage(rule.MediaId, nil)
+
+	case wechat.OAAutoReplyTypeNews:
+		news := messages.NewNews([]*object.HashMap{
+			{
+				"Title":       rule.Title,
+				"Description": rule.Description,
+				"PicUrl":      rule.PicUrl,
+				"Url":         rule.Url,
+			},
+		})
+		news.SetAttribute("title", rule.Title)
+		news.SetAttribute("description", rule.Description)
+		news.SetAttribute("picUrl", rule.PicUrl)
+		news.SetAttribute("url", rule.Url)
+		return news
+
+	case wechat.OAAutoReplyTypeMiniProgram:
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					logx.Errorf("wechat oa send mini program page panic: %v", r)
+				}
+			}()
+			res, err := uc.App.CustomerService.Send(context.Background(), &object.HashMap{
+				"touser":  openId,
+				"msgtype": wechat.OAAutoReplyTypeMiniProgram,
+				"miniprogrampage": &object.HashMap{
+					"title":          rule.Title,
+					"appid":          rule.AppId,
+					"pagepath":       rule.PagePath,
+					"thumb_media_id": rule.MediaId,
+				},
+			})
+			if err != nil || res.ErrCode != 0 {
+				logx.Errorf("wechat oa send mini program page failed, openId: %s, err: %v, res: %v", openId, err, res)
+			}
+		}()
+		return kernel.SUCCESS_EMPTY_RESPONSE
+	}
+
+	return kernel.SUCCESS_EMPTY_RESPONSE
+}
@@ -0,0 +1,315 @@
+package excelx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 最小化的xlsx读写, 只处理单个工作表的文本内容, 不处理样式和公式
+
+const ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		Id     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		RId  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	builder := strings.Builder{}
+	builder.WriteString(t.T)
+	for _, run := range t.Runs {
+		builder.WriteString(run.T)
+	}
+	return builder.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxCell struct {
+	Ref   string    `xml:"r,attr"`
+	Type  string    `xml:"t,attr"`
+	Value string    `xml:"v"`
+	Is    *xlsxText `xml:"is"`
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Ref   int        `xml:"r,attr"`
+		Cells []xlsxCell `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func readZipFile(files map[string]*zip.File, name string, v interface{}) (bool, error) {
+	file, ok := files[name]
+	if !ok {
+		return false, nil
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return true, err
+	}
+	defer reader.Close()
+	return true, xml.NewDecoder(reader).Decode(v)
+}
+
+// ReadRows 读取第一个工作表的所有行, 空单元格为空字符串, 每行按最大列数补齐
+func ReadRows(content []byte) ([][]string, error) {
+	zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid xlsx file: %w", err)
+	}
+	files := map[string]*zip.File{}
+	for _, file := range zipReader.File {
+		files[file.Name] = file
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+
+	sharedStrings := &xlsxSharedStrings{}
+	if _, err = readZipFile(files, "xl/sharedStrings.xml", sharedStrings); err != nil {
+		return nil, err
+	}
+
+	worksheet := &xlsxWorksheet{}
+	found, err := readZipFile(files, sheetPath, worksheet)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("worksheet %s not found", sheetPath)
+	}
+
+	rows := [][]string{}
+	maxColumns := 0
+	for _, row := range worksheet.Rows {
+		// 跳过的空行补齐, 保持行号与文件一致
+		for row.Ref > len(rows)+1 {
+			rows = append(rows, []string{})
+		}
+		values := []string{}
+		for i, cell := range row.Cells {
+			column := i
+			if cell.Ref != "" {
+				if column, err = ColumnIndex(cell.Ref); err != nil {
+					return nil, err
+				}
+			}
+			for len(values) < column {
+				values = append(values, "")
+			}
+			value, err := cellValue(cell, sharedStrings)
+			if err != nil {
+				return nil, err
+			}
+			if column < len(values) {
+				values[column] = value
+			} else {
+				values = append(values, value)
+			}
+		}
+		if len(values) > maxColumns {
+			maxColumns = len(values)
+		}
+		rows = append(rows, values)
+	}
+	for i := range rows {
+		for len(rows[i]) < maxColumns {
+			rows[i] = append(rows[i], "")
+		}
+	}
+	return rows, nil
+}
+
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	workbook := &xlsxWorkbook{}
+	found, err := readZipFile(files, "xl/workbook.xml", workbook)
+	if err != nil {
+		return "", err
+	}
+	if !found || len(workbook.Sheets) == 0 {
+		return "xl/worksheets/sheet1.xml", nil
+	}
+	relationships := &xlsxRelationships{}
+	if _, err = readZipFile(files, "xl/_rels/workbook.xml.rels", relationships); err != nil {
+		return "", err
+	}
+	for _, relationship := range relationships.Relationships {
+		if relationship.Id != workbook.Sheets[0].RId {
+			continue
+		}
+		if strings.HasPrefix(relationship.Target, "/") {
+			return strings.TrimPrefix(relationship.Target, "/"), nil
+		}
+		return path.Join("xl", relationship.Target), nil
+	}
+	return "xl/worksheets/sheet1.xml", nil
+}
+
+func cellValue(cell xlsxCell, sharedStrings *xlsxSharedStrings) (string, error) {
+	switch cell.Type {
+	case "s":
+		index, err := strconv.Atoi(strings.TrimSpace(cell.Value))
+		if err != nil || index < 0 || index >= len(sharedStrings.Items) {
+			return "", fmt.Errorf("invalid shared string index %s in cell %s", cell.Value, cell.Ref)
+		}
+		return sharedStrings.Items[index].String(), nil
+	case "inlineStr":
+		if cell.Is == nil {
+			return "", nil
+		}
+		return cell.Is.String(), nil
+	case "b":
+		if cell.Value == "1" {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	default:
+		return cell.Value, nil
+	}
+}
+
+// ColumnIndex 单元格引用的列序号, 从0开始, 如 "B3" 为1
+func ColumnIndex(ref string) (int, error) {
+	index := 0
+	letters := 0
+	for _, r := range ref {
+		if r >= 'A' && r <= 'Z' {
+			index = index*26 + int(r-'A') + 1
+			letters++
+		} else if r >= 'a' && r <= 'z' {
+			index = index*26 + int(r-'a') + 1
+			letters++
+		} else {
+			break
+		}
+	}
+	if letters == 0 {
+		return 0, fmt.Errorf("invalid cell reference %s", ref)
+	}
+	return index - 1, nil
+}
+
+// ColumnName 列序号对应的列名, 从0开始, 如 27 为 "AB"
+func ColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// SerialToTime Excel日期序列值转换为时间, 以1900日期系统计算
+func SerialToTime(serial float64) time.Time {
+	base := time.Date(1899, 12, 30, 0, 0, 0, 0, time.Local)
+	days := int(serial)
+	seconds := int((serial-float64(days))*86400 + 0.5)
+	return base.AddDate(0, 0, days).Add(time.Duration(seconds) * time.Second)
+}
+
+const (
+	contentTypesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`
+	rootRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	workbookRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`
+	stylesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts><fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills><borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders><cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs><cellXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/></cellXfs></styleSheet>`
+)
+
+// WriteRows 将所有行写入单个工作表, 单元格均以文本保存
+func WriteRows(sheetName string, rows [][]string) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	zipWriter := zip.NewWriter(buffer)
+
+	workbookXML := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="` +
+		escapeXML(sheetName) + `" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+	sheet := &strings.Builder{}
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for i, row := range rows {
+		sheet.WriteString(`<row r="` + strconv.Itoa(i+1) + `">`)
+		for j, value := range row {
+			if value == "" {
+				continue
+			}
+			sheet.WriteString(`<c r="` + ColumnName(j) + strconv.Itoa(i+1) + `" t="inlineStr"><is><t xml:space="preserve">`)
+			sheet.WriteString(escapeXML(value))
+			sheet.WriteString(`</t></is></c>`)
+		}
+		sheet.WriteString(`</row>`)
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypesXML},
+		{"_rels/.rels", rootRelsXML},
+		{"xl/workbook.xml", workbookXML},
+		{"xl/_rels/workbook.xml.rels", workbookRelsXML},
+		{"xl/styles.xml", stylesXML},
+		{"xl/worksheets/sheet1.xml", sheet.String()},
+	}
+	for _, part := range parts {
+		writer, err := zipWriter.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = io.WriteString(writer, part.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func escapeXML(value string) string {
+	// xml 1.0 不允许的控制字符直接去掉
+	value = strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, value)
+	buffer := &bytes.Buffer{}
+	_ = xml.EscapeText(buffer, []byte(value))
+	return buffer.String()
+}
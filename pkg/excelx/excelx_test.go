@@ -0,0 +1,77 @@
+package excelx
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWriteAndReadRows(t *testing.T) {
+	rows := [][]string{
+		{"产品货号", "产品名称", "备注"},
+		{"SPU-001", "拿铁 <大杯> & 冰", ""},
+		{"", "", "仅第三列\n换行"},
+	}
+	content, err := WriteRows("产品", rows)
+	if err != nil {
+		t.Fatalf("write rows: %v", err)
+	}
+	got, err := ReadRows(content)
+	if err != nil {
+		t.Fatalf("read rows: %v", err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("read rows = %q, want %q", got, rows)
+	}
+}
+
+func TestReadRowsSharedStrings(t *testing.T) {
+	// Excel保存的文件使用共享字符串, 数字和布尔值直接保存
+	sheet := `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c></row>
+<row r="3"><c r="A3"><v>12.5</v></c><c r="B3" t="b"><v>1</v></c></row>
+</sheetData></worksheet>`
+	sharedStrings := `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><si><t>名称</t></si><si><r><t>富</t></r><r><t>文本</t></r></si></sst>`
+
+	buffer := &bytes.Buffer{}
+	zipWriter := zip.NewWriter(buffer)
+	for name, content := range map[string]string{"xl/worksheets/sheet1.xml": sheet, "xl/sharedStrings.xml": sharedStrings} {
+		writer, _ := zipWriter.Create(name)
+		_, _ = writer.Write([]byte(content))
+	}
+	_ = zipWriter.Close()
+
+	got, err := ReadRows(buffer.Bytes())
+	if err != nil {
+		t.Fatalf("read rows: %v", err)
+	}
+	want := [][]string{
+		{"名称", "", "富文本"},
+		{"", "", ""},
+		{"12.5", "TRUE", ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("read rows = %q, want %q", got, want)
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for index, name := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := ColumnName(index); got != name {
+			t.Errorf("column name of %d = %s, want %s", index, got, name)
+		}
+		if got, _ := ColumnIndex(name + "12"); got != index {
+			t.Errorf("column index of %s = %d, want %d", name, got, index)
+		}
+	}
+}
+
+func TestSerialToTime(t *testing.T) {
+	got := SerialToTime(45078.5)
+	want := time.Date(2023, 6, 1, 12, 0, 0, 0, time.Local)
+	if !got.Equal(want) {
+		t.Errorf("serial to time = %v, want %v", got, want)
+	}
+}